@@ -0,0 +1,118 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HostnameRenamePhase is the step of a hostname rename the operator carries out
+// +kubebuilder:validation:Enum=Pending;Prepare;Cutover;Rollback
+type HostnameRenamePhase string
+
+const (
+	// HostnameRenamePhasePending changes nothing yet
+	HostnameRenamePhasePending HostnameRenamePhase = "Pending"
+	// HostnameRenamePhasePrepare serves the new hostnames next to the old ones
+	HostnameRenamePhasePrepare HostnameRenamePhase = "Prepare"
+	// HostnameRenamePhaseCutover serves the new hostnames, the old ones only for OldHostnameRetention
+	HostnameRenamePhaseCutover HostnameRenamePhase = "Cutover"
+	// HostnameRenamePhaseRollback serves the old hostnames only
+	HostnameRenamePhaseRollback HostnameRenamePhase = "Rollback"
+)
+
+// HostnameRenameState reports what a HostnameRenamePlan currently serves
+type HostnameRenameState string
+
+const (
+	// HostnameRenameStatePending serves the old hostnames, the plan was not started
+	HostnameRenameStatePending HostnameRenameState = "Pending"
+	// HostnameRenameStateServingBoth serves the old and the new hostnames
+	HostnameRenameStateServingBoth HostnameRenameState = "ServingBoth"
+	// HostnameRenameStateRetainingOld serves the new hostnames and the old ones until the retention ends
+	HostnameRenameStateRetainingOld HostnameRenameState = "RetainingOldHostnames"
+	// HostnameRenameStateCompleted serves the new hostnames only
+	HostnameRenameStateCompleted HostnameRenameState = "Completed"
+	// HostnameRenameStateRolledBack serves the old hostnames only again
+	HostnameRenameStateRolledBack HostnameRenameState = "RolledBack"
+)
+
+// HostnameRenamePlanSpec defines a rename of the hostname suffix the routes are generated with
+type HostnameRenamePlanSpec struct {
+	// From is the hostname suffix renamed, as produced by the hostname rewrite, e.g. apps.old.example
+	// +kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+	// To replaces From, e.g. apps.new.example
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
+	// Phase is the step to carry out, change it to move the rename forward or back
+	// +optional
+	// +kubebuilder:default=Pending
+	Phase HostnameRenamePhase `json:"phase,omitempty"`
+	// OldHostnameRetention is how long the old hostnames stay served after the cutover, 1h when unset
+	// +optional
+	OldHostnameRetention *metav1.Duration `json:"oldHostnameRetention,omitempty"`
+}
+
+// HostnameRenamePlanStatus defines the observed state of HostnameRenamePlan
+type HostnameRenamePlanStatus struct {
+	// ObservedGeneration is the generation the operator last applied or rejected
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// State reports which hostnames are served
+	// +optional
+	State HostnameRenameState `json:"state,omitempty"`
+	// CutoverTime is when the operator started the cutover, the old hostnames are removed after the retention
+	// +optional
+	CutoverTime *metav1.Time `json:"cutoverTime,omitempty"`
+	// Conditions report whether the plan is in effect
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=hrp
+// +kubebuilder:printcolumn:name="From",type=string,JSONPath=`.spec.from`
+// +kubebuilder:printcolumn:name="To",type=string,JSONPath=`.spec.to`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.spec.phase`
+// +kubebuilder:printcolumn:name="State",type=string,JSONPath=`.status.state`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// HostnameRenamePlan renames a hostname suffix of the generated routes in phases that can be rolled back
+type HostnameRenamePlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HostnameRenamePlanSpec   `json:"spec,omitempty"`
+	Status HostnameRenamePlanStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HostnameRenamePlanList contains a list of HostnameRenamePlan
+type HostnameRenamePlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HostnameRenamePlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&HostnameRenamePlan{}, &HostnameRenamePlanList{})
+}
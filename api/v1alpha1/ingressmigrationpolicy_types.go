@@ -0,0 +1,134 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GatewayStrategy selects how Ingresses are spread over Gateways
+// +kubebuilder:validation:Enum=shared;per-namespace;per-ingress
+type GatewayStrategy string
+
+const (
+	// GatewayStrategyShared shares one Gateway per ingress class
+	GatewayStrategyShared GatewayStrategy = "shared"
+	// GatewayStrategyPerNamespace creates one Gateway per Ingress namespace
+	GatewayStrategyPerNamespace GatewayStrategy = "per-namespace"
+	// GatewayStrategyPerIngress creates one Gateway per Ingress
+	GatewayStrategyPerIngress GatewayStrategy = "per-ingress"
+)
+
+// IngressPostProcessing selects what happens to an Ingress once its HTTPRoutes are in place
+// +kubebuilder:validation:Enum=none;disable;remove;disable-external-dns
+type IngressPostProcessing string
+
+const (
+	// IngressPostProcessingNone leaves the Ingress unchanged
+	IngressPostProcessingNone IngressPostProcessing = "none"
+	// IngressPostProcessingDisable removes the ingress class of the Ingress
+	IngressPostProcessingDisable IngressPostProcessing = "disable"
+	// IngressPostProcessingRemove deletes the Ingress
+	IngressPostProcessingRemove IngressPostProcessing = "remove"
+	// IngressPostProcessingDisableExternalDNS makes external-dns read the Gateway instead of the Ingress
+	IngressPostProcessingDisableExternalDNS IngressPostProcessing = "disable-external-dns"
+)
+
+// NamespaceSelection limits the namespaces whose Ingresses are migrated
+type NamespaceSelection struct {
+	// Include lists the only namespaces migrated, empty means all namespaces
+	// +optional
+	Include []string `json:"include,omitempty"`
+	// Exclude lists namespaces never migrated, it wins over Include
+	// +optional
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// HostnameRewrite replaces a hostname suffix of every Ingress host
+type HostnameRewrite struct {
+	// From is the suffix replaced, e.g. example.com
+	// +kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+	// To is the suffix put in its place, e.g. migration.example.com
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
+}
+
+// IngressMigrationPolicySpec defines the operator settings. Unset fields keep the command line value.
+type IngressMigrationPolicySpec struct {
+	// Namespaces limits the namespaces whose Ingresses are migrated
+	// +optional
+	Namespaces *NamespaceSelection `json:"namespaces,omitempty"`
+	// GatewayStrategy replaces --one-gateway-per-ingress and --one-gateway-per-namespace
+	// +optional
+	GatewayStrategy GatewayStrategy `json:"gatewayStrategy,omitempty"`
+	// HostnameRewrite replaces --hostname-rewrite-from and --hostname-rewrite-to
+	// +optional
+	HostnameRewrite *HostnameRewrite `json:"hostnameRewrite,omitempty"`
+	// IngressPostProcessing replaces --ingress-postprocessing, including the external-dns handover
+	// +optional
+	IngressPostProcessing IngressPostProcessing `json:"ingressPostProcessing,omitempty"`
+	// RolloutPercentage replaces --rollout-percentage, the share of eligible Ingresses migrated. Every Ingress
+	// keeps its place in a fixed order, so raising it only adds Ingresses.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	RolloutPercentage *int32 `json:"rolloutPercentage,omitempty"`
+}
+
+// IngressMigrationPolicyStatus defines the observed state of IngressMigrationPolicy
+type IngressMigrationPolicyStatus struct {
+	// ObservedGeneration is the generation the operator last applied or rejected
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions report whether the policy is in effect
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=imp
+// +kubebuilder:printcolumn:name="Strategy",type=string,JSONPath=`.spec.gatewayStrategy`
+// +kubebuilder:printcolumn:name="Post-processing",type=string,JSONPath=`.spec.ingressPostProcessing`
+// +kubebuilder:printcolumn:name="Rollout",type=integer,JSONPath=`.spec.rolloutPercentage`
+// +kubebuilder:printcolumn:name="Accepted",type=string,JSONPath=`.status.conditions[?(@.type=="Accepted")].status`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// IngressMigrationPolicy configures the operator at runtime, so changing it doesn't need a restart
+type IngressMigrationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IngressMigrationPolicySpec   `json:"spec,omitempty"`
+	Status IngressMigrationPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IngressMigrationPolicyList contains a list of IngressMigrationPolicy
+type IngressMigrationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IngressMigrationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IngressMigrationPolicy{}, &IngressMigrationPolicyList{})
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"go.uber.org/zap/zapcore"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/controller"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+const (
+	modeDisable            = "disable"
+	modeDisableExternalDNS = "disable-external-dns"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.Install(scheme))
+}
+
+type disablerOptions struct {
+	mode         string
+	clearStatus  bool
+	skipUnready  bool
+	dryRun       bool
+	nameTemplate *translator.NameTemplate
+	strategy     controller.DisableStrategy
+}
+
+func main() {
+	var namespace string
+	var ingressNamePattern string
+	var verbosity int
+	var nameTemplateRaw string
+	var disableStrategy string
+	var opts disablerOptions
+
+	flag.CommandLine.SetOutput(os.Stderr)
+	flag.StringVar(&namespace, "namespace", "", "If set, only process Ingresses in this namespace")
+	flag.StringVar(&ingressNamePattern, "ingress-name", "",
+		"If set, only process Ingresses whose name matches any of the glob patterns (comma-separated, e.g., 'api-*,web-?')")
+	flag.StringVar(&opts.mode, "mode", modeDisable,
+		"What to disable: 'disable' (switch to the disabled IngressClass) or 'disable-external-dns' "+
+			"(make external-dns ignore the Ingress)")
+	flag.StringVar(&disableStrategy, "disable-strategy", string(controller.DisableStrategyClassSwap),
+		"How to disable Ingresses (mode=disable only): 'class-swap' (switch to the disabled IngressClass) or "+
+			"'host-prefix' (prefix every host with '"+controller.DisabledHostPrefix+"', must match the operator)")
+	flag.BoolVar(&opts.clearStatus, "clear-ingress-status", true,
+		"If true, clear status.loadBalancer of disabled Ingresses (mode=disable only)")
+	flag.BoolVar(&opts.skipUnready, "skip-unready", false,
+		"If true, disable the Ingresses that pass the checks and skip the others instead of changing nothing")
+	flag.BoolVar(&opts.dryRun, "dry-run", false, "If true, only report what would be disabled")
+	flag.StringVar(&nameTemplateRaw, "name-template", "",
+		"Go template the operator named generated resources with (must match the operator --name-template)")
+	flag.IntVar(&verbosity, "v", 0, "Log verbosity (0 = info, higher = more verbose)")
+	zapOpts := zap.Options{
+		Development: true,
+	}
+	zapOpts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	if verbosity > 0 {
+		zapOpts.Development = false
+		zapOpts.Level = zapcore.Level(-verbosity)
+	}
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&zapOpts)))
+
+	if opts.mode != modeDisable && opts.mode != modeDisableExternalDNS {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid --mode %q (allowed: %s, %s)\n",
+			opts.mode, modeDisable, modeDisableExternalDNS)
+		os.Exit(1)
+	}
+	strategy, err := controller.NewDisableStrategy(controller.DisableStrategyName(disableStrategy))
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid --disable-strategy: %v\n", err)
+		os.Exit(1)
+	}
+	opts.strategy = strategy
+	nameTemplate, err := translator.ParseNameTemplate(nameTemplateRaw)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid --name-template: %v\n", err)
+		os.Exit(1)
+	}
+	opts.nameTemplate = nameTemplate
+
+	cfg := ctrl.GetConfigOrDie()
+	cli, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create Kubernetes client")
+		os.Exit(1)
+	}
+
+	if err := runDisabler(context.Background(), cli, namespace, ingressNamePattern, opts); err != nil {
+		setupLog.Error(err, "disabler failed")
+		os.Exit(1)
+	}
+}
+
+// runDisabler verifies every selected Ingress before touching any of them, so a change window either
+// cuts over the whole selection or nothing (unless --skip-unready)
+func runDisabler(
+	ctx context.Context,
+	cli client.Client,
+	namespace string,
+	ingressNamePattern string,
+	opts disablerOptions,
+) error {
+	ingresses, err := listIngresses(ctx, cli, namespace, ingressNamePattern)
+	if err != nil {
+		return err
+	}
+
+	manager := utils.HTTPRouteManager{Client: cli, NameTemplate: opts.nameTemplate}
+	ready := make([]*networkingv1.Ingress, 0, len(ingresses))
+	var unready []string
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		if controller.IngressIgnored(ingress) {
+			setupLog.V(1).Info("Ingress is ignored by ingress-doperator, skipping",
+				"namespace", ingress.Namespace,
+				"name", ingress.Name)
+			continue
+		}
+		if alreadyDisabled(ingress, opts.mode) {
+			setupLog.V(1).Info("Ingress already disabled, skipping",
+				"namespace", ingress.Namespace,
+				"name", ingress.Name)
+			continue
+		}
+		ok, reason, err := checkRoutesAccepted(ctx, &manager, ingress)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			setupLog.Info("Ingress is not ready to be disabled",
+				"namespace", ingress.Namespace,
+				"name", ingress.Name,
+				"reason", reason)
+			unready = append(unready, fmt.Sprintf("%s/%s (%s)", ingress.Namespace, ingress.Name, reason))
+			continue
+		}
+		ready = append(ready, ingress)
+	}
+
+	if len(unready) > 0 && !opts.skipUnready {
+		return fmt.Errorf("refusing to disable any Ingress, %d not ready: %s",
+			len(unready), strings.Join(unready, "; "))
+	}
+
+	var errCount int
+	var lastErr error
+	for _, ingress := range ready {
+		if opts.dryRun {
+			setupLog.Info("Would disable Ingress (dry run)",
+				"namespace", ingress.Namespace,
+				"name", ingress.Name,
+				"mode", opts.mode)
+			continue
+		}
+		if err := disableIngress(ctx, cli, ingress, opts); err != nil {
+			setupLog.Error(err, "failed to disable ingress",
+				"namespace", ingress.Namespace,
+				"name", ingress.Name)
+			lastErr = err
+			errCount++
+			continue
+		}
+		setupLog.Info("Disabled Ingress",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name,
+			"mode", opts.mode)
+	}
+
+	setupLog.Info("Disabler finished",
+		"ready", len(ready),
+		"unready", len(unready),
+		"failed", errCount,
+		"dryRun", opts.dryRun)
+	if errCount > 0 {
+		return fmt.Errorf("disabler completed with %d errors (last: %w)", errCount, lastErr)
+	}
+	return nil
+}
+
+func disableIngress(ctx context.Context, cli client.Client, ingress *networkingv1.Ingress, opts disablerOptions) error {
+	if opts.mode == modeDisableExternalDNS {
+		return controller.DisableExternalDNS(ctx, cli, ingress)
+	}
+	return controller.DisableIngress(ctx, cli, ingress, opts.clearStatus, opts.strategy)
+}
+
+func alreadyDisabled(ingress *networkingv1.Ingress, mode string) bool {
+	if ingress.Annotations == nil {
+		return false
+	}
+	if mode == modeDisableExternalDNS {
+		return ingress.Annotations[controller.ExternalDNSIngressHostnameSource] ==
+			controller.ExternalDNSHostnameSourceAnnotationOnly
+	}
+	return ingress.Annotations[controller.IngressDisabledAnnotation] == controller.IngressDisabledReasonNormal
+}
+
+// checkRoutesAccepted reports whether the Ingress has managed routes and every one of them was Accepted
+// by all of its parents. Like the controller, ssl-passthrough Ingresses are served by TLSRoutes and gRPC
+// Ingresses by GRPCRoutes; HTTPRoutes are checked when there are none (the operator runs without
+// --enable-tls-routes / --enable-grpc-routes).
+func checkRoutesAccepted(
+	ctx context.Context,
+	manager *utils.HTTPRouteManager,
+	ingress *networkingv1.Ingress,
+) (bool, string, error) {
+	switch {
+	case translator.IsSSLPassthrough(ingress.Annotations):
+		tlsManager := utils.TLSRouteManager{Client: manager.Client, NameTemplate: manager.NameTemplate}
+		routes, err := tlsManager.GetTLSRoutesForIngress(ctx, ingress)
+		if err != nil {
+			return false, "", err
+		}
+		if len(routes) > 0 {
+			for i := range routes {
+				if ok, reason := routeAccepted("TLSRoute", routes[i].Name, routes[i].Status.Parents); !ok {
+					return false, reason, nil
+				}
+			}
+			return true, "", nil
+		}
+	case translator.IsGRPCBackend(ingress.Annotations):
+		grpcManager := utils.GRPCRouteManager{Client: manager.Client, NameTemplate: manager.NameTemplate}
+		routes, err := grpcManager.GetGRPCRoutesForIngress(ctx, ingress)
+		if err != nil {
+			return false, "", err
+		}
+		if len(routes) > 0 {
+			for i := range routes {
+				if ok, reason := routeAccepted("GRPCRoute", routes[i].Name, routes[i].Status.Parents); !ok {
+					return false, reason, nil
+				}
+			}
+			return true, "", nil
+		}
+	}
+
+	routes, err := manager.GetHTTPRoutesForIngress(ctx, ingress)
+	if err != nil {
+		return false, "", err
+	}
+	found := false
+	for i := range routes {
+		route := &routes[i]
+		if !utils.IsManagedByUsForIngress(route, ingress.Namespace, ingress.Name) {
+			continue
+		}
+		found = true
+		if ok, reason := routeAccepted("HTTPRoute", route.Name, route.Status.Parents); !ok {
+			return false, reason, nil
+		}
+	}
+	if !found {
+		return false, "missing managed route", nil
+	}
+	return true, "", nil
+}
+
+// routeAccepted reports whether a route has parent status and every parent Accepted it
+func routeAccepted(kind, name string, parents []gatewayv1.RouteParentStatus) (bool, string) {
+	if len(parents) == 0 {
+		return false, fmt.Sprintf("%s %s has no parent status yet", kind, name)
+	}
+	for _, parent := range parents {
+		if !meta.IsStatusConditionTrue(parent.Conditions, string(gatewayv1.RouteConditionAccepted)) {
+			return false, fmt.Sprintf("%s %s is not Accepted by Gateway %s", kind, name, parent.ParentRef.Name)
+		}
+	}
+	return true, ""
+}
+
+func listIngresses(
+	ctx context.Context,
+	cli client.Client,
+	namespace, namePattern string,
+) ([]networkingv1.Ingress, error) {
+	list := &networkingv1.IngressList{}
+	var listOpts []client.ListOption
+	if namespace != "" {
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	}
+	if err := cli.List(ctx, list, listOpts...); err != nil {
+		return nil, err
+	}
+	patterns := utils.ParseCommaSeparatedList(namePattern)
+	if len(patterns) == 0 {
+		return list.Items, nil
+	}
+	matches := make([]networkingv1.Ingress, 0, len(list.Items))
+	for _, ingress := range list.Items {
+		for _, pattern := range patterns {
+			ok, err := filepath.Match(pattern, ingress.Name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ingress-name pattern %q: %w", pattern, err)
+			}
+			if ok {
+				matches = append(matches, ingress)
+				break
+			}
+		}
+	}
+	return matches, nil
+}
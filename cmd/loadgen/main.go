@@ -0,0 +1,608 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+const (
+	// LoadgenLabel marks the Ingresses and Namespaces created by the load generator, cleanup only
+	// touches objects carrying it
+	LoadgenLabel = "ingress-doperator.fiction.si/loadgen"
+	// GenerationAnnotation counts the updates the load generator made to an Ingress
+	GenerationAnnotation = "ingress-doperator.fiction.si/loadgen-generation"
+
+	opCreate = "create"
+	opUpdate = "update"
+	opDelete = "delete"
+
+	routePollInterval = 2 * time.Second
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.Install(scheme))
+}
+
+type loadgenOptions struct {
+	ingresses       int
+	namespaces      int
+	namespacePrefix string
+	rate            float64
+	duration        time.Duration
+	deleteFraction  float64
+	workers         int
+	class           string
+	domain          string
+	tls             bool
+	annotations     map[string]string
+	template        *template.Template
+	seed            uint64
+	reportInterval  time.Duration
+	waitForRoutes   time.Duration
+	cleanup         bool
+	cleanupOnly     bool
+}
+
+func main() {
+	var verbosity int
+	var annotationsRaw string
+	var templatePath string
+	var opts loadgenOptions
+
+	flag.CommandLine.SetOutput(os.Stderr)
+	flag.IntVar(&opts.ingresses, "ingresses", 100, "Number of synthetic Ingresses kept alive")
+	flag.IntVar(&opts.namespaces, "namespaces", 10, "Number of namespaces the Ingresses are spread across")
+	flag.StringVar(&opts.namespacePrefix, "namespace-prefix", "loadgen-",
+		"Prefix of the namespaces, they are named <prefix>0 ... <prefix>N-1 and created when missing")
+	flag.Float64Var(&opts.rate, "rate", 60, "Ingress creates, updates and deletes per minute")
+	flag.DurationVar(&opts.duration, "duration", 10*time.Minute, "How long to generate churn (0 = until interrupted)")
+	flag.Float64Var(&opts.deleteFraction, "delete-fraction", 0.1,
+		"Fraction (0-1) of the operations on a full pool that delete an Ingress instead of updating it")
+	flag.IntVar(&opts.workers, "workers", 4, "Number of concurrent API writers")
+	flag.StringVar(&opts.class, "ingress-class", "nginx", "IngressClass of the synthetic Ingresses")
+	flag.StringVar(&opts.domain, "domain", "loadgen.example.com",
+		"Domain of the Ingress hosts, they are <name>.<namespace>.<domain>")
+	flag.BoolVar(&opts.tls, "tls", false, "If true, the Ingresses terminate TLS with the Secret <name>-tls")
+	flag.StringVar(&annotationsRaw, "annotations", "",
+		"Comma-separated key=value pairs added to every synthetic Ingress")
+	flag.StringVar(&templatePath, "template", "",
+		"Path of a Go template rendering an Ingress manifest (default: built-in template)")
+	flag.Uint64Var(&opts.seed, "seed", 0, "Seed of the operation sequence (0 = random)")
+	flag.DurationVar(&opts.reportInterval, "report-interval", 30*time.Second, "How often progress is logged")
+	flag.DurationVar(&opts.waitForRoutes, "wait-for-routes", 0,
+		"If set, wait up to this long after the churn for every live Ingress to have a managed HTTPRoute")
+	flag.BoolVar(&opts.cleanup, "cleanup", true,
+		"If true, delete the synthetic Ingresses and the namespaces created for them at the end")
+	flag.BoolVar(&opts.cleanupOnly, "cleanup-only", false,
+		"If true, generate no churn and only clean up what an earlier run left behind")
+	flag.IntVar(&verbosity, "v", 0, "Log verbosity (0 = info, higher = more verbose)")
+	zapOpts := zap.Options{
+		Development: true,
+	}
+	zapOpts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	if verbosity > 0 {
+		zapOpts.Development = false
+		zapOpts.Level = zapcore.Level(-verbosity)
+	}
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&zapOpts)))
+
+	if err := validateOptions(&opts); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid options: %v\n", err)
+		os.Exit(1)
+	}
+	opts.annotations = parseKeyValueCSV(annotationsRaw)
+	tmpl, err := parseIngressTemplate(templatePath)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid --template: %v\n", err)
+		os.Exit(1)
+	}
+	opts.template = tmpl
+	if _, err := renderIngress(&opts, 0, 1); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid --template: %v\n", err)
+		os.Exit(1)
+	}
+	if opts.seed == 0 {
+		opts.seed = rand.Uint64()
+	}
+
+	cfg := ctrl.GetConfigOrDie()
+	cli, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create Kubernetes client")
+		os.Exit(1)
+	}
+
+	if err := runLoadgen(ctrl.SetupSignalHandler(), cli, &opts); err != nil {
+		setupLog.Error(err, "loadgen failed")
+		os.Exit(1)
+	}
+}
+
+func validateOptions(opts *loadgenOptions) error {
+	switch {
+	case opts.ingresses < 1:
+		return fmt.Errorf("--ingresses must be at least 1")
+	case opts.namespaces < 1:
+		return fmt.Errorf("--namespaces must be at least 1")
+	case opts.rate <= 0:
+		return fmt.Errorf("--rate must be positive")
+	case opts.deleteFraction < 0 || opts.deleteFraction > 1:
+		return fmt.Errorf("--delete-fraction must be between 0 and 1")
+	case opts.workers < 1:
+		return fmt.Errorf("--workers must be at least 1")
+	case opts.reportInterval <= 0:
+		return fmt.Errorf("--report-interval must be positive")
+	}
+	return nil
+}
+
+// runLoadgen generates churn until --duration elapses or the context is cancelled, then optionally waits
+// for the operator to catch up and cleans up. Cleanup runs even after an interrupt, a second one aborts it
+func runLoadgen(ctx context.Context, cli client.Client, opts *loadgenOptions) error {
+	cleanupCtx := context.WithoutCancel(ctx)
+	if opts.cleanupOnly {
+		return cleanup(cleanupCtx, cli, opts)
+	}
+
+	if err := ensureNamespaces(ctx, cli, opts); err != nil {
+		return err
+	}
+	gen := newGenerator(cli, opts)
+	if err := gen.adoptExisting(ctx); err != nil {
+		return err
+	}
+
+	churnCtx := ctx
+	if opts.duration > 0 {
+		var cancel context.CancelFunc
+		churnCtx, cancel = context.WithTimeout(ctx, opts.duration)
+		defer cancel()
+	}
+	setupLog.Info("Generating Ingress churn",
+		"ingresses", opts.ingresses,
+		"namespaces", opts.namespaces,
+		"ratePerMinute", opts.rate,
+		"duration", opts.duration,
+		"seed", opts.seed,
+		"adopted", len(gen.live))
+	started := time.Now()
+	gen.run(churnCtx)
+	gen.report("Churn finished", time.Since(started))
+
+	var errs []error
+	if opts.waitForRoutes > 0 && ctx.Err() == nil {
+		if err := gen.waitForRoutes(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if opts.cleanup {
+		if err := cleanup(cleanupCtx, cli, opts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// loadOp is a single write against one synthetic Ingress
+type loadOp struct {
+	kind       string
+	index      int
+	generation int
+}
+
+// generator picks operations at the configured rate and hands them to the workers. live maps the index
+// of every existing synthetic Ingress to its generation, busy holds the indexes a worker is writing
+type generator struct {
+	cli  client.Client
+	opts *loadgenOptions
+
+	mu   sync.Mutex
+	rng  *rand.Rand
+	live map[int]int
+	busy map[int]bool
+
+	created atomic.Int64
+	updated atomic.Int64
+	deleted atomic.Int64
+	failed  atomic.Int64
+	skipped atomic.Int64
+}
+
+func newGenerator(cli client.Client, opts *loadgenOptions) *generator {
+	return &generator{
+		cli:  cli,
+		opts: opts,
+		rng:  rand.New(rand.NewPCG(opts.seed, opts.seed)),
+		live: make(map[int]int),
+		busy: make(map[int]bool),
+	}
+}
+
+// adoptExisting takes over the synthetic Ingresses an earlier run left behind, so a restart keeps the
+// pool size instead of creating on top of them
+func (g *generator) adoptExisting(ctx context.Context) error {
+	list := &networkingv1.IngressList{}
+	if err := g.cli.List(ctx, list, client.MatchingLabels{LoadgenLabel: "true"}); err != nil {
+		return fmt.Errorf("failed to list synthetic Ingresses: %w", err)
+	}
+	for i := range list.Items {
+		ingress := &list.Items[i]
+		index, err := strconv.Atoi(strings.TrimPrefix(ingress.Name, ingressNamePrefix))
+		if err != nil || index >= g.opts.ingresses || ingress.Namespace != namespaceName(g.opts, index) {
+			continue
+		}
+		generation, err := strconv.Atoi(ingress.Annotations[GenerationAnnotation])
+		if err != nil {
+			generation = 1
+		}
+		g.live[index] = generation
+	}
+	return nil
+}
+
+func (g *generator) run(ctx context.Context) {
+	ops := make(chan loadOp)
+	var wg sync.WaitGroup
+	for range g.opts.workers {
+		wg.Go(func() {
+			for op := range ops {
+				g.apply(ctx, op)
+			}
+		})
+	}
+
+	interval := time.Duration(float64(time.Minute) / g.opts.rate)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	reportTicker := time.NewTicker(g.opts.reportInterval)
+	defer reportTicker.Stop()
+	started := time.Now()
+
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-reportTicker.C:
+			g.report("Churn progress", time.Since(started))
+		case <-ticker.C:
+			op, ok := g.next()
+			if !ok {
+				g.skipped.Add(1)
+				continue
+			}
+			// Never queue up, a missed slot shows up as skipped so the achieved rate stays honest
+			select {
+			case ops <- op:
+			default:
+				g.release(op, false)
+				g.skipped.Add(1)
+			}
+		}
+	}
+	close(ops)
+	wg.Wait()
+}
+
+// next picks the next operation: a create while the pool is not full, otherwise an update or, with
+// --delete-fraction probability, a delete of a random live Ingress
+func (g *generator) next() (loadOp, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	pending := 0
+	for index := range g.busy {
+		if _, ok := g.live[index]; !ok {
+			pending++
+		}
+	}
+	if len(g.live)+pending < g.opts.ingresses {
+		if index, ok := g.pick(func(index int) bool { _, ok := g.live[index]; return !ok }); ok {
+			g.busy[index] = true
+			return loadOp{kind: opCreate, index: index, generation: 1}, true
+		}
+	}
+	index, ok := g.pick(func(index int) bool { _, ok := g.live[index]; return ok })
+	if !ok {
+		return loadOp{}, false
+	}
+	g.busy[index] = true
+	if g.rng.Float64() < g.opts.deleteFraction {
+		return loadOp{kind: opDelete, index: index}, true
+	}
+	return loadOp{kind: opUpdate, index: index, generation: g.live[index] + 1}, true
+}
+
+// pick returns a random index that is not busy and satisfies want, scanning from a random start
+func (g *generator) pick(want func(int) bool) (int, bool) {
+	start := g.rng.IntN(g.opts.ingresses)
+	for i := range g.opts.ingresses {
+		index := (start + i) % g.opts.ingresses
+		if !g.busy[index] && want(index) {
+			return index, true
+		}
+	}
+	return 0, false
+}
+
+// release records the outcome of op, applied reports whether it reached the API server
+func (g *generator) release(op loadOp, applied bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.busy, op.index)
+	if !applied {
+		return
+	}
+	if op.kind == opDelete {
+		delete(g.live, op.index)
+		return
+	}
+	g.live[op.index] = op.generation
+}
+
+func (g *generator) apply(ctx context.Context, op loadOp) {
+	var err error
+	switch op.kind {
+	case opCreate:
+		err = g.create(ctx, op)
+	case opUpdate:
+		err = g.update(ctx, op)
+	case opDelete:
+		err = g.delete(ctx, op)
+	}
+	if err != nil {
+		g.failed.Add(1)
+		if ctx.Err() == nil {
+			setupLog.Error(err, "synthetic Ingress write failed",
+				"op", op.kind,
+				"namespace", namespaceName(g.opts, op.index),
+				"name", ingressName(op.index))
+		}
+	}
+	g.release(op, err == nil)
+}
+
+func (g *generator) create(ctx context.Context, op loadOp) error {
+	ingress, err := renderIngress(g.opts, op.index, op.generation)
+	if err != nil {
+		return err
+	}
+	if err := g.cli.Create(ctx, ingress); err != nil {
+		return err
+	}
+	g.created.Add(1)
+	return nil
+}
+
+// update re-renders the Ingress at its next generation, keeping annotations and labels others added
+func (g *generator) update(ctx context.Context, op loadOp) error {
+	desired, err := renderIngress(g.opts, op.index, op.generation)
+	if err != nil {
+		return err
+	}
+	existing := &networkingv1.Ingress{}
+	if err := g.cli.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name},
+		existing); err != nil {
+		return err
+	}
+	existing.Spec = desired.Spec
+	if existing.Annotations == nil {
+		existing.Annotations = make(map[string]string)
+	}
+	for key, value := range desired.Annotations {
+		existing.Annotations[key] = value
+	}
+	if existing.Labels == nil {
+		existing.Labels = make(map[string]string)
+	}
+	for key, value := range desired.Labels {
+		existing.Labels[key] = value
+	}
+	if err := g.cli.Update(ctx, existing); err != nil {
+		return err
+	}
+	g.updated.Add(1)
+	return nil
+}
+
+func (g *generator) delete(ctx context.Context, op loadOp) error {
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Namespace: namespaceName(g.opts, op.index),
+		Name:      ingressName(op.index),
+	}}
+	if err := g.cli.Delete(ctx, ingress); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	g.deleted.Add(1)
+	return nil
+}
+
+func (g *generator) report(msg string, elapsed time.Duration) {
+	g.mu.Lock()
+	live := len(g.live)
+	g.mu.Unlock()
+	written := g.created.Load() + g.updated.Load() + g.deleted.Load()
+	achieved := 0.0
+	if elapsed > 0 {
+		achieved = float64(written) / elapsed.Minutes()
+	}
+	setupLog.Info(msg,
+		"elapsed", elapsed.Round(time.Second),
+		"live", live,
+		"created", g.created.Load(),
+		"updated", g.updated.Load(),
+		"deleted", g.deleted.Load(),
+		"failed", g.failed.Load(),
+		"skipped", g.skipped.Load(),
+		"achievedPerMinute", fmt.Sprintf("%.1f", achieved))
+}
+
+// waitForRoutes polls until every live synthetic Ingress has an HTTPRoute managed for it and logs how long
+// the operator needed to catch up after the churn stopped
+func (g *generator) waitForRoutes(ctx context.Context) error {
+	started := time.Now()
+	deadline := started.Add(g.opts.waitForRoutes)
+	for {
+		missing, err := g.missingRoutes(ctx)
+		if err != nil {
+			return err
+		}
+		if missing == 0 {
+			setupLog.Info("Every synthetic Ingress has a managed HTTPRoute",
+				"live", len(g.live),
+				"convergedAfter", time.Since(started).Round(time.Second))
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%d of %d synthetic Ingresses still have no managed HTTPRoute after %s",
+				missing, len(g.live), g.opts.waitForRoutes)
+		}
+		setupLog.V(1).Info("Waiting for HTTPRoutes", "missing", missing)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(routePollInterval):
+		}
+	}
+}
+
+// missingRoutes counts the live synthetic Ingresses without a managed HTTPRoute, listing each namespace once
+func (g *generator) missingRoutes(ctx context.Context) (int, error) {
+	byNamespace := make(map[string][]string)
+	for index := range g.live {
+		namespace := namespaceName(g.opts, index)
+		byNamespace[namespace] = append(byNamespace[namespace], ingressName(index))
+	}
+	missing := 0
+	for namespace, names := range byNamespace {
+		routes := &gatewayv1.HTTPRouteList{}
+		if err := g.cli.List(ctx, routes, client.InNamespace(namespace)); err != nil {
+			return 0, fmt.Errorf("failed to list HTTPRoutes in %s: %w", namespace, err)
+		}
+		for _, name := range names {
+			found := false
+			for i := range routes.Items {
+				if utils.IsManagedByUsForIngress(&routes.Items[i], namespace, name) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				missing++
+			}
+		}
+	}
+	return missing, nil
+}
+
+// ensureNamespaces creates the namespaces the Ingresses are spread across, labelled so cleanup can tell
+// them from namespaces that existed before
+func ensureNamespaces(ctx context.Context, cli client.Client, opts *loadgenOptions) error {
+	for i := range opts.namespaces {
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   namespaceName(opts, i),
+			Labels: map[string]string{LoadgenLabel: "true"},
+		}}
+		if err := cli.Create(ctx, namespace); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create namespace %s: %w", namespace.Name, err)
+		}
+	}
+	return nil
+}
+
+// cleanup deletes the synthetic Ingresses and the namespaces the load generator created. Namespaces
+// without the loadgen label are left alone
+func cleanup(ctx context.Context, cli client.Client, opts *loadgenOptions) error {
+	var errs []error
+	for i := range opts.namespaces {
+		name := namespaceName(opts, i)
+		if err := cli.DeleteAllOf(ctx, &networkingv1.Ingress{}, client.InNamespace(name),
+			client.MatchingLabels{LoadgenLabel: "true"}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete synthetic Ingresses in %s: %w", name, err))
+			continue
+		}
+		namespace := &corev1.Namespace{}
+		if err := cli.Get(ctx, types.NamespacedName{Name: name}, namespace); err != nil {
+			if !apierrors.IsNotFound(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if namespace.Labels[LoadgenLabel] != "true" {
+			continue
+		}
+		if err := cli.Delete(ctx, namespace); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete namespace %s: %w", name, err))
+		}
+	}
+	setupLog.Info("Cleaned up synthetic Ingresses", "namespaces", opts.namespaces, "failed", len(errs))
+	return errors.Join(errs...)
+}
+
+func parseKeyValueCSV(raw string) map[string]string {
+	out := make(map[string]string)
+	if raw == "" {
+		return out
+	}
+	for _, pair := range strings.Split(raw, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) == 2 {
+			out[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+	return out
+}
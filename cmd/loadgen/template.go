@@ -0,0 +1,138 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"text/template"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// ingressNamePrefix is followed by the index of the synthetic Ingress
+const ingressNamePrefix = "loadgen-"
+
+// defaultIngressTemplate is rendered for every synthetic Ingress unless --template names another one
+const defaultIngressTemplate = `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  annotations:
+    nginx.ingress.kubernetes.io/proxy-body-size: "{{ .Generation }}m"
+    nginx.ingress.kubernetes.io/ssl-redirect: "{{ .TLS }}"
+spec:
+  ingressClassName: {{ .Class }}
+  {{- if .TLS }}
+  tls:
+  - hosts:
+    - {{ .Host }}
+    secretName: {{ .Name }}-tls
+  {{- end }}
+  rules:
+  - host: {{ .Host }}
+    http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: {{ .Name }}
+            port:
+              number: 80
+      - path: /api/v{{ .Generation }}
+        pathType: Prefix
+        backend:
+          service:
+            name: {{ .Name }}-api
+            port:
+              number: 8080
+`
+
+// templateData is what an Ingress template is rendered with
+type templateData struct {
+	Name       string
+	Namespace  string
+	Index      int
+	Generation int
+	Class      string
+	Domain     string
+	Host       string
+	TLS        bool
+}
+
+// parseIngressTemplate reads the Ingress template from path, the built-in one when path is empty
+func parseIngressTemplate(path string) (*template.Template, error) {
+	raw := defaultIngressTemplate
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		raw = string(data)
+	}
+	return template.New("ingress").Option("missingkey=error").Parse(raw)
+}
+
+// renderIngress renders the synthetic Ingress with the given index at the given generation. Name, namespace,
+// the loadgen label and the generation annotation are always set by us, whatever the template says
+func renderIngress(opts *loadgenOptions, index, generation int) (*networkingv1.Ingress, error) {
+	data := templateData{
+		Name:       ingressName(index),
+		Namespace:  namespaceName(opts, index),
+		Index:      index,
+		Generation: generation,
+		Class:      opts.class,
+		Domain:     opts.domain,
+		TLS:        opts.tls,
+	}
+	data.Host = fmt.Sprintf("%s.%s.%s", data.Name, data.Namespace, opts.domain)
+
+	var buf bytes.Buffer
+	if err := opts.template.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render Ingress template: %w", err)
+	}
+	ingress := &networkingv1.Ingress{}
+	if err := yaml.UnmarshalStrict(buf.Bytes(), ingress); err != nil {
+		return nil, fmt.Errorf("rendered Ingress template is not a valid Ingress: %w", err)
+	}
+
+	ingress.Name = data.Name
+	ingress.Namespace = data.Namespace
+	if ingress.Labels == nil {
+		ingress.Labels = make(map[string]string)
+	}
+	ingress.Labels[LoadgenLabel] = "true"
+	if ingress.Annotations == nil {
+		ingress.Annotations = make(map[string]string)
+	}
+	for key, value := range opts.annotations {
+		ingress.Annotations[key] = value
+	}
+	ingress.Annotations[GenerationAnnotation] = strconv.Itoa(generation)
+	return ingress, nil
+}
+
+func ingressName(index int) string {
+	return ingressNamePrefix + strconv.Itoa(index)
+}
+
+func namespaceName(opts *loadgenOptions, index int) string {
+	return fmt.Sprintf("%s%d", opts.namespacePrefix, index%opts.namespaces)
+}
@@ -21,8 +21,11 @@ import (
 	"crypto/tls"
 	"flag"
 	"fmt"
+	"net/url"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -33,22 +36,36 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/recorder"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	doperatorv1alpha1 "github.com/fiksn/ingress-doperator/api/v1alpha1"
 	"github.com/fiksn/ingress-doperator/internal/controller"
-	_ "github.com/fiksn/ingress-doperator/internal/metrics" // Import to register metrics
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/probe"
 	"github.com/fiksn/ingress-doperator/internal/translator"
 	"github.com/fiksn/ingress-doperator/internal/utils"
 	// +kubebuilder:scaffold:imports
@@ -70,7 +87,9 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 	utilruntime.Must(gatewayv1.Install(scheme))
+	utilruntime.Must(gatewayv1alpha2.Install(scheme))
 	utilruntime.Must(gatewayv1beta1.Install(scheme))
+	utilruntime.Must(doperatorv1alpha1.AddToScheme(scheme))
 
 	// +kubebuilder:scaffold:scheme
 }
@@ -86,6 +105,11 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if err := metrics.Register(cfg.MetricsNamespace, cfg.LegacyMetrics); err != nil {
+		setupLog.Error(err, "unable to register metrics")
+		os.Exit(1)
+	}
+
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
 	// prevent from being vulnerable to the HTTP/2 Stream Cancellation and
@@ -116,8 +140,10 @@ func main() {
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: cfg.ProbeAddr,
-		LeaderElection:         cfg.EnableLeaderElection,
+		LeaderElection:         cfg.EnableLeaderElection && !cfg.ReadOnly,
 		LeaderElectionID:       "94203fac.fiction.si",
+		// Record per-verb, per-kind API latency to tell slow API servers from slow translation
+		NewClient: newClientFunc(cfg.DryRun, cfg.ReadOnly),
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -136,7 +162,28 @@ func main() {
 	}
 
 	ctx := context.Background()
-	if cfg.IngressPostProcessingMode == controller.IngressPostProcessingModeDisable {
+	if cfg.ReadOnly {
+		setupLog.Info("Read only, no writes are sent and leader election is off")
+	}
+	// Missing permissions are reported now rather than by the first reconcile needing them
+	if cfg.RBACCheck != rbacCheckOff {
+		if err := checkPermissions(ctx, mgr, cfg); err != nil {
+			setupLog.Error(err, "missing permissions")
+			os.Exit(1)
+		}
+	}
+
+	// Features needing Gateway API kinds the cluster lacks are switched off instead of failing their reconciles
+	gatewayAPI, err := utils.NewGatewayAPICapabilities(discovery.NewDiscoveryClientForConfigOrDie(mgr.GetConfig()))
+	if err != nil {
+		setupLog.Error(err, "unable to detect Gateway API kinds")
+		os.Exit(1)
+	}
+	gatewayAPIWatches := gateGatewayAPIFeatures(gatewayAPI, &cfg)
+	gatewayAPIReady := len(gatewayAPI.Missing(utils.GatewayKind, utils.HTTPRouteKind)) == 0
+
+	// An IngressMigrationPolicy may switch to the disable mode at runtime
+	if cfg.IngressPostProcessingMode == controller.IngressPostProcessingModeDisable || cfg.MigrationPolicy != "" {
 		if err := ensureDisabledIngressClass(ctx, mgr.GetAPIReader(), mgr.GetClient()); err != nil {
 			setupLog.Error(err, "failed to ensure disabled IngressClass")
 			os.Exit(1)
@@ -152,9 +199,10 @@ func main() {
 	}
 	setupLog.Info("Verified Gateway namespace exists", "namespace", cfg.GatewayNamespace)
 
-	reconcileCache := make(map[string]utils.ReconcileCacheEntry)
-	if cfg.ReconcileCachePersist {
-		reconcileCache = loadReconcileCache(ctx, mgr.GetAPIReader(), cfg.GatewayNamespace)
+	reconcileCache, err := newReconcileCache(ctx, mgr, cfg)
+	if err != nil {
+		setupLog.Error(err, "unable to set up reconcile cache")
+		os.Exit(1)
 	}
 
 	for _, mapping := range cfg.ParsedClassSnippetsFilters {
@@ -171,63 +219,206 @@ func main() {
 		}
 	}
 
-	// Setup Ingress controller (manages Ingress → HTTPRoute translation)
-	if err = (&controller.IngressReconciler{
-		Client:                           mgr.GetClient(),
-		Scheme:                           mgr.GetScheme(),
-		Recorder:                         mgr.GetEventRecorder("ingress-doperator"),
-		GatewayNamespace:                 cfg.GatewayNamespace,
-		GatewayName:                      cfg.GatewayName,
-		GatewayClassName:                 cfg.GatewayClassName,
-		WatchNamespace:                   cfg.WatchNamespace,
-		OneGatewayPerIngress:             cfg.OneGatewayPerIngress,
-		EnableDeletion:                   cfg.EnableDeletion,
-		HostnameRewriteFrom:              cfg.HostnameRewriteFrom,
-		HostnameRewriteTo:                cfg.HostnameRewriteTo,
-		IngressPostProcessingMode:        cfg.IngressPostProcessingMode,
-		GatewayAnnotationFilters:         cfg.GatewayFilters,
-		HTTPRouteAnnotationFilters:       cfg.HTTPRouteFilters,
-		DefaultGatewayAnnotations:        cfg.GatewayAnnotationsMap,
-		GatewayInfrastructureAnnotations: cfg.GatewayInfraAnnotationsMap,
-		InfrastructureAnnotationsByClass: cfg.InfrastructureAnnotationsByClass,
-		IngressClassFilters:              cfg.IngressClassFilters,
-		IngressClassIgnoreFilters:        cfg.IngressClassIgnoreFilters,
-		IngressClassEmpty:                cfg.IngressClassEmpty,
-		IngressClassSnippetsFilters:      cfg.ParsedClassSnippetsFilters,
-		IngressNameSnippetsFilters:       cfg.ParsedNameSnippetsFilters,
-		IngressAnnotationSnippetsAdd:     cfg.ParsedAnnotationSnippetsAdd,
-		IngressAnnotationSnippetsRemove:  cfg.ParsedAnnotationSnippetsRemove,
-		ClearIngressStatusOnDisable:      cfg.ClearIngressStatusOnDisable,
-		ReconcileCache:                   reconcileCache,
-		ReconcileCacheNamespace:          cfg.GatewayNamespace,
-		ReconcileCacheBaseName:           utils.ReconcileCacheConfigMapBaseName,
-		ReconcileCacheShards:             utils.ReconcileCacheShardCount,
-		ReconcileCachePersist:            cfg.ReconcileCachePersist,
-		ReconcileCacheMaxEntries:         cfg.ReconcileCacheMaxEntries,
-		UseIngress2Gateway:               cfg.UseIngress2Gateway,
-		Ingress2GatewayProvider:          cfg.Ingress2GatewayProvider,
-		Ingress2GatewayIngressClass:      cfg.Ingress2GatewayIngressClass,
-		HTTPRouteManager: &utils.HTTPRouteManager{
+	// Derived resources in Ingress namespaces are written with the tenant identity when impersonating
+	tenantClient := mgr.GetClient()
+	if cfg.ImpersonateTemplate != "" {
+		tenantClient, err = utils.NewImpersonatingClient(mgr.GetConfig(), client.Options{
+			Scheme: mgr.GetScheme(),
+			Mapper: mgr.GetRESTMapper(),
+			Cache:  &client.CacheOptions{Reader: mgr.GetCache()},
+		}, cfg.ImpersonateTemplate)
+		if err != nil {
+			setupLog.Error(err, "unable to create impersonating client")
+			os.Exit(1)
+		}
+		if cfg.DryRun {
+			tenantClient = utils.NewDryRunClient(tenantClient)
+		}
+		if cfg.ReadOnly {
+			tenantClient = utils.NewReadOnlyClient(tenantClient)
+		}
+		setupLog.Info("Writing derived resources in Ingress namespaces as tenant identity",
+			"template", cfg.ImpersonateTemplate)
+	}
+
+	fanIn, err := newFanIn(ctx, mgr, cfg)
+	if err != nil {
+		setupLog.Error(err, "unable to set up fan-in source clusters")
+		os.Exit(1)
+	}
+
+	dryRunReport, err := newDryRunReport(mgr, cfg)
+	if err != nil {
+		setupLog.Error(err, "unable to create dry run report client")
+		os.Exit(1)
+	}
+
+	// Setup IngressMigrationPolicy controller (hot-reloads settings otherwise given as flags)
+	migrationPolicy := newMigrationPolicy(cfg)
+	if migrationPolicy != nil {
+		if err = (&controller.MigrationPolicyReconciler{
 			Client: mgr.GetClient(),
-		},
-	}).SetupWithManager(mgr); err != nil {
+			Policy: migrationPolicy,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "IngressMigrationPolicy")
+			os.Exit(1)
+		}
+		setupLog.Info("Following IngressMigrationPolicy", "name", cfg.MigrationPolicy)
+	}
+
+	// Setup HostnameRenamePlan controller (phased renames of a hostname suffix)
+	var hostnameRenames *controller.HostnameRenames
+	if cfg.HostnameRenamePlans {
+		hostnameRenames = controller.NewHostnameRenames()
+		if err = (&controller.HostnameRenamePlanReconciler{
+			Client:  mgr.GetClient(),
+			Renames: hostnameRenames,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "HostnameRenamePlan")
+			os.Exit(1)
+		}
+		setupLog.Info("Following HostnameRenamePlans")
+	}
+
+	// Setup Ingress status controller (Gateway addresses in the status of disabled Ingresses)
+	if cfg.IngressStatusFromGateway && gatewayAPIReady {
+		if err = (&controller.IngressStatusReconciler{
+			Client:              mgr.GetClient(),
+			ClearWithoutAddress: cfg.ClearIngressStatusOnDisable,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "IngressStatus")
+			os.Exit(1)
+		}
+	}
+
+	notifier, err := newNotifier(mgr, cfg)
+	if err != nil {
+		setupLog.Error(err, "unable to set up notifications")
+		os.Exit(1)
+	}
+
+	// Setup Ingress controller (manages Ingress → HTTPRoute translation)
+	intentLog := newIntentLog(mgr, cfg)
+	hostnameStates := newHostnameStates(mgr, cfg)
+	ingressReconciler := newIngressReconciler(mgr, cfg, reconcileCache, tenantClient, intentLog)
+	ingressReconciler.FanIn = fanIn
+	ingressReconciler.DryRunReport = dryRunReport
+	ingressReconciler.MigrationPolicy = migrationPolicy
+	ingressReconciler.HostnameRenames = hostnameRenames
+	ingressReconciler.Notifier = notifier
+	ingressReconciler.HostnameStates = hostnameStates
+	ingressReconciler.GatewayAPI = gatewayAPI
+	if err = ingressReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Ingress")
 		os.Exit(1)
 	}
+	if cfg.MigrationAPI {
+		for path, handler := range (&controller.MigrationAPI{Reconciler: ingressReconciler}).Handlers() {
+			if err := mgr.AddMetricsServerExtraHandler(path, handler); err != nil {
+				setupLog.Error(err, "unable to serve the migration API", "path", path)
+				os.Exit(1)
+			}
+		}
+	}
+	fanInReconcilers, err := setupFanInReconcilers(mgr, cfg, fanIn, reconcileCache, tenantClient, intentLog,
+		migrationPolicy, hostnameRenames)
+	if err != nil {
+		setupLog.Error(err, "unable to create fan-in controllers")
+		os.Exit(1)
+	}
+	for _, r := range fanInReconcilers {
+		r.DryRunReport = dryRunReport
+		r.Notifier = notifier
+		r.HostnameStates = hostnameStates
+		r.GatewayAPI = gatewayAPI
+	}
+	if intentLog != nil {
+		// Reports (and with --intent-log-resume finishes) operations interrupted by a crash
+		if err := mgr.Add(&controller.IntentRecovery{
+			IntentLog:   intentLog,
+			Reconcilers: append([]*controller.IngressReconciler{ingressReconciler}, fanInReconcilers...),
+			Resume:      cfg.IntentLogResume,
+		}); err != nil {
+			setupLog.Error(err, "unable to set up intent log recovery")
+			os.Exit(1)
+		}
+	}
 
 	// Setup HTTPRoute controller (manages Gateway listeners based on HTTPRoutes)
-	if err = (&controller.HTTPRouteReconciler{
-		Client:                    mgr.GetClient(),
-		Scheme:                    mgr.GetScheme(),
-		GatewayNamespace:          cfg.GatewayNamespace,
-		GatewayName:               cfg.GatewayName,
-		GatewayClassName:          cfg.GatewayClassName,
-		HostnameRewriteFrom:       cfg.HostnameRewriteFrom,
-		HostnameRewriteTo:         cfg.HostnameRewriteTo,
-		IngressPostProcessingMode: cfg.IngressPostProcessingMode,
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "HTTPRoute")
-		os.Exit(1)
+	httpRouteReconciler := &controller.HTTPRouteReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		GatewayNamespace:             cfg.GatewayNamespace,
+		GatewayName:                  cfg.GatewayName,
+		GatewayClassName:             cfg.GatewayClassName,
+		GatewayClassMapping:          cfg.ParsedGatewayClassMapping,
+		IngressClassEmpty:            cfg.IngressClassEmpty,
+		HostnameRewriteFrom:          cfg.HostnameRewriteFrom,
+		HostnameRewriteTo:            cfg.HostnameRewriteTo,
+		IngressPostProcessingMode:    cfg.IngressPostProcessingMode,
+		PauseOnUnhealthyGatewayClass: cfg.PauseOnUnhealthyGatewayClass,
+		ListenerAllowedRoutes:        cfg.ParsedListenerAllowedRoutes,
+		GatewayAddresses:             cfg.ParsedGatewayAddresses,
+		InfraAnnotationsByGateway:    cfg.ParsedInfraAnnotationsByGateway,
+		WildcardListenerDomains:      cfg.ParsedWildcardListenerDomains,
+		AttachOnly:                   cfg.AttachOnly,
+		FanIn:                        fanIn,
+		GRPCRoutes:                   cfg.EnableGRPCRoutes,
+		TLSRoutes:                    cfg.EnableTLSRoutes,
+		CertReplication:              cfg.CertReplicationMode,
+		OwnershipMode:                cfg.OwnershipMode,
+		APIReader:                    mgr.GetAPIReader(),
+		IntentLog:                    intentLog,
+		MigrationPolicy:              migrationPolicy,
+		HostnameRenames:              hostnameRenames,
+		Notifier:                     notifier,
+		GatewayAPI:                   gatewayAPI,
+	}
+	if gatewayAPIReady {
+		if err = httpRouteReconciler.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "HTTPRoute")
+			os.Exit(1)
+		}
+	}
+	if cfg.EnableGRPCRoutes && !cfg.AttachOnly && gatewayAPIReady {
+		// Setup GRPCRoute controller (manages Gateway listeners based on GRPCRoutes)
+		if err = (&controller.GRPCRouteReconciler{
+			Client:    mgr.GetClient(),
+			Listeners: httpRouteReconciler,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "GRPCRoute")
+			os.Exit(1)
+		}
+	}
+	if cfg.EnableTLSRoutes && !cfg.AttachOnly && gatewayAPIReady {
+		// Setup TLSRoute controller (manages Gateway passthrough listeners based on TLSRoutes)
+		if err = (&controller.TLSRouteReconciler{
+			Client:    mgr.GetClient(),
+			Listeners: httpRouteReconciler,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "TLSRoute")
+			os.Exit(1)
+		}
+	}
+	// Setup tcp-services/udp-services controllers (manage TCPRoutes/UDPRoutes and their listeners)
+	for protocol, configMap := range map[gatewayv1.ProtocolType]types.NamespacedName{
+		gatewayv1.TCPProtocolType: cfg.ParsedTCPServicesConfigMap,
+		gatewayv1.UDPProtocolType: cfg.ParsedUDPServicesConfigMap,
+	} {
+		if configMap.Name == "" || !gatewayAPIReady {
+			continue
+		}
+		if err = (&controller.StreamServicesReconciler{
+			Client:            mgr.GetClient(),
+			Protocol:          protocol,
+			ConfigMap:         configMap,
+			Listeners:         httpRouteReconciler,
+			Recorder:          eventRecorder(mgr, cfg),
+			OwnedMetadataKeys: cfg.OwnedMetadataKeyPatterns,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", string(protocol)+"Services")
+			os.Exit(1)
+		}
 	}
 
 	if cfg.WatchNamespace != "" {
@@ -236,13 +427,22 @@ func main() {
 		setupLog.Info("Watching Ingresses in all namespaces")
 	}
 
-	if cfg.OneGatewayPerIngress {
+	switch {
+	case cfg.AttachOnly:
+		setupLog.Info("Mode: Attach to pre-provisioned Gateway",
+			"gateway", cfg.GatewayNamespace+"/"+cfg.GatewayName,
+			"sectionNames", cfg.AttachSectionNames)
+	case cfg.OneGatewayPerIngress:
 		setupLog.Info("Mode: One Gateway per Ingress")
-	} else {
+	case cfg.OneGatewayPerNamespace:
+		setupLog.Info("Mode: One Gateway per namespace", "gatewayNamePrefix", cfg.GatewayName)
+	default:
 		setupLog.Info("Mode: Shared Gateway", "gatewayName", cfg.GatewayName)
 	}
 
-	if cfg.EnableDeletion {
+	if cfg.DeletionCascadePolicy == controller.DeletionCascadeAdopt {
+		setupLog.Info("Deletion cascade adopt: derived resources are handed over when Ingress is deleted")
+	} else if cfg.EnableDeletion || cfg.DeletionCascadePolicy == controller.DeletionCascadeDelete {
 		setupLog.Info("Deletion enabled: HTTPRoute and Gateway resources will be deleted when Ingress is deleted")
 	} else {
 		setupLog.Info("Deletion disabled: HTTPRoute and Gateway resources will remain when Ingress is deleted")
@@ -270,6 +470,23 @@ func main() {
 	case controller.IngressPostProcessingModeDisableExternalDNS:
 		setupLog.Info("Ingress post processing mode: disable-external-dns")
 	}
+	metrics.RolloutPercentage.Set(float64(cfg.RolloutPercentage))
+	if cfg.RolloutPercentage < 100 {
+		setupLog.Info("Phased rollout enabled", "percentage", cfg.RolloutPercentage)
+	}
+
+	// A Gateway API CRD installed or removed later changes what the operator can do
+	managerCtx, stopManager := context.WithCancel(ctrl.SetupSignalHandler())
+	gatewayAPIReconciler := &controller.GatewayAPIReconciler{
+		Client:       mgr.GetClient(),
+		Capabilities: gatewayAPI,
+		RestartOn:    gatewayAPIWatches,
+		Restart:      stopManager,
+	}
+	if err = gatewayAPIReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GatewayAPI")
+		os.Exit(1)
+	}
 
 	// +kubebuilder:scaffold:builder
 
@@ -289,10 +506,14 @@ func main() {
 		setupLog.Info("Serving metrics server", "addr", cfg.MetricsAddr, "secure", cfg.SecureMetrics)
 	}
 
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(managerCtx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+	if gatewayAPIReconciler.RestartRequested() {
+		setupLog.Info("Gateway API CRDs changed, exiting to restart with matching watches")
+		os.Exit(1)
+	}
 }
 
 type operatorConfig struct {
@@ -306,22 +527,33 @@ type operatorConfig struct {
 	EnableLeaderElection            bool
 	ProbeAddr                       string
 	SecureMetrics                   bool
+	MetricsNamespace                string
+	LegacyMetrics                   bool
 	EnableHTTP2                     bool
 	Verbosity                       int
 	GatewayNamespace                string
 	GatewayName                     string
 	GatewayClassName                string
+	GatewayClassMapping             string
 	WatchNamespace                  string
 	OneGatewayPerIngress            bool
+	OneGatewayPerNamespace          bool
+	MaxListenersPerGateway          int
+	GatewayCapacity                 string
+	MaxGatewayConfigSize            string
 	GatewayAnnotationFilters        string
 	HTTPRouteAnnotationFilters      string
+	OwnedMetadataKeys               string
 	EnableDeletion                  bool
+	DeletionCascade                 string
+	ClassChangePolicy               string
 	HostnameRewriteFrom             string
 	HostnameRewriteTo               string
 	IngressPostProcessing           string
 	GatewayAnnotations              string
 	GatewayInfraAnnotations         string
 	AnnotationsByClass              string
+	InfraLabelPrefixes              string
 	IngressClassFilter              string
 	IngressClassIgnoreFilter        string
 	IngressClassEmpty               string
@@ -329,25 +561,128 @@ type operatorConfig struct {
 	IngressNameSnippetsFilters      string
 	IngressAnnotationSnippetsAdd    string
 	IngressAnnotationSnippetsRemove string
+	DisableSnippets                 bool
+	AllowLossy                      bool
+	DataPlaneProvider               string
 	ReconcileCachePersist           bool
 	ReconcileCacheMaxEntries        int
+	ReconcileCacheEmptyShardTTL     time.Duration
+	ReconcileCacheBackend           string
+	ReconcileCacheRedisURL          string
+	ReconcileCacheRedisKeyPrefix    string
 	ClearIngressStatusOnDisable     bool
+	DisableStrategyName             string
+	IngressStatusFromGateway        bool
+	ProposeConflictNames            bool
 	UseIngress2Gateway              bool
 	Ingress2GatewayProvider         string
 	Ingress2GatewayIngressClass     string
+	RegexPathMatch                  string
+	ImplementationSpecificPaths     string
+	ProxySSLTranslation             string
+	Ownership                       string
+	BackendConflicts                string
+	CertReplication                 string
+	PauseOnUnhealthyGatewayClass    bool
+	AllowCrossNamespaceHeaders      bool
+	NamespaceFailureThreshold       int
+	NamespaceFailureCooldown        time.Duration
+	DriftResyncInterval             time.Duration
+	AnnotationCoverageInterval      time.Duration
+	AnnotationCoverageConfigMap     string
+	ValidateTLSSecrets              bool
+	MigrationAPI                    bool
+	RBACCheck                       string
+	TLSOnlyHosts                    string
+	PrioritizeUnmigrated            bool
+	ListenerAllowedRoutes           string
+	GatewayAddresses                string
+	AnnotationsByGateway            string
+	WildcardListenerDomains         string
+	ImpersonateTemplate             string
+	PairedHTTPListeners             bool
+	TraefikEntryPoints              string
+	HAProxyIngressClasses           string
+	NameTemplate                    string
+	SnippetsFilterNameTemplate      string
+	AttachOnly                      bool
+	AttachSectionNames              string
+	FanInSources                    string
+	FanInHostnamePrefixes           string
+	FanInHostnameRewrites           string
+	FanInConflictPolicy             string
+	EnableGRPCRoutes                bool
+	EnableTLSRoutes                 bool
+	TCPServicesConfigMap            string
+	UDPServicesConfigMap            string
+	HostnameStates                  bool
+	HostnameRenamePlans             bool
+	NetworkPolicies                 bool
+	GatewayPodSelector              string
+	HostnameHandoffWindow           time.Duration
+	ListenerRemovalAck              bool
+	ListenerRemovalAckTimeout       time.Duration
+	IntentLog                       bool
+	IntentLogResume                 bool
+	DryRun                          bool
+	ReadOnly                        bool
+	MigrationPolicy                 string
+	NotifyWebhookURL                string
+	NamespaceSelector               string
+	IngressSelector                 string
+	NotifyEvents                    string
+	NotifyPayloadTemplate           string
+	ProbeResolvers                  string
+	ProbeProxy                      string
+	ProbeCABundle                   string
+	ProbeInsecureSkipVerify         bool
 
 	ParsedClassSnippetsFilters       []utils.IngressClassSnippetsFilter
 	ParsedNameSnippetsFilters        []utils.IngressClassSnippetsFilter
 	ParsedAnnotationSnippetsAdd      []utils.IngressAnnotationSnippetsRule
 	ParsedAnnotationSnippetsRemove   []utils.IngressAnnotationSnippetsRule
 	IngressPostProcessingMode        controller.IngressPostProcessingMode
+	RolloutPercentage                int
 	GatewayFilters                   []string
 	HTTPRouteFilters                 []string
+	OwnedMetadataKeyPatterns         []string
 	GatewayAnnotationsMap            map[string]string
 	GatewayInfraAnnotationsMap       map[string]string
 	InfrastructureAnnotationsByClass []translator.IngressClassAnnotationsRule
+	ParsedGatewayClassMapping        []translator.GatewayClassRule
+	InfrastructureLabelPrefixes      []string
 	IngressClassFilters              []string
 	IngressClassIgnoreFilters        []string
+	ParsedNamespaceSelector          labels.Selector
+	ParsedIngressSelector            labels.Selector
+	ParsedGatewayPodSelector         *metav1.LabelSelector
+	RegexPathMatchMode               controller.RegexPathMatchMode
+	ProxySSLMode                     controller.ProxySSLMode
+	OwnershipMode                    controller.OwnershipMode
+	DeletionCascadePolicy            controller.DeletionCascadePolicy
+	ParsedClassChangePolicy          controller.ClassChangePolicy
+	BackendConflictPolicy            controller.BackendConflictPolicy
+	DisableStrategy                  controller.DisableStrategy
+	GatewayCapacityAction            controller.GatewayCapacityAction
+	MaxGatewayConfigBytes            int64
+	CertReplicationMode              controller.CertReplicationMode
+	ParsedDataPlaneProvider          controller.DataPlaneProvider
+	TLSOnlyHostsMode                 translator.TLSOnlyHostsMode
+	ImplementationSpecificPolicy     translator.ImplementationSpecificPolicy
+	ParsedListenerAllowedRoutes      translator.AllowedRoutesPolicies
+	ParsedGatewayAddresses           translator.GatewayAddressRules
+	ParsedInfraAnnotationsByGateway  translator.GatewayInfrastructureAnnotationRules
+	ParsedWildcardListenerDomains    []string
+	ParsedTraefikEntryPoints         translator.TraefikEntryPoints
+	ParsedHAProxyIngressClasses      []string
+	ParsedNameTemplate               *translator.NameTemplate
+	ParsedSnippetsFilterNameTemplate *translator.SnippetsFilterNameTemplate
+	ParsedAttachSectionNames         []gatewayv1.SectionName
+	ParsedFanInSources               []fanInSourceConfig
+	ParsedNotifyEvents               []utils.NotificationEvent
+	ParsedProbeConfig                probe.Config
+	ParsedTCPServicesConfigMap       types.NamespacedName
+	ParsedUDPServicesConfigMap       types.NamespacedName
 }
 
 func parseOperatorConfig() (operatorConfig, zap.Options, error) {
@@ -363,8 +698,17 @@ func parseOperatorConfig() (operatorConfig, zap.Options, error) {
 		"The name of the Gateway resource (only used when one-gateway-per-ingress is false)")
 	flag.StringVar(&cfg.GatewayClassName, "gateway-class-name", "nginx",
 		"The GatewayClass to use for created Gateway resources")
+	flag.StringVar(&cfg.GatewayClassMapping, "gateway-class-mapping", "",
+		"Comma-separated list of ingressClassPattern=gatewayClass entries selecting the GatewayClass per source "+
+			"ingress class (e.g., 'nginx=nginx-gateway,nginx-internal=nginx-gateway-internal'); "+
+			"unmatched classes use --gateway-class-name")
 	flag.StringVar(&cfg.WatchNamespace, "watch-namespace", "",
 		"If specified, only watch Ingresses in this namespace (default: watch all namespaces)")
+	flag.StringVar(&cfg.NamespaceSelector, "namespace-selector", "",
+		"Label selector of the namespaces whose Ingresses are migrated, e.g. 'ingress-doperator=enabled' to opt in "+
+			"or '!legacy' to opt out (empty = all namespaces)")
+	flag.StringVar(&cfg.IngressSelector, "ingress-selector", "",
+		"Label selector of the Ingresses migrated (empty = all Ingresses)")
 	flag.StringVar(&cfg.IngressClassFilter, "ingress-class-filter", "*",
 		"Comma-separated list of glob patterns to filter which ingress classes to process "+
 			"(e.g., '*private*', 'nginx', '*'). Default '*' processes all classes.")
@@ -375,8 +719,35 @@ func parseOperatorConfig() (operatorConfig, zap.Options, error) {
 		"Value to use when an Ingress has no class set. This value is matched against class filters.")
 	flag.BoolVar(&cfg.OneGatewayPerIngress, "one-gateway-per-ingress", false,
 		"If true, create a separate Gateway for each Ingress with the same name")
+	flag.BoolVar(&cfg.OneGatewayPerNamespace, "one-gateway-per-namespace", false,
+		"If true, create one Gateway named <gateway-name>-<namespace> for all Ingresses of a namespace")
+	flag.IntVar(&cfg.MaxListenersPerGateway, "max-listeners-per-gateway", controller.MaxGatewayListeners,
+		"Listeners per shared Gateway before Ingresses overflow to <gateway>-1..N (0 = never shard)")
+	flag.StringVar(&cfg.GatewayCapacity, "gateway-capacity-action", string(controller.GatewayCapacityActionWarn),
+		"What to do when migrating an Ingress would exceed the listener or config size limit of its Gateway: "+
+			"'off' (do not estimate), 'warn' (record an event) or 'defer' (hold back Ingresses not yet on the Gateway)")
+	flag.StringVar(&cfg.MaxGatewayConfigSize, "max-gateway-config-size", "",
+		"Estimated nginx config size a Gateway may reach, as a quantity (e.g. 8Mi). Empty means only listeners "+
+			"are checked.")
 	flag.BoolVar(&cfg.EnableDeletion, "enable-deletion", false,
 		"If true, delete HTTPRoute (and Gateway in one-gateway-per-ingress mode) when Ingress is deleted")
+	flag.StringVar(&cfg.DeletionCascade, "deletion-cascade", string(controller.DeletionCascadeKeep),
+		"What happens to the derived resources of a migrated Ingress when it is deleted: 'keep' (leave them), "+
+			"'delete' (delete routes, filters and policies, which prunes their listeners; same as --enable-deletion) "+
+			"or 'adopt' (remove their ownerReference and managed-by annotation so they outlive the Ingress)")
+	flag.StringVar(&cfg.ClassChangePolicy, "class-change-policy", string(controller.ClassChangeDelete),
+		"What happens to the derived resources of a migrated Ingress whose class is changed to one that is not "+
+			"migrated: 'delete' (delete routes, filters and policies), 'adopt' (hand them over as with "+
+			"--deletion-cascade=adopt) or 'ignore' (leave them serving)")
+	flag.StringVar(&cfg.Ownership, "ownership-mode", string(controller.OwnershipModeReferences),
+		"How derived resources are tied to their Ingress: 'references' (ownerReferences only) or 'finalizer' "+
+			"(routes block the Ingress deletion, ReferenceGrants are owned by their Ingresses and a finalizer "+
+			"deletes HTTPRoutes before the resources they reference)")
+	flag.StringVar(&cfg.BackendConflicts, "backend-conflict-policy", string(controller.BackendConflictWarn),
+		"What happens to Ingresses routing the same host and path to different backends: 'warn' (migrate both "+
+			"with a BackendConflict warning), 'refuse' (migrate neither until the conflict is resolved), "+
+			"'oldest-wins' or 'newest-wins' (route the path only to the backend of the oldest or newest Ingress) or "+
+			"'merge-as-weighted' (split the path evenly between the backends of Ingresses in the same namespace)")
 	flag.StringVar(&cfg.HostnameRewriteFrom, "hostname-rewrite-from", "",
 		"Comma-separated list of domain suffixes to match for rewriting (e.g., 'domain.cc,other.com'). "+
 			"Used with --hostname-rewrite-to.")
@@ -388,14 +759,23 @@ func parseOperatorConfig() (operatorConfig, zap.Options, error) {
 		"How to handle the post processing of ingress: 'none' (no action), "+
 			"'disable' (remove ingress class), 'remove' (delete ingress), "+
 			"'disable-external-dns' (force external-dns to read annotations only)")
+	flag.IntVar(&cfg.RolloutPercentage, "rollout-percentage", 100,
+		"Percentage (0-100) of eligible Ingresses migrated. Ingresses are ordered by a hash of their name, so "+
+			"raising it migrates more Ingresses and never reshuffles those already in.")
 	flag.StringVar(&cfg.GatewayAnnotations, "gateway-annotations", DefaultGatewayAnnotations,
 		"Comma-separated key=value pairs for Gateway metadata annotations (applied to all Gateways)")
 	flag.StringVar(&cfg.GatewayInfraAnnotations, "gateway-infrastructure-annotations",
 		DefaultGatewayInfraAnnotations,
 		"Comma-separated key=value pairs for Gateway infrastructure annotations (applied to all Gateways)")
+	flag.StringVar(&cfg.InfraLabelPrefixes, "gateway-infrastructure-label-prefixes", "",
+		"Comma-separated label key prefixes; matching Ingress labels are copied to Gateway infrastructure labels")
 	flag.StringVar(&cfg.AnnotationsByClass, "annotations-by-class", "",
 		"Semicolon-separated list of ingressClassPattern:key=value pairs for Gateway infrastructure annotations "+
 			"(e.g., '*private*:k=v,k2=v2;*:k3=v3').")
+	flag.StringVar(&cfg.AnnotationsByGateway, "annotations-by-gateway", "",
+		"Semicolon-separated list of gatewayPattern:key=value pairs for Gateway infrastructure annotations "+
+			"(e.g., 'internal-*:k=v,k2=v2;public:k3=v3'). Gateway is a glob; the first match wins. "+
+			"Annotations dropped from the list are removed from the Gateways again.")
 	flag.StringVar(&cfg.IngressClassSnippetsFilters, "ingress-class-snippets-filter", "",
 		"Comma-separated list of pattern:snippetsFilterName entries. "+
 			"If ingress class matches the glob, the SnippetsFilter is copied from the Gateway namespace and attached.")
@@ -408,24 +788,213 @@ func parseOperatorConfig() (operatorConfig, zap.Options, error) {
 	flag.StringVar(&cfg.IngressAnnotationSnippetsRemove, "ingress-annotation-snippets-remove", "",
 		"Semicolon-separated list of key=value:filter1,filter2 entries. "+
 			"If annotation value matches glob, remove SnippetsFilter(s).")
+	flag.BoolVar(&cfg.DisableSnippets, "disable-snippets", false,
+		"If true, never create or attach SnippetsFilters. Ingress behavior that needs them is listed in the "+
+			"ingress-doperator.fiction.si/snippets-lost annotation and such Ingresses are not disabled or removed.")
+	flag.BoolVar(&cfg.AllowLossy, "allow-lossy", false,
+		"If true, disable or remove Ingresses even when --disable-snippets or the data plane provider drops some "+
+			"of their behavior.")
+	flag.StringVar(&cfg.DataPlaneProvider, "data-plane-provider", controller.DataPlaneProviderAuto,
+		"Which implementation-specific filters and policies to synthesize: auto (detect from the GatewayClass "+
+			"controllerName) or one of "+strings.Join(controller.DataPlaneProviderNames(), ", "))
 	flag.BoolVar(&cfg.ReconcileCachePersist, "reconcile-cache-persist", true,
 		"If false, do not persist the reconcile cache to ConfigMaps.")
 	flag.IntVar(&cfg.ReconcileCacheMaxEntries, "reconcile-cache-max-entries", 0,
 		"Maximum number of entries to keep in reconcile cache (0 = unlimited).")
+	flag.DurationVar(&cfg.ReconcileCacheEmptyShardTTL, "reconcile-cache-empty-shard-ttl", time.Hour,
+		"Delete reconcile cache shard ConfigMaps that have been empty for this long (0 = keep them).")
+	flag.StringVar(&cfg.ReconcileCacheBackend, "reconcile-cache-backend", reconcileCacheBackendConfigMap,
+		"Reconcile cache backend: 'configmap' (in memory, persisted to ConfigMaps) or 'redis' (shared by all "+
+			"replicas and shards, degrades to ConfigMaps while Redis is unavailable)")
+	flag.StringVar(&cfg.ReconcileCacheRedisURL, "reconcile-cache-redis-url", "",
+		"Redis URL for --reconcile-cache-backend=redis: redis://[user:password@]host[:port][/db] (rediss:// for TLS)")
+	flag.StringVar(&cfg.ReconcileCacheRedisKeyPrefix, "reconcile-cache-redis-key-prefix",
+		utils.DefaultRedisReconcileCacheKeyPrefix, "Prefix for reconcile cache keys in Redis")
+	flag.BoolVar(&cfg.ProposeConflictNames, "propose-conflict-names", false,
+		"If true, suggest a free alternative name on Ingresses whose generated resources collide with unmanaged ones")
 	flag.BoolVar(&cfg.ClearIngressStatusOnDisable, "clear-ingress-status-on-disable", true,
 		"If true, clear status.loadBalancer when disabling an Ingress (requires update on ingresses/status).")
+	flag.StringVar(&cfg.DisableStrategyName, "disable-strategy", string(controller.DisableStrategyClassSwap),
+		"How disabled Ingresses are taken away from the legacy controller: 'class-swap' (switch to the disabled "+
+			"IngressClass) or 'host-prefix' (prefix every host with '"+controller.DisabledHostPrefix+"', for "+
+			"controllers whose admission rewrites the class back)")
+	flag.BoolVar(&cfg.IngressStatusFromGateway, "ingress-status-from-gateway", false,
+		"If true, point status.loadBalancer of disabled Ingresses at the addresses of their Gateways "+
+			"(requires update on ingresses/status)")
 	flag.StringVar(&cfg.GatewayAnnotationFilters, "gateway-annotation-filters",
 		controller.DefaultGatewayAnnotationFilters,
 		"Comma-separated list of annotation prefixes to exclude from Gateway resources")
 	flag.StringVar(&cfg.HTTPRouteAnnotationFilters, "httproute-annotation-filters",
 		controller.DefaultHTTPRouteAnnotationFilters,
 		"Comma-separated list of annotation prefixes to exclude from HTTPRoute resources")
+	flag.StringVar(&cfg.OwnedMetadataKeys, "owned-metadata-keys", "",
+		"Comma-separated glob patterns of annotation and label keys the operator owns on generated routes; "+
+			"other keys added by third parties (e.g. policy engines) are kept on update")
 	flag.BoolVar(&cfg.UseIngress2Gateway, "use-ingress2gateway", false,
 		"If true, use the ingress2gateway library for translation (disables hostname/certificate mangling)")
 	flag.StringVar(&cfg.Ingress2GatewayProvider, "ingress2gateway-provider", "ingress-nginx",
 		"Provider to use with ingress2gateway (e.g., ingress-nginx, istio, kong)")
 	flag.StringVar(&cfg.Ingress2GatewayIngressClass, "ingress2gateway-ingress-class", "nginx",
 		"Ingress class name for provider-specific filtering in ingress2gateway")
+	flag.StringVar(&cfg.RegexPathMatch, "regex-path-match", "auto",
+		"How to translate ImplementationSpecific paths on use-regex Ingresses: 'auto' (RegularExpression "+
+			"matches if the GatewayClass supports them), 'enabled' (always), 'disabled' (always PathPrefix)")
+	flag.StringVar(&cfg.ImplementationSpecificPaths, "implementation-specific-paths",
+		string(translator.ImplementationSpecificAuto),
+		"How ImplementationSpecific paths are interpreted: 'auto' (regex on use-regex Ingresses, prefix otherwise), "+
+			"'treat-as-prefix', 'treat-as-regex' or 'skip-with-warning' (not routed, warning on the Ingress)")
+	flag.StringVar(&cfg.ProxySSLTranslation, "proxy-ssl-translation", "auto",
+		"How to translate proxy-ssl-* annotations into BackendTLSPolicies: 'auto' (hold the Ingress back if the "+
+			"GatewayClass cannot express them), 'enabled' (always translate), 'disabled' (ignore)")
+	flag.StringVar(&cfg.CertReplication, "cert-replication", string(controller.CertReplicationReferenceGrant),
+		"How Gateway listeners reach TLS Secrets of Ingress namespaces: 'reference-grant' (reference them in place), "+
+			"'secret-copy' (copy them into the Gateway namespace), 'reflector-annotation' (annotate them for reflector)")
+	flag.BoolVar(&cfg.PauseOnUnhealthyGatewayClass, "pause-on-unhealthy-gatewayclass", true,
+		"If true, pause Ingress post-processing (disable/remove/disable-external-dns) while the target "+
+			"GatewayClass is missing or not Accepted")
+	flag.BoolVar(&cfg.AllowCrossNamespaceHeaders, "allow-cross-namespace-header-configmaps", false,
+		"If true, proxy-set-headers and custom-headers may reference ConfigMaps of other namespaces. Their data "+
+			"ends up in HTTPRoutes readable by the Ingress author")
+	flag.IntVar(&cfg.NamespaceFailureThreshold, "namespace-failure-threshold", 10,
+		"Consecutive reconcile failures in a namespace before it is backed off (0 = disabled)")
+	flag.DurationVar(&cfg.NamespaceFailureCooldown, "namespace-failure-cooldown", 5*time.Minute,
+		"How long a namespace is backed off once --namespace-failure-threshold is reached")
+	flag.DurationVar(&cfg.DriftResyncInterval, "drift-resync-interval", 0,
+		"How often every Ingress is rendered again to repair manual edits of its Gateway and HTTPRoutes "+
+			"(0 = only when the Ingress changes)")
+	flag.DurationVar(&cfg.AnnotationCoverageInterval, "annotation-coverage-interval", 0,
+		"How often to count the Ingresses using each ingress-nginx annotation and whether it is translated "+
+			"natively, via snippet or dropped, reported as the nginx_annotation_usage metric (0 = disabled)")
+	flag.StringVar(&cfg.AnnotationCoverageConfigMap, "annotation-coverage-configmap", "",
+		"Also write the annotation coverage report to this ConfigMap of the Gateway namespace")
+	flag.BoolVar(&cfg.ValidateTLSSecrets, "validate-tls-secrets", false,
+		"Skip Ingresses whose spec.tls Secrets are missing, not of type kubernetes.io/tls, don't parse or don't "+
+			"cover their hosts, instead of synthesizing listeners for them and disabling them")
+	flag.StringVar(&cfg.RBACCheck, "rbac-check", rbacCheckFail,
+		"Check at startup that the operator has every permission its configuration needs: 'fail' (exit with the "+
+			"list of missing permissions), 'warn' (log them and start anyway) or 'off'")
+	flag.BoolVar(&cfg.MigrationAPI, "migration-api", false,
+		"If true, serve the migration state of Ingresses and namespaces as read-only JSON under /migrations/ "+
+			"on the metrics server")
+	flag.StringVar(&cfg.TLSOnlyHosts, "tls-only-hosts", "ignore",
+		"How to handle spec.tls hosts that are not used by any rule: 'ignore' (no listener), "+
+			"'default-backend' (route to spec.defaultBackend, 404 if unset), 'not-found' (always 404)")
+	flag.BoolVar(&cfg.PrioritizeUnmigrated, "prioritize-unmigrated", true,
+		"If true, use a priority queue so that on startup Ingresses that are not yet migrated (or changed "+
+			"since their last reconcile according to the reconcile cache) are processed first")
+	flag.StringVar(&cfg.ListenerAllowedRoutes, "listener-allowed-routes", "",
+		"Comma-separated [gateway/listener=]policy entries controlling allowedRoutes of generated listeners. "+
+			"Policy is 'namespaces' (default, only the Ingress namespaces), 'same', 'all' or 'selector:<requirements>' "+
+			"(&-separated key, !key, key=value, key!=value, key=v1|v2, key!=v1|v2). "+
+			"Gateway and listener are globs; the first match wins.")
+	flag.StringVar(&cfg.GatewayAddresses, "gateway-addresses", "",
+		"Comma-separated gateway=address|address entries pinning spec.addresses of managed Gateways. "+
+			"Address is [type:]value with type IPAddress (default), Hostname or NamedAddress. "+
+			"Gateway is a glob; the first match wins.")
+	flag.StringVar(&cfg.WildcardListenerDomains, "wildcard-listener-domains", "",
+		"Comma-separated domains served by wildcard certificates; <label>.<domain> hostnames share one "+
+			"*.<domain> listener instead of one listener per hostname")
+	flag.BoolVar(&cfg.AttachOnly, "attach-only", false,
+		"If true, never create or modify Gateways: HTTPRoutes attach to the pre-provisioned "+
+			"--gateway-namespace/--gateway-name Gateway, which must exist")
+	flag.StringVar(&cfg.AttachSectionNames, "attach-section-names", "",
+		"Comma-separated listener names of the pre-provisioned Gateway HTTPRoutes attach to (attach-only mode, "+
+			"default: every listener whose hostname matches)")
+	flag.BoolVar(&cfg.EnableGRPCRoutes, "enable-grpc-routes", false,
+		"If true, translate Ingresses with backend-protocol GRPC or GRPCS to GRPCRoutes instead of HTTPRoutes "+
+			"(requires the GRPCRoute CRD)")
+	flag.BoolVar(&cfg.EnableTLSRoutes, "enable-tls-routes", false,
+		"If true, translate Ingresses with ssl-passthrough to TLSRoutes on TLS passthrough listeners instead of "+
+			"HTTPRoutes (requires the TLSRoute CRD)")
+	flag.StringVar(&cfg.TCPServicesConfigMap, "tcp-services-configmap", "",
+		"namespace/name of the ingress-nginx tcp-services ConfigMap to migrate into TCPRoutes and TCP listeners "+
+			"on the shared Gateway (requires the TCPRoute CRD, default: disabled)")
+	flag.StringVar(&cfg.UDPServicesConfigMap, "udp-services-configmap", "",
+		"namespace/name of the ingress-nginx udp-services ConfigMap to migrate into UDPRoutes and UDP listeners "+
+			"on the shared Gateway (requires the UDPRoute CRD, default: disabled)")
+	flag.DurationVar(&cfg.HostnameHandoffWindow, "hostname-handoff-window", 0,
+		"How long a hostname removed from an Ingress stays served while waiting for another Ingress to take it "+
+			"over; it is released once the new HTTPRoute is accepted (0 = release immediately)")
+	flag.BoolVar(&cfg.ListenerRemovalAck, "listener-removal-ack", false,
+		"If true, hostnames removed from an Ingress stay served and are staged in the "+
+			controller.PendingRemovalsAnnotation+" annotation of the Gateway until acknowledged with "+
+			controller.AckRemovalsAnnotation+" or --listener-removal-ack-timeout")
+	flag.DurationVar(&cfg.ListenerRemovalAckTimeout, "listener-removal-ack-timeout", 24*time.Hour,
+		"How long an unacknowledged hostname removal is staged before it is carried out anyway")
+	flag.BoolVar(&cfg.IntentLog, "intent-log", false,
+		"If true, record destructive operations (Ingress/HTTPRoute deletion, disabling, external-dns) in the "+
+			"ingress-doperator-intent-log ConfigMap before carrying them out; unfinished ones are reported at startup")
+	flag.BoolVar(&cfg.IntentLogResume, "intent-log-resume", false,
+		"If true, carry out destructive operations a previous run started but never finished (requires --intent-log)")
+	flag.BoolVar(&cfg.HostnameStates, "hostname-states", false,
+		"If true, record which data plane serves every migrated hostname (ingress-only, dual, gateway-only) in the "+
+			utils.HostnameStatesConfigMapName+" ConfigMap of the Gateway namespace")
+	flag.BoolVar(&cfg.HostnameRenamePlans, "hostname-rename-plans", false,
+		"If true, follow HostnameRenamePlans, which rename a hostname suffix of the generated routes in phases "+
+			"(serve both, cut over, drop the old hostnames) that can be rolled back; needs their CRD")
+	flag.BoolVar(&cfg.NetworkPolicies, "network-policies", false,
+		"If true, create a NetworkPolicy per backend Service of a migrated Ingress admitting traffic from the "+
+			"Gateway pods (--gateway-pod-selector in --gateway-namespace) to the ports it uses")
+	flag.StringVar(&cfg.GatewayPodSelector, "gateway-pod-selector", "",
+		"Label selector of the Gateway data plane pods in --gateway-namespace that --network-policies admits "+
+			"(empty = all pods of the namespace)")
+	flag.BoolVar(&cfg.DryRun, "dry-run", false,
+		"If true, translate Ingresses but send every write with dryRun=All, so nothing is persisted; the writes "+
+			"are reported per Ingress in the "+utils.DryRunReportConfigMapName+" ConfigMap of the Gateway namespace")
+	flag.BoolVar(&cfg.ReadOnly, "read-only", false,
+		"If true, run every watch and translation but send no writes at all, not even Events or leader election; "+
+			"what the operator would do is logged and exported as metrics. Needs read permissions only.")
+	flag.StringVar(&cfg.FanInSources, "fan-in-sources", "",
+		"Comma-separated name=kubeconfig-path entries of remote clusters whose Ingresses are merged into the "+
+			"local Gateways")
+	flag.StringVar(&cfg.FanInHostnamePrefixes, "fan-in-hostname-prefixes", "",
+		"Comma-separated source=label entries; label is added as first label of every hostname of the source")
+	flag.StringVar(&cfg.FanInHostnameRewrites, "fan-in-hostname-rewrites", "",
+		"Comma-separated source=from:to entries replacing --hostname-rewrite-from/to for the source "+
+			"(repeat a source for several rules)")
+	flag.StringVar(&cfg.FanInConflictPolicy, "fan-in-conflict-policy", string(controller.FanInConflictPriority),
+		"Hostnames used by several clusters: 'priority' (local cluster first, then --fan-in-sources order; "+
+			"other Ingresses are held back) or 'merge' (routes of all clusters attach)")
+	flag.StringVar(&cfg.NameTemplate, "name-template", "",
+		"Go template for the base name of generated HTTPRoutes, per-Ingress Gateways and automatic SnippetsFilters "+
+			"(fields: .IngressName, .Namespace, .IngressClass, .HostHash; default: the Ingress name)")
+	flag.StringVar(&cfg.SnippetsFilterNameTemplate, "snippets-filter-name-template", "",
+		"Go template for the name of the SnippetsFilter built from the annotations of an Ingress (fields: those "+
+			"of --name-template, .BaseName for the --name-template result and .IngressHash, a hash of "+
+			"namespace/name; default: automatic-{{.BaseName}}-annotations). Filters under the previous name are "+
+			"deleted once the HTTPRoutes use the new one")
+	flag.BoolVar(&cfg.PairedHTTPListeners, "paired-http-listeners", false,
+		"If true, give every HTTPS listener created from Ingress TLS a port 80 listener that redirects to HTTPS "+
+			"(or serves the routes when ssl-redirect is \"false\"), like ingress-nginx. "+
+			"Overridable per Ingress with the ingress-doperator.fiction.si/paired-http-listener annotation")
+	flag.StringVar(&cfg.TraefikEntryPoints, "traefik-entrypoints", translator.DefaultTraefikEntryPoints,
+		"Comma-separated name=port entries mapping the Traefik entry points of "+
+			"traefik.ingress.kubernetes.io/router.entrypoints to Gateway listener ports")
+	flag.StringVar(&cfg.HAProxyIngressClasses, "haproxy-ingress-classes", "haproxy",
+		"Comma-separated glob patterns of the ingress classes whose haproxy.org/ annotations are translated "+
+			"(empty = none)")
+	flag.StringVar(&cfg.ImpersonateTemplate, "impersonate-template", "",
+		"If set, create, update and delete HTTPRoutes and other resources in Ingress namespaces as this user, "+
+			"e.g. 'system:serviceaccount:{namespace}:doperator', so namespace RBAC applies. "+
+			"Gateway namespace and cluster-scoped writes keep the operator identity")
+	flag.StringVar(&cfg.MigrationPolicy, "migration-policy", "",
+		"Name of the cluster-scoped IngressMigrationPolicy whose namespace selection, gateway strategy, hostname "+
+			"rewrite and post-processing replace the flags at runtime, without a restart (empty = flags only)")
+	flag.StringVar(&cfg.NotifyWebhookURL, "notify-webhook-url", "",
+		"If set, POST a JSON notification to this URL (e.g. a Slack incoming webhook) on the --notify-events")
+	flag.StringVar(&cfg.NotifyEvents, "notify-events", "namespace-migrated,cert-mismatch,ingress-deleted",
+		"Comma-separated events to notify about: namespace-migrated, cert-mismatch, ingress-deleted")
+	flag.StringVar(&cfg.NotifyPayloadTemplate, "notify-payload-template", utils.DefaultNotificationTemplate,
+		"Go template of the notification JSON, with .Event, .Cluster, .Namespace, .Name, .Message, .Time, "+
+			".Text and a json function quoting values")
+	flag.StringVar(&cfg.ProbeResolvers, "probe-resolvers", "",
+		"Comma-separated DNS servers (ip or ip:port) outbound HTTP clients resolve names with (empty = system resolver)")
+	flag.StringVar(&cfg.ProbeProxy, "probe-proxy", "",
+		"HTTP(S) or SOCKS5 proxy URL of outbound HTTP clients (empty = HTTP_PROXY, HTTPS_PROXY and NO_PROXY)")
+	flag.StringVar(&cfg.ProbeCABundle, "probe-ca-bundle", "",
+		"PEM file of CAs outbound HTTP clients trust in addition to the system pool")
+	flag.BoolVar(&cfg.ProbeInsecureSkipVerify, "probe-insecure-skip-verify", false,
+		"If true, outbound HTTP clients do not verify server certificates")
 	flag.StringVar(&cfg.MetricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&cfg.ProbeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -434,6 +1003,10 @@ func parseOperatorConfig() (operatorConfig, zap.Options, error) {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&cfg.SecureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
+	flag.StringVar(&cfg.MetricsNamespace, "metrics-namespace", metrics.DefaultNamespace,
+		"Prefix of the operator metric names")
+	flag.BoolVar(&cfg.LegacyMetrics, "legacy-metrics", true,
+		"Also export every metric under its deprecated "+metrics.LegacyNamespace+"_ name")
 	flag.StringVar(&cfg.WebhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
 	flag.StringVar(&cfg.WebhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
 	flag.StringVar(&cfg.WebhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
@@ -477,10 +1050,278 @@ func parseOperatorConfig() (operatorConfig, zap.Options, error) {
 		return cfg, opts, err
 	}
 
+	if cfg.RolloutPercentage < 0 || cfg.RolloutPercentage > 100 {
+		return cfg, opts, fmt.Errorf("invalid rollout-percentage %d: must be between 0 and 100", cfg.RolloutPercentage)
+	}
+
+	if cfg.ReadOnly && cfg.DryRun {
+		return cfg, opts, fmt.Errorf("--read-only and --dry-run are mutually exclusive")
+	}
+	if cfg.OneGatewayPerIngress && cfg.OneGatewayPerNamespace {
+		return cfg, opts, fmt.Errorf("--one-gateway-per-ingress and --one-gateway-per-namespace are mutually exclusive")
+	}
+	if cfg.AttachOnly && (cfg.OneGatewayPerIngress || cfg.OneGatewayPerNamespace || cfg.PairedHTTPListeners) {
+		return cfg, opts, fmt.Errorf("--attach-only cannot be combined with --one-gateway-per-ingress, " +
+			"--one-gateway-per-namespace or --paired-http-listeners")
+	}
+	if cfg.AllowLossy && !cfg.DisableSnippets && cfg.DataPlaneProvider == controller.DataPlaneProviderNginx {
+		return cfg, opts, fmt.Errorf("--allow-lossy requires --disable-snippets or a data plane provider other than nginx")
+	}
+	if cfg.IntentLogResume && !cfg.IntentLog {
+		return cfg, opts, fmt.Errorf("--intent-log-resume requires --intent-log")
+	}
+	cfg.ParsedTCPServicesConfigMap, err = parseServicesConfigMap(cfg, "tcp-services-configmap",
+		cfg.TCPServicesConfigMap)
+	if err != nil {
+		return cfg, opts, err
+	}
+	cfg.ParsedUDPServicesConfigMap, err = parseServicesConfigMap(cfg, "udp-services-configmap",
+		cfg.UDPServicesConfigMap)
+	if err != nil {
+		return cfg, opts, err
+	}
+	if cfg.AttachSectionNames != "" && !cfg.AttachOnly {
+		return cfg, opts, fmt.Errorf("--attach-section-names requires --attach-only")
+	}
+	if cfg.MaxListenersPerGateway < 0 || cfg.MaxListenersPerGateway > controller.MaxGatewayListeners {
+		return cfg, opts, fmt.Errorf("invalid max-listeners-per-gateway value %d (allowed: 0-%d)",
+			cfg.MaxListenersPerGateway, controller.MaxGatewayListeners)
+	}
+	cfg.GatewayCapacityAction, err = parseGatewayCapacityAction(cfg.GatewayCapacity)
+	if err != nil {
+		return cfg, opts, err
+	}
+	if cfg.MaxGatewayConfigSize != "" {
+		size, err := resource.ParseQuantity(cfg.MaxGatewayConfigSize)
+		if err != nil || size.Sign() < 0 {
+			return cfg, opts, fmt.Errorf("invalid max-gateway-config-size value %q", cfg.MaxGatewayConfigSize)
+		}
+		cfg.MaxGatewayConfigBytes = size.Value()
+	}
+	if cfg.HostnameHandoffWindow < 0 {
+		return cfg, opts, fmt.Errorf("invalid hostname-handoff-window value: must not be negative")
+	}
+	if cfg.ListenerRemovalAck {
+		if cfg.ListenerRemovalAckTimeout <= 0 {
+			return cfg, opts, fmt.Errorf("invalid listener-removal-ack-timeout value: must be positive")
+		}
+		if cfg.AttachOnly {
+			return cfg, opts, fmt.Errorf("--listener-removal-ack cannot be combined with --attach-only")
+		}
+	}
+	if cfg.NamespaceFailureThreshold > 0 && cfg.NamespaceFailureCooldown <= 0 {
+		return cfg, opts, fmt.Errorf("invalid namespace-failure-cooldown value: must be positive")
+	}
+	if cfg.DriftResyncInterval < 0 {
+		return cfg, opts, fmt.Errorf("invalid drift-resync-interval value: must not be negative")
+	}
+	if cfg.AnnotationCoverageInterval < 0 {
+		return cfg, opts, fmt.Errorf("invalid annotation-coverage-interval value: must not be negative")
+	}
+	if cfg.AnnotationCoverageConfigMap != "" {
+		if cfg.AnnotationCoverageInterval == 0 {
+			return cfg, opts, fmt.Errorf("annotation-coverage-configmap requires annotation-coverage-interval")
+		}
+		if errs := validation.IsDNS1123Subdomain(cfg.AnnotationCoverageConfigMap); len(errs) > 0 {
+			return cfg, opts, fmt.Errorf("invalid annotation-coverage-configmap %q: %s",
+				cfg.AnnotationCoverageConfigMap, strings.Join(errs, "; "))
+		}
+	}
+	switch cfg.RBACCheck {
+	case rbacCheckFail, rbacCheckWarn, rbacCheckOff:
+	default:
+		return cfg, opts, fmt.Errorf("invalid rbac-check value %q (allowed: fail, warn, off)", cfg.RBACCheck)
+	}
+	if cfg.MigrationAPI && cfg.MetricsAddr == "0" {
+		return cfg, opts, fmt.Errorf("--migration-api is served on the metrics server, set --metrics-bind-address")
+	}
+
+	cfg.RegexPathMatchMode, err = parseRegexPathMatchMode(cfg.RegexPathMatch)
+	if err != nil {
+		return cfg, opts, err
+	}
+
+	cfg.ProxySSLMode, err = parseProxySSLMode(cfg.ProxySSLTranslation)
+	if err != nil {
+		return cfg, opts, err
+	}
+
+	cfg.OwnershipMode, err = parseOwnershipMode(cfg.Ownership)
+	if err != nil {
+		return cfg, opts, err
+	}
+
+	cfg.BackendConflictPolicy, err = parseBackendConflictPolicy(cfg.BackendConflicts)
+	if err != nil {
+		return cfg, opts, err
+	}
+
+	cfg.DeletionCascadePolicy, err = parseDeletionCascadePolicy(cfg.DeletionCascade)
+	if err != nil {
+		return cfg, opts, err
+	}
+	if cfg.EnableDeletion && cfg.DeletionCascadePolicy == controller.DeletionCascadeAdopt {
+		return cfg, opts, fmt.Errorf("--enable-deletion cannot be combined with --deletion-cascade=adopt")
+	}
+	cfg.ParsedClassChangePolicy, err = parseClassChangePolicy(cfg.ClassChangePolicy)
+	if err != nil {
+		return cfg, opts, err
+	}
+
+	cfg.DisableStrategy, err = controller.NewDisableStrategy(controller.DisableStrategyName(cfg.DisableStrategyName))
+	if err != nil {
+		return cfg, opts, fmt.Errorf("invalid disable-strategy value %q (allowed: class-swap, host-prefix)",
+			cfg.DisableStrategyName)
+	}
+
+	cfg.ParsedDataPlaneProvider, err = controller.LookupDataPlaneProvider(cfg.DataPlaneProvider)
+	if err != nil {
+		return cfg, opts, fmt.Errorf("invalid data-plane-provider value %q (allowed: %s, %s)", cfg.DataPlaneProvider,
+			controller.DataPlaneProviderAuto, strings.Join(controller.DataPlaneProviderNames(), ", "))
+	}
+
+	cfg.CertReplicationMode, err = parseCertReplicationMode(cfg.CertReplication)
+	if err != nil {
+		return cfg, opts, err
+	}
+
+	cfg.TLSOnlyHostsMode, err = translator.ParseTLSOnlyHostsMode(cfg.TLSOnlyHosts)
+	if err != nil {
+		return cfg, opts, err
+	}
+	cfg.ImplementationSpecificPolicy, err = translator.ParseImplementationSpecificPolicy(cfg.ImplementationSpecificPaths)
+	if err != nil {
+		return cfg, opts, err
+	}
+
+	if cfg.ReconcileCacheEmptyShardTTL < 0 {
+		return cfg, opts, fmt.Errorf("invalid reconcile-cache-empty-shard-ttl value: must not be negative")
+	}
+
+	switch cfg.ReconcileCacheBackend {
+	case reconcileCacheBackendConfigMap:
+	case reconcileCacheBackendRedis:
+		if cfg.ReconcileCacheRedisURL == "" {
+			return cfg, opts, fmt.Errorf("--reconcile-cache-redis-url is required for --reconcile-cache-backend=redis")
+		}
+	default:
+		return cfg, opts, fmt.Errorf("invalid reconcile-cache-backend value %q (allowed: configmap, redis)",
+			cfg.ReconcileCacheBackend)
+	}
+
+	cfg.ParsedListenerAllowedRoutes, err = translator.ParseAllowedRoutesPolicies(cfg.ListenerAllowedRoutes)
+	if err != nil {
+		return cfg, opts, fmt.Errorf("invalid listener-allowed-routes value: %w", err)
+	}
+
+	cfg.ParsedGatewayAddresses, err = translator.ParseGatewayAddressRules(cfg.GatewayAddresses)
+	if err != nil {
+		return cfg, opts, fmt.Errorf("invalid gateway-addresses value: %w", err)
+	}
+	if cfg.AttachOnly && len(cfg.ParsedGatewayAddresses) > 0 {
+		return cfg, opts, fmt.Errorf("--gateway-addresses cannot be combined with --attach-only")
+	}
+
+	cfg.ParsedInfraAnnotationsByGateway, err = translator.ParseGatewayInfrastructureAnnotationRules(
+		cfg.AnnotationsByGateway)
+	if err != nil {
+		return cfg, opts, fmt.Errorf("invalid annotations-by-gateway value: %w", err)
+	}
+	if cfg.AttachOnly && len(cfg.ParsedInfraAnnotationsByGateway) > 0 {
+		return cfg, opts, fmt.Errorf("--annotations-by-gateway cannot be combined with --attach-only")
+	}
+
+	cfg.ParsedWildcardListenerDomains, err = translator.ParseWildcardListenerDomains(cfg.WildcardListenerDomains)
+	if err != nil {
+		return cfg, opts, err
+	}
+
+	cfg.ParsedTraefikEntryPoints, err = translator.ParseTraefikEntryPoints(cfg.TraefikEntryPoints)
+	if err != nil {
+		return cfg, opts, err
+	}
+
+	cfg.ParsedHAProxyIngressClasses, err = translator.ParseHAProxyIngressClasses(cfg.HAProxyIngressClasses)
+	if err != nil {
+		return cfg, opts, err
+	}
+
+	cfg.ParsedNameTemplate, err = translator.ParseNameTemplate(cfg.NameTemplate)
+	if err != nil {
+		return cfg, opts, err
+	}
+	cfg.ParsedSnippetsFilterNameTemplate, err = translator.ParseSnippetsFilterNameTemplate(cfg.SnippetsFilterNameTemplate)
+	if err != nil {
+		return cfg, opts, err
+	}
+
+	cfg.ParsedAttachSectionNames, err = translator.ParseAttachSectionNames(cfg.AttachSectionNames)
+	if err != nil {
+		return cfg, opts, err
+	}
+
+	cfg.ParsedFanInSources, err = parseFanInSources(cfg.FanInSources, cfg.FanInHostnamePrefixes,
+		cfg.FanInHostnameRewrites)
+	if err != nil {
+		return cfg, opts, err
+	}
+	switch controller.FanInConflictPolicy(cfg.FanInConflictPolicy) {
+	case controller.FanInConflictPriority, controller.FanInConflictMerge:
+	default:
+		return cfg, opts, fmt.Errorf("invalid fan-in-conflict-policy value %q (allowed: priority, merge)",
+			cfg.FanInConflictPolicy)
+	}
+	if len(cfg.ParsedFanInSources) > 0 && cfg.OneGatewayPerIngress {
+		return cfg, opts, fmt.Errorf("--fan-in-sources cannot be combined with --one-gateway-per-ingress")
+	}
+	if cfg.NotifyWebhookURL != "" {
+		if _, err := url.ParseRequestURI(cfg.NotifyWebhookURL); err != nil {
+			return cfg, opts, fmt.Errorf("invalid notify-webhook-url: %w", err)
+		}
+	}
+	cfg.ParsedNotifyEvents, err = utils.ParseNotificationEvents(cfg.NotifyEvents)
+	if err != nil {
+		return cfg, opts, err
+	}
+	cfg.ParsedProbeConfig, err = parseProbeConfig(cfg)
+	if err != nil {
+		return cfg, opts, err
+	}
+	if cfg.MigrationPolicy != "" {
+		if errs := validation.IsDNS1123Subdomain(cfg.MigrationPolicy); len(errs) > 0 {
+			return cfg, opts, fmt.Errorf("invalid migration-policy %q: %s", cfg.MigrationPolicy, strings.Join(errs, "; "))
+		}
+	}
+
+	if cfg.ImpersonateTemplate != "" {
+		if err := utils.ValidateImpersonateTemplate(cfg.ImpersonateTemplate); err != nil {
+			return cfg, opts, err
+		}
+	}
+
 	cfg.GatewayFilters = splitCSV(cfg.GatewayAnnotationFilters)
 	cfg.HTTPRouteFilters = splitCSV(cfg.HTTPRouteAnnotationFilters)
+	cfg.OwnedMetadataKeyPatterns = utils.ParseCommaSeparatedList(cfg.OwnedMetadataKeys)
+	if err := utils.ValidateOwnedMetadataKeys(cfg.OwnedMetadataKeyPatterns); err != nil {
+		return cfg, opts, err
+	}
 	cfg.IngressClassFilters = utils.ParseCommaSeparatedList(cfg.IngressClassFilter)
 	cfg.IngressClassIgnoreFilters = utils.ParseCommaSeparatedList(cfg.IngressClassIgnoreFilter)
+	if cfg.NamespaceSelector != "" {
+		if cfg.ParsedNamespaceSelector, err = labels.Parse(cfg.NamespaceSelector); err != nil {
+			return cfg, opts, fmt.Errorf("invalid namespace-selector %q: %w", cfg.NamespaceSelector, err)
+		}
+	}
+	if cfg.IngressSelector != "" {
+		if cfg.ParsedIngressSelector, err = labels.Parse(cfg.IngressSelector); err != nil {
+			return cfg, opts, fmt.Errorf("invalid ingress-selector %q: %w", cfg.IngressSelector, err)
+		}
+	}
+	if cfg.GatewayPodSelector != "" {
+		if cfg.ParsedGatewayPodSelector, err = metav1.ParseToLabelSelector(cfg.GatewayPodSelector); err != nil {
+			return cfg, opts, fmt.Errorf("invalid gateway-pod-selector %q: %w", cfg.GatewayPodSelector, err)
+		}
+	}
 	if cfg.IngressPostProcessingMode == controller.IngressPostProcessingModeDisableExternalDNS {
 		cfg.GatewayFilters = appendFilterIfMissing(cfg.GatewayFilters, controller.ExternalDNSIngressHostnameSource)
 		cfg.GatewayFilters = appendFilterIfMissing(cfg.GatewayFilters, controller.ExternalDNSHostnameAnnotation)
@@ -489,10 +1330,18 @@ func parseOperatorConfig() (operatorConfig, zap.Options, error) {
 	}
 	cfg.GatewayAnnotationsMap = parseKeyValueCSV(cfg.GatewayAnnotations)
 	cfg.GatewayInfraAnnotationsMap = parseKeyValueCSV(cfg.GatewayInfraAnnotations)
+	cfg.InfrastructureLabelPrefixes = utils.ParseCommaSeparatedList(cfg.InfraLabelPrefixes)
 	cfg.InfrastructureAnnotationsByClass, err = translator.ParseIngressClassAnnotationsByClass(cfg.AnnotationsByClass)
 	if err != nil {
 		return cfg, opts, fmt.Errorf("invalid annotations-by-class value: %w", err)
 	}
+	cfg.ParsedGatewayClassMapping, err = translator.ParseGatewayClassMapping(cfg.GatewayClassMapping)
+	if err != nil {
+		return cfg, opts, fmt.Errorf("invalid gateway-class-mapping value: %w", err)
+	}
+	if len(cfg.ParsedGatewayClassMapping) > 0 && cfg.AttachOnly {
+		return cfg, opts, fmt.Errorf("--gateway-class-mapping cannot be combined with --attach-only")
+	}
 
 	return cfg, opts, nil
 }
@@ -513,6 +1362,118 @@ func parseIngressPostProcessingMode(value string) (controller.IngressPostProcess
 	}
 }
 
+func parseRegexPathMatchMode(value string) (controller.RegexPathMatchMode, error) {
+	switch value {
+	case "auto":
+		return controller.RegexPathMatchModeAuto, nil
+	case "enabled":
+		return controller.RegexPathMatchModeEnabled, nil
+	case "disabled":
+		return controller.RegexPathMatchModeDisabled, nil
+	default:
+		return controller.RegexPathMatchModeAuto,
+			fmt.Errorf("invalid regex-path-match value %q (allowed: auto, enabled, disabled)", value)
+	}
+}
+
+func parseProxySSLMode(value string) (controller.ProxySSLMode, error) {
+	switch value {
+	case "auto":
+		return controller.ProxySSLModeAuto, nil
+	case "enabled":
+		return controller.ProxySSLModeEnabled, nil
+	case "disabled":
+		return controller.ProxySSLModeDisabled, nil
+	default:
+		return controller.ProxySSLModeAuto,
+			fmt.Errorf("invalid proxy-ssl-translation value %q (allowed: auto, enabled, disabled)", value)
+	}
+}
+
+func parseOwnershipMode(value string) (controller.OwnershipMode, error) {
+	switch mode := controller.OwnershipMode(value); mode {
+	case controller.OwnershipModeReferences, controller.OwnershipModeFinalizer:
+		return mode, nil
+	default:
+		return controller.OwnershipModeReferences,
+			fmt.Errorf("invalid ownership-mode value %q (allowed: references, finalizer)", value)
+	}
+}
+
+func parseClassChangePolicy(value string) (controller.ClassChangePolicy, error) {
+	switch policy := controller.ClassChangePolicy(value); policy {
+	case controller.ClassChangeIgnore, controller.ClassChangeDelete, controller.ClassChangeAdopt:
+		return policy, nil
+	default:
+		return controller.ClassChangeDelete,
+			fmt.Errorf("invalid class-change-policy value %q (allowed: delete, adopt, ignore)", value)
+	}
+}
+
+func parseDeletionCascadePolicy(value string) (controller.DeletionCascadePolicy, error) {
+	switch policy := controller.DeletionCascadePolicy(value); policy {
+	case controller.DeletionCascadeKeep, controller.DeletionCascadeDelete, controller.DeletionCascadeAdopt:
+		return policy, nil
+	default:
+		return controller.DeletionCascadeKeep,
+			fmt.Errorf("invalid deletion-cascade value %q (allowed: keep, delete, adopt)", value)
+	}
+}
+
+func parseBackendConflictPolicy(value string) (controller.BackendConflictPolicy, error) {
+	switch policy := controller.BackendConflictPolicy(value); policy {
+	case controller.BackendConflictWarn, controller.BackendConflictRefuse, controller.BackendConflictOldestWins,
+		controller.BackendConflictNewestWins, controller.BackendConflictMergeWeighted:
+		return policy, nil
+	default:
+		return controller.BackendConflictWarn, fmt.Errorf(
+			"invalid backend-conflict-policy value %q (allowed: warn, refuse, oldest-wins, newest-wins, merge-as-weighted)",
+			value)
+	}
+}
+
+func parseGatewayCapacityAction(value string) (controller.GatewayCapacityAction, error) {
+	switch action := controller.GatewayCapacityAction(value); action {
+	case controller.GatewayCapacityActionOff, controller.GatewayCapacityActionWarn,
+		controller.GatewayCapacityActionDefer:
+		return action, nil
+	default:
+		return controller.GatewayCapacityActionWarn,
+			fmt.Errorf("invalid gateway-capacity-action value %q (allowed: off, warn, defer)", value)
+	}
+}
+
+// parseServicesConfigMap parses the namespace/name of a tcp-services or udp-services ConfigMap,
+// which needs a shared Gateway for its listeners
+func parseServicesConfigMap(cfg operatorConfig, flagName, value string) (types.NamespacedName, error) {
+	if value == "" {
+		return types.NamespacedName{}, nil
+	}
+	if cfg.AttachOnly || cfg.OneGatewayPerIngress || cfg.OneGatewayPerNamespace {
+		return types.NamespacedName{}, fmt.Errorf("--%s requires a shared Gateway and cannot be combined "+
+			"with --attach-only, --one-gateway-per-ingress or --one-gateway-per-namespace", flagName)
+	}
+	namespace, name, ok := strings.Cut(value, "/")
+	if !ok || namespace == "" || name == "" {
+		return types.NamespacedName{}, fmt.Errorf("invalid %s value %q (expected namespace/name)", flagName, value)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+func parseCertReplicationMode(value string) (controller.CertReplicationMode, error) {
+	switch value {
+	case "reference-grant":
+		return controller.CertReplicationReferenceGrant, nil
+	case "secret-copy":
+		return controller.CertReplicationSecretCopy, nil
+	case "reflector-annotation":
+		return controller.CertReplicationReflectorAnnotation, nil
+	default:
+		return controller.CertReplicationReferenceGrant, fmt.Errorf(
+			"invalid cert-replication value %q (allowed: reference-grant, secret-copy, reflector-annotation)", value)
+	}
+}
+
 func buildTLSOptions(enableHTTP2 bool) []func(*tls.Config) {
 	if enableHTTP2 {
 		return nil
@@ -589,6 +1550,43 @@ func buildMetricsServerOptions(
 	return metricsServerOptions
 }
 
+// gateGatewayAPIFeatures switches off the features whose Gateway API kinds are not installed and returns the
+// kinds the operator watches or would watch if they were installed, a change to any of them needs a restart
+func gateGatewayAPIFeatures(gatewayAPI *utils.GatewayAPICapabilities, cfg *operatorConfig) []schema.GroupVersionKind {
+	watches := []schema.GroupVersionKind{utils.GatewayKind, utils.HTTPRouteKind}
+	if missing := gatewayAPI.Missing(watches...); len(missing) > 0 {
+		setupLog.Info("Gateway API CRDs missing, Ingresses are not migrated until they are installed",
+			"missing", strings.Join(missing, ", "))
+	}
+	gate := func(enabled bool, gvk schema.GroupVersionKind, flagName string) bool {
+		if !enabled {
+			return false
+		}
+		watches = append(watches, gvk)
+		if gatewayAPI.Installed(gvk) {
+			return true
+		}
+		setupLog.Info("Gateway API kind not installed, switching off the feature needing it",
+			"kind", gvk.Kind, "version", gvk.Version, "flag", flagName)
+		return false
+	}
+	cfg.EnableGRPCRoutes = gate(cfg.EnableGRPCRoutes, utils.GRPCRouteKind, "--enable-grpc-routes")
+	cfg.EnableTLSRoutes = gate(cfg.EnableTLSRoutes, utils.TLSRouteKind, "--enable-tls-routes")
+	cfg.PauseOnUnhealthyGatewayClass = gate(cfg.PauseOnUnhealthyGatewayClass, utils.GatewayClassKind,
+		"--pause-on-unhealthy-gatewayclass")
+	if !gate(cfg.ParsedTCPServicesConfigMap.Name != "", utils.TCPRouteKind, "--tcp-services-configmap") {
+		cfg.ParsedTCPServicesConfigMap = types.NamespacedName{}
+	}
+	if !gate(cfg.ParsedUDPServicesConfigMap.Name != "", utils.UDPRouteKind, "--udp-services-configmap") {
+		cfg.ParsedUDPServicesConfigMap = types.NamespacedName{}
+	}
+	if !gatewayAPI.Installed(utils.ReferenceGrantKind) {
+		setupLog.Info("ReferenceGrant CRD not installed, cross-namespace references are not granted",
+			"kind", utils.ReferenceGrantKind.Kind, "version", utils.ReferenceGrantKind.Version)
+	}
+	return watches
+}
+
 func ensureGatewayNamespace(ctx context.Context, reader client.Reader, namespace string) error {
 	var ns corev1.Namespace
 	return reader.Get(ctx, client.ObjectKey{Name: namespace}, &ns)
@@ -618,6 +1616,586 @@ func ensureDisabledIngressClass(ctx context.Context, reader client.Reader, c cli
 	return nil
 }
 
+const (
+	reconcileCacheBackendConfigMap = "configmap"
+	reconcileCacheBackendRedis     = "redis"
+)
+
+const (
+	rbacCheckFail = "fail"
+	rbacCheckWarn = "warn"
+	rbacCheckOff  = "off"
+)
+
+// requiredPermissions lists the permissions the operator needs with this configuration
+func requiredPermissions(cfg operatorConfig) []utils.PermissionRequirement {
+	ns := cfg.WatchNamespace
+	requirements := []utils.PermissionRequirement{
+		{Group: "networking.k8s.io", Resource: "ingresses", Namespace: ns,
+			Verbs: []string{"get", "list", "watch", "update", "patch"}, Feature: "Ingress reconciles"},
+		{Resource: "namespaces", Verbs: []string{"get", "list", "watch"}, Feature: "namespace opt-in"},
+		{Resource: "services", Namespace: ns, Verbs: []string{"get", "list", "watch"},
+			Feature: "named port resolution"},
+		{Group: gatewayv1.GroupName, Resource: "gatewayclasses", Verbs: []string{"get", "list", "watch"},
+			Feature: "GatewayClass checks"},
+		{Group: gatewayv1.GroupName, Resource: "httproutes", Namespace: ns,
+			Verbs: []string{"get", "list", "watch", "create", "update", "delete"}, Feature: "HTTPRoutes"},
+		{Group: gatewayv1.GroupName, Resource: "referencegrants", Namespace: ns,
+			Verbs: []string{"get", "list", "watch", "create", "update", "delete"}, Feature: "TLS Secret references"},
+		{Group: "events.k8s.io", Resource: "events", Namespace: ns, Verbs: []string{"create"},
+			Feature: "events on Ingresses"},
+		{Group: "apiextensions.k8s.io", Resource: "customresourcedefinitions", Verbs: []string{"get", "list", "watch"},
+			Feature: "Gateway API detection"},
+	}
+	gatewayVerbs := []string{"get", "list", "watch", "create", "update"}
+	if cfg.AttachOnly {
+		gatewayVerbs = []string{"get", "list", "watch"}
+	}
+	requirements = append(requirements, utils.PermissionRequirement{
+		Group: gatewayv1.GroupName, Resource: "gateways", Namespace: cfg.GatewayNamespace,
+		Verbs: gatewayVerbs, Feature: "Gateways",
+	})
+	if cfg.IngressPostProcessingMode == controller.IngressPostProcessingModeDisable || cfg.MigrationPolicy != "" {
+		requirements = append(requirements, utils.PermissionRequirement{
+			Group: "networking.k8s.io", Resource: "ingressclasses", Verbs: []string{"get", "create"},
+			Feature: "the disabled IngressClass",
+		})
+	}
+	if !cfg.DisableSnippets && (cfg.DataPlaneProvider == controller.DataPlaneProviderNginx ||
+		cfg.DataPlaneProvider == controller.DataPlaneProviderAuto && cfg.GatewayClassName == "nginx") {
+		requirements = append(requirements, utils.PermissionRequirement{
+			Group: "gateway.nginx.org", Resource: "snippetsfilters", Namespace: ns,
+			Verbs: []string{"get", "list", "watch", "create", "update", "delete"}, Feature: "SnippetsFilters",
+		})
+	}
+	if cfg.EnableGRPCRoutes && !cfg.AttachOnly {
+		requirements = append(requirements, utils.PermissionRequirement{
+			Group: gatewayv1.GroupName, Resource: "grpcroutes", Namespace: ns,
+			Verbs: []string{"get", "list", "watch", "create", "update", "delete"}, Feature: "--enable-grpcroutes",
+		})
+	}
+	if cfg.EnableTLSRoutes && !cfg.AttachOnly {
+		requirements = append(requirements, utils.PermissionRequirement{
+			Group: gatewayv1.GroupName, Resource: "tlsroutes", Namespace: ns,
+			Verbs: []string{"get", "list", "watch", "create", "update", "delete"}, Feature: "--enable-tlsroutes",
+		})
+	}
+	if cfg.ReconcileCachePersist || cfg.ReconcileCacheBackend == reconcileCacheBackendConfigMap ||
+		cfg.IntentLog || cfg.HostnameStates || cfg.DryRun {
+		requirements = append(requirements, utils.PermissionRequirement{
+			Resource: "configmaps", Namespace: cfg.GatewayNamespace, Verbs: []string{"get", "create", "update"},
+			Feature: "reconcile cache, intent log, hostname states and dry-run report",
+		})
+	}
+	if cfg.NetworkPolicies {
+		requirements = append(requirements, utils.PermissionRequirement{
+			Group: "networking.k8s.io", Resource: "networkpolicies", Namespace: ns,
+			Verbs: []string{"get", "list", "watch", "create", "update", "delete"}, Feature: "--network-policies",
+		})
+	}
+	if cfg.MigrationPolicy != "" {
+		requirements = append(requirements, utils.PermissionRequirement{
+			Group: "ingress-doperator.fiction.si", Resource: "ingressmigrationpolicies",
+			Verbs: []string{"get", "list", "watch"}, Feature: "--migration-policy",
+		})
+	}
+	if cfg.HostnameRenamePlans {
+		requirements = append(requirements, utils.PermissionRequirement{
+			Group: "ingress-doperator.fiction.si", Resource: "hostnamerenameplans",
+			Verbs: []string{"get", "list", "watch"}, Feature: "--hostname-rename-plans",
+		})
+	}
+	if cfg.ReadOnly {
+		// Writes are never sent
+		readOnly := requirements[:0]
+		for _, requirement := range requirements {
+			requirement.Verbs = slices.DeleteFunc(slices.Clone(requirement.Verbs), func(verb string) bool {
+				return verb != "get" && verb != "list" && verb != "watch"
+			})
+			if len(requirement.Verbs) > 0 {
+				readOnly = append(readOnly, requirement)
+			}
+		}
+		requirements = readOnly
+	}
+	return requirements
+}
+
+// checkPermissions reviews the permissions of the operator with SelfSubjectAccessReviews. Missing ones fail
+// with --rbac-check=fail and are logged otherwise; a review the API server cannot answer does not block.
+func checkPermissions(ctx context.Context, mgr ctrl.Manager, cfg operatorConfig) error {
+	c, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme()})
+	if err != nil {
+		setupLog.Error(err, "unable to check permissions")
+		return nil
+	}
+	missing, err := utils.CheckPermissions(ctx, c, requiredPermissions(cfg))
+	if err != nil {
+		setupLog.Error(err, "unable to check permissions")
+		return nil
+	}
+	if len(missing) == 0 {
+		setupLog.Info("Verified permissions")
+		return nil
+	}
+	report := utils.FormatMissingPermissions(missing)
+	if cfg.RBACCheck == rbacCheckWarn {
+		setupLog.Info("Missing permissions, the features needing them will fail", "missing", report)
+		return nil
+	}
+	return fmt.Errorf("%d permissions are missing (--rbac-check=warn starts anyway):\n%s", len(missing), report)
+}
+
+// newIngressReconciler creates an IngressReconciler for the local cluster from the configuration
+func newIngressReconciler(
+	mgr ctrl.Manager,
+	cfg operatorConfig,
+	reconcileCache utils.ReconcileCache,
+	tenantClient client.Client,
+	intentLog *utils.IntentLog,
+) *controller.IngressReconciler {
+	var grpcRouteManager *utils.GRPCRouteManager
+	if cfg.EnableGRPCRoutes {
+		grpcRouteManager = &utils.GRPCRouteManager{
+			Client:            tenantClient,
+			NameTemplate:      cfg.ParsedNameTemplate,
+			OwnedMetadataKeys: cfg.OwnedMetadataKeyPatterns,
+		}
+	}
+	var tlsRouteManager *utils.TLSRouteManager
+	if cfg.EnableTLSRoutes {
+		tlsRouteManager = &utils.TLSRouteManager{
+			Client:            tenantClient,
+			NameTemplate:      cfg.ParsedNameTemplate,
+			OwnedMetadataKeys: cfg.OwnedMetadataKeyPatterns,
+		}
+	}
+	return &controller.IngressReconciler{
+		Client:                           mgr.GetClient(),
+		Scheme:                           mgr.GetScheme(),
+		Recorder:                         eventRecorder(mgr, cfg),
+		GatewayNamespace:                 cfg.GatewayNamespace,
+		GatewayName:                      cfg.GatewayName,
+		GatewayClassName:                 cfg.GatewayClassName,
+		GatewayClassMapping:              cfg.ParsedGatewayClassMapping,
+		WatchNamespace:                   cfg.WatchNamespace,
+		OneGatewayPerIngress:             cfg.OneGatewayPerIngress,
+		OneGatewayPerNamespace:           cfg.OneGatewayPerNamespace,
+		MaxListenersPerGateway:           cfg.MaxListenersPerGateway,
+		GatewayCapacityAction:            cfg.GatewayCapacityAction,
+		MaxGatewayConfigBytes:            cfg.MaxGatewayConfigBytes,
+		EnableDeletion:                   cfg.EnableDeletion,
+		DeletionCascade:                  cfg.DeletionCascadePolicy,
+		ClassChangePolicy:                cfg.ParsedClassChangePolicy,
+		HostnameRewriteFrom:              cfg.HostnameRewriteFrom,
+		HostnameRewriteTo:                cfg.HostnameRewriteTo,
+		IngressPostProcessingMode:        cfg.IngressPostProcessingMode,
+		RolloutPercentage:                cfg.RolloutPercentage,
+		GatewayAnnotationFilters:         cfg.GatewayFilters,
+		HTTPRouteAnnotationFilters:       cfg.HTTPRouteFilters,
+		DefaultGatewayAnnotations:        cfg.GatewayAnnotationsMap,
+		GatewayInfrastructureAnnotations: cfg.GatewayInfraAnnotationsMap,
+		InfrastructureAnnotationsByClass: cfg.InfrastructureAnnotationsByClass,
+		InfrastructureLabelPrefixes:      cfg.InfrastructureLabelPrefixes,
+		IngressClassFilters:              cfg.IngressClassFilters,
+		IngressClassIgnoreFilters:        cfg.IngressClassIgnoreFilters,
+		NamespaceSelector:                cfg.ParsedNamespaceSelector,
+		IngressSelector:                  cfg.ParsedIngressSelector,
+		IngressClassEmpty:                cfg.IngressClassEmpty,
+		IngressClassSnippetsFilters:      cfg.ParsedClassSnippetsFilters,
+		IngressNameSnippetsFilters:       cfg.ParsedNameSnippetsFilters,
+		IngressAnnotationSnippetsAdd:     cfg.ParsedAnnotationSnippetsAdd,
+		IngressAnnotationSnippetsRemove:  cfg.ParsedAnnotationSnippetsRemove,
+		DisableSnippets:                  cfg.DisableSnippets,
+		AllowLossy:                       cfg.AllowLossy,
+		DataPlaneProvider:                cfg.ParsedDataPlaneProvider,
+		ClearIngressStatusOnDisable:      cfg.ClearIngressStatusOnDisable,
+		DisableStrategy:                  cfg.DisableStrategy,
+		IngressStatusFromGateway:         cfg.IngressStatusFromGateway,
+		ProposeConflictNames:             cfg.ProposeConflictNames,
+		ReconcileCache:                   reconcileCache,
+		IntentLog:                        intentLog,
+		UseIngress2Gateway:               cfg.UseIngress2Gateway,
+		Ingress2GatewayProvider:          cfg.Ingress2GatewayProvider,
+		Ingress2GatewayIngressClass:      cfg.Ingress2GatewayIngressClass,
+		RegexPathMatchMode:               cfg.RegexPathMatchMode,
+		ProxySSLMode:                     cfg.ProxySSLMode,
+		OwnershipMode:                    cfg.OwnershipMode,
+		BackendConflictPolicy:            cfg.BackendConflictPolicy,
+		CertReplication:                  cfg.CertReplicationMode,
+		HostnameHandoffWindow:            cfg.HostnameHandoffWindow,
+		ListenerRemovalAck:               cfg.ListenerRemovalAck,
+		ListenerRemovalAckTimeout:        cfg.ListenerRemovalAckTimeout,
+		APIReader:                        mgr.GetAPIReader(),
+		TenantClient:                     tenantClient,
+		PauseOnUnhealthyGatewayClass:     cfg.PauseOnUnhealthyGatewayClass,
+		AllowCrossNamespaceHeaders:       cfg.AllowCrossNamespaceHeaders,
+		TLSOnlyHosts:                     cfg.TLSOnlyHostsMode,
+		ImplementationSpecificPaths:      cfg.ImplementationSpecificPolicy,
+		PrioritizeUnmigrated:             cfg.PrioritizeUnmigrated,
+		ListenerAllowedRoutes:            cfg.ParsedListenerAllowedRoutes,
+		GatewayAddresses:                 cfg.ParsedGatewayAddresses,
+		InfraAnnotationsByGateway:        cfg.ParsedInfraAnnotationsByGateway,
+		WildcardListenerDomains:          cfg.ParsedWildcardListenerDomains,
+		PairedHTTPListeners:              cfg.PairedHTTPListeners,
+		TraefikEntryPoints:               cfg.ParsedTraefikEntryPoints,
+		HAProxyIngressClasses:            cfg.ParsedHAProxyIngressClasses,
+		NameTemplate:                     cfg.ParsedNameTemplate,
+		SnippetsFilterNameTemplate:       cfg.ParsedSnippetsFilterNameTemplate,
+		AttachOnly:                       cfg.AttachOnly,
+		AttachSectionNames:               cfg.ParsedAttachSectionNames,
+		NetworkPolicies:                  cfg.NetworkPolicies,
+		GatewayPodSelector:               cfg.ParsedGatewayPodSelector,
+		DriftResyncInterval:              cfg.DriftResyncInterval,
+		AnnotationCoverageInterval:       cfg.AnnotationCoverageInterval,
+		AnnotationCoverageConfigMap:      cfg.AnnotationCoverageConfigMap,
+		ValidateTLSSecrets:               cfg.ValidateTLSSecrets,
+		ReadOnly:                         cfg.ReadOnly,
+		NamespaceCircuitBreaker: controller.NewNamespaceCircuitBreaker(
+			cfg.NamespaceFailureThreshold,
+			cfg.NamespaceFailureCooldown,
+		),
+		HTTPRouteManager: &utils.HTTPRouteManager{
+			Client:            tenantClient,
+			NameTemplate:      cfg.ParsedNameTemplate,
+			OwnedMetadataKeys: cfg.OwnedMetadataKeyPatterns,
+		},
+		GRPCRouteManager: grpcRouteManager,
+		TLSRouteManager:  tlsRouteManager,
+	}
+}
+
+// fanInSourceConfig is a parsed --fan-in-sources entry with its hostname transformation
+type fanInSourceConfig struct {
+	Name           string
+	Kubeconfig     string
+	HostnamePrefix string
+	RewriteFrom    []string
+	RewriteTo      []string
+}
+
+// parseFanInSources parses --fan-in-sources, --fan-in-hostname-prefixes and --fan-in-hostname-rewrites
+func parseFanInSources(sources, prefixes, rewrites string) ([]fanInSourceConfig, error) {
+	var out []fanInSourceConfig
+	index := make(map[string]int)
+	for _, entry := range splitCSV(sources) {
+		name, kubeconfig, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		name, kubeconfig = strings.TrimSpace(name), strings.TrimSpace(kubeconfig)
+		if !ok || name == "" || kubeconfig == "" {
+			return nil, fmt.Errorf("invalid fan-in-sources entry %q, expected name=kubeconfig-path", entry)
+		}
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid fan-in source name %q: %s", name, strings.Join(errs, "; "))
+		}
+		if _, exists := index[name]; exists {
+			return nil, fmt.Errorf("duplicate fan-in source %q", name)
+		}
+		index[name] = len(out)
+		out = append(out, fanInSourceConfig{Name: name, Kubeconfig: kubeconfig})
+	}
+
+	for entry, value := range parseKeyValueCSV(prefixes) {
+		i, ok := index[entry]
+		if !ok {
+			return nil, fmt.Errorf("fan-in-hostname-prefixes references unknown source %q", entry)
+		}
+		if errs := validation.IsDNS1123Label(value); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid hostname prefix %q for source %q: %s", value, entry, strings.Join(errs, "; "))
+		}
+		out[i].HostnamePrefix = value
+	}
+
+	for _, entry := range splitCSV(rewrites) {
+		name, rule, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		from, to, ok := strings.Cut(rule, ":")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid fan-in-hostname-rewrites entry %q, expected source=from:to", entry)
+		}
+		i, known := index[strings.TrimSpace(name)]
+		if !known {
+			return nil, fmt.Errorf("fan-in-hostname-rewrites references unknown source %q", name)
+		}
+		out[i].RewriteFrom = append(out[i].RewriteFrom, from)
+		out[i].RewriteTo = append(out[i].RewriteTo, to)
+	}
+	return out, nil
+}
+
+// newFanIn connects to the fan-in source clusters, nil without --fan-in-sources
+func newFanIn(ctx context.Context, mgr ctrl.Manager, cfg operatorConfig) (*controller.FanIn, error) {
+	if len(cfg.ParsedFanInSources) == 0 {
+		return nil, nil
+	}
+	sources := make([]*controller.FanInSource, 0, len(cfg.ParsedFanInSources))
+	for _, sourceCfg := range cfg.ParsedFanInSources {
+		restConfig, err := clientcmd.BuildConfigFromFlags("", sourceCfg.Kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load kubeconfig of fan-in source %q: %w", sourceCfg.Name, err)
+		}
+		sourceCluster, err := cluster.New(restConfig, func(o *cluster.Options) {
+			o.Scheme = mgr.GetScheme()
+			if cfg.DryRun || cfg.ReadOnly {
+				o.NewClient = newClientFunc(cfg.DryRun, cfg.ReadOnly)
+			}
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client for fan-in source %q: %w", sourceCfg.Name, err)
+		}
+		if err := mgr.Add(sourceCluster); err != nil {
+			return nil, err
+		}
+		if cfg.IngressPostProcessingMode == controller.IngressPostProcessingModeDisable || cfg.MigrationPolicy != "" {
+			if err := ensureDisabledIngressClass(ctx, sourceCluster.GetAPIReader(), sourceCluster.GetClient()); err != nil {
+				return nil, fmt.Errorf("failed to ensure disabled IngressClass in fan-in source %q: %w", sourceCfg.Name, err)
+			}
+		}
+		sources = append(sources, &controller.FanInSource{
+			Name:                sourceCfg.Name,
+			Cluster:             sourceCluster,
+			HostnamePrefix:      sourceCfg.HostnamePrefix,
+			HostnameRewriteFrom: strings.Join(sourceCfg.RewriteFrom, ","),
+			HostnameRewriteTo:   strings.Join(sourceCfg.RewriteTo, ","),
+		})
+		setupLog.Info("Merging Ingresses of fan-in source cluster", "source", sourceCfg.Name,
+			"host", restConfig.Host, "hostnamePrefix", sourceCfg.HostnamePrefix)
+	}
+	return controller.NewFanIn(controller.FanInConflictPolicy(cfg.FanInConflictPolicy), sources), nil
+}
+
+// setupFanInReconcilers starts an IngressReconciler per fan-in source. Generated resources are named
+// <source>-<name> so that Ingresses with the same name in several clusters don't collide.
+func setupFanInReconcilers(
+	mgr ctrl.Manager,
+	cfg operatorConfig,
+	fanIn *controller.FanIn,
+	reconcileCache utils.ReconcileCache,
+	tenantClient client.Client,
+	intentLog *utils.IntentLog,
+	migrationPolicy *controller.MigrationPolicy,
+	hostnameRenames *controller.HostnameRenames,
+) ([]*controller.IngressReconciler, error) {
+	if fanIn == nil {
+		return nil, nil
+	}
+	baseTemplate := cfg.NameTemplate
+	if baseTemplate == "" {
+		baseTemplate = "{{.IngressName}}"
+	}
+	reconcilers := make([]*controller.IngressReconciler, 0, len(fanIn.Sources))
+	for _, source := range fanIn.Sources {
+		nameTemplate, err := translator.ParseNameTemplate(source.Name + "-" + baseTemplate)
+		if err != nil {
+			return nil, err
+		}
+		r := newIngressReconciler(mgr, cfg, reconcileCache, tenantClient, intentLog)
+		reconcilers = append(reconcilers, r)
+		r.Client = controller.NewSourceClusterClient(mgr.GetClient(), source.Cluster.GetClient())
+		r.Recorder = eventRecorder(source.Cluster, cfg)
+		r.FanIn = fanIn
+		r.SourceCluster = source
+		r.NameTemplate = nameTemplate
+		r.MigrationPolicy = migrationPolicy
+		r.HostnameRenames = hostnameRenames
+		r.HTTPRouteManager.NameTemplate = nameTemplate
+		r.HTTPRouteManager.SourceCluster = source.Name
+		if r.GRPCRouteManager != nil {
+			r.GRPCRouteManager.NameTemplate = nameTemplate
+			r.GRPCRouteManager.SourceCluster = source.Name
+		}
+		if r.TLSRouteManager != nil {
+			r.TLSRouteManager.NameTemplate = nameTemplate
+			r.TLSRouteManager.SourceCluster = source.Name
+		}
+		if err := r.SetupWithManager(mgr); err != nil {
+			return nil, fmt.Errorf("fan-in source %q: %w", source.Name, err)
+		}
+	}
+	return reconcilers, nil
+}
+
+// newClientFunc returns the client constructor of the manager: every call is timed and, with --dry-run,
+// every write is sent with dryRun=All, with --read-only not sent at all
+func newClientFunc(dryRun, readOnly bool) client.NewClientFunc {
+	if !dryRun && !readOnly {
+		return metrics.NewInstrumentedClient
+	}
+	return func(config *rest.Config, options client.Options) (client.Client, error) {
+		c, err := metrics.NewInstrumentedClient(config, options)
+		if err != nil {
+			return nil, err
+		}
+		if readOnly {
+			return utils.NewReadOnlyClient(c), nil
+		}
+		return utils.NewDryRunClient(c), nil
+	}
+}
+
+// eventRecorder returns the event recorder of the operator, which only logs events with --read-only
+func eventRecorder(provider recorder.Provider, cfg operatorConfig) events.EventRecorder {
+	if cfg.ReadOnly {
+		return utils.NewReadOnlyEventRecorder(ctrl.Log.WithName("events"))
+	}
+	return provider.GetEventRecorder("ingress-doperator")
+}
+
+// newMigrationPolicy returns the runtime settings of --migration-policy, nil unless it is set. The flags
+// apply until the IngressMigrationPolicy exists.
+func newMigrationPolicy(cfg operatorConfig) *controller.MigrationPolicy {
+	if cfg.MigrationPolicy == "" {
+		return nil
+	}
+	policy := controller.NewMigrationPolicy(cfg.MigrationPolicy, controller.MigrationSettings{
+		OneGatewayPerIngress:      cfg.OneGatewayPerIngress,
+		OneGatewayPerNamespace:    cfg.OneGatewayPerNamespace,
+		HostnameRewriteFrom:       cfg.HostnameRewriteFrom,
+		HostnameRewriteTo:         cfg.HostnameRewriteTo,
+		IngressPostProcessingMode: cfg.IngressPostProcessingMode,
+		RolloutPercentage:         cfg.RolloutPercentage,
+	})
+	// The same combinations parseOperatorConfig rejects for the flags
+	var shared []string
+	if cfg.AttachOnly {
+		shared = append(shared, "--attach-only")
+	}
+	if cfg.PairedHTTPListeners {
+		shared = append(shared, "--paired-http-listeners")
+	}
+	if cfg.TCPServicesConfigMap != "" {
+		shared = append(shared, "--tcp-services-configmap")
+	}
+	if cfg.UDPServicesConfigMap != "" {
+		shared = append(shared, "--udp-services-configmap")
+	}
+	policy.SharedGatewayOnly = strings.Join(shared, ", ")
+	if len(cfg.ParsedFanInSources) > 0 {
+		policy.NoGatewayPerIngress = "--fan-in-sources"
+	}
+	return policy
+}
+
+// newDryRunReport returns the report of writes left out by --dry-run, nil unless it is set. The report is
+// the only write that persists in dry-run mode, so it gets a client of its own.
+func newDryRunReport(mgr ctrl.Manager, cfg operatorConfig) (*utils.DryRunReport, error) {
+	if !cfg.DryRun {
+		return nil, nil
+	}
+	c, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+	if err != nil {
+		return nil, err
+	}
+	setupLog.Info("Dry run, writes are validated by the API server but not persisted",
+		"report", cfg.GatewayNamespace+"/"+utils.DryRunReportConfigMapName)
+	return utils.NewDryRunReport(c, mgr.GetAPIReader(), cfg.GatewayNamespace, utils.DryRunReportConfigMapName), nil
+}
+
+// parseProbeConfig validates the resolvers, proxy and CA bundle outbound HTTP clients (the notifier) use
+func parseProbeConfig(cfg operatorConfig) (probe.Config, error) {
+	resolvers, err := probe.ParseResolvers(cfg.ProbeResolvers)
+	if err != nil {
+		return probe.Config{}, fmt.Errorf("invalid probe-resolvers: %w", err)
+	}
+	if err := probe.ParseProxy(cfg.ProbeProxy); err != nil {
+		return probe.Config{}, fmt.Errorf("invalid probe-proxy: %w", err)
+	}
+	probeConfig := probe.Config{
+		Resolvers:          resolvers,
+		Proxy:              cfg.ProbeProxy,
+		CABundle:           cfg.ProbeCABundle,
+		InsecureSkipVerify: cfg.ProbeInsecureSkipVerify,
+	}
+	// Reads the CA bundle, so a missing or empty file fails at startup
+	if _, err := probeConfig.TLSConfig(); err != nil {
+		return probe.Config{}, fmt.Errorf("invalid probe-ca-bundle: %w", err)
+	}
+	return probeConfig, nil
+}
+
+// newNotifier returns the webhook notifier, nil unless --notify-webhook-url is set
+func newNotifier(mgr ctrl.Manager, cfg operatorConfig) (*utils.Notifier, error) {
+	if cfg.NotifyWebhookURL == "" {
+		return nil, nil
+	}
+	if cfg.DryRun || cfg.ReadOnly {
+		// Nothing is deleted or handed over for real
+		setupLog.Info("Not sending notifications in dry run or read-only mode")
+		return nil, nil
+	}
+	// A webhook endpoint may redirect, unlike hostname probes the notifier follows it
+	probeConfig := cfg.ParsedProbeConfig
+	probeConfig.FollowRedirects = true
+	httpClient, err := probeConfig.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	notifier, err := utils.NewNotifier(cfg.NotifyWebhookURL, cfg.ParsedNotifyEvents, cfg.NotifyPayloadTemplate,
+		httpClient)
+	if err != nil {
+		return nil, err
+	}
+	if err := mgr.Add(notifier); err != nil {
+		return nil, err
+	}
+	setupLog.Info("Sending notifications", "events", cfg.NotifyEvents)
+	return notifier, nil
+}
+
+// newIntentLog returns the write-ahead log of destructive operations, nil unless --intent-log is set
+func newIntentLog(mgr ctrl.Manager, cfg operatorConfig) *utils.IntentLog {
+	if !cfg.IntentLog {
+		return nil
+	}
+	setupLog.Info("Recording destructive operations in the intent log",
+		"configMap", cfg.GatewayNamespace+"/"+utils.IntentLogConfigMapName, "resume", cfg.IntentLogResume)
+	return utils.NewIntentLog(mgr.GetClient(), mgr.GetAPIReader(), cfg.GatewayNamespace, utils.IntentLogConfigMapName)
+}
+
+// newHostnameStates returns the per-hostname serving states, nil unless --hostname-states is set
+func newHostnameStates(mgr ctrl.Manager, cfg operatorConfig) *utils.HostnameStates {
+	if !cfg.HostnameStates {
+		return nil
+	}
+	if cfg.DryRun || cfg.ReadOnly {
+		// Nothing is served by the Gateway for real
+		setupLog.Info("Not recording hostname serving states in dry run or read-only mode")
+		return nil
+	}
+	setupLog.Info("Recording hostname serving states",
+		"configMap", cfg.GatewayNamespace+"/"+utils.HostnameStatesConfigMapName)
+	return utils.NewHostnameStates(mgr.GetClient(), mgr.GetAPIReader(), cfg.GatewayNamespace,
+		utils.HostnameStatesConfigMapName)
+}
+
+func newReconcileCache(ctx context.Context, mgr ctrl.Manager, cfg operatorConfig) (utils.ReconcileCache, error) {
+	var entries map[string]utils.ReconcileCacheEntry
+	if cfg.ReconcileCachePersist {
+		entries = loadReconcileCache(ctx, mgr.GetAPIReader(), cfg.GatewayNamespace)
+	}
+	configMapCache := utils.NewConfigMapReconcileCache(
+		mgr.GetClient(),
+		cfg.GatewayNamespace,
+		utils.ReconcileCacheConfigMapBaseName,
+		utils.ReconcileCacheShardCount,
+		cfg.ReconcileCachePersist,
+		cfg.ReconcileCacheMaxEntries,
+		cfg.ReconcileCacheEmptyShardTTL,
+		entries,
+	)
+	if cfg.ReconcileCachePersist {
+		// Periodically drops expired entries and empty shard ConfigMaps
+		if err := mgr.Add(configMapCache); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.ReconcileCacheBackend != reconcileCacheBackendRedis {
+		return configMapCache, nil
+	}
+	setupLog.Info("Using Redis reconcile cache", "keyPrefix", cfg.ReconcileCacheRedisKeyPrefix)
+	return utils.NewRedisReconcileCache(cfg.ReconcileCacheRedisURL, cfg.ReconcileCacheRedisKeyPrefix, configMapCache)
+}
+
 func loadReconcileCache(
 	ctx context.Context,
 	reader client.Reader,
@@ -0,0 +1,277 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// plannedWrite is a write --dry-run left out
+type plannedWrite struct {
+	Verb    string
+	Kind    string
+	Object  string
+	Changes []utils.FieldChange
+}
+
+// ingressPlan is what the reenabler would do for one Ingress, nil Ingress for the writes of no Ingress
+type ingressPlan struct {
+	ingress *networkingv1.Ingress
+	writes  []plannedWrite
+}
+
+type plannedKey struct {
+	gvk       schema.GroupVersionKind
+	namespace string
+	name      string
+}
+
+// planClient is the client of --dry-run. It sends no writes but records them, with the fields an update
+// changes, for the Ingress being processed. Reads see the writes made so far, so later steps (e.g. deleting
+// Gateways whose routes are gone) plan the same as they would run.
+type planClient struct {
+	client.Client
+	plans   []*ingressPlan
+	current *ingressPlan
+	updated map[plannedKey]client.Object
+	deleted map[plannedKey]bool
+}
+
+func newPlanClient(c client.Client) *planClient {
+	return &planClient{
+		Client:  c,
+		updated: map[plannedKey]client.Object{},
+		deleted: map[plannedKey]bool{},
+	}
+}
+
+// begin attributes the following writes to ingress
+func (c *planClient) begin(ingress *networkingv1.Ingress) {
+	c.current = &ingressPlan{ingress: ingress}
+	c.plans = append(c.plans, c.current)
+}
+
+func (c *planClient) key(obj runtime.Object) (plannedKey, bool) {
+	object, ok := obj.(client.Object)
+	if !ok {
+		return plannedKey{}, false
+	}
+	gvk, err := c.GroupVersionKindFor(obj)
+	if err != nil {
+		return plannedKey{}, false
+	}
+	return plannedKey{gvk: gvk, namespace: object.GetNamespace(), name: object.GetName()}, true
+}
+
+func (c *planClient) record(verb string, obj client.Object, changes []utils.FieldChange) {
+	if c.current == nil {
+		c.begin(nil)
+	}
+	write := plannedWrite{Verb: verb, Kind: "unknown", Object: client.ObjectKeyFromObject(obj).String(), Changes: changes}
+	if key, ok := c.key(obj); ok {
+		write.Kind = key.gvk.Kind
+	}
+	c.current.writes = append(c.current.writes, write)
+}
+
+// recordApply records a server-side apply, whose configuration does not tell the object
+func (c *planClient) recordApply(verb string) {
+	if c.current == nil {
+		c.begin(nil)
+	}
+	c.current.writes = append(c.current.writes, plannedWrite{Verb: verb, Kind: "unknown"})
+}
+
+func (c *planClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if planned, ok := c.key(obj); ok {
+		planned.namespace, planned.name = key.Namespace, key.Name
+		if c.deleted[planned] {
+			return apierrors.NewNotFound(schema.GroupResource{Group: planned.gvk.Group, Resource: planned.gvk.Kind},
+				key.Name)
+		}
+		if updated, ok := c.updated[planned]; ok && reflect.TypeOf(updated) == reflect.TypeOf(obj) {
+			reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(updated.DeepCopyObject()).Elem())
+			return nil
+		}
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *planClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.Client.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	kept := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		key, ok := c.key(item)
+		if ok && c.deleted[key] {
+			continue
+		}
+		if updated, found := c.updated[key]; ok && found && reflect.TypeOf(updated) == reflect.TypeOf(item) {
+			item = updated.DeepCopyObject()
+		}
+		kept = append(kept, item)
+	}
+	return meta.SetList(list, kept)
+}
+
+func (c *planClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	c.record("create", obj, nil)
+	return nil
+}
+
+func (c *planClient) Update(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	var changes []utils.FieldChange
+	if key, ok := c.key(obj); ok {
+		if current, err := c.Scheme().New(key.gvk); err == nil {
+			if currentObj, ok := current.(client.Object); ok &&
+				c.Get(ctx, client.ObjectKeyFromObject(obj), currentObj) == nil {
+				changes, _ = utils.DiffObjects(currentObj, obj)
+			}
+		}
+		c.updated[key] = obj.DeepCopyObject().(client.Object)
+	}
+	c.record("update", obj, changes)
+	return nil
+}
+
+func (c *planClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	c.record("patch", obj, nil)
+	return nil
+}
+
+func (c *planClient) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	if key, ok := c.key(obj); ok {
+		c.deleted[key] = true
+	}
+	c.record("delete", obj, nil)
+	return nil
+}
+
+func (c *planClient) DeleteAllOf(_ context.Context, obj client.Object, _ ...client.DeleteAllOfOption) error {
+	c.record("deletecollection", obj, nil)
+	return nil
+}
+
+func (c *planClient) Apply(_ context.Context, _ runtime.ApplyConfiguration, _ ...client.ApplyOption) error {
+	c.recordApply("apply")
+	return nil
+}
+
+func (c *planClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+// SubResource reads through, writes are recorded like those of the object itself
+func (c *planClient) SubResource(subResource string) client.SubResourceClient {
+	return &planSubResourceClient{SubResourceClient: c.Client.SubResource(subResource), plan: c, subResource: subResource}
+}
+
+type planSubResourceClient struct {
+	client.SubResourceClient
+	plan        *planClient
+	subResource string
+}
+
+func (c *planSubResourceClient) Create(
+	_ context.Context,
+	obj client.Object,
+	_ client.Object,
+	_ ...client.SubResourceCreateOption,
+) error {
+	c.plan.record("create "+c.subResource+" of", obj, nil)
+	return nil
+}
+
+func (c *planSubResourceClient) Update(
+	_ context.Context,
+	obj client.Object,
+	_ ...client.SubResourceUpdateOption,
+) error {
+	c.plan.record("update "+c.subResource+" of", obj, nil)
+	return nil
+}
+
+func (c *planSubResourceClient) Patch(
+	_ context.Context,
+	obj client.Object,
+	_ client.Patch,
+	_ ...client.SubResourcePatchOption,
+) error {
+	c.plan.record("patch "+c.subResource+" of", obj, nil)
+	return nil
+}
+
+func (c *planSubResourceClient) Apply(
+	_ context.Context,
+	_ runtime.ApplyConfiguration,
+	_ ...client.SubResourceApplyOption,
+) error {
+	c.plan.recordApply("apply " + c.subResource)
+	return nil
+}
+
+// print writes the plan per Ingress, e.g.
+//
+//	default/app:
+//	  update Ingress default/app
+//	    spec.ingressClassName: "ingress-doperator-disabled" -> "nginx"
+//	  delete HTTPRoute default/app
+func (c *planClient) print(w io.Writer) {
+	for _, plan := range c.plans {
+		if len(plan.writes) == 0 {
+			continue
+		}
+		if plan.ingress == nil {
+			_, _ = fmt.Fprintln(w, "(no Ingress):")
+		} else {
+			_, _ = fmt.Fprintf(w, "%s/%s:\n", plan.ingress.Namespace, plan.ingress.Name)
+		}
+		for _, write := range plan.writes {
+			_, _ = fmt.Fprintf(w, "  %s %s %s\n", write.Verb, write.Kind, write.Object)
+			for _, change := range write.Changes {
+				_, _ = fmt.Fprintf(w, "    %s: %s -> %s\n", change.Path, planValue(change.Old), planValue(change.New))
+			}
+		}
+	}
+}
+
+func planValue(value interface{}) string {
+	if value == nil {
+		return "(unset)"
+	}
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -18,22 +18,27 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"go.uber.org/zap/zapcore"
 	networkingv1 "k8s.io/api/networking/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -41,8 +46,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"github.com/fiksn/ingress-doperator/internal/controller"
+	"github.com/fiksn/ingress-doperator/internal/translator"
 	"github.com/fiksn/ingress-doperator/internal/utils"
 )
 
@@ -51,6 +58,119 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 )
 
+// Exit codes, so pipelines can branch on the result
+const (
+	exitSuccess        = 0
+	exitError          = 1
+	exitPartialFailure = 2
+	exitNothingMatched = 3
+	exitIneligible     = 4
+)
+
+// Reason codes of the failures manifest
+const (
+	failureReasonIneligible    = "Ineligible"
+	failureReasonPreDeleteHook = "PreDeleteHookFailed"
+	failureReasonForbidden     = "Forbidden"
+	failureReasonConflict      = "Conflict"
+	failureReasonNotFound      = "NotFound"
+	failureReasonTimeout       = "Timeout"
+	failureReasonError         = "Error"
+)
+
+// Actions of the failures manifest
+const (
+	failureActionRestore              = "restore"
+	failureActionDelete               = "delete"
+	failureActionRemoveServicesRoutes = "remove-services-routes"
+	failureActionRun                  = "run"
+)
+
+// reenablerFailure is one entry of the failures manifest
+type reenablerFailure struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Action is one of the failureAction constants, run when the whole run failed
+	Action  string `json:"action"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// reenablerReport is written to --failures-manifest
+type reenablerReport struct {
+	ExitCode int                `json:"exitCode"`
+	Matched  int                `json:"matched"`
+	Failures []reenablerFailure `json:"failures"`
+}
+
+// reasonError carries the reason code of a failure that API errors cannot tell
+type reasonError struct {
+	reason string
+	err    error
+}
+
+func (e *reasonError) Error() string {
+	return e.err.Error()
+}
+
+func (e *reasonError) Unwrap() error {
+	return e.err
+}
+
+func failureReason(err error) string {
+	var withReason *reasonError
+	switch {
+	case errors.As(err, &withReason):
+		return withReason.reason
+	case apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err):
+		return failureReasonForbidden
+	case apierrors.IsConflict(err):
+		return failureReasonConflict
+	case apierrors.IsNotFound(err):
+		return failureReasonNotFound
+	case apierrors.IsTimeout(err) || apierrors.IsServerTimeout(err) || errors.Is(err, context.DeadlineExceeded):
+		return failureReasonTimeout
+	}
+	return failureReasonError
+}
+
+func newFailure(ingress *networkingv1.Ingress, action string, err error) reenablerFailure {
+	failure := reenablerFailure{Action: action, Reason: failureReason(err), Message: err.Error()}
+	if ingress != nil {
+		failure.Namespace = ingress.Namespace
+		failure.Name = ingress.Name
+	}
+	return failure
+}
+
+// exitCode is 2 when anything failed, 4 when the only failures are refused deletions and 3 when no
+// Ingress matched and there was nothing else to do
+func (r *reenablerReport) exitCode(otherWork bool) int {
+	if len(r.Failures) == 0 {
+		if r.Matched == 0 && !otherWork {
+			return exitNothingMatched
+		}
+		return exitSuccess
+	}
+	for _, failure := range r.Failures {
+		if failure.Reason != failureReasonIneligible {
+			return exitPartialFailure
+		}
+	}
+	return exitIneligible
+}
+
+func writeFailuresManifest(path string, report *reenablerReport) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
 type trackedBool struct {
 	value bool
 	set   bool
@@ -75,12 +195,15 @@ func (b *trackedBool) IsBoolFlag() bool { return true }
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(gatewayv1.Install(scheme))
+	utilruntime.Must(gatewayv1alpha2.Install(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 }
 
 func main() {
 	var namespace string
 	var verbosity int
 	var removeDerivedResources bool
+	var removeServicesRoutes bool
 	var restore trackedBool
 	var restoreClass trackedBool
 	var restoreExternalDNS trackedBool
@@ -88,13 +211,25 @@ func main() {
 	var preventFurtherReconciliation bool
 	var markIgnoreIngress bool
 	var ingressNamePattern string
+	var labelSelectorRaw string
+	var fieldSelectorRaw string
+	var nameTemplateRaw string
+	var preDeleteHookTimeout time.Duration
+	var failuresManifest string
+	var dryRun bool
 
 	flag.CommandLine.SetOutput(os.Stderr)
 	flag.StringVar(&namespace, "namespace", "", "If set, only process Ingresses in this namespace")
 	flag.StringVar(&ingressNamePattern, "ingress-name", "",
 		"If set, only process Ingresses whose name matches any of the glob patterns (comma-separated, e.g., 'api-*,web-?')")
+	flag.StringVar(&labelSelectorRaw, "selector", "",
+		"If set, only process Ingresses matching this label selector (e.g., 'team=shop,app in (web,api)')")
+	flag.StringVar(&fieldSelectorRaw, "field-selector", "",
+		"If set, only process Ingresses matching this field selector (e.g., 'metadata.name!=legacy')")
 	flag.BoolVar(&removeDerivedResources, "remove-derived-resources", false,
 		"If true, remove managed HTTPRoutes and automatic SnippetsFilters derived from the Ingress")
+	flag.BoolVar(&removeServicesRoutes, "remove-services-routes", false,
+		"If true, remove TCPRoutes/UDPRoutes generated from tcp-services/udp-services ConfigMaps and their listeners")
 	restore.value = true
 	flag.Var(&restore, "restore",
 		"If true, restore both ingress class and external-dns annotations")
@@ -108,6 +243,15 @@ func main() {
 		"If true, mark restored Ingresses as disabled to stop future reconciles")
 	flag.BoolVar(&markIgnoreIngress, "mark-ignore-ingress", false,
 		"If true, add ingress-doperator.fiction.si/ignore-ingress=true to restored Ingresses")
+	flag.StringVar(&nameTemplateRaw, "name-template", "",
+		"Go template the operator named generated resources with (must match the operator --name-template)")
+	flag.DurationVar(&preDeleteHookTimeout, "pre-delete-hook-timeout", 10*time.Minute,
+		"How long --dangerously-delete-ingresses waits for an Ingress pre-delete hook Job to complete")
+	flag.StringVar(&failuresManifest, "failures-manifest", "failures.json",
+		"Where to write the JSON list of Ingresses that could not be restored or deleted (empty disables it)")
+	flag.BoolVar(&dryRun, "dry-run", false,
+		"If true, change nothing and print per Ingress which annotations would be restored, which class would be "+
+			"set and which derived resources would be removed")
 	flag.IntVar(&verbosity, "v", 0, "Log verbosity (0 = info, higher = more verbose)")
 	opts := zap.Options{
 		Development: true,
@@ -148,10 +292,35 @@ func main() {
 		os.Exit(1)
 	}
 
+	if removeServicesRoutes && (labelSelectorRaw != "" || fieldSelectorRaw != "") {
+		_, _ = fmt.Fprintln(os.Stderr,
+			"Invalid flag combination: --remove-services-routes cannot be combined with --selector or --field-selector")
+		setupLog.Error(fmt.Errorf("invalid flag combination"),
+			"--remove-services-routes cannot be combined with --selector or --field-selector")
+		os.Exit(1)
+	}
+
 	if restoreClass.set || restoreExternalDNS.set {
 		restore.value = false
 	}
 
+	labelSelector, err := labels.Parse(labelSelectorRaw)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid --selector: %v\n", err)
+		os.Exit(1)
+	}
+	fieldSelector, err := fields.ParseSelector(fieldSelectorRaw)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid --field-selector: %v\n", err)
+		os.Exit(1)
+	}
+
+	nameTemplate, err := translator.ParseNameTemplate(nameTemplateRaw)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid --name-template: %v\n", err)
+		os.Exit(1)
+	}
+
 	if restore.value {
 		restoreClass.value = true
 		restoreExternalDNS.value = true
@@ -164,22 +333,45 @@ func main() {
 		os.Exit(1)
 	}
 
+	var plan *planClient
+	if dryRun {
+		plan = newPlanClient(cli)
+		cli = plan
+	}
+
 	ctx := context.Background()
-	if err := runReenabler(
+	report, err := runReenabler(
 		ctx,
 		cli,
 		namespace,
 		ingressNamePattern,
+		labelSelector,
+		fieldSelector,
 		removeDerivedResources,
+		removeServicesRoutes,
 		restoreClass.value,
 		restoreExternalDNS.value,
 		dangerouslyDeleteIngresses,
 		preventFurtherReconciliation,
 		markIgnoreIngress,
-	); err != nil {
+		preDeleteHookTimeout,
+		nameTemplate,
+	)
+	if err != nil {
 		setupLog.Error(err, "reenabler failed")
-		os.Exit(1)
+		report = &reenablerReport{
+			ExitCode: exitError,
+			Failures: []reenablerFailure{newFailure(nil, failureActionRun, err)},
+		}
 	}
+	if plan != nil {
+		plan.print(os.Stdout)
+	}
+	if err := writeFailuresManifest(failuresManifest, report); err != nil {
+		setupLog.Error(err, "unable to write failures manifest", "path", failuresManifest)
+		os.Exit(exitError)
+	}
+	os.Exit(report.ExitCode)
 }
 
 func runReenabler(
@@ -187,13 +379,18 @@ func runReenabler(
 	cli client.Client,
 	namespace string,
 	ingressNamePattern string,
+	labelSelector labels.Selector,
+	fieldSelector fields.Selector,
 	removeDerivedResources bool,
+	removeServicesRoutes bool,
 	restoreClass bool,
 	restoreExternalDNS bool,
 	dangerouslyDeleteIngresses bool,
 	preventFurtherReconciliation bool,
 	markIgnoreIngress bool,
-) error {
+	preDeleteHookTimeout time.Duration,
+	nameTemplate *translator.NameTemplate,
+) (*reenablerReport, error) {
 	opts := reenablerOptions{
 		removeDerivedResources:       removeDerivedResources,
 		restoreClass:                 restoreClass,
@@ -201,38 +398,72 @@ func runReenabler(
 		dangerouslyDeleteIngresses:   dangerouslyDeleteIngresses,
 		preventFurtherReconciliation: preventFurtherReconciliation,
 		markIgnoreIngress:            markIgnoreIngress,
+		preDeleteHookTimeout:         preDeleteHookTimeout,
 	}
 
-	ingresses, err := listIngresses(ctx, cli, namespace, ingressNamePattern)
+	ingresses, err := listIngresses(ctx, cli, namespace, ingressNamePattern, labelSelector, fieldSelector)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	report := &reenablerReport{Matched: len(ingresses), Failures: []reenablerFailure{}}
+	if len(ingresses) == 0 {
+		setupLog.Info("No Ingress matched",
+			"namespace", namespace,
+			"ingress-name", ingressNamePattern,
+			"selector", labelSelector.String(),
+			"field-selector", fieldSelector.String())
 	}
 
-	manager := utils.HTTPRouteManager{Client: cli}
-	var errCount int
-	var lastErr error
+	manager := utils.HTTPRouteManager{Client: cli, NameTemplate: nameTemplate}
 
 	if opts.dangerouslyDeleteIngresses {
-		if err := preflightDelete(ctx, cli, &manager, ingresses); err != nil {
-			return err
+		refused, err := preflightDelete(ctx, cli, &manager, ingresses)
+		if err != nil {
+			return nil, err
+		}
+		if len(refused) > 0 {
+			report.Failures = refused
+			report.ExitCode = report.exitCode(removeServicesRoutes)
+			return report, nil
 		}
 	}
 
+	plan, dryRun := cli.(*planClient)
+	opts.dryRun = dryRun
 	for i := range ingresses {
 		ingress := &ingresses[i]
+		if dryRun {
+			plan.begin(ingress)
+		}
 		if err := processIngress(ctx, cli, &manager, ingress, opts); err != nil {
 			setupLog.Error(err, "failed to process ingress",
 				"namespace", ingress.Namespace,
 				"name", ingress.Name)
-			lastErr = err
-			errCount++
+			action := failureActionRestore
+			if opts.dangerouslyDeleteIngresses && shouldDeleteIngress(ingress) {
+				action = failureActionDelete
+			}
+			report.Failures = append(report.Failures, newFailure(ingress, action, err))
+		}
+	}
+
+	if removeServicesRoutes {
+		if dryRun {
+			plan.begin(nil)
+		}
+		if err := removeManagedServicesRoutes(ctx, cli, namespace); err != nil {
+			setupLog.Error(err, "failed to remove routes generated from services ConfigMaps")
+			failure := newFailure(nil, failureActionRemoveServicesRoutes, err)
+			failure.Namespace = namespace
+			report.Failures = append(report.Failures, failure)
 		}
 	}
 
-	if errCount > 0 {
-		return fmt.Errorf("reenabler completed with %d errors (last: %w)", errCount, lastErr)
+	if len(report.Failures) > 0 {
+		setupLog.Info("reenabler completed with failures", "failures", len(report.Failures))
 	}
-	return nil
+	report.ExitCode = report.exitCode(removeServicesRoutes)
+	return report, nil
 }
 
 type reenablerOptions struct {
@@ -242,22 +473,31 @@ type reenablerOptions struct {
 	dangerouslyDeleteIngresses   bool
 	preventFurtherReconciliation bool
 	markIgnoreIngress            bool
+	preDeleteHookTimeout         time.Duration
+	// dryRun is set when the client only plans writes (--dry-run)
+	dryRun bool
 }
 
 func listIngresses(
 	ctx context.Context,
 	cli client.Client,
 	namespace, namePattern string,
+	labelSelector labels.Selector,
+	fieldSelector fields.Selector,
 ) ([]networkingv1.Ingress, error) {
 	list := &networkingv1.IngressList{}
+	var listOpts []client.ListOption
+	if !labelSelector.Empty() {
+		listOpts = append(listOpts, client.MatchingLabelsSelector{Selector: labelSelector})
+	}
+	if !fieldSelector.Empty() {
+		listOpts = append(listOpts, client.MatchingFieldsSelector{Selector: fieldSelector})
+	}
 	if namespace != "" {
-		if err := cli.List(ctx, list, client.InNamespace(namespace)); err != nil {
-			return nil, err
-		}
-	} else {
-		if err := cli.List(ctx, list); err != nil {
-			return nil, err
-		}
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	}
+	if err := cli.List(ctx, list, listOpts...); err != nil {
+		return nil, err
 	}
 	if namePattern == "" {
 		return list.Items, nil
@@ -290,23 +530,30 @@ func filterIngressesByName(ingresses []networkingv1.Ingress, pattern string) ([]
 	return matches, nil
 }
 
+// preflightDelete checks every Ingress before anything is deleted and returns the ones that are not eligible
 func preflightDelete(
 	ctx context.Context,
 	cli client.Client,
 	manager *utils.HTTPRouteManager,
 	ingresses []networkingv1.Ingress,
-) error {
+) ([]reenablerFailure, error) {
+	refused := make([]reenablerFailure, 0)
 	for i := range ingresses {
 		ingress := &ingresses[i]
 		ok, reason, err := checkDeleteEligibility(ctx, cli, manager, ingress)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if !ok {
-			return fmt.Errorf("refusing to delete ingress %s/%s: %s", ingress.Namespace, ingress.Name, reason)
+			setupLog.Info("Refusing to delete ingress",
+				"namespace", ingress.Namespace,
+				"name", ingress.Name,
+				"reason", reason)
+			refused = append(refused, newFailure(ingress, failureActionDelete,
+				&reasonError{reason: failureReasonIneligible, err: errors.New(reason)}))
 		}
 	}
-	return nil
+	return refused, nil
 }
 
 func processIngress(
@@ -332,7 +579,7 @@ func processIngress(
 		}
 	}
 	if opts.dangerouslyDeleteIngresses && shouldDeleteIngress(ingress) {
-		return deleteIngressIfEligible(ctx, cli, manager, ingress)
+		return deleteIngressIfEligible(ctx, cli, manager, ingress, opts.preDeleteHookTimeout, opts.dryRun)
 	}
 	if !shouldRestoreIngress(ingress, disabled, opts.restoreExternalDNS) {
 		return nil
@@ -354,7 +601,13 @@ func processIngress(
 		if err := removeManagedHTTPRoutes(ctx, manager, ingress); err != nil {
 			return err
 		}
-		if err := removeAutomaticSnippetsFilter(ctx, cli, ingress); err != nil {
+		if err := removeManagedGRPCRoutes(ctx, manager, ingress); err != nil {
+			return err
+		}
+		if err := removeManagedTLSRoutes(ctx, manager, ingress); err != nil {
+			return err
+		}
+		if err := removeAutomaticSnippetsFilter(ctx, cli, manager.NameTemplate, ingress); err != nil {
 			return err
 		}
 		if err := removeManagedGatewaysIfEmpty(ctx, cli, ingress); err != nil {
@@ -417,17 +670,26 @@ func deleteIngressIfEligible(
 	cli client.Client,
 	manager *utils.HTTPRouteManager,
 	ingress *networkingv1.Ingress,
+	preDeleteHookTimeout time.Duration,
+	dryRun bool,
 ) error {
 	ok, reason, err := checkDeleteEligibility(ctx, cli, manager, ingress)
 	if err != nil {
 		return err
 	}
 	if !ok {
-		setupLog.Info("Skipping ingress deletion due to failed checks",
-			"namespace", ingress.Namespace,
-			"name", ingress.Name,
-			"reason", reason)
-		return nil
+		return &reasonError{reason: failureReasonIneligible, err: errors.New(reason)}
+	}
+	if dryRun {
+		// The hook Job would never complete, only its creation is planned
+		if err := utils.RunPreDeleteHook(ctx, cli, ingress); err != nil && !errors.Is(err, utils.ErrPreDeleteHookPending) {
+			return err
+		}
+	} else if err := utils.WaitForPreDeleteHook(ctx, cli, ingress, preDeleteHookTimeout); err != nil {
+		return &reasonError{
+			reason: failureReasonPreDeleteHook,
+			err:    fmt.Errorf("not deleting ingress %s/%s: %w", ingress.Namespace, ingress.Name, err),
+		}
 	}
 	orphan := metav1.DeletePropagationOrphan
 	if err := cli.Delete(ctx, ingress, &client.DeleteOptions{PropagationPolicy: &orphan}); err != nil {
@@ -454,23 +716,7 @@ func shouldRestoreIngress(ingress *networkingv1.Ingress, disabled bool, restoreE
 }
 
 func isDisabledIngress(ingress *networkingv1.Ingress) bool {
-	if ingress == nil {
-		return false
-	}
-	if ingress.Annotations == nil {
-		return false
-	}
-	if ingress.Annotations[controller.IngressDisabledAnnotation] == "" {
-		return false
-	}
-	if ingress.Spec.IngressClassName != nil &&
-		*ingress.Spec.IngressClassName == controller.DisabledIngressClassName {
-		return true
-	}
-	if ingress.Annotations[controller.IngressClassAnnotation] == controller.DisabledIngressClassName {
-		return true
-	}
-	return false
+	return controller.HasDisabledMarker(ingress)
 }
 
 func restoreIngressState(
@@ -491,24 +737,9 @@ func restoreIngressState(
 
 		modified := false
 		if restoreClass {
-			originalClassName := annotations[controller.OriginalIngressClassNameAnnotation]
-			originalClassAnnotation := annotations[controller.OriginalIngressClassAnnotation]
-
-			if originalClassName != "" {
-				updated.Spec.IngressClassName = &originalClassName
-			} else {
-				updated.Spec.IngressClassName = nil
-			}
-
-			if originalClassAnnotation != "" {
-				annotations[controller.IngressClassAnnotation] = originalClassAnnotation
-			} else {
-				delete(annotations, controller.IngressClassAnnotation)
-			}
-
-			delete(annotations, controller.IngressDisabledAnnotation)
-			delete(annotations, controller.OriginalIngressClassNameAnnotation)
-			delete(annotations, controller.OriginalIngressClassAnnotation)
+			// Swap the class back or strip the host prefix, whichever disabled the Ingress
+			updated.Annotations = annotations
+			controller.RestoreDisabledIngress(updated)
 			modified = true
 		}
 
@@ -704,7 +935,7 @@ func removeManagedHTTPRoutes(
 	if manager == nil || ingress == nil {
 		return nil
 	}
-	routes, err := manager.GetHTTPRoutesWithPrefix(ctx, ingress.Namespace, ingress.Name)
+	routes, err := manager.GetHTTPRoutesForIngress(ctx, ingress)
 	if err != nil {
 		return err
 	}
@@ -722,6 +953,32 @@ func removeManagedHTTPRoutes(
 	return nil
 }
 
+// removeManagedGRPCRoutes deletes the GRPCRoutes generated for gRPC backends of the Ingress
+func removeManagedGRPCRoutes(
+	ctx context.Context,
+	manager *utils.HTTPRouteManager,
+	ingress *networkingv1.Ingress,
+) error {
+	if manager == nil || ingress == nil {
+		return nil
+	}
+	grpcManager := utils.GRPCRouteManager{Client: manager.Client, NameTemplate: manager.NameTemplate}
+	return grpcManager.ApplyGRPCRoutes(ctx, ingress, nil, nil)
+}
+
+// removeManagedTLSRoutes deletes the TLSRoutes generated for ssl-passthrough hosts of the Ingress
+func removeManagedTLSRoutes(
+	ctx context.Context,
+	manager *utils.HTTPRouteManager,
+	ingress *networkingv1.Ingress,
+) error {
+	if manager == nil || ingress == nil {
+		return nil
+	}
+	tlsManager := utils.TLSRouteManager{Client: manager.Client, NameTemplate: manager.NameTemplate}
+	return tlsManager.ApplyTLSRoutes(ctx, ingress, nil, nil)
+}
+
 func removeManagedGatewaysIfEmpty(ctx context.Context, cli client.Client, ingress *networkingv1.Ingress) error {
 	if ingress == nil {
 		return nil
@@ -742,6 +999,47 @@ func removeManagedGatewaysIfEmpty(ctx context.Context, cli client.Client, ingres
 			parentCounts[key]++
 		}
 	}
+	allGRPCRoutes := &gatewayv1.GRPCRouteList{}
+	if err := cli.List(ctx, allGRPCRoutes); err != nil && !meta.IsNoMatchError(err) {
+		return err
+	}
+	for i := range allGRPCRoutes.Items {
+		route := &allGRPCRoutes.Items[i]
+		for _, parent := range route.Spec.ParentRefs {
+			key, ok := parentRefKey(route.Namespace, parent)
+			if !ok {
+				continue
+			}
+			parentCounts[key]++
+		}
+	}
+	allTLSRoutes := &gatewayv1.TLSRouteList{}
+	if err := cli.List(ctx, allTLSRoutes); err != nil && !meta.IsNoMatchError(err) {
+		return err
+	}
+	for i := range allTLSRoutes.Items {
+		route := &allTLSRoutes.Items[i]
+		for _, parent := range route.Spec.ParentRefs {
+			key, ok := parentRefKey(route.Namespace, parent)
+			if !ok {
+				continue
+			}
+			parentCounts[key]++
+		}
+	}
+	streamRoutes, err := listStreamRoutes(ctx, cli, "")
+	if err != nil {
+		return err
+	}
+	for _, route := range streamRoutes {
+		for _, parent := range streamRouteParentRefs(route) {
+			key, ok := parentRefKey(route.GetNamespace(), parent)
+			if !ok {
+				continue
+			}
+			parentCounts[key]++
+		}
+	}
 
 	gateways := &gatewayv1.GatewayList{}
 	if err := cli.List(ctx, gateways); err != nil {
@@ -776,7 +1074,7 @@ func hasManagedResources(
 	if manager == nil || ingress == nil {
 		return false, false, nil
 	}
-	routes, err := manager.GetHTTPRoutesWithPrefix(ctx, ingress.Namespace, ingress.Name)
+	routes, err := manager.GetHTTPRoutesForIngress(ctx, ingress)
 	if err != nil {
 		return false, false, err
 	}
@@ -788,6 +1086,22 @@ func hasManagedResources(
 			break
 		}
 	}
+	if !hasRoute {
+		grpcManager := utils.GRPCRouteManager{Client: manager.Client, NameTemplate: manager.NameTemplate}
+		grpcRoutes, err := grpcManager.GetGRPCRoutesForIngress(ctx, ingress)
+		if err != nil {
+			return false, false, err
+		}
+		hasRoute = len(grpcRoutes) > 0
+	}
+	if !hasRoute {
+		tlsManager := utils.TLSRouteManager{Client: manager.Client, NameTemplate: manager.NameTemplate}
+		tlsRoutes, err := tlsManager.GetTLSRoutesForIngress(ctx, ingress)
+		if err != nil {
+			return false, false, err
+		}
+		hasRoute = len(tlsRoutes) > 0
+	}
 	if !hasRoute {
 		return false, false, nil
 	}
@@ -815,7 +1129,7 @@ func disableExternalDNSForDerived(
 	if ingress == nil || manager == nil {
 		return nil
 	}
-	routes, err := manager.GetHTTPRoutesWithPrefix(ctx, ingress.Namespace, ingress.Name)
+	routes, err := manager.GetHTTPRoutesForIngress(ctx, ingress)
 	if err != nil {
 		return err
 	}
@@ -974,29 +1288,118 @@ func parentRefKey(defaultNamespace string, ref gatewayv1.ParentReference) (strin
 	return fmt.Sprintf("%s/%s", namespace, ref.Name), true
 }
 
-func removeAutomaticSnippetsFilter(ctx context.Context, cli client.Client, ingress *networkingv1.Ingress) error {
-	if ingress == nil {
-		return nil
+// listStreamRoutes returns the TCPRoutes and UDPRoutes, of one namespace unless namespace is empty.
+// Clusters without the CRDs have none.
+func listStreamRoutes(ctx context.Context, cli client.Client, namespace string) ([]client.Object, error) {
+	var routes []client.Object
+	tcpRoutes := &gatewayv1alpha2.TCPRouteList{}
+	if err := cli.List(ctx, tcpRoutes, client.InNamespace(namespace)); err != nil && !meta.IsNoMatchError(err) {
+		return nil, err
+	}
+	for i := range tcpRoutes.Items {
+		routes = append(routes, &tcpRoutes.Items[i])
 	}
-	filterName := utils.AutomaticSnippetsFilterName(ingress.Name)
-	version, ok, err := utils.GetCRDVersion(ctx, cli, utils.SnippetsFilterCRDName)
-	if err != nil || !ok {
+	udpRoutes := &gatewayv1alpha2.UDPRouteList{}
+	if err := cli.List(ctx, udpRoutes, client.InNamespace(namespace)); err != nil && !meta.IsNoMatchError(err) {
+		return nil, err
+	}
+	for i := range udpRoutes.Items {
+		routes = append(routes, &udpRoutes.Items[i])
+	}
+	return routes, nil
+}
+
+func streamRouteParentRefs(route client.Object) []gatewayv1.ParentReference {
+	switch r := route.(type) {
+	case *gatewayv1alpha2.TCPRoute:
+		return r.Spec.ParentRefs
+	case *gatewayv1alpha2.UDPRoute:
+		return r.Spec.ParentRefs
+	}
+	return nil
+}
+
+// removeManagedServicesRoutes deletes the TCPRoutes and UDPRoutes generated from tcp-services and
+// udp-services ConfigMaps, then removes their listeners from the Gateways managed by us
+func removeManagedServicesRoutes(ctx context.Context, cli client.Client, namespace string) error {
+	routes, err := listStreamRoutes(ctx, cli, namespace)
+	if err != nil {
 		return err
 	}
-	filter := &unstructured.Unstructured{}
-	filter.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   utils.NginxGatewayGroup,
-		Version: version,
-		Kind:    utils.SnippetsFilterKind,
-	})
-	if err := cli.Get(ctx, client.ObjectKey{Namespace: ingress.Namespace, Name: filterName}, filter); err != nil {
-		if apierrors.IsNotFound(err) {
-			return nil
+
+	listeners := map[types.NamespacedName]map[gatewayv1.SectionName]bool{}
+	for _, route := range routes {
+		if !utils.IsManagedByUs(route) || route.GetAnnotations()[translator.ServicesConfigMapAnnotation] == "" {
+			continue
 		}
-		return err
+		for _, parent := range streamRouteParentRefs(route) {
+			if parent.SectionName == nil {
+				continue
+			}
+			gatewayNN := types.NamespacedName{Namespace: route.GetNamespace(), Name: string(parent.Name)}
+			if parent.Namespace != nil {
+				gatewayNN.Namespace = string(*parent.Namespace)
+			}
+			if listeners[gatewayNN] == nil {
+				listeners[gatewayNN] = map[gatewayv1.SectionName]bool{}
+			}
+			listeners[gatewayNN][*parent.SectionName] = true
+		}
+		if err := cli.Delete(ctx, route); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		setupLog.Info("Deleted route generated from services ConfigMap",
+			"kind", fmt.Sprintf("%T", route),
+			"namespace", route.GetNamespace(),
+			"name", route.GetName(),
+			"configMap", route.GetAnnotations()[translator.ServicesConfigMapAnnotation])
 	}
-	if !utils.IsManagedByUs(filter) {
+
+	for gatewayNN, names := range listeners {
+		gateway := &gatewayv1.Gateway{}
+		if err := cli.Get(ctx, gatewayNN, gateway); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !utils.IsManagedByUs(gateway) {
+			continue
+		}
+		remaining := make([]gatewayv1.Listener, 0, len(gateway.Spec.Listeners))
+		for _, listener := range gateway.Spec.Listeners {
+			if translator.IsStreamListener(&listener) && names[listener.Name] {
+				continue
+			}
+			remaining = append(remaining, listener)
+		}
+		if len(remaining) == len(gateway.Spec.Listeners) {
+			continue
+		}
+		gateway.Spec.Listeners = remaining
+		if err := cli.Update(ctx, gateway); err != nil {
+			return err
+		}
+		setupLog.Info("Removed services listeners from Gateway",
+			"namespace", gateway.Namespace,
+			"name", gateway.Name,
+			"listeners", len(names))
+	}
+	return nil
+}
+
+func removeAutomaticSnippetsFilter(
+	ctx context.Context,
+	cli client.Client,
+	nameTemplate *translator.NameTemplate,
+	ingress *networkingv1.Ingress,
+) error {
+	if ingress == nil {
 		return nil
 	}
-	return cli.Delete(ctx, filter)
+	// Whatever --snippets-filter-name-template the operator used, its filters carry the automatic marker
+	legacyName := utils.AutomaticSnippetsFilterName(nameTemplate.Name(ingress))
+	_, err := utils.PruneAutomaticSnippetsFilters(ctx, cli, ingress.Namespace, ingress.Namespace, ingress.Name,
+		legacyName, "")
+	return err
 }
@@ -37,7 +37,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
-	_ "github.com/fiksn/ingress-doperator/internal/metrics" // Import to register metrics
+	"github.com/fiksn/ingress-doperator/internal/metrics"
 	"github.com/fiksn/ingress-doperator/internal/translator"
 	"github.com/fiksn/ingress-doperator/internal/utils"
 	webhookhandler "github.com/fiksn/ingress-doperator/internal/webhook"
@@ -74,10 +74,20 @@ func main() {
 	var ingressClassFilter string
 	var ingressClassIgnoreFilter string
 	var ingressClassEmpty string
+	var listenerAllowedRoutes string
 	var verbosity int
+	var metricsNamespace string
+	var legacyMetrics bool
+	var blockLegacyIngresses bool
+	var normalizeAnnotations bool
+	var defaultTargetGateway bool
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080",
 		"The address the metric endpoint binds to.")
+	flag.StringVar(&metricsNamespace, "metrics-namespace", metrics.DefaultNamespace,
+		"Prefix of the metric names")
+	flag.BoolVar(&legacyMetrics, "legacy-metrics", true,
+		"Also export every metric under its deprecated "+metrics.LegacyNamespace+"_ name")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081",
 		"The address the probe endpoint binds to.")
 	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook server binds to.")
@@ -131,6 +141,19 @@ func main() {
 			"If an ingress class matches this list, it is skipped even if it matches --ingress-class-filter.")
 	flag.StringVar(&ingressClassEmpty, "ingress-class-empty", "none",
 		"Value to use when an Ingress has no class set. This value is matched against class filters.")
+	flag.StringVar(&listenerAllowedRoutes, "listener-allowed-routes", "",
+		"Comma-separated [gateway/listener=]policy entries controlling allowedRoutes of generated listeners "+
+			"(policy: namespaces, same, all or selector:key[=value]).")
+	flag.BoolVar(&blockLegacyIngresses, "block-legacy-ingresses", false,
+		"If true, also serve /validate-v1-ingress-legacy, which denies new Ingresses of a class in namespaces "+
+			"where every Ingress of that class was migrated")
+	flag.BoolVar(&normalizeAnnotations, "normalize-annotations", false,
+		"If true, also serve /mutate-v1-ingress-defaults, which trims and normalizes the ingress-doperator "+
+			"annotations of new Ingresses (booleans become true or false)")
+	flag.BoolVar(&defaultTargetGateway, "default-target-gateway", true,
+		"If true, /mutate-v1-ingress-defaults also fills in the "+translator.TargetGatewayAnnotation+
+			" annotation with the shared Gateway of the ingress class (disable when the operator runs with "+
+			"one Gateway per Ingress or per namespace)")
 	flag.IntVar(&verbosity, "v", 0, "Log verbosity (0 = info, higher = more verbose)")
 
 	opts := zap.Options{
@@ -146,6 +169,11 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if err := metrics.Register(metricsNamespace, legacyMetrics); err != nil {
+		setupLog.Error(err, "unable to register metrics")
+		os.Exit(1)
+	}
+
 	parsedSnippetsFilters, err := utils.ParseIngressClassSnippetsFilters(ingressClassSnippetsFilters)
 	if err != nil {
 		setupLog.Error(err, "Invalid ingress-class-snippets-filter value")
@@ -166,6 +194,11 @@ func main() {
 		setupLog.Error(err, "Invalid ingress-annotation-snippets-remove value")
 		os.Exit(1)
 	}
+	parsedListenerAllowedRoutes, err := translator.ParseAllowedRoutesPolicies(listenerAllowedRoutes)
+	if err != nil {
+		setupLog.Error(err, "Invalid listener-allowed-routes value")
+		os.Exit(1)
+	}
 	ingressClassFilters := utils.ParseCommaSeparatedList(ingressClassFilter)
 	ingressClassIgnoreFilters := utils.ParseCommaSeparatedList(ingressClassIgnoreFilter)
 
@@ -233,6 +266,7 @@ func main() {
 		UseIngress2Gateway:          useIngress2Gateway,
 		Ingress2GatewayProvider:     ingress2GatewayProvider,
 		Ingress2GatewayIngressClass: ingress2GatewayIngressClass,
+		ListenerAllowedRoutes:       parsedListenerAllowedRoutes,
 	}
 	trans := translator.New(translatorConfig)
 
@@ -272,6 +306,36 @@ func main() {
 		os.Exit(1)
 	}
 
+	if blockLegacyIngresses {
+		blocker := &webhookhandler.LegacyIngressBlocker{
+			Client:                    mgr.GetClient(),
+			IngressClassFilters:       ingressClassFilters,
+			IngressClassIgnoreFilters: ingressClassIgnoreFilters,
+			IngressClassEmpty:         ingressClassEmpty,
+		}
+		if err := blocker.InjectDecoder(&decoder); err != nil {
+			setupLog.Error(err, "unable to inject decoder")
+			os.Exit(1)
+		}
+		mgr.GetWebhookServer().Register("/validate-v1-ingress-legacy", &webhook.Admission{Handler: blocker})
+		setupLog.Info("Denying new Ingresses in migrated namespaces", "path", "/validate-v1-ingress-legacy")
+	}
+
+	if normalizeAnnotations {
+		normalizer := &webhookhandler.AnnotationNormalizer{
+			IngressClassEmpty: ingressClassEmpty,
+		}
+		if defaultTargetGateway {
+			normalizer.GatewayName = gatewayName
+		}
+		if err := normalizer.InjectDecoder(&decoder); err != nil {
+			setupLog.Error(err, "unable to inject decoder")
+			os.Exit(1)
+		}
+		mgr.GetWebhookServer().Register("/mutate-v1-ingress-defaults", &webhook.Admission{Handler: normalizer})
+		setupLog.Info("Normalizing annotations of new Ingresses", "path", "/mutate-v1-ingress-defaults")
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -0,0 +1,206 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command benchgate compares two `go test -bench -benchmem` outputs and fails when a benchmark got slower
+// or allocates more than the thresholds allow. Samples of repeated runs (-count) are reduced to their median.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	unitTime   = "ns/op"
+	unitBytes  = "B/op"
+	unitAllocs = "allocs/op"
+)
+
+// gomaxprocsSuffix is appended to benchmark names by `go test`, baselines from machines with another CPU
+// count still compare
+var gomaxprocsSuffix = regexp.MustCompile(`-\d+$`)
+
+// samples holds the values of every run of each benchmark, by benchmark name and unit
+type samples map[string]map[string][]float64
+
+// regression is a metric of a benchmark that exceeded its threshold
+type regression struct {
+	name   string
+	unit   string
+	before float64
+	after  float64
+	delta  float64
+}
+
+func main() {
+	var baselinePath, currentPath string
+	var timeThreshold, allocThreshold float64
+	flag.StringVar(&baselinePath, "baseline", "bench_baseline.txt", "Benchmark output to compare against")
+	flag.StringVar(&currentPath, "current", "bench_output.txt", "Benchmark output to check")
+	flag.Float64Var(&timeThreshold, "time-threshold", 0.20,
+		"Largest allowed relative ns/op increase (0.20 = 20%, negative disables the check)")
+	flag.Float64Var(&allocThreshold, "alloc-threshold", 0.10,
+		"Largest allowed relative B/op and allocs/op increase (negative disables the check)")
+	flag.Parse()
+
+	baseline, err := readSamples(baselinePath)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid --baseline: %v\n", err)
+		os.Exit(2)
+	}
+	current, err := readSamples(currentPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Invalid --current: %v\n", err)
+		os.Exit(2)
+	}
+
+	thresholds := map[string]float64{unitTime: timeThreshold, unitBytes: allocThreshold, unitAllocs: allocThreshold}
+	regressions := compare(os.Stdout, baseline, current, thresholds)
+	if len(regressions) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "\n%d benchmark metrics regressed:\n", len(regressions))
+	for _, r := range regressions {
+		_, _ = fmt.Fprintf(os.Stderr, "  %s %s: %s -> %s (%+.1f%%, allowed %+.1f%%)\n",
+			r.name, r.unit, formatValue(r.before), formatValue(r.after), r.delta*100, thresholds[r.unit]*100)
+	}
+	os.Exit(1)
+}
+
+func readSamples(path string) (samples, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	result, err := parseBenchmarks(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%s: no benchmark results", path)
+	}
+	return result, nil
+}
+
+// parseBenchmarks collects the results of the benchmark lines, e.g.
+// "BenchmarkTranslate/simple-8  4096  286010 ns/op  180515 B/op  975 allocs/op". Other lines are skipped
+func parseBenchmarks(r io.Reader) (samples, error) {
+	result := make(samples)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[1]); err != nil {
+			continue
+		}
+		name := gomaxprocsSuffix.ReplaceAllString(fields[0], "")
+		for i := 2; i+1 < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value %q of %s", fields[i+1], fields[i], name)
+			}
+			if result[name] == nil {
+				result[name] = make(map[string][]float64)
+			}
+			result[name][fields[i+1]] = append(result[name][fields[i+1]], value)
+		}
+	}
+	return result, scanner.Err()
+}
+
+// compare writes a table of the medians of both runs to w and returns the metrics that regressed beyond their
+// threshold. Benchmarks only one of the runs has are listed but never fail the gate
+func compare(w io.Writer, baseline, current samples, thresholds map[string]float64) []regression {
+	names := make([]string, 0, len(current))
+	for name := range current {
+		names = append(names, name)
+	}
+	for name := range baseline {
+		if _, ok := current[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "benchmark\tunit\tbaseline\tcurrent\tdelta\t")
+	var regressions []regression
+	for _, name := range names {
+		for _, unit := range []string{unitTime, unitBytes, unitAllocs} {
+			beforeSamples, hasBefore := baseline[name][unit]
+			afterSamples, hasAfter := current[name][unit]
+			switch {
+			case !hasBefore && !hasAfter:
+				continue
+			case !hasBefore:
+				_, _ = fmt.Fprintf(table, "%s\t%s\t-\t%s\tnew\t\n", name, unit, formatValue(median(afterSamples)))
+				continue
+			case !hasAfter:
+				_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t-\tgone\t\n", name, unit, formatValue(median(beforeSamples)))
+				continue
+			}
+			before, after := median(beforeSamples), median(afterSamples)
+			delta := relativeChange(before, after)
+			mark := ""
+			if threshold := thresholds[unit]; threshold >= 0 && delta > threshold {
+				mark = "  REGRESSION"
+				regressions = append(regressions,
+					regression{name: name, unit: unit, before: before, after: after, delta: delta})
+			}
+			_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%+.1f%%%s\t\n",
+				name, unit, formatValue(before), formatValue(after), delta*100, mark)
+		}
+	}
+	_ = table.Flush()
+	return regressions
+}
+
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// relativeChange is the change from before to after relative to before, a metric growing from zero counts
+// as +100%
+func relativeChange(before, after float64) float64 {
+	if before == 0 {
+		if after == 0 {
+			return 0
+		}
+		return 1
+	}
+	return (after - before) / before
+}
+
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
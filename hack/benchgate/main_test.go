@@ -0,0 +1,85 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+const benchBaseline = `goos: linux
+pkg: github.com/fiksn/ingress-doperator/internal/translator
+BenchmarkTranslate/simple-8     4000   300 ns/op   1000 B/op   10 allocs/op
+BenchmarkTranslate/simple-8     4000   200 ns/op   1000 B/op   10 allocs/op
+BenchmarkTranslate/simple-8     4000   250 ns/op   1000 B/op   10 allocs/op
+BenchmarkTranslate/gone-8       4000   100 ns/op    100 B/op    1 allocs/op
+PASS
+`
+
+func mustParse(t *testing.T, raw string) samples {
+	t.Helper()
+	result, err := parseBenchmarks(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("parseBenchmarks() error = %v", err)
+	}
+	return result
+}
+
+func TestParseBenchmarks(t *testing.T) {
+	result := mustParse(t, benchBaseline)
+	simple := result["BenchmarkTranslate/simple"]
+	if simple == nil {
+		t.Fatalf("GOMAXPROCS suffix not stripped, got %v", result)
+	}
+	if got := len(simple[unitTime]); got != 3 {
+		t.Errorf("got %d ns/op samples, want 3", got)
+	}
+	if got := median(simple[unitTime]); got != 250 {
+		t.Errorf("median ns/op = %v, want 250", got)
+	}
+	if _, err := parseBenchmarks(strings.NewReader("BenchmarkX-8 10 fast ns/op\n")); err == nil {
+		t.Errorf("parseBenchmarks() accepted a non-numeric value")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	thresholds := map[string]float64{unitTime: 0.20, unitBytes: 0.10, unitAllocs: -1}
+	tests := []struct {
+		name    string
+		current string
+		want    []string
+	}{
+		{"within thresholds", "BenchmarkTranslate/simple-4 1 290 ns/op 1099 B/op 50 allocs/op", nil},
+		{"slower", "BenchmarkTranslate/simple-4 1 301 ns/op 1000 B/op 10 allocs/op", []string{unitTime}},
+		{"more bytes", "BenchmarkTranslate/simple-4 1 250 ns/op 1200 B/op 10 allocs/op", []string{unitBytes}},
+		{"new benchmark", "BenchmarkTranslate/other-4 1 9999 ns/op 9999 B/op 99 allocs/op", nil},
+	}
+	baseline := mustParse(t, benchBaseline)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			regressions := compare(io.Discard, baseline, mustParse(t, tt.current), thresholds)
+			var got []string
+			for _, r := range regressions {
+				got = append(got, r.unit)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("regressed units = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+// getGatewayNameForALBGroup returns <gateway>-<group> for an Ingress of an ALB IngressGroup: the AWS Load
+// Balancer Controller serves every Ingress of group.name from one ALB, so they share one Gateway
+func (r *IngressReconciler) getGatewayNameForALBGroup(ingress *networkingv1.Ingress, gatewayName string) string {
+	group, ok, err := translator.ALBGroupName(ingress.Annotations)
+	if err != nil {
+		r.recordWarning(ingress, "ALBGroupNotTranslated", err.Error())
+	}
+	if !ok {
+		return gatewayName
+	}
+	return translator.ALBGroupGatewayName(gatewayName, group)
+}
+
+// reportALBAnnotationsLost records the alb.ingress.kubernetes.io/ annotations that were not translated:
+// an invalid listen-ports, and the health check unless Envoy Gateway serves the route (Gateway API has
+// no active health checks)
+func (r *IngressReconciler) reportALBAnnotationsLost(ingress *networkingv1.Ingress, servedByEnvoyGateway bool) {
+	if _, err := translator.ParseALBListenPorts(ingress.Annotations); err != nil {
+		r.recordWarning(ingress, "ALBListenPortsNotTranslated", err.Error())
+	}
+	if servedByEnvoyGateway {
+		return
+	}
+	if _, ok, _ := translator.ParseALBHealthCheck(ingress.Annotations); ok {
+		r.recordWarning(ingress, "ALBHealthCheckNotTranslated",
+			fmt.Sprintf("%shealthcheck-* annotations were not translated: only the %s data plane provider "+
+				"supports active health checks", translator.ALBAnnotationPrefix, DataPlaneProviderEnvoyGateway))
+	}
+}
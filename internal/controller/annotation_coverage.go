@@ -0,0 +1,191 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// annotationCoverageReportKey is the ConfigMap key holding the annotation coverage report
+const annotationCoverageReportKey = "coverage.json"
+
+// AnnotationCoverageEntry counts the Ingresses using an ingress-nginx annotation that is translated one way
+type AnnotationCoverageEntry struct {
+	Annotation  string                      `json:"annotation"`
+	Translation utils.AnnotationTranslation `json:"translation"`
+	Ingresses   int                         `json:"ingresses"`
+}
+
+// annotationCoverage periodically counts how many Ingresses use each ingress-nginx annotation and whether
+// it is translated natively, via snippet or dropped, so the most used dropped annotations show which
+// translations to add next
+type annotationCoverage struct {
+	reconciler *IngressReconciler
+	interval   time.Duration
+}
+
+// Start implements manager.Runnable
+func (a *annotationCoverage) Start(ctx context.Context) error {
+	a.report(ctx)
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			a.report(ctx)
+		}
+	}
+}
+
+// report recounts the annotations of every Ingress the reconciler handles, replacing the previous counts
+func (a *annotationCoverage) report(ctx context.Context) {
+	r := a.reconciler
+	logger := log.FromContext(ctx)
+
+	list := &networkingv1.IngressList{}
+	opts := []client.ListOption{}
+	if r.WatchNamespace != "" {
+		opts = append(opts, client.InNamespace(r.WatchNamespace))
+	}
+	if err := r.List(ctx, list, opts...); err != nil {
+		logger.Error(err, "failed to list Ingresses for annotation coverage report")
+		return
+	}
+	entries := annotationCoverageEntries(list.Items, r.shouldEnqueueIngressByClass)
+
+	metrics.NginxAnnotationUsage.Reset()
+	for _, entry := range entries {
+		metrics.NginxAnnotationUsage.WithLabelValues(entry.Annotation, string(entry.Translation)).
+			Set(float64(entry.Ingresses))
+	}
+	logger.V(1).Info("Counted ingress-nginx annotation coverage", "annotations", len(entries))
+
+	if r.AnnotationCoverageConfigMap == "" {
+		return
+	}
+	if err := a.writeConfigMap(ctx, entries); err != nil {
+		logger.Error(err, "failed to write annotation coverage report",
+			"namespace", r.GatewayNamespace, "name", r.AnnotationCoverageConfigMap)
+	}
+}
+
+// annotationCoverageEntries counts the ingress-nginx annotations of the Ingresses accepted by include,
+// most used first. A snippet annotation listed in the snippets-lost annotation of an Ingress counts as
+// dropped for it, since snippets are disabled or the data plane has none.
+func annotationCoverageEntries(
+	ingresses []networkingv1.Ingress,
+	include func(*networkingv1.Ingress) bool,
+) []AnnotationCoverageEntry {
+	type usage struct {
+		annotation  string
+		translation utils.AnnotationTranslation
+	}
+	counts := make(map[usage]int)
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		if !include(ingress) {
+			continue
+		}
+		lost := make(map[string]struct{})
+		if value := ingress.Annotations[SnippetsLostAnnotation]; value != "" {
+			for _, key := range strings.Split(value, ",") {
+				lost[key] = struct{}{}
+			}
+		}
+		for key := range ingress.Annotations {
+			suffix, ok := strings.CutPrefix(key, translator.NginxIngressAnnotationPrefix)
+			if !ok || suffix == "" {
+				continue
+			}
+			translation := utils.NginxAnnotationTranslation(suffix)
+			if _, ok := lost[key]; ok && translation == utils.AnnotationTranslationSnippet {
+				translation = utils.AnnotationTranslationDropped
+			}
+			counts[usage{annotation: key, translation: translation}]++
+		}
+	}
+
+	entries := make([]AnnotationCoverageEntry, 0, len(counts))
+	for u, count := range counts {
+		entries = append(entries, AnnotationCoverageEntry{
+			Annotation:  u.annotation,
+			Translation: u.translation,
+			Ingresses:   count,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Ingresses != entries[j].Ingresses {
+			return entries[i].Ingresses > entries[j].Ingresses
+		}
+		if entries[i].Annotation != entries[j].Annotation {
+			return entries[i].Annotation < entries[j].Annotation
+		}
+		return entries[i].Translation < entries[j].Translation
+	})
+	return entries
+}
+
+// writeConfigMap stores the report in the annotation coverage ConfigMap of the Gateway namespace
+func (a *annotationCoverage) writeConfigMap(ctx context.Context, entries []AnnotationCoverageEntry) error {
+	r := a.reconciler
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	desired := map[string]string{
+		annotationCoverageReportKey: string(data),
+		"updatedAt":                 time.Now().UTC().Format(time.RFC3339),
+	}
+
+	cm := &corev1.ConfigMap{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: r.GatewayNamespace, Name: r.AnnotationCoverageConfigMap}, cm)
+	if apierrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      r.AnnotationCoverageConfigMap,
+				Namespace: r.GatewayNamespace,
+			},
+			Data: desired,
+		}
+		return r.Create(ctx, cm)
+	}
+	if err != nil {
+		return err
+	}
+	if cm.Data[annotationCoverageReportKey] == desired[annotationCoverageReportKey] {
+		return nil
+	}
+	cm.Data = desired
+	return r.Update(ctx, cm)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// attachGatewayRequeue is how often an Ingress waits for a missing pre-provisioned Gateway
+const attachGatewayRequeue = time.Minute
+
+// attachGatewayReady checks that the pre-provisioned Gateway exists before HTTPRoutes are attached to it.
+// Configured listeners missing on the Gateway only produce a warning, the platform team may add them later.
+func (r *IngressReconciler) attachGatewayReady(ctx context.Context, ingress *networkingv1.Ingress) bool {
+	logger := log.FromContext(ctx)
+	gateway := &gatewayv1.Gateway{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: r.GatewayNamespace, Name: r.GatewayName}, gateway)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to get pre-provisioned Gateway")
+		}
+		logger.Info("Skipping Ingress, pre-provisioned Gateway not available",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name,
+			"gateway", r.GatewayNamespace+"/"+r.GatewayName)
+		r.recordWarning(ingress, "AttachGatewayNotFound",
+			fmt.Sprintf("Gateway %s/%s to attach HTTPRoutes to is not available", r.GatewayNamespace, r.GatewayName))
+		metrics.IngressReconcileSkipsTotal.WithLabelValues("attach-gateway-missing", ingress.Namespace, ingress.Name).Inc()
+		return false
+	}
+
+	listeners := make(map[gatewayv1.SectionName]bool, len(gateway.Spec.Listeners))
+	for _, listener := range gateway.Spec.Listeners {
+		listeners[listener.Name] = true
+	}
+	var missing []string
+	for _, sectionName := range r.AttachSectionNames {
+		if !listeners[sectionName] {
+			missing = append(missing, string(sectionName))
+		}
+	}
+	if len(missing) > 0 {
+		r.recordWarning(ingress, "AttachListenerNotFound",
+			fmt.Sprintf("Gateway %s/%s has no listener %s, HTTPRoutes will not attach there",
+				r.GatewayNamespace, r.GatewayName, strings.Join(missing, ", ")))
+	}
+	return true
+}
+
+// releaseAttachOnlyHTTPRoute drops the listener cleanup finalizer from HTTPRoutes created before
+// attach-only mode was enabled: there are no listeners of ours to clean up
+func (r *HTTPRouteReconciler) releaseAttachOnlyHTTPRoute(ctx context.Context, httpRoute *gatewayv1.HTTPRoute) error {
+	if !utils.ContainsString(httpRoute.Finalizers, HTTPRouteFinalizerName) {
+		return nil
+	}
+	httpRoute.Finalizers = utils.RemoveString(httpRoute.Finalizers, HTTPRouteFinalizerName)
+	utils.LogUpdateDiff(ctx, r.Client, httpRoute)
+	if err := r.Update(ctx, httpRoute); err != nil {
+		log.FromContext(ctx).Error(err, "failed to remove finalizer")
+		return err
+	}
+	return nil
+}
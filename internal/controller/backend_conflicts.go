@@ -0,0 +1,406 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+)
+
+// BackendConflictPolicy decides what happens to Ingresses routing the same host and path to different backends,
+// which the Gateway would otherwise resolve by picking one of the routes
+type BackendConflictPolicy string
+
+const (
+	// BackendConflictWarn migrates both Ingresses and records a BackendConflict warning on each
+	BackendConflictWarn BackendConflictPolicy = "warn"
+	// BackendConflictRefuse does not migrate either Ingress until the conflict is resolved
+	BackendConflictRefuse BackendConflictPolicy = "refuse"
+	// BackendConflictOldestWins routes a conflicting path only to the backend of the oldest Ingress
+	BackendConflictOldestWins BackendConflictPolicy = "oldest-wins"
+	// BackendConflictNewestWins routes a conflicting path only to the backend of the newest Ingress
+	BackendConflictNewestWins BackendConflictPolicy = "newest-wins"
+	// BackendConflictMergeWeighted splits a conflicting path evenly between the backends of all Ingresses
+	// routing it, Ingresses of other namespaces fall back to oldest-wins as their Services are not referenced
+	BackendConflictMergeWeighted BackendConflictPolicy = "merge-as-weighted"
+)
+
+// resolvesBackendConflicts reports whether the policy changes the routes of conflicting Ingresses
+func (p BackendConflictPolicy) resolvesBackendConflicts() bool {
+	return p == BackendConflictOldestWins || p == BackendConflictNewestWins || p == BackendConflictMergeWeighted
+}
+
+// backendConflict is a host and path two Ingresses route to different backends
+type backendConflict struct {
+	match   string
+	other   types.NamespacedName
+	backend string
+}
+
+func (c backendConflict) String() string {
+	return fmt.Sprintf("%s (%s in %s)", c.match, c.backend, c.other)
+}
+
+// pathMatch identifies a host and path of an Ingress rule
+func pathMatch(host string, path networkingv1.HTTPIngressPath) string {
+	pathType := networkingv1.PathTypeImplementationSpecific
+	if path.PathType != nil {
+		pathType = *path.PathType
+	}
+	if host == "" {
+		host = "*"
+	}
+	return fmt.Sprintf("%s%s (%s)", host, path.Path, pathType)
+}
+
+// ingressPathBackends maps every host and path of an Ingress to its backend
+func ingressPathBackends(ingress *networkingv1.Ingress) map[string]string {
+	backends := make(map[string]string)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			backends[pathMatch(rule.Host, path)] = backendString(ingress.Namespace, path.Backend)
+		}
+	}
+	return backends
+}
+
+// ingressPathsByMatch maps every host and path of an Ingress to its path
+func ingressPathsByMatch(ingress *networkingv1.Ingress) map[string]networkingv1.HTTPIngressPath {
+	paths := make(map[string]networkingv1.HTTPIngressPath)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			paths[pathMatch(rule.Host, path)] = path
+		}
+	}
+	return paths
+}
+
+// ingressOlder reports whether a was created before b, Ingresses created in the same second are ordered by
+// namespace and name so every reconcile picks the same one
+func ingressOlder(a, b *networkingv1.Ingress) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// backendString identifies a backend, Services of different namespaces are different backends
+func backendString(namespace string, backend networkingv1.IngressBackend) string {
+	if backend.Resource != nil {
+		return fmt.Sprintf("%s/%s/%s", namespace, backend.Resource.Kind, backend.Resource.Name)
+	}
+	if backend.Service == nil {
+		return ""
+	}
+	port := backend.Service.Port.Name
+	if port == "" {
+		port = fmt.Sprintf("%d", backend.Service.Port.Number)
+	}
+	return fmt.Sprintf("%s/%s:%s", namespace, backend.Service.Name, port)
+}
+
+// backendConflictPeers lists the other managed Ingresses sharing a host and path with ingress, including
+// Ingresses disabled after their migration
+func (r *IngressReconciler) backendConflictPeers(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+) ([]networkingv1.Ingress, error) {
+	list := &networkingv1.IngressList{}
+	opts := []client.ListOption{}
+	if r.WatchNamespace != "" {
+		opts = append(opts, client.InNamespace(r.WatchNamespace))
+	}
+	if err := r.List(ctx, list, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list Ingresses: %w", err)
+	}
+	own := ingressPathBackends(ingress)
+	peers := make([]networkingv1.Ingress, 0)
+	for i := range list.Items {
+		peer := &list.Items[i]
+		if peer.Namespace == ingress.Namespace && peer.Name == ingress.Name {
+			continue
+		}
+		if !peer.DeletionTimestamp.IsZero() || IngressIgnored(peer) {
+			continue
+		}
+		if ingressDisabled(peer) {
+			// Compare the hosts a disabled Ingress was translated with
+			peer = withOriginalSpec(peer)
+			if !r.shouldEnqueueIngressByClass(peer) {
+				continue
+			}
+		} else if !r.shouldEnqueueIngressByClass(peer) {
+			continue
+		}
+		for match := range ingressPathBackends(peer) {
+			if _, ok := own[match]; ok {
+				peers = append(peers, *peer)
+				break
+			}
+		}
+	}
+	return peers, nil
+}
+
+// findBackendConflicts returns the hosts and paths of ingress another Ingress routes to a different backend
+func (r *IngressReconciler) findBackendConflicts(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+) ([]backendConflict, error) {
+	peers, err := r.backendConflictPeers(ctx, ingress)
+	if err != nil {
+		return nil, err
+	}
+	own := ingressPathBackends(ingress)
+	var conflicts []backendConflict
+	for i := range peers {
+		for match, backend := range ingressPathBackends(&peers[i]) {
+			if ownBackend, ok := own[match]; ok && ownBackend != backend {
+				conflicts = append(conflicts, backendConflict{
+					match:   match,
+					other:   types.NamespacedName{Namespace: peers[i].Namespace, Name: peers[i].Name},
+					backend: backend,
+				})
+			}
+		}
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].String() < conflicts[j].String()
+	})
+	return conflicts, nil
+}
+
+// checkBackendConflicts flags the hosts and paths another Ingress routes elsewhere and reports whether the
+// Ingress must not be migrated because of them
+func (r *IngressReconciler) checkBackendConflicts(ctx context.Context, ingress *networkingv1.Ingress) bool {
+	logger := log.FromContext(ctx)
+	conflicts, err := r.findBackendConflicts(ctx, ingress)
+	if err != nil {
+		// Do not hold the Ingress back on a failed check, it is repeated on the next reconcile
+		logger.Error(err, "failed to check Ingresses routing the same paths to other backends")
+		return false
+	}
+	if len(conflicts) == 0 {
+		return false
+	}
+	descriptions := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		descriptions = append(descriptions, conflict.String())
+	}
+	logger.Info("Other Ingresses route the same host and path to a different backend",
+		"namespace", ingress.Namespace,
+		"name", ingress.Name,
+		"conflicts", strings.Join(descriptions, ", "),
+		"policy", r.BackendConflictPolicy)
+	switch r.BackendConflictPolicy {
+	case BackendConflictRefuse:
+	case BackendConflictOldestWins, BackendConflictNewestWins, BackendConflictMergeWeighted:
+		r.recordWarning(ingress, "BackendConflict",
+			fmt.Sprintf("Other Ingresses route the same paths to different backends, resolved by the %s policy: %s",
+				r.BackendConflictPolicy, strings.Join(descriptions, ", ")))
+		return false
+	default:
+		r.recordWarning(ingress, "BackendConflict",
+			fmt.Sprintf("Other Ingresses route the same paths to different backends, the Gateway serves only one: %s",
+				strings.Join(descriptions, ", ")))
+		return false
+	}
+	r.recordWarning(ingress, "BackendConflict",
+		fmt.Sprintf("Not migrated while other Ingresses route the same paths to different backends: %s",
+			strings.Join(descriptions, ", ")))
+	metrics.IngressReconcileSkipsTotal.WithLabelValues("backend-conflict", ingress.Namespace, ingress.Name).Inc()
+	return true
+}
+
+// resolveBackendConflicts returns the Ingress to translate under the backend conflict policy: a copy without the
+// paths another Ingress wins, or with the backends of other Ingresses added next to the paths to merge. The
+// merged paths are returned so their rules can be folded into weighted ones after translation.
+func (r *IngressReconciler) resolveBackendConflicts(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+) (*networkingv1.Ingress, map[string]bool) {
+	if !r.BackendConflictPolicy.resolvesBackendConflicts() {
+		return ingress, nil
+	}
+	logger := log.FromContext(ctx)
+	peers, err := r.backendConflictPeers(ctx, ingress)
+	if err != nil {
+		logger.Error(err, "failed to resolve Ingresses routing the same paths to other backends")
+		return ingress, nil
+	}
+	own := ingressPathBackends(ingress)
+	lost := make(map[string]bool)
+	added := make(map[string][]networkingv1.HTTPIngressPath)
+	merged := make(map[string]bool)
+	for i := range peers {
+		peer := &peers[i]
+		for match, path := range ingressPathsByMatch(peer) {
+			backend := backendString(peer.Namespace, path.Backend)
+			if ownBackend, ok := own[match]; !ok || ownBackend == backend {
+				continue
+			}
+			if r.BackendConflictPolicy == BackendConflictMergeWeighted &&
+				peer.Namespace == ingress.Namespace && path.Backend.Service != nil {
+				added[match] = append(added[match], path)
+				merged[path.Path] = true
+				continue
+			}
+			peerWins := ingressOlder(peer, ingress)
+			if r.BackendConflictPolicy == BackendConflictNewestWins {
+				peerWins = !peerWins
+			}
+			if peerWins {
+				lost[match] = true
+			}
+		}
+	}
+	if len(lost) == 0 && len(added) == 0 {
+		return ingress, nil
+	}
+
+	resolved := ingress.DeepCopy()
+	rules := make([]networkingv1.IngressRule, 0, len(resolved.Spec.Rules))
+	for _, rule := range resolved.Spec.Rules {
+		if rule.HTTP == nil {
+			rules = append(rules, rule)
+			continue
+		}
+		paths := make([]networkingv1.HTTPIngressPath, 0, len(rule.HTTP.Paths))
+		for _, path := range rule.HTTP.Paths {
+			match := pathMatch(rule.Host, path)
+			if lost[match] {
+				logger.Info("Path is routed by another Ingress under the backend conflict policy",
+					"namespace", ingress.Namespace,
+					"name", ingress.Name,
+					"path", match,
+					"policy", r.BackendConflictPolicy)
+				continue
+			}
+			paths = append(paths, path)
+			paths = append(paths, added[match]...)
+			// Only add the backends of other Ingresses once if the Ingress repeats the path
+			delete(added, match)
+		}
+		// A rule without paths would route its host to nothing, leave the host to the winning Ingress
+		if len(paths) == 0 {
+			continue
+		}
+		rule.HTTP.Paths = paths
+		rules = append(rules, rule)
+	}
+	resolved.Spec.Rules = rules
+	return resolved, merged
+}
+
+// mergeWeightedBackends folds the rules of merged paths with identical matches into a single rule splitting
+// the traffic evenly between their backends
+func mergeWeightedBackends(httpRoute *gatewayv1.HTTPRoute, merged map[string]bool) {
+	if len(merged) == 0 {
+		return
+	}
+	rules := make([]gatewayv1.HTTPRouteRule, 0, len(httpRoute.Spec.Rules))
+	for _, rule := range httpRoute.Spec.Rules {
+		if len(rule.Matches) == 0 || rule.Matches[0].Path == nil || rule.Matches[0].Path.Value == nil ||
+			!merged[*rule.Matches[0].Path.Value] {
+			rules = append(rules, rule)
+			continue
+		}
+		folded := false
+		for i := range rules {
+			if equality.Semantic.DeepEqual(rules[i].Matches, rule.Matches) && len(rules[i].BackendRefs) > 0 {
+				rules[i].BackendRefs = append(rules[i].BackendRefs, rule.BackendRefs...)
+				folded = true
+				break
+			}
+		}
+		if !folded {
+			rules = append(rules, rule)
+		}
+	}
+	weight := int32(1)
+	for i := range rules {
+		if len(rules[i].Matches) == 0 || rules[i].Matches[0].Path == nil || rules[i].Matches[0].Path.Value == nil ||
+			!merged[*rules[i].Matches[0].Path.Value] || len(rules[i].BackendRefs) < 2 {
+			continue
+		}
+		for j := range rules[i].BackendRefs {
+			rules[i].BackendRefs[j].Weight = &weight
+		}
+	}
+	httpRoute.Spec.Rules = rules
+}
+
+// enqueueIngressesWithSharedPaths requeues the Ingresses sharing a host and path with a changed Ingress, so a
+// conflict is flagged on both and an Ingress held back by it is migrated once it is resolved
+func (r *IngressReconciler) enqueueIngressesWithSharedPaths(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	ingress, ok := obj.(*networkingv1.Ingress)
+	if !ok {
+		return nil
+	}
+	peers, err := r.backendConflictPeers(ctx, ingress)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list Ingresses sharing paths")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(peers))
+	for i := range peers {
+		r.evictReconcileCache(ctx, &peers[i])
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: peers[i].Namespace, Name: peers[i].Name},
+		})
+	}
+	return requests
+}
+
+// backendConflictPredicate passes Ingress events that may add or resolve a conflict
+func backendConflictPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return true },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
+				!e.ObjectNew.GetDeletionTimestamp().IsZero()
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return true },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
@@ -0,0 +1,478 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// CertReplicationMode selects how Gateway listeners get at TLS Secrets of Ingress namespaces
+type CertReplicationMode string
+
+const (
+	// CertReplicationReferenceGrant references the Secrets in place, allowed by a ReferenceGrant
+	CertReplicationReferenceGrant CertReplicationMode = "reference-grant"
+	// CertReplicationSecretCopy copies the Secrets into the Gateway namespace
+	CertReplicationSecretCopy CertReplicationMode = "secret-copy"
+	// CertReplicationReflectorAnnotation asks reflector to mirror the Secrets into the Gateway namespace
+	CertReplicationReflectorAnnotation CertReplicationMode = "reflector-annotation"
+)
+
+const (
+	// CertReplicaLabel marks Secret copies (secret-copy) and Secrets annotated for reflector
+	CertReplicaLabel = "ingress-doperator.fiction.si/cert-replica"
+	// CertReplicaOfAnnotation names the Secret a copy was made from
+	CertReplicaOfAnnotation = "ingress-doperator.fiction.si/replica-of"
+
+	reflectorAllowedAnnotation           = "reflector.v1.k8s.emberstack.com/reflection-allowed"
+	reflectorAllowedNamespacesAnnotation = "reflector.v1.k8s.emberstack.com/reflection-allowed-namespaces"
+	reflectorAutoEnabledAnnotation       = "reflector.v1.k8s.emberstack.com/reflection-auto-enabled"
+	reflectorAutoNamespacesAnnotation    = "reflector.v1.k8s.emberstack.com/reflection-auto-namespaces"
+
+	// certReplicaResync refreshes Secret copies so renewed certificates reach the Gateway namespace
+	certReplicaResync = 10 * time.Minute
+
+	maxSecretNameLength = 253
+)
+
+// certReplicator makes the TLS Secrets of a route reachable for the Gateway listeners
+type certReplicator interface {
+	// listenerCertificateRef returns the Secret a listener references for a TLS Secret of an Ingress
+	listenerCertificateRef(secret types.NamespacedName) types.NamespacedName
+	// ensure sets up access to the TLS Secrets of the route before listeners reference them
+	ensure(ctx context.Context, httpRoute *gatewayv1.HTTPRoute, ingress *networkingv1.Ingress) error
+	// release undoes ensure for a deleted route
+	release(ctx context.Context, namespace, name string) error
+}
+
+// certReplicator returns the strategy for the configured cert replication mode
+func (r *HTTPRouteReconciler) certReplicator() certReplicator {
+	switch r.CertReplication {
+	case CertReplicationSecretCopy:
+		return secretCopyReplicator{r: r}
+	case CertReplicationReflectorAnnotation:
+		return reflectorReplicator{r: r}
+	default:
+		return referenceGrantReplicator{r: r}
+	}
+}
+
+// listenerCertificateRef builds the certificate reference of a listener for a TLS Secret of an Ingress
+func (r *HTTPRouteReconciler) listenerCertificateRef(secretNamespace, secretName string) gatewayv1.SecretObjectReference {
+	ref := types.NamespacedName{Namespace: secretNamespace, Name: secretName}
+	if secretNamespace != r.GatewayNamespace {
+		ref = r.certReplicator().listenerCertificateRef(ref)
+	}
+	return gatewayv1.SecretObjectReference{
+		Name:      gatewayv1.ObjectName(ref.Name),
+		Namespace: (*gatewayv1.Namespace)(&ref.Namespace),
+	}
+}
+
+// secretReader returns the reader for Secrets of the route's source cluster, uncached for local Secrets
+func (r *HTTPRouteReconciler) secretReader(httpRoute *gatewayv1.HTTPRoute) (client.Reader, error) {
+	if httpRoute.Annotations[translator.SourceClusterAnnotation] == "" && r.APIReader != nil {
+		return r.APIReader, nil
+	}
+	return r.sourceClient(httpRoute)
+}
+
+// routeTLSSecrets returns the Ingress TLS Secrets outside the Gateway namespace that listeners of the
+// route reference. Hosts whose certificate does not cover the rewritten hostname use a Secret in the
+// Gateway namespace instead and are left out.
+func (r *HTTPRouteReconciler) routeTLSSecrets(
+	httpRoute *gatewayv1.HTTPRoute,
+	ingress *networkingv1.Ingress,
+) []types.NamespacedName {
+	if ingress == nil || ingress.Namespace == r.GatewayNamespace || isPassthroughRoute(httpRoute) {
+		return nil
+	}
+	trans := r.listenerTranslator(httpRoute)
+	routeHosts := make(map[string]bool, len(httpRoute.Spec.Hostnames))
+	for _, host := range httpRoute.Spec.Hostnames {
+		routeHosts[string(host)] = true
+	}
+
+	seen := make(map[types.NamespacedName]bool)
+	var secrets []types.NamespacedName
+	// TLS-only hosts are only on a route when they are served
+	for _, host := range ingressHosts(ingress, true) {
+		transformed := trans.TransformHostname(host)
+		if !routeHosts[transformed] {
+			continue
+		}
+		tlsConfig := findTLSConfigForHost(ingress, host)
+		if tlsConfig == nil || tlsConfig.SecretName == "" {
+			continue
+		}
+		if host != transformed && !trans.CheckCertificateMatch(host, transformed, tlsConfig.Hosts) {
+			continue
+		}
+		secret := types.NamespacedName{Namespace: ingress.Namespace, Name: tlsConfig.SecretName}
+		if !seen[secret] {
+			seen[secret] = true
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets
+}
+
+// referenceGrantReplicator leaves the Secrets where they are and grants the Gateway access with a
+// ReferenceGrant in the route namespace
+type referenceGrantReplicator struct {
+	r *HTTPRouteReconciler
+}
+
+func (s referenceGrantReplicator) listenerCertificateRef(secret types.NamespacedName) types.NamespacedName {
+	return secret
+}
+
+func (s referenceGrantReplicator) ensure(
+	ctx context.Context,
+	httpRoute *gatewayv1.HTTPRoute,
+	_ *networkingv1.Ingress,
+) error {
+	if httpRoute.Namespace == s.r.GatewayNamespace {
+		return nil
+	}
+	return s.r.ensureReferenceGrant(ctx, httpRoute)
+}
+
+func (s referenceGrantReplicator) release(ctx context.Context, namespace, name string) error {
+	if namespace == s.r.GatewayNamespace {
+		return nil
+	}
+	return s.r.cleanupReferenceGrant(ctx, namespace, name)
+}
+
+// secretCopyReplicator keeps managed copies of the Secrets in the Gateway namespace. A copy lists the
+// routes using it in its source annotation and is deleted with the last of them.
+type secretCopyReplicator struct {
+	r *HTTPRouteReconciler
+}
+
+func (s secretCopyReplicator) listenerCertificateRef(secret types.NamespacedName) types.NamespacedName {
+	return types.NamespacedName{Namespace: s.r.GatewayNamespace, Name: certReplicaName(secret)}
+}
+
+func (s secretCopyReplicator) ensure(
+	ctx context.Context,
+	httpRoute *gatewayv1.HTTPRoute,
+	ingress *networkingv1.Ingress,
+) error {
+	routeKey := fmt.Sprintf("%s/%s", httpRoute.Namespace, httpRoute.Name)
+	secrets := s.r.routeTLSSecrets(httpRoute, ingress)
+	reader, err := s.r.secretReader(httpRoute)
+	if err != nil {
+		return err
+	}
+
+	desired := make(map[string]bool, len(secrets))
+	var errs []string
+	for _, secret := range secrets {
+		desired[certReplicaName(secret)] = true
+		if err := s.copySecret(ctx, reader, secret, routeKey); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if err := s.releaseExcept(ctx, routeKey, desired); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (s secretCopyReplicator) release(ctx context.Context, namespace, name string) error {
+	return s.releaseExcept(ctx, fmt.Sprintf("%s/%s", namespace, name), nil)
+}
+
+// copySecret creates or refreshes the copy of a Secret and records the route among its sources
+func (s secretCopyReplicator) copySecret(
+	ctx context.Context,
+	reader client.Reader,
+	secret types.NamespacedName,
+	routeKey string,
+) error {
+	logger := log.FromContext(ctx)
+
+	source := &corev1.Secret{}
+	if err := reader.Get(ctx, secret, source); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("TLS Secret not found, nothing to copy", "secret", secret.String())
+			return nil
+		}
+		return fmt.Errorf("failed to read Secret %s: %w", secret, err)
+	}
+
+	replicaNN := types.NamespacedName{Namespace: s.r.GatewayNamespace, Name: certReplicaName(secret)}
+	replica := &corev1.Secret{}
+	if err := s.r.secretAPIReader().Get(ctx, replicaNN, replica); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		replica = &corev1.Secret{}
+		replica.Name = replicaNN.Name
+		replica.Namespace = replicaNN.Namespace
+		replica.Labels = map[string]string{CertReplicaLabel: "true"}
+		replica.Annotations = map[string]string{
+			translator.ManagedByAnnotation: translator.ManagedByValue,
+			translator.SourceAnnotation:    routeKey,
+			CertReplicaOfAnnotation:        secret.String(),
+		}
+		replica.Type = source.Type
+		replica.Data = source.Data
+		logger.Info("Copying TLS Secret into Gateway namespace", "secret", secret.String(), "copy", replicaNN.String())
+		if err := s.r.Create(ctx, replica); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create Secret copy %s: %w", replicaNN, err)
+		}
+		return nil
+	}
+	if !utils.IsManagedByUs(replica) || replica.Annotations[CertReplicaOfAnnotation] != secret.String() {
+		logger.Info("Secret exists in Gateway namespace but is not our copy, skipping", "secret", replicaNN.String())
+		return nil
+	}
+
+	sources := getSourcesFromAnnotation(replica.Annotations[translator.SourceAnnotation])
+	changed := false
+	if !utils.ContainsString(sources, routeKey) {
+		sources = append(sources, routeKey)
+		sort.Strings(sources)
+		replica.Annotations[translator.SourceAnnotation] = strings.Join(sources, ",")
+		changed = true
+	}
+	if replica.Type != source.Type || !secretDataEqual(replica.Data, source.Data) {
+		replica.Type = source.Type
+		replica.Data = source.Data
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	logger.Info("Updating TLS Secret copy", "secret", secret.String(), "copy", replicaNN.String())
+	if err := s.r.Update(ctx, replica); err != nil {
+		return fmt.Errorf("failed to update Secret copy %s: %w", replicaNN, err)
+	}
+	return nil
+}
+
+// releaseExcept drops the route from the sources of copies it no longer uses, deleting unused copies
+func (s secretCopyReplicator) releaseExcept(ctx context.Context, routeKey string, keep map[string]bool) error {
+	replicas := &corev1.SecretList{}
+	if err := s.r.secretAPIReader().List(ctx, replicas, client.InNamespace(s.r.GatewayNamespace),
+		client.MatchingLabels{CertReplicaLabel: "true"}); err != nil {
+		return err
+	}
+	for i := range replicas.Items {
+		replica := &replicas.Items[i]
+		if keep[replica.Name] || !utils.IsManagedByUs(replica) {
+			continue
+		}
+		sources := getSourcesFromAnnotation(replica.Annotations[translator.SourceAnnotation])
+		remaining := utils.RemoveString(sources, routeKey)
+		if len(remaining) == len(sources) {
+			continue
+		}
+		if len(remaining) == 0 {
+			log.FromContext(ctx).Info("Deleting unused TLS Secret copy", "namespace", replica.Namespace, "name", replica.Name)
+			if err := s.r.Delete(ctx, replica); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+			continue
+		}
+		replica.Annotations[translator.SourceAnnotation] = strings.Join(remaining, ",")
+		if err := s.r.Update(ctx, replica); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reflectorReplicator annotates the Secrets so reflector mirrors them into the Gateway namespace under
+// the same name. Only annotations it added itself are removed again.
+type reflectorReplicator struct {
+	r *HTTPRouteReconciler
+}
+
+func (s reflectorReplicator) listenerCertificateRef(secret types.NamespacedName) types.NamespacedName {
+	return types.NamespacedName{Namespace: s.r.GatewayNamespace, Name: secret.Name}
+}
+
+func (s reflectorReplicator) ensure(
+	ctx context.Context,
+	httpRoute *gatewayv1.HTTPRoute,
+	ingress *networkingv1.Ingress,
+) error {
+	routeKey := fmt.Sprintf("%s/%s", httpRoute.Namespace, httpRoute.Name)
+	desired := make(map[string]bool)
+	var errs []string
+	for _, secret := range s.r.routeTLSSecrets(httpRoute, ingress) {
+		desired[secret.Name] = true
+		if err := s.annotateSecret(ctx, secret, routeKey); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if err := s.releaseExcept(ctx, httpRoute.Namespace, routeKey, desired); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (s reflectorReplicator) release(ctx context.Context, namespace, name string) error {
+	return s.releaseExcept(ctx, namespace, fmt.Sprintf("%s/%s", namespace, name), nil)
+}
+
+// annotateSecret adds the reflector annotations and records the route among the Secret's users
+func (s reflectorReplicator) annotateSecret(ctx context.Context, secret types.NamespacedName, routeKey string) error {
+	logger := log.FromContext(ctx)
+
+	current := &corev1.Secret{}
+	if err := s.r.secretAPIReader().Get(ctx, secret, current); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("TLS Secret not found, nothing to annotate", "secret", secret.String())
+			return nil
+		}
+		return fmt.Errorf("failed to read Secret %s: %w", secret, err)
+	}
+	if current.Labels[CertReplicaLabel] != "true" && current.Annotations[reflectorAutoEnabledAnnotation] != "" {
+		logger.V(1).Info("Secret is already set up for reflector, leaving it alone", "secret", secret.String())
+		return nil
+	}
+
+	patchBase := client.MergeFrom(current.DeepCopy())
+	if current.Labels == nil {
+		current.Labels = map[string]string{}
+	}
+	if current.Annotations == nil {
+		current.Annotations = map[string]string{}
+	}
+	sources := getSourcesFromAnnotation(current.Annotations[translator.SourceAnnotation])
+	if current.Labels[CertReplicaLabel] == "true" && utils.ContainsString(sources, routeKey) {
+		return nil
+	}
+	if !utils.ContainsString(sources, routeKey) {
+		sources = append(sources, routeKey)
+		sort.Strings(sources)
+	}
+	current.Labels[CertReplicaLabel] = "true"
+	current.Annotations[translator.SourceAnnotation] = strings.Join(sources, ",")
+	current.Annotations[reflectorAllowedAnnotation] = "true"
+	current.Annotations[reflectorAllowedNamespacesAnnotation] = s.r.GatewayNamespace
+	current.Annotations[reflectorAutoEnabledAnnotation] = "true"
+	current.Annotations[reflectorAutoNamespacesAnnotation] = s.r.GatewayNamespace
+	logger.Info("Annotating TLS Secret for reflector", "secret", secret.String(), "namespace", s.r.GatewayNamespace)
+	if err := s.r.Patch(ctx, current, patchBase); err != nil {
+		return fmt.Errorf("failed to annotate Secret %s: %w", secret, err)
+	}
+	return nil
+}
+
+// releaseExcept drops the route from Secrets it no longer uses and removes the reflector annotations
+// from Secrets no route uses anymore
+func (s reflectorReplicator) releaseExcept(
+	ctx context.Context,
+	namespace string,
+	routeKey string,
+	keep map[string]bool,
+) error {
+	secrets := &corev1.SecretList{}
+	if err := s.r.secretAPIReader().List(ctx, secrets, client.InNamespace(namespace),
+		client.MatchingLabels{CertReplicaLabel: "true"}); err != nil {
+		return err
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if keep[secret.Name] {
+			continue
+		}
+		sources := getSourcesFromAnnotation(secret.Annotations[translator.SourceAnnotation])
+		remaining := utils.RemoveString(sources, routeKey)
+		if len(remaining) == len(sources) {
+			continue
+		}
+		patchBase := client.MergeFrom(secret.DeepCopy())
+		if len(remaining) > 0 {
+			secret.Annotations[translator.SourceAnnotation] = strings.Join(remaining, ",")
+		} else {
+			log.FromContext(ctx).Info("Removing reflector annotations from unused TLS Secret",
+				"namespace", secret.Namespace, "name", secret.Name)
+			delete(secret.Labels, CertReplicaLabel)
+			for _, key := range []string{
+				translator.SourceAnnotation,
+				reflectorAllowedAnnotation,
+				reflectorAllowedNamespacesAnnotation,
+				reflectorAutoEnabledAnnotation,
+				reflectorAutoNamespacesAnnotation,
+			} {
+				delete(secret.Annotations, key)
+			}
+		}
+		if err := s.r.Patch(ctx, secret, patchBase); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// secretAPIReader reads Secrets of the local cluster without starting a Secret informer
+func (r *HTTPRouteReconciler) secretAPIReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// certReplicaName names the copy of a Secret in the Gateway namespace
+func certReplicaName(secret types.NamespacedName) string {
+	name := fmt.Sprintf("%s-%s", secret.Namespace, secret.Name)
+	if len(name) > maxSecretNameLength {
+		name = strings.TrimRight(name[:maxSecretNameLength], "-.")
+	}
+	return name
+}
+
+func secretDataEqual(a, b map[string][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		other, ok := b[key]
+		if !ok || string(other) != string(value) {
+			return false
+		}
+	}
+	return true
+}
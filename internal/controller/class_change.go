@@ -0,0 +1,116 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ClassChangePolicy decides what happens to the derived resources of a migrated Ingress whose class was
+// changed to one the operator does not migrate
+type ClassChangePolicy string
+
+const (
+	// ClassChangeIgnore leaves the derived resources alone, as if the class had not changed
+	ClassChangeIgnore ClassChangePolicy = "ignore"
+	// ClassChangeDelete deletes the derived resources like the delete deletion cascade
+	ClassChangeDelete ClassChangePolicy = "delete"
+	// ClassChangeAdopt hands the derived resources over like the adopt deletion cascade
+	ClassChangeAdopt ClassChangePolicy = "adopt"
+)
+
+// SourceIngressClassAnnotation records on an HTTPRoute the class of the Ingress it was translated from
+const SourceIngressClassAnnotation = "ingress-doperator.fiction.si/source-ingress-class"
+
+// recordSourceIngressClass remembers the class of the Ingress on its HTTPRoute, so a later change of the class
+// can be told apart from a change of the class filters
+func (r *IngressReconciler) recordSourceIngressClass(ingress *networkingv1.Ingress, httpRoute *gatewayv1.HTTPRoute) {
+	if httpRoute.Annotations == nil {
+		httpRoute.Annotations = make(map[string]string)
+	}
+	httpRoute.Annotations[SourceIngressClassAnnotation] = r.getIngressClass(ingress)
+}
+
+// previousIngressClass returns the class the Ingress had when its HTTPRoutes were generated, if it has since
+// been changed to a class that is not migrated. Ingresses the operator disabled or removed keep their routes.
+func (r *IngressReconciler) previousIngressClass(ctx context.Context, ingress *networkingv1.Ingress) (string, bool) {
+	if r.ClassChangePolicy == "" || r.ClassChangePolicy == ClassChangeIgnore {
+		return "", false
+	}
+	if r.matchesIngressClassFilter(ingress) && !r.matchesIngressClassIgnoreFilter(ingress) {
+		return "", false
+	}
+	if r.getIngressClass(ingress) == DisabledIngressClassName || HasDisabledMarker(ingress) ||
+		ingress.Annotations[IngressRemovedAnnotation] == fmt.Sprintf("%t", true) {
+		return "", false
+	}
+
+	routes, err := r.HTTPRouteManager.GetHTTPRoutesForIngress(ctx, ingress)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list HTTPRoutes to detect an ingress class change")
+		return "", false
+	}
+	class := r.getIngressClass(ingress)
+	for _, route := range routes {
+		// Routes from before the class was recorded, and a class filter that no longer matches, are left alone
+		if previous, ok := route.Annotations[SourceIngressClassAnnotation]; ok && previous != class {
+			return previous, true
+		}
+	}
+	return "", false
+}
+
+// handleIngressClassChange cleans up after an Ingress moved to a class the operator does not migrate, the
+// same way --deletion-cascade does after a deletion, and stops managing the Ingress
+func (r *IngressReconciler) handleIngressClassChange(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	previous string,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	class := r.getIngressClass(ingress)
+	logger.Info("Ingress class changed to a class that is not migrated",
+		"namespace", ingress.Namespace,
+		"name", ingress.Name,
+		"previous", previous,
+		"ingressClass", class,
+		"policy", r.ClassChangePolicy)
+	r.recordNormal(ingress, "IngressClassChanged",
+		fmt.Sprintf("Ingress class changed from %q to %q, which is not migrated (--class-change-policy=%s)",
+			previous, class, r.ClassChangePolicy))
+
+	// Unlike after a deletion, the routes are not awaited: once they are gone the change can't be detected
+	switch r.ClassChangePolicy {
+	case ClassChangeAdopt:
+		if err := r.adoptDerivedResources(ctx, ingress, logger); err != nil {
+			return ctrl.Result{}, err
+		}
+	default:
+		if err := r.deleteDerivedResources(ctx, ingress, logger); err != nil {
+			return ctrl.Result{}, err
+		}
+		r.recordNormal(ingress, "DerivedResourcesDeleted", "Deleted the routes and other derived resources")
+	}
+	// Also releases the finalizer, the Ingress is no longer ours to clean up
+	return r.finalizeDeletion(ctx, ingress)
+}
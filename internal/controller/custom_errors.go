@@ -0,0 +1,59 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// customErrorPageSnippets returns the snippets proxying custom-http-errors to the default-backend Service.
+// nginx resolves proxy_pass hostnames only when the configuration is loaded, so the ClusterIP is used: a
+// Service DNS name that does not resolve yet would break the whole Gateway configuration.
+func (r *IngressReconciler) customErrorPageSnippets(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+) []map[string]interface{} {
+	backend, ok := utils.CustomHTTPErrorBackend(ingress.Annotations)
+	if !ok {
+		return nil
+	}
+	service := &corev1.Service{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: ingress.Namespace, Name: backend.Service}, service)
+	switch {
+	case err != nil:
+		log.FromContext(ctx).Error(err, "failed to get custom error backend Service", "service", backend.Service)
+		r.recordWarning(ingress, "CustomErrorBackendUnavailable",
+			fmt.Sprintf("custom-http-errors are returned as is: default-backend Service %s cannot be read: %v",
+				backend.Service, err))
+		return nil
+	case service.Spec.ClusterIP == "" || service.Spec.ClusterIP == corev1.ClusterIPNone || len(service.Spec.Ports) == 0:
+		r.recordWarning(ingress, "CustomErrorBackendUnavailable",
+			fmt.Sprintf("custom-http-errors are returned as is: default-backend Service %s has no ClusterIP or port",
+				backend.Service))
+		return nil
+	}
+	return utils.BuildCustomErrorPageSnippets(ingress.Namespace, ingress.Name, backend, service.Spec.ClusterIP,
+		service.Spec.Ports[0].Port)
+}
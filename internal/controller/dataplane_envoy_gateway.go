@@ -0,0 +1,286 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// DataPlaneProviderEnvoyGateway attaches Envoy Gateway traffic and security policies
+const DataPlaneProviderEnvoyGateway = "envoy-gateway"
+
+var secretGVK = corev1.SchemeGroupVersion.WithKind("Secret")
+
+// envoyGatewayDataPlaneProvider carries over what Gateway API cannot express with Envoy Gateway policies:
+// load balancing, rate limits, connect timeouts and ALB health checks become a BackendTrafficPolicy of the
+// HTTPRoutes, basic and external authentication a SecurityPolicy, and ssl-ciphers a ClientTrafficPolicy of
+// the Gateway. SnippetsFilters and NGF filters have no equivalent and are reported as lost.
+type envoyGatewayDataPlaneProvider struct{}
+
+func (envoyGatewayDataPlaneProvider) Name() string {
+	return DataPlaneProviderEnvoyGateway
+}
+
+func (envoyGatewayDataPlaneProvider) Serves(controllerName gatewayv1.GatewayController) bool {
+	return controllerName == "gateway.envoyproxy.io/gatewayclass-controller"
+}
+
+func (envoyGatewayDataPlaneProvider) ApplyExtensions(
+	ctx context.Context,
+	r *IngressReconciler,
+	ingress *networkingv1.Ingress,
+	httpRoute *gatewayv1.HTTPRoute,
+) {
+	// Policies target every part SplitHTTPRouteIfNeeded makes of the route
+	routeNames := utils.SplitHTTPRouteNames(httpRoute)
+	r.applyEnvoyGatewayBackendTraffic(ctx, ingress, httpRoute.Namespace, routeNames)
+	lost := r.applyEnvoyGatewaySecurity(ctx, ingress, httpRoute.Namespace, routeNames)
+
+	translated := make([]string, 0)
+	if gateway, ok := routeGateway(httpRoute); ok && r.applyEnvoyGatewayClientTraffic(ctx, ingress, gateway) {
+		translated = append(translated, translator.NginxSSLCiphersKey)
+	}
+	r.reportNginxExtensionsLost(ctx, ingress, httpRoute, lost, translated)
+}
+
+func (envoyGatewayDataPlaneProvider) Watch(
+	_ context.Context,
+	_ *IngressReconciler,
+	b *ctrlbuilder.Builder,
+	_ client.Reader,
+) *ctrlbuilder.Builder {
+	return b
+}
+
+// Cleanup removes the ClientTrafficPolicy of the Ingress from the Gateway namespace, and its route
+// policies and basic auth Secret when they have no ownerReference (e.g. --ingress-postprocessing=remove)
+func (envoyGatewayDataPlaneProvider) Cleanup(
+	ctx context.Context,
+	r *IngressReconciler,
+	ingress *networkingv1.Ingress,
+) error {
+	var errs []error
+	for _, kind := range []struct {
+		kind, crdName, namespace string
+		c                        client.Client
+	}{
+		{utils.BackendTrafficPolicyKind, utils.BackendTrafficPolicyCRDName, ingress.Namespace, r.tenantClient()},
+		{utils.SecurityPolicyKind, utils.SecurityPolicyCRDName, ingress.Namespace, r.tenantClient()},
+		{utils.ClientTrafficPolicyKind, utils.ClientTrafficPolicyCRDName, r.GatewayNamespace, r.Client},
+	} {
+		gvk, ok, err := utils.InstalledGVK(ctx, r.Client, utils.EnvoyGatewayGroup, kind.kind, kind.crdName)
+		if err != nil || !ok {
+			errs = append(errs, err)
+			continue
+		}
+		errs = append(errs, utils.SyncIngressResources(ctx, kind.c, nil, nil, ingress, gvk, kind.namespace, nil))
+	}
+	errs = append(errs, utils.DeleteIngressResource(ctx, r.tenantClient(), ingress, secretGVK, ingress.Namespace,
+		utils.AutomaticResourceName(r.NameTemplate.Name(ingress), "basic-auth")))
+	return errors.Join(errs...)
+}
+
+// applyEnvoyGatewayBackendTraffic translates load balancing, limit-rps/limit-rpm, proxy-connect-timeout and
+// the ALB healthcheck-* annotations into a BackendTrafficPolicy. ingress-nginx limits per client address,
+// Envoy Gateway per Gateway replica.
+func (r *IngressReconciler) applyEnvoyGatewayBackendTraffic(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	namespace string,
+	routeNames []string,
+) {
+	spec := map[string]interface{}{}
+
+	loadBalancing, ok, err := translator.ParseUpstreamLoadBalancing(ingress.Annotations)
+	if err != nil {
+		r.recordWarning(ingress, "LoadBalanceNotTranslated", err.Error())
+	}
+	if ok {
+		if loadBalancer, translated := utils.EnvoyGatewayLoadBalancer(loadBalancing); translated {
+			spec["loadBalancer"] = loadBalancer
+			r.recordLoadBalanceApproximated(ingress, loadBalancing, fmt.Sprintf("%v", loadBalancer["type"]))
+		} else {
+			r.recordWarning(ingress, "LoadBalanceNotTranslated",
+				fmt.Sprintf("%s has no BackendTrafficPolicy equivalent", loadBalancing.Annotation))
+		}
+	}
+	limit, ok, err := translator.ParseRateLimit(ingress.Annotations)
+	if err != nil {
+		r.recordWarning(ingress, "RateLimitNotTranslated", err.Error())
+	}
+	if ok {
+		spec["rateLimit"] = utils.EnvoyGatewayLocalRateLimit(limit)
+	}
+	if timeout, ok := translator.ProxyConnectTimeout(ingress.Annotations); ok {
+		spec["timeout"] = map[string]interface{}{
+			"tcp": map[string]interface{}{"connectTimeout": timeout.String()},
+		}
+	}
+	healthCheck, ok, err := translator.ParseALBHealthCheck(ingress.Annotations)
+	if err != nil {
+		r.recordWarning(ingress, "ALBHealthCheckNotTranslated", err.Error())
+	}
+	if ok {
+		spec["healthCheck"] = utils.EnvoyGatewayActiveHealthCheck(healthCheck)
+	}
+
+	desired := make([]*unstructured.Unstructured, 0)
+	if len(spec) > 0 {
+		desired = append(desired, utils.BuildEnvoyGatewayRoutePolicy(
+			utils.AutomaticResourceName(r.NameTemplate.Name(ingress), "backend-traffic"),
+			routeNames,
+			spec,
+		))
+	}
+	r.syncDataPlaneResources(ctx, ingress, "EnvoyGateway", utils.EnvoyGatewayGroup, utils.BackendTrafficPolicyKind,
+		utils.BackendTrafficPolicyCRDName, r.tenantClient(), r.resourceOwner(ingress), namespace, desired)
+}
+
+// applyEnvoyGatewaySecurity translates basic authentication and auth-url into a SecurityPolicy. It returns
+// the annotations that could not be translated, an Ingress whose authentication is lost must stay served.
+func (r *IngressReconciler) applyEnvoyGatewaySecurity(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	namespace string,
+	routeNames []string,
+) []string {
+	lost := make([]string, 0)
+	spec := map[string]interface{}{}
+
+	secretName := utils.AutomaticResourceName(r.NameTemplate.Name(ingress), "basic-auth")
+	basicAuth, ok, err := translator.ParseBasicAuth(ingress.Annotations, ingress.Namespace)
+	if err != nil {
+		r.recordWarning(ingress, "AuthNotTranslated", err.Error())
+		lost = append(lost, translator.NginxIngressAnnotationPrefix+translator.NginxAuthTypeKey)
+	}
+	if ok {
+		if err := r.applyEnvoyGatewayBasicAuthSecret(ctx, ingress, namespace, secretName, basicAuth); err != nil {
+			r.recordWarning(ingress, "AuthNotTranslated", err.Error())
+			lost = append(lost, translator.NginxIngressAnnotationPrefix+translator.NginxAuthTypeKey)
+		} else {
+			spec["basicAuth"] = map[string]interface{}{"users": map[string]interface{}{"name": secretName}}
+		}
+	} else if err := utils.DeleteIngressResource(ctx, r.tenantClient(), ingress, secretGVK, namespace,
+		secretName); err != nil {
+		log.FromContext(ctx).Error(err, "failed to remove basic auth Secret", "name", secretName)
+	}
+
+	externalAuth, ok, err := translator.ParseExternalAuth(ingress.Annotations, ingress.Namespace)
+	if err != nil {
+		r.recordWarning(ingress, "AuthNotTranslated", err.Error())
+		lost = append(lost, translator.NginxIngressAnnotationPrefix+translator.NginxAuthURLKey)
+	}
+	if ok {
+		spec["extAuth"] = utils.BuildExternalAuth(externalAuth)
+	}
+
+	desired := make([]*unstructured.Unstructured, 0)
+	if len(spec) > 0 {
+		desired = append(desired, utils.BuildEnvoyGatewayRoutePolicy(
+			utils.AutomaticResourceName(r.NameTemplate.Name(ingress), "security"),
+			routeNames,
+			spec,
+		))
+	}
+	if !r.syncDataPlaneResources(ctx, ingress, "EnvoyGateway", utils.EnvoyGatewayGroup, utils.SecurityPolicyKind,
+		utils.SecurityPolicyCRDName, r.tenantClient(), r.resourceOwner(ingress), namespace, desired) {
+		if _, configured := spec["basicAuth"]; configured {
+			lost = append(lost, translator.NginxIngressAnnotationPrefix+translator.NginxAuthTypeKey)
+		}
+		if _, configured := spec["extAuth"]; configured {
+			lost = append(lost, translator.NginxIngressAnnotationPrefix+translator.NginxAuthURLKey)
+		}
+	}
+	return lost
+}
+
+// applyEnvoyGatewayBasicAuthSecret copies the htpasswd entries of auth-secret into a Secret next to the
+// SecurityPolicy, under the key Envoy Gateway expects
+func (r *IngressReconciler) applyEnvoyGatewayBasicAuthSecret(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	namespace, name string,
+	basicAuth translator.BasicAuth,
+) error {
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	source := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: basicAuth.SecretNamespace, Name: basicAuth.SecretName}
+	if err := reader.Get(ctx, key, source); err != nil {
+		return fmt.Errorf("failed to read auth-secret %s: %w", key, err)
+	}
+	secret, unsupported, err := utils.BuildBasicAuthSecret(name, source, basicAuth.SecretType)
+	if err != nil {
+		return err
+	}
+	if len(unsupported) > 0 {
+		r.recordWarning(ingress, "AuthNotTranslated",
+			fmt.Sprintf("Envoy Gateway only accepts {SHA} password hashes, users %s cannot log in",
+				strings.Join(unsupported, ", ")))
+	}
+	return utils.ApplyIngressResource(ctx, r.tenantClient(), r.Scheme, r.resourceOwner(ingress), ingress,
+		secretGVK, namespace, secret)
+}
+
+// applyEnvoyGatewayClientTraffic translates ssl-ciphers into a ClientTrafficPolicy of the Gateway, which
+// is only possible when the Gateway serves this Ingress alone. It returns true when ssl-ciphers is carried over.
+func (r *IngressReconciler) applyEnvoyGatewayClientTraffic(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	gateway types.NamespacedName,
+) bool {
+	desired := make([]*unstructured.Unstructured, 0)
+	if value, ok := translator.GetNginxAnnotation(ingress.Annotations, translator.NginxSSLCiphersKey); ok &&
+		value != "" {
+		if r.settings().OneGatewayPerIngress {
+			ciphers := make([]interface{}, 0)
+			for _, cipher := range strings.Split(value, ":") {
+				if cipher = strings.TrimSpace(cipher); cipher != "" {
+					ciphers = append(ciphers, cipher)
+				}
+			}
+			desired = append(desired, utils.BuildClientTrafficPolicy(
+				utils.AutomaticResourceName(ingress.Namespace, r.NameTemplate.Name(ingress), "client-traffic"),
+				gateway.Name,
+				map[string]interface{}{"tls": map[string]interface{}{"ciphers": ciphers}},
+			))
+		} else {
+			r.recordWarning(ingress, "TLSCiphersNotTranslated",
+				"ssl-ciphers applies to the whole Gateway with Envoy Gateway and needs --one-gateway-per-ingress")
+		}
+	}
+	applied := r.syncDataPlaneResources(ctx, ingress, "EnvoyGateway", utils.EnvoyGatewayGroup,
+		utils.ClientTrafficPolicyKind, utils.ClientTrafficPolicyCRDName, r.Client, nil, gateway.Namespace, desired)
+	return applied && len(desired) > 0
+}
@@ -0,0 +1,217 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// DataPlaneProviderIstio attaches DestinationRules, EnvoyFilters and Telemetry to Istio Gateways
+const DataPlaneProviderIstio = "istio"
+
+// istioDataPlaneProvider carries over what Gateway API cannot express with Istio resources:
+// load balancing and connect timeouts become DestinationRules of the backend Services, rate limits
+// EnvoyFilters of the Gateway and disabled access logs a Telemetry of the Gateway. SnippetsFilters and
+// NGF filters have no Istio equivalent and are reported as lost.
+type istioDataPlaneProvider struct{}
+
+func (istioDataPlaneProvider) Name() string {
+	return DataPlaneProviderIstio
+}
+
+func (istioDataPlaneProvider) Serves(controllerName gatewayv1.GatewayController) bool {
+	return controllerName == "istio.io/gateway-controller"
+}
+
+func (istioDataPlaneProvider) ApplyExtensions(
+	ctx context.Context,
+	r *IngressReconciler,
+	ingress *networkingv1.Ingress,
+	httpRoute *gatewayv1.HTTPRoute,
+) {
+	r.reportNginxExtensionsLost(ctx, ingress, httpRoute, nil, nil)
+	r.applyIstioDestinationRules(ctx, ingress)
+
+	gateway, ok := routeGateway(httpRoute)
+	if !ok {
+		return
+	}
+	r.applyIstioRateLimit(ctx, ingress, httpRoute, gateway)
+	r.applyIstioAccessLog(ctx, ingress, gateway)
+}
+
+func (istioDataPlaneProvider) Watch(
+	_ context.Context,
+	_ *IngressReconciler,
+	b *ctrlbuilder.Builder,
+	_ client.Reader,
+) *ctrlbuilder.Builder {
+	return b
+}
+
+// Cleanup removes the EnvoyFilters and Telemetry of the Ingress from the Gateway namespace, and its
+// DestinationRules when they have no ownerReference (e.g. --ingress-postprocessing=remove)
+func (istioDataPlaneProvider) Cleanup(ctx context.Context, r *IngressReconciler, ingress *networkingv1.Ingress) error {
+	var errs []error
+	for _, kind := range []struct {
+		group, kind, crdName, namespace string
+		c                               client.Client
+	}{
+		{utils.IstioNetworkingGroup, utils.DestinationRuleKind, utils.DestinationRuleCRDName, ingress.Namespace,
+			r.tenantClient()},
+		{utils.IstioNetworkingGroup, utils.EnvoyFilterKind, utils.EnvoyFilterCRDName, r.GatewayNamespace, r.Client},
+		{utils.IstioTelemetryGroup, utils.TelemetryKind, utils.TelemetryCRDName, r.GatewayNamespace, r.Client},
+	} {
+		gvk, ok, err := utils.InstalledGVK(ctx, r.Client, kind.group, kind.kind, kind.crdName)
+		if err != nil || !ok {
+			errs = append(errs, err)
+			continue
+		}
+		errs = append(errs, utils.SyncIngressResources(ctx, kind.c, nil, nil, ingress, gvk, kind.namespace, nil))
+	}
+	return errors.Join(errs...)
+}
+
+// routeGateway returns the Gateway the HTTPRoute attaches to
+func routeGateway(httpRoute *gatewayv1.HTTPRoute) (types.NamespacedName, bool) {
+	if len(httpRoute.Spec.ParentRefs) == 0 {
+		return types.NamespacedName{}, false
+	}
+	parent := httpRoute.Spec.ParentRefs[0]
+	gateway := types.NamespacedName{Namespace: httpRoute.Namespace, Name: string(parent.Name)}
+	if parent.Namespace != nil {
+		gateway.Namespace = string(*parent.Namespace)
+	}
+	return gateway, true
+}
+
+// applyIstioDestinationRules translates load balancing and proxy-connect-timeout into a DestinationRule
+// per backend Service. Several Ingresses configuring the same Service conflict, Istio uses the oldest rule.
+func (r *IngressReconciler) applyIstioDestinationRules(ctx context.Context, ingress *networkingv1.Ingress) {
+	logger := log.FromContext(ctx)
+	trafficPolicy := map[string]interface{}{}
+
+	loadBalancing, ok, err := translator.ParseUpstreamLoadBalancing(ingress.Annotations)
+	if err != nil {
+		r.recordWarning(ingress, "LoadBalanceNotTranslated", err.Error())
+	}
+	if ok {
+		if loadBalancer, translated := utils.IstioLoadBalancer(loadBalancing); translated {
+			trafficPolicy["loadBalancer"] = loadBalancer
+			r.recordLoadBalanceApproximated(ingress, loadBalancing, fmt.Sprintf("%v", loadBalancer["simple"]))
+		} else {
+			r.recordWarning(ingress, "LoadBalanceNotTranslated",
+				fmt.Sprintf("%s has no DestinationRule equivalent", loadBalancing.Annotation))
+		}
+	}
+	if timeout, ok := translator.ProxyConnectTimeout(ingress.Annotations); ok {
+		trafficPolicy["connectionPool"] = map[string]interface{}{
+			"tcp": map[string]interface{}{"connectTimeout": timeout.String()},
+		}
+	}
+
+	desired := make([]*unstructured.Unstructured, 0)
+	if len(trafficPolicy) > 0 {
+		for _, service := range utils.IngressBackendServiceNames(ingress) {
+			desired = append(desired, utils.BuildDestinationRule(
+				utils.AutomaticResourceName(r.NameTemplate.Name(ingress), service),
+				ingress.Namespace,
+				service,
+				runtime.DeepCopyJSONValue(trafficPolicy).(map[string]interface{}),
+			))
+		}
+	}
+	r.syncDataPlaneResources(ctx, ingress, "Istio", utils.IstioNetworkingGroup, utils.DestinationRuleKind,
+		utils.DestinationRuleCRDName, r.tenantClient(), r.resourceOwner(ingress), ingress.Namespace, desired)
+	if loadBalancing.Approximated && len(desired) > 0 {
+		logger.V(1).Info("load-balance approximated with LEAST_REQUEST",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name)
+	}
+}
+
+// applyIstioRateLimit translates limit-rps/limit-rpm into a local rate limit on the virtual hosts of the
+// HTTPRoute. ingress-nginx limits per client address, Envoy per Gateway replica.
+func (r *IngressReconciler) applyIstioRateLimit(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	httpRoute *gatewayv1.HTTPRoute,
+	gateway types.NamespacedName,
+) {
+	limit, ok, err := translator.ParseRateLimit(ingress.Annotations)
+	if err != nil {
+		r.recordWarning(ingress, "RateLimitNotTranslated", err.Error())
+	}
+	desired := make([]*unstructured.Unstructured, 0)
+	if ok && len(httpRoute.Spec.Hostnames) > 0 {
+		gvk, installed, err := utils.InstalledGVK(ctx, r.Client, utils.IstioNetworkingGroup, utils.EnvoyFilterKind,
+			utils.EnvoyFilterCRDName)
+		if err == nil && installed {
+			err = utils.EnsureLocalRateLimitFilter(ctx, r.Client, gvk, gateway)
+		}
+		if err != nil {
+			log.FromContext(ctx).Error(err, "failed to add local rate limit filter to Gateway", "gateway", gateway)
+			return
+		}
+		desired = append(desired, utils.BuildLocalRateLimitEnvoyFilter(
+			utils.AutomaticResourceName(ingress.Namespace, r.NameTemplate.Name(ingress), "ratelimit"),
+			gateway.Name,
+			httpRoute.Spec.Hostnames,
+			limit,
+		))
+	}
+	r.syncDataPlaneResources(ctx, ingress, "Istio", utils.IstioNetworkingGroup, utils.EnvoyFilterKind,
+		utils.EnvoyFilterCRDName, r.Client, nil, gateway.Namespace, desired)
+}
+
+// applyIstioAccessLog translates enable-access-log: "false" into a Telemetry of the Gateway, which is
+// only possible when the Gateway serves this Ingress alone
+func (r *IngressReconciler) applyIstioAccessLog(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	gateway types.NamespacedName,
+) {
+	desired := make([]*unstructured.Unstructured, 0)
+	if value, ok := translator.GetNginxAnnotation(ingress.Annotations, translator.NginxEnableAccessLogKey); ok &&
+		value == "false" {
+		if r.settings().OneGatewayPerIngress {
+			desired = append(desired, utils.BuildAccessLogTelemetry(
+				utils.AutomaticResourceName(ingress.Namespace, r.NameTemplate.Name(ingress), "telemetry"),
+				gateway.Name,
+			))
+		} else {
+			r.recordWarning(ingress, "AccessLogNotTranslated",
+				"enable-access-log: \"false\" applies to the whole Gateway with Istio and needs --one-gateway-per-ingress")
+		}
+	}
+	r.syncDataPlaneResources(ctx, ingress, "Istio", utils.IstioTelemetryGroup, utils.TelemetryKind,
+		utils.TelemetryCRDName, r.Client, nil, gateway.Namespace, desired)
+}
@@ -0,0 +1,308 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+const (
+	// DataPlaneProviderAuto picks the provider serving the controllerName of the target GatewayClass
+	DataPlaneProviderAuto = "auto"
+	// DataPlaneProviderNginx synthesizes NGINX Gateway Fabric SnippetsFilters and policies
+	DataPlaneProviderNginx = "nginx"
+	// DataPlaneProviderGatewayAPI sticks to portable Gateway API resources
+	DataPlaneProviderGatewayAPI = "gateway-api"
+)
+
+// DataPlaneProvider synthesizes the implementation-specific filters and policies that carry over
+// Ingress behavior plain HTTPRoutes cannot express
+type DataPlaneProvider interface {
+	// Name selects the provider with --data-plane-provider
+	Name() string
+	// Serves reports whether the provider implements GatewayClasses of the given controllerName
+	Serves(controllerName gatewayv1.GatewayController) bool
+	// ApplyExtensions creates the filters and policies for the Ingress and references them from the HTTPRoute
+	ApplyExtensions(ctx context.Context, r *IngressReconciler, ingress *networkingv1.Ingress,
+		httpRoute *gatewayv1.HTTPRoute)
+	// Watch adds watches on the provider resources whose changes requeue Ingresses
+	Watch(ctx context.Context, r *IngressReconciler, b *ctrlbuilder.Builder, apiReader client.Reader) *ctrlbuilder.Builder
+	// Cleanup deletes what ApplyExtensions created and ownerReferences do not remove with the Ingress
+	Cleanup(ctx context.Context, r *IngressReconciler, ingress *networkingv1.Ingress) error
+}
+
+var dataPlaneProviders = map[string]DataPlaneProvider{}
+
+// RegisterDataPlaneProvider makes a provider selectable by name and by GatewayClass detection
+func RegisterDataPlaneProvider(provider DataPlaneProvider) {
+	dataPlaneProviders[provider.Name()] = provider
+}
+
+func init() {
+	RegisterDataPlaneProvider(nginxDataPlaneProvider{})
+	RegisterDataPlaneProvider(gatewayAPIDataPlaneProvider{})
+	RegisterDataPlaneProvider(istioDataPlaneProvider{})
+	RegisterDataPlaneProvider(envoyGatewayDataPlaneProvider{})
+	RegisterDataPlaneProvider(traefikDataPlaneProvider{})
+}
+
+// DataPlaneProviderNames returns the registered provider names, sorted
+func DataPlaneProviderNames() []string {
+	names := make([]string, 0, len(dataPlaneProviders))
+	for name := range dataPlaneProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupDataPlaneProvider returns the named provider, nil for auto
+func LookupDataPlaneProvider(name string) (DataPlaneProvider, error) {
+	if name == DataPlaneProviderAuto {
+		return nil, nil
+	}
+	provider, ok := dataPlaneProviders[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown data plane provider %q", name)
+	}
+	return provider, nil
+}
+
+// dataPlaneProviderForController returns the provider serving controllerName, falling back to
+// the portable gateway-api provider
+func dataPlaneProviderForController(controllerName gatewayv1.GatewayController) DataPlaneProvider {
+	for _, name := range DataPlaneProviderNames() {
+		if provider := dataPlaneProviders[name]; provider.Serves(controllerName) {
+			return provider
+		}
+	}
+	return dataPlaneProviders[DataPlaneProviderGatewayAPI]
+}
+
+// dataPlaneProvider returns the configured provider or detects it from the target GatewayClass.
+// Until the GatewayClass can be read the nginx provider is assumed.
+func (r *IngressReconciler) dataPlaneProvider(ctx context.Context) DataPlaneProvider {
+	if r.DataPlaneProvider != nil {
+		return r.DataPlaneProvider
+	}
+	gatewayClass := &gatewayv1.GatewayClass{}
+	if err := r.Get(ctx, client.ObjectKey{Name: r.gatewayClassName()}, gatewayClass); err != nil {
+		log.FromContext(ctx).V(1).Info("Unable to read GatewayClass, assuming the nginx data plane provider",
+			"gatewayClass", r.gatewayClassName(),
+			"error", err.Error())
+		return dataPlaneProviders[DataPlaneProviderNginx]
+	}
+	return dataPlaneProviderForController(gatewayClass.Spec.ControllerName)
+}
+
+// watchDataPlaneProviders adds the watches of the configured provider, or of every provider when
+// it is detected per reconcile
+func (r *IngressReconciler) watchDataPlaneProviders(
+	ctx context.Context,
+	b *ctrlbuilder.Builder,
+	apiReader client.Reader,
+) *ctrlbuilder.Builder {
+	if r.DataPlaneProvider != nil {
+		return r.DataPlaneProvider.Watch(ctx, r, b, apiReader)
+	}
+	for _, name := range DataPlaneProviderNames() {
+		b = dataPlaneProviders[name].Watch(ctx, r, b, apiReader)
+	}
+	return b
+}
+
+// nginxDataPlaneProvider targets NGINX Gateway Fabric: SnippetsFilters, AuthenticationFilters,
+// RequestHeaderModifierFilters and UpstreamSettingsPolicies
+type nginxDataPlaneProvider struct{}
+
+func (nginxDataPlaneProvider) Name() string {
+	return DataPlaneProviderNginx
+}
+
+func (nginxDataPlaneProvider) Serves(controllerName gatewayv1.GatewayController) bool {
+	return controllerName == "gateway.nginx.org/nginx-gateway-controller"
+}
+
+func (nginxDataPlaneProvider) ApplyExtensions(
+	ctx context.Context,
+	r *IngressReconciler,
+	ingress *networkingv1.Ingress,
+	httpRoute *gatewayv1.HTTPRoute,
+) {
+	r.applyHTTPRouteExtensionRefs(ctx, ingress, httpRoute)
+	r.applyUpstreamLoadBalancing(ctx, ingress)
+}
+
+func (nginxDataPlaneProvider) Watch(
+	ctx context.Context,
+	r *IngressReconciler,
+	b *ctrlbuilder.Builder,
+	apiReader client.Reader,
+) *ctrlbuilder.Builder {
+	for _, watched := range []struct {
+		kind    string
+		crdName string
+		enqueue func(context.Context, client.Object) []reconcile.Request
+	}{
+		{utils.SnippetsFilterKind, utils.SnippetsFilterCRDName, r.enqueueIngressesForSnippetsFilter},
+		{utils.AuthenticationFilterKind, utils.AuthenticationFilterCRDName,
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return r.enqueueIngressesForExtension(ctx, obj, HTTPRouteAuthenticationAnnotation)
+			}},
+		{utils.RequestHeaderModifierFilterKind, utils.RequestHeaderModifierCRDName,
+			func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return r.enqueueIngressesForExtension(ctx, obj, HTTPRouteRequestHeaderAnnotation)
+			}},
+	} {
+		version, ok, err := utils.GetCRDVersion(ctx, apiReader, watched.crdName)
+		if err != nil || !ok {
+			log.FromContext(ctx).V(1).Info(watched.kind + " CRD not installed, skipping watch")
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   utils.NginxGatewayGroup,
+			Version: version,
+			Kind:    watched.kind,
+		})
+		b = b.Watches(
+			obj,
+			handler.EnqueueRequestsFromMapFunc(watched.enqueue),
+			ctrlbuilder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetNamespace() == r.GatewayNamespace
+			})),
+		)
+	}
+	return b
+}
+
+func (nginxDataPlaneProvider) Cleanup(context.Context, *IngressReconciler, *networkingv1.Ingress) error {
+	return nil
+}
+
+// gatewayAPIDataPlaneProvider creates no implementation-specific resources. Behavior that would need
+// them is recorded in the snippets-lost annotation, as with --disable-snippets.
+type gatewayAPIDataPlaneProvider struct{}
+
+func (gatewayAPIDataPlaneProvider) Name() string {
+	return DataPlaneProviderGatewayAPI
+}
+
+func (gatewayAPIDataPlaneProvider) Serves(gatewayv1.GatewayController) bool {
+	return false
+}
+
+func (gatewayAPIDataPlaneProvider) ApplyExtensions(
+	ctx context.Context,
+	r *IngressReconciler,
+	ingress *networkingv1.Ingress,
+	httpRoute *gatewayv1.HTTPRoute,
+) {
+	r.reportNginxExtensionsLost(ctx, ingress, httpRoute, nil, nil)
+
+	if loadBalancing, ok, _ := translator.ParseUpstreamLoadBalancing(ingress.Annotations); ok {
+		r.recordWarning(ingress, "LoadBalanceNotTranslated",
+			fmt.Sprintf("%s was not translated: the gateway-api data plane provider has no load balancing policy",
+				loadBalancing.Annotation))
+	}
+}
+
+func (gatewayAPIDataPlaneProvider) Watch(
+	_ context.Context,
+	_ *IngressReconciler,
+	b *ctrlbuilder.Builder,
+	_ client.Reader,
+) *ctrlbuilder.Builder {
+	return b
+}
+
+func (gatewayAPIDataPlaneProvider) Cleanup(context.Context, *IngressReconciler, *networkingv1.Ingress) error {
+	return nil
+}
+
+// reportNginxExtensionsLost lists the SnippetsFilters and NGF filters the Ingress asks for in the
+// snippets-lost annotation together with extraLost, for providers that cannot create them. Annotations
+// in translated are carried over by the provider and not reported.
+func (r *IngressReconciler) reportNginxExtensionsLost(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	httpRoute *gatewayv1.HTTPRoute,
+	extraLost []string,
+	translated []string,
+) {
+	lost := make([]string, 0)
+	for _, name := range r.snippetsFilterNames(ingress, log.FromContext(ctx)) {
+		lost = append(lost, utils.SnippetsFilterKind+"/"+name)
+	}
+	for _, annotation := range []string{HTTPRouteAuthenticationAnnotation, HTTPRouteRequestHeaderAnnotation} {
+		if _, ok := ingress.Annotations[annotation]; ok {
+			lost = append(lost, annotation)
+		}
+	}
+	r.suppressSnippets(ctx, ingress, httpRoute, append(lost, extraLost...), translated)
+}
+
+// syncDataPlaneResources applies the desired resources of one custom resource kind of a data plane,
+// warning when its CRD is missing. Event reasons are prefixed with the data plane. It returns false when
+// the desired resources could not be applied.
+func (r *IngressReconciler) syncDataPlaneResources(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	dataPlane string,
+	group, kind, crdName string,
+	c client.Client,
+	owner client.Object,
+	namespace string,
+	desired []*unstructured.Unstructured,
+) bool {
+	logger := log.FromContext(ctx)
+	gvk, ok, err := utils.InstalledGVK(ctx, r.Client, group, kind, crdName)
+	if err != nil {
+		logger.Error(err, "failed to look up data plane CRD", "kind", kind)
+		return false
+	}
+	if !ok {
+		if len(desired) > 0 {
+			r.recordWarning(ingress, dataPlane+"CRDMissing",
+				fmt.Sprintf("%s CRD is not installed, Ingress annotations needing it are not translated", kind))
+			return false
+		}
+		return true
+	}
+	if err := utils.SyncIngressResources(ctx, c, r.Scheme, owner, ingress, gvk, namespace, desired); err != nil {
+		logger.Error(err, "failed to apply data plane resources", "kind", kind, "namespace", namespace)
+		r.recordWarning(ingress, dataPlane+"ResourcesFailed", fmt.Sprintf("failed to apply %s: %v", kind, err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+const (
+	// DataPlaneProviderTraefik references Traefik Middlewares from HTTPRoutes served by Traefik
+	DataPlaneProviderTraefik = "traefik"
+
+	traefikGroup          = "traefik.io"
+	traefikMiddlewareKind = "Middleware"
+)
+
+// traefikDataPlaneProvider targets Traefik's Gateway API provider. The Middlewares a Traefik Ingress
+// lists in router.middlewares become ExtensionRef filters; SnippetsFilters and NGF filters have no
+// Traefik equivalent and are reported as lost.
+type traefikDataPlaneProvider struct{}
+
+func (traefikDataPlaneProvider) Name() string {
+	return DataPlaneProviderTraefik
+}
+
+func (traefikDataPlaneProvider) Serves(controllerName gatewayv1.GatewayController) bool {
+	return controllerName == "traefik.io/gateway-controller"
+}
+
+func (traefikDataPlaneProvider) ApplyExtensions(
+	ctx context.Context,
+	r *IngressReconciler,
+	ingress *networkingv1.Ingress,
+	httpRoute *gatewayv1.HTTPRoute,
+) {
+	r.reportNginxExtensionsLost(ctx, ingress, httpRoute, nil, nil)
+
+	middlewares, _ := translator.TraefikMiddlewares(ingress)
+	utils.AddExtensionRefFilters(httpRoute, traefikGroup, traefikMiddlewareKind, middlewares)
+
+	if loadBalancing, ok, _ := translator.ParseUpstreamLoadBalancing(ingress.Annotations); ok {
+		r.recordWarning(ingress, "LoadBalanceNotTranslated",
+			fmt.Sprintf("%s was not translated: use a Traefik TraefikService or the Service annotations instead",
+				loadBalancing.Annotation))
+	}
+}
+
+func (traefikDataPlaneProvider) Watch(
+	_ context.Context,
+	_ *IngressReconciler,
+	b *ctrlbuilder.Builder,
+	_ client.Reader,
+) *ctrlbuilder.Builder {
+	return b
+}
+
+// Cleanup has nothing to do, the Middlewares belong to the Ingress owner
+func (traefikDataPlaneProvider) Cleanup(context.Context, *IngressReconciler, *networkingv1.Ingress) error {
+	return nil
+}
+
+// reportTraefikMiddlewaresLost adds the Traefik Middlewares of the Ingress that do not become HTTPRoute
+// filters to the snippets-lost annotation of the HTTPRoute: all of them unless Traefik serves the route,
+// otherwise those of other namespaces or providers
+func (r *IngressReconciler) reportTraefikMiddlewaresLost(
+	ingress *networkingv1.Ingress,
+	httpRoute *gatewayv1.HTTPRoute,
+	servedByTraefik bool,
+) {
+	local, unsupported := translator.TraefikMiddlewares(ingress)
+	if !servedByTraefik {
+		unsupported = append(local, unsupported...)
+	}
+	if len(unsupported) == 0 {
+		return
+	}
+
+	lost := make([]string, 0, len(unsupported)+1)
+	if existing := httpRoute.Annotations[SnippetsLostAnnotation]; existing != "" {
+		lost = append(lost, existing)
+	}
+	for _, middleware := range unsupported {
+		lost = append(lost, traefikMiddlewareKind+"/"+middleware)
+	}
+	if httpRoute.Annotations == nil {
+		httpRoute.Annotations = map[string]string{}
+	}
+	httpRoute.Annotations[SnippetsLostAnnotation] = strings.Join(lost, ",")
+	r.recordWarning(ingress, "TraefikMiddlewaresNotTranslated",
+		fmt.Sprintf("Traefik Middlewares %s are not referenced by HTTPRoute %s: only Middlewares of the Ingress "+
+			"namespace can be attached, and only when Traefik serves the Gateway",
+			strings.Join(unsupported, ", "), httpRoute.Name))
+}
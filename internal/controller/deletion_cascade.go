@@ -0,0 +1,213 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// DeletionCascadePolicy decides what happens to the derived resources of a migrated Ingress its owner deleted
+type DeletionCascadePolicy string
+
+const (
+	// DeletionCascadeKeep leaves the derived resources alone, those with an ownerReference to the Ingress are
+	// still garbage collected by Kubernetes
+	DeletionCascadeKeep DeletionCascadePolicy = "keep"
+	// DeletionCascadeDelete deletes the routes, which prunes their Gateway listeners, and the SnippetsFilters,
+	// policies and other resources generated for the Ingress
+	DeletionCascadeDelete DeletionCascadePolicy = "delete"
+	// DeletionCascadeAdopt hands the derived resources over: their ownerReference to the Ingress and our
+	// managed-by annotation are removed, so neither garbage collection nor the operator touches them again
+	DeletionCascadeAdopt DeletionCascadePolicy = "adopt"
+)
+
+// AdoptedFromAnnotation marks a resource handed over by the adopt deletion cascade, with the Ingress
+// (namespace/name) it was generated for
+const AdoptedFromAnnotation = "ingress-doperator.fiction.si/adopted-from"
+
+// adoptedKinds are the kinds generated for an Ingress in its namespace, next to those listed by CRD name in
+// adoptedCustomKinds. Kinds whose API is not installed are skipped.
+var adoptedKinds = []schema.GroupVersionKind{
+	utils.HTTPRouteKind,
+	utils.GRPCRouteKind,
+	utils.TLSRouteKind,
+	utils.BackendTLSPolicyKind,
+	utils.ReferenceGrantKind,
+	{Group: networkingv1.GroupName, Version: "v1", Kind: "NetworkPolicy"},
+	{Version: "v1", Kind: "ConfigMap"},
+}
+
+var adoptedCustomKinds = []struct {
+	group, kind, crdName string
+}{
+	{utils.NginxGatewayGroup, utils.SnippetsFilterKind, utils.SnippetsFilterCRDName},
+	{utils.NginxGatewayGroup, utils.SnippetsPolicyKind, utils.SnippetsPolicyCRDName},
+	{utils.NginxGatewayGroup, utils.AuthenticationFilterKind, utils.AuthenticationFilterCRDName},
+	{utils.NginxGatewayGroup, utils.RequestHeaderModifierFilterKind, utils.RequestHeaderModifierCRDName},
+	{utils.NginxGatewayGroup, utils.RateLimitPolicyKind, utils.RateLimitPolicyCRDName},
+	{utils.NginxGatewayGroup, utils.UpstreamSettingsPolicyKind, utils.UpstreamSettingsPolicyCRDName},
+	{utils.EnvoyGatewayGroup, utils.BackendTrafficPolicyKind, utils.BackendTrafficPolicyCRDName},
+	{utils.EnvoyGatewayGroup, utils.ClientTrafficPolicyKind, utils.ClientTrafficPolicyCRDName},
+	{utils.EnvoyGatewayGroup, utils.SecurityPolicyKind, utils.SecurityPolicyCRDName},
+	{utils.IstioNetworkingGroup, utils.DestinationRuleKind, utils.DestinationRuleCRDName},
+	{utils.IstioNetworkingGroup, utils.EnvoyFilterKind, utils.EnvoyFilterCRDName},
+	{utils.IstioTelemetryGroup, utils.TelemetryKind, utils.TelemetryCRDName},
+}
+
+// deletionCascade returns the effective policy: --enable-deletion and the finalizer ownership mode delete
+// derived resources unless they are adopted
+func (r *IngressReconciler) deletionCascade() DeletionCascadePolicy {
+	switch {
+	case r.DeletionCascade == DeletionCascadeAdopt:
+		return DeletionCascadeAdopt
+	case r.DeletionCascade == DeletionCascadeDelete || r.EnableDeletion || r.OwnershipMode == OwnershipModeFinalizer:
+		return DeletionCascadeDelete
+	default:
+		return DeletionCascadeKeep
+	}
+}
+
+// isAdoptedRoute reports whether a route was handed over by the adopt deletion cascade. Its listeners are kept
+// with the certificate they have, as its Ingress is gone.
+func isAdoptedRoute(route client.Object) bool {
+	return route.GetAnnotations()[AdoptedFromAnnotation] != ""
+}
+
+// adoptDerivedResources hands the resources generated for a deleted Ingress in its namespace over to their
+// users and records the outcome on the Ingress
+func (r *IngressReconciler) adoptDerivedResources(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	logger logr.Logger,
+) error {
+	kinds := append([]schema.GroupVersionKind(nil), adoptedKinds...)
+	for _, custom := range adoptedCustomKinds {
+		gvk, ok, err := utils.InstalledGVK(ctx, r.Client, custom.group, custom.kind, custom.crdName)
+		if err != nil {
+			return err
+		}
+		if ok {
+			kinds = append(kinds, gvk)
+		}
+	}
+
+	adopted := make([]string, 0)
+	for _, gvk := range kinds {
+		names, err := r.adoptDerivedResourcesOfKind(ctx, ingress, gvk)
+		if err != nil {
+			return fmt.Errorf("failed to adopt %s resources: %w", gvk.Kind, err)
+		}
+		adopted = append(adopted, names...)
+	}
+	sort.Strings(adopted)
+
+	logger.Info("Adopted derived resources of deleted Ingress",
+		"namespace", ingress.Namespace,
+		"name", ingress.Name,
+		"resources", strings.Join(adopted, ", "))
+	if len(adopted) > 0 {
+		r.recordNormal(ingress, "DerivedResourcesAdopted",
+			fmt.Sprintf("Handed over %d derived resources: %s", len(adopted), strings.Join(adopted, ", ")))
+	}
+	return nil
+}
+
+func (r *IngressReconciler) adoptDerivedResourcesOfKind(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	gvk schema.GroupVersionKind,
+) ([]string, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := r.tenantClient().List(ctx, list, client.InNamespace(ingress.Namespace)); err != nil {
+		// Kinds we may not list were not generated by us either
+		if meta.IsNoMatchError(err) || apierrors.IsForbidden(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	adopted := make([]string, 0)
+	for i := range list.Items {
+		object := &list.Items[i]
+		if !object.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		controlled, referenced := r.derivedFrom(object, ingress)
+		if !controlled && !referenced {
+			continue
+		}
+		owners := make([]metav1.OwnerReference, 0, len(object.GetOwnerReferences()))
+		for _, owner := range object.GetOwnerReferences() {
+			if owner.UID != ingress.UID {
+				owners = append(owners, owner)
+			}
+		}
+		object.SetOwnerReferences(owners)
+		if controlled {
+			// Shared resources such as the ReferenceGrant stay managed for the other Ingresses using them
+			annotations := object.GetAnnotations()
+			delete(annotations, utils.ManagedByAnnotation)
+			annotations[AdoptedFromAnnotation] = fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name)
+			object.SetAnnotations(annotations)
+			object.SetFinalizers(utils.RemoveString(object.GetFinalizers(), HTTPRouteFinalizerName))
+			adopted = append(adopted, fmt.Sprintf("%s/%s", gvk.Kind, object.GetName()))
+		}
+		if err := r.tenantClient().Update(ctx, object); err != nil {
+			return nil, err
+		}
+	}
+	return adopted, nil
+}
+
+// derivedFrom reports whether the object was generated for the Ingress alone (controlled) or is a shared
+// resource with a (non-controller) ownerReference to it
+func (r *IngressReconciler) derivedFrom(object client.Object, ingress *networkingv1.Ingress) (bool, bool) {
+	if !utils.IsManagedByUs(object) {
+		return false, false
+	}
+	if r.SourceCluster != nil {
+		// Fan-in resources are linked by annotations, the Ingress lives in another cluster
+		return utils.IsManagedByUsForIngress(object, ingress.Namespace, ingress.Name) &&
+			object.GetAnnotations()[translator.SourceClusterAnnotation] == r.fanInSourceName(), false
+	}
+	for _, owner := range object.GetOwnerReferences() {
+		if owner.UID != ingress.UID {
+			continue
+		}
+		if owner.Controller != nil && *owner.Controller {
+			return true, false
+		}
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,288 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// DisableStrategyAnnotation records which DisableStrategy disabled an Ingress, so it is restored the same
+	// way even after the cluster switched to another strategy
+	DisableStrategyAnnotation = "ingress-doperator.fiction.si/disable-strategy"
+	// DisabledHostPrefix is the reserved token the host-prefix strategy puts in front of every host
+	DisabledHostPrefix = "disabled--"
+	// disabledCatchAllHost stands in for the empty host of a catch-all rule disabled by host prefixing
+	disabledCatchAllHost = DisabledHostPrefix + "catch-all.invalid"
+	// maxHostLabelLength is the longest DNS label a prefixed host may end up with
+	maxHostLabelLength = 63
+)
+
+// ErrCannotDisable is returned by DisableStrategy.Disable when the strategy cannot take the Ingress away
+// from its legacy controller, the Ingress is then left as it is
+var ErrCannotDisable = errors.New("the Ingress cannot be taken away from its legacy controller")
+
+// DisableStrategyName selects how migrated Ingresses are taken away from the legacy controller
+type DisableStrategyName string
+
+const (
+	// DisableStrategyClassSwap moves the Ingress to the disabled IngressClass, saving the original class
+	DisableStrategyClassSwap DisableStrategyName = "class-swap"
+	// DisableStrategyHostPrefix keeps the class and prefixes every rule and TLS host with DisabledHostPrefix,
+	// for legacy controllers whose admission rewrites the class back
+	DisableStrategyHostPrefix DisableStrategyName = "host-prefix"
+)
+
+// DisableStrategy takes a migrated Ingress away from its legacy controller and gives it back on restore.
+// Bookkeeping annotations shared by all strategies are handled by the callers.
+type DisableStrategy interface {
+	// Name identifies the strategy in DisableStrategyAnnotation
+	Name() DisableStrategyName
+	// Disable changes the Ingress so the legacy controller stops serving it and reports whether it changed.
+	// It returns ErrCannotDisable, changing nothing, when the legacy controller would keep serving part of it
+	Disable(ingress *networkingv1.Ingress) (bool, error)
+	// Disabled reports whether the Ingress carries the marker of the strategy
+	Disabled(ingress *networkingv1.Ingress) bool
+	// Original returns a copy of a disabled Ingress as it was before it was disabled
+	Original(ingress *networkingv1.Ingress) *networkingv1.Ingress
+	// Restore undoes Disable
+	Restore(ingress *networkingv1.Ingress)
+}
+
+// NewDisableStrategy returns the DisableStrategy with the given name
+func NewDisableStrategy(name DisableStrategyName) (DisableStrategy, error) {
+	switch name {
+	case DisableStrategyClassSwap, "":
+		return classSwapStrategy{}, nil
+	case DisableStrategyHostPrefix:
+		return hostPrefixStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown disable strategy %q", name)
+	}
+}
+
+// disableStrategyOf returns the DisableStrategy that disabled an Ingress, Ingresses disabled before the
+// strategy was recorded had their class swapped
+func disableStrategyOf(ingress *networkingv1.Ingress) DisableStrategy {
+	strategy, err := NewDisableStrategy(DisableStrategyName(ingress.Annotations[DisableStrategyAnnotation]))
+	if err != nil {
+		return classSwapStrategy{}
+	}
+	return strategy
+}
+
+// HasDisabledMarker reports whether the Ingress carries the marker of the strategy that disabled it
+func HasDisabledMarker(ingress *networkingv1.Ingress) bool {
+	if ingress == nil || ingress.Annotations[IngressDisabledAnnotation] == "" {
+		return false
+	}
+	return disableStrategyOf(ingress).Disabled(ingress)
+}
+
+// RestoreDisabledIngress hands a disabled Ingress back to its legacy controller and drops the annotations
+// recorded while disabling it
+func RestoreDisabledIngress(ingress *networkingv1.Ingress) {
+	disableStrategyOf(ingress).Restore(ingress)
+	delete(ingress.Annotations, IngressDisabledAnnotation)
+	delete(ingress.Annotations, OriginalSpecHashAnnotation)
+	delete(ingress.Annotations, DisableStrategyAnnotation)
+}
+
+// withOriginalSpec returns a copy of a disabled Ingress as it was before it was disabled, so it translates
+// onto the same Gateway and hostnames as before
+func withOriginalSpec(ingress *networkingv1.Ingress) *networkingv1.Ingress {
+	return disableStrategyOf(ingress).Original(ingress)
+}
+
+// classSwapStrategy disables an Ingress by switching it to DisabledIngressClassName
+type classSwapStrategy struct{}
+
+func (classSwapStrategy) Name() DisableStrategyName {
+	return DisableStrategyClassSwap
+}
+
+func (classSwapStrategy) Disable(ingress *networkingv1.Ingress) (bool, error) {
+	if ingress.Annotations == nil {
+		ingress.Annotations = make(map[string]string)
+	}
+	// Save the original ingressClassName and ingress.class annotation if they exist
+	if ingress.Spec.IngressClassName != nil && *ingress.Spec.IngressClassName != fmt.Sprintf("%t", true) {
+		if _, exists := ingress.Annotations[OriginalIngressClassNameAnnotation]; !exists {
+			ingress.Annotations[OriginalIngressClassNameAnnotation] = *ingress.Spec.IngressClassName
+		}
+	}
+	if class, exists := ingress.Annotations[IngressClassAnnotation]; exists && class != "" {
+		if _, saved := ingress.Annotations[OriginalIngressClassAnnotation]; !saved {
+			ingress.Annotations[OriginalIngressClassAnnotation] = class
+		}
+	}
+
+	modified := false
+	if ingress.Spec.IngressClassName == nil || *ingress.Spec.IngressClassName != DisabledIngressClassName {
+		ingress.Spec.IngressClassName = ptr.To(DisabledIngressClassName)
+		modified = true
+	}
+	if ingress.Annotations[IngressClassAnnotation] != DisabledIngressClassName {
+		ingress.Annotations[IngressClassAnnotation] = DisabledIngressClassName
+		modified = true
+	}
+	return modified, nil
+}
+
+func (classSwapStrategy) Disabled(ingress *networkingv1.Ingress) bool {
+	if ingress.Spec.IngressClassName != nil && *ingress.Spec.IngressClassName == DisabledIngressClassName {
+		return true
+	}
+	return ingress.Annotations[IngressClassAnnotation] == DisabledIngressClassName
+}
+
+func (classSwapStrategy) Original(ingress *networkingv1.Ingress) *networkingv1.Ingress {
+	original := ingress.DeepCopy()
+	restoreOriginalClass(original)
+	return original
+}
+
+func (classSwapStrategy) Restore(ingress *networkingv1.Ingress) {
+	restoreOriginalClass(ingress)
+	delete(ingress.Annotations, OriginalIngressClassNameAnnotation)
+	delete(ingress.Annotations, OriginalIngressClassAnnotation)
+}
+
+// restoreOriginalClass puts the saved class of an Ingress back in place
+func restoreOriginalClass(ingress *networkingv1.Ingress) {
+	if className := ingress.Annotations[OriginalIngressClassNameAnnotation]; className != "" {
+		ingress.Spec.IngressClassName = &className
+	} else {
+		ingress.Spec.IngressClassName = nil
+	}
+	if ingress.Annotations == nil {
+		return
+	}
+	if class := ingress.Annotations[OriginalIngressClassAnnotation]; class != "" {
+		ingress.Annotations[IngressClassAnnotation] = class
+	} else {
+		delete(ingress.Annotations, IngressClassAnnotation)
+	}
+}
+
+// hostPrefixStrategy disables an Ingress by prefixing its hosts with DisabledHostPrefix, leaving the class
+// alone. The legacy controller keeps the Ingress but no longer matches any request to it.
+type hostPrefixStrategy struct{}
+
+func (hostPrefixStrategy) Name() DisableStrategyName {
+	return DisableStrategyHostPrefix
+}
+
+func (hostPrefixStrategy) Disable(ingress *networkingv1.Ingress) (bool, error) {
+	// The default backend serves requests for any host, no prefix takes it away
+	if ingress.Spec.DefaultBackend != nil {
+		return false, fmt.Errorf("%w: the host-prefix strategy cannot disable spec.defaultBackend", ErrCannotDisable)
+	}
+	// Prefix a copy first, so an Ingress with one host that is too long is not half disabled
+	spec := ingress.Spec.DeepCopy()
+	modified := false
+	for i := range spec.Rules {
+		host, err := prefixHost(spec.Rules[i].Host)
+		if err != nil {
+			return false, fmt.Errorf("%w: %w", ErrCannotDisable, err)
+		}
+		spec.Rules[i].Host = host
+		modified = true
+	}
+	for i := range spec.TLS {
+		for j, tlsHost := range spec.TLS[i].Hosts {
+			if tlsHost == "" {
+				continue
+			}
+			host, err := prefixHost(tlsHost)
+			if err != nil {
+				return false, fmt.Errorf("%w: %w", ErrCannotDisable, err)
+			}
+			spec.TLS[i].Hosts[j] = host
+			modified = true
+		}
+	}
+	ingress.Spec = *spec
+	return modified, nil
+}
+
+func (hostPrefixStrategy) Disabled(ingress *networkingv1.Ingress) bool {
+	for _, rule := range ingress.Spec.Rules {
+		if hostPrefixed(rule.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s hostPrefixStrategy) Original(ingress *networkingv1.Ingress) *networkingv1.Ingress {
+	original := ingress.DeepCopy()
+	s.Restore(original)
+	return original
+}
+
+func (hostPrefixStrategy) Restore(ingress *networkingv1.Ingress) {
+	for i := range ingress.Spec.Rules {
+		ingress.Spec.Rules[i].Host = unprefixHost(ingress.Spec.Rules[i].Host)
+	}
+	for i := range ingress.Spec.TLS {
+		for j, tlsHost := range ingress.Spec.TLS[i].Hosts {
+			ingress.Spec.TLS[i].Hosts[j] = unprefixHost(tlsHost)
+		}
+	}
+}
+
+// prefixHost prefixes the first non-wildcard label of a host, catch-all rules get a reserved host instead.
+// Hosts that already carry the reserved token are refused, unprefixHost could not tell them apart.
+func prefixHost(host string) (string, error) {
+	if hostPrefixed(host) {
+		return host, fmt.Errorf("host %q already starts with the reserved %q", host, DisabledHostPrefix)
+	}
+	if host == "" {
+		return disabledCatchAllHost, nil
+	}
+	wildcard, name := "", host
+	if strings.HasPrefix(host, "*.") {
+		wildcard, name = "*.", strings.TrimPrefix(host, "*.")
+	}
+	label, _, _ := strings.Cut(name, ".")
+	if len(DisabledHostPrefix)+len(label) > maxHostLabelLength {
+		return host, fmt.Errorf("host %q is too long to be prefixed with %q", host, DisabledHostPrefix)
+	}
+	return wildcard + DisabledHostPrefix + name, nil
+}
+
+// hostPrefixed reports whether a host was prefixed by prefixHost
+func hostPrefixed(host string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(host, "*."), DisabledHostPrefix)
+}
+
+// unprefixHost reverses prefixHost
+func unprefixHost(host string) string {
+	if host == disabledCatchAllHost {
+		return ""
+	}
+	if strings.HasPrefix(host, "*.") {
+		return "*." + strings.TrimPrefix(strings.TrimPrefix(host, "*."), DisabledHostPrefix)
+	}
+	return strings.TrimPrefix(host, DisabledHostPrefix)
+}
@@ -0,0 +1,130 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+func TestPrefixHostRoundTrip(t *testing.T) {
+	longestLabel := strings.Repeat("a", maxHostLabelLength-len(DisabledHostPrefix))
+	tests := []struct {
+		name    string
+		host    string
+		want    string
+		wantErr bool
+	}{
+		{"plain host", "shop.example.com", "disabled--shop.example.com", false},
+		{"single label", "localhost", "disabled--localhost", false},
+		{"wildcard host", "*.example.com", "*.disabled--example.com", false},
+		{"catch-all", "", disabledCatchAllHost, false},
+		{"label at the limit", longestLabel + ".example.com", DisabledHostPrefix + longestLabel + ".example.com", false},
+		{"wildcard label at the limit", "*." + longestLabel + ".example.com",
+			"*." + DisabledHostPrefix + longestLabel + ".example.com", false},
+		{"label over the limit", longestLabel + "a.example.com", "", true},
+		{"wildcard label over the limit", "*." + longestLabel + "a.example.com", "", true},
+		{"already prefixed", "disabled--shop.example.com", "", true},
+		{"wildcard already prefixed", "*.disabled--example.com", "", true},
+		{"reserved catch-all host", disabledCatchAllHost, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := prefixHost(tt.host)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("prefixHost(%q) = %q, want an error", tt.host, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("prefixHost(%q) error = %v", tt.host, err)
+			}
+			if got != tt.want {
+				t.Errorf("prefixHost(%q) = %q, want %q", tt.host, got, tt.want)
+			}
+			if !hostPrefixed(got) {
+				t.Errorf("hostPrefixed(%q) = false", got)
+			}
+			if back := unprefixHost(got); back != tt.host {
+				t.Errorf("unprefixHost(%q) = %q, want %q", got, back, tt.host)
+			}
+		})
+	}
+}
+
+func TestHostPrefixStrategyDisable(t *testing.T) {
+	backend := &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "web"}}
+	rules := func(hosts ...string) []networkingv1.IngressRule {
+		var out []networkingv1.IngressRule
+		for _, host := range hosts {
+			out = append(out, networkingv1.IngressRule{Host: host})
+		}
+		return out
+	}
+	tests := []struct {
+		name      string
+		spec      networkingv1.IngressSpec
+		wantHosts []string
+		wantErr   bool
+	}{
+		{"rules and TLS", networkingv1.IngressSpec{
+			Rules: rules("shop.example.com", ""),
+			TLS:   []networkingv1.IngressTLS{{Hosts: []string{"shop.example.com"}}},
+		}, []string{"disabled--shop.example.com", disabledCatchAllHost}, false},
+		{"default backend only", networkingv1.IngressSpec{DefaultBackend: backend}, nil, true},
+		{"default backend and rules", networkingv1.IngressSpec{
+			DefaultBackend: backend,
+			Rules:          rules("shop.example.com"),
+		}, nil, true},
+		{"one host too long", networkingv1.IngressSpec{
+			Rules: rules("shop.example.com", strings.Repeat("a", maxHostLabelLength)+".example.com"),
+		}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ingress := &networkingv1.Ingress{Spec: *tt.spec.DeepCopy()}
+			modified, err := hostPrefixStrategy{}.Disable(ingress)
+			if tt.wantErr {
+				if !errors.Is(err, ErrCannotDisable) {
+					t.Fatalf("Disable() error = %v, want ErrCannotDisable", err)
+				}
+				if modified || !reflect.DeepEqual(ingress.Spec, tt.spec) {
+					t.Errorf("Disable() changed the Ingress it refused: %+v", ingress.Spec)
+				}
+				return
+			}
+			if err != nil || !modified {
+				t.Fatalf("Disable() = %v, %v, want true, nil", modified, err)
+			}
+			var hosts []string
+			for _, rule := range ingress.Spec.Rules {
+				hosts = append(hosts, rule.Host)
+			}
+			if !reflect.DeepEqual(hosts, tt.wantHosts) {
+				t.Errorf("hosts = %v, want %v", hosts, tt.wantHosts)
+			}
+			if original := (hostPrefixStrategy{}).Original(ingress); !reflect.DeepEqual(original.Spec, tt.spec) {
+				t.Errorf("Original() = %+v, want %+v", original.Spec, tt.spec)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+)
+
+// driftResyncKey marks the context of a reconcile started by the drift resync
+type driftResyncKey struct{}
+
+// driftResync periodically requeues every Ingress of a reconciler, so derived Gateways and routes that were
+// edited by hand are rendered again even though the Ingress did not change
+type driftResync struct {
+	interval time.Duration
+	events   chan event.GenericEvent
+}
+
+func newDriftResync(interval time.Duration) *driftResync {
+	return &driftResync{interval: interval, events: make(chan event.GenericEvent, 1)}
+}
+
+// Start implements manager.Runnable
+func (d *driftResync) Start(ctx context.Context) error {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			select {
+			case d.events <- event.GenericEvent{Object: &networkingv1.Ingress{}}:
+			default:
+				// The previous resync is still being enqueued
+			}
+		}
+	}
+}
+
+// enqueueIngressesForResync requeues every Ingress for the drift resync, bypassing the reconcile cache
+func (r *IngressReconciler) enqueueIngressesForResync(ctx context.Context, _ client.Object) []reconcile.Request {
+	requests := r.enqueueAllIngresses(ctx)
+	r.driftResyncMu.Lock()
+	if r.driftResyncPending == nil {
+		r.driftResyncPending = make(map[types.NamespacedName]struct{}, len(requests))
+	}
+	for _, request := range requests {
+		r.driftResyncPending[request.NamespacedName] = struct{}{}
+	}
+	r.driftResyncMu.Unlock()
+	for _, request := range requests {
+		ingress := &networkingv1.Ingress{}
+		if err := r.Get(ctx, request.NamespacedName, ingress); err == nil {
+			r.evictReconcileCache(ctx, ingress)
+		}
+	}
+	log.FromContext(ctx).V(1).Info("Resyncing Ingresses for drift", "ingresses", len(requests))
+	return requests
+}
+
+// withDriftResync marks ctx when the reconcile of key was requested by the drift resync
+func (r *IngressReconciler) withDriftResync(ctx context.Context, key types.NamespacedName) context.Context {
+	r.driftResyncMu.Lock()
+	defer r.driftResyncMu.Unlock()
+	if _, ok := r.driftResyncPending[key]; !ok {
+		return ctx
+	}
+	delete(r.driftResyncPending, key)
+	return context.WithValue(ctx, driftResyncKey{}, true)
+}
+
+// inDriftResync reports whether the reconcile was requested by the drift resync
+func inDriftResync(ctx context.Context) bool {
+	resync, _ := ctx.Value(driftResyncKey{}).(bool)
+	return resync
+}
+
+// recordDrift counts a write the drift resync made. The Ingress did not change since its last reconcile,
+// so whatever the write repairs was changed on the derived resource itself.
+func (r *IngressReconciler) recordDrift(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	kind, operation, namespace, name string,
+) {
+	if !inDriftResync(ctx) {
+		return
+	}
+	log.FromContext(ctx).Info("Repaired drift of derived resource",
+		"kind", kind,
+		"operation", operation,
+		"namespace", namespace,
+		"name", name)
+	metrics.DriftRepairsTotal.WithLabelValues(kind, operation, namespace, name).Inc()
+	r.recordNormal(ingress, "DriftRepaired",
+		fmt.Sprintf("%s %s/%s was changed outside of the Ingress and has been restored (%s)",
+			kind, namespace, name, operation))
+}
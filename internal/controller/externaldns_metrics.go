@@ -0,0 +1,77 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+)
+
+const (
+	// ExternalDNSStateEnabled means external-dns publishes the Ingress hostnames
+	ExternalDNSStateEnabled = "enabled"
+	// ExternalDNSStateDisabled means the Ingress is annotation-only for external-dns
+	ExternalDNSStateDisabled = "disabled"
+
+	ExternalDNSRewriteDisable = "disable"
+	ExternalDNSRewriteRestore = "restore"
+)
+
+// externalDNSState returns the external-dns state of an Ingress
+func externalDNSState(ingress *networkingv1.Ingress) string {
+	if ingress.Annotations[ExternalDNSIngressHostnameSource] == ExternalDNSHostnameSourceAnnotationOnly {
+		return ExternalDNSStateDisabled
+	}
+	return ExternalDNSStateEnabled
+}
+
+// trackExternalDNSState records the external-dns state of an Ingress (nil ingress forgets key) and
+// updates the per-state gauge. A disabled Ingress turning enabled again (e.g. by the reenabler) counts
+// as a restore.
+func (r *IngressReconciler) trackExternalDNSState(key string, ingress *networkingv1.Ingress) {
+	r.externalDNSStatesMu.Lock()
+	defer r.externalDNSStatesMu.Unlock()
+	if r.externalDNSStates == nil {
+		r.externalDNSStates = make(map[string]string)
+	}
+
+	previous, known := r.externalDNSStates[key]
+	if ingress == nil {
+		if !known {
+			return
+		}
+		delete(r.externalDNSStates, key)
+	} else {
+		state := externalDNSState(ingress)
+		if known && previous == state {
+			return
+		}
+		r.externalDNSStates[key] = state
+		if known && previous == ExternalDNSStateDisabled && state == ExternalDNSStateEnabled {
+			metrics.ExternalDNSRewritesTotal.WithLabelValues(ExternalDNSRewriteRestore).Inc()
+		}
+	}
+
+	counts := map[string]int{ExternalDNSStateEnabled: 0, ExternalDNSStateDisabled: 0}
+	for _, state := range r.externalDNSStates {
+		counts[state]++
+	}
+	for state, count := range counts {
+		metrics.ExternalDNSIngresses.WithLabelValues(state).Set(float64(count))
+	}
+}
@@ -0,0 +1,480 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+// FanInConflictPolicy decides what happens when Ingresses of several clusters use the same hostname
+type FanInConflictPolicy string
+
+const (
+	// FanInConflictPriority gives a hostname to the first cluster using it, the local cluster first and
+	// then the sources in the configured order; Ingresses of other clusters using it are held back
+	FanInConflictPriority FanInConflictPolicy = "priority"
+	// FanInConflictMerge attaches the routes of every cluster, Gateway API precedence decides per path
+	FanInConflictMerge FanInConflictPolicy = "merge"
+)
+
+// FanInSource is a remote cluster whose Ingresses are translated into local HTTPRoutes and Gateways
+type FanInSource struct {
+	Name    string
+	Cluster cluster.Cluster
+	// HostnamePrefix is added as first label of every hostname of the source
+	HostnamePrefix string
+	// HostnameRewriteFrom and HostnameRewriteTo replace the global hostname rewrite when set
+	HostnameRewriteFrom string
+	HostnameRewriteTo   string
+}
+
+// applyHostnameTransform overrides the hostname transformation of cfg with the one of the source
+func (s *FanInSource) applyHostnameTransform(cfg *translator.Config) {
+	if s == nil {
+		return
+	}
+	if s.HostnameRewriteFrom != "" {
+		cfg.HostnameRewriteFrom = s.HostnameRewriteFrom
+		cfg.HostnameRewriteTo = s.HostnameRewriteTo
+	}
+	cfg.HostnamePrefix = s.HostnamePrefix
+}
+
+type fanInClaim struct {
+	source string
+	key    types.NamespacedName
+}
+
+// FanIn merges Ingresses of remote clusters into the local Gateways and resolves hostname conflicts
+// between the clusters. The local cluster is the source with the empty name.
+type FanIn struct {
+	Policy  FanInConflictPolicy
+	Sources []*FanInSource
+
+	mu     sync.Mutex
+	claims map[string]map[fanInClaim]types.UID
+	events map[string]chan event.GenericEvent
+}
+
+// NewFanIn creates the fan-in state for sources, listed by decreasing priority
+func NewFanIn(policy FanInConflictPolicy, sources []*FanInSource) *FanIn {
+	return &FanIn{
+		Policy:  policy,
+		Sources: sources,
+		claims:  make(map[string]map[fanInClaim]types.UID),
+		events:  make(map[string]chan event.GenericEvent),
+	}
+}
+
+// Source returns the source called name, nil for the local cluster or unknown names
+func (f *FanIn) Source(name string) *FanInSource {
+	if f == nil {
+		return nil
+	}
+	for _, source := range f.Sources {
+		if source.Name == name {
+			return source
+		}
+	}
+	return nil
+}
+
+func (f *FanIn) priority(source string) int {
+	if source == "" {
+		return 0
+	}
+	for i, s := range f.Sources {
+		if s.Name == source {
+			return i + 1
+		}
+	}
+	return len(f.Sources) + 1
+}
+
+// requeueEvents returns the channel Ingresses of source are requeued through when they win or lose
+// a hostname because of a change in another cluster
+func (f *FanIn) requeueEvents(source string) chan event.GenericEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch, ok := f.events[source]
+	if !ok {
+		ch = make(chan event.GenericEvent, 1024)
+		f.events[source] = ch
+	}
+	return ch
+}
+
+// Claim records the hostnames used by an Ingress of source and returns the hostnames owned by
+// another cluster with their owner. Ingresses of other clusters whose ownership changed are requeued.
+func (f *FanIn) Claim(
+	source string,
+	ingress *networkingv1.Ingress,
+	hostnames []string,
+) map[string]string {
+	if f == nil || f.Policy != FanInConflictPriority {
+		return nil
+	}
+	claim := fanInClaim{source: source, key: types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}}
+	wanted := make(map[string]bool, len(hostnames))
+	for _, hostname := range hostnames {
+		wanted[hostname] = true
+	}
+
+	f.mu.Lock()
+	affected := make(map[string]bool)
+	for hostname, claims := range f.claims {
+		if _, ok := claims[claim]; ok && !wanted[hostname] {
+			affected[hostname] = true
+		}
+	}
+	for hostname := range wanted {
+		if _, ok := f.claims[hostname][claim]; !ok {
+			affected[hostname] = true
+		}
+	}
+	before := f.ownersLocked(affected)
+	for hostname := range affected {
+		if wanted[hostname] {
+			if f.claims[hostname] == nil {
+				f.claims[hostname] = make(map[fanInClaim]types.UID)
+			}
+			f.claims[hostname][claim] = ingress.UID
+		} else {
+			f.forgetLocked(hostname, claim)
+		}
+	}
+	for hostname := range wanted {
+		f.claims[hostname][claim] = ingress.UID
+	}
+	requeue := f.ownerChangesLocked(before, claim)
+
+	lost := make(map[string]string)
+	for hostname := range wanted {
+		if owner := f.ownerLocked(hostname); owner != source {
+			lost[hostname] = owner
+		}
+	}
+	f.mu.Unlock()
+
+	f.requeue(requeue)
+	return lost
+}
+
+// Release forgets the hostnames of a deleted Ingress of source
+func (f *FanIn) Release(source string, key types.NamespacedName) {
+	if f == nil || f.Policy != FanInConflictPriority {
+		return
+	}
+	claim := fanInClaim{source: source, key: key}
+
+	f.mu.Lock()
+	affected := make(map[string]bool)
+	for hostname, claims := range f.claims {
+		if _, ok := claims[claim]; ok {
+			affected[hostname] = true
+		}
+	}
+	before := f.ownersLocked(affected)
+	for hostname := range affected {
+		f.forgetLocked(hostname, claim)
+	}
+	requeue := f.ownerChangesLocked(before, claim)
+	f.mu.Unlock()
+
+	f.requeue(requeue)
+}
+
+func (f *FanIn) forgetLocked(hostname string, claim fanInClaim) {
+	delete(f.claims[hostname], claim)
+	if len(f.claims[hostname]) == 0 {
+		delete(f.claims, hostname)
+	}
+}
+
+// ownerLocked returns the source owning hostname: the one with the highest priority claiming it
+func (f *FanIn) ownerLocked(hostname string) string {
+	owner, best := "", -1
+	for claim := range f.claims[hostname] {
+		if p := f.priority(claim.source); best < 0 || p < best {
+			owner, best = claim.source, p
+		}
+	}
+	return owner
+}
+
+func (f *FanIn) ownersLocked(hostnames map[string]bool) map[string]string {
+	owners := make(map[string]string, len(hostnames))
+	for hostname := range hostnames {
+		owners[hostname] = f.ownerLocked(hostname)
+	}
+	return owners
+}
+
+// ownerChangesLocked returns the claims, other than self, on hostnames whose owner changed
+func (f *FanIn) ownerChangesLocked(before map[string]string, self fanInClaim) map[fanInClaim]types.UID {
+	requeue := make(map[fanInClaim]types.UID)
+	for hostname, owner := range before {
+		if f.ownerLocked(hostname) == owner {
+			continue
+		}
+		for claim, uid := range f.claims[hostname] {
+			if claim != self {
+				requeue[claim] = uid
+			}
+		}
+	}
+	return requeue
+}
+
+func (f *FanIn) requeue(claims map[fanInClaim]types.UID) {
+	for claim, uid := range claims {
+		ch := f.requeueEvents(claim.source)
+		ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+			Namespace: claim.key.Namespace,
+			Name:      claim.key.Name,
+			UID:       uid,
+		}}
+		// The controller may not consume events yet, never block the reconcile
+		go func() { ch <- event.GenericEvent{Object: ingress} }()
+	}
+}
+
+// fanInSourceName returns the name of the cluster the reconciler reads Ingresses from, empty for local
+func (r *IngressReconciler) fanInSourceName() string {
+	if r.SourceCluster == nil {
+		return ""
+	}
+	return r.SourceCluster.Name
+}
+
+// claimFanInHostnames claims the hostnames of the Ingress and reports whether it may be translated.
+// Ingresses losing a hostname to another cluster are held back and their HTTPRoutes removed.
+func (r *IngressReconciler) claimFanInHostnames(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	trans *translator.Translator,
+) bool {
+	if r.FanIn == nil {
+		return true
+	}
+	hostnames := make([]string, 0, len(ingress.Spec.Rules))
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" {
+			hostnames = append(hostnames, trans.TransformHostname(rule.Host))
+		}
+	}
+	lost := r.FanIn.Claim(r.fanInSourceName(), ingress, hostnames)
+	if len(lost) == 0 {
+		return true
+	}
+
+	conflicts := make([]string, 0, len(lost))
+	for hostname, owner := range lost {
+		if owner == "" {
+			owner = "local cluster"
+		}
+		conflicts = append(conflicts, fmt.Sprintf("%s (%s)", hostname, owner))
+	}
+	sort.Strings(conflicts)
+	logger := log.FromContext(ctx)
+	logger.Info("Skipping Ingress, its hostnames are served from another cluster",
+		"namespace", ingress.Namespace, "name", ingress.Name, "conflicts", strings.Join(conflicts, ", "))
+	r.recordWarning(ingress, "FanInHostnameConflict",
+		fmt.Sprintf("Hostnames are served from a cluster with higher priority: %s", strings.Join(conflicts, ", ")))
+	metrics.IngressReconcileSkipsTotal.WithLabelValues("fan-in-conflict", ingress.Namespace, ingress.Name).Inc()
+	if err := r.deleteManagedHTTPRoutes(ctx, ingress, logger); err != nil {
+		logger.Error(err, "failed to remove HTTPRoutes of Ingress losing a hostname")
+	}
+	if err := r.applyGRPCRoutes(ctx, ingress, nil); err != nil {
+		logger.Error(err, "failed to remove GRPCRoutes of Ingress losing a hostname")
+	}
+	if err := r.applyTLSRoutes(ctx, ingress, nil); err != nil {
+		logger.Error(err, "failed to remove TLSRoutes of Ingress losing a hostname")
+	}
+	return false
+}
+
+// fanInRequeueHandler requeues Ingresses whose hostname ownership changed, bypassing the reconcile cache
+func (r *IngressReconciler) fanInRequeueHandler() handler.EventHandler {
+	return handler.Funcs{
+		GenericFunc: func(
+			ctx context.Context,
+			e event.GenericEvent,
+			q workqueue.TypedRateLimitingInterface[reconcile.Request],
+		) {
+			ingress, ok := e.Object.(*networkingv1.Ingress)
+			if !ok {
+				return
+			}
+			r.evictReconcileCache(ctx, ingress)
+			q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+				Namespace: ingress.Namespace,
+				Name:      ingress.Name,
+			}})
+		},
+	}
+}
+
+// resourceOwner returns the owner of resources derived from the Ingress, nil when they must outlive it
+// (remove mode) or when the Ingress lives in another cluster
+func (r *IngressReconciler) resourceOwner(ingress *networkingv1.Ingress) client.Object {
+	if r.settings().IngressPostProcessingMode == IngressPostProcessingModeRemove || r.SourceCluster != nil {
+		return nil
+	}
+	return ingress
+}
+
+// setRouteOwner links a generated HTTPRoute, GRPCRoute or TLSRoute to its Ingress: by ownerReference for
+// local Ingresses and by the source cluster annotation for Ingresses of a fan-in source
+func (r *IngressReconciler) setRouteOwner(route client.Object, ingress *networkingv1.Ingress) {
+	if r.SourceCluster == nil {
+		setRouteOwnerReference(route, ingress, r.OwnershipMode == OwnershipModeFinalizer)
+		return
+	}
+	annotations := route.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[translator.SourceClusterAnnotation] = r.SourceCluster.Name
+	route.SetAnnotations(annotations)
+}
+
+// sourceClient returns the client for the cluster the HTTPRoute's Ingress lives in
+func (r *HTTPRouteReconciler) sourceClient(httpRoute *gatewayv1.HTTPRoute) (client.Client, error) {
+	name := httpRoute.Annotations[translator.SourceClusterAnnotation]
+	if name == "" {
+		return r.Client, nil
+	}
+	source := r.FanIn.Source(name)
+	if source == nil {
+		return nil, fmt.Errorf("unknown fan-in source cluster %q", name)
+	}
+	return source.Cluster.GetClient(), nil
+}
+
+// listenerTranslator returns a translator mapping the hostnames of the HTTPRoute's Ingress like the
+// reconciler that generated the HTTPRoute
+func (r *HTTPRouteReconciler) listenerTranslator(httpRoute *gatewayv1.HTTPRoute) *translator.Translator {
+	settings := r.settings()
+	cfg := translator.Config{
+		GatewayNamespace:        r.GatewayNamespace,
+		HostnameRewriteFrom:     settings.HostnameRewriteFrom,
+		HostnameRewriteTo:       settings.HostnameRewriteTo,
+		HostnameRenames:         r.HostnameRenames.Primary(),
+		WildcardListenerDomains: r.WildcardListenerDomains,
+	}
+	if httpRoute != nil {
+		r.FanIn.Source(httpRoute.Annotations[translator.SourceClusterAnnotation]).applyHostnameTransform(&cfg)
+		if translator.IsRenameRoute(httpRoute) {
+			cfg.HostnameRenames = translator.ParseHostnameRenames(httpRoute.Annotations[translator.HostnameRenamesAnnotation])
+		}
+	}
+	return translator.New(cfg)
+}
+
+// sourceClusterClient reads and writes Ingresses and IngressClasses in a fan-in source cluster and
+// everything else in the local cluster
+type sourceClusterClient struct {
+	client.Client
+	source client.Client
+}
+
+// NewSourceClusterClient routes Ingress access of local to source
+func NewSourceClusterClient(local, source client.Client) client.Client {
+	return &sourceClusterClient{Client: local, source: source}
+}
+
+func (c *sourceClusterClient) clientFor(obj runtime.Object) client.Client {
+	switch obj.(type) {
+	case *networkingv1.Ingress, *networkingv1.IngressList, *networkingv1.IngressClass, *networkingv1.IngressClassList:
+		return c.source
+	default:
+		return c.Client
+	}
+}
+
+func (c *sourceClusterClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object,
+	opts ...client.GetOption) error {
+	return c.clientFor(obj).Get(ctx, key, obj, opts...)
+}
+
+func (c *sourceClusterClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return c.clientFor(list).List(ctx, list, opts...)
+}
+
+func (c *sourceClusterClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	return c.clientFor(obj).Create(ctx, obj, opts...)
+}
+
+func (c *sourceClusterClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return c.clientFor(obj).Delete(ctx, obj, opts...)
+}
+
+func (c *sourceClusterClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return c.clientFor(obj).Update(ctx, obj, opts...)
+}
+
+func (c *sourceClusterClient) Patch(ctx context.Context, obj client.Object, patch client.Patch,
+	opts ...client.PatchOption) error {
+	return c.clientFor(obj).Patch(ctx, obj, patch, opts...)
+}
+
+func (c *sourceClusterClient) Status() client.SubResourceWriter {
+	return &sourceClusterStatusWriter{SubResourceWriter: c.Client.Status(), source: c.source.Status()}
+}
+
+type sourceClusterStatusWriter struct {
+	client.SubResourceWriter
+	source client.SubResourceWriter
+}
+
+func (w *sourceClusterStatusWriter) writerFor(obj runtime.Object) client.SubResourceWriter {
+	if _, ok := obj.(*networkingv1.Ingress); ok {
+		return w.source
+	}
+	return w.SubResourceWriter
+}
+
+func (w *sourceClusterStatusWriter) Update(ctx context.Context, obj client.Object,
+	opts ...client.SubResourceUpdateOption) error {
+	return w.writerFor(obj).Update(ctx, obj, opts...)
+}
+
+func (w *sourceClusterStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch,
+	opts ...client.SubResourcePatchOption) error {
+	return w.writerFor(obj).Patch(ctx, obj, patch, opts...)
+}
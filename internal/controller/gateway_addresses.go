@@ -0,0 +1,145 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+// syncGatewayAddresses makes the pinned spec.addresses of a managed Gateway match the GatewayAddresses
+// rules. Addresses set by hand are kept, and an address another Gateway already requests is not
+// requested a second time. Returns whether the Gateway changed.
+func (r *HTTPRouteReconciler) syncGatewayAddresses(ctx context.Context, gateway *gatewayv1.Gateway) bool {
+	logger := log.FromContext(ctx)
+	desired := r.GatewayAddresses.For(gateway.Name)
+	previous := gateway.Annotations[translator.PinnedAddressesAnnotation]
+	if desired == nil && previous == "" {
+		return false
+	}
+
+	if len(desired) > 0 {
+		held, err := r.addressesOfOtherGateways(ctx, gateway)
+		if err != nil {
+			logger.Error(err, "failed to list Gateways, leaving Gateway addresses unchanged",
+				"namespace", gateway.Namespace,
+				"name", gateway.Name)
+			return false
+		}
+		kept := make([]gatewayv1.GatewaySpecAddress, 0, len(desired))
+		for _, address := range desired {
+			if other, ok := held[translator.GatewayAddressKey(address)]; ok {
+				logger.Error(fmt.Errorf("address %s is already requested by Gateway %s", address.Value, other),
+					"not pinning Gateway address",
+					"namespace", gateway.Namespace,
+					"name", gateway.Name)
+				metrics.GatewayAddressConflictsTotal.WithLabelValues(gateway.Namespace, gateway.Name).Inc()
+				continue
+			}
+			kept = append(kept, address)
+		}
+		desired = kept
+	}
+
+	pinnedBefore := make(map[string]bool)
+	for _, key := range strings.Split(previous, ",") {
+		pinnedBefore[key] = true
+	}
+	present := make(map[string]bool)
+	addresses := make([]gatewayv1.GatewaySpecAddress, 0, len(gateway.Spec.Addresses)+len(desired))
+	for _, address := range gateway.Spec.Addresses {
+		key := translator.GatewayAddressKey(address)
+		if pinnedBefore[key] || present[key] {
+			continue
+		}
+		present[key] = true
+		addresses = append(addresses, address)
+	}
+	for _, address := range desired {
+		if key := translator.GatewayAddressKey(address); !present[key] {
+			present[key] = true
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) == 0 {
+		addresses = nil
+	}
+
+	pinned := translator.FormatGatewayAddresses(desired)
+	if pinned == previous && equality.Semantic.DeepEqual(addresses, gateway.Spec.Addresses) {
+		return false
+	}
+	gateway.Spec.Addresses = addresses
+	if pinned == "" {
+		delete(gateway.Annotations, translator.PinnedAddressesAnnotation)
+	} else {
+		if gateway.Annotations == nil {
+			gateway.Annotations = make(map[string]string)
+		}
+		gateway.Annotations[translator.PinnedAddressesAnnotation] = pinned
+	}
+	logger.Info("Pinned Gateway addresses",
+		"namespace", gateway.Namespace,
+		"name", gateway.Name,
+		"addresses", pinned)
+	return true
+}
+
+// addressesOfOtherGateways maps the spec.addresses of every other Gateway to the Gateway requesting it
+func (r *HTTPRouteReconciler) addressesOfOtherGateways(
+	ctx context.Context,
+	gateway *gatewayv1.Gateway,
+) (map[string]string, error) {
+	gateways := &gatewayv1.GatewayList{}
+	if err := r.List(ctx, gateways); err != nil {
+		return nil, err
+	}
+	held := make(map[string]string)
+	for i := range gateways.Items {
+		other := &gateways.Items[i]
+		if other.Namespace == gateway.Namespace && other.Name == gateway.Name {
+			continue
+		}
+		for _, address := range other.Spec.Addresses {
+			held[translator.GatewayAddressKey(address)] = other.Namespace + "/" + other.Name
+		}
+	}
+	return held, nil
+}
+
+// syncInfrastructureAnnotations makes the pinned spec.infrastructure.annotations of a managed Gateway match
+// the InfraAnnotationsByGateway rules, removing the ones dropped from the configuration.
+// Returns whether the Gateway changed.
+func (r *HTTPRouteReconciler) syncInfrastructureAnnotations(ctx context.Context, gateway *gatewayv1.Gateway) bool {
+	desired := r.InfraAnnotationsByGateway.For(gateway.Name)
+	if !translator.SyncPinnedInfrastructureAnnotations(gateway, desired) {
+		return false
+	}
+	log.FromContext(ctx).Info("Synced Gateway infrastructure annotations",
+		"namespace", gateway.Namespace,
+		"name", gateway.Name,
+		"annotations", gateway.Annotations[translator.PinnedInfrastructureAnnotationsAnnotation])
+	return true
+}
@@ -0,0 +1,118 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// ConditionGatewayAPIAvailable tells whether the Gateway API kinds the Ingress is translated to are installed
+const ConditionGatewayAPIAvailable = "GatewayAPIAvailable"
+
+// errGatewayAPIUnavailable marks reconciles that could not write anything because the Gateway or HTTPRoute
+// CRDs are missing
+var errGatewayAPIUnavailable = errors.New("gateway API CRDs are not installed")
+
+// missingCoreGatewayAPIKinds returns the Gateway API kinds every translation needs but the cluster lacks
+func (r *IngressReconciler) missingCoreGatewayAPIKinds() []string {
+	return r.GatewayAPI.Missing(utils.GatewayKind, utils.HTTPRouteKind)
+}
+
+// gatewayAPICondition reports the Gateway API kinds missing for the Ingress
+func (r *IngressReconciler) gatewayAPICondition(generation int64) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               ConditionGatewayAPIAvailable,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "Installed",
+		Message:            "The Gateway API kinds the Ingress is translated to are installed",
+	}
+	if missing := r.missingCoreGatewayAPIKinds(); len(missing) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "CRDsMissing"
+		condition.Message = "Gateway API CRDs not installed: " + strings.Join(missing, ", ")
+	}
+	return condition
+}
+
+// GatewayAPIReconciler refreshes the detected Gateway API kinds whenever a Gateway API CRD changes. Kinds
+// watched by other controllers cannot be added or removed at runtime, so a change to one of them stops the
+// manager and the operator restarts with watches matching the cluster.
+type GatewayAPIReconciler struct {
+	client.Client
+	Capabilities *utils.GatewayAPICapabilities
+	// RestartOn are the kinds whose installation or removal needs different watches
+	RestartOn []schema.GroupVersionKind
+	// Restart stops the manager
+	Restart func()
+
+	restartRequested atomic.Bool
+}
+
+// RestartRequested reports whether the manager was stopped for a changed Gateway API kind
+func (r *GatewayAPIReconciler) RestartRequested() bool {
+	return r.restartRequested.Load()
+}
+
+func (r *GatewayAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	changed, err := r.Capabilities.Refresh()
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to detect Gateway API kinds: %w", err)
+	}
+	restart := false
+	for _, gvk := range changed {
+		logger.Info("Gateway API kind changed", "kind", gvk.Kind, "version", gvk.Version,
+			"installed", r.Capabilities.Installed(gvk), "crd", req.Name)
+		if slices.Contains(r.RestartOn, gvk) {
+			restart = true
+		}
+	}
+	if restart && r.Restart != nil && !r.restartRequested.Swap(true) {
+		logger.Info("Restarting to watch the changed Gateway API kinds")
+		r.Restart()
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *GatewayAPIReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&apiextensionsv1.CustomResourceDefinition{}, ctrlbuilder.OnlyMetadata,
+			ctrlbuilder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return strings.HasSuffix(obj.GetName(), "."+gatewayv1.GroupName)
+			}))).
+		Named("gatewayapi").
+		Complete(r)
+}
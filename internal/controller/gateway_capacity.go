@@ -0,0 +1,156 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// GatewayCapacityAction controls what happens when migrating an Ingress would push a Gateway over its limits
+type GatewayCapacityAction string
+
+const (
+	// GatewayCapacityActionOff does not estimate Gateway capacity
+	GatewayCapacityActionOff GatewayCapacityAction = "off"
+	// GatewayCapacityActionWarn migrates anyway and records a warning event
+	GatewayCapacityActionWarn GatewayCapacityAction = "warn"
+	// GatewayCapacityActionDefer holds back Ingresses not yet on the Gateway until it has room again
+	GatewayCapacityActionDefer GatewayCapacityAction = "defer"
+)
+
+// gatewayCapacityRequeue is how long a deferred Ingress waits before the Gateway is estimated again
+const gatewayCapacityRequeue = 5 * time.Minute
+
+// gatewayCapacityLimits returns the limits Gateways are estimated against
+func (r *IngressReconciler) gatewayCapacityLimits() utils.GatewayCapacityLimits {
+	listeners := r.MaxListenersPerGateway
+	if listeners <= 0 {
+		listeners = MaxGatewayListeners
+	}
+	return utils.GatewayCapacityLimits{Listeners: listeners, ConfigBytes: r.MaxGatewayConfigBytes}
+}
+
+// checkGatewayCapacity estimates the size of the Gateway once the routes of the Ingress are applied and
+// its listeners added, and exports it as ingress_doperator_gateway_capacity_ratio. It returns false when
+// the Ingress must not be migrated onto the Gateway yet.
+func (r *IngressReconciler) checkGatewayCapacity(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	gatewayName string,
+	listenerNames []string,
+	routes []*gatewayv1.HTTPRoute,
+) bool {
+	if r.GatewayCapacityAction == "" || r.GatewayCapacityAction == GatewayCapacityActionOff {
+		return true
+	}
+	logger := log.FromContext(ctx)
+
+	listeners := make(map[string]bool, len(listenerNames))
+	gateway := &gatewayv1.Gateway{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: r.GatewayNamespace, Name: gatewayName}, gateway)
+	if err != nil && !apierrors.IsNotFound(err) {
+		logger.Error(err, "failed to get Gateway for capacity estimate", "gateway", gatewayName)
+		return true
+	}
+	for _, listener := range gateway.Spec.Listeners {
+		listeners[string(listener.Name)] = true
+	}
+	for _, name := range listenerNames {
+		listeners[name] = true
+	}
+
+	var routeList gatewayv1.HTTPRouteList
+	if err := r.List(ctx, &routeList); err != nil {
+		logger.Error(err, "failed to list HTTPRoutes for capacity estimate", "gateway", gatewayName)
+		return true
+	}
+	replaced := make(map[types.NamespacedName]bool, len(routes))
+	for _, route := range routes {
+		replaced[types.NamespacedName{Namespace: route.Namespace, Name: route.Name}] = true
+	}
+	onGateway := false
+	gatewayRoutes := make([]*gatewayv1.HTTPRoute, 0, len(routeList.Items)+len(routes))
+	for i := range routeList.Items {
+		route := &routeList.Items[i]
+		if !r.routeAttachesTo(route, gatewayName) {
+			continue
+		}
+		if utils.IsManagedByUsForIngress(route, ingress.Namespace, ingress.Name) {
+			// The routes of the Ingress are estimated as they are about to be applied
+			onGateway = true
+			continue
+		}
+		if replaced[client.ObjectKeyFromObject(route)] {
+			continue
+		}
+		gatewayRoutes = append(gatewayRoutes, route)
+	}
+	gatewayRoutes = append(gatewayRoutes, routes...)
+
+	limits := r.gatewayCapacityLimits()
+	capacity := utils.EstimateGatewayCapacity(len(listeners), gatewayRoutes)
+	metrics.GatewayCapacityRatio.WithLabelValues(r.GatewayNamespace, gatewayName).Set(capacity.Ratio(limits))
+
+	exceeded := capacity.Exceeded(limits)
+	if len(exceeded) == 0 {
+		return true
+	}
+	message := fmt.Sprintf("Gateway %s/%s would have %s after migrating this Ingress",
+		r.GatewayNamespace, gatewayName, strings.Join(exceeded, " and "))
+	logger.Info("Gateway capacity exceeded", "gateway", gatewayName, "listeners", capacity.Listeners,
+		"routes", capacity.Routes, "configBytes", capacity.ConfigBytes, "deferred",
+		r.GatewayCapacityAction == GatewayCapacityActionDefer && !onGateway)
+
+	// Ingresses already served by the Gateway keep being updated, deferring them would freeze stale routes
+	if r.GatewayCapacityAction != GatewayCapacityActionDefer || onGateway {
+		r.recordWarning(ingress, "GatewayCapacityExceeded", message)
+		return true
+	}
+	r.recordWarning(ingress, "GatewayCapacityDeferred", message+", migration deferred")
+	metrics.IngressReconcileSkipsTotal.WithLabelValues("gateway-capacity", ingress.Namespace, ingress.Name).Inc()
+	return false
+}
+
+// routeAttachesTo reports whether the HTTPRoute has a parentRef to the named Gateway
+func (r *IngressReconciler) routeAttachesTo(route *gatewayv1.HTTPRoute, gatewayName string) bool {
+	for _, parentRef := range route.Spec.ParentRefs {
+		if parentRef.Kind != nil && *parentRef.Kind != "Gateway" {
+			continue
+		}
+		namespace := route.Namespace
+		if parentRef.Namespace != nil {
+			namespace = string(*parentRef.Namespace)
+		}
+		if string(parentRef.Name) == gatewayName && namespace == r.GatewayNamespace {
+			return true
+		}
+	}
+	return false
+}
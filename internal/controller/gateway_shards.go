@@ -0,0 +1,258 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+const (
+	// GatewayShardAnnotation records the shared Gateway shard an Ingress landed on
+	GatewayShardAnnotation = "ingress-doperator.fiction.si/gateway-shard"
+
+	// MaxGatewayListeners is the Gateway API limit on listeners per Gateway
+	MaxGatewayListeners = 64
+)
+
+// gatewayShardName returns the Gateway name of a shard: shard 0 keeps the base name so existing
+// Gateways are not moved, overflow shards are <base>-1..N. Numbering every shard (<base>-0..N) would
+// rename the Gateway of every existing installation and change its address.
+func gatewayShardName(base string, shard int) string {
+	if shard == 0 {
+		return base
+	}
+	suffix := "-" + strconv.Itoa(shard)
+	if len(base)+len(suffix) > 63 {
+		base = strings.TrimRight(base[:63-len(suffix)], "-")
+	}
+	return base + suffix
+}
+
+// gatewayShardIndex returns the shard number of name for base, -1 when name is not one of its shards
+func gatewayShardIndex(base, name string) int {
+	if name == base {
+		return 0
+	}
+	for shard := 1; shard <= MaxGatewayListeners; shard++ {
+		if gatewayShardName(base, shard) == name {
+			return shard
+		}
+	}
+	return -1
+}
+
+// ingressListenerNames returns the names of the (rewritten, consolidated) listeners the Ingress needs,
+// including paired HTTP listeners
+func ingressListenerNames(trans *translator.Translator, ingress *networkingv1.Ingress) []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0, len(ingress.Spec.Rules))
+	add := func(name string) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	for _, host := range ingressHosts(ingress, trans.Config.TLSOnlyHosts.Serves()) {
+		add(string(translator.ListenerName(trans.ListenerHostname(trans.TransformHostname(host)))))
+	}
+	for _, name := range trans.HTTPListenerNames(ingress) {
+		add(string(name))
+	}
+	return names
+}
+
+// gatewayShard is the listener state of one shard Gateway
+type gatewayShard struct {
+	name      string
+	listeners map[string]bool
+}
+
+// additionalListeners counts the listeners that would be new on the shard
+func (s gatewayShard) additionalListeners(names []string) int {
+	count := 0
+	for _, name := range names {
+		if !s.listeners[name] {
+			count++
+		}
+	}
+	return count
+}
+
+// selectGatewayShard places an Ingress on one of the shards of the shared Gateway base so that no
+// Gateway exceeds MaxListenersPerGateway listeners. The choice is deterministic for a given cluster
+// state: the shard recorded on the Ingress if it still fits, then the shard already serving most of its
+// listeners, then the lowest numbered shard with room.
+func (r *IngressReconciler) selectGatewayShard(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	base string,
+	listeners []string,
+) (string, error) {
+	limit := r.MaxListenersPerGateway
+	if limit <= 0 {
+		return base, nil
+	}
+
+	var gateways gatewayv1.GatewayList
+	if err := r.List(ctx, &gateways, client.InNamespace(r.GatewayNamespace)); err != nil {
+		return "", fmt.Errorf("failed to list Gateways: %w", err)
+	}
+	shards := make(map[int]gatewayShard)
+	for i := range gateways.Items {
+		gateway := &gateways.Items[i]
+		index := gatewayShardIndex(base, gateway.Name)
+		if index < 0 || (index > 0 && !utils.IsManagedByUs(gateway)) {
+			continue
+		}
+		shard := gatewayShard{name: gateway.Name, listeners: make(map[string]bool, len(gateway.Spec.Listeners))}
+		for _, listener := range gateway.Spec.Listeners {
+			shard.listeners[string(listener.Name)] = true
+		}
+		shards[index] = shard
+	}
+	shardFor := func(index int) gatewayShard {
+		if shard, ok := shards[index]; ok {
+			return shard
+		}
+		return gatewayShard{name: gatewayShardName(base, index)}
+	}
+	fits := func(shard gatewayShard) bool {
+		return len(shard.listeners)+shard.additionalListeners(listeners) <= limit
+	}
+
+	// Stay where we are unless the Ingress grew beyond what the shard can hold
+	if index := gatewayShardIndex(base, ingress.Annotations[GatewayShardAnnotation]); index >= 0 {
+		if shard := shardFor(index); fits(shard) {
+			return shard.name, nil
+		}
+	}
+
+	indexes := make([]int, 0, len(shards))
+	for index := range shards {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	// Listeners already on a shard stay there (a hostname on two Gateways would split its traffic)
+	best, bestShared := -1, 0
+	for _, index := range indexes {
+		shard := shards[index]
+		shared := len(listeners) - shard.additionalListeners(listeners)
+		if shared > bestShared && fits(shard) {
+			best, bestShared = index, shared
+		}
+	}
+	if best >= 0 {
+		return shards[best].name, nil
+	}
+
+	for index := 0; index <= MaxGatewayListeners; index++ {
+		if shard := shardFor(index); fits(shard) {
+			return shard.name, nil
+		}
+	}
+	return "", fmt.Errorf("ingress needs %d listeners, more than the %d a Gateway can hold", len(listeners), limit)
+}
+
+// recordGatewayShard remembers the shard of base on the Ingress and, when the Ingress moved, drops its
+// listeners from the previous shard
+func (r *IngressReconciler) recordGatewayShard(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	listenerReconciler *HTTPRouteReconciler,
+	base string,
+	shard string,
+) {
+	logger := log.FromContext(ctx)
+	previous := ingress.Annotations[GatewayShardAnnotation]
+	if previous == shard {
+		return
+	}
+
+	patchBase := client.MergeFrom(ingress.DeepCopy())
+	if ingress.Annotations == nil {
+		ingress.Annotations = map[string]string{}
+	}
+	ingress.Annotations[GatewayShardAnnotation] = shard
+	if err := r.Patch(ctx, ingress, patchBase); err != nil {
+		logger.Error(err, "failed to record Gateway shard on Ingress", "shard", shard)
+		return
+	}
+	if previous == "" {
+		return
+	}
+
+	logger.Info("Ingress moved to another Gateway shard, cleaning up previous shard",
+		"namespace", ingress.Namespace,
+		"name", ingress.Name,
+		"previous", previous,
+		"shard", shard)
+	r.releaseGatewayShard(ctx, listenerReconciler, base, previous)
+}
+
+// releaseGatewayShard reconciles the listeners of a shard against its remaining HTTPRoutes and deletes
+// it once nothing is attached to it, if it is an overflow shard of base. The base Gateway (shard 0) and
+// Gateways of another base are kept, deleting and recreating them would change their address.
+func (r *IngressReconciler) releaseGatewayShard(
+	ctx context.Context,
+	listenerReconciler *HTTPRouteReconciler,
+	base string,
+	shard string,
+) {
+	logger := log.FromContext(ctx)
+	routes, err := listenerReconciler.listHTTPRoutesForGateway(ctx, shard, "")
+	if err != nil {
+		logger.Error(err, "failed to list HTTPRoutes for Gateway shard", "gateway", shard)
+		return
+	}
+	if _, err := listenerReconciler.reconcileGatewayListeners(ctx, shard, routes); err != nil {
+		logger.Error(err, "failed to reconcile listeners of Gateway shard", "gateway", shard)
+		return
+	}
+	if len(routes) > 0 || gatewayShardIndex(base, shard) <= 0 {
+		return
+	}
+
+	gateway := &gatewayv1.Gateway{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: r.GatewayNamespace, Name: shard}, gateway); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to get Gateway shard", "gateway", shard)
+		}
+		return
+	}
+	if len(gateway.Spec.Listeners) == 0 && utils.IsManagedByUs(gateway) {
+		logger.Info("Deleting empty Gateway shard", "gateway", shard)
+		if err := r.Delete(ctx, gateway); err != nil && !apierrors.IsNotFound(err) {
+			logger.Error(err, "failed to delete empty Gateway shard", "gateway", shard)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+func TestGatewayShardName(t *testing.T) {
+	long := strings.Repeat("a", 62) + "-b"
+	tests := []struct {
+		name  string
+		base  string
+		shard int
+		want  string
+	}{
+		{name: "base keeps its name", base: "nginx", shard: 0, want: "nginx"},
+		{name: "overflow shard", base: "nginx", shard: 1, want: "nginx-1"},
+		{name: "two digit shard", base: "nginx", shard: 12, want: "nginx-12"},
+		{name: "long base is shortened", base: long, shard: 3, want: strings.Repeat("a", 61) + "-3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := gatewayShardName(tt.base, tt.shard)
+			if got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+			if len(got) > 63 {
+				t.Fatalf("name %q is longer than 63 characters", got)
+			}
+			if index := gatewayShardIndex(tt.base, got); index != tt.shard {
+				t.Fatalf("expected shard index %d for %q, got %d", tt.shard, got, index)
+			}
+		})
+	}
+}
+
+func TestGatewayShardIndexOnlyOverflowShardsAreDeletable(t *testing.T) {
+	// releaseGatewayShard deletes a Gateway only for an index above 0
+	tests := []struct {
+		name string
+		base string
+		gw   string
+		want int
+	}{
+		{name: "base Gateway", base: "nginx", gw: "nginx", want: 0},
+		{name: "overflow shard", base: "nginx", gw: "nginx-2", want: 2},
+		{name: "other base", base: "nginx", gw: "internal", want: -1},
+		{name: "overflow shard of another base", base: "nginx", gw: "internal-1", want: -1},
+		{name: "prefix only", base: "nginx", gw: "nginx-public", want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gatewayShardIndex(tt.base, tt.gw); got != tt.want {
+				t.Fatalf("expected %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestIngressListenerNamesTLSOnlyHosts(t *testing.T) {
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{Host: "shop.example.com"}},
+			TLS: []networkingv1.IngressTLS{
+				{Hosts: []string{"shop.example.com", "www.shop.example.com"}, SecretName: "shop-tls"},
+			},
+		},
+	}
+	tests := []struct {
+		mode translator.TLSOnlyHostsMode
+		want int
+	}{
+		{mode: "", want: 1},
+		{mode: translator.TLSOnlyHostsIgnore, want: 1},
+		{mode: translator.TLSOnlyHostsNotFound, want: 2},
+		{mode: translator.TLSOnlyHostsDefaultBackend, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			trans := translator.New(translator.Config{TLSOnlyHosts: tt.mode})
+			if got := ingressListenerNames(trans, ingress); len(got) != tt.want {
+				t.Fatalf("expected %d listeners, got %v", tt.want, got)
+			}
+		})
+	}
+}
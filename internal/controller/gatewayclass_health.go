@@ -0,0 +1,165 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+const gatewayClassPausedRequeue = time.Minute
+
+// checkGatewayClassHealth reports whether the GatewayClass exists and has been Accepted
+// by its controller. The returned message explains why it is considered unhealthy.
+func checkGatewayClassHealth(ctx context.Context, reader client.Reader, name string) (bool, string) {
+	gatewayClass := &gatewayv1.GatewayClass{}
+	if err := reader.Get(ctx, client.ObjectKey{Name: name}, gatewayClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, fmt.Sprintf("GatewayClass %q not found", name)
+		}
+		return false, fmt.Sprintf("unable to fetch GatewayClass %q: %v", name, err)
+	}
+	if !gatewayClass.DeletionTimestamp.IsZero() {
+		return false, fmt.Sprintf("GatewayClass %q is being deleted", name)
+	}
+
+	accepted := meta.FindStatusCondition(gatewayClass.Status.Conditions, string(gatewayv1.GatewayClassConditionStatusAccepted))
+	if accepted == nil || accepted.Status != metav1.ConditionTrue {
+		reason := "no Accepted condition"
+		if accepted != nil {
+			reason = fmt.Sprintf("Accepted=%s (%s)", accepted.Status, accepted.Reason)
+		}
+		return false, fmt.Sprintf("GatewayClass %q is not accepted: %s", name, reason)
+	}
+	return true, ""
+}
+
+func (r *IngressReconciler) gatewayClassName() string {
+	if r.GatewayClassName == "" {
+		return "nginx"
+	}
+	return r.GatewayClassName
+}
+
+// gatewayClassFor returns the GatewayClass the Ingress is migrated to: the --gateway-class-mapping
+// entry of its ingress class, or the default GatewayClass
+func (r *IngressReconciler) gatewayClassFor(ingress *networkingv1.Ingress) string {
+	if gatewayClass, ok := translator.ResolveGatewayClass(r.GatewayClassMapping, r.getIngressClass(ingress)); ok {
+		return gatewayClass
+	}
+	return r.gatewayClassName()
+}
+
+// targetGatewayClasses returns every GatewayClass Ingresses may be migrated to
+func (r *IngressReconciler) targetGatewayClasses() []string {
+	classes := translator.GatewayClasses(r.GatewayClassMapping)
+	if !slices.Contains(classes, r.gatewayClassName()) {
+		classes = append(classes, r.gatewayClassName())
+	}
+	return classes
+}
+
+// postProcessingPaused checks the target GatewayClass and reports whether the disable
+// step for this Ingress must be held back
+func (r *IngressReconciler) postProcessingPaused(ctx context.Context, ingress *networkingv1.Ingress) bool {
+	if !r.PauseOnUnhealthyGatewayClass {
+		return false
+	}
+	gatewayClassName := r.gatewayClassFor(ingress)
+	healthy, message := checkGatewayClassHealth(ctx, r.Client, gatewayClassName)
+	r.setGatewayClassPaused(ctx, gatewayClassName, nil, !healthy, message)
+	if healthy {
+		return false
+	}
+	r.recordWarning(ingress, "PostProcessingPaused",
+		fmt.Sprintf("Ingress post-processing paused: %s", message))
+	return true
+}
+
+// setGatewayClassPaused updates the pause state of a GatewayClass and reports whether it changed
+func (r *IngressReconciler) setGatewayClassPaused(
+	ctx context.Context,
+	gatewayClassName string,
+	gatewayClass client.Object,
+	paused bool,
+	message string,
+) bool {
+	r.gatewayClassPausedMu.Lock()
+	if r.gatewayClassPaused == nil {
+		r.gatewayClassPaused = make(map[string]bool)
+	}
+	changed := r.gatewayClassPaused[gatewayClassName] != paused
+	r.gatewayClassPaused[gatewayClassName] = paused
+	r.gatewayClassPausedMu.Unlock()
+
+	value := 0.0
+	if paused {
+		value = 1
+	}
+	metrics.MigrationPaused.WithLabelValues(gatewayClassName).Set(value)
+
+	if !changed {
+		return false
+	}
+
+	logger := log.FromContext(ctx)
+	if paused {
+		logger.Info("Pausing Ingress post-processing", "gatewayClass", gatewayClassName, "reason", message)
+	} else {
+		logger.Info("Resuming Ingress post-processing", "gatewayClass", gatewayClassName)
+	}
+	if r.Recorder != nil && gatewayClass != nil {
+		if paused {
+			r.Recorder.Eventf(gatewayClass, nil, "Warning", "MigrationPaused", "Pause",
+				"Ingress post-processing paused: %s", message)
+		} else {
+			r.Recorder.Eventf(gatewayClass, nil, "Normal", "MigrationResumed", "Resume",
+				"Ingress post-processing resumed")
+		}
+	}
+	return true
+}
+
+// enqueueIngressesForGatewayClass tracks GatewayClass health and requeues all Ingresses
+// once post-processing resumes
+func (r *IngressReconciler) enqueueIngressesForGatewayClass(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	if !r.PauseOnUnhealthyGatewayClass || !slices.Contains(r.targetGatewayClasses(), obj.GetName()) {
+		return nil
+	}
+	healthy, message := checkGatewayClassHealth(ctx, r.Client, obj.GetName())
+	if r.setGatewayClassPaused(ctx, obj.GetName(), obj, !healthy, message) && healthy {
+		return r.enqueueAllIngresses(ctx)
+	}
+	return nil
+}
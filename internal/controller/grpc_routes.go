@@ -0,0 +1,168 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+const (
+	GRPCRouteFinalizerName = "ingress-doperator.fiction.si/grpcroute-finalizer"
+)
+
+// buildGRPCRoutes translates a gRPC Ingress into its (split) GRPCRoutes
+func (r *IngressReconciler) buildGRPCRoutes(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	singleTrans *translator.Translator,
+) []*gatewayv1.GRPCRoute {
+	grpcRoute, unsupported := singleTrans.TranslateToGRPCRoute(ingress)
+	if len(unsupported) > 0 {
+		log.FromContext(ctx).Info("Ingress paths are no gRPC service or method, leaving them out of the GRPCRoute",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name,
+			"paths", unsupported)
+		r.recordWarning(ingress, "GRPCPathUnsupported",
+			fmt.Sprintf("Paths %s cannot be expressed as gRPC method matches and were not translated; "+
+				"use /<package.Service> or /<package.Service>/<Method>", strings.Join(unsupported, ", ")))
+	}
+	r.setRouteOwner(grpcRoute, ingress)
+	r.GRPCRouteManager.ResolveNamedPorts(ctx, ingress, grpcRoute)
+	return r.GRPCRouteManager.SplitGRPCRouteIfNeeded(grpcRoute)
+}
+
+// applyGRPCRoutes applies the GRPCRoutes of an Ingress, removing all of them when it has none
+func (r *IngressReconciler) applyGRPCRoutes(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	grpcRoutes []*gatewayv1.GRPCRoute,
+) error {
+	if r.GRPCRouteManager == nil {
+		return nil
+	}
+	metricRecorder := r.resourceRecorder(ctx, ingress, "GRPCRoute", metrics.GRPCRouteResourcesTotal)
+	if err := r.GRPCRouteManager.ApplyGRPCRoutes(ctx, ingress, grpcRoutes, metricRecorder); err != nil {
+		log.FromContext(ctx).Error(err, "failed to apply GRPCRoutes")
+		r.logErrorRateLimited(err, "apply-grpcroutes", "failed to apply GRPCRoutes")
+		return err
+	}
+	return nil
+}
+
+// listGRPCRouteListenerViews returns listener views of the managed GRPCRoutes attached to a Gateway
+func (r *HTTPRouteReconciler) listGRPCRouteListenerViews(
+	ctx context.Context,
+	gatewayName string,
+	excludeKey string,
+) ([]gatewayv1.HTTPRoute, error) {
+	if !r.GRPCRoutes {
+		return nil, nil
+	}
+	allRoutes := &gatewayv1.GRPCRouteList{}
+	if err := r.List(ctx, allRoutes); err != nil {
+		return nil, err
+	}
+
+	views := make([]gatewayv1.HTTPRoute, 0, len(allRoutes.Items))
+	for i := range allRoutes.Items {
+		view := translator.GRPCRouteListenerView(&allRoutes.Items[i])
+		if !view.DeletionTimestamp.IsZero() || !(r.isManagedByUs(view) || isAdoptedRoute(view)) {
+			continue
+		}
+		if excludeKey != "" && fmt.Sprintf("%s/%s", view.Namespace, view.Name) == excludeKey {
+			continue
+		}
+		if r.getGatewayNameFromHTTPRoute(view) != gatewayName {
+			continue
+		}
+		views = append(views, *view)
+	}
+	return views, nil
+}
+
+// GRPCRouteReconciler manages the Gateway listeners of GRPCRoutes the way HTTPRouteReconciler does for
+// HTTPRoutes, sharing its listener bookkeeping and debouncing
+type GRPCRouteReconciler struct {
+	client.Client
+	Listeners *HTTPRouteReconciler
+}
+
+// Reconcile manages Gateway listeners based on GRPCRoute changes
+func (r *GRPCRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	grpcRoute := &gatewayv1.GRPCRoute{}
+	if err := r.Get(ctx, req.NamespacedName, grpcRoute); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("GRPCRoute not found, already deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "unable to fetch GRPCRoute")
+		return ctrl.Result{}, err
+	}
+	if !utils.IsManagedByUs(grpcRoute) {
+		return ctrl.Result{}, nil
+	}
+
+	view := translator.GRPCRouteListenerView(grpcRoute)
+	if !grpcRoute.DeletionTimestamp.IsZero() {
+		if utils.ContainsString(grpcRoute.Finalizers, GRPCRouteFinalizerName) {
+			logger.Info("GRPCRoute being deleted, cleaning up Gateway listeners", "namespace", req.Namespace, "name", req.Name)
+			if err := r.Listeners.handleHTTPRouteDelete(ctx, view); err != nil {
+				logger.Error(err, "failed to cleanup Gateway for deleted GRPCRoute, removing finalizer anyway")
+			}
+			grpcRoute.Finalizers = utils.RemoveString(grpcRoute.Finalizers, GRPCRouteFinalizerName)
+			if err := r.Update(ctx, grpcRoute); err != nil {
+				logger.Error(err, "failed to remove finalizer")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !utils.ContainsString(grpcRoute.Finalizers, GRPCRouteFinalizerName) {
+		grpcRoute.Finalizers = append(grpcRoute.Finalizers, GRPCRouteFinalizerName)
+		if err := r.Update(ctx, grpcRoute); err != nil {
+			logger.Error(err, "failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return r.Listeners.handleHTTPRouteCreateOrUpdate(ctx, view)
+}
+
+// SetupWithManager sets up the controller with the Manager. It must be called after the
+// HTTPRouteReconciler it shares the debouncer with is set up.
+func (r *GRPCRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1.GRPCRoute{}).
+		WithEventFilter(ManagedByIngressDoperatorPredicate()).
+		Complete(r)
+}
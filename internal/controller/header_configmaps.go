@@ -0,0 +1,124 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// headerConfigMapAnnotations reference ConfigMaps whose entries become header filters
+var headerConfigMapAnnotations = []string{translator.NginxProxySetHeadersKey, translator.NginxCustomHeadersKey}
+
+// headerConfigMapKey resolves the ConfigMap reference of a header annotation. The operator reads it with
+// cluster-wide access and copies its data into an HTTPRoute the Ingress author can read, so references to
+// other namespaces are refused unless AllowCrossNamespaceHeaders is set (like ingress-nginx
+// refuses them unless allow-cross-namespace-resources is enabled).
+func (r *IngressReconciler) headerConfigMapKey(
+	ingress *networkingv1.Ingress,
+	ref string,
+) (types.NamespacedName, error) {
+	key, err := utils.ParseConfigMapReference(ref, ingress.Namespace)
+	if err != nil {
+		return types.NamespacedName{}, err
+	}
+	if key.Namespace != ingress.Namespace && !r.AllowCrossNamespaceHeaders {
+		return types.NamespacedName{}, fmt.Errorf(
+			"ConfigMap %s is outside namespace %s (see --allow-cross-namespace-header-configmaps)",
+			key.String(), ingress.Namespace)
+	}
+	return key, nil
+}
+
+// referencesHeaderConfigMap reports whether an Ingress takes headers from the ConfigMap
+func (r *IngressReconciler) referencesHeaderConfigMap(
+	ingress *networkingv1.Ingress,
+	configMap types.NamespacedName,
+) bool {
+	for _, annotation := range headerConfigMapAnnotations {
+		ref, ok := translator.GetNginxAnnotation(ingress.Annotations, annotation)
+		if !ok || ref == "" {
+			continue
+		}
+		if key, err := r.headerConfigMapKey(ingress, ref); err == nil && key == configMap {
+			return true
+		}
+	}
+	return false
+}
+
+// watchHeaderConfigMaps requeues the Ingresses taking headers from a ConfigMap when it changes, the
+// Ingresses themselves are unchanged so their reconcile cache entries are evicted first
+func (r *IngressReconciler) watchHeaderConfigMaps(b *ctrlbuilder.Builder) *ctrlbuilder.Builder {
+	// Creations are included, the Ingress may have been reconciled while its ConfigMap was missing
+	changed := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldConfigMap, okOld := e.ObjectOld.(*corev1.ConfigMap)
+			newConfigMap, okNew := e.ObjectNew.(*corev1.ConfigMap)
+			return okOld && okNew && !equality.Semantic.DeepEqual(oldConfigMap.Data, newConfigMap.Data)
+		},
+	}
+	return b.Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.enqueueIngressesForHeaderConfigMap),
+		ctrlbuilder.WithPredicates(changed))
+}
+
+func (r *IngressReconciler) enqueueIngressesForHeaderConfigMap(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	configMap := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	list := &networkingv1.IngressList{}
+	opts := []client.ListOption{}
+	switch {
+	case r.WatchNamespace != "":
+		opts = append(opts, client.InNamespace(r.WatchNamespace))
+	case !r.AllowCrossNamespaceHeaders:
+		opts = append(opts, client.InNamespace(configMap.Namespace))
+	}
+	if err := r.List(ctx, list, opts...); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list Ingresses for header ConfigMap change",
+			"configMap", configMap.String())
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range list.Items {
+		ingress := &list.Items[i]
+		if !r.shouldEnqueueIngressByClass(ingress) || !r.referencesHeaderConfigMap(ingress, configMap) {
+			continue
+		}
+		r.evictReconcileCache(ctx, ingress)
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name},
+		})
+	}
+	return requests
+}
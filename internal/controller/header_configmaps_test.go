@@ -0,0 +1,106 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+func TestHeaderConfigMapKey(t *testing.T) {
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"}}
+	tests := []struct {
+		name         string
+		ref          string
+		allowCrossNS bool
+		want         types.NamespacedName
+		wantErr      bool
+	}{
+		{name: "name only", ref: "headers", want: types.NamespacedName{Namespace: "shop", Name: "headers"}},
+		{name: "own namespace", ref: "shop/headers", want: types.NamespacedName{Namespace: "shop", Name: "headers"}},
+		{name: "other namespace refused", ref: "kube-system/coredns", wantErr: true},
+		{
+			name:         "other namespace allowed",
+			ref:          "ingress-nginx/custom-headers",
+			allowCrossNS: true,
+			want:         types.NamespacedName{Namespace: "ingress-nginx", Name: "custom-headers"},
+		},
+		{name: "invalid", ref: "a/b/c", allowCrossNS: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &IngressReconciler{AllowCrossNamespaceHeaders: tt.allowCrossNS}
+			got, err := r.headerConfigMapKey(ingress, tt.ref)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReferencesHeaderConfigMap(t *testing.T) {
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "shop",
+		Name:      "web",
+		Annotations: map[string]string{
+			translator.NginxIngressAnnotationPrefix + translator.NginxProxySetHeadersKey: "request-headers",
+			translator.NginxIngressAnnotationPrefix + translator.NginxCustomHeadersKey:   "ingress-nginx/response-headers",
+		},
+	}}
+	tests := []struct {
+		name         string
+		configMap    types.NamespacedName
+		allowCrossNS bool
+		want         bool
+	}{
+		{name: "proxy-set-headers", configMap: types.NamespacedName{Namespace: "shop", Name: "request-headers"}, want: true},
+		{name: "unrelated", configMap: types.NamespacedName{Namespace: "shop", Name: "other"}},
+		{name: "same name elsewhere", configMap: types.NamespacedName{Namespace: "other", Name: "request-headers"}},
+		{
+			name:      "refused cross-namespace reference",
+			configMap: types.NamespacedName{Namespace: "ingress-nginx", Name: "response-headers"},
+		},
+		{
+			name:         "allowed cross-namespace reference",
+			configMap:    types.NamespacedName{Namespace: "ingress-nginx", Name: "response-headers"},
+			allowCrossNS: true,
+			want:         true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &IngressReconciler{AllowCrossNamespaceHeaders: tt.allowCrossNS}
+			if got := r.referencesHeaderConfigMap(ingress, tt.configMap); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
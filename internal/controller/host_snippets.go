@@ -0,0 +1,180 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// hostSnippetsContribution returns the server snippet contribution of an Ingress, false when it has none
+func hostSnippetsContribution(ingress *networkingv1.Ingress) (utils.HostSnippetsContribution, bool) {
+	snippets, _, ok := utils.BuildNginxIngressSnippets(ingress.Annotations)
+	if !ok || !utils.HasServerSnippets(snippets) {
+		return utils.HostSnippetsContribution{}, false
+	}
+	return utils.HostSnippetsContribution{
+		Namespace: ingress.Namespace,
+		Name:      ingress.Name,
+		Order:     utils.ParseSnippetsOrder(ingress.Annotations),
+		Snippets:  snippets,
+	}, true
+}
+
+// sharesHost reports whether two Ingresses have a served host in common
+func sharesHost(a, b *networkingv1.Ingress, includeTLSOnly bool) bool {
+	hosts := make(map[string]bool)
+	for _, host := range ingressHosts(a, includeTLSOnly) {
+		hosts[host] = true
+	}
+	for _, host := range ingressHosts(b, includeTLSOnly) {
+		if hosts[host] {
+			return true
+		}
+	}
+	return false
+}
+
+// hostSnippetsPeers lists the other managed Ingresses sharing a host with ingress that contribute server
+// snippets
+func (r *IngressReconciler) hostSnippetsPeers(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+) ([]networkingv1.Ingress, error) {
+	list := &networkingv1.IngressList{}
+	opts := []client.ListOption{}
+	if r.WatchNamespace != "" {
+		opts = append(opts, client.InNamespace(r.WatchNamespace))
+	}
+	if err := r.List(ctx, list, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list Ingresses: %w", err)
+	}
+	peers := make([]networkingv1.Ingress, 0)
+	for i := range list.Items {
+		peer := &list.Items[i]
+		if peer.Namespace == ingress.Namespace && peer.Name == ingress.Name {
+			continue
+		}
+		if !peer.DeletionTimestamp.IsZero() || IngressIgnored(peer) {
+			continue
+		}
+		if !r.shouldEnqueueIngressByClass(peer) || !sharesHost(ingress, peer, r.TLSOnlyHosts.Serves()) {
+			continue
+		}
+		if _, ok := hostSnippetsContribution(peer); ok {
+			peers = append(peers, *peer)
+		}
+	}
+	return peers, nil
+}
+
+// orderHostSnippets makes the server snippets of Ingresses sharing a host deterministic: the first
+// Ingress in snippets order carries the merged server snippets of all of them, the others drop theirs
+// so nginx sees every directive once and always in the same order
+func (r *IngressReconciler) orderHostSnippets(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	snippets []map[string]interface{},
+) []map[string]interface{} {
+	own, ok := hostSnippetsContribution(ingress)
+	if !ok {
+		return snippets
+	}
+	peers, err := r.hostSnippetsPeers(ctx, ingress)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to order host-scoped snippets, keeping own snippets")
+		return snippets
+	}
+	if len(peers) == 0 {
+		return snippets
+	}
+
+	contributions := []utils.HostSnippetsContribution{own}
+	for i := range peers {
+		contribution, _ := hostSnippetsContribution(&peers[i])
+		contributions = append(contributions, contribution)
+	}
+	utils.SortHostSnippetsContributions(contributions)
+
+	ordered := utils.WithoutServerSnippets(snippets)
+	if leader := contributions[0]; leader.Namespace != ingress.Namespace || leader.Name != ingress.Name {
+		log.FromContext(ctx).V(1).Info("Server snippets merged into another Ingress sharing the host",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name,
+			"leader", leader.Namespace+"/"+leader.Name)
+		return ordered
+	}
+	if merged, ok := utils.MergeServerSnippets(contributions); ok {
+		ordered = append(ordered, merged)
+	}
+	return ordered
+}
+
+// enqueueIngressesSharingHosts requeues Ingresses whose merged server snippets depend on ingress. Their
+// reconcile cache entries are evicted since their own resourceVersion did not change.
+func (r *IngressReconciler) enqueueIngressesSharingHosts(ctx context.Context, obj client.Object) []reconcile.Request {
+	ingress, ok := obj.(*networkingv1.Ingress)
+	if !ok {
+		return nil
+	}
+	peers, err := r.hostSnippetsPeers(ctx, ingress)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to list Ingresses sharing hosts")
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(peers))
+	for i := range peers {
+		r.evictReconcileCache(ctx, &peers[i])
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: peers[i].Namespace, Name: peers[i].Name},
+		})
+	}
+	return requests
+}
+
+// hasServerSnippets reports whether obj is an Ingress contributing server snippets
+func hasServerSnippets(obj client.Object) bool {
+	ingress, ok := obj.(*networkingv1.Ingress)
+	if !ok {
+		return false
+	}
+	_, ok = hostSnippetsContribution(ingress)
+	return ok
+}
+
+// hostSnippetsPredicate passes Ingress events that may change merged server snippets, including an
+// update dropping them
+func hostSnippetsPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return hasServerSnippets(e.Object) },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return hasServerSnippets(e.ObjectOld) || hasServerSnippets(e.ObjectNew)
+		},
+		DeleteFunc:  func(e event.DeleteEvent) bool { return hasServerSnippets(e.Object) },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
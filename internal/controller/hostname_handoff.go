@@ -0,0 +1,367 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+const (
+	// handoffPollInterval is how often an Ingress holding hostnames checks whether they were taken over
+	handoffPollInterval = 10 * time.Second
+)
+
+// holdDepartingHostnames keeps hostnames that left the Ingress served by its handoff HTTPRoute until
+// the HTTPRoute of another Ingress serving them is accepted by the Gateway, or until the handoff window
+// ends without anyone taking them over. With --listener-removal-ack the hostnames are staged on the
+// Gateway instead and released once acknowledged there or when the acknowledgement times out.
+// It must run before the Ingress HTTPRoutes drop the hostnames.
+// Returns when to check again, zero once nothing is held.
+func (r *IngressReconciler) holdDepartingHostnames(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	gatewayName string,
+	singleTrans *translator.Translator,
+	httpRoutes []*gatewayv1.HTTPRoute,
+) (time.Duration, error) {
+	window := r.handoffWindow()
+	if window <= 0 {
+		return 0, nil
+	}
+	logger := log.FromContext(ctx)
+
+	existingRoutes, err := r.HTTPRouteManager.GetHTTPRoutesForIngress(ctx, ingress)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get existing HTTPRoutes: %w", err)
+	}
+	desiredHosts := make(map[string]bool)
+	for _, route := range httpRoutes {
+		for _, host := range route.Spec.Hostnames {
+			desiredHosts[string(host)] = true
+		}
+	}
+
+	var handoffRoute *gatewayv1.HTTPRoute
+	var routes []gatewayv1.HTTPRoute
+	for i := range existingRoutes {
+		if translator.IsHandoffRoute(&existingRoutes[i]) {
+			handoffRoute = existingRoutes[i].DeepCopy()
+			continue
+		}
+		routes = append(routes, existingRoutes[i])
+	}
+	held := map[string]time.Time{}
+	if handoffRoute != nil {
+		held = translator.ParseHandoffHostnames(handoffRoute.Annotations[translator.HandoffHostnamesAnnotation])
+	}
+	previous := maps.Clone(held)
+
+	// Hostnames that came back to the Ingress are served by its own HTTPRoutes again
+	for host := range held {
+		if desiredHosts[host] {
+			delete(held, host)
+		}
+	}
+
+	now := time.Now()
+	var departed []string
+	for i := range routes {
+		route := &routes[i]
+		if isAuxiliaryRoute(route.Name) {
+			continue
+		}
+		for _, hostname := range route.Spec.Hostnames {
+			host := string(hostname)
+			if desiredHosts[host] {
+				continue
+			}
+			if _, ok := held[host]; !ok {
+				held[host] = now.Add(window)
+				departed = append(departed, host)
+			}
+			if handoffRoute == nil {
+				handoffRoute = newCompanionRoute(route, translator.HandoffRouteName(r.HTTPRouteManager.NameTemplate.Name(ingress)))
+			}
+			addHandoffHostname(handoffRoute, route, hostname, singleTrans)
+		}
+	}
+	if len(departed) > 0 && r.ListenerRemovalAck {
+		sort.Strings(departed)
+		logger.Info("Hostnames left the Ingress, staging their removal until it is acknowledged on the Gateway",
+			"namespace", ingress.Namespace, "name", ingress.Name, "hostnames", departed,
+			"gateway", r.GatewayNamespace+"/"+gatewayName, "timeout", window.String())
+		r.recordWarning(ingress, "HostnameRemovalPending",
+			fmt.Sprintf("Hostnames %s left the Ingress; they stay served until the removal is acknowledged with the "+
+				"%s annotation of Gateway %s/%s or for up to %s", strings.Join(departed, ", "), AckRemovalsAnnotation,
+				r.GatewayNamespace, gatewayName, window))
+	} else if len(departed) > 0 {
+		sort.Strings(departed)
+		logger.Info("Hostnames left the Ingress, keeping them served until another Ingress takes them over",
+			"namespace", ingress.Namespace, "name", ingress.Name, "hostnames", departed,
+			"window", r.HostnameHandoffWindow.String())
+		r.recordWarning(ingress, "HostnameHandoffPending",
+			fmt.Sprintf("Hostnames %s left the Ingress; they stay served for up to %s until the HTTPRoute of "+
+				"another Ingress serving them is accepted", strings.Join(departed, ", "), r.HostnameHandoffWindow))
+	}
+	if handoffRoute == nil {
+		return 0, nil
+	}
+
+	acked, err := r.acknowledgedRemovals(ctx, gatewayName)
+	if err != nil {
+		return 0, err
+	}
+	for host, deadline := range held {
+		claimant, accepted, err := r.findHostnameClaimant(ctx, ingress, host)
+		if err != nil {
+			return 0, err
+		}
+		switch {
+		case claimant != "" && accepted:
+			logger.Info("Hostname handed off", "hostname", host, "httproute", claimant)
+			r.recordWarning(ingress, "HostnameHandoffCompleted",
+				fmt.Sprintf("Hostname %s handed off to HTTPRoute %s", host, claimant))
+			metrics.HostnameHandoffsTotal.WithLabelValues("completed").Inc()
+			delete(held, host)
+		case claimant != "":
+			// The new owner was seen, never detach before its route is attached
+			logger.V(1).Info("Waiting for the HTTPRoute taking over a hostname to be accepted",
+				"hostname", host, "httproute", claimant)
+		case r.ListenerRemovalAck && removalAcknowledged(acked, host):
+			logger.Info("Hostname removal acknowledged, releasing it", "hostname", host)
+			r.recordNormal(ingress, "HostnameRemovalAcknowledged",
+				fmt.Sprintf("Removal of hostname %s was acknowledged", host))
+			metrics.HostnameHandoffsTotal.WithLabelValues("acknowledged").Inc()
+			delete(held, host)
+		case now.After(deadline):
+			logger.Info("Handoff window ended without another Ingress serving the hostname, releasing it",
+				"hostname", host)
+			metrics.HostnameHandoffsTotal.WithLabelValues("expired").Inc()
+			delete(held, host)
+		}
+	}
+
+	if err := r.syncPendingRemovals(ctx, gatewayName, previous, held); err != nil {
+		return 0, fmt.Errorf("failed to stage hostname removals on Gateway %s: %w", gatewayName, err)
+	}
+
+	metricRecorder := r.resourceRecorder(ctx, ingress, "HTTPRoute", metrics.HTTPRouteResourcesTotal)
+	if len(held) == 0 {
+		if handoffRoute.ResourceVersion == "" {
+			return 0, nil
+		}
+		logger.Info("Deleting handoff HTTPRoute", "namespace", handoffRoute.Namespace, "name", handoffRoute.Name)
+		if err := r.HTTPRouteManager.Client.Delete(ctx, handoffRoute); err != nil && !apierrors.IsNotFound(err) {
+			return 0, fmt.Errorf("failed to delete handoff HTTPRoute %s: %w", handoffRoute.Name, err)
+		}
+		metricRecorder("delete", handoffRoute.Namespace, handoffRoute.Name)
+		return 0, nil
+	}
+
+	retainHandoffHostnames(handoffRoute, held, singleTrans)
+	if err := r.HTTPRouteManager.ApplyHTTPRoute(ctx, handoffRoute, metricRecorder); err != nil {
+		return 0, fmt.Errorf("failed to apply handoff HTTPRoute %s: %w", handoffRoute.Name, err)
+	}
+	return handoffPollInterval, nil
+}
+
+// isAuxiliaryRoute reports whether an HTTPRoute is one of the TLS-only, default backend or HTTPS redirect
+// routes of an Ingress; their hostnames are handed off with the main HTTPRoute
+func isAuxiliaryRoute(name string) bool {
+	return strings.HasSuffix(name, translator.TLSOnlyHTTPRouteSuffix) ||
+		strings.HasSuffix(name, translator.DefaultBackendHTTPRouteSuffix) ||
+		strings.HasSuffix(name, translator.HTTPRedirectHTTPRouteSuffix)
+}
+
+// newCompanionRoute starts a handoff or rename HTTPRoute carrying over the metadata of an Ingress HTTPRoute
+func newCompanionRoute(route *gatewayv1.HTTPRoute, name string) *gatewayv1.HTTPRoute {
+	annotations := make(map[string]string, len(route.Annotations))
+	for key, value := range route.Annotations {
+		annotations[key] = value
+	}
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       route.Namespace,
+			Annotations:     annotations,
+			OwnerReferences: append([]metav1.OwnerReference(nil), route.OwnerReferences...),
+		},
+	}
+}
+
+// addHandoffHostname moves hostname with the rules and parentRefs it was served with into the handoff route
+func addHandoffHostname(
+	handoffRoute *gatewayv1.HTTPRoute,
+	route *gatewayv1.HTTPRoute,
+	hostname gatewayv1.Hostname,
+	singleTrans *translator.Translator,
+) {
+	if !containsHostname(handoffRoute.Spec.Hostnames, hostname) {
+		handoffRoute.Spec.Hostnames = append(handoffRoute.Spec.Hostnames, hostname)
+	}
+	sections := hostnameSectionNames(string(hostname), singleTrans)
+	for _, parentRef := range route.Spec.ParentRefs {
+		if parentRef.SectionName != nil && !sections[*parentRef.SectionName] {
+			continue
+		}
+		if !containsParentRef(handoffRoute.Spec.ParentRefs, parentRef) {
+			handoffRoute.Spec.ParentRefs = append(handoffRoute.Spec.ParentRefs, parentRef)
+		}
+	}
+	for _, rule := range route.Spec.Rules {
+		if len(handoffRoute.Spec.Rules) >= utils.MaxHTTPRouteRules {
+			break
+		}
+		if !containsRule(handoffRoute.Spec.Rules, rule) {
+			handoffRoute.Spec.Rules = append(handoffRoute.Spec.Rules, rule)
+		}
+	}
+}
+
+// retainHandoffHostnames drops released hostnames and their parentRefs from the handoff route
+func retainHandoffHostnames(
+	handoffRoute *gatewayv1.HTTPRoute,
+	held map[string]time.Time,
+	singleTrans *translator.Translator,
+) {
+	hostnames := make([]gatewayv1.Hostname, 0, len(held))
+	sections := make(map[gatewayv1.SectionName]bool)
+	for _, hostname := range handoffRoute.Spec.Hostnames {
+		if _, ok := held[string(hostname)]; !ok {
+			continue
+		}
+		hostnames = append(hostnames, hostname)
+		for section := range hostnameSectionNames(string(hostname), singleTrans) {
+			sections[section] = true
+		}
+	}
+	parentRefs := make([]gatewayv1.ParentReference, 0, len(handoffRoute.Spec.ParentRefs))
+	for _, parentRef := range handoffRoute.Spec.ParentRefs {
+		if parentRef.SectionName == nil || sections[*parentRef.SectionName] {
+			parentRefs = append(parentRefs, parentRef)
+		}
+	}
+	handoffRoute.Spec.Hostnames = hostnames
+	handoffRoute.Spec.ParentRefs = parentRefs
+	handoffRoute.Annotations[translator.HandoffHostnamesAnnotation] = translator.FormatHandoffHostnames(held)
+}
+
+// hostnameSectionNames returns the names of the listeners serving hostname
+func hostnameSectionNames(hostname string, singleTrans *translator.Translator) map[gatewayv1.SectionName]bool {
+	listenerHostname := singleTrans.ListenerHostname(hostname)
+	return map[gatewayv1.SectionName]bool{
+		translator.ListenerName(listenerHostname):     true,
+		translator.HTTPListenerName(listenerHostname): true,
+	}
+}
+
+// findHostnameClaimant returns another managed HTTPRoute serving hostname and whether a Gateway accepted it
+func (r *IngressReconciler) findHostnameClaimant(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	hostname string,
+) (string, bool, error) {
+	routeList := &gatewayv1.HTTPRouteList{}
+	if err := r.List(ctx, routeList); err != nil {
+		return "", false, fmt.Errorf("failed to list HTTPRoutes: %w", err)
+	}
+	sourceCluster := r.HTTPRouteManager.SourceCluster
+	claimant := ""
+	for i := range routeList.Items {
+		route := &routeList.Items[i]
+		if !route.DeletionTimestamp.IsZero() || translator.IsHandoffRoute(route) ||
+			!containsHostname(route.Spec.Hostnames, gatewayv1.Hostname(hostname)) {
+			continue
+		}
+		if utils.IsManagedByUsForIngress(route, ingress.Namespace, ingress.Name) &&
+			route.Annotations[translator.SourceClusterAnnotation] == sourceCluster {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s", route.Namespace, route.Name)
+		if routeAccepted(route) {
+			return key, true, nil
+		}
+		claimant = key
+	}
+	return claimant, false, nil
+}
+
+// routeAccepted reports whether any Gateway accepted the HTTPRoute
+func routeAccepted(route *gatewayv1.HTTPRoute) bool {
+	for _, parent := range route.Status.Parents {
+		if meta.IsStatusConditionTrue(parent.Conditions, string(gatewayv1.RouteConditionAccepted)) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsHostname(hostnames []gatewayv1.Hostname, hostname gatewayv1.Hostname) bool {
+	for _, h := range hostnames {
+		if h == hostname {
+			return true
+		}
+	}
+	return false
+}
+
+func containsParentRef(parentRefs []gatewayv1.ParentReference, parentRef gatewayv1.ParentReference) bool {
+	for _, ref := range parentRefs {
+		if reflect.DeepEqual(ref, parentRef) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsRule(rules []gatewayv1.HTTPRouteRule, rule gatewayv1.HTTPRouteRule) bool {
+	for _, r := range rules {
+		if reflect.DeepEqual(r, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// withHandoffRequeue makes sure an Ingress holding hostnames is checked again
+func withHandoffRequeue(result ctrl.Result, err error, handoffRequeue time.Duration) (ctrl.Result, error) {
+	if err != nil || handoffRequeue == 0 {
+		return result, err
+	}
+	if result.RequeueAfter == 0 || handoffRequeue < result.RequeueAfter {
+		result.RequeueAfter = handoffRequeue
+	}
+	return result, nil
+}
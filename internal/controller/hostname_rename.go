@@ -0,0 +1,433 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	doperatorv1alpha1 "github.com/fiksn/ingress-doperator/api/v1alpha1"
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+const (
+	// HostnameRenamePlanConditionAccepted reports whether the HostnameRenamePlan is in effect
+	HostnameRenamePlanConditionAccepted = "Accepted"
+
+	// defaultOldHostnameRetention is how long old hostnames stay served after a cutover
+	defaultOldHostnameRetention = time.Hour
+)
+
+// hostnameRename is what a HostnameRenamePlan serves
+type hostnameRename struct {
+	rename translator.HostnameRename
+	// renamed means the Ingress HTTPRoutes serve the new hostnames
+	renamed bool
+	// both means the rename HTTPRoute serves the other hostnames as well
+	both bool
+}
+
+// HostnameRenames holds the HostnameRenamePlans in effect for the Ingress and HTTPRoute controllers
+type HostnameRenames struct {
+	mu     sync.RWMutex
+	plans  map[string]hostnameRename
+	events []chan event.GenericEvent
+}
+
+// NewHostnameRenames creates the runtime state of the HostnameRenamePlans
+func NewHostnameRenames() *HostnameRenames {
+	return &HostnameRenames{plans: make(map[string]hostnameRename)}
+}
+
+// Primary returns the renames the Ingress HTTPRoutes are generated with
+func (h *HostnameRenames) Primary() []translator.HostnameRename {
+	if h == nil {
+		return nil
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var renames []translator.HostnameRename
+	for _, name := range slices.Sorted(maps.Keys(h.plans)) {
+		if plan := h.plans[name]; plan.renamed {
+			renames = append(renames, plan.rename)
+		}
+	}
+	return renames
+}
+
+// Alias returns the renames the rename HTTPRoutes are generated with: the other side of the plans
+// serving both hostnames and the primary side of the rest. Nil when no plan serves both.
+func (h *HostnameRenames) Alias() []translator.HostnameRename {
+	if h == nil {
+		return nil
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	both := false
+	renames := []translator.HostnameRename{}
+	for _, name := range slices.Sorted(maps.Keys(h.plans)) {
+		plan := h.plans[name]
+		both = both || plan.both
+		if plan.renamed != plan.both {
+			renames = append(renames, plan.rename)
+		}
+	}
+	if !both {
+		return nil
+	}
+	return renames
+}
+
+// conflict returns another plan renaming from or to the same suffix
+func (h *HostnameRenames) conflict(name string, rename translator.HostnameRename) string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, other := range slices.Sorted(maps.Keys(h.plans)) {
+		plan := h.plans[other]
+		if other != name && (plan.rename.From == rename.From || plan.rename.To == rename.To) {
+			return other
+		}
+	}
+	return ""
+}
+
+// set replaces the state of the plan called name, nil drops it, and requeues every Ingress when it changed
+func (h *HostnameRenames) set(name string, plan *hostnameRename) bool {
+	h.mu.Lock()
+	previous, existed := h.plans[name]
+	switch {
+	case plan == nil && !existed, plan != nil && existed && previous == *plan:
+		h.mu.Unlock()
+		return false
+	case plan == nil:
+		delete(h.plans, name)
+	default:
+		h.plans[name] = *plan
+	}
+	events := h.events
+	h.mu.Unlock()
+
+	for _, ch := range events {
+		// The controllers may not consume events yet, never block the reconcile
+		go func() { ch <- event.GenericEvent{Object: &doperatorv1alpha1.HostnameRenamePlan{}} }()
+	}
+	return true
+}
+
+// requeueEvents returns a channel signalling that the renames changed, one per Ingress controller
+func (h *HostnameRenames) requeueEvents() chan event.GenericEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ch := make(chan event.GenericEvent, 1)
+	h.events = append(h.events, ch)
+	return ch
+}
+
+// HostnameRenamePlanReconciler loads the HostnameRenamePlans into the running controllers and moves them
+// through their phases
+type HostnameRenamePlanReconciler struct {
+	client.Client
+	Renames *HostnameRenames
+}
+
+// Reconcile applies the phase of a HostnameRenamePlan, or drops the rename once the plan is gone
+func (r *HostnameRenamePlanReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	plan := &doperatorv1alpha1.HostnameRenamePlan{}
+	if err := r.Get(ctx, req.NamespacedName, plan); err != nil {
+		if apierrors.IsNotFound(err) {
+			if r.Renames.set(req.Name, nil) {
+				logger.Info("HostnameRenamePlan removed, requeueing all Ingresses", "name", req.Name)
+			}
+			metrics.HostnameRenamePlanState.DeleteLabelValues(req.Name)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	condition := metav1.Condition{
+		Type:               HostnameRenamePlanConditionAccepted,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Applied",
+		Message:            "Phase is in effect",
+		ObservedGeneration: plan.Generation,
+	}
+	status := plan.Status.DeepCopy()
+	var result ctrl.Result
+	if err := r.validate(plan); err != nil {
+		// The previous phase stays in effect
+		logger.Error(err, "invalid HostnameRenamePlan, keeping the previous phase", "name", plan.Name)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Invalid"
+		condition.Message = err.Error()
+	} else {
+		var rename *hostnameRename
+		rename, result = r.resolve(plan, status)
+		if r.Renames.set(plan.Name, rename) {
+			logger.Info("Applied HostnameRenamePlan, requeueing all Ingresses", "name", plan.Name,
+				"from", plan.Spec.From, "to", plan.Spec.To, "phase", plan.Spec.Phase, "state", status.State)
+		}
+		metrics.HostnameRenamePlanState.WithLabelValues(plan.Name).Set(hostnameRenameStateValue(status.State))
+	}
+
+	meta.SetStatusCondition(&status.Conditions, condition)
+	status.ObservedGeneration = plan.Generation
+	if !equalRenamePlanStatus(&plan.Status, status) {
+		plan.Status = *status
+		if err := r.Status().Update(ctx, plan); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return result, nil
+}
+
+// validate checks the suffixes of the plan and that no other plan renames them
+func (r *HostnameRenamePlanReconciler) validate(plan *doperatorv1alpha1.HostnameRenamePlan) error {
+	for _, suffix := range []string{plan.Spec.From, plan.Spec.To} {
+		if errs := validation.IsDNS1123Subdomain(suffix); len(errs) > 0 {
+			return fmt.Errorf("invalid hostname suffix %q: %s", suffix, strings.Join(errs, "; "))
+		}
+	}
+	if plan.Spec.From == plan.Spec.To {
+		return fmt.Errorf("from and to are both %q", plan.Spec.From)
+	}
+	if retention := plan.Spec.OldHostnameRetention; retention != nil && retention.Duration < 0 {
+		return fmt.Errorf("oldHostnameRetention %s must not be negative", retention.Duration)
+	}
+	rename := translator.HostnameRename{From: plan.Spec.From, To: plan.Spec.To}
+	if other := r.Renames.conflict(plan.Name, rename); other != "" {
+		return fmt.Errorf("HostnameRenamePlan %s already renames %s or %s", other, plan.Spec.From, plan.Spec.To)
+	}
+	return nil
+}
+
+// resolve returns what the phase of the plan serves, nil for nothing, updates the state in status and
+// returns when the cutover retention ends
+func (r *HostnameRenamePlanReconciler) resolve(
+	plan *doperatorv1alpha1.HostnameRenamePlan,
+	status *doperatorv1alpha1.HostnameRenamePlanStatus,
+) (*hostnameRename, ctrl.Result) {
+	rename := translator.HostnameRename{From: plan.Spec.From, To: plan.Spec.To}
+	if plan.Spec.Phase != doperatorv1alpha1.HostnameRenamePhaseCutover {
+		status.CutoverTime = nil
+	}
+
+	switch plan.Spec.Phase {
+	case doperatorv1alpha1.HostnameRenamePhasePrepare:
+		status.State = doperatorv1alpha1.HostnameRenameStateServingBoth
+		return &hostnameRename{rename: rename, both: true}, ctrl.Result{}
+	case doperatorv1alpha1.HostnameRenamePhaseCutover:
+		now := time.Now()
+		if status.CutoverTime == nil {
+			status.CutoverTime = &metav1.Time{Time: now}
+		}
+		retention := defaultOldHostnameRetention
+		if plan.Spec.OldHostnameRetention != nil {
+			retention = plan.Spec.OldHostnameRetention.Duration
+		}
+		if remaining := status.CutoverTime.Add(retention).Sub(now); remaining > 0 {
+			status.State = doperatorv1alpha1.HostnameRenameStateRetainingOld
+			return &hostnameRename{rename: rename, renamed: true, both: true}, ctrl.Result{RequeueAfter: remaining}
+		}
+		status.State = doperatorv1alpha1.HostnameRenameStateCompleted
+		return &hostnameRename{rename: rename, renamed: true}, ctrl.Result{}
+	case doperatorv1alpha1.HostnameRenamePhaseRollback:
+		status.State = doperatorv1alpha1.HostnameRenameStateRolledBack
+		return nil, ctrl.Result{}
+	default:
+		status.State = doperatorv1alpha1.HostnameRenameStatePending
+		return nil, ctrl.Result{}
+	}
+}
+
+// equalRenamePlanStatus reports whether two statuses are the same, ignoring condition transition times
+func equalRenamePlanStatus(a, b *doperatorv1alpha1.HostnameRenamePlanStatus) bool {
+	if a.ObservedGeneration != b.ObservedGeneration || a.State != b.State ||
+		!a.CutoverTime.Equal(b.CutoverTime) || len(a.Conditions) != len(b.Conditions) {
+		return false
+	}
+	for i := range a.Conditions {
+		if a.Conditions[i].Type != b.Conditions[i].Type || a.Conditions[i].Status != b.Conditions[i].Status ||
+			a.Conditions[i].Reason != b.Conditions[i].Reason || a.Conditions[i].Message != b.Conditions[i].Message ||
+			a.Conditions[i].ObservedGeneration != b.Conditions[i].ObservedGeneration {
+			return false
+		}
+	}
+	return true
+}
+
+// hostnameRenameStateValue maps a plan state to the value of the hostname_rename_plan_state metric
+func hostnameRenameStateValue(state doperatorv1alpha1.HostnameRenameState) float64 {
+	switch state {
+	case doperatorv1alpha1.HostnameRenameStateServingBoth:
+		return 1
+	case doperatorv1alpha1.HostnameRenameStateRetainingOld:
+		return 2
+	case doperatorv1alpha1.HostnameRenameStateCompleted:
+		return 3
+	case doperatorv1alpha1.HostnameRenameStateRolledBack:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *HostnameRenamePlanReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&doperatorv1alpha1.HostnameRenamePlan{}).
+		Named("hostnamerenameplan").
+		Complete(r)
+}
+
+// buildRenameRoute returns the rename HTTPRoute serving the other hostnames of the plans serving both next
+// to the Ingress HTTPRoutes, with the rules and parentRefs their counterparts have. Nil when there is none.
+func (r *IngressReconciler) buildRenameRoute(
+	ingress *networkingv1.Ingress,
+	singleTrans *translator.Translator,
+	httpRoutes []*gatewayv1.HTTPRoute,
+) *gatewayv1.HTTPRoute {
+	renames := r.HostnameRenames.Alias()
+	if renames == nil {
+		return nil
+	}
+	aliasConfig := singleTrans.Config
+	aliasConfig.HostnameRenames = renames
+	aliasTrans := translator.New(aliasConfig)
+
+	aliases := make(map[gatewayv1.Hostname]gatewayv1.Hostname)
+	for _, host := range ingressHosts(ingress, r.TLSOnlyHosts.Serves()) {
+		primary, alias := singleTrans.TransformHostname(host), aliasTrans.TransformHostname(host)
+		if primary != alias {
+			aliases[gatewayv1.Hostname(primary)] = gatewayv1.Hostname(alias)
+		}
+	}
+
+	var renameRoute *gatewayv1.HTTPRoute
+	for _, route := range httpRoutes {
+		if isAuxiliaryRoute(route.Name) {
+			continue
+		}
+		for _, hostname := range route.Spec.Hostnames {
+			alias, ok := aliases[hostname]
+			if !ok {
+				continue
+			}
+			if renameRoute == nil {
+				renameRoute = newCompanionRoute(route,
+					translator.RenameRouteName(r.HTTPRouteManager.NameTemplate.Name(ingress)))
+				renameRoute.Annotations[translator.HostnameRenamesAnnotation] = translator.FormatHostnameRenames(renames)
+			}
+			if !containsHostname(renameRoute.Spec.Hostnames, alias) {
+				renameRoute.Spec.Hostnames = append(renameRoute.Spec.Hostnames, alias)
+			}
+			for _, parentRef := range route.Spec.ParentRefs {
+				parentRef, ok := r.aliasParentRef(parentRef, string(hostname), string(alias), singleTrans, aliasTrans)
+				if ok && !containsParentRef(renameRoute.Spec.ParentRefs, parentRef) {
+					renameRoute.Spec.ParentRefs = append(renameRoute.Spec.ParentRefs, parentRef)
+				}
+			}
+			for _, rule := range route.Spec.Rules {
+				if len(renameRoute.Spec.Rules) >= utils.MaxHTTPRouteRules {
+					break
+				}
+				if !containsRule(renameRoute.Spec.Rules, rule) {
+					renameRoute.Spec.Rules = append(renameRoute.Spec.Rules, rule)
+				}
+			}
+		}
+	}
+	return renameRoute
+}
+
+// aliasParentRef moves a parentRef of hostname to the listener of its alias, false when it belongs to
+// another hostname
+func (r *IngressReconciler) aliasParentRef(
+	parentRef gatewayv1.ParentReference,
+	hostname, alias string,
+	singleTrans, aliasTrans *translator.Translator,
+) (gatewayv1.ParentReference, bool) {
+	if parentRef.SectionName == nil || r.AttachOnly {
+		return parentRef, true
+	}
+	listenerHostname := singleTrans.ListenerHostname(hostname)
+	aliasListenerHostname := aliasTrans.ListenerHostname(alias)
+	var section gatewayv1.SectionName
+	switch *parentRef.SectionName {
+	case translator.ListenerName(listenerHostname):
+		section = translator.ListenerName(aliasListenerHostname)
+	case translator.HTTPListenerName(listenerHostname):
+		section = translator.HTTPListenerName(aliasListenerHostname)
+	default:
+		return parentRef, false
+	}
+	parentRef.SectionName = &section
+	return parentRef, true
+}
+
+// applyRenameRoute creates or updates the rename HTTPRoute of the Ingress, or deletes it when nil
+func (r *IngressReconciler) applyRenameRoute(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	renameRoute *gatewayv1.HTTPRoute,
+) error {
+	metricRecorder := r.resourceRecorder(ctx, ingress, "HTTPRoute", metrics.HTTPRouteResourcesTotal)
+	if renameRoute != nil {
+		if err := r.HTTPRouteManager.ApplyHTTPRoute(ctx, renameRoute, metricRecorder); err != nil {
+			return fmt.Errorf("failed to apply rename HTTPRoute %s: %w", renameRoute.Name, err)
+		}
+		return nil
+	}
+
+	key := types.NamespacedName{
+		Namespace: ingress.Namespace,
+		Name:      translator.RenameRouteName(r.HTTPRouteManager.NameTemplate.Name(ingress)),
+	}
+	existing := &gatewayv1.HTTPRoute{}
+	if err := r.HTTPRouteManager.Client.Get(ctx, key, existing); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !translator.IsRenameRoute(existing) || !utils.IsManagedByUsForIngress(existing, ingress.Namespace, ingress.Name) {
+		return nil
+	}
+	log.FromContext(ctx).Info("Deleting rename HTTPRoute", "namespace", existing.Namespace, "name", existing.Name)
+	if err := r.HTTPRouteManager.Client.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete rename HTTPRoute %s: %w", existing.Name, err)
+	}
+	metricRecorder("delete", existing.Namespace, existing.Name)
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// ingressHostnames returns the distinct hostnames of the Ingress rules and TLS sections, sorted
+func ingressHostnames(ingress *networkingv1.Ingress) []string {
+	var hostnames []string
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" {
+			hostnames = append(hostnames, rule.Host)
+		}
+	}
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			if host != "" {
+				hostnames = append(hostnames, host)
+			}
+		}
+	}
+	slices.Sort(hostnames)
+	return slices.Compact(hostnames)
+}
+
+// recordHostnameStates records which data plane serves the hostnames of the Ingress
+func (r *IngressReconciler) recordHostnameStates(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	gatewayName string,
+	state utils.HostnameServingState,
+) {
+	if r.HostnameStates == nil {
+		return
+	}
+	err := r.HostnameStates.Set(ctx, r.fanInSourceName(), client.ObjectKeyFromObject(ingress), gatewayName,
+		ingressHostnames(ingress), state)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "failed to record hostname serving states",
+			"namespace", ingress.Namespace, "name", ingress.Name, "state", state)
+	}
+}
+
+// releaseHostnameStates drops the hostname states of a deleted Ingress, all of them once its routes are gone
+func (r *IngressReconciler) releaseHostnameStates(ctx context.Context, key types.NamespacedName, all bool) {
+	if err := r.HostnameStates.Release(ctx, r.fanInSourceName(), key, all); err != nil {
+		log.FromContext(ctx).Error(err, "failed to release hostname serving states", "ingress", key.String())
+	}
+}
@@ -46,13 +46,51 @@ import (
 // HTTPRouteReconciler reconciles HTTPRoute resources and manages Gateway listeners
 type HTTPRouteReconciler struct {
 	client.Client
-	Scheme                    *runtime.Scheme
-	GatewayNamespace          string
-	GatewayName               string
-	GatewayClassName          string
+	Scheme           *runtime.Scheme
+	GatewayNamespace string
+	GatewayName      string
+	GatewayClassName string
+	// GatewayClassMapping overrides GatewayClassName for Gateways of matching ingress classes
+	GatewayClassMapping []translator.GatewayClassRule
+	// IngressClassEmpty is the ingress class assumed for Ingresses without one
+	IngressClassEmpty         string
 	HostnameRewriteFrom       string
 	HostnameRewriteTo         string
 	IngressPostProcessingMode IngressPostProcessingMode
+	// PauseOnUnhealthyGatewayClass holds back external-dns disabling while the GatewayClass is unhealthy
+	PauseOnUnhealthyGatewayClass bool
+	// ListenerAllowedRoutes selects the allowedRoutes strategy per Gateway or listener
+	ListenerAllowedRoutes translator.AllowedRoutesPolicies
+	// WildcardListenerDomains consolidates <label>.<domain> hostnames into one *.<domain> listener
+	WildcardListenerDomains []string
+	// AttachOnly leaves the Gateway listeners to whoever provisioned the Gateway
+	AttachOnly bool
+	// FanIn resolves HTTPRoutes generated from Ingresses of remote clusters
+	FanIn *FanIn
+	// GRPCRoutes keeps the listeners of generated GRPCRoutes alongside those of HTTPRoutes
+	GRPCRoutes bool
+	// TLSRoutes keeps the passthrough listeners of generated TLSRoutes
+	TLSRoutes bool
+	// IntentLog records external-dns disabling before it happens, nil = off
+	IntentLog *utils.IntentLog
+	// CertReplication selects how listeners reach TLS Secrets of other namespaces
+	CertReplication CertReplicationMode
+	// APIReader reads Secrets without caching them, falls back to the client
+	APIReader client.Reader
+	// GatewayAddresses pins spec.addresses per Gateway
+	GatewayAddresses translator.GatewayAddressRules
+	// InfraAnnotationsByGateway sets spec.infrastructure.annotations per Gateway
+	InfraAnnotationsByGateway translator.GatewayInfrastructureAnnotationRules
+	// OwnershipMode links ReferenceGrants to the Ingresses of their HTTPRoutes in finalizer mode
+	OwnershipMode OwnershipMode
+	// MigrationPolicy overrides the hostname rewrite and post-processing at runtime, nil = fields only
+	MigrationPolicy *MigrationPolicy
+	// HostnameRenames are the HostnameRenamePlans in effect, nil = none
+	HostnameRenames *HostnameRenames
+	// Notifier sends cert-mismatch notifications, nil = off
+	Notifier *utils.Notifier
+	// GatewayAPI tracks the installed Gateway API kinds, nil assumes all of them are installed
+	GatewayAPI *utils.GatewayAPICapabilities
 
 	// Debouncing state
 	gatewayUpdateDebouncer *gatewayUpdateDebouncer
@@ -121,7 +159,7 @@ func (d *gatewayUpdateDebouncer) executeGatewayUpdate(ctx context.Context, gatew
 	}
 
 	// Gateway successfully updated - now safe to disable external-dns on source Ingresses
-	if updated && d.reconciler.IngressPostProcessingMode == IngressPostProcessingModeDisableExternalDNS {
+	if updated && d.reconciler.settings().IngressPostProcessingMode == IngressPostProcessingModeDisableExternalDNS {
 		// Track which Ingresses we've already processed to avoid duplicates
 		processedIngresses := make(map[string]bool)
 
@@ -141,8 +179,16 @@ func (d *gatewayUpdateDebouncer) executeGatewayUpdate(ctx context.Context, gatew
 				continue
 			}
 			processedIngresses[ingressKey] = true
+			if d.reconciler.externalDNSDisablePaused(ctx, ingress) {
+				continue
+			}
 
-			if err := disableExternalDNS(ctx, d.reconciler.Client, ingress); err != nil {
+			ingressClient, err := d.reconciler.sourceClient(route)
+			if err != nil {
+				logger.Error(err, "failed to disable external-dns on source Ingress", "ingress", ingressKey)
+				continue
+			}
+			if err := disableExternalDNS(ctx, ingressClient, d.reconciler.intents(route), ingress); err != nil {
 				logger.Error(err, "failed to disable external-dns on source Ingress after Gateway update",
 					"ingress", ingressKey)
 				// Don't fail - continue with other Ingresses
@@ -196,6 +242,10 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, nil
 	}
 
+	if r.AttachOnly {
+		return ctrl.Result{}, r.releaseAttachOnlyHTTPRoute(ctx, httpRoute)
+	}
+
 	// Handle deletion with finalizer
 	if !httpRoute.DeletionTimestamp.IsZero() {
 		// HTTPRoute is being deleted
@@ -208,6 +258,7 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 			// Remove our finalizer
 			httpRoute.Finalizers = utils.RemoveString(httpRoute.Finalizers, HTTPRouteFinalizerName)
+			utils.LogUpdateDiff(ctx, r.Client, httpRoute)
 			if err := r.Update(ctx, httpRoute); err != nil {
 				logger.Error(err, "failed to remove finalizer")
 				return ctrl.Result{}, err
@@ -219,6 +270,7 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// Add finalizer if not present
 	if !utils.ContainsString(httpRoute.Finalizers, HTTPRouteFinalizerName) {
 		httpRoute.Finalizers = append(httpRoute.Finalizers, HTTPRouteFinalizerName)
+		utils.LogUpdateDiff(ctx, r.Client, httpRoute)
 		if err := r.Update(ctx, httpRoute); err != nil {
 			logger.Error(err, "failed to add finalizer")
 			return ctrl.Result{}, err
@@ -228,7 +280,17 @@ func (r *HTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	// HTTPRoute created/updated - add/update listener on Gateway
 	logger.V(1).Info("HTTPRoute created/updated, updating Gateway listener")
-	return r.handleHTTPRouteCreateOrUpdate(ctx, httpRoute)
+	result, err := r.handleHTTPRouteCreateOrUpdate(ctx, httpRoute)
+	if err == nil && result.IsZero() && r.CertReplication == CertReplicationSecretCopy {
+		// Nothing watches the source Secrets, pick up renewed certificates periodically
+		result.RequeueAfter = certReplicaResync
+	}
+	return result, err
+}
+
+// intents returns the intent scope of the cluster the HTTPRoute's Ingress lives in
+func (r *HTTPRouteReconciler) intents(httpRoute *gatewayv1.HTTPRoute) intentScope {
+	return intentScope{log: r.IntentLog, cluster: httpRoute.Annotations[translator.SourceClusterAnnotation]}
 }
 
 // isManagedByUs checks if the HTTPRoute is managed by ingress-doperator
@@ -271,7 +333,7 @@ func (r *HTTPRouteReconciler) handleHTTPRouteCreateOrUpdate(ctx context.Context,
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			gatewayExists = false
-			gateway = r.createInitialGateway(gatewayName)
+			gateway = r.createInitialGateway(gatewayName, r.gatewayClassFor(ingress))
 		} else {
 			logger.Error(err, "unable to fetch Gateway")
 			return ctrl.Result{}, err
@@ -291,17 +353,19 @@ func (r *HTTPRouteReconciler) handleHTTPRouteCreateOrUpdate(ctx context.Context,
 		}
 	}
 
-	// Ensure ReferenceGrant exists if HTTPRoute is in a different namespace than Gateway
+	// Make TLS Secrets of other namespaces reachable (ReferenceGrant, copy or reflector annotations)
 	// This must be done BEFORE updating the Gateway
-	if httpRoute.Namespace != r.GatewayNamespace {
-		if err := r.ensureReferenceGrant(ctx, httpRoute); err != nil {
-			logger.Error(err, "failed to ensure ReferenceGrant")
-			// Don't fail the reconcile, just log the error
-		}
+	if err := r.certReplicator().ensure(ctx, httpRoute, ingress); err != nil {
+		logger.Error(err, "failed to replicate TLS Secrets", "mode", r.CertReplication)
+		// Don't fail the reconcile, just log the error
 	}
 
 	// Merge this HTTPRoute into existing Gateway listeners (no removals here)
 	updated := r.updateGatewayListeners(ctx, gateway, httpRoute, ingress)
+	if !gatewayExists {
+		r.syncGatewayAddresses(ctx, gateway)
+		r.syncInfrastructureAnnotations(ctx, gateway)
+	}
 
 	if gatewayExists {
 		if updated {
@@ -323,8 +387,9 @@ func (r *HTTPRouteReconciler) handleHTTPRouteCreateOrUpdate(ctx context.Context,
 		metrics.GatewayResourcesTotal.WithLabelValues("create", gateway.Namespace, gateway.Name).Inc()
 
 		// Gateway created successfully - now safe to disable external-dns on source Ingress
-		if r.IngressPostProcessingMode == IngressPostProcessingModeDisableExternalDNS {
-			if err := disableExternalDNS(ctx, r.Client, ingress); err != nil {
+		if r.settings().IngressPostProcessingMode == IngressPostProcessingModeDisableExternalDNS &&
+			!r.externalDNSDisablePaused(ctx, ingress) {
+			if err := disableExternalDNS(ctx, r.Client, r.intents(httpRoute), ingress); err != nil {
 				logger.Error(err, "failed to disable external-dns on source Ingress after Gateway creation")
 				// Don't fail the reconcile - Gateway is already created
 			}
@@ -334,17 +399,34 @@ func (r *HTTPRouteReconciler) handleHTTPRouteCreateOrUpdate(ctx context.Context,
 	return ctrl.Result{}, nil
 }
 
+// externalDNSDisablePaused reports whether external-dns disabling must wait for the GatewayClass
+// (the Ingress controller retries once it becomes healthy again) or for the Ingress to be unpaused
+func (r *HTTPRouteReconciler) externalDNSDisablePaused(ctx context.Context, ingress *networkingv1.Ingress) bool {
+	if IngressPaused(ingress) {
+		log.FromContext(ctx).V(1).Info("Skipping external-dns disable, Ingress is paused",
+			"namespace", ingress.Namespace, "name", ingress.Name)
+		return true
+	}
+	if !r.PauseOnUnhealthyGatewayClass {
+		return false
+	}
+	gatewayClassName := r.gatewayClassFor(ingress)
+	healthy, message := checkGatewayClassHealth(ctx, r.Client, gatewayClassName)
+	if !healthy {
+		log.FromContext(ctx).Info("Skipping external-dns disable, GatewayClass is unhealthy", "reason", message)
+	}
+	return !healthy
+}
+
 // handleHTTPRouteDelete removes listeners/namespaces from Gateway when HTTPRoute is deleted
 // With finalizer, we still have access to HTTPRoute spec for surgical cleanup
 func (r *HTTPRouteReconciler) handleHTTPRouteDelete(ctx context.Context, httpRoute *gatewayv1.HTTPRoute) error {
 	logger := log.FromContext(ctx)
 
-	// Clean up ReferenceGrant for this HTTPRoute's namespace
-	if httpRoute.Namespace != r.GatewayNamespace {
-		if err := r.cleanupReferenceGrant(ctx, httpRoute.Namespace, httpRoute.Name); err != nil {
-			logger.Error(err, "failed to cleanup ReferenceGrant", "namespace", httpRoute.Namespace, "name", httpRoute.Name)
-			// Don't fail - continue with Gateway cleanup
-		}
+	// Clean up the ReferenceGrant or Secret replicas for this HTTPRoute's namespace
+	if err := r.certReplicator().release(ctx, httpRoute.Namespace, httpRoute.Name); err != nil {
+		logger.Error(err, "failed to release TLS Secret replication", "namespace", httpRoute.Namespace, "name", httpRoute.Name)
+		// Don't fail - continue with Gateway cleanup
 	}
 
 	// Get the Gateway name from the HTTPRoute (we can still read spec!)
@@ -426,11 +508,20 @@ func (r *HTTPRouteReconciler) reconcileGatewayListeners(
 
 		// Build desired state: which hostnames should have listeners with which namespaces
 		desiredState := r.calculateDesiredListenerState(routes)
+		desiredHTTPState := r.calculateDesiredHTTPListenerState(routes)
+		desiredPassthroughState := r.calculateDesiredPassthroughListenerState(routes)
 
 		// Update Gateway listeners to match desired state (incremental updates)
 		desiredTLS, certMismatches, tlsUnknown := r.buildDesiredListenerTLS(ctx, desiredState, routes)
 
-		updated := r.reconcileListenersToDesiredState(gateway, desiredState, desiredTLS, tlsUnknown, logger)
+		updated := r.reconcileListenersToDesiredState(gateway, desiredState, desiredHTTPState, desiredPassthroughState,
+			desiredTLS, tlsUnknown, logger)
+		if r.syncGatewayAddresses(ctx, gateway) {
+			updated = true
+		}
+		if r.syncInfrastructureAnnotations(ctx, gateway) {
+			updated = true
+		}
 
 		desiredMismatch := ""
 		if len(certMismatches) > 0 {
@@ -439,7 +530,8 @@ func (r *HTTPRouteReconciler) reconcileGatewayListeners(
 		if gateway.Annotations == nil {
 			gateway.Annotations = make(map[string]string)
 		}
-		if gateway.Annotations[translator.MismatchedCertAnnotation] != desiredMismatch {
+		previousMismatch := gateway.Annotations[translator.MismatchedCertAnnotation]
+		if previousMismatch != desiredMismatch {
 			updated = true
 			if desiredMismatch == "" {
 				delete(gateway.Annotations, translator.MismatchedCertAnnotation)
@@ -449,6 +541,7 @@ func (r *HTTPRouteReconciler) reconcileGatewayListeners(
 		}
 
 		if updated {
+			utils.LogUpdateDiff(ctx, r.Client, gateway)
 			if err := r.Update(ctx, gateway); err != nil {
 				if apierrors.IsConflict(err) {
 					backoff := time.Duration(attempt+1) * 200 * time.Millisecond
@@ -462,6 +555,7 @@ func (r *HTTPRouteReconciler) reconcileGatewayListeners(
 				return false, err
 			}
 			logger.Info("Reconciled Gateway listeners", "gateway", gatewayNN, "listenerCount", len(gateway.Spec.Listeners))
+			r.notifyCertMismatches(ctx, gateway, previousMismatch, desiredMismatch)
 		}
 
 		return updated, nil
@@ -475,24 +569,118 @@ func (r *HTTPRouteReconciler) calculateDesiredListenerState(routes []gatewayv1.H
 	// hostname -> set of namespaces that need access
 	state := make(map[string]map[string]bool)
 
-	for _, route := range routes {
-		for _, hostname := range route.Spec.Hostnames {
-			hostnameStr := string(hostname)
+	for i := range routes {
+		hostnames, _, _ := r.routeListenerHostnames(&routes[i])
+		for _, hostnameStr := range hostnames {
 			if _, exists := state[hostnameStr]; !exists {
 				state[hostnameStr] = make(map[string]bool)
 			}
-			state[hostnameStr][route.Namespace] = true
+			state[hostnameStr][routes[i].Namespace] = true
 		}
 	}
 
 	return state
 }
 
+// calculateDesiredHTTPListenerState computes which paired HTTP listeners should exist based on HTTPRoutes
+func (r *HTTPRouteReconciler) calculateDesiredHTTPListenerState(routes []gatewayv1.HTTPRoute) map[string]map[string]bool {
+	// hostname -> set of namespaces that need access
+	state := make(map[string]map[string]bool)
+
+	for i := range routes {
+		_, hostnames, _ := r.routeListenerHostnames(&routes[i])
+		for _, hostnameStr := range hostnames {
+			if _, exists := state[hostnameStr]; !exists {
+				state[hostnameStr] = make(map[string]bool)
+			}
+			state[hostnameStr][routes[i].Namespace] = true
+		}
+	}
+
+	return state
+}
+
+// calculateDesiredPassthroughListenerState computes which TLS passthrough listeners should exist based on
+// the listener views of TLSRoutes
+func (r *HTTPRouteReconciler) calculateDesiredPassthroughListenerState(
+	routes []gatewayv1.HTTPRoute,
+) map[string]map[string]bool {
+	// hostname -> set of namespaces that need access
+	state := make(map[string]map[string]bool)
+
+	for i := range routes {
+		_, _, hostnames := r.routeListenerHostnames(&routes[i])
+		for _, hostnameStr := range hostnames {
+			if _, exists := state[hostnameStr]; !exists {
+				state[hostnameStr] = make(map[string]bool)
+			}
+			state[hostnameStr][routes[i].Namespace] = true
+		}
+	}
+
+	return state
+}
+
+// routeListenerHostnames returns the listener hostnames a route needs HTTPS listeners for, those it
+// needs paired HTTP listeners for and those it needs TLS passthrough listeners for. A route attached
+// only to paired HTTP listeners (the HTTPS redirect) or passthrough listeners needs no HTTPS listener.
+func (r *HTTPRouteReconciler) routeListenerHostnames(route *gatewayv1.HTTPRoute) ([]string, []string, []string) {
+	needsHTTPS := len(route.Spec.ParentRefs) == 0
+	httpSections := make(map[gatewayv1.SectionName]bool)
+	passthroughSections := make(map[gatewayv1.SectionName]bool)
+	for _, parentRef := range route.Spec.ParentRefs {
+		switch {
+		case parentRef.SectionName != nil && translator.IsHTTPListenerName(*parentRef.SectionName):
+			httpSections[*parentRef.SectionName] = true
+		case parentRef.SectionName != nil && translator.IsPassthroughListenerName(*parentRef.SectionName):
+			passthroughSections[*parentRef.SectionName] = true
+		default:
+			needsHTTPS = true
+		}
+	}
+
+	seen := make(map[string]bool, len(route.Spec.Hostnames))
+	var httpsHostnames, httpHostnames, passthroughHostnames []string
+	for _, hostname := range route.Spec.Hostnames {
+		hostnameStr := translator.WildcardListenerHostname(string(hostname), r.WildcardListenerDomains)
+		if seen[hostnameStr] {
+			continue
+		}
+		seen[hostnameStr] = true
+		if needsHTTPS {
+			httpsHostnames = append(httpsHostnames, hostnameStr)
+		}
+		if httpSections[translator.HTTPListenerName(hostnameStr)] {
+			httpHostnames = append(httpHostnames, hostnameStr)
+		}
+		if passthroughSections[translator.PassthroughListenerName(hostnameStr)] {
+			passthroughHostnames = append(passthroughHostnames, hostnameStr)
+		}
+	}
+	return httpsHostnames, httpHostnames, passthroughHostnames
+}
+
+// isPassthroughRoute reports whether a route (the listener view of a TLSRoute) only attaches to TLS
+// passthrough listeners, whose certificates stay with the backends
+func isPassthroughRoute(route *gatewayv1.HTTPRoute) bool {
+	if len(route.Spec.ParentRefs) == 0 {
+		return false
+	}
+	for _, parentRef := range route.Spec.ParentRefs {
+		if parentRef.SectionName == nil || !translator.IsPassthroughListenerName(*parentRef.SectionName) {
+			return false
+		}
+	}
+	return true
+}
+
 // reconcileListenersToDesiredState incrementally updates Gateway listeners
 // Returns true if Gateway was modified
 func (r *HTTPRouteReconciler) reconcileListenersToDesiredState(
 	gateway *gatewayv1.Gateway,
 	desiredState map[string]map[string]bool,
+	desiredHTTPState map[string]map[string]bool,
+	desiredPassthroughState map[string]map[string]bool,
 	desiredTLS map[string]*gatewayv1.ListenerTLSConfig,
 	tlsUnknown map[string]bool,
 	logger logr.Logger,
@@ -502,13 +690,25 @@ func (r *HTTPRouteReconciler) reconcileListenersToDesiredState(
 	// Step 1: Remove listeners that shouldn't exist
 	newListeners := make([]gatewayv1.Listener, 0, len(gateway.Spec.Listeners))
 	for _, listener := range gateway.Spec.Listeners {
+		// TCP and UDP listeners belong to the services ConfigMap reconcilers
+		if translator.IsStreamListener(&listener) {
+			newListeners = append(newListeners, listener)
+			continue
+		}
 		hostname := ""
 		if listener.Hostname != nil {
 			hostname = string(*listener.Hostname)
 		}
 
 		// Keep listener if it's in desired state
-		if _, shouldExist := desiredState[hostname]; shouldExist {
+		state := desiredState
+		switch listener.Protocol {
+		case gatewayv1.HTTPProtocolType:
+			state = desiredHTTPState
+		case gatewayv1.TLSProtocolType:
+			state = desiredPassthroughState
+		}
+		if _, shouldExist := state[hostname]; shouldExist {
 			newListeners = append(newListeners, listener)
 		} else {
 			// Remove listener
@@ -532,8 +732,14 @@ func (r *HTTPRouteReconciler) reconcileListenersToDesiredState(
 
 		if listenerIdx >= 0 {
 			// Update existing listener's allowed namespaces
-			if r.updateListenerNamespaces(&gateway.Spec.Listeners[listenerIdx], namespaceList) {
-				logger.Info("Updated listener namespaces", "listener", gateway.Spec.Listeners[listenerIdx].Name, "namespaces", namespaceList)
+			listener := &gateway.Spec.Listeners[listenerIdx]
+			policy := r.ListenerAllowedRoutes.Resolve(gateway.Name, string(listener.Name))
+			if policy.Apply(listener, namespaceList) {
+				logger.Info("Updated listener allowedRoutes", "listener", listener.Name, "policy", policy.Mode)
+				updated = true
+			} else if policy.Mode == translator.AllowedRoutesNamespaces &&
+				r.updateListenerNamespaces(listener, namespaceList) {
+				logger.Info("Updated listener namespaces", "listener", listener.Name, "namespaces", namespaceList)
 				updated = true
 			}
 			if !tlsUnknown[hostname] {
@@ -545,7 +751,7 @@ func (r *HTTPRouteReconciler) reconcileListenersToDesiredState(
 		} else {
 			// Add new listener only when TLS is known (safe)
 			if !tlsUnknown[hostname] {
-				listener := r.createListenerWithNamespaces(hostname, namespaceList, desiredTLS[hostname])
+				listener := r.createListenerWithNamespaces(gateway.Name, hostname, namespaceList, desiredTLS[hostname])
 				gateway.Spec.Listeners = append(gateway.Spec.Listeners, listener)
 				logger.Info("Added new listener", "listener", listener.Name, "hostname", hostname, "namespaces", namespaceList)
 				updated = true
@@ -553,6 +759,63 @@ func (r *HTTPRouteReconciler) reconcileListenersToDesiredState(
 		}
 	}
 
+	// Step 3: Paired HTTP listeners (no TLS to wait for)
+	for hostname, namespaces := range desiredHTTPState {
+		namespaceList := make([]string, 0, len(namespaces))
+		for ns := range namespaces {
+			namespaceList = append(namespaceList, ns)
+		}
+		sort.Strings(namespaceList)
+
+		listenerIdx := r.findHTTPListenerByHostname(gateway, hostname)
+		if listenerIdx < 0 {
+			listener := r.createHTTPListenerWithNamespaces(gateway.Name, hostname, namespaceList)
+			gateway.Spec.Listeners = append(gateway.Spec.Listeners, listener)
+			logger.Info("Added new HTTP listener", "listener", listener.Name, "hostname", hostname, "namespaces", namespaceList)
+			updated = true
+			continue
+		}
+		listener := &gateway.Spec.Listeners[listenerIdx]
+		policy := r.ListenerAllowedRoutes.Resolve(gateway.Name, string(listener.Name))
+		if policy.Apply(listener, namespaceList) {
+			logger.Info("Updated listener allowedRoutes", "listener", listener.Name, "policy", policy.Mode)
+			updated = true
+		} else if policy.Mode == translator.AllowedRoutesNamespaces &&
+			r.updateListenerNamespaces(listener, namespaceList) {
+			logger.Info("Updated listener namespaces", "listener", listener.Name, "namespaces", namespaceList)
+			updated = true
+		}
+	}
+
+	// Step 4: TLS passthrough listeners (certificates stay with the backends)
+	for hostname, namespaces := range desiredPassthroughState {
+		namespaceList := make([]string, 0, len(namespaces))
+		for ns := range namespaces {
+			namespaceList = append(namespaceList, ns)
+		}
+		sort.Strings(namespaceList)
+
+		listenerIdx := r.findPassthroughListenerByHostname(gateway, hostname)
+		if listenerIdx < 0 {
+			listener := r.createPassthroughListenerWithNamespaces(gateway.Name, hostname, namespaceList)
+			gateway.Spec.Listeners = append(gateway.Spec.Listeners, listener)
+			logger.Info("Added new passthrough listener", "listener", listener.Name, "hostname", hostname,
+				"namespaces", namespaceList)
+			updated = true
+			continue
+		}
+		listener := &gateway.Spec.Listeners[listenerIdx]
+		policy := r.ListenerAllowedRoutes.Resolve(gateway.Name, string(listener.Name))
+		if policy.Apply(listener, namespaceList) {
+			logger.Info("Updated listener allowedRoutes", "listener", listener.Name, "policy", policy.Mode)
+			updated = true
+		} else if policy.Mode == translator.AllowedRoutesNamespaces &&
+			r.updateListenerNamespaces(listener, namespaceList) {
+			logger.Info("Updated listener namespaces", "listener", listener.Name, "namespaces", namespaceList)
+			updated = true
+		}
+	}
+
 	return updated
 }
 
@@ -572,12 +835,17 @@ func (r *HTTPRouteReconciler) updateGatewayListeners(
 
 	desiredTLS, certMismatches := r.buildTLSForRouteFromIngress(httpRoute, ingress)
 
-	for _, hostname := range httpRoute.Spec.Hostnames {
-		hostnameStr := string(hostname)
+	httpsHostnames, httpHostnames, passthroughHostnames := r.routeListenerHostnames(httpRoute)
+	for _, hostnameStr := range httpsHostnames {
 		listenerIdx := r.findListenerByHostname(gateway, hostnameStr)
 
 		if listenerIdx >= 0 {
-			if r.addNamespaceToListener(&gateway.Spec.Listeners[listenerIdx], httpRoute.Namespace) {
+			listener := &gateway.Spec.Listeners[listenerIdx]
+			policy := r.ListenerAllowedRoutes.Resolve(gateway.Name, string(listener.Name))
+			if policy.Apply(listener, []string{httpRoute.Namespace}) {
+				updated = true
+			} else if policy.Mode == translator.AllowedRoutesNamespaces &&
+				r.addNamespaceToListener(listener, httpRoute.Namespace) {
 				updated = true
 			}
 			// Only set TLS if listener doesn't have it yet
@@ -586,10 +854,47 @@ func (r *HTTPRouteReconciler) updateGatewayListeners(
 				updated = true
 			}
 		} else {
-			listener := r.createListenerWithNamespaces(hostnameStr, []string{httpRoute.Namespace}, desiredTLS[hostnameStr])
+			listener := r.createListenerWithNamespaces(
+				gateway.Name, hostnameStr, []string{httpRoute.Namespace}, desiredTLS[hostnameStr])
+			gateway.Spec.Listeners = append(gateway.Spec.Listeners, listener)
+			updated = true
+			logger.Info("Added new listener", "listener", listener.Name, "hostname", hostnameStr)
+		}
+	}
+	for _, hostnameStr := range httpHostnames {
+		listenerIdx := r.findHTTPListenerByHostname(gateway, hostnameStr)
+		if listenerIdx >= 0 {
+			listener := &gateway.Spec.Listeners[listenerIdx]
+			policy := r.ListenerAllowedRoutes.Resolve(gateway.Name, string(listener.Name))
+			if policy.Apply(listener, []string{httpRoute.Namespace}) {
+				updated = true
+			} else if policy.Mode == translator.AllowedRoutesNamespaces &&
+				r.addNamespaceToListener(listener, httpRoute.Namespace) {
+				updated = true
+			}
+		} else {
+			listener := r.createHTTPListenerWithNamespaces(gateway.Name, hostnameStr, []string{httpRoute.Namespace})
 			gateway.Spec.Listeners = append(gateway.Spec.Listeners, listener)
 			updated = true
-			logger.Info("Added new listener", "listener", listener.Name, "hostname", hostname)
+			logger.Info("Added new HTTP listener", "listener", listener.Name, "hostname", hostnameStr)
+		}
+	}
+	for _, hostnameStr := range passthroughHostnames {
+		listenerIdx := r.findPassthroughListenerByHostname(gateway, hostnameStr)
+		if listenerIdx >= 0 {
+			listener := &gateway.Spec.Listeners[listenerIdx]
+			policy := r.ListenerAllowedRoutes.Resolve(gateway.Name, string(listener.Name))
+			if policy.Apply(listener, []string{httpRoute.Namespace}) {
+				updated = true
+			} else if policy.Mode == translator.AllowedRoutesNamespaces &&
+				r.addNamespaceToListener(listener, httpRoute.Namespace) {
+				updated = true
+			}
+		} else {
+			listener := r.createPassthroughListenerWithNamespaces(gateway.Name, hostnameStr, []string{httpRoute.Namespace})
+			gateway.Spec.Listeners = append(gateway.Spec.Listeners, listener)
+			updated = true
+			logger.Info("Added new passthrough listener", "listener", listener.Name, "hostname", hostnameStr)
 		}
 	}
 
@@ -609,10 +914,33 @@ func (r *HTTPRouteReconciler) updateGatewayListeners(
 	return updated
 }
 
-// findListenerByHostname finds a listener index by hostname, returns -1 if not found
+// findListenerByHostname finds an HTTPS listener index by hostname, returns -1 if not found
 func (r *HTTPRouteReconciler) findListenerByHostname(gateway *gatewayv1.Gateway, hostname string) int {
 	for i, listener := range gateway.Spec.Listeners {
-		if listener.Hostname != nil && string(*listener.Hostname) == hostname {
+		if listener.Protocol != gatewayv1.HTTPProtocolType && listener.Protocol != gatewayv1.TLSProtocolType &&
+			listener.Hostname != nil && string(*listener.Hostname) == hostname {
+			return i
+		}
+	}
+	return -1
+}
+
+// findHTTPListenerByHostname finds a paired HTTP listener index by hostname, returns -1 if not found
+func (r *HTTPRouteReconciler) findHTTPListenerByHostname(gateway *gatewayv1.Gateway, hostname string) int {
+	for i, listener := range gateway.Spec.Listeners {
+		if listener.Protocol == gatewayv1.HTTPProtocolType &&
+			listener.Hostname != nil && string(*listener.Hostname) == hostname {
+			return i
+		}
+	}
+	return -1
+}
+
+// findPassthroughListenerByHostname finds a TLS passthrough listener index by hostname, returns -1 if not found
+func (r *HTTPRouteReconciler) findPassthroughListenerByHostname(gateway *gatewayv1.Gateway, hostname string) int {
+	for i, listener := range gateway.Spec.Listeners {
+		if listener.Protocol == gatewayv1.TLSProtocolType &&
+			listener.Hostname != nil && string(*listener.Hostname) == hostname {
 			return i
 		}
 	}
@@ -690,34 +1018,58 @@ func stringSlicesEqual(a, b []string) bool {
 	return true
 }
 
-// createListenerWithNamespaces creates a listener with multiple allowed namespaces
+// createListenerWithNamespaces creates a listener with multiple allowed namespaces,
+// or with the allowedRoutes policy configured for the Gateway/listener
 func (r *HTTPRouteReconciler) createListenerWithNamespaces(
+	gatewayName string,
 	hostname string,
 	namespaces []string,
 	tlsConfig *gatewayv1.ListenerTLSConfig,
 ) gatewayv1.Listener {
-	from := gatewayv1.NamespacesFromSelector
-	return gatewayv1.Listener{
-		Name:     gatewayv1.SectionName(hostname),
+	listener := gatewayv1.Listener{
+		Name:     translator.ListenerName(hostname),
 		Hostname: (*gatewayv1.Hostname)(&hostname),
 		Port:     gatewayv1.PortNumber(443),
 		Protocol: gatewayv1.HTTPSProtocolType,
 		TLS:      tlsConfig,
-		AllowedRoutes: &gatewayv1.AllowedRoutes{
-			Namespaces: &gatewayv1.RouteNamespaces{
-				From: &from,
-				Selector: &metav1.LabelSelector{
-					MatchExpressions: []metav1.LabelSelectorRequirement{
-						{
-							Key:      labelSelectorNamespaceKey,
-							Operator: metav1.LabelSelectorOpIn,
-							Values:   namespaces,
-						},
-					},
-				},
-			},
-		},
 	}
+	listener.AllowedRoutes = r.ListenerAllowedRoutes.Resolve(gatewayName, hostname).AllowedRoutes(namespaces)
+	return listener
+}
+
+// createHTTPListenerWithNamespaces creates the port 80 listener paired with the HTTPS listener of hostname
+func (r *HTTPRouteReconciler) createHTTPListenerWithNamespaces(
+	gatewayName string,
+	hostname string,
+	namespaces []string,
+) gatewayv1.Listener {
+	listener := gatewayv1.Listener{
+		Name:     translator.HTTPListenerName(hostname),
+		Hostname: (*gatewayv1.Hostname)(&hostname),
+		Port:     gatewayv1.PortNumber(80),
+		Protocol: gatewayv1.HTTPProtocolType,
+	}
+	listener.AllowedRoutes = r.ListenerAllowedRoutes.Resolve(gatewayName, string(listener.Name)).AllowedRoutes(namespaces)
+	return listener
+}
+
+// createPassthroughListenerWithNamespaces creates the port 443 listener handing TLS connections for
+// hostname to TLSRoute backends without terminating them
+func (r *HTTPRouteReconciler) createPassthroughListenerWithNamespaces(
+	gatewayName string,
+	hostname string,
+	namespaces []string,
+) gatewayv1.Listener {
+	mode := gatewayv1.TLSModePassthrough
+	listener := gatewayv1.Listener{
+		Name:     translator.PassthroughListenerName(hostname),
+		Hostname: (*gatewayv1.Hostname)(&hostname),
+		Port:     gatewayv1.PortNumber(443),
+		Protocol: gatewayv1.TLSProtocolType,
+		TLS:      &gatewayv1.ListenerTLSConfig{Mode: &mode},
+	}
+	listener.AllowedRoutes = r.ListenerAllowedRoutes.Resolve(gatewayName, string(listener.Name)).AllowedRoutes(namespaces)
+	return listener
 }
 
 // addNamespaceToListener adds a namespace to the listener's allowed routes if not present
@@ -763,9 +1115,15 @@ func (r *HTTPRouteReconciler) getGatewayNameFromHTTPRoute(httpRoute *gatewayv1.H
 }
 
 // ensureReferenceGrant creates or updates a ReferenceGrant for the HTTPRoute's namespace
-// Tracks the HTTPRoute in the source annotation (no ownerReference to avoid premature deletion)
+// Tracks the HTTPRoute in the source annotation (no ownerReference to avoid premature deletion), in finalizer
+// ownership mode the Ingress of the HTTPRoute is added as a non-controller owner as well
 func (r *HTTPRouteReconciler) ensureReferenceGrant(ctx context.Context, httpRoute *gatewayv1.HTTPRoute) error {
 	logger := log.FromContext(ctx)
+	if !r.GatewayAPI.Installed(utils.ReferenceGrantKind) {
+		logger.Info("ReferenceGrant CRD not installed, the Gateway cannot reference certificates in the route namespace",
+			"namespace", httpRoute.Namespace, "name", httpRoute.Name)
+		return nil
+	}
 
 	refGrantName := translator.ReferenceGrantName
 	refGrant := &gatewayv1beta1.ReferenceGrant{}
@@ -809,6 +1167,10 @@ func (r *HTTPRouteReconciler) ensureReferenceGrant(ctx context.Context, httpRout
 			},
 		}
 
+		if owner := r.referenceGrantOwner(httpRoute); owner != nil {
+			newRefGrant.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+
 		logger.Info("Creating ReferenceGrant", "namespace", httpRoute.Namespace, "name", refGrantName, "source", httpRouteKey)
 		if err := r.Create(ctx, newRefGrant); err != nil {
 			if apierrors.IsAlreadyExists(err) {
@@ -831,13 +1193,21 @@ func (r *HTTPRouteReconciler) ensureReferenceGrant(ctx context.Context, httpRout
 	}
 
 	// ReferenceGrant exists - add this HTTPRoute to sources if not present
+	changed := false
 	sources := getSourcesFromAnnotation(refGrant.Annotations[translator.SourceAnnotation])
 	if !utils.ContainsString(sources, httpRouteKey) {
 		sources = append(sources, httpRouteKey)
 		sort.Strings(sources)
 		refGrant.Annotations[translator.SourceAnnotation] = strings.Join(sources, ",")
+		changed = true
 
 		logger.Info("Updating ReferenceGrant sources", "namespace", httpRoute.Namespace, "name", refGrantName, "addedSource", httpRouteKey)
+	}
+	if owner := r.referenceGrantOwner(httpRoute); owner != nil && !hasOwnerReference(refGrant.OwnerReferences, owner) {
+		refGrant.OwnerReferences = append(refGrant.OwnerReferences, *owner)
+		changed = true
+	}
+	if changed {
 		if err := r.Update(ctx, refGrant); err != nil {
 			return err
 		}
@@ -849,6 +1219,9 @@ func (r *HTTPRouteReconciler) ensureReferenceGrant(ctx context.Context, httpRout
 // cleanupReferenceGrant removes the HTTPRoute from ReferenceGrant sources and deletes if empty
 func (r *HTTPRouteReconciler) cleanupReferenceGrant(ctx context.Context, namespace, name string) error {
 	logger := log.FromContext(ctx)
+	if !r.GatewayAPI.Installed(utils.ReferenceGrantKind) {
+		return nil
+	}
 
 	refGrantName := translator.ReferenceGrantName
 	refGrant := &gatewayv1beta1.ReferenceGrant{}
@@ -953,7 +1326,16 @@ func (r *HTTPRouteReconciler) listHTTPRoutesForGateway(
 		routes = append(routes, route)
 	}
 
-	return routes, nil
+	grpcViews, err := r.listGRPCRouteListenerViews(ctx, gatewayName, excludeKey)
+	if err != nil {
+		return nil, err
+	}
+	tlsViews, err := r.listTLSRouteListenerViews(ctx, gatewayName, excludeKey)
+	if err != nil {
+		return nil, err
+	}
+	routes = append(routes, grpcViews...)
+	return append(routes, tlsViews...), nil
 }
 
 type tlsCandidate struct {
@@ -962,6 +1344,7 @@ type tlsCandidate struct {
 	originalHost     string
 	transformedHost  string
 	tlsConfig        *networkingv1.IngressTLS
+	trans            *translator.Translator
 }
 
 func (r *HTTPRouteReconciler) buildDesiredListenerTLS(
@@ -973,24 +1356,35 @@ func (r *HTTPRouteReconciler) buildDesiredListenerTLS(
 	certMismatches := make([]string, 0)
 	tlsUnknown := make(map[string]bool)
 
-	trans := translator.New(translator.Config{
-		GatewayNamespace:    r.GatewayNamespace,
-		HostnameRewriteFrom: r.HostnameRewriteFrom,
-		HostnameRewriteTo:   r.HostnameRewriteTo,
-	})
-
 	bestCandidates := make(map[string]tlsCandidate)
+	handoffHosts := make(map[string]bool)
 
 	for i := range routes {
 		route := &routes[i]
-		if !r.isManagedByUs(route) {
+		if isAdoptedRoute(route) && !isPassthroughRoute(route) {
+			// The Ingress of an adopted route is gone, its listeners keep the certificate they have
+			trans := r.listenerTranslator(route)
+			for _, host := range route.Spec.Hostnames {
+				handoffHosts[trans.ListenerHostname(string(host))] = true
+			}
+			continue
+		}
+		if !r.isManagedByUs(route) || isPassthroughRoute(route) {
+			continue
+		}
+		trans := r.listenerTranslator(route)
+		if translator.IsHandoffRoute(route) {
+			// The Ingress no longer lists these hostnames, their listeners keep the certificate they have
+			for _, host := range route.Spec.Hostnames {
+				handoffHosts[trans.ListenerHostname(string(host))] = true
+			}
 			continue
 		}
 
 		ingress, _, err := r.resolveIngressForHTTPRoute(ctx, route)
 		if err != nil {
 			for _, host := range route.Spec.Hostnames {
-				tlsUnknown[string(host)] = true
+				tlsUnknown[trans.ListenerHostname(string(host))] = true
 			}
 			continue
 		}
@@ -1001,16 +1395,14 @@ func (r *HTTPRouteReconciler) buildDesiredListenerTLS(
 			routeHosts[string(host)] = true
 		}
 
-		for _, rule := range ingress.Spec.Rules {
-			if rule.Host == "" {
-				continue
-			}
-			transformed := trans.TransformHostname(rule.Host)
+		// TLS-only hosts are only on a route when they are served
+		for _, host := range ingressHosts(ingress, true) {
+			transformed := trans.TransformHostname(host)
 			if !routeHosts[transformed] {
 				continue
 			}
 
-			tlsConfig := findTLSConfigForHost(ingress, rule.Host)
+			tlsConfig := findTLSConfigForHost(ingress, host)
 			if tlsConfig == nil || tlsConfig.SecretName == "" {
 				continue
 			}
@@ -1018,20 +1410,27 @@ func (r *HTTPRouteReconciler) buildDesiredListenerTLS(
 			candidate := tlsCandidate{
 				ingressKey:       fmt.Sprintf("%s/%s", ingressNamespace, ingressName),
 				ingressNamespace: ingressNamespace,
-				originalHost:     rule.Host,
+				originalHost:     host,
 				transformedHost:  transformed,
 				tlsConfig:        tlsConfig,
+				trans:            trans,
 			}
 
-			existing, exists := bestCandidates[transformed]
-			if !exists || candidate.ingressKey < existing.ingressKey {
-				bestCandidates[transformed] = candidate
+			// Hostnames sharing a wildcard listener compete for its certificate
+			listenerHost := trans.ListenerHostname(transformed)
+			existing, exists := bestCandidates[listenerHost]
+			if !exists || candidate.ingressKey < existing.ingressKey ||
+				(candidate.ingressKey == existing.ingressKey && candidate.transformedHost < existing.transformedHost) {
+				bestCandidates[listenerHost] = candidate
 			}
 		}
 	}
 
 	for hostname := range desiredState {
 		candidate, ok := bestCandidates[hostname]
+		if !ok && handoffHosts[hostname] {
+			tlsUnknown[hostname] = true
+		}
 		if !ok || tlsUnknown[hostname] {
 			desiredTLS[hostname] = nil
 			continue
@@ -1041,7 +1440,8 @@ func (r *HTTPRouteReconciler) buildDesiredListenerTLS(
 		secretNamespace := candidate.ingressNamespace
 
 		if candidate.originalHost != candidate.transformedHost &&
-			!trans.CheckCertificateMatch(candidate.originalHost, candidate.transformedHost, candidate.tlsConfig.Hosts) {
+			!candidate.trans.CheckCertificateMatch(candidate.originalHost, candidate.transformedHost,
+				candidate.tlsConfig.Hosts) {
 			newSecretName := generateSafeSecretName(candidate.ingressNamespace, candidate.transformedHost)
 			certMismatches = append(certMismatches,
 				fmt.Sprintf("%s->%s: %s/%s->%s/%s",
@@ -1054,13 +1454,8 @@ func (r *HTTPRouteReconciler) buildDesiredListenerTLS(
 
 		mode := gatewayv1.TLSModeTerminate
 		desiredTLS[hostname] = &gatewayv1.ListenerTLSConfig{
-			Mode: &mode,
-			CertificateRefs: []gatewayv1.SecretObjectReference{
-				{
-					Name:      gatewayv1.ObjectName(secretName),
-					Namespace: (*gatewayv1.Namespace)(&secretNamespace),
-				},
-			},
+			Mode:            &mode,
+			CertificateRefs: []gatewayv1.SecretObjectReference{r.listenerCertificateRef(secretNamespace, secretName)},
 		}
 	}
 
@@ -1068,6 +1463,25 @@ func (r *HTTPRouteReconciler) buildDesiredListenerTLS(
 	return desiredTLS, certMismatches, tlsUnknown
 }
 
+// ingressHosts returns the rule hosts of an Ingress, followed by its TLS-only hosts if includeTLSOnly
+// (they are only served unless --tls-only-hosts=ignore). Hosts are the ones it had before it was
+// disabled if they were prefixed.
+func ingressHosts(ingress *networkingv1.Ingress, includeTLSOnly bool) []string {
+	if ingressDisabled(ingress) && disableStrategyOf(ingress).Name() == DisableStrategyHostPrefix {
+		ingress = withOriginalSpec(ingress)
+	}
+	hosts := make([]string, 0, len(ingress.Spec.Rules))
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" {
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	if !includeTLSOnly {
+		return hosts
+	}
+	return append(hosts, translator.GetTLSOnlyHosts(ingress)...)
+}
+
 func findTLSConfigForHost(ingress *networkingv1.Ingress, host string) *networkingv1.IngressTLS {
 	for _, tls := range ingress.Spec.TLS {
 		for _, tlsHost := range tls.Hosts {
@@ -1112,46 +1526,43 @@ func (r *HTTPRouteReconciler) buildTLSForRouteFromIngress(
 	desiredTLS := make(map[string]*gatewayv1.ListenerTLSConfig)
 	certMismatches := make([]string, 0)
 
-	if !r.isManagedByUs(httpRoute) || ingress == nil {
+	if !r.isManagedByUs(httpRoute) || ingress == nil || isPassthroughRoute(httpRoute) {
 		return desiredTLS, certMismatches
 	}
 
 	ingressNamespace := ingress.Namespace
 
-	trans := translator.New(translator.Config{
-		GatewayNamespace:    r.GatewayNamespace,
-		HostnameRewriteFrom: r.HostnameRewriteFrom,
-		HostnameRewriteTo:   r.HostnameRewriteTo,
-	})
+	trans := r.listenerTranslator(httpRoute)
 
 	routeHosts := make(map[string]bool)
 	for _, host := range httpRoute.Spec.Hostnames {
 		routeHosts[string(host)] = true
 	}
 
-	for _, rule := range ingress.Spec.Rules {
-		if rule.Host == "" {
-			continue
-		}
-
-		transformed := trans.TransformHostname(rule.Host)
+	// TLS-only hosts are only on a route when they are served
+	for _, host := range ingressHosts(ingress, true) {
+		transformed := trans.TransformHostname(host)
 		if !routeHosts[transformed] {
 			continue
 		}
 
-		tlsConfig := findTLSConfigForHost(ingress, rule.Host)
+		tlsConfig := findTLSConfigForHost(ingress, host)
 		if tlsConfig == nil || tlsConfig.SecretName == "" {
 			continue
 		}
+		listenerHost := trans.ListenerHostname(transformed)
+		if _, exists := desiredTLS[listenerHost]; exists {
+			continue
+		}
 
 		secretName := tlsConfig.SecretName
 		secretNamespace := ingressNamespace
 
-		if rule.Host != transformed && !trans.CheckCertificateMatch(rule.Host, transformed, tlsConfig.Hosts) {
+		if host != transformed && !trans.CheckCertificateMatch(host, transformed, tlsConfig.Hosts) {
 			newSecretName := generateSafeSecretName(ingressNamespace, transformed)
 			certMismatches = append(certMismatches,
 				fmt.Sprintf("%s->%s: %s/%s->%s/%s",
-					rule.Host, transformed,
+					host, transformed,
 					ingressNamespace, tlsConfig.SecretName,
 					r.GatewayNamespace, newSecretName))
 			secretName = newSecretName
@@ -1159,14 +1570,9 @@ func (r *HTTPRouteReconciler) buildTLSForRouteFromIngress(
 		}
 
 		mode := gatewayv1.TLSModeTerminate
-		desiredTLS[transformed] = &gatewayv1.ListenerTLSConfig{
-			Mode: &mode,
-			CertificateRefs: []gatewayv1.SecretObjectReference{
-				{
-					Name:      gatewayv1.ObjectName(secretName),
-					Namespace: (*gatewayv1.Namespace)(&secretNamespace),
-				},
-			},
+		desiredTLS[listenerHost] = &gatewayv1.ListenerTLSConfig{
+			Mode:            &mode,
+			CertificateRefs: []gatewayv1.SecretObjectReference{r.listenerCertificateRef(secretNamespace, secretName)},
 		}
 	}
 
@@ -1182,6 +1588,11 @@ func (r *HTTPRouteReconciler) resolveIngressForHTTPRoute(
 		return nil, "", fmt.Errorf("HTTPRoute is nil")
 	}
 
+	reader, err := r.sourceClient(httpRoute)
+	if err != nil {
+		return nil, httpRoute.Annotations[translator.SourceAnnotation], err
+	}
+
 	// Prefer ownerReference when present
 	for _, ownerRef := range httpRoute.OwnerReferences {
 		if ownerRef.Kind != "Ingress" {
@@ -1195,7 +1606,7 @@ func (r *HTTPRouteReconciler) resolveIngressForHTTPRoute(
 			Name:      ownerRef.Name,
 		}
 		ingress := &networkingv1.Ingress{}
-		if err := r.Get(ctx, ingressNN, ingress); err != nil {
+		if err := reader.Get(ctx, ingressNN, ingress); err != nil {
 			if apierrors.IsNotFound(err) {
 				return nil, fmt.Sprintf("%s/%s", ingressNN.Namespace, ingressNN.Name),
 					fmt.Errorf("source Ingress %s/%s not found", ingressNN.Namespace, ingressNN.Name)
@@ -1222,7 +1633,7 @@ func (r *HTTPRouteReconciler) resolveIngressForHTTPRoute(
 	}
 	ingressNN := types.NamespacedName{Namespace: parts[0], Name: parts[1]}
 	ingress := &networkingv1.Ingress{}
-	if err := r.Get(ctx, ingressNN, ingress); err != nil {
+	if err := reader.Get(ctx, ingressNN, ingress); err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil, source, fmt.Errorf("source Ingress %s not found", source)
 		}
@@ -1245,9 +1656,22 @@ func (r *HTTPRouteReconciler) validateIngressForHTTPRoute(ingress *networkingv1.
 	return nil
 }
 
+// gatewayClassFor returns the GatewayClass of the Gateway serving the Ingress
+func (r *HTTPRouteReconciler) gatewayClassFor(ingress *networkingv1.Ingress) string {
+	if ingress != nil {
+		ingressClass := ingressClassName(ingress, r.IngressClassEmpty)
+		if gatewayClass, ok := translator.ResolveGatewayClass(r.GatewayClassMapping, ingressClass); ok {
+			return gatewayClass
+		}
+	}
+	if r.GatewayClassName == "" {
+		return "nginx" // Default from CLI flag
+	}
+	return r.GatewayClassName
+}
+
 // createInitialGateway creates a minimal Gateway resource
-func (r *HTTPRouteReconciler) createInitialGateway(gatewayName string) *gatewayv1.Gateway {
-	gatewayClassName := r.GatewayClassName
+func (r *HTTPRouteReconciler) createInitialGateway(gatewayName, gatewayClassName string) *gatewayv1.Gateway {
 	if gatewayClassName == "" {
 		gatewayClassName = "nginx" // Default from CLI flag
 	}
@@ -0,0 +1,163 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+)
+
+// ConditionsAnnotation holds the conditions of a source Ingress as a JSON list, since Ingress has no
+// status conditions of its own
+const ConditionsAnnotation = "ingress-doperator.fiction.si/conditions"
+
+const (
+	// ConditionReconciled tells whether the derived Gateway API resources are in sync with the Ingress
+	ConditionReconciled = "Reconciled"
+	// ConditionTranslationWarnings tells whether the last reconcile recorded warnings on the Ingress
+	ConditionTranslationWarnings = "TranslationWarnings"
+)
+
+// resourceOperationReasons maps a resource operation to the past tense used in event reasons
+var resourceOperationReasons = map[string]string{
+	"create": "Created",
+	"update": "Updated",
+	"delete": "Deleted",
+}
+
+// resourceRecorder returns a metric recorder for a derived resource kind that also records an event
+// on the source Ingress for every create, update and delete, and counts the writes of a drift resync
+func (r *IngressReconciler) resourceRecorder(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	kind string,
+	counter *metrics.CounterVec,
+) func(operation, namespace, name string) {
+	return func(operation, namespace, name string) {
+		counter.WithLabelValues(operation, namespace, name).Inc()
+		r.recordDrift(ctx, ingress, kind, operation, namespace, name)
+		past, ok := resourceOperationReasons[operation]
+		if !ok {
+			return
+		}
+		r.recordNormal(ingress, kind+past, fmt.Sprintf("%s %s %s/%s", past, kind, namespace, name))
+	}
+}
+
+// noteWarning remembers the reason of a warning recorded on an Ingress for its conditions
+func (r *IngressReconciler) noteWarning(ingress *networkingv1.Ingress, reason string) {
+	key := types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name}
+	r.warningsMu.Lock()
+	defer r.warningsMu.Unlock()
+	if r.warnings == nil {
+		r.warnings = make(map[types.NamespacedName]map[string]struct{})
+	}
+	if r.warnings[key] == nil {
+		r.warnings[key] = make(map[string]struct{})
+	}
+	r.warnings[key][reason] = struct{}{}
+}
+
+// takeWarnings returns the sorted warning reasons noted for an Ingress and forgets them
+func (r *IngressReconciler) takeWarnings(key types.NamespacedName) []string {
+	r.warningsMu.Lock()
+	noted := r.warnings[key]
+	delete(r.warnings, key)
+	r.warningsMu.Unlock()
+
+	reasons := make([]string, 0, len(noted))
+	for reason := range noted {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	return reasons
+}
+
+// syncConditions records the outcome of a reconcile in the conditions annotation of the Ingress. The
+// annotation is only patched when a condition changed, transition times are kept otherwise.
+func (r *IngressReconciler) syncConditions(ctx context.Context, ingress *networkingv1.Ingress, reconcileErr error) {
+	warnings := r.takeWarnings(types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name})
+
+	var conditions []metav1.Condition
+	current := ingress.Annotations[ConditionsAnnotation]
+	if current != "" {
+		if err := json.Unmarshal([]byte(current), &conditions); err != nil {
+			conditions = nil
+		}
+	}
+
+	reconciled := metav1.Condition{
+		Type:               ConditionReconciled,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: ingress.Generation,
+		Reason:             "ReconcileSucceeded",
+		Message:            "Derived Gateway API resources were reconciled",
+	}
+	if reconcileErr != nil {
+		reconciled.Status = metav1.ConditionFalse
+		reconciled.Reason = "ReconcileFailed"
+		reconciled.Message = reconcileErr.Error()
+		if errors.Is(reconcileErr, errGatewayAPIUnavailable) {
+			reconciled.Reason = "GatewayAPIUnavailable"
+		}
+	}
+	meta.SetStatusCondition(&conditions, reconciled)
+	if r.GatewayAPI != nil {
+		meta.SetStatusCondition(&conditions, r.gatewayAPICondition(ingress.Generation))
+	}
+
+	warned := metav1.Condition{
+		Type:               ConditionTranslationWarnings,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: ingress.Generation,
+		Reason:             "NoWarnings",
+		Message:            "No warnings were recorded",
+	}
+	if len(warnings) > 0 {
+		warned.Status = metav1.ConditionTrue
+		warned.Reason = "WarningsRecorded"
+		warned.Message = "Warning events recorded: " + strings.Join(warnings, ", ")
+	}
+	meta.SetStatusCondition(&conditions, warned)
+
+	data, err := json.Marshal(conditions)
+	if err != nil || string(data) == current {
+		return
+	}
+	patchBase := client.MergeFrom(ingress.DeepCopy())
+	if ingress.Annotations == nil {
+		ingress.Annotations = map[string]string{}
+	}
+	ingress.Annotations[ConditionsAnnotation] = string(data)
+	if err := r.Patch(ctx, ingress, patchBase); err != nil && !apierrors.IsNotFound(err) {
+		log.FromContext(ctx).Error(err, "failed to update conditions annotation on Ingress")
+	}
+}
@@ -18,9 +18,12 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"path/filepath"
-	"sort"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -29,19 +32,20 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/fiksn/ingress-doperator/internal/metrics"
@@ -67,6 +71,9 @@ const (
 	HTTPRouteSnippetsFilterAnnotation        = "ingress-doperator.fiction.si/httproute-snippets-filter"
 	HTTPRouteAuthenticationAnnotation        = "ingress-doperator.fiction.si/httproute-authentication-filter"
 	HTTPRouteRequestHeaderAnnotation         = "ingress-doperator.fiction.si/httproute-request-header-modifier-filter"
+	ConflictWithAnnotation                   = "ingress-doperator.fiction.si/conflict-with"
+	ConflictSuggestedNameAnnotation          = "ingress-doperator.fiction.si/conflict-suggested-name"
+	SnippetsLostAnnotation                   = "ingress-doperator.fiction.si/snippets-lost"
 	DisabledIngressClassName                 = "ingress-doperator-disabled"
 	DisabledIngressClassController           = "dummy.io/no-controller"
 	IngressDisabledReasonNormal              = "normal"
@@ -94,50 +101,154 @@ const (
 	IngressPostProcessingModeDisableExternalDNS IngressPostProcessingMode = "disable-external-dns"
 )
 
+// RegexPathMatchMode controls translation of use-regex paths into RegularExpression matches
+type RegexPathMatchMode string
+
+const (
+	// RegexPathMatchModeAuto enables regex matches when the target GatewayClass supports them
+	RegexPathMatchModeAuto RegexPathMatchMode = "auto"
+	// RegexPathMatchModeEnabled always emits RegularExpression path matches
+	RegexPathMatchModeEnabled RegexPathMatchMode = "enabled"
+	// RegexPathMatchModeDisabled always falls back to PathPrefix matches
+	RegexPathMatchModeDisabled RegexPathMatchMode = "disabled"
+)
+
+// ProxySSLMode controls translation of proxy-ssl-* annotations into BackendTLSPolicies
+type ProxySSLMode string
+
+const (
+	// ProxySSLModeAuto translates when the GatewayClass supports it and holds the Ingress back otherwise
+	ProxySSLModeAuto ProxySSLMode = "auto"
+	// ProxySSLModeEnabled always translates, without checking the GatewayClass
+	ProxySSLModeEnabled ProxySSLMode = "enabled"
+	// ProxySSLModeDisabled ignores proxy-ssl-secret
+	ProxySSLModeDisabled ProxySSLMode = "disabled"
+)
+
 const requeueAfterError = 30 * time.Second
 const selfDeletedIngressTTL = 10 * time.Minute
+const preDeleteHookRequeue = 10 * time.Second
 
 type IngressReconciler struct {
 	client.Client
-	Scheme                           *runtime.Scheme
-	Recorder                         events.EventRecorder
-	GatewayNamespace                 string
-	GatewayName                      string
-	GatewayClassName                 string
-	WatchNamespace                   string
-	OneGatewayPerIngress             bool
-	EnableDeletion                   bool
+	Scheme           *runtime.Scheme
+	Recorder         events.EventRecorder
+	GatewayNamespace string
+	GatewayName      string
+	GatewayClassName string
+	// GatewayClassMapping overrides GatewayClassName for Gateways of matching ingress classes
+	GatewayClassMapping    []translator.GatewayClassRule
+	WatchNamespace         string
+	OneGatewayPerIngress   bool
+	OneGatewayPerNamespace bool
+	EnableDeletion         bool
+	// DeletionCascade decides what happens to the derived resources of a deleted Ingress (--deletion-cascade)
+	DeletionCascade DeletionCascadePolicy
+	// ClassChangePolicy decides what happens to the derived resources of an Ingress moved to a class that is
+	// not migrated (--class-change-policy)
+	ClassChangePolicy                ClassChangePolicy
 	HostnameRewriteFrom              string
 	HostnameRewriteTo                string
 	IngressPostProcessingMode        IngressPostProcessingMode
+	RolloutPercentage                int
 	GatewayAnnotationFilters         []string
 	HTTPRouteAnnotationFilters       []string
 	DefaultGatewayAnnotations        map[string]string
 	GatewayInfrastructureAnnotations map[string]string
 	InfrastructureAnnotationsByClass []translator.IngressClassAnnotationsRule
+	InfrastructureLabelPrefixes      []string
+	NameTemplate                     *translator.NameTemplate
+	SnippetsFilterNameTemplate       *translator.SnippetsFilterNameTemplate // nil = automatic-<name>-annotations
 	IngressClassFilters              []string
 	IngressClassIgnoreFilters        []string
 	IngressClassEmpty                string
 	UseIngress2Gateway               bool
 	Ingress2GatewayProvider          string
 	Ingress2GatewayIngressClass      string
+	RegexPathMatchMode               RegexPathMatchMode
+	PauseOnUnhealthyGatewayClass     bool
+	AllowCrossNamespaceHeaders       bool // proxy-set-headers/custom-headers may reference other namespaces
+	NamespaceCircuitBreaker          *NamespaceCircuitBreaker
+	TLSOnlyHosts                     translator.TLSOnlyHostsMode
+	ImplementationSpecificPaths      translator.ImplementationSpecificPolicy
+	PrioritizeUnmigrated             bool
+	ListenerAllowedRoutes            translator.AllowedRoutesPolicies
+	GatewayAddresses                 translator.GatewayAddressRules
+	InfraAnnotationsByGateway        translator.GatewayInfrastructureAnnotationRules
 	HTTPRouteManager                 *utils.HTTPRouteManager
+	GRPCRouteManager                 *utils.GRPCRouteManager // nil unless gRPC Ingresses get GRPCRoutes
+	TLSRouteManager                  *utils.TLSRouteManager  // nil unless ssl-passthrough Ingresses get TLSRoutes
+	CertReplication                  CertReplicationMode
+	HostnameHandoffWindow            time.Duration // how long hostnames leaving an Ingress wait for a new owner
+	ListenerRemovalAck               bool          // hostnames leaving an Ingress wait for an ack on the Gateway
+	ListenerRemovalAckTimeout        time.Duration // when unacknowledged hostname removals happen anyway
 	IngressClassSnippetsFilters      []utils.IngressClassSnippetsFilter
 	IngressNameSnippetsFilters       []utils.IngressClassSnippetsFilter
 	IngressAnnotationSnippetsAdd     []utils.IngressAnnotationSnippetsRule
 	IngressAnnotationSnippetsRemove  []utils.IngressAnnotationSnippetsRule
+	DisableSnippets                  bool              // never reference SnippetsFilters, mark what is lost instead
+	DataPlaneProvider                DataPlaneProvider // nil = detect from the GatewayClass controllerName
+	AllowLossy                       bool              // disable or remove Ingresses even when snippet behavior is lost
 	ClearIngressStatusOnDisable      bool
-	ReconcileCache                   map[string]utils.ReconcileCacheEntry
-	ReconcileCacheNamespace          string
-	ReconcileCacheBaseName           string
-	ReconcileCacheShards             int
-	ReconcileCachePersist            bool
-	ReconcileCacheMaxEntries         int
-	SelfDeletedIngresses             map[string]time.Time
-	SelfDeletedIngressesMu           sync.Mutex
-	reconcileCacheMu                 sync.Mutex
-	errorLogMu                       sync.Mutex
-	errorLogLast                     map[string]time.Time
+	// DisableStrategy takes disabled Ingresses away from the legacy controller, nil swaps their class
+	DisableStrategy DisableStrategy
+	// GatewayAPI tracks the installed Gateway API kinds, nil assumes all of them are installed
+	GatewayAPI                  *utils.GatewayAPICapabilities
+	IngressStatusFromGateway    bool // IngressStatusReconciler owns the status of disabled Ingresses
+	ProposeConflictNames        bool
+	ProxySSLMode                ProxySSLMode
+	OwnershipMode               OwnershipMode // how derived resources are tied to the Ingress
+	BackendConflictPolicy       BackendConflictPolicy
+	MaxListenersPerGateway      int
+	GatewayCapacityAction       GatewayCapacityAction
+	MaxGatewayConfigBytes       int64 // estimated nginx config size a Gateway may reach, 0 = unchecked
+	WildcardListenerDomains     []string
+	PairedHTTPListeners         bool
+	TraefikEntryPoints          translator.TraefikEntryPoints
+	HAProxyIngressClasses       []string
+	AttachOnly                  bool // attach HTTPRoutes to GatewayName, never write Gateways
+	AttachSectionNames          []gatewayv1.SectionName
+	FanIn                       *FanIn        // Ingresses of remote clusters merged into the local Gateways
+	SourceCluster               *FanInSource  // cluster Ingresses are read from, nil for the local cluster
+	APIReader                   client.Reader // uncached reads, e.g. proxy-ssl-secret Secrets
+	TenantClient                client.Client // writes into Ingress namespaces, may impersonate
+	ReconcileCache              utils.ReconcileCache
+	IntentLog                   *utils.IntentLog      // write-ahead log of destructive operations, nil = off
+	DryRunReport                *utils.DryRunReport   // writes that --dry-run left out, nil = off
+	MigrationPolicy             *MigrationPolicy      // runtime overrides of the flags, nil = flags only
+	HostnameRenames             *HostnameRenames      // HostnameRenamePlans in effect, nil = none
+	NamespaceSelector           labels.Selector       // Namespaces opted into migration, nil = all
+	IngressSelector             labels.Selector       // Ingresses opted into migration, nil = all
+	Notifier                    *utils.Notifier       // webhook notifications on milestones, nil = off
+	HostnameStates              *utils.HostnameStates // per-hostname serving states, nil = off
+	NetworkPolicies             bool                  // admit the Gateway to backend Services
+	GatewayPodSelector          *metav1.LabelSelector // Gateway data plane pods, nil = all of GatewayNamespace
+	DriftResyncInterval         time.Duration         // re-render every Ingress this often, 0 = off
+	AnnotationCoverageInterval  time.Duration         // count ingress-nginx annotation use this often, 0 = off
+	AnnotationCoverageConfigMap string                // GatewayNamespace ConfigMap for the counts, "" = metric only
+	ValidateTLSSecrets          bool                  // skip Ingresses whose TLS Secrets can't serve a listener
+	ReadOnly                    bool                  // writes are dropped by the clients and only reported
+	SelfDeletedIngresses        map[string]time.Time
+	SelfDeletedIngressesMu      sync.Mutex
+	migratedNamespaces          sync.Map // namespaces namespace-migrated was sent for
+	errorLogMu                  sync.Mutex
+	errorLogLast                map[string]time.Time
+	warningsMu                  sync.Mutex
+	warnings                    map[types.NamespacedName]map[string]struct{}
+	gatewayClassPausedMu        sync.Mutex
+	gatewayClassPaused          map[string]bool
+	externalDNSStatesMu         sync.Mutex
+	externalDNSStates           map[string]string
+	driftResyncMu               sync.Mutex
+	driftResyncPending          map[types.NamespacedName]struct{}
+}
+
+// tenantClient returns the client for writes of derived resources into Ingress namespaces
+func (r *IngressReconciler) tenantClient() client.Client {
+	if r.TenantClient != nil {
+		return r.TenantClient
+	}
+	return r.Client
 }
 
 // getTranslator creates a translator instance with the reconciler's configuration
@@ -146,26 +257,46 @@ func (r *IngressReconciler) getTranslator() *translator.Translator {
 	if gatewayClassName == "" {
 		gatewayClassName = "nginx"
 	}
-	return translator.New(translator.Config{
+	settings := r.settings()
+	trans := translator.New(translator.Config{
 		GatewayNamespace:                 r.GatewayNamespace,
 		GatewayName:                      r.GatewayName,
 		GatewayClassName:                 gatewayClassName,
-		HostnameRewriteFrom:              r.HostnameRewriteFrom,
-		HostnameRewriteTo:                r.HostnameRewriteTo,
+		HostnameRewriteFrom:              settings.HostnameRewriteFrom,
+		HostnameRewriteTo:                settings.HostnameRewriteTo,
+		HostnameRenames:                  r.HostnameRenames.Primary(),
 		DefaultGatewayAnnotations:        r.DefaultGatewayAnnotations,
 		GatewayInfrastructureAnnotations: r.GatewayInfrastructureAnnotations,
 		InfrastructureAnnotationsByClass: r.InfrastructureAnnotationsByClass,
+		InfraAnnotationsByGateway:        r.InfraAnnotationsByGateway,
+		InfrastructureLabelPrefixes:      r.InfrastructureLabelPrefixes,
+		NameTemplate:                     r.NameTemplate,
+		AttachOnly:                       r.AttachOnly,
+		AttachSectionNames:               r.AttachSectionNames,
 		GatewayAnnotationFilters:         r.GatewayAnnotationFilters,
 		HTTPRouteAnnotationFilters:       r.HTTPRouteAnnotationFilters,
 		UseIngress2Gateway:               r.UseIngress2Gateway,
 		Ingress2GatewayProvider:          r.Ingress2GatewayProvider,
 		Ingress2GatewayIngressClass:      r.Ingress2GatewayIngressClass,
+		TLSOnlyHosts:                     r.TLSOnlyHosts,
+		ImplementationSpecificPaths:      r.ImplementationSpecificPaths,
+		WildcardListenerDomains:          r.WildcardListenerDomains,
+		ListenerAllowedRoutes:            r.ListenerAllowedRoutes,
+		PairedHTTPListeners:              r.PairedHTTPListeners,
+		TraefikEntryPoints:               r.TraefikEntryPoints,
+		HAProxyIngressClasses:            r.HAProxyIngressClasses,
 	})
+	r.SourceCluster.applyHostnameTransform(&trans.Config)
+	return trans
 }
 
 func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Reconciling Ingress", "namespace", req.Namespace, "name", req.Name)
+	if r.DryRunReport != nil || r.ReadOnly {
+		// Collects what the dry-run or read-only client leaves out
+		ctx = utils.WithDryRunRecorder(ctx)
+	}
 
 	// Get the specific Ingress that triggered this reconciliation
 	var ingress networkingv1.Ingress
@@ -173,13 +304,23 @@ func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		if apierrors.IsNotFound(err) {
 			// Ingress was deleted - this is normal, no error
 			logger.V(1).Info("Ingress not found, likely deleted")
+			r.trackExternalDNSState(req.String(), nil)
+			r.FanIn.Release(r.fanInSourceName(), req.NamespacedName)
+			r.releaseHostnameStates(ctx, req.NamespacedName, false)
+			if err := r.DryRunReport.Remove(ctx, r.fanInSourceName(), req.NamespacedName); err != nil {
+				logger.Error(err, "failed to remove Ingress from the dry run report")
+			}
+			metrics.ReadOnlyPendingWrites.DeleteLabelValues(req.Namespace, req.Name)
+			metrics.UntranslatedFeatures.DeleteLabelValues(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "unable to fetch Ingress")
 		return ctrl.Result{RequeueAfter: requeueAfterError}, nil
 	}
+	r.trackExternalDNSState(req.String(), &ingress)
+	ctx = r.withDriftResync(ctx, req.NamespacedName)
 
-	if r.shouldSkipIngress(&ingress, logger) {
+	if r.shouldSkipIngress(ctx, &ingress, logger) {
 		return ctrl.Result{}, nil
 	}
 
@@ -188,8 +329,31 @@ func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return r.handleDeletion(ctx, &ingress)
 	}
 
-	// Add finalizer if deletion is enabled and not already present
-	if r.EnableDeletion && !utils.ContainsString(ingress.Finalizers, FinalizerName) {
+	// Moved to a class that is not migrated, the derived resources must not keep serving it
+	if previous, changed := r.previousIngressClass(ctx, &ingress); changed {
+		return r.handleIngressClassChange(ctx, &ingress, previous)
+	}
+
+	if allowed, remaining := r.NamespaceCircuitBreaker.Allow(ingress.Namespace); !allowed {
+		logger.V(1).Info("Namespace circuit breaker open, backing off",
+			"namespace", ingress.Namespace,
+			"retryAfter", remaining.String())
+		metrics.IngressReconcileSkipsTotal.WithLabelValues("circuit-open", ingress.Namespace, ingress.Name).Inc()
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	// Without the Gateway and HTTPRoute CRDs nothing can be written, say so instead of failing every reconcile
+	if missing := r.missingCoreGatewayAPIKinds(); len(missing) > 0 {
+		logger.Info("Gateway API CRDs missing, skipping translation", "missing", strings.Join(missing, ", "))
+		r.recordWarning(&ingress, "GatewayAPIUnavailable",
+			"Not migrated while Gateway API CRDs are missing: "+strings.Join(missing, ", "))
+		metrics.IngressReconcileSkipsTotal.WithLabelValues("gateway-api-unavailable", ingress.Namespace, ingress.Name).Inc()
+		r.syncConditions(ctx, &ingress, fmt.Errorf("%w: %s", errGatewayAPIUnavailable, strings.Join(missing, ", ")))
+		return ctrl.Result{}, nil
+	}
+
+	// Add finalizer if deleted Ingresses are cleaned up and not already present
+	if r.cleansUpOnDeletion() && !utils.ContainsString(ingress.Finalizers, FinalizerName) {
 		ingress.Finalizers = append(ingress.Finalizers, FinalizerName)
 		if err := r.Update(ctx, &ingress); err != nil {
 			logger.Error(err, "failed to add finalizer")
@@ -198,13 +362,54 @@ func (r *IngressReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		logger.V(1).Info("Added finalizer to Ingress")
 	}
 
+	// Translate-only Ingresses may only keep their derived resources for a while
+	expired, previewRemaining, err := r.checkPreviewTTL(ctx, &ingress)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if expired {
+		return ctrl.Result{}, nil
+	}
+
+	// Two Ingresses routing the same path to different backends would leave the Gateway to pick one
+	if r.checkBackendConflicts(ctx, &ingress) {
+		return ctrl.Result{}, nil
+	}
+
 	// Translate this Ingress to HTTPRoute (Gateway listeners are managed by HTTPRoute controller)
-	result, err := r.reconcileIngressToHTTPRoute(ctx, &ingress)
-	r.maybeRecordReconcile(&ingress, result, err)
+	var result ctrl.Result
+	if changedWhileDisabled(&ingress) {
+		result, err = r.reconcileChangedWhileDisabled(ctx, &ingress)
+	} else if ingressDisabled(&ingress) {
+		// Only the drift resync gets here, the derived resources still follow the original class
+		result, err = r.reconcileIngressToHTTPRoute(ctx, withOriginalSpec(&ingress))
+	} else {
+		result, err = r.reconcileIngressToHTTPRoute(ctx, &ingress)
+	}
+	if err != nil {
+		if r.NamespaceCircuitBreaker.RecordFailure(ingress.Namespace) {
+			logger.Info("Too many consecutive failures in namespace, opening circuit breaker",
+				"namespace", ingress.Namespace)
+			r.recordWarning(&ingress, "NamespaceBackoff",
+				"Reconciles in this namespace are backed off after repeated failures")
+		}
+	} else {
+		r.NamespaceCircuitBreaker.RecordSuccess(ingress.Namespace)
+	}
+	if err == nil && previewRemaining > 0 && (result.RequeueAfter == 0 || previewRemaining < result.RequeueAfter) {
+		result.RequeueAfter = previewRemaining
+	}
+	r.syncConditions(ctx, &ingress, err)
+	if err := r.DryRunReport.Record(ctx, r.fanInSourceName(), req.NamespacedName); err != nil {
+		logger.Error(err, "failed to update the dry run report")
+	}
+	r.recordReadOnlyWrites(ctx, req.NamespacedName)
+	r.maybeRecordReconcile(ctx, &ingress, result, err)
 	return result, err
 }
 
 func (r *IngressReconciler) shouldSkipIngress(
+	ctx context.Context,
 	ingress *networkingv1.Ingress,
 	logger logr.Logger,
 ) bool {
@@ -212,11 +417,50 @@ func (r *IngressReconciler) shouldSkipIngress(
 		return true
 	}
 
-	if ingress.Annotations != nil && ingress.Annotations[IgnoreIngressAnnotation] == fmt.Sprintf("%t", true) {
+	if IngressIgnored(ingress) {
 		logger.Info("Ingress has ignore annotation, skipping reconciliation")
 		return true
 	}
 
+	if IngressPaused(ingress) {
+		logger.Info("Ingress is paused, skipping reconciliation", "annotation", PausedAnnotation)
+		metrics.IngressReconcileSkipsTotal.WithLabelValues("paused", ingress.Namespace, ingress.Name).Inc()
+		return true
+	}
+
+	if !r.matchesIngressSelector(ingress) {
+		logger.V(1).Info("Ingress labels do not match the ingress selector, skipping reconciliation",
+			"selector", r.IngressSelector.String())
+		metrics.IngressReconcileSkipsTotal.WithLabelValues("ingress-selector", ingress.Namespace, ingress.Name).Inc()
+		return true
+	}
+
+	if reason := r.namespaceOptedOut(ctx, ingress); reason != "" {
+		logger.V(1).Info("Namespace is not opted into migration, skipping reconciliation",
+			"namespace", ingress.Namespace, "reason", reason)
+		metrics.IngressReconcileSkipsTotal.WithLabelValues(reason, ingress.Namespace, ingress.Name).Inc()
+		return true
+	}
+
+	if !r.settings().namespaceAllowed(ingress.Namespace) {
+		logger.V(1).Info("Namespace is not selected by the IngressMigrationPolicy, skipping reconciliation",
+			"namespace", ingress.Namespace)
+		metrics.IngressReconcileSkipsTotal.WithLabelValues("namespace-filter", ingress.Namespace, ingress.Name).Inc()
+		return true
+	}
+
+	if !r.settings().rolloutAllowed(r.fanInSourceName(), ingress.Namespace, ingress.Name) {
+		logger.V(1).Info("Ingress is not yet within the rollout percentage, skipping reconciliation",
+			"percentage", r.settings().RolloutPercentage)
+		metrics.IngressReconcileSkipsTotal.WithLabelValues("rollout", ingress.Namespace, ingress.Name).Inc()
+		return true
+	}
+
+	if _, changed := r.previousIngressClass(ctx, ingress); changed {
+		// Reconcile cleans up what was generated for the previous class
+		return false
+	}
+
 	if r.matchesIngressClassIgnoreFilter(ingress) {
 		ingressClass := r.getIngressClass(ingress)
 		logger.V(1).Info("Ingress class matches ignore filter, skipping reconciliation",
@@ -243,7 +487,10 @@ func (r *IngressReconciler) shouldSkipIngress(
 		return true
 	}
 
-	if r.getIngressClass(ingress) == DisabledIngressClassName {
+	if r.getIngressClass(ingress) == DisabledIngressClassName || HasDisabledMarker(ingress) {
+		if changedWhileDisabled(ingress) || (inDriftResync(ctx) && ingressDisabled(ingress)) {
+			return false
+		}
 		logger.Info("Ingress uses disabled class, skipping reconciliation",
 			"namespace", ingress.Namespace,
 			"name", ingress.Name)
@@ -259,7 +506,7 @@ func (r *IngressReconciler) shouldSkipIngress(
 		return true
 	}
 
-	if r.shouldSkipReconcile(ingress) {
+	if r.shouldSkipReconcile(ctx, ingress) {
 		logger.V(3).Info("Ingress resourceVersion unchanged, skipping reconciliation",
 			"namespace", ingress.Namespace,
 			"name", ingress.Name,
@@ -295,66 +542,236 @@ func (r *IngressReconciler) reconcileIngressToHTTPRoute(
 		return ctrl.Result{}, nil
 	}
 
+	// A listener with a missing or mismatching certificate would break HTTPS for the hostnames
+	tlsReady, err := r.tlsSecretsReady(ctx, ingress)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !tlsReady {
+		return ctrl.Result{RequeueAfter: tlsSecretRequeue}, nil
+	}
+
 	// Handle source Ingress post-processing mode
 	effectiveMode := r.resolveIngressPostProcessingMode(ingress)
 
+	// The Ingress keeps serving its hostnames until routes for them are in place
+	r.recordHostnameStates(ctx, ingress, "", utils.HostnameIngressOnly)
+
 	// Get translator
 	trans := r.getTranslator()
 
+	if !r.claimFanInHostnames(ctx, ingress, trans) {
+		return ctrl.Result{}, nil
+	}
+
 	// Determine Gateway name based on mode
+	settings := r.settings()
 	var gatewayName string
-	if r.OneGatewayPerIngress {
-		// One Gateway per Ingress mode - use ingress name
-		gatewayName = ingress.Name
-	} else {
+	sharded := false
+	shardBase := ""
+	switch {
+	case r.AttachOnly:
+		// Attach-only mode - the pre-provisioned Gateway is managed by someone else
+		gatewayName = r.GatewayName
+		if !r.attachGatewayReady(ctx, ingress) {
+			return ctrl.Result{RequeueAfter: attachGatewayRequeue}, nil
+		}
+	case settings.OneGatewayPerIngress:
+		// One Gateway per Ingress mode - use ingress name (or the name template)
+		gatewayName = r.NameTemplate.Name(ingress)
+	case settings.OneGatewayPerNamespace:
+		// One Gateway per namespace mode - isolates tenants and spreads listeners over Gateways
+		gatewayName = r.getGatewayNameForNamespace(ingress.Namespace)
+	default:
 		// Shared Gateway mode - use ingress class
 		ingressClass := r.getIngressClass(ingress)
 		gatewayName = r.getGatewayNameForClass(ingressClass)
+		gatewayName = r.getGatewayNameForALBGroup(ingress, gatewayName)
+		if target := ingress.Annotations[translator.TargetGatewayAnnotation]; target != "" && target != gatewayName {
+			r.recordWarning(ingress, "TargetGatewayMismatch",
+				fmt.Sprintf("Ingress is annotated with %s=%s but its class maps to Gateway %q, using %q",
+					translator.TargetGatewayAnnotation, target, gatewayName, gatewayName))
+		}
+		if r.MaxListenersPerGateway > 0 {
+			// Spread hostnames over <gateway>-1..N once a Gateway runs out of listeners
+			shard, err := r.selectGatewayShard(ctx, ingress, gatewayName, ingressListenerNames(trans, ingress))
+			if err != nil {
+				logger.Info("Skipping Ingress, no Gateway shard can hold its listeners",
+					"namespace", ingress.Namespace, "name", ingress.Name, "error", err.Error())
+				r.recordWarning(ingress, "GatewayListenerLimit", fmt.Sprintf("Unable to place Ingress on a Gateway: %v", err))
+				metrics.IngressReconcileSkipsTotal.WithLabelValues("gateway-listener-limit", ingress.Namespace, ingress.Name).Inc()
+				return ctrl.Result{}, nil
+			}
+			shardBase, gatewayName, sharded = gatewayName, shard, true
+		}
 	}
 
 	// Override gateway name in translator config
 	transConfig := trans.Config
 	transConfig.GatewayName = gatewayName
+	transConfig.GatewayClassName = r.gatewayClassFor(ingress)
+	if translator.UsesRegexPaths(ingress, transConfig.ImplementationSpecificPaths) {
+		transConfig.RegexPathMatchSupported = r.regexPathMatchSupported(ctx, transConfig.GatewayClassName)
+		if !transConfig.RegexPathMatchSupported {
+			r.recordWarning(ingress, "RegexPathUnsupported",
+				fmt.Sprintf("Ingress has regex paths but GatewayClass %q does not support RegularExpression "+
+					"path matches; regex paths were translated to PathPrefix and may not match as before",
+					transConfig.GatewayClassName))
+		}
+	}
+	if skipped := transConfig.ImplementationSpecificPaths.SkippedPaths(ingress); len(skipped) > 0 {
+		r.recordWarning(ingress, "ImplementationSpecificPathSkipped",
+			fmt.Sprintf("ImplementationSpecific paths are not routed (--implementation-specific-paths=%s): %s",
+				transConfig.ImplementationSpecificPaths, strings.Join(skipped, ", ")))
+	}
+	if _, ok := translator.GetNginxAnnotation(ingress.Annotations, translator.NginxUpstreamVhostKey); ok {
+		transConfig.HostRewriteSupported = r.hostRewriteSupported(ctx, transConfig.GatewayClassName)
+	}
+	proxySSL, proxySSLReady := r.applyProxySSL(ctx, ingress, gatewayName)
+	if !proxySSLReady {
+		metrics.IngressReconcileSkipsTotal.WithLabelValues("backend-tls-unsupported", ingress.Namespace, ingress.Name).Inc()
+		return ctrl.Result{}, nil
+	}
 	singleTrans := translator.New(transConfig)
 
-	// Translate to HTTPRoute (we no longer create Gateway here)
-	httpRoute := singleTrans.TranslateToHTTPRoute(ingress)
-	setHTTPRouteOwnerReference(httpRoute, ingress)
+	// ssl-passthrough Ingresses get TLSRoutes and gRPC backends GRPCRoutes instead of HTTPRoutes
+	passthrough := r.TLSRouteManager != nil && translator.IsSSLPassthrough(ingress.Annotations)
+	grpc := !passthrough && r.GRPCRouteManager != nil && translator.IsGRPCBackend(ingress.Annotations)
+	var httpRoutes []*gatewayv1.HTTPRoute
+	var grpcRoutes []*gatewayv1.GRPCRoute
+	var tlsRoutes []*gatewayv1.TLSRoute
+	switch {
+	case passthrough:
+		tlsRoutes = r.buildTLSRoutes(ctx, ingress, singleTrans)
+	case grpc:
+		grpcRoutes = r.buildGRPCRoutes(ctx, ingress, singleTrans)
+	default:
+		httpRoutes = r.buildHTTPRoutes(ctx, ingress, singleTrans)
+	}
+	// A HostnameRenamePlan serving both hostnames adds the other ones in a route of their own
+	renameRoute := r.buildRenameRoute(ingress, singleTrans, httpRoutes)
+	servedRoutes := httpRoutes
+	if renameRoute != nil {
+		servedRoutes = append(slices.Clone(httpRoutes), renameRoute)
+	}
 
-	// Apply extension refs (snippets, auth, headers)
-	r.applyHTTPRouteExtensionRefs(ctx, ingress, httpRoute)
+	// GRPCRoutes need the same listeners as HTTPRoutes, TLSRoutes their passthrough listeners
+	listenerRoutes := servedRoutes
+	for _, grpcRoute := range grpcRoutes {
+		listenerRoutes = append(listenerRoutes, translator.GRPCRouteListenerView(grpcRoute))
+	}
+	for _, tlsRoute := range tlsRoutes {
+		listenerRoutes = append(listenerRoutes, translator.TLSRouteListenerView(tlsRoute))
+	}
 
-	// Resolve any named ports before applying
-	if err := r.HTTPRouteManager.ResolveNamedPorts(ctx, ingress, httpRoute); err != nil {
-		logger.Error(err, "failed to resolve named ports")
-		// Continue anyway with fallback ports
+	// Estimate the Gateway before hundreds of listeners and locations make it slow to reload
+	var capacityListeners []string
+	if !r.AttachOnly {
+		capacityListeners = ingressListenerNames(singleTrans, ingress)
+	}
+	if !r.checkGatewayCapacity(ctx, ingress, gatewayName, capacityListeners, listenerRoutes) {
+		return ctrl.Result{RequeueAfter: gatewayCapacityRequeue}, nil
 	}
 
-	// Split HTTPRoute if it exceeds the Gateway API limit
-	httpRoutes := r.HTTPRouteManager.SplitHTTPRouteIfNeeded(httpRoute)
+	// The Gateway needs to reach the backends before any route points at them
+	r.syncNetworkPolicies(ctx, ingress)
 
-	// Apply all HTTPRoute(s) with proper cleanup of obsolete split routes
-	metricRecorder := func(operation, namespace, name string) {
-		metrics.HTTPRouteResourcesTotal.WithLabelValues(operation, namespace, name).Inc()
+	// TLSRoutes and GRPCRoutes go in before HTTPRoutes of an Ingress that used neither before are removed
+	if passthrough {
+		if err := r.applyTLSRoutes(ctx, ingress, tlsRoutes); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if grpc {
+		if err := r.applyGRPCRoutes(ctx, ingress, grpcRoutes); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Hostnames moving to another Ingress stay served until the new owner's HTTPRoute is accepted
+	handoffRequeue, err := r.holdDepartingHostnames(ctx, ingress, gatewayName, singleTrans, servedRoutes)
+	if err != nil {
+		logger.Error(err, "failed to hold hostnames leaving the Ingress")
+		return ctrl.Result{}, err
 	}
+
+	// Apply all HTTPRoute(s) with proper cleanup of obsolete split routes
+	metricRecorder := r.resourceRecorder(ctx, ingress, "HTTPRoute", metrics.HTTPRouteResourcesTotal)
 	if err := r.HTTPRouteManager.ApplyHTTPRoutesAtomic(ctx, ingress, httpRoutes, metricRecorder); err != nil {
 		logger.Error(err, "failed to apply HTTPRoutes")
 		r.logErrorRateLimited(err, "apply-httproutes", "failed to apply HTTPRoutes")
 		return ctrl.Result{}, err
 	}
+	r.pruneRenamedSnippetsFilters(ctx, ingress, httpRoutes)
+	if err := r.applyRenameRoute(ctx, ingress, renameRoute); err != nil {
+		logger.Error(err, "failed to apply rename HTTPRoute")
+		return ctrl.Result{}, err
+	}
 
-	logger.V(1).Info("HTTPRoute applied successfully", "namespace", httpRoute.Namespace, "name", httpRoute.Name)
+	if !grpc {
+		if err := r.applyGRPCRoutes(ctx, ingress, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	if !passthrough {
+		if err := r.applyTLSRoutes(ctx, ingress, nil); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	r.syncSnippetsLostAnnotation(ctx, ingress, httpRoutes)
+	r.syncUntranslatedAnnotation(ctx, ingress, transConfig.ImplementationSpecificPaths,
+		transConfig.RegexPathMatchSupported)
 
-	// Ensure Gateway listeners are updated from this Ingress change before post-processing
-	listenerReconciler := &HTTPRouteReconciler{
-		Client:              r.Client,
-		GatewayNamespace:    r.GatewayNamespace,
-		GatewayClassName:    r.GatewayClassName,
-		HostnameRewriteFrom: r.HostnameRewriteFrom,
-		HostnameRewriteTo:   r.HostnameRewriteTo,
+	logger.V(1).Info("Routes applied successfully", "namespace", ingress.Namespace, "name", ingress.Name)
+
+	conflicts := r.findHTTPRouteConflicts(ctx, httpRoutes)
+
+	// Mirroring to a Service in another namespace needs a ReferenceGrant there
+	if !r.GatewayAPI.Installed(utils.ReferenceGrantKind) {
+		if len(translator.CrossNamespaceMirrorTargets(httpRoutes)) > 0 {
+			r.recordWarning(ingress, "ReferenceGrantUnavailable",
+				"Mirroring to a Service in another namespace needs a ReferenceGrant, whose CRD is not installed")
+		}
+	} else if err := utils.SyncMirrorReferenceGrants(
+		ctx, r.Client, ingress.Namespace, ingress.Namespace, ingress.Name, httpRoutes,
+	); err != nil {
+		logger.Error(err, "failed to sync mirror ReferenceGrants")
+		r.recordWarning(ingress, "MirrorReferenceGrantFailed",
+			fmt.Sprintf("Unable to grant access to the mirror-target Service: %v", err))
 	}
 
-	gateway, canManageGateway, gatewayExists, err := r.ensureGatewayForListenerUpdate(ctx, gatewayName)
+	if r.AttachOnly {
+		r.syncConflictAnnotations(ctx, ingress, conflicts)
+		if proxySSL != nil && proxySSL.ClientCertificate != nil {
+			r.recordWarning(ingress, "BackendClientCertificateNotApplied",
+				"proxy-ssl-secret client certificate needs spec.tls.backend on the Gateway, which is not managed "+
+					"by ingress-doperator in attach-only mode")
+		}
+		// No Gateway update follows, so nothing else disables external-dns
+		r.recordHostnameStates(ctx, ingress, gatewayName, utils.HostnameDual)
+		result, err := r.postProcessIngress(ctx, ingress, effectiveMode, true)
+		return withHandoffRequeue(result, err, handoffRequeue)
+	}
+
+	// Ensure Gateway listeners are updated from this Ingress change before post-processing
+	listenerReconciler := &HTTPRouteReconciler{
+		Client:                    r.Client,
+		GatewayNamespace:          r.GatewayNamespace,
+		GatewayClassName:          transConfig.GatewayClassName,
+		HostnameRewriteFrom:       settings.HostnameRewriteFrom,
+		HostnameRewriteTo:         settings.HostnameRewriteTo,
+		HostnameRenames:           r.HostnameRenames,
+		ListenerAllowedRoutes:     r.ListenerAllowedRoutes,
+		WildcardListenerDomains:   r.WildcardListenerDomains,
+		FanIn:                     r.FanIn,
+		CertReplication:           r.CertReplication,
+		APIReader:                 r.APIReader,
+		GatewayAddresses:          r.GatewayAddresses,
+		InfraAnnotationsByGateway: r.InfraAnnotationsByGateway,
+	}
+
+	gateway, canManageGateway, gatewayExists, err := r.ensureGatewayForListenerUpdate(
+		ctx, gatewayName, transConfig.GatewayClassName)
 	if err != nil {
 		logger.Error(err, "failed to ensure Gateway for listener update")
 		return ctrl.Result{}, err
@@ -363,34 +780,55 @@ func (r *IngressReconciler) reconcileIngressToHTTPRoute(
 		logger.Info("Skipping Gateway listener update - Gateway is not managed by us",
 			"namespace", r.GatewayNamespace,
 			"name", gatewayName)
+		conflicts = append(conflicts, utils.ResourceConflict{
+			Kind:      "Gateway",
+			Namespace: r.GatewayNamespace,
+			Name:      gatewayName,
+		})
+		r.syncConflictAnnotations(ctx, ingress, conflicts)
 		return ctrl.Result{}, nil
 	}
+	r.syncConflictAnnotations(ctx, ingress, conflicts)
 
-	// Ensure ReferenceGrant exists before updating Gateway listeners (cross-namespace secrets)
-	for _, route := range httpRoutes {
-		if route.Namespace != r.GatewayNamespace {
-			if err := listenerReconciler.ensureReferenceGrant(ctx, route); err != nil {
-				logger.Error(err, "failed to ensure ReferenceGrant for HTTPRoute",
-					"namespace", route.Namespace,
-					"name", route.Name)
-			}
+	// Make cross-namespace TLS Secrets reachable before updating Gateway listeners
+	for _, route := range listenerRoutes {
+		if err := listenerReconciler.certReplicator().ensure(ctx, route, ingress); err != nil {
+			logger.Error(err, "failed to replicate TLS Secrets for HTTPRoute",
+				"namespace", route.Namespace,
+				"name", route.Name)
 		}
 	}
 
 	updated := false
-	for _, route := range httpRoutes {
+	for _, route := range listenerRoutes {
 		routeUpdated := listenerReconciler.updateGatewayListeners(ctx, gateway, route, ingress)
 		if routeUpdated {
 			updated = true
 		}
 	}
+	// Load balancer provisioning may key off Gateway infrastructure labels
+	if translator.MergeInfrastructureLabels(gateway, singleTrans.InfrastructureLabels(ingress)) {
+		updated = true
+	}
+	if proxySSL != nil && proxySSL.ClientCertificate != nil {
+		if r.applyBackendClientCertificate(ctx, ingress, gateway, proxySSL.ClientCertificate) {
+			updated = true
+		}
+	}
+	if listenerReconciler.syncGatewayAddresses(ctx, gateway) {
+		updated = true
+	}
+	if listenerReconciler.syncInfrastructureAnnotations(ctx, gateway) {
+		updated = true
+	}
 	if updated {
 		if gatewayExists {
+			utils.LogUpdateDiff(ctx, r.Client, gateway)
 			if err := r.Update(ctx, gateway); err != nil {
 				logger.Error(err, "failed to update Gateway after listener changes")
 				return ctrl.Result{}, err
 			}
-			metrics.GatewayResourcesTotal.WithLabelValues("update", gateway.Namespace, gateway.Name).Inc()
+			r.resourceRecorder(ctx, ingress, "Gateway", metrics.GatewayResourcesTotal)("update", gateway.Namespace, gateway.Name)
 		} else if len(gateway.Spec.Listeners) > 0 {
 			if err := r.Create(ctx, gateway); err != nil {
 				if apierrors.IsAlreadyExists(err) {
@@ -399,21 +837,136 @@ func (r *IngressReconciler) reconcileIngressToHTTPRoute(
 				logger.Error(err, "failed to create Gateway after listener changes")
 				return ctrl.Result{}, err
 			}
-			metrics.GatewayResourcesTotal.WithLabelValues("create", gateway.Namespace, gateway.Name).Inc()
+			r.resourceRecorder(ctx, ingress, "Gateway", metrics.GatewayResourcesTotal)("create", gateway.Namespace, gateway.Name)
 		}
 		logger.Info("Updated Gateway listeners from Ingress", "gateway", gatewayName)
 	}
+	if sharded {
+		r.recordGatewayShard(ctx, ingress, listenerReconciler, shardBase, gatewayName)
+	}
+
+	r.recordHostnameStates(ctx, ingress, gatewayName, utils.HostnameDual)
+	result, err := r.postProcessIngress(ctx, ingress, effectiveMode, !updated && gatewayExists)
+	return withHandoffRequeue(result, err, handoffRequeue)
+}
+
+// buildHTTPRoutes translates an Ingress into its HTTPRoutes: the (split) main route plus the
+// TLS-only, default backend and HTTPS redirect routes
+func (r *IngressReconciler) buildHTTPRoutes(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	singleTrans *translator.Translator,
+) []*gatewayv1.HTTPRoute {
+	logger := log.FromContext(ctx)
+
+	// Paths other Ingresses route to different backends are dropped or merged as the conflict policy decides
+	routed, merged := r.resolveBackendConflicts(ctx, ingress)
+	httpRoute := singleTrans.TranslateToHTTPRoute(routed)
+	mergeWeightedBackends(httpRoute, merged)
+	r.setRouteOwner(httpRoute, ingress)
+
+	// Apply implementation-specific extensions (snippets, auth, headers, load balancing)
+	dataPlane := r.dataPlaneProvider(ctx)
+	dataPlane.ApplyExtensions(ctx, r, ingress, httpRoute)
+	r.reportTraefikMiddlewaresLost(ingress, httpRoute, dataPlane.Name() == DataPlaneProviderTraefik)
+	r.reportALBAnnotationsLost(ingress, dataPlane.Name() == DataPlaneProviderEnvoyGateway)
+	r.applyConfigMapHeaders(ctx, ingress, httpRoute)
+
+	// Resolve any named ports before applying
+	if err := r.HTTPRouteManager.ResolveNamedPorts(ctx, routed, httpRoute); err != nil {
+		logger.Error(err, "failed to resolve named ports")
+		// Continue anyway with fallback ports
+	}
+
+	// Dedupe filters and split HTTPRoute if it exceeds the Gateway API limits
+	httpRoutes := r.HTTPRouteManager.SplitHTTPRouteIfNeeded(httpRoute)
+	metrics.HTTPRouteChunks.WithLabelValues(ingress.Namespace, ingress.Name).Set(float64(len(httpRoutes)))
+	for _, part := range httpRoutes {
+		// Which Ingress path each rule comes from, for tracing 404s back to the Ingress
+		utils.SetRuleOrigins(ingress, part)
+		r.recordSourceIngressClass(ingress, part)
+		if violations := utils.HTTPRouteLimitViolations(part); len(violations) > 0 {
+			r.recordWarning(ingress, "HTTPRouteLimitExceeded", strings.Join(violations, "; "))
+		}
+	}
+
+	// TLS-only hosts get their own HTTPRoute so they do not inherit the Ingress rules
+	if tlsOnlyRoute := singleTrans.TranslateTLSOnlyHostsToHTTPRoute(ingress); tlsOnlyRoute != nil {
+		r.setRouteOwner(tlsOnlyRoute, ingress)
+		if err := r.HTTPRouteManager.ResolveNamedPorts(ctx, ingress, tlsOnlyRoute); err != nil {
+			logger.Error(err, "failed to resolve named ports for TLS-only HTTPRoute")
+		}
+		httpRoutes = append(httpRoutes, tlsOnlyRoute)
+	}
+
+	// spec.defaultBackend / default-backend become a catch-all HTTPRoute with the lowest precedence
+	if defaultBackendRoute := singleTrans.TranslateDefaultBackendToHTTPRoute(ingress); defaultBackendRoute != nil {
+		r.setRouteOwner(defaultBackendRoute, ingress)
+		if err := r.HTTPRouteManager.ResolveNamedPorts(ctx, ingress, defaultBackendRoute); err != nil {
+			logger.Error(err, "failed to resolve named ports for default backend HTTPRoute")
+		}
+		httpRoutes = append(httpRoutes, defaultBackendRoute)
+	}
+
+	// TLS hosts answer on port 80 too, redirecting to HTTPS unless ssl-redirect is off
+	if redirectRoute := singleTrans.TranslateHTTPRedirectToHTTPRoute(ingress); redirectRoute != nil {
+		r.setRouteOwner(redirectRoute, ingress)
+		httpRoutes = append(httpRoutes, redirectRoute)
+	}
+
+	return httpRoutes
+}
+
+// postProcessIngress disables or removes the source Ingress once its HTTPRoutes are in place.
+// disableExternalDNSNow is set when no Gateway update follows that would make HTTPRouteReconciler do it.
+func (r *IngressReconciler) postProcessIngress(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	effectiveMode IngressPostProcessingMode,
+	disableExternalDNSNow bool,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	// Hold back post-processing while the target GatewayClass is unhealthy
+	if effectiveMode != IngressPostProcessingModeNone && r.postProcessingPaused(ctx, ingress) {
+		logger.Info("Ingress post-processing paused, GatewayClass is unhealthy",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name,
+			"gatewayClass", r.gatewayClassFor(ingress))
+		return ctrl.Result{RequeueAfter: gatewayClassPausedRequeue}, nil
+	}
+
+	if r.lossyPostProcessingBlocked(ingress, effectiveMode) {
+		return ctrl.Result{}, nil
+	}
 
 	// Handle post-processing based on mode
 	switch effectiveMode {
 	case IngressPostProcessingModeRemove:
 		if err := r.removeIngress(ctx, ingress); err != nil {
+			if errors.Is(err, utils.ErrPreDeleteHookPending) {
+				logger.Info("Waiting for pre-delete hook Job before removing source Ingress",
+					"namespace", ingress.Namespace,
+					"name", ingress.Name,
+					"job", utils.PreDeleteHookJobName(ingress))
+				return ctrl.Result{RequeueAfter: preDeleteHookRequeue}, nil
+			}
 			logger.Error(err, "failed to remove source Ingress")
+			r.recordWarning(ingress, "IngressRemovalFailed",
+				fmt.Sprintf("Source Ingress was not removed: %v", err))
 			return ctrl.Result{}, err
 		}
 		logger.Info("Removed source Ingress", "namespace", ingress.Namespace, "name", ingress.Name)
 	case IngressPostProcessingModeDisable:
 		if err := r.disableIngress(ctx, ingress); err != nil {
+			if errors.Is(err, ErrCannotDisable) {
+				// Leave the Ingress and its status to the legacy controller, retrying would change nothing
+				logger.Info("Not disabling source Ingress", "namespace", ingress.Namespace, "name", ingress.Name,
+					"reason", err.Error())
+				r.recordWarning(ingress, "IngressNotDisabled",
+					fmt.Sprintf("Source Ingress is still served by its legacy controller: %v", err))
+				return ctrl.Result{}, nil
+			}
 			logger.Error(err, "failed to disable source Ingress")
 			return ctrl.Result{}, err
 		}
@@ -421,18 +974,267 @@ func (r *IngressReconciler) reconcileIngressToHTTPRoute(
 	case IngressPostProcessingModeDisableExternalDNS:
 		// External-DNS disabling is now handled by HTTPRouteReconciler after Gateway is updated
 		// This ensures the Gateway has the listener ready before external-dns processing stops
-		logger.V(1).Info("External-DNS will be disabled by HTTPRouteReconciler after Gateway update")
+		if disableExternalDNSNow {
+			// Listeners were already in place (e.g. resuming after a pause), nothing will trigger it
+			if err := disableExternalDNS(ctx, r.Client, r.intents(), ingress); err != nil {
+				logger.Error(err, "failed to disable external-dns on source Ingress")
+				return ctrl.Result{}, err
+			}
+		} else {
+			logger.V(1).Info("External-DNS will be disabled by HTTPRouteReconciler after Gateway update")
+		}
 	case IngressPostProcessingModeNone:
 		// Do nothing
 	}
+	if effectiveMode != IngressPostProcessingModeNone {
+		// Without disableExternalDNSNow the HTTPRoute controller hands the Ingress over later, which requeues it
+		currentHandedOver := effectiveMode != IngressPostProcessingModeDisableExternalDNS || disableExternalDNSNow
+		if currentHandedOver || handedOver(ingress) {
+			r.recordHostnameStates(ctx, ingress, "", utils.HostnameGatewayOnly)
+		}
+		r.notifyNamespaceMigrated(ctx, ingress, currentHandedOver)
+	}
+
+	r.recordNormal(ingress, "ReconcileSuccess", "Ingress reconciled to HTTPRoute successfully")
+	return ctrl.Result{}, nil
+}
+
+// findHTTPRouteConflicts returns the HTTPRoutes that were skipped because an unmanaged HTTPRoute has the name
+func (r *IngressReconciler) findHTTPRouteConflicts(
+	ctx context.Context,
+	httpRoutes []*gatewayv1.HTTPRoute,
+) []utils.ResourceConflict {
+	var conflicts []utils.ResourceConflict
+	for _, route := range httpRoutes {
+		conflict, err := utils.FindUnmanagedConflict(ctx, r.Client, "HTTPRoute", &gatewayv1.HTTPRoute{},
+			types.NamespacedName{Namespace: route.Namespace, Name: route.Name})
+		if err != nil {
+			log.FromContext(ctx).V(1).Info("Unable to check HTTPRoute for conflicts",
+				"namespace", route.Namespace,
+				"name", route.Name,
+				"error", err.Error())
+			continue
+		}
+		if conflict != nil {
+			conflicts = append(conflicts, *conflict)
+		}
+	}
+	return conflicts
+}
+
+// syncConflictAnnotations records name collisions with unmanaged resources on the source Ingress
+// (conflict-with: kind/ns/name,...) and clears the annotations once the collisions are gone
+func (r *IngressReconciler) syncConflictAnnotations(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	conflicts []utils.ResourceConflict,
+) {
+	logger := log.FromContext(ctx)
+
+	values := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		values = append(values, conflict.String())
+		metrics.ResourceConflictsTotal.WithLabelValues(conflict.Kind, conflict.Namespace, conflict.Name).Inc()
+	}
+	conflictWith := strings.Join(values, ",")
+
+	suggestion := ""
+	if r.ProposeConflictNames && len(conflicts) > 0 {
+		var scratch client.Object = &gatewayv1.HTTPRoute{}
+		if conflicts[0].Kind == "Gateway" {
+			scratch = &gatewayv1.Gateway{}
+		}
+		proposed, err := utils.ProposeAlternativeName(ctx, r.Client, scratch, conflicts[0])
+		if err != nil {
+			logger.V(1).Info("Unable to propose an alternative name",
+				"conflict", conflicts[0].String(),
+				"error", err.Error())
+		} else {
+			suggestion = proposed
+		}
+	}
+
+	if len(conflicts) > 0 {
+		message := fmt.Sprintf("Generated resources collide with objects not managed by ingress-doperator: %s",
+			conflictWith)
+		if suggestion != "" {
+			message += fmt.Sprintf(" (suggested name: %s)", suggestion)
+		}
+		r.recordWarning(ingress, "ResourceConflict", message)
+	}
+
+	current := ingress.Annotations[ConflictWithAnnotation]
+	currentSuggestion := ingress.Annotations[ConflictSuggestedNameAnnotation]
+	if current == conflictWith && currentSuggestion == suggestion {
+		return
+	}
+
+	patchBase := client.MergeFrom(ingress.DeepCopy())
+	if ingress.Annotations == nil {
+		ingress.Annotations = map[string]string{}
+	}
+	setOrDelete := func(key, value string) {
+		if value == "" {
+			delete(ingress.Annotations, key)
+			return
+		}
+		ingress.Annotations[key] = value
+	}
+	setOrDelete(ConflictWithAnnotation, conflictWith)
+	setOrDelete(ConflictSuggestedNameAnnotation, suggestion)
+	if err := r.Patch(ctx, ingress, patchBase); err != nil {
+		logger.Error(err, "failed to update conflict annotations on Ingress")
+	}
+}
+
+// applyProxySSL translates proxy-ssl-* into BackendTLSPolicies. It returns false when the Ingress has to
+// stay on its current controller because the target implementation cannot express the backend TLS setup.
+func (r *IngressReconciler) applyProxySSL(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	gatewayName string,
+) (*utils.BackendTLSResult, bool) {
+	logger := log.FromContext(ctx)
+	if r.ProxySSLMode == ProxySSLModeDisabled {
+		return nil, true
+	}
+	block := func(message string) (*utils.BackendTLSResult, bool) {
+		logger.Info("Holding back Ingress, backend TLS cannot be translated",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name,
+			"reason", message)
+		r.recordWarning(ingress, "BackendTLSUnsupported", message)
+		return nil, false
+	}
+
+	config, err := translator.ParseProxySSL(ingress.Annotations, ingress.Namespace)
+	if err != nil {
+		return block(err.Error())
+	}
+	if config == nil {
+		if err := utils.DeleteBackendTLSForIngress(ctx, r.tenantClient(), ingress); err != nil {
+			logger.Error(err, "failed to delete BackendTLSPolicies", "namespace", ingress.Namespace, "name", ingress.Name)
+		}
+		return nil, true
+	}
+
+	auto := r.ProxySSLMode != ProxySSLModeEnabled
+	gatewayClassName := r.gatewayClassFor(ingress)
+	if auto && !r.gatewayClassSupports(ctx, gatewayClassName, utils.GatewayClassSupportsBackendTLSPolicy) {
+		return block(fmt.Sprintf("proxy-ssl-secret needs BackendTLSPolicy, which GatewayClass %q does not support",
+			gatewayClassName))
+	}
+
+	if !r.GatewayAPI.Installed(utils.BackendTLSPolicyKind) {
+		return block("proxy-ssl-secret needs BackendTLSPolicy, whose CRD is not installed")
+	}
+
+	owner := r.resourceOwner(ingress)
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	result, err := utils.EnsureBackendTLSForIngress(ctx, r.tenantClient(), reader, r.Scheme, owner, ingress, config)
+	if err != nil {
+		logger.Error(err, "failed to apply BackendTLSPolicies", "namespace", ingress.Namespace, "name", ingress.Name)
+		return block(fmt.Sprintf("Unable to translate proxy-ssl-secret: %v", err))
+	}
+	if !config.Verify {
+		r.recordWarning(ingress, "BackendTLSVerification",
+			"proxy-ssl-verify is off, but BackendTLSPolicy always verifies backend certificates")
+	}
+
+	if result.ClientCertificate != nil {
+		if auto && !r.gatewayClassSupports(ctx, gatewayClassName, utils.GatewayClassSupportsBackendClientCertificate) {
+			return block(fmt.Sprintf("proxy-ssl-secret holds a client certificate, but GatewayClass %q "+
+				"cannot present client certificates to backends (mTLS)", gatewayClassName))
+		}
+		gateway := &gatewayv1.Gateway{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: r.GatewayNamespace, Name: gatewayName}, gateway)
+		if err == nil {
+			if _, conflict := utils.SetGatewayBackendClientCertificate(gateway, result.ClientCertificate); conflict {
+				return block(fmt.Sprintf("Gateway %s/%s already presents another backend client certificate",
+					r.GatewayNamespace, gatewayName))
+			}
+		}
+	}
+	return &result, true
+}
+
+// applyBackendClientCertificate makes the Gateway present the proxy-ssl-secret client certificate
+// and returns true when the Gateway spec changed
+func (r *IngressReconciler) applyBackendClientCertificate(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	gateway *gatewayv1.Gateway,
+	ref *gatewayv1.SecretObjectReference,
+) bool {
+	if !r.GatewayAPI.Installed(utils.ReferenceGrantKind) {
+		log.FromContext(ctx).V(1).Info("ReferenceGrant CRD not installed, not granting the backend client certificate")
+	} else if err := utils.EnsureBackendClientCertReferenceGrant(
+		ctx, r.Client, r.GatewayNamespace, ref, ingress,
+	); err != nil {
+		log.FromContext(ctx).Error(err, "failed to ensure ReferenceGrant for backend client certificate")
+	}
+	changed, conflict := utils.SetGatewayBackendClientCertificate(gateway, ref)
+	if conflict {
+		r.recordWarning(ingress, "BackendTLSUnsupported",
+			fmt.Sprintf("Gateway %s/%s already presents another backend client certificate", gateway.Namespace, gateway.Name))
+	}
+	return changed
+}
+
+// gatewayClassSupports runs a GatewayClass capability check, treating lookup errors as unsupported
+func (r *IngressReconciler) gatewayClassSupports(
+	ctx context.Context,
+	gatewayClassName string,
+	check func(context.Context, client.Reader, string) (bool, error),
+) bool {
+	supported, err := check(ctx, r.Client, gatewayClassName)
+	if err != nil {
+		log.FromContext(ctx).V(1).Info("Unable to check GatewayClass capabilities, assuming unsupported",
+			"gatewayClass", gatewayClassName,
+			"error", err.Error())
+		return false
+	}
+	return supported
+}
+
+// regexPathMatchSupported resolves whether RegularExpression path matches can be emitted
+func (r *IngressReconciler) regexPathMatchSupported(ctx context.Context, gatewayClassName string) bool {
+	switch r.RegexPathMatchMode {
+	case RegexPathMatchModeEnabled:
+		return true
+	case RegexPathMatchModeDisabled:
+		return false
+	}
 
-	r.recordNormal(ingress, "ReconcileSuccess", "Ingress reconciled to HTTPRoute successfully")
-	return ctrl.Result{}, nil
+	supported, err := utils.GatewayClassSupportsRegexPathMatch(ctx, r.Client, gatewayClassName)
+	if err != nil {
+		log.FromContext(ctx).V(1).Info("Unable to check GatewayClass regex support, assuming unsupported",
+			"gatewayClass", gatewayClassName,
+			"error", err.Error())
+		return false
+	}
+	return supported
+}
+
+// hostRewriteSupported checks whether the target GatewayClass implements URLRewrite hostname filters
+func (r *IngressReconciler) hostRewriteSupported(ctx context.Context, gatewayClassName string) bool {
+	supported, err := utils.GatewayClassSupportsHostRewrite(ctx, r.Client, gatewayClassName)
+	if err != nil {
+		log.FromContext(ctx).V(1).Info("Unable to check GatewayClass hostname rewrite support, assuming unsupported",
+			"gatewayClass", gatewayClassName,
+			"error", err.Error())
+		return false
+	}
+	return supported
 }
 
 func (r *IngressReconciler) ensureGatewayForListenerUpdate(
 	ctx context.Context,
 	gatewayName string,
+	gatewayClassName string,
 ) (*gatewayv1.Gateway, bool, bool, error) {
 	gatewayNN := types.NamespacedName{
 		Namespace: r.GatewayNamespace,
@@ -444,11 +1246,8 @@ func (r *IngressReconciler) ensureGatewayForListenerUpdate(
 			return nil, false, false, err
 		}
 		// Return a new Gateway object without creating it yet
-		initializer := &HTTPRouteReconciler{
-			GatewayNamespace: r.GatewayNamespace,
-			GatewayClassName: r.GatewayClassName,
-		}
-		gateway = initializer.createInitialGateway(gatewayName)
+		initializer := &HTTPRouteReconciler{GatewayNamespace: r.GatewayNamespace}
+		gateway = initializer.createInitialGateway(gatewayName, gatewayClassName)
 		return gateway, true, false, nil
 	}
 
@@ -470,13 +1269,34 @@ func (r *IngressReconciler) shouldIncludeIngressForSynthesis(
 		return false
 	}
 
-	if ingress.Annotations != nil && ingress.Annotations[IgnoreIngressAnnotation] == fmt.Sprintf("%t", true) {
+	if IngressIgnored(ingress) {
 		logger.V(1).Info("Ingress has ignore annotation, skipping synthesis",
 			"namespace", ingress.Namespace,
 			"name", ingress.Name)
 		return false
 	}
 
+	if !r.matchesIngressSelector(ingress) {
+		logger.V(1).Info("Ingress labels do not match the ingress selector, skipping synthesis",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name)
+		return false
+	}
+
+	if !r.settings().namespaceAllowed(ingress.Namespace) {
+		logger.V(1).Info("Namespace is not selected by the IngressMigrationPolicy, skipping synthesis",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name)
+		return false
+	}
+
+	if !r.settings().rolloutAllowed(r.fanInSourceName(), ingress.Namespace, ingress.Name) {
+		logger.V(1).Info("Ingress is not yet within the rollout percentage, skipping synthesis",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name)
+		return false
+	}
+
 	if r.matchesIngressClassIgnoreFilter(ingress) {
 		ingressClass := r.getIngressClass(ingress)
 		logger.V(1).Info("Ingress class matches ignore filter, skipping synthesis",
@@ -503,7 +1323,14 @@ func (r *IngressReconciler) shouldIncludeIngressForSynthesis(
 		return false
 	}
 
-	if r.getIngressClass(ingress) == DisabledIngressClassName {
+	if r.previewExpired(ingress) {
+		logger.V(1).Info("Preview of the Ingress expired, skipping synthesis",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name)
+		return false
+	}
+
+	if r.getIngressClass(ingress) == DisabledIngressClassName || HasDisabledMarker(ingress) {
 		logger.V(1).Info("Ingress uses disabled class, skipping synthesis",
 			"namespace", ingress.Namespace,
 			"name", ingress.Name)
@@ -526,7 +1353,7 @@ func (r *IngressReconciler) resolveIngressPostProcessingMode(
 	ingress *networkingv1.Ingress,
 ) IngressPostProcessingMode {
 	if ingress == nil || ingress.Annotations == nil {
-		return r.IngressPostProcessingMode
+		return r.settings().IngressPostProcessingMode
 	}
 	switch ingress.Annotations[IngressDisabledAnnotation] {
 	case IngressDisabledReasonNormal:
@@ -534,7 +1361,7 @@ func (r *IngressReconciler) resolveIngressPostProcessingMode(
 	case IngressDisabledReasonExternalDNS:
 		return IngressPostProcessingModeDisableExternalDNS
 	default:
-		return r.IngressPostProcessingMode
+		return r.settings().IngressPostProcessingMode
 	}
 }
 func (r *IngressReconciler) applyHTTPRouteExtensionRefs(
@@ -566,6 +1393,103 @@ func (r *IngressReconciler) applyHTTPRouteExtensionRefs(
 	r.applySnippetsFilters(ctx, ingress, httpRoute, logger)
 }
 
+// applyConfigMapHeaders resolves proxy-set-headers (request) and custom-headers (response)
+// ConfigMaps and sets the headers on the HTTPRoute
+func (r *IngressReconciler) applyConfigMapHeaders(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	httpRoute *gatewayv1.HTTPRoute,
+) {
+	logger := log.FromContext(ctx)
+	load := func(key string) []gatewayv1.HTTPHeader {
+		ref, ok := translator.GetNginxAnnotation(ingress.Annotations, key)
+		if !ok || ref == "" {
+			return nil
+		}
+		cmKey, err := r.headerConfigMapKey(ingress, ref)
+		if err == nil {
+			var headers []gatewayv1.HTTPHeader
+			headers, err = utils.GetConfigMapHeaders(ctx, r.Client, cmKey)
+			if err == nil {
+				return headers
+			}
+		}
+		logger.Error(err, "failed to load headers from ConfigMap", "annotation", key, "reference", ref)
+		r.recordWarning(ingress, "HeadersConfigMapError",
+			fmt.Sprintf("Unable to load headers for %s from ConfigMap %q: %v", key, ref, err))
+		return nil
+	}
+
+	translator.ApplyHeaderSets(
+		httpRoute,
+		load(translator.NginxProxySetHeadersKey),
+		load(translator.NginxCustomHeadersKey),
+	)
+}
+
+// applyUpstreamLoadBalancing translates upstream-hash-by and load-balance into an NGF
+// UpstreamSettingsPolicy targeting the backend Services. The Gateway API BackendLBPolicy (now
+// XBackendTrafficPolicy) has no load balancing algorithm, and the balancing directives are only valid
+// in the upstream block NGF generates, which no SnippetsFilter context reaches. So there is no snippet
+// fallback when the CRD is missing, the Ingress gets a warning event instead.
+func (r *IngressReconciler) applyUpstreamLoadBalancing(ctx context.Context, ingress *networkingv1.Ingress) {
+	logger := log.FromContext(ctx)
+	policyName := utils.AutomaticUpstreamSettingsPolicyName(ingress.Name)
+	loadBalancing, ok, err := translator.ParseUpstreamLoadBalancing(ingress.Annotations)
+	if err != nil {
+		r.recordWarning(ingress, "LoadBalanceNotTranslated", err.Error())
+	}
+	if !ok {
+		if err := utils.DeleteUpstreamSettingsPolicyForIngress(ctx, r.tenantClient(), ingress.Namespace, policyName); err != nil {
+			logger.Error(err, "failed to delete UpstreamSettingsPolicy", "name", policyName, "namespace", ingress.Namespace)
+		}
+		return
+	}
+
+	owner := r.resourceOwner(ingress)
+	ready, err := utils.EnsureUpstreamSettingsPolicyForIngress(
+		ctx,
+		r.tenantClient(),
+		r.Scheme,
+		owner,
+		ingress,
+		policyName,
+		loadBalancing,
+	)
+	if err != nil {
+		logger.Error(err, "failed to apply UpstreamSettingsPolicy", "name", policyName, "namespace", ingress.Namespace)
+		r.recordWarning(ingress, "UpstreamSettingsPolicyFailed",
+			fmt.Sprintf("failed to apply UpstreamSettingsPolicy %s for %s", policyName, loadBalancing.Annotation))
+		return
+	}
+	if !ready {
+		reason := "LoadBalanceNotTranslated"
+		if loadBalancing.Annotation == translator.NginxUpstreamHashByKey {
+			reason = "UpstreamHashByNotTranslated"
+		}
+		r.recordWarning(ingress, reason,
+			fmt.Sprintf("%s was not translated: UpstreamSettingsPolicy CRD missing (snippets cannot set the "+
+				"upstream balancer), no backend Services or %s is not managed by ingress-doperator",
+				loadBalancing.Annotation, policyName))
+		return
+	}
+	r.recordLoadBalanceApproximated(ingress, loadBalancing, loadBalancing.Method)
+}
+
+// recordLoadBalanceApproximated warns that the data plane has no balancer matching load-balance
+func (r *IngressReconciler) recordLoadBalanceApproximated(
+	ingress *networkingv1.Ingress,
+	loadBalancing translator.UpstreamLoadBalancing,
+	equivalent string,
+) {
+	if !loadBalancing.Approximated {
+		return
+	}
+	value, _ := translator.GetNginxAnnotation(ingress.Annotations, loadBalancing.Annotation)
+	r.recordWarning(ingress, "LoadBalanceApproximated",
+		fmt.Sprintf("%s %q has no exact equivalent, approximated with %s", loadBalancing.Annotation, value, equivalent))
+}
+
 func (r *IngressReconciler) applyAnnotationExtensionRefs(
 	ctx context.Context,
 	ingress *networkingv1.Ingress,
@@ -584,7 +1508,7 @@ func (r *IngressReconciler) applyAnnotationExtensionRefs(
 	for _, name := range names {
 		ok, err := utils.EnsureExtensionResource(
 			ctx,
-			r.Client,
+			r.tenantClient(),
 			kind,
 			name,
 			r.GatewayNamespace,
@@ -610,12 +1534,9 @@ func (r *IngressReconciler) applyAnnotationExtensionRefs(
 	}
 }
 
-func (r *IngressReconciler) applySnippetsFilters(
-	ctx context.Context,
-	ingress *networkingv1.Ingress,
-	httpRoute *gatewayv1.HTTPRoute,
-	logger logr.Logger,
-) {
+// snippetsFilterNames returns the SnippetsFilters of the Gateway namespace the Ingress asks for,
+// through the class and name mappings or its annotations, in order
+func (r *IngressReconciler) snippetsFilterNames(ingress *networkingv1.Ingress, logger logr.Logger) []string {
 	snippetsOrder := make([]string, 0)
 	snippetsSet := make(map[string]struct{})
 	addSnippet := func(name string) {
@@ -684,11 +1605,29 @@ func (r *IngressReconciler) applySnippetsFilters(
 		}
 		snippetsOrder = filtered
 	}
+	return snippetsOrder
+}
+
+func (r *IngressReconciler) applySnippetsFilters(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	httpRoute *gatewayv1.HTTPRoute,
+	logger logr.Logger,
+) {
+	snippetsOrder := r.snippetsFilterNames(ingress, logger)
+	if r.DisableSnippets {
+		lost := make([]string, 0, len(snippetsOrder))
+		for _, name := range snippetsOrder {
+			lost = append(lost, utils.SnippetsFilterKind+"/"+name)
+		}
+		r.suppressSnippets(ctx, ingress, httpRoute, lost, nil)
+		return
+	}
 
 	for _, name := range snippetsOrder {
 		ok, err := utils.EnsureSnippetsFilterCopyForHTTPRoute(
 			ctx,
-			r.Client,
+			r.tenantClient(),
 			r.Scheme,
 			r.GatewayNamespace,
 			httpRoute.Namespace,
@@ -720,7 +1659,34 @@ func (r *IngressReconciler) applySnippetsFilters(
 				"name", ingress.Name)
 		}
 	}
-	snippets, warnings, ok := utils.BuildNginxIngressSnippets(ingress.Annotations)
+	snippetAnnotations, _ := r.snippetAnnotations(ingress)
+	snippets, warnings, ok := utils.BuildNginxIngressSnippets(snippetAnnotations)
+	if !translator.RewritesHost(httpRoute) {
+		// upstream-vhost could not become a URLRewrite hostname filter, keep it as an nginx directive
+		if vhostSnippet, vhostOK := utils.BuildUpstreamVhostSnippet(ingress.Annotations); vhostOK {
+			snippets = append(snippets, vhostSnippet)
+			ok = true
+		}
+	}
+	if ok {
+		snippets = r.orderHostSnippets(ctx, ingress, snippets)
+		ok = len(snippets) > 0
+	}
+	// Error page locations are named per Ingress, they never take part in host-scoped ordering
+	if errorSnippets := r.customErrorPageSnippets(ctx, ingress); len(errorSnippets) > 0 {
+		snippets = append(snippets, errorSnippets...)
+		ok = true
+	}
+	if ok {
+		// NGF rejects a SnippetsFilter with a single disallowed directive, drop just those
+		var rejections []utils.SnippetRejection
+		snippets, rejections = utils.SanitizeSnippets(snippets)
+		for _, rejection := range rejections {
+			r.recordWarning(ingress, "SnippetDirectiveRejected",
+				fmt.Sprintf("dropped from the automatic SnippetsFilter: %s", rejection))
+		}
+		ok = len(snippets) > 0
+	}
 	if !ok {
 		return
 	}
@@ -730,14 +1696,11 @@ func (r *IngressReconciler) applySnippetsFilters(
 			"namespace", ingress.Namespace,
 			"name", ingress.Name)
 	}
-	filterName := utils.AutomaticSnippetsFilterName(ingress.Name)
-	var owner client.Object = ingress
-	if r.IngressPostProcessingMode == IngressPostProcessingModeRemove {
-		owner = nil
-	}
+	filterName := r.automaticSnippetsFilterName(ingress)
+	owner := r.resourceOwner(ingress)
 	ready, err := utils.EnsureSnippetsFilterForIngress(
 		ctx,
-		r.Client,
+		r.tenantClient(),
 		r.Scheme,
 		httpRoute,
 		owner,
@@ -746,6 +1709,13 @@ func (r *IngressReconciler) applySnippetsFilters(
 		filterName,
 		snippets,
 	)
+	var collision *utils.SnippetsFilterCollisionError
+	if errors.As(err, &collision) {
+		r.recordWarning(ingress, "SnippetsFilterNameCollision",
+			fmt.Sprintf("annotation snippets are not applied: %v, change --snippets-filter-name-template "+
+				"or rename that filter", collision))
+		return
+	}
 	if err != nil {
 		logger.Error(err, "failed to apply annotation SnippetsFilter", "name", filterName, "namespace", httpRoute.Namespace)
 		r.recordWarning(ingress, "AnnotationSnippetsFilterFailed",
@@ -765,18 +1735,72 @@ func (r *IngressReconciler) handleDeletion(ctx context.Context, ingress *network
 		return ctrl.Result{}, nil
 	}
 
-	if !r.EnableDeletion {
+	if !r.cleansUpOnDeletion() {
 		logger.V(1).Info("Deletion disabled - HTTPRoute and Gateway will not be deleted")
+		r.recordNormal(ingress, "DerivedResourcesKept",
+			"Kept the derived resources, those with an ownerReference to the Ingress are garbage collected")
+		return r.finalizeDeletion(ctx, ingress)
+	}
+	// Removing the CRDs deleted every derived resource with them
+	if missing := r.missingCoreGatewayAPIKinds(); len(missing) > 0 {
+		logger.V(1).Info("Gateway API CRDs missing, nothing to delete", "missing", strings.Join(missing, ", "))
+		return r.finalizeDeletion(ctx, ingress)
+	}
+	if r.deletionCascade() == DeletionCascadeAdopt {
+		if err := r.adoptDerivedResources(ctx, ingress, logger); err != nil {
+			return ctrl.Result{}, err
+		}
 		return r.finalizeDeletion(ctx, ingress)
 	}
+	if r.OwnershipMode == OwnershipModeFinalizer {
+		return r.deleteDerivedResourcesInOrder(ctx, ingress, logger)
+	}
 
-	if err := r.deleteManagedHTTPRoutes(ctx, ingress, logger); err != nil {
+	if err := r.deleteDerivedResources(ctx, ingress, logger); err != nil {
 		return ctrl.Result{}, err
 	}
+	r.recordNormal(ingress, "DerivedResourcesDeleted", "Deleted the routes and other derived resources")
 
 	return r.finalizeDeletion(ctx, ingress)
 }
 
+// deleteDerivedResources deletes the routes and the other resources generated for an Ingress
+func (r *IngressReconciler) deleteDerivedResources(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	logger logr.Logger,
+) error {
+	if err := r.deleteManagedHTTPRoutes(ctx, ingress, logger); err != nil {
+		return err
+	}
+	if err := r.applyGRPCRoutes(ctx, ingress, nil); err != nil {
+		return err
+	}
+	if err := r.applyTLSRoutes(ctx, ingress, nil); err != nil {
+		return err
+	}
+	r.releaseHostnameStates(ctx, client.ObjectKeyFromObject(ingress), true)
+	if !r.GatewayAPI.Installed(utils.ReferenceGrantKind) {
+		logger.V(1).Info("ReferenceGrant CRD not installed, no mirror ReferenceGrants to release")
+	} else if err := utils.SyncMirrorReferenceGrants(
+		ctx, r.Client, ingress.Namespace, ingress.Namespace, ingress.Name, nil,
+	); err != nil {
+		logger.Error(err, "failed to release mirror ReferenceGrants")
+	}
+	if err := utils.DeleteBackendTLSForIngress(ctx, r.tenantClient(), ingress); err != nil {
+		logger.Error(err, "failed to delete BackendTLSPolicies")
+	}
+	if r.NetworkPolicies {
+		if err := utils.DeleteGatewayNetworkPoliciesForIngress(ctx, r.tenantClient(), ingress); err != nil {
+			logger.Error(err, "failed to delete NetworkPolicies")
+		}
+	}
+	if err := r.dataPlaneProvider(ctx).Cleanup(ctx, r, ingress); err != nil {
+		logger.Error(err, "failed to delete data plane provider resources")
+	}
+	return nil
+}
+
 func (r *IngressReconciler) shouldSkipDeletionCleanup(
 	ctx context.Context,
 	ingress *networkingv1.Ingress,
@@ -806,20 +1830,38 @@ func (r *IngressReconciler) deleteManagedHTTPRoutes(
 	ingress *networkingv1.Ingress,
 	logger logr.Logger,
 ) error {
-	routes, err := r.HTTPRouteManager.GetHTTPRoutesWithPrefix(ctx, ingress.Namespace, ingress.Name)
+	routes, err := r.HTTPRouteManager.GetHTTPRoutesForIngress(ctx, ingress)
 	if err != nil {
 		return err
 	}
+	if len(routes) == 0 {
+		return nil
+	}
+
+	return r.intents().run(ctx, utils.IntentDeleteHTTPRoutes, ingress, func() error {
+		return r.deleteHTTPRoutes(ctx, ingress, routes, logger)
+	})
+}
 
+func (r *IngressReconciler) deleteHTTPRoutes(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	routes []gatewayv1.HTTPRoute,
+	logger logr.Logger,
+) error {
 	for _, route := range routes {
 		httpRoute := &route
+		if !httpRoute.DeletionTimestamp.IsZero() {
+			continue
+		}
 		if utils.IsManagedByUsForIngress(httpRoute, ingress.Namespace, ingress.Name) {
 			logger.V(1).Info("Deleting managed HTTPRoute", "namespace", httpRoute.Namespace, "name", httpRoute.Name)
-			if err := r.Delete(ctx, httpRoute); err != nil && !apierrors.IsNotFound(err) {
+			if err := r.tenantClient().Delete(ctx, httpRoute); err != nil && !apierrors.IsNotFound(err) {
 				logger.Error(err, "failed to delete HTTPRoute")
 				return err
 			}
-			metrics.HTTPRouteResourcesTotal.WithLabelValues("delete", httpRoute.Namespace, httpRoute.Name).Inc()
+			r.resourceRecorder(ctx, ingress, "HTTPRoute", metrics.HTTPRouteResourcesTotal)(
+				"delete", httpRoute.Namespace, httpRoute.Name)
 		} else {
 			logger.V(1).Info("HTTPRoute exists but is not managed by us for this Ingress, skipping deletion",
 				"namespace", httpRoute.Namespace, "name", httpRoute.Name)
@@ -836,61 +1878,22 @@ func (r *IngressReconciler) finalizeDeletion(
 		log.FromContext(ctx).Error(removeErr, "failed to remove finalizer")
 		return ctrl.Result{}, removeErr
 	}
-	r.evictReconcileCache(ingress)
+	r.evictReconcileCache(ctx, ingress)
+	r.takeWarnings(types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name})
+	metrics.HTTPRouteChunks.DeleteLabelValues(ingress.Namespace, ingress.Name)
+	metrics.UntranslatedFeatures.DeleteLabelValues(ingress.Namespace, ingress.Name)
+	r.FanIn.Release(r.fanInSourceName(), types.NamespacedName{Namespace: ingress.Namespace, Name: ingress.Name})
 	return ctrl.Result{}, nil
 }
 
-func (r *IngressReconciler) evictReconcileCache(ingress *networkingv1.Ingress) {
+func (r *IngressReconciler) evictReconcileCache(ctx context.Context, ingress *networkingv1.Ingress) {
 	if ingress == nil || r.ReconcileCache == nil {
 		return
 	}
 	if string(ingress.UID) == "" {
 		return
 	}
-	key := utils.ReconcileCacheKey(string(ingress.UID))
-	r.reconcileCacheMu.Lock()
-	delete(r.ReconcileCache, key)
-	cacheCopyEntries := make(map[string]utils.ReconcileCacheEntry, len(r.ReconcileCache))
-	for k, v := range r.ReconcileCache {
-		cacheCopyEntries[k] = v
-	}
-	r.reconcileCacheMu.Unlock()
-
-	if !r.ReconcileCachePersist {
-		return
-	}
-	if r.ReconcileCacheBaseName == "" || r.ReconcileCacheNamespace == "" {
-		return
-	}
-	if err := utils.SaveReconcileCacheSharded(
-		context.Background(),
-		r.Client,
-		r.ReconcileCacheNamespace,
-		r.ReconcileCacheBaseName,
-		r.ReconcileCacheShards,
-		cacheCopyEntries,
-	); err != nil {
-		log.FromContext(context.Background()).Error(err, "failed to persist reconcile cache eviction")
-	}
-}
-
-func (r *IngressReconciler) evictOldestCacheEntries() {
-	type entry struct {
-		key string
-		ts  int64
-	}
-	entries := make([]entry, 0, len(r.ReconcileCache))
-	for key, value := range r.ReconcileCache {
-		entries = append(entries, entry{key: key, ts: value.UpdatedAtUnix})
-	}
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].ts < entries[j].ts
-	})
-	for len(r.ReconcileCache) > r.ReconcileCacheMaxEntries && len(entries) > 0 {
-		oldest := entries[0]
-		delete(r.ReconcileCache, oldest.key)
-		entries = entries[1:]
-	}
+	r.ReconcileCache.Evict(ctx, utils.ReconcileCacheKey(string(ingress.UID)))
 }
 
 func (r *IngressReconciler) markSelfDeleted(ingress *networkingv1.Ingress) {
@@ -942,7 +1945,7 @@ func (r *IngressReconciler) disableIngress(ctx context.Context, ingress *network
 
 	// Check if already disabled
 	if ingress.Annotations != nil && ingress.Annotations[IngressDisabledAnnotation] == IngressDisabledReasonNormal {
-		if r.ClearIngressStatusOnDisable {
+		if r.ClearIngressStatusOnDisable && !r.IngressStatusFromGateway {
 			if err := r.clearIngressStatus(ctx, ingress); err != nil {
 				return err
 			}
@@ -950,60 +1953,37 @@ func (r *IngressReconciler) disableIngress(ctx context.Context, ingress *network
 		return nil // Already disabled
 	}
 
-	if err := r.ensureDisabledIngressClass(ctx); err != nil {
-		return err
-	}
-
-	modified := false
-
-	// Save original ingressClassName if it exists
-	if ingress.Spec.IngressClassName != nil && *ingress.Spec.IngressClassName != fmt.Sprintf("%t", true) {
-		if ingress.Annotations == nil {
-			ingress.Annotations = make(map[string]string)
-		}
-		// Save the original value
-		if _, exists := ingress.Annotations[OriginalIngressClassNameAnnotation]; !exists {
-			ingress.Annotations[OriginalIngressClassNameAnnotation] = *ingress.Spec.IngressClassName
-			logger.Info("Saved original ingressClassName", "value", *ingress.Spec.IngressClassName)
+	strategy := r.disableStrategy()
+	if strategy.Name() == DisableStrategyClassSwap {
+		if err := r.ensureDisabledIngressClass(ctx); err != nil {
+			return err
 		}
 	}
 
+	modified, err := strategy.Disable(ingress)
+	if err != nil {
+		return fmt.Errorf("failed to disable Ingress with the %s strategy: %w", strategy.Name(), err)
+	}
 	if ingress.Annotations == nil {
 		ingress.Annotations = make(map[string]string)
 	}
 
-	// Save original ingress.class annotation if it exists
-	if class, exists := ingress.Annotations[IngressClassAnnotation]; exists && class != "" {
-		if _, saved := ingress.Annotations[OriginalIngressClassAnnotation]; !saved {
-			ingress.Annotations[OriginalIngressClassAnnotation] = class
-			logger.Info("Saved original ingress.class annotation", "value", class)
-		}
-	}
-
-	if ingress.Spec.IngressClassName == nil || *ingress.Spec.IngressClassName != DisabledIngressClassName {
-		ingress.Spec.IngressClassName = ptr.To(DisabledIngressClassName)
-		modified = true
-		logger.Info("Set spec.ingressClassName to disable Ingress", "value", DisabledIngressClassName)
-	}
-
-	if ingress.Annotations[IngressClassAnnotation] != DisabledIngressClassName {
-		ingress.Annotations[IngressClassAnnotation] = DisabledIngressClassName
-		modified = true
-		logger.Info("Set kubernetes.io/ingress.class annotation to disable Ingress",
-			"value", DisabledIngressClassName)
-	}
-
-	// Mark as disabled
+	// Mark as disabled, remembering how and what the derived resources were translated from
 	if modified {
+		logger.Info("Disabling Ingress", "strategy", strategy.Name())
 		ingress.Annotations[IngressDisabledAnnotation] = IngressDisabledReasonNormal
+		ingress.Annotations[DisableStrategyAnnotation] = string(strategy.Name())
+		ingress.Annotations[OriginalSpecHashAnnotation] = ingressSpecHash(ingress)
 
-		if err := r.Update(ctx, ingress); err != nil {
+		if err := r.intents().run(ctx, utils.IntentDisableIngress, ingress, func() error {
+			return r.Update(ctx, ingress)
+		}); err != nil {
 			return fmt.Errorf("failed to update Ingress to disable it: %w", err)
 		}
 		logger.Info("Successfully disabled source Ingress", "namespace", ingress.Namespace, "name", ingress.Name)
 	}
 
-	if r.ClearIngressStatusOnDisable {
+	if r.ClearIngressStatusOnDisable && !r.IngressStatusFromGateway {
 		if err := r.clearIngressStatus(ctx, ingress); err != nil {
 			return err
 		}
@@ -1012,9 +1992,35 @@ func (r *IngressReconciler) disableIngress(ctx context.Context, ingress *network
 	return nil
 }
 
+// disableStrategy returns the configured DisableStrategy, swapping the class by default
+func (r *IngressReconciler) disableStrategy() DisableStrategy {
+	if r.DisableStrategy == nil {
+		return classSwapStrategy{}
+	}
+	return r.DisableStrategy
+}
+
+// DisableIngress performs the disable post-processing step (switch to the disabled IngressClass or prefix
+// the hosts, as the strategy decides) outside of a reconcile, e.g. from the disabler CLI
+func DisableIngress(
+	ctx context.Context,
+	cli client.Client,
+	ingress *networkingv1.Ingress,
+	clearStatus bool,
+	strategy DisableStrategy,
+) error {
+	r := &IngressReconciler{Client: cli, ClearIngressStatusOnDisable: clearStatus, DisableStrategy: strategy}
+	return r.disableIngress(ctx, ingress)
+}
+
+// DisableExternalDNS performs the disable-external-dns post-processing step outside of a reconcile
+func DisableExternalDNS(ctx context.Context, cli client.Client, ingress *networkingv1.Ingress) error {
+	return disableExternalDNS(ctx, cli, intentScope{}, ingress)
+}
+
 // disableExternalDNS is a package-level function that disables external-dns processing on an Ingress
 // It can be called by both IngressReconciler and HTTPRouteReconciler
-func disableExternalDNS(ctx context.Context, cli client.Client, ingress *networkingv1.Ingress) error {
+func disableExternalDNS(ctx context.Context, cli client.Client, intents intentScope, ingress *networkingv1.Ingress) error {
 	if ingress == nil {
 		return nil
 	}
@@ -1071,9 +2077,11 @@ func disableExternalDNS(ctx context.Context, cli client.Client, ingress *network
 		modified = true
 	}
 
+	rewritten := false
 	if latestIngress.Annotations[ExternalDNSIngressHostnameSource] != ExternalDNSHostnameSourceAnnotationOnly {
 		latestIngress.Annotations[ExternalDNSIngressHostnameSource] = ExternalDNSHostnameSourceAnnotationOnly
 		modified = true
+		rewritten = true
 		logger.Info("Set external-dns ingress hostname source to annotation-only",
 			"namespace", ingress.Namespace,
 			"name", ingress.Name,
@@ -1084,9 +2092,14 @@ func disableExternalDNS(ctx context.Context, cli client.Client, ingress *network
 		return nil
 	}
 
-	if err := cli.Update(ctx, latestIngress); err != nil {
+	if err := intents.run(ctx, utils.IntentDisableExternalDNS, latestIngress, func() error {
+		return cli.Update(ctx, latestIngress)
+	}); err != nil {
 		return fmt.Errorf("failed to update Ingress to disable external-dns: %w", err)
 	}
+	if rewritten {
+		metrics.ExternalDNSRewritesTotal.WithLabelValues(ExternalDNSRewriteDisable).Inc()
+	}
 
 	logger.Info("Successfully disabled external-dns on Ingress",
 		"namespace", ingress.Namespace, "name", ingress.Name)
@@ -1145,6 +2158,18 @@ func (r *IngressReconciler) clearIngressStatus(ctx context.Context, ingress *net
 }
 
 func (r *IngressReconciler) removeIngress(ctx context.Context, ingress *networkingv1.Ingress) error {
+	// A failing or unfinished pre-delete hook keeps the Ingress around
+	if err := utils.RunPreDeleteHook(ctx, r.Client, ingress); err != nil {
+		return err
+	}
+
+	return r.intents().run(ctx, utils.IntentDeleteIngress, ingress, func() error {
+		return r.deleteSourceIngress(ctx, ingress)
+	})
+}
+
+// deleteSourceIngress marks the Ingress as removed by us and deletes it, leaving its dependents
+func (r *IngressReconciler) deleteSourceIngress(ctx context.Context, ingress *networkingv1.Ingress) error {
 	logger := log.FromContext(ctx)
 
 	// Check if already marked for removal to avoid re-deletion
@@ -1160,6 +2185,7 @@ func (r *IngressReconciler) removeIngress(ctx context.Context, ingress *networki
 			return fmt.Errorf("failed to delete source Ingress: %w", err)
 		}
 		logger.Info("Successfully deleted source Ingress", "namespace", ingress.Namespace, "name", ingress.Name)
+		r.notifyIngressDeleted(ctx, ingress)
 		return nil
 	}
 
@@ -1188,19 +2214,43 @@ func (r *IngressReconciler) removeIngress(ctx context.Context, ingress *networki
 		return fmt.Errorf("failed to delete source Ingress: %w", err)
 	}
 	logger.Info("Successfully deleted source Ingress", "namespace", ingress.Namespace, "name", ingress.Name)
+	r.notifyIngressDeleted(ctx, ingress)
 
 	return nil
 }
 
+const (
+	// maxGatewayNameLength keeps Gateway names usable in the labels implementations derive from them
+	maxGatewayNameLength = 63
+	// namespaceHashLength is the number of hex characters of the namespace hash in shortened Gateway names
+	namespaceHashLength = 8
+)
+
+// getGatewayNameForNamespace returns <gateway-name>-<namespace>. Names longer than the 63 characters
+// implementations use in labels derived from the Gateway name end in a hash of the namespace instead, so
+// namespaces sharing a long prefix never share a Gateway
+func (r *IngressReconciler) getGatewayNameForNamespace(namespace string) string {
+	name := r.GatewayName + "-" + namespace
+	if len(name) <= maxGatewayNameLength {
+		return name
+	}
+	sum := sha256.Sum256([]byte(namespace))
+	suffix := "-" + hex.EncodeToString(sum[:])[:namespaceHashLength]
+	return strings.TrimRight(name[:maxGatewayNameLength-len(suffix)], "-.") + suffix
+}
+
 func (r *IngressReconciler) getGatewayNameForClass(ingressClass string) string {
-	if ingressClass == "" || ingressClass == "default" {
-		return r.GatewayName
-	}
-	return ingressClass
+	return translator.SharedGatewayName(r.GatewayName, ingressClass)
 }
 
 // getIngressClass returns the ingress class from spec.ingressClassName or the legacy annotation
 func (r *IngressReconciler) getIngressClass(ingress *networkingv1.Ingress) string {
+	return ingressClassName(ingress, r.IngressClassEmpty)
+}
+
+// ingressClassName returns the ingress class from spec.ingressClassName or the legacy annotation,
+// falling back to empty (the --ingress-class-empty value)
+func ingressClassName(ingress *networkingv1.Ingress, empty string) string {
 	// First check spec.ingressClassName
 	if ingress.Spec.IngressClassName != nil && *ingress.Spec.IngressClassName != "" {
 		return *ingress.Spec.IngressClassName
@@ -1213,10 +2263,7 @@ func (r *IngressReconciler) getIngressClass(ingress *networkingv1.Ingress) strin
 		}
 	}
 
-	if r.IngressClassEmpty != "" {
-		return r.IngressClassEmpty
-	}
-	return ""
+	return empty
 }
 
 // matchesIngressClassFilter checks if the Ingress class matches any configured filter pattern
@@ -1257,82 +2304,107 @@ func matchIngressClassPatterns(patterns []string, ingressClass, label string) bo
 }
 
 func (r *IngressReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	b := ctrl.NewControllerManagedBy(mgr).
-		For(&networkingv1.Ingress{})
+	b := ctrl.NewControllerManagedBy(mgr)
+	name := "ingress"
+	if r.SourceCluster != nil {
+		name = "ingress-fan-in-" + r.SourceCluster.Name
+	}
+	switch {
+	case r.PrioritizeUnmigrated:
+		// Same controller name as For(), but with a handler ranking the initial sync
+		b = r.watchIngresses(b.Named(name), r.ingressEventHandler()).
+			WithOptions(ctrlcontroller.Options{UsePriorityQueue: ptr.To(true)})
+	case r.SourceCluster != nil:
+		b = r.watchIngresses(b.Named(name), &handler.EnqueueRequestForObject{})
+	default:
+		b = b.For(&networkingv1.Ingress{})
+	}
 
 	// If watching specific namespace, add namespace filter
 	if r.WatchNamespace != "" {
 		b = b.WithEventFilter(NamespaceFilter(r.WatchNamespace))
 	}
 
-	apiReader := mgr.GetAPIReader()
-	ctx := context.Background()
-	if version, ok, err := utils.GetCRDVersion(ctx, apiReader, utils.SnippetsFilterCRDName); err == nil && ok {
-		snippetsGVK := schema.GroupVersionKind{
-			Group:   utils.NginxGatewayGroup,
-			Version: version,
-			Kind:    utils.SnippetsFilterKind,
-		}
-		snippets := &unstructured.Unstructured{}
-		snippets.SetGroupVersionKind(snippetsGVK)
-		b = b.Watches(
-			snippets,
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
-				return r.enqueueIngressesForSnippetsFilter(ctx, obj)
-			}),
-			ctrlbuilder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
-				return obj.GetNamespace() == r.GatewayNamespace
-			})),
-		)
-	} else {
-		log.FromContext(ctx).V(1).Info("SnippetsFilter CRD not installed, skipping watch")
+	// Filters and policies of the data plane provider requeue the Ingresses referencing them
+	b = r.watchDataPlaneProviders(context.Background(), b, mgr.GetAPIReader())
+
+	if r.ReconcileCache != nil {
+		b = r.watchIngresses(b, r.reconcileCachePruneHandler())
+	}
+
+	// Server snippets of Ingresses sharing a host are merged, so a change to one affects the others
+	b = r.watchIngresses(b, handler.EnqueueRequestsFromMapFunc(r.enqueueIngressesSharingHosts), hostSnippetsPredicate())
+	// So are backend conflicts of Ingresses sharing a path
+	b = r.watchIngresses(b, handler.EnqueueRequestsFromMapFunc(r.enqueueIngressesWithSharedPaths),
+		backendConflictPredicate())
+
+	// Ingresses winning or losing a hostname to another fan-in cluster are requeued
+	if r.FanIn != nil {
+		b = b.WatchesRawSource(source.Channel(r.FanIn.requeueEvents(r.fanInSourceName()), r.fanInRequeueHandler()))
 	}
 
-	if version, ok, err := utils.GetCRDVersion(ctx, apiReader, utils.AuthenticationFilterCRDName); err == nil && ok {
-		authGVK := schema.GroupVersionKind{
-			Group:   utils.NginxGatewayGroup,
-			Version: version,
-			Kind:    utils.AuthenticationFilterKind,
+	// Namespace labels and the ignore annotation opt Ingresses in or out
+	b = r.watchNamespaces(b, mgr.GetCache())
+
+	// Edited proxy-set-headers/custom-headers ConfigMaps requeue the Ingresses referencing them
+	b = r.watchHeaderConfigMaps(b)
+
+	// A changed IngressMigrationPolicy requeues every Ingress
+	if r.MigrationPolicy != nil {
+		b = b.WatchesRawSource(source.Channel(r.MigrationPolicy.requeueEvents(),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueIngressesForSettings)))
+	}
+	// So does a HostnameRenamePlan moving to another phase
+	if r.HostnameRenames != nil {
+		b = b.WatchesRawSource(source.Channel(r.HostnameRenames.requeueEvents(),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueIngressesForSettings)))
+	}
+	// And the drift resync, every interval
+	if r.DriftResyncInterval > 0 {
+		resync := newDriftResync(r.DriftResyncInterval)
+		if err := mgr.Add(resync); err != nil {
+			return err
 		}
-		auth := &unstructured.Unstructured{}
-		auth.SetGroupVersionKind(authGVK)
-		b = b.Watches(
-			auth,
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
-				return r.enqueueIngressesForExtension(ctx, obj, HTTPRouteAuthenticationAnnotation)
-			}),
-			ctrlbuilder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
-				return obj.GetNamespace() == r.GatewayNamespace
-			})),
-		)
-	} else {
-		log.FromContext(ctx).V(1).Info("AuthenticationFilter CRD not installed, skipping watch")
+		b = b.WatchesRawSource(source.Channel(resync.events,
+			handler.EnqueueRequestsFromMapFunc(r.enqueueIngressesForResync)))
 	}
 
-	if version, ok, err := utils.GetCRDVersion(ctx, apiReader, utils.RequestHeaderModifierCRDName); err == nil && ok {
-		headerGVK := schema.GroupVersionKind{
-			Group:   utils.NginxGatewayGroup,
-			Version: version,
-			Kind:    utils.RequestHeaderModifierFilterKind,
+	if r.AnnotationCoverageInterval > 0 {
+		if err := mgr.Add(&annotationCoverage{reconciler: r, interval: r.AnnotationCoverageInterval}); err != nil {
+			return err
 		}
-		header := &unstructured.Unstructured{}
-		header.SetGroupVersionKind(headerGVK)
+	}
+
+	if r.PauseOnUnhealthyGatewayClass {
 		b = b.Watches(
-			header,
-			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
-				return r.enqueueIngressesForExtension(ctx, obj, HTTPRouteRequestHeaderAnnotation)
-			}),
+			&gatewayv1.GatewayClass{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueIngressesForGatewayClass),
 			ctrlbuilder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
-				return obj.GetNamespace() == r.GatewayNamespace
+				return slices.Contains(r.targetGatewayClasses(), obj.GetName())
 			})),
 		)
-	} else {
-		log.FromContext(ctx).V(1).Info("RequestHeaderModifierFilter CRD not installed, skipping watch")
 	}
 
 	return b.Complete(r)
 }
 
+// watchIngresses watches the Ingresses of the cluster the reconciler reads them from
+func (r *IngressReconciler) watchIngresses(
+	b *ctrlbuilder.Builder,
+	h handler.EventHandler,
+	predicates ...predicate.Predicate,
+) *ctrlbuilder.Builder {
+	if r.SourceCluster == nil {
+		return b.Watches(&networkingv1.Ingress{}, h, ctrlbuilder.WithPredicates(predicates...))
+	}
+	// Global event filters don't apply to raw sources
+	if r.WatchNamespace != "" {
+		predicates = append(predicates, NamespaceFilter(r.WatchNamespace))
+	}
+	return b.WatchesRawSource(source.Kind[client.Object](
+		r.SourceCluster.Cluster.GetCache(), &networkingv1.Ingress{}, h, predicates...))
+}
+
 func (r *IngressReconciler) enqueueAllIngresses(ctx context.Context) []reconcile.Request {
 	list := &networkingv1.IngressList{}
 	opts := []client.ListOption{}
@@ -1514,7 +2586,7 @@ func dedupeReconcileRequests(requests []reconcile.Request) []reconcile.Request {
 	return out
 }
 
-func (r *IngressReconciler) shouldSkipReconcile(ingress *networkingv1.Ingress) bool {
+func (r *IngressReconciler) shouldSkipReconcile(ctx context.Context, ingress *networkingv1.Ingress) bool {
 	if ingress == nil {
 		return false
 	}
@@ -1524,21 +2596,11 @@ func (r *IngressReconciler) shouldSkipReconcile(ingress *networkingv1.Ingress) b
 	if ingress.ResourceVersion == "" {
 		return false
 	}
-	key := utils.ReconcileCacheKey(string(ingress.UID))
-	r.reconcileCacheMu.Lock()
-	defer r.reconcileCacheMu.Unlock()
-	last, ok := r.ReconcileCache[key]
+	last, ok := r.ReconcileCache.Get(ctx, utils.ReconcileCacheKey(string(ingress.UID)))
 	if !ok {
 		return false
 	}
-	if last.ResourceVersion != ingress.ResourceVersion {
-		return false
-	}
-	if time.Since(time.Unix(last.UpdatedAtUnix, 0)) > utils.ReconcileCacheTTL {
-		delete(r.ReconcileCache, key)
-		return false
-	}
-	return true
+	return last.ResourceVersion == ingress.ResourceVersion
 }
 
 func (r *IngressReconciler) shouldEnqueueIngressByClass(ingress *networkingv1.Ingress) bool {
@@ -1551,10 +2613,11 @@ func (r *IngressReconciler) shouldEnqueueIngressByClass(ingress *networkingv1.In
 	if !r.matchesIngressClassFilter(ingress) {
 		return false
 	}
-	return true
+	return r.matchesIngressSelector(ingress)
 }
 
 func (r *IngressReconciler) maybeRecordReconcile(
+	ctx context.Context,
 	ingress *networkingv1.Ingress,
 	result ctrl.Result,
 	err error,
@@ -1571,37 +2634,10 @@ func (r *IngressReconciler) maybeRecordReconcile(
 	if ingress.ResourceVersion == "" {
 		return
 	}
-	key := utils.ReconcileCacheKey(string(ingress.UID))
-	r.reconcileCacheMu.Lock()
-	r.ReconcileCache[key] = utils.ReconcileCacheEntry{
+	r.ReconcileCache.Record(ctx, utils.ReconcileCacheKey(string(ingress.UID)), utils.ReconcileCacheEntry{
 		ResourceVersion: ingress.ResourceVersion,
 		UpdatedAtUnix:   time.Now().Unix(),
-	}
-	if r.ReconcileCacheMaxEntries > 0 && len(r.ReconcileCache) > r.ReconcileCacheMaxEntries {
-		r.evictOldestCacheEntries()
-	}
-	cacheCopyEntries := make(map[string]utils.ReconcileCacheEntry, len(r.ReconcileCache))
-	for k, v := range r.ReconcileCache {
-		cacheCopyEntries[k] = v
-	}
-	r.reconcileCacheMu.Unlock()
-
-	if !r.ReconcileCachePersist {
-		return
-	}
-	if r.ReconcileCacheBaseName == "" || r.ReconcileCacheNamespace == "" {
-		return
-	}
-	if err := utils.SaveReconcileCacheSharded(
-		context.Background(),
-		r.Client,
-		r.ReconcileCacheNamespace,
-		r.ReconcileCacheBaseName,
-		r.ReconcileCacheShards,
-		cacheCopyEntries,
-	); err != nil {
-		log.FromContext(context.Background()).Error(err, "failed to persist reconcile cache")
-	}
+	})
 }
 
 func NamespaceFilter(namespace string) predicate.Predicate {
@@ -1611,7 +2647,11 @@ func NamespaceFilter(namespace string) predicate.Predicate {
 }
 
 func (r *IngressReconciler) recordWarning(ingress *networkingv1.Ingress, reason, message string) {
-	if r.Recorder == nil || ingress == nil {
+	if ingress == nil {
+		return
+	}
+	r.noteWarning(ingress, reason)
+	if r.Recorder == nil {
 		return
 	}
 	r.Recorder.Eventf(ingress, nil, "Warning", reason, "Reconcile", message)
@@ -1644,13 +2684,12 @@ func (r *IngressReconciler) logErrorRateLimited(err error, key, message string)
 	log.FromContext(context.Background()).Error(err, message)
 }
 
-func setHTTPRouteOwnerReference(httpRoute *gatewayv1.HTTPRoute, ingress *networkingv1.Ingress) {
-	if httpRoute == nil || ingress == nil {
+func setRouteOwnerReference(route client.Object, ingress *networkingv1.Ingress, blockOwnerDeletion bool) {
+	if route == nil || ingress == nil {
 		return
 	}
 	controller := true
-	blockOwnerDeletion := false
-	httpRoute.OwnerReferences = []metav1.OwnerReference{
+	route.SetOwnerReferences([]metav1.OwnerReference{
 		{
 			APIVersion:         ingress.APIVersion,
 			Kind:               ingress.Kind,
@@ -1659,7 +2698,7 @@ func setHTTPRouteOwnerReference(httpRoute *gatewayv1.HTTPRoute, ingress *network
 			Controller:         &controller,
 			BlockOwnerDeletion: &blockOwnerDeletion,
 		},
-	}
+	})
 }
 
 func hasHostnames(ingress *networkingv1.Ingress) bool {
@@ -0,0 +1,52 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetGatewayNameForNamespace(t *testing.T) {
+	r := &IngressReconciler{GatewayName: "tenant"}
+	if got := r.getGatewayNameForNamespace("team-a"); got != "tenant-team-a" {
+		t.Errorf("short namespace: got %q, want tenant-team-a", got)
+	}
+
+	fits := strings.Repeat("n", maxGatewayNameLength-len("tenant-"))
+	if got := r.getGatewayNameForNamespace(fits); got != "tenant-"+fits {
+		t.Errorf("namespace at the limit: got %q, want it unchanged", got)
+	}
+
+	prefix := strings.Repeat("platform-shared-services-", 3)
+	first := r.getGatewayNameForNamespace(prefix + "payments")
+	second := r.getGatewayNameForNamespace(prefix + "checkout")
+	for _, name := range []string{first, second} {
+		if len(name) > maxGatewayNameLength {
+			t.Errorf("%q is longer than %d characters", name, maxGatewayNameLength)
+		}
+		if !strings.HasPrefix(name, "tenant-platform-shared-services-") {
+			t.Errorf("%q lost the readable prefix", name)
+		}
+	}
+	if first == second {
+		t.Errorf("namespaces sharing a long prefix map to the same Gateway %q", first)
+	}
+	if again := r.getGatewayNameForNamespace(prefix + "payments"); again != first {
+		t.Errorf("name is not stable: %q then %q", first, again)
+	}
+}
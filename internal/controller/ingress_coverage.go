@@ -0,0 +1,90 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// UntranslatedAnnotation holds the annotations and spec fields of a source Ingress that its Gateway API
+// resources do not carry over, as a JSON list of {field, reason}
+const UntranslatedAnnotation = "ingress-doperator.fiction.si/untranslated"
+
+// syncUntranslatedAnnotation records what the translation of an Ingress left out in the untranslated
+// annotation: ingress-nginx annotations without equivalent, spec fields translated approximately and
+// whatever the snippets-lost annotation lists. Anything left out is also recorded as a warning.
+func (r *IngressReconciler) syncUntranslatedAnnotation(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	implementationSpecific translator.ImplementationSpecificPolicy,
+	regexPathMatchSupported bool,
+) {
+	features := utils.UntranslatedIngressFeatures(ingress, implementationSpecific, regexPathMatchSupported)
+	if lost := ingress.Annotations[SnippetsLostAnnotation]; lost != "" {
+		for _, entry := range strings.Split(lost, ",") {
+			features = append(features, utils.UntranslatedFeature{Field: entry,
+				Reason: "needs a SnippetsFilter, snippets are disabled or unsupported by the data plane"})
+		}
+	}
+	metrics.UntranslatedFeatures.WithLabelValues(ingress.Namespace, ingress.Name).Set(float64(len(features)))
+
+	if len(features) > 0 {
+		fields := make([]string, 0, len(features))
+		for _, feature := range features {
+			fields = append(fields, feature.Field)
+		}
+		r.recordWarning(ingress, "UntranslatedFeatures",
+			fmt.Sprintf("Not carried over to Gateway API, see the %s annotation: %s",
+				UntranslatedAnnotation, strings.Join(fields, ", ")))
+	}
+
+	desired := ""
+	if len(features) > 0 {
+		data, err := json.Marshal(features)
+		if err != nil {
+			return
+		}
+		desired = string(data)
+	}
+	if ingress.Annotations[UntranslatedAnnotation] == desired {
+		return
+	}
+	patchBase := client.MergeFrom(ingress.DeepCopy())
+	if desired == "" {
+		delete(ingress.Annotations, UntranslatedAnnotation)
+	} else {
+		if ingress.Annotations == nil {
+			ingress.Annotations = map[string]string{}
+		}
+		ingress.Annotations[UntranslatedAnnotation] = desired
+	}
+	if err := r.Patch(ctx, ingress, patchBase); err != nil && !apierrors.IsNotFound(err) {
+		log.FromContext(ctx).Error(err, "failed to update untranslated annotation on Ingress")
+	}
+}
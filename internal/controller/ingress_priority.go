@@ -0,0 +1,107 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ingressPendingPriority is used for Ingresses that were never reconciled or changed since
+	ingressPendingPriority = 0
+	// ingressMigratedPriority is used for Ingresses whose last reconcile is still current
+	ingressMigratedPriority = handler.LowPriority
+)
+
+// initialSyncPriority ranks an Ingress from the initial list using the reconcile cache so
+// that un-migrated or drifted Ingresses are processed before already migrated ones
+func (r *IngressReconciler) initialSyncPriority(ctx context.Context, obj client.Object) int {
+	ingress, ok := obj.(*networkingv1.Ingress)
+	if !ok {
+		return ingressPendingPriority
+	}
+	if r.shouldSkipReconcile(ctx, ingress) {
+		return ingressMigratedPriority
+	}
+	return ingressPendingPriority
+}
+
+// ingressEventHandler enqueues Ingresses like EnqueueRequestForObject, but when the priority
+// queue is in use the initial full sync is ordered by initialSyncPriority
+func (r *IngressReconciler) ingressEventHandler() handler.EventHandler {
+	enqueue := func(q workqueue.TypedRateLimitingInterface[reconcile.Request], obj client.Object) {
+		if obj == nil {
+			return
+		}
+		q.Add(reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+		}})
+	}
+
+	return handler.Funcs{
+		CreateFunc: func(
+			ctx context.Context,
+			e event.CreateEvent,
+			q workqueue.TypedRateLimitingInterface[reconcile.Request],
+		) {
+			pq, isPriorityQueue := q.(priorityqueue.PriorityQueue[reconcile.Request])
+			if !isPriorityQueue || !e.IsInInitialList || e.Object == nil {
+				enqueue(q, e.Object)
+				return
+			}
+			pq.AddWithOpts(
+				priorityqueue.AddOpts{Priority: ptr.To(r.initialSyncPriority(ctx, e.Object))},
+				reconcile.Request{NamespacedName: types.NamespacedName{
+					Namespace: e.Object.GetNamespace(),
+					Name:      e.Object.GetName(),
+				}},
+			)
+		},
+		UpdateFunc: func(
+			_ context.Context,
+			e event.UpdateEvent,
+			q workqueue.TypedRateLimitingInterface[reconcile.Request],
+		) {
+			enqueue(q, e.ObjectNew)
+		},
+		DeleteFunc: func(
+			_ context.Context,
+			e event.DeleteEvent,
+			q workqueue.TypedRateLimitingInterface[reconcile.Request],
+		) {
+			enqueue(q, e.Object)
+		},
+		GenericFunc: func(
+			_ context.Context,
+			e event.GenericEvent,
+			q workqueue.TypedRateLimitingInterface[reconcile.Request],
+		) {
+			enqueue(q, e.Object)
+		},
+	}
+}
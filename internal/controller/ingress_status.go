@@ -0,0 +1,224 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// IngressStatusReconciler points status.loadBalancer of disabled Ingresses at the addresses of the
+// Gateways their HTTPRoutes attach to, so tooling reading the Ingress status keeps working
+type IngressStatusReconciler struct {
+	client.Client
+	// ClearWithoutAddress clears the status while none of the Gateways has an address yet
+	ClearWithoutAddress bool
+}
+
+// Reconcile copies the Gateway addresses into the status of a disabled Ingress
+func (r *IngressStatusReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	ingress := &networkingv1.Ingress{}
+	if err := r.Get(ctx, req.NamespacedName, ingress); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !ingressDisabled(ingress) || !ingress.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	routes := &gatewayv1.HTTPRouteList{}
+	if err := r.List(ctx, routes, client.InNamespace(ingress.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+	gateways := make(map[types.NamespacedName]struct{})
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		if !utils.IsManagedByUsForIngress(route, ingress.Namespace, ingress.Name) ||
+			route.Annotations[translator.SourceClusterAnnotation] != "" {
+			continue
+		}
+		for _, gateway := range routeGateways(route) {
+			gateways[gateway] = struct{}{}
+		}
+	}
+
+	desired, err := r.gatewayLoadBalancerStatus(ctx, gateways)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(desired) == 0 && !r.ClearWithoutAddress {
+		return ctrl.Result{}, nil
+	}
+	if equality.Semantic.DeepEqual(ingress.Status.LoadBalancer.Ingress, desired) {
+		return ctrl.Result{}, nil
+	}
+
+	updated := ingress.DeepCopy()
+	updated.Status.LoadBalancer.Ingress = desired
+	if err := r.Status().Update(ctx, updated); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update Ingress status.loadBalancer: %w", err)
+	}
+	logger.Info("Pointed Ingress status at its Gateways",
+		"namespace", ingress.Namespace,
+		"name", ingress.Name,
+		"addresses", len(desired))
+	return ctrl.Result{}, nil
+}
+
+// gatewayLoadBalancerStatus returns the IP and hostname addresses of the Gateways in a stable order
+func (r *IngressStatusReconciler) gatewayLoadBalancerStatus(
+	ctx context.Context,
+	gateways map[types.NamespacedName]struct{},
+) ([]networkingv1.IngressLoadBalancerIngress, error) {
+	keys := make([]types.NamespacedName, 0, len(gateways))
+	for key := range gateways {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	seen := make(map[string]bool)
+	var status []networkingv1.IngressLoadBalancerIngress
+	for _, key := range keys {
+		gateway := &gatewayv1.Gateway{}
+		if err := r.Get(ctx, key, gateway); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, address := range gateway.Status.Addresses {
+			var entry networkingv1.IngressLoadBalancerIngress
+			switch {
+			case address.Type == nil || *address.Type == gatewayv1.IPAddressType:
+				entry.IP = address.Value
+			case *address.Type == gatewayv1.HostnameAddressType:
+				entry.Hostname = address.Value
+			default:
+				continue
+			}
+			if key := entry.IP + "/" + entry.Hostname; !seen[key] {
+				seen[key] = true
+				status = append(status, entry)
+			}
+		}
+	}
+	return status, nil
+}
+
+// routeGateways returns the Gateways an HTTPRoute attaches to
+func routeGateways(route *gatewayv1.HTTPRoute) []types.NamespacedName {
+	var gateways []types.NamespacedName
+	for _, ref := range route.Spec.ParentRefs {
+		if ref.Kind != nil && *ref.Kind != "Gateway" {
+			continue
+		}
+		namespace := route.Namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		gateways = append(gateways, types.NamespacedName{Namespace: namespace, Name: string(ref.Name)})
+	}
+	return gateways
+}
+
+// ingressDisabled reports whether the Ingress was disabled by switching its class
+func ingressDisabled(ingress *networkingv1.Ingress) bool {
+	return ingress.Annotations[IngressDisabledAnnotation] == IngressDisabledReasonNormal
+}
+
+// enqueueIngressesForGateway requeues the local Ingresses with HTTPRoutes attached to a Gateway
+func (r *IngressStatusReconciler) enqueueIngressesForGateway(ctx context.Context, obj client.Object) []reconcile.Request {
+	routes := &gatewayv1.HTTPRouteList{}
+	if err := r.List(ctx, routes); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list HTTPRoutes for Gateway", "gateway", obj.GetName())
+		return nil
+	}
+	gateway := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	seen := make(map[types.NamespacedName]bool)
+	var requests []reconcile.Request
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		source, ok := routeSourceIngress(route)
+		if !ok || seen[source] || !slices.Contains(routeGateways(route), gateway) {
+			continue
+		}
+		seen[source] = true
+		requests = append(requests, reconcile.Request{NamespacedName: source})
+	}
+	return requests
+}
+
+// enqueueIngressForHTTPRoute requeues the local Ingress an HTTPRoute was generated for
+func (r *IngressStatusReconciler) enqueueIngressForHTTPRoute(_ context.Context, obj client.Object) []reconcile.Request {
+	route, ok := obj.(*gatewayv1.HTTPRoute)
+	if !ok {
+		return nil
+	}
+	source, ok := routeSourceIngress(route)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: source}}
+}
+
+// routeSourceIngress returns the local Ingress a managed HTTPRoute was generated for
+func routeSourceIngress(route *gatewayv1.HTTPRoute) (types.NamespacedName, bool) {
+	if route.Annotations[translator.ManagedByAnnotation] != translator.ManagedByValue ||
+		route.Annotations[translator.SourceClusterAnnotation] != "" {
+		return types.NamespacedName{}, false
+	}
+	namespace, name, ok := strings.Cut(route.Annotations[translator.SourceAnnotation], "/")
+	if !ok || namespace != route.Namespace {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, true
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *IngressStatusReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&networkingv1.Ingress{}, ctrlbuilder.WithPredicates(
+			predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				ingress, ok := obj.(*networkingv1.Ingress)
+				return ok && ingressDisabled(ingress)
+			}))).
+		Watches(&gatewayv1.Gateway{}, handler.EnqueueRequestsFromMapFunc(r.enqueueIngressesForGateway)).
+		Watches(&gatewayv1.HTTPRoute{}, handler.EnqueueRequestsFromMapFunc(r.enqueueIngressForHTTPRoute),
+			ctrlbuilder.WithPredicates(ManagedByIngressDoperatorPredicate())).
+		Named("ingressstatus").
+		Complete(r)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// intentScope records the destructive operations on Ingresses of one cluster in the intent log
+type intentScope struct {
+	log     *utils.IntentLog
+	cluster string
+}
+
+// run performs a destructive operation between a write-ahead intent entry and its finish mark.
+// The operation does not start when the intent cannot be recorded.
+func (s intentScope) run(
+	ctx context.Context,
+	operation utils.IntentOperation,
+	ingress *networkingv1.Ingress,
+	fn func() error,
+) error {
+	id, err := s.log.Begin(ctx, operation, fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name), s.cluster)
+	if err != nil {
+		return err
+	}
+	opErr := fn()
+	if err := s.log.Finish(ctx, id, opErr); err != nil {
+		log.FromContext(ctx).Error(err, "failed to mark intent finished", "intent", id)
+	}
+	return opErr
+}
+
+// intents returns the intent scope of the cluster the reconciler reads Ingresses from
+func (r *IngressReconciler) intents() intentScope {
+	return intentScope{log: r.IntentLog, cluster: r.fanInSourceName()}
+}
+
+// IntentRecovery reports destructive operations a previous run started but never finished and,
+// with Resume set, carries them out. It runs once on the leader after the caches are synced.
+type IntentRecovery struct {
+	IntentLog *utils.IntentLog
+	// Reconcilers are the Ingress reconcilers of the local cluster and every fan-in source
+	Reconcilers []*IngressReconciler
+	Resume      bool
+}
+
+// Start implements manager.Runnable
+func (i *IntentRecovery) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("intent-recovery")
+	intents, err := i.IntentLog.Incomplete(ctx)
+	if err != nil {
+		logger.Error(err, "failed to read intent log")
+		return nil
+	}
+	metrics.IncompleteIntents.Set(float64(len(intents)))
+	for _, intent := range intents {
+		logger.Info("Found destructive operation that was started but never finished",
+			"intent", intent.ID,
+			"operation", intent.Operation,
+			"target", intent.Target,
+			"cluster", intent.Cluster,
+			"age", time.Since(intent.StartedAt).Round(time.Second).String())
+	}
+	if !i.Resume {
+		return nil
+	}
+
+	remaining := len(intents)
+	for _, intent := range intents {
+		r := i.reconcilerFor(intent.Cluster)
+		if r == nil {
+			logger.Info("Not resuming intent of unknown cluster", "intent", intent.ID, "cluster", intent.Cluster)
+			continue
+		}
+		if err := r.resumeIntent(ctx, intent); err != nil {
+			logger.Error(err, "failed to resume intent", "intent", intent.ID, "operation", intent.Operation,
+				"target", intent.Target)
+			continue
+		}
+		if err := i.IntentLog.Finish(ctx, intent.ID, nil); err != nil {
+			logger.Error(err, "failed to mark resumed intent finished", "intent", intent.ID)
+			continue
+		}
+		remaining--
+		logger.Info("Resumed interrupted operation", "intent", intent.ID, "operation", intent.Operation,
+			"target", intent.Target)
+	}
+	metrics.IncompleteIntents.Set(float64(remaining))
+	return nil
+}
+
+func (i *IntentRecovery) reconcilerFor(cluster string) *IngressReconciler {
+	for _, r := range i.Reconcilers {
+		if r.fanInSourceName() == cluster {
+			return r
+		}
+	}
+	return nil
+}
+
+// resumeIntent carries out an interrupted operation again; each operation is idempotent and
+// treats an Ingress that is gone as done
+func (r *IngressReconciler) resumeIntent(ctx context.Context, intent utils.Intent) error {
+	namespace, name, ok := strings.Cut(intent.Target, "/")
+	if !ok || namespace == "" || name == "" {
+		return fmt.Errorf("invalid intent target %q", intent.Target)
+	}
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+
+	switch intent.Operation {
+	case utils.IntentDeleteHTTPRoutes:
+		if err := r.deleteManagedHTTPRoutes(ctx, ingress, log.FromContext(ctx)); err != nil {
+			return err
+		}
+		return r.applyGRPCRoutes(ctx, ingress, nil)
+	case utils.IntentDisableExternalDNS:
+		return disableExternalDNS(ctx, r.Client, r.intents(), ingress)
+	case utils.IntentDeleteIngress, utils.IntentDisableIngress:
+		if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, ingress); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if intent.Operation == utils.IntentDisableIngress {
+			return r.disableIngress(ctx, ingress)
+		}
+		return r.removeIngress(ctx, ingress)
+	default:
+		return fmt.Errorf("unknown intent operation %q", intent.Operation)
+	}
+}
@@ -0,0 +1,321 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// MigrationAPIIngressesPath serves the migration state of every Ingress
+	MigrationAPIIngressesPath = "/migrations/ingresses"
+	// MigrationAPINamespacesPath serves the migration state counts per namespace
+	MigrationAPINamespacesPath = "/migrations/namespaces"
+
+	migrationAPIDefaultLimit = 500
+	migrationAPIMaxLimit     = 5000
+)
+
+// MigrationState is where an Ingress stands in its migration to Gateway API
+type MigrationState string
+
+const (
+	// MigrationStateUnmanaged is an Ingress outside of the class filters and selectors
+	MigrationStateUnmanaged MigrationState = "unmanaged"
+	// MigrationStateIgnored is an Ingress carrying the ignore annotation
+	MigrationStateIgnored MigrationState = "ignored"
+	// MigrationStatePaused is an Ingress carrying the paused annotation
+	MigrationStatePaused MigrationState = "paused"
+	// MigrationStatePending is an Ingress that was not reconciled yet
+	MigrationStatePending MigrationState = "pending"
+	// MigrationStateFailed is an Ingress whose last reconcile failed
+	MigrationStateFailed MigrationState = "failed"
+	// MigrationStateTranslated is an Ingress with its Gateway API resources in place that still serves traffic
+	MigrationStateTranslated MigrationState = "translated"
+	// MigrationStateMigrated is an Ingress that was disabled or removed after its translation
+	MigrationStateMigrated MigrationState = "migrated"
+)
+
+// IngressMigrationState is the migration state of one Ingress
+type IngressMigrationState struct {
+	Namespace    string         `json:"namespace"`
+	Name         string         `json:"name"`
+	IngressClass string         `json:"ingressClass,omitempty"`
+	State        MigrationState `json:"state"`
+	Message      string         `json:"message,omitempty"`
+	Hostnames    []string       `json:"hostnames,omitempty"`
+	// Since is when the last reconcile changed its outcome
+	Since *metav1.Time `json:"since,omitempty"`
+}
+
+// IngressMigrationStateList is a page of Ingress migration states, Continue requests the next one
+type IngressMigrationStateList struct {
+	Items    []IngressMigrationState `json:"items"`
+	Continue string                  `json:"continue,omitempty"`
+}
+
+// NamespaceMigrationState counts the Ingresses of a namespace by migration state
+type NamespaceMigrationState struct {
+	Namespace string                 `json:"namespace"`
+	States    map[MigrationState]int `json:"states"`
+	// Complete is true once the namespace has migrated Ingresses and every other one is ignored or unmanaged
+	Complete bool `json:"complete"`
+}
+
+// NamespaceMigrationStateList lists the namespaces with Ingresses
+type NamespaceMigrationStateList struct {
+	Items []NamespaceMigrationState `json:"items"`
+}
+
+// ingressMigrationState derives the migration state of an Ingress from its annotations
+func (r *IngressReconciler) ingressMigrationState(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+) IngressMigrationState {
+	source := ingress
+	if ingressDisabled(ingress) {
+		source = withOriginalSpec(ingress)
+	}
+	state := IngressMigrationState{
+		Namespace:    ingress.Namespace,
+		Name:         ingress.Name,
+		IngressClass: r.getIngressClass(source),
+		Hostnames:    ingressHosts(source, r.TLSOnlyHosts.Serves()),
+	}
+	var conditions []metav1.Condition
+	if raw := ingress.Annotations[ConditionsAnnotation]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &conditions); err != nil {
+			conditions = nil
+		}
+	}
+	reconciled := meta.FindStatusCondition(conditions, ConditionReconciled)
+	if reconciled != nil {
+		state.Since = &reconciled.LastTransitionTime
+	}
+
+	switch {
+	case IngressIgnored(ingress):
+		state.State = MigrationStateIgnored
+	case !r.shouldEnqueueIngressByClass(source):
+		state.State = MigrationStateUnmanaged
+	case r.namespaceOptedOut(ctx, ingress) != "":
+		state.State = MigrationStateUnmanaged
+		state.Message = "namespace opted out"
+	case IngressPaused(ingress):
+		state.State = MigrationStatePaused
+	case ingressDisabled(ingress):
+		state.State = MigrationStateMigrated
+		state.Message = "disabled"
+	case ingress.Annotations[IngressDisabledAnnotation] == IngressDisabledReasonExternalDNS:
+		state.State = MigrationStateMigrated
+		state.Message = "external-dns disabled"
+	case ingress.Annotations[IngressRemovedAnnotation] == fmt.Sprintf("%t", true):
+		state.State = MigrationStateMigrated
+		state.Message = "removed"
+	case reconciled == nil:
+		state.State = MigrationStatePending
+	case reconciled.Status != metav1.ConditionTrue:
+		state.State = MigrationStateFailed
+		state.Message = reconciled.Message
+	default:
+		state.State = MigrationStateTranslated
+	}
+	return state
+}
+
+// listMigrationStates returns the migration states of all Ingresses sorted by namespace and name
+func (r *IngressReconciler) listMigrationStates(
+	ctx context.Context,
+	namespace string,
+) ([]IngressMigrationState, error) {
+	list := &networkingv1.IngressList{}
+	opts := []client.ListOption{}
+	if r.WatchNamespace != "" {
+		if namespace != "" && namespace != r.WatchNamespace {
+			return nil, nil
+		}
+		namespace = r.WatchNamespace
+	}
+	if namespace != "" {
+		opts = append(opts, client.InNamespace(namespace))
+	}
+	if err := r.List(ctx, list, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list Ingresses: %w", err)
+	}
+	states := make([]IngressMigrationState, 0, len(list.Items))
+	for i := range list.Items {
+		states = append(states, r.ingressMigrationState(ctx, &list.Items[i]))
+	}
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].Namespace != states[j].Namespace {
+			return states[i].Namespace < states[j].Namespace
+		}
+		return states[i].Name < states[j].Name
+	})
+	return states, nil
+}
+
+// MigrationAPI serves the migration state of the Ingresses as read-only JSON, so dashboards do not need
+// to list every Ingress and derive it themselves. It reads from the informer cache.
+type MigrationAPI struct {
+	Reconciler *IngressReconciler
+}
+
+// Handlers returns the handlers of the API by path
+func (a *MigrationAPI) Handlers() map[string]http.Handler {
+	return map[string]http.Handler{
+		MigrationAPIIngressesPath:  http.HandlerFunc(a.serveIngresses),
+		MigrationAPINamespacesPath: http.HandlerFunc(a.serveNamespaces),
+	}
+}
+
+// serveIngresses lists Ingress migration states. Query parameters: namespace, state and ingressClass
+// (comma-separated), hostname, limit (default 500) and continue (from the previous page).
+func (a *MigrationAPI) serveIngresses(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	query := req.URL.Query()
+	limit := migrationAPIDefaultLimit
+	if raw := query.Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			http.Error(w, fmt.Sprintf("invalid limit %q", raw), http.StatusBadRequest)
+			return
+		}
+		limit = min(parsed, migrationAPIMaxLimit)
+	}
+	after := ""
+	if raw := query.Get("continue"); raw != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(raw)
+		if err != nil {
+			http.Error(w, "invalid continue token", http.StatusBadRequest)
+			return
+		}
+		after = string(decoded)
+	}
+	matchStates := splitQueryValues(query.Get("state"))
+	matchClasses := splitQueryValues(query.Get("ingressClass"))
+	hostname := query.Get("hostname")
+
+	states, err := a.Reconciler.listMigrationStates(req.Context(), query.Get("namespace"))
+	if err != nil {
+		log.FromContext(req.Context()).Error(err, "failed to serve Ingress migration states")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	page := IngressMigrationStateList{Items: []IngressMigrationState{}}
+	for _, state := range states {
+		key := state.Namespace + "/" + state.Name
+		if key <= after {
+			continue
+		}
+		if len(matchStates) > 0 && !matchStates[string(state.State)] {
+			continue
+		}
+		if len(matchClasses) > 0 && !matchClasses[state.IngressClass] {
+			continue
+		}
+		if hostname != "" && !slices.Contains(state.Hostnames, hostname) {
+			continue
+		}
+		if len(page.Items) == limit {
+			last := page.Items[len(page.Items)-1]
+			page.Continue = base64.RawURLEncoding.EncodeToString([]byte(last.Namespace + "/" + last.Name))
+			break
+		}
+		page.Items = append(page.Items, state)
+	}
+	writeJSON(w, page)
+}
+
+// serveNamespaces counts Ingress migration states per namespace. Query parameter: namespace.
+func (a *MigrationAPI) serveNamespaces(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	states, err := a.Reconciler.listMigrationStates(req.Context(), req.URL.Query().Get("namespace"))
+	if err != nil {
+		log.FromContext(req.Context()).Error(err, "failed to serve namespace migration states")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	list := NamespaceMigrationStateList{Items: []NamespaceMigrationState{}}
+	for _, state := range states {
+		if len(list.Items) == 0 || list.Items[len(list.Items)-1].Namespace != state.Namespace {
+			list.Items = append(list.Items, NamespaceMigrationState{
+				Namespace: state.Namespace,
+				States:    map[MigrationState]int{},
+			})
+		}
+		list.Items[len(list.Items)-1].States[state.State]++
+	}
+	for i := range list.Items {
+		list.Items[i].Complete = namespaceMigrationComplete(list.Items[i].States)
+	}
+	writeJSON(w, list)
+}
+
+// namespaceMigrationComplete reports whether a namespace with these state counts is fully migrated
+func namespaceMigrationComplete(states map[MigrationState]int) bool {
+	for state, count := range states {
+		switch state {
+		case MigrationStateMigrated, MigrationStateIgnored, MigrationStateUnmanaged:
+		default:
+			if count > 0 {
+				return false
+			}
+		}
+	}
+	return states[MigrationStateMigrated] > 0
+}
+
+func splitQueryValues(raw string) map[string]bool {
+	if raw == "" {
+		return nil
+	}
+	values := make(map[string]bool)
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values[value] = true
+		}
+	}
+	return values
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
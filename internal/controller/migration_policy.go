@@ -0,0 +1,316 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	doperatorv1alpha1 "github.com/fiksn/ingress-doperator/api/v1alpha1"
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+)
+
+const (
+	// MigrationPolicyConditionAccepted reports whether the IngressMigrationPolicy is in effect
+	MigrationPolicyConditionAccepted = "Accepted"
+)
+
+// MigrationSettings are the settings an IngressMigrationPolicy changes without a restart
+type MigrationSettings struct {
+	IncludeNamespaces         []string
+	ExcludeNamespaces         []string
+	OneGatewayPerIngress      bool
+	OneGatewayPerNamespace    bool
+	HostnameRewriteFrom       string
+	HostnameRewriteTo         string
+	IngressPostProcessingMode IngressPostProcessingMode
+	// RolloutPercentage is the share of eligible Ingresses migrated, 100 migrates all of them
+	RolloutPercentage int
+}
+
+// namespaceAllowed reports whether Ingresses of namespace are migrated
+func (s MigrationSettings) namespaceAllowed(namespace string) bool {
+	if slices.Contains(s.ExcludeNamespaces, namespace) {
+		return false
+	}
+	return len(s.IncludeNamespaces) == 0 || slices.Contains(s.IncludeNamespaces, namespace)
+}
+
+// rolloutAllowed reports whether the Ingress is within the rollout percentage. The bucket of an Ingress
+// never changes, so raising the percentage only adds Ingresses.
+func (s MigrationSettings) rolloutAllowed(cluster, namespace, name string) bool {
+	return s.RolloutPercentage >= 100 || RolloutBucket(cluster, namespace, name) < s.RolloutPercentage
+}
+
+// RolloutBucket returns the place of an Ingress in the rollout order, 0 to 99. The Ingress is migrated
+// once the rollout percentage is above it.
+func RolloutBucket(cluster, namespace, name string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(cluster + "/" + namespace + "/" + name))
+	return int(h.Sum32() % 100)
+}
+
+// MigrationPolicy holds the settings of the IngressMigrationPolicy the operator follows. Until the
+// policy exists, or after it is deleted, the command line flags in Defaults apply.
+type MigrationPolicy struct {
+	Name     string
+	Defaults MigrationSettings
+	// SharedGatewayOnly names the flags that need a shared Gateway, so the policy may not change the
+	// gateway strategy. Empty when any strategy is fine.
+	SharedGatewayOnly string
+	// NoGatewayPerIngress names the flags ruling out the per-ingress gateway strategy
+	NoGatewayPerIngress string
+
+	mu      sync.RWMutex
+	current *MigrationSettings
+	events  []chan event.GenericEvent
+}
+
+// NewMigrationPolicy creates the runtime settings of the IngressMigrationPolicy called name
+func NewMigrationPolicy(name string, defaults MigrationSettings) *MigrationPolicy {
+	return &MigrationPolicy{Name: name, Defaults: defaults}
+}
+
+// Settings returns the settings in effect
+func (p *MigrationPolicy) Settings() MigrationSettings {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.current == nil {
+		return p.Defaults
+	}
+	return *p.current
+}
+
+// set replaces the settings in effect, nil restores the defaults, and requeues every Ingress when
+// they changed
+func (p *MigrationPolicy) set(settings *MigrationSettings) bool {
+	p.mu.Lock()
+	previous := p.Defaults
+	if p.current != nil {
+		previous = *p.current
+	}
+	p.current = settings
+	next := p.Defaults
+	if settings != nil {
+		next = *settings
+	}
+	events := p.events
+	p.mu.Unlock()
+
+	if reflect.DeepEqual(previous, next) {
+		return false
+	}
+	metrics.RolloutPercentage.Set(float64(next.RolloutPercentage))
+	for _, ch := range events {
+		// The controllers may not consume events yet, never block the reconcile
+		go func() { ch <- event.GenericEvent{Object: &doperatorv1alpha1.IngressMigrationPolicy{}} }()
+	}
+	return true
+}
+
+// requeueEvents returns a channel signalling that the settings changed, one per Ingress controller
+func (p *MigrationPolicy) requeueEvents() chan event.GenericEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ch := make(chan event.GenericEvent, 1)
+	p.events = append(p.events, ch)
+	return ch
+}
+
+// resolve applies spec on top of the defaults
+func (p *MigrationPolicy) resolve(spec *doperatorv1alpha1.IngressMigrationPolicySpec) (MigrationSettings, error) {
+	settings := p.Defaults
+	if spec.Namespaces != nil {
+		settings.IncludeNamespaces = spec.Namespaces.Include
+		settings.ExcludeNamespaces = spec.Namespaces.Exclude
+	}
+
+	if spec.GatewayStrategy != "" {
+		if p.SharedGatewayOnly != "" && spec.GatewayStrategy != doperatorv1alpha1.GatewayStrategyShared {
+			return settings, fmt.Errorf("gatewayStrategy %q cannot be combined with %s",
+				spec.GatewayStrategy, p.SharedGatewayOnly)
+		}
+		switch spec.GatewayStrategy {
+		case doperatorv1alpha1.GatewayStrategyShared:
+			settings.OneGatewayPerIngress, settings.OneGatewayPerNamespace = false, false
+		case doperatorv1alpha1.GatewayStrategyPerNamespace:
+			settings.OneGatewayPerIngress, settings.OneGatewayPerNamespace = false, true
+		case doperatorv1alpha1.GatewayStrategyPerIngress:
+			if p.NoGatewayPerIngress != "" {
+				return settings, fmt.Errorf("gatewayStrategy %q cannot be combined with %s",
+					spec.GatewayStrategy, p.NoGatewayPerIngress)
+			}
+			settings.OneGatewayPerIngress, settings.OneGatewayPerNamespace = true, false
+		default:
+			return settings, fmt.Errorf("invalid gatewayStrategy %q (allowed: shared, per-namespace, per-ingress)",
+				spec.GatewayStrategy)
+		}
+	}
+
+	if rewrite := spec.HostnameRewrite; rewrite != nil {
+		if rewrite.From == "" || rewrite.To == "" {
+			return settings, fmt.Errorf("hostnameRewrite needs both from and to")
+		}
+		if len(strings.Split(rewrite.From, ",")) != len(strings.Split(rewrite.To, ",")) {
+			return settings, fmt.Errorf("hostnameRewrite from and to must have same number of items")
+		}
+		settings.HostnameRewriteFrom = rewrite.From
+		settings.HostnameRewriteTo = rewrite.To
+	}
+
+	switch IngressPostProcessingMode(spec.IngressPostProcessing) {
+	case "":
+	case IngressPostProcessingModeNone, IngressPostProcessingModeDisable, IngressPostProcessingModeRemove,
+		IngressPostProcessingModeDisableExternalDNS:
+		settings.IngressPostProcessingMode = IngressPostProcessingMode(spec.IngressPostProcessing)
+	default:
+		return settings, fmt.Errorf("invalid ingressPostProcessing %q "+
+			"(allowed: none, disable, remove, disable-external-dns)", spec.IngressPostProcessing)
+	}
+
+	if percentage := spec.RolloutPercentage; percentage != nil {
+		if *percentage < 0 || *percentage > 100 {
+			return settings, fmt.Errorf("invalid rolloutPercentage %d (allowed: 0 to 100)", *percentage)
+		}
+		settings.RolloutPercentage = int(*percentage)
+	}
+	return settings, nil
+}
+
+// MigrationPolicyReconciler loads the IngressMigrationPolicy into the running controllers
+type MigrationPolicyReconciler struct {
+	client.Client
+	Policy *MigrationPolicy
+}
+
+// Reconcile applies the IngressMigrationPolicy, or falls back to the flags once it is gone
+func (r *MigrationPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	if req.Name != r.Policy.Name {
+		return ctrl.Result{}, nil
+	}
+
+	policy := &doperatorv1alpha1.IngressMigrationPolicy{}
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		if apierrors.IsNotFound(err) {
+			if r.Policy.set(nil) {
+				logger.Info("IngressMigrationPolicy removed, falling back to command line flags", "name", req.Name)
+			}
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	condition := metav1.Condition{
+		Type:               MigrationPolicyConditionAccepted,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Applied",
+		Message:            "Settings are in effect",
+		ObservedGeneration: policy.Generation,
+	}
+	settings, err := r.Policy.resolve(&policy.Spec)
+	if err != nil {
+		// The last valid settings stay in effect
+		logger.Error(err, "invalid IngressMigrationPolicy, keeping the previous settings", "name", policy.Name)
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Invalid"
+		condition.Message = err.Error()
+	} else if r.Policy.set(&settings) {
+		logger.Info("Applied IngressMigrationPolicy, requeueing all Ingresses", "name", policy.Name,
+			"generation", policy.Generation)
+	}
+
+	if meta.SetStatusCondition(&policy.Status.Conditions, condition) ||
+		policy.Status.ObservedGeneration != policy.Generation {
+		policy.Status.ObservedGeneration = policy.Generation
+		if err := r.Status().Update(ctx, policy); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *MigrationPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&doperatorv1alpha1.IngressMigrationPolicy{}, ctrlbuilder.WithPredicates(
+			predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetName() == r.Policy.Name
+			}))).
+		Named("ingressmigrationpolicy").
+		Complete(r)
+}
+
+// settings returns the migration settings in effect, the flags when no IngressMigrationPolicy is followed
+func (r *IngressReconciler) settings() MigrationSettings {
+	if r.MigrationPolicy != nil {
+		return r.MigrationPolicy.Settings()
+	}
+	return MigrationSettings{
+		OneGatewayPerIngress:      r.OneGatewayPerIngress,
+		OneGatewayPerNamespace:    r.OneGatewayPerNamespace,
+		HostnameRewriteFrom:       r.HostnameRewriteFrom,
+		HostnameRewriteTo:         r.HostnameRewriteTo,
+		IngressPostProcessingMode: r.IngressPostProcessingMode,
+		RolloutPercentage:         r.RolloutPercentage,
+	}
+}
+
+// enqueueIngressesForSettings requeues every Ingress once the migration settings or hostname renames
+// changed, bypassing the reconcile cache
+func (r *IngressReconciler) enqueueIngressesForSettings(
+	ctx context.Context,
+	_ client.Object,
+) []reconcile.Request {
+	requests := r.enqueueAllIngresses(ctx)
+	for _, request := range requests {
+		ingress := &networkingv1.Ingress{}
+		if err := r.Get(ctx, request.NamespacedName, ingress); err == nil {
+			r.evictReconcileCache(ctx, ingress)
+		}
+	}
+	return requests
+}
+
+// settings returns the migration settings in effect, the fields when no IngressMigrationPolicy is followed
+func (r *HTTPRouteReconciler) settings() MigrationSettings {
+	if r.MigrationPolicy != nil {
+		return r.MigrationPolicy.Settings()
+	}
+	return MigrationSettings{
+		HostnameRewriteFrom:       r.HostnameRewriteFrom,
+		HostnameRewriteTo:         r.HostnameRewriteTo,
+		IngressPostProcessingMode: r.IngressPostProcessingMode,
+	}
+}
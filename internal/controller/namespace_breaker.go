@@ -0,0 +1,111 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+)
+
+// NamespaceCircuitBreaker backs off namespaces whose Ingresses keep failing so that
+// they do not monopolize the workqueue with retries
+type NamespaceCircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu     sync.Mutex
+	states map[string]*namespaceBreakerState
+}
+
+type namespaceBreakerState struct {
+	consecutiveFailures int
+	openUntil           time.Time
+}
+
+// NewNamespaceCircuitBreaker creates a breaker that opens after threshold consecutive
+// failures in a namespace and keeps it open for cooldown. A threshold <= 0 disables it.
+func NewNamespaceCircuitBreaker(threshold int, cooldown time.Duration) *NamespaceCircuitBreaker {
+	return &NamespaceCircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		states:    make(map[string]*namespaceBreakerState),
+	}
+}
+
+// Allow reports whether reconciles in the namespace may proceed. When the breaker is
+// open it also returns how long until the cooldown expires.
+func (b *NamespaceCircuitBreaker) Allow(namespace string) (bool, time.Duration) {
+	if b == nil || b.threshold <= 0 {
+		return true, 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.states[namespace]
+	if !ok || state.openUntil.IsZero() {
+		return true, 0
+	}
+	remaining := time.Until(state.openUntil)
+	if remaining > 0 {
+		return false, remaining
+	}
+	// Cooldown expired: half-open, let the next reconcile probe the namespace
+	state.openUntil = time.Time{}
+	metrics.NamespaceCircuitOpen.WithLabelValues(namespace).Set(0)
+	return true, 0
+}
+
+// RecordSuccess resets the failure count for the namespace
+func (b *NamespaceCircuitBreaker) RecordSuccess(namespace string) {
+	if b == nil || b.threshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.states[namespace]; !ok {
+		return
+	}
+	delete(b.states, namespace)
+	metrics.NamespaceCircuitOpen.WithLabelValues(namespace).Set(0)
+}
+
+// RecordFailure counts a failed reconcile and reports whether it opened the breaker
+func (b *NamespaceCircuitBreaker) RecordFailure(namespace string) bool {
+	if b == nil || b.threshold <= 0 {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.states[namespace]
+	if !ok {
+		state = &namespaceBreakerState{}
+		b.states[namespace] = state
+	}
+	state.consecutiveFailures++
+	if state.consecutiveFailures < b.threshold || !state.openUntil.IsZero() {
+		return false
+	}
+
+	state.openUntil = time.Now().Add(b.cooldown)
+	metrics.NamespaceCircuitOpen.WithLabelValues(namespace).Set(1)
+	metrics.NamespaceCircuitTripsTotal.WithLabelValues(namespace).Inc()
+	return true
+}
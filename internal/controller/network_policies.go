@@ -0,0 +1,43 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// syncNetworkPolicies admits the Gateway data plane to the backend Services of an Ingress, for
+// namespaces whose NetworkPolicies only let the Ingress controller in
+func (r *IngressReconciler) syncNetworkPolicies(ctx context.Context, ingress *networkingv1.Ingress) {
+	if !r.NetworkPolicies {
+		return
+	}
+	peer := utils.GatewayPeer{Namespace: r.GatewayNamespace, PodSelector: r.GatewayPodSelector}
+	if err := utils.SyncGatewayNetworkPolicies(
+		ctx, r.tenantClient(), r.Scheme, r.resourceOwner(ingress), ingress, peer,
+	); err != nil {
+		log.FromContext(ctx).Error(err, "failed to apply NetworkPolicies")
+		r.recordWarning(ingress, "NetworkPolicyFailed",
+			fmt.Sprintf("Unable to admit the Gateway to the backend Services: %v", err))
+	}
+}
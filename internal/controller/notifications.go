@@ -0,0 +1,117 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// handedOver reports whether the Ingress was disabled, removed or had external-dns disabled by the operator
+func handedOver(ingress *networkingv1.Ingress) bool {
+	switch ingress.Annotations[IngressDisabledAnnotation] {
+	case IngressDisabledReasonNormal, IngressDisabledReasonExternalDNS:
+		return true
+	}
+	return ingress.Annotations[IngressRemovedAnnotation] == fmt.Sprintf("%t", true)
+}
+
+// notifyNamespaceMigrated sends namespace-migrated, once per namespace, when every Ingress of the
+// namespace the operator handles is handed over. currentHandedOver is set when current was just handed
+// over, which the cache may not show yet.
+func (r *IngressReconciler) notifyNamespaceMigrated(
+	ctx context.Context,
+	current *networkingv1.Ingress,
+	currentHandedOver bool,
+) {
+	if r.Notifier == nil {
+		return
+	}
+	list := &networkingv1.IngressList{}
+	if err := r.List(ctx, list, client.InNamespace(current.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list Ingresses for namespace migration notification",
+			"namespace", current.Namespace)
+		return
+	}
+	key := r.fanInSourceName() + "/" + current.Namespace
+	for i := range list.Items {
+		ingress := &list.Items[i]
+		if (currentHandedOver && ingress.Name == current.Name) || handedOver(ingress) ||
+			IngressIgnored(ingress) ||
+			!r.shouldEnqueueIngressByClass(ingress) {
+			continue
+		}
+		return
+	}
+	if _, notified := r.migratedNamespaces.LoadOrStore(key, true); notified {
+		return
+	}
+	r.Notifier.Notify(ctx, utils.Notification{
+		Event:     utils.NotificationNamespaceMigrated,
+		Cluster:   r.fanInSourceName(),
+		Namespace: current.Namespace,
+		Message:   "Every Ingress of the namespace is handed over to the Gateway API",
+	})
+}
+
+// notifyIngressDeleted sends ingress-deleted for a source Ingress the operator deleted
+func (r *IngressReconciler) notifyIngressDeleted(ctx context.Context, ingress *networkingv1.Ingress) {
+	r.Notifier.Notify(ctx, utils.Notification{
+		Event:     utils.NotificationIngressDeleted,
+		Cluster:   r.fanInSourceName(),
+		Namespace: ingress.Namespace,
+		Name:      ingress.Name,
+		Message:   "Source Ingress deleted after its HTTPRoutes took over",
+	})
+}
+
+// notifyCertMismatches sends cert-mismatch for the mismatch entries of a Gateway that weren't reported before
+func (r *HTTPRouteReconciler) notifyCertMismatches(
+	ctx context.Context,
+	gateway client.Object,
+	previous, desired string,
+) {
+	if r.Notifier == nil || desired == "" {
+		return
+	}
+	known := make(map[string]bool)
+	for _, entry := range strings.Split(previous, ";") {
+		known[strings.TrimSpace(entry)] = true
+	}
+	var added []string
+	for _, entry := range strings.Split(desired, ";") {
+		if entry = strings.TrimSpace(entry); entry != "" && !known[entry] {
+			added = append(added, entry)
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+	r.Notifier.Notify(ctx, utils.Notification{
+		Event:     utils.NotificationCertMismatch,
+		Namespace: gateway.GetNamespace(),
+		Name:      gateway.GetName(),
+		Message:   "Listener certificate differs from the Ingress TLS Secret: " + strings.Join(added, "; "),
+	})
+}
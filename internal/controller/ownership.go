@@ -0,0 +1,115 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// OwnershipMode controls how derived resources are tied to their Ingress
+type OwnershipMode string
+
+const (
+	// OwnershipModeReferences links derived resources by ownerReferences, routes do not block the Ingress deletion
+	OwnershipModeReferences OwnershipMode = "references"
+	// OwnershipModeFinalizer additionally lets routes block the Ingress deletion, links ReferenceGrants to the
+	// Ingresses using them and holds a deleted Ingress until its derived resources are deleted in order
+	OwnershipModeFinalizer OwnershipMode = "finalizer"
+)
+
+// derivedRouteRequeue is how often a deleted Ingress checks whether its HTTPRoutes are gone
+const derivedRouteRequeue = 5 * time.Second
+
+// cleansUpOnDeletion reports whether deleted Ingresses get a finalizer and their derived resources deleted
+// or adopted
+func (r *IngressReconciler) cleansUpOnDeletion() bool {
+	return r.deletionCascade() != DeletionCascadeKeep
+}
+
+// deleteDerivedResourcesInOrder deletes the routes of an Ingress first and the resources they reference only
+// once the HTTPRoutes are gone, so the Gateway never serves a route whose SnippetsFilter or policy was deleted.
+// The HTTPRoute finalizer prunes the Gateway listeners and releases the ReferenceGrant before that.
+func (r *IngressReconciler) deleteDerivedResourcesInOrder(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	logger logr.Logger,
+) (ctrl.Result, error) {
+	if err := r.deleteManagedHTTPRoutes(ctx, ingress, logger); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.applyGRPCRoutes(ctx, ingress, nil); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.applyTLSRoutes(ctx, ingress, nil); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	routes, err := r.HTTPRouteManager.GetHTTPRoutesForIngress(ctx, ingress)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(routes) > 0 {
+		logger.Info("Waiting for HTTPRoutes to be deleted before deleting the other derived resources",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name,
+			"remaining", len(routes))
+		return ctrl.Result{RequeueAfter: derivedRouteRequeue}, nil
+	}
+
+	if err := r.deleteDerivedResources(ctx, ingress, logger); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.recordNormal(ingress, "DerivedResourcesDeleted", "Deleted the routes and other derived resources")
+	return r.finalizeDeletion(ctx, ingress)
+}
+
+// referenceGrantOwner returns a non-controller ownerReference to the Ingress of an HTTPRoute in finalizer
+// ownership mode, so a ReferenceGrant is garbage collected with the last Ingress using it. nil otherwise.
+func (r *HTTPRouteReconciler) referenceGrantOwner(httpRoute *gatewayv1.HTTPRoute) *metav1.OwnerReference {
+	if r.OwnershipMode != OwnershipModeFinalizer {
+		return nil
+	}
+	owner := metav1.GetControllerOf(httpRoute)
+	if owner == nil || owner.Kind != "Ingress" {
+		return nil
+	}
+	blockOwnerDeletion := false
+	return &metav1.OwnerReference{
+		APIVersion:         owner.APIVersion,
+		Kind:               owner.Kind,
+		Name:               owner.Name,
+		UID:                owner.UID,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	}
+}
+
+// hasOwnerReference reports whether refs already contain an ownerReference to the owner of ref
+func hasOwnerReference(refs []metav1.OwnerReference, ref *metav1.OwnerReference) bool {
+	for _, existing := range refs {
+		if existing.UID == ref.UID {
+			return true
+		}
+	}
+	return false
+}
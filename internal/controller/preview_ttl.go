@@ -0,0 +1,127 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+)
+
+const (
+	// PreviewTTLAnnotation limits how long the derived resources of an Ingress migrated with post-processing
+	// none (translate-only) are kept, as a Go duration
+	PreviewTTLAnnotation = "ingress-doperator.fiction.si/preview-ttl"
+	// PreviewSinceAnnotation is when the preview of an Ingress with a TTL started
+	PreviewSinceAnnotation = "ingress-doperator.fiction.si/preview-since"
+	// PreviewExpiredAnnotation marks an Ingress whose preview expired, it is not translated anymore
+	PreviewExpiredAnnotation = "ingress-doperator.fiction.si/preview-expired"
+)
+
+// previewExpired reports whether the translate-only preview of an Ingress expired
+func (r *IngressReconciler) previewExpired(ingress *networkingv1.Ingress) bool {
+	return ingress.Annotations[PreviewExpiredAnnotation] == fmt.Sprintf("%t", true) &&
+		ingress.Annotations[PreviewTTLAnnotation] != "" &&
+		r.resolveIngressPostProcessingMode(ingress) == IngressPostProcessingModeNone
+}
+
+// checkPreviewTTL enforces the preview-ttl annotation of a translate-only Ingress. It records when the
+// preview started, removes the derived resources once the TTL passed and returns true for an expired
+// preview, which is not translated. The remaining time is returned to requeue the Ingress at expiry. An
+// Ingress moving to full migration, or losing its TTL, drops the preview annotations and is translated again.
+func (r *IngressReconciler) checkPreviewTTL(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+) (bool, time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	value := ingress.Annotations[PreviewTTLAnnotation]
+	if value == "" || r.resolveIngressPostProcessingMode(ingress) != IngressPostProcessingModeNone {
+		r.clearPreviewAnnotations(ctx, ingress)
+		return false, 0, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		r.recordWarning(ingress, "InvalidPreviewTTL",
+			fmt.Sprintf("Annotation %s=%q is not a positive duration, the preview does not expire",
+				PreviewTTLAnnotation, value))
+		return false, 0, nil
+	}
+
+	if r.previewExpired(ingress) {
+		logger.V(1).Info("Preview of the Ingress expired, skipping reconciliation",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name)
+		metrics.IngressReconcileSkipsTotal.WithLabelValues("preview-expired", ingress.Namespace, ingress.Name).Inc()
+		return true, 0, nil
+	}
+
+	since, err := time.Parse(time.RFC3339, ingress.Annotations[PreviewSinceAnnotation])
+	if err != nil {
+		patchBase := client.MergeFrom(ingress.DeepCopy())
+		ingress.Annotations[PreviewSinceAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		if err := r.Patch(ctx, ingress, patchBase); err != nil {
+			logger.Error(err, "failed to record the preview start on Ingress")
+			return false, 0, err
+		}
+		return false, ttl, nil
+	}
+	if remaining := time.Until(since.Add(ttl)); remaining > 0 {
+		return false, remaining, nil
+	}
+
+	logger.Info("Preview of the Ingress expired, removing its derived resources",
+		"namespace", ingress.Namespace,
+		"name", ingress.Name,
+		"since", since,
+		"ttl", ttl)
+	if err := r.deleteDerivedResources(ctx, ingress, logger); err != nil {
+		return false, 0, err
+	}
+	patchBase := client.MergeFrom(ingress.DeepCopy())
+	delete(ingress.Annotations, PreviewSinceAnnotation)
+	ingress.Annotations[PreviewExpiredAnnotation] = fmt.Sprintf("%t", true)
+	if err := r.Patch(ctx, ingress, patchBase); err != nil {
+		logger.Error(err, "failed to mark the preview of the Ingress expired")
+		return false, 0, err
+	}
+	metrics.PreviewsExpiredTotal.WithLabelValues(ingress.Namespace, ingress.Name).Inc()
+	r.recordNormal(ingress, "PreviewExpired",
+		fmt.Sprintf("Derived resources removed, the preview TTL of %s expired", ttl))
+	return true, 0, nil
+}
+
+// clearPreviewAnnotations drops the preview start and expiry of an Ingress that is no preview anymore
+func (r *IngressReconciler) clearPreviewAnnotations(ctx context.Context, ingress *networkingv1.Ingress) {
+	_, hasSince := ingress.Annotations[PreviewSinceAnnotation]
+	_, hasExpired := ingress.Annotations[PreviewExpiredAnnotation]
+	if !hasSince && !hasExpired {
+		return
+	}
+	patchBase := client.MergeFrom(ingress.DeepCopy())
+	delete(ingress.Annotations, PreviewSinceAnnotation)
+	delete(ingress.Annotations, PreviewExpiredAnnotation)
+	if err := r.Patch(ctx, ingress, patchBase); err != nil {
+		log.FromContext(ctx).Error(err, "failed to clear the preview annotations on Ingress")
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// recordReadOnlyWrites reports the writes a reconcile in read-only mode dropped, which is what the operator
+// would have done for the Ingress
+func (r *IngressReconciler) recordReadOnlyWrites(ctx context.Context, ingress types.NamespacedName) {
+	if !r.ReadOnly {
+		return
+	}
+	actions := utils.DryRunActions(ctx)
+	metrics.ReadOnlyPendingWrites.WithLabelValues(ingress.Namespace, ingress.Name).Set(float64(len(actions)))
+	if len(actions) == 0 {
+		return
+	}
+	described := make([]string, 0, len(actions))
+	for _, action := range actions {
+		described = append(described, action.String())
+	}
+	log.FromContext(ctx).Info("Read only, Ingress would need writes",
+		"namespace", ingress.Namespace,
+		"name", ingress.Name,
+		"writes", described)
+}
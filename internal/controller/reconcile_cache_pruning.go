@@ -0,0 +1,46 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// reconcileCachePruneHandler evicts the reconcile cache entry of deleted Ingresses right away
+// instead of keeping it until it expires. It never enqueues anything, deletions are reconciled
+// through the regular Ingress watch.
+func (r *IngressReconciler) reconcileCachePruneHandler() handler.EventHandler {
+	return handler.Funcs{
+		DeleteFunc: func(
+			ctx context.Context,
+			e event.DeleteEvent,
+			_ workqueue.TypedRateLimitingInterface[reconcile.Request],
+		) {
+			ingress, ok := e.Object.(*networkingv1.Ingress)
+			if !ok {
+				return
+			}
+			r.evictReconcileCache(ctx, ingress)
+		},
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+const (
+	// PendingRemovalsAnnotation lists on a Gateway the hostnames whose removal waits for an acknowledgement,
+	// with the time they are removed anyway: host=RFC3339[,host=RFC3339...]
+	PendingRemovalsAnnotation = "ingress-doperator.fiction.si/pending-removals"
+	// AckRemovalsAnnotation on a Gateway acknowledges pending removals: comma-separated hostnames or "*"
+	AckRemovalsAnnotation = "ingress-doperator.fiction.si/ack-removals"
+
+	ackAllRemovals = "*"
+)
+
+// handoffWindow returns how long a hostname leaving an Ingress is held before it is released
+func (r *IngressReconciler) handoffWindow() time.Duration {
+	if r.ListenerRemovalAck && r.ListenerRemovalAckTimeout > r.HostnameHandoffWindow {
+		return r.ListenerRemovalAckTimeout
+	}
+	return r.HostnameHandoffWindow
+}
+
+// acknowledgedRemovals returns the hostnames whose removal the Gateway acknowledges, nil without
+// --listener-removal-ack or Gateway
+func (r *IngressReconciler) acknowledgedRemovals(ctx context.Context, gatewayName string) (map[string]bool, error) {
+	if !r.ListenerRemovalAck {
+		return nil, nil
+	}
+	gateway := &gatewayv1.Gateway{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: r.GatewayNamespace, Name: gatewayName}, gateway)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	acked := make(map[string]bool)
+	for _, host := range strings.Split(gateway.Annotations[AckRemovalsAnnotation], ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			acked[host] = true
+		}
+	}
+	return acked, nil
+}
+
+// removalAcknowledged reports whether the removal of hostname was acknowledged
+func removalAcknowledged(acked map[string]bool, hostname string) bool {
+	return acked[ackAllRemovals] || acked[hostname]
+}
+
+// syncPendingRemovals stages the hostnames the Ingress holds in the pending-removals annotation of the
+// Gateway and drops the ones it held before (previous) but released, or that are overdue. Acknowledgements
+// of released hostnames are dropped as well, "*" once nothing is pending anymore.
+func (r *IngressReconciler) syncPendingRemovals(
+	ctx context.Context,
+	gatewayName string,
+	previous map[string]time.Time,
+	held map[string]time.Time,
+) error {
+	if !r.ListenerRemovalAck || (len(previous) == 0 && len(held) == 0) {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		gateway := &gatewayv1.Gateway{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: r.GatewayNamespace, Name: gatewayName}, gateway)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		pending := translator.ParseHandoffHostnames(gateway.Annotations[PendingRemovalsAnnotation])
+		var acked []string
+		for _, host := range strings.Split(gateway.Annotations[AckRemovalsAnnotation], ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				acked = append(acked, host)
+			}
+		}
+		for host := range previous {
+			if _, ok := held[host]; !ok {
+				delete(pending, host)
+				acked = slices.DeleteFunc(acked, func(h string) bool { return h == host })
+			}
+		}
+		for host, deadline := range pending {
+			// Left behind by a deleted Ingress
+			if _, ok := held[host]; !ok && time.Now().After(deadline) {
+				delete(pending, host)
+			}
+		}
+		for host, deadline := range held {
+			pending[host] = deadline
+		}
+		if len(pending) == 0 {
+			acked = slices.DeleteFunc(acked, func(h string) bool { return h == ackAllRemovals })
+		}
+
+		annotations := make(map[string]string, len(gateway.Annotations)+2)
+		for key, value := range gateway.Annotations {
+			annotations[key] = value
+		}
+		setOrDelete := func(key, value string) {
+			if value == "" {
+				delete(annotations, key)
+				return
+			}
+			annotations[key] = value
+		}
+		setOrDelete(PendingRemovalsAnnotation, translator.FormatHandoffHostnames(pending))
+		setOrDelete(AckRemovalsAnnotation, strings.Join(acked, ","))
+		if annotations[PendingRemovalsAnnotation] == gateway.Annotations[PendingRemovalsAnnotation] &&
+			annotations[AckRemovalsAnnotation] == gateway.Annotations[AckRemovalsAnnotation] {
+			return nil
+		}
+		gateway.Annotations = annotations
+		utils.LogUpdateDiff(ctx, r.Client, gateway)
+		return r.Update(ctx, gateway)
+	})
+}
@@ -0,0 +1,131 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// IgnoreAnnotation opts an Ingress, or every Ingress of a Namespace, out of migration
+const IgnoreAnnotation = "ingress-doperator.fiction.si/ignore"
+
+// PausedAnnotation freezes everything the operator does for an Ingress until it is removed
+const PausedAnnotation = "ingress-doperator.fiction.si/paused"
+
+// IngressPaused reports whether the Ingress carries the paused annotation
+func IngressPaused(ingress *networkingv1.Ingress) bool {
+	return ingress.Annotations[PausedAnnotation] == fmt.Sprintf("%t", true)
+}
+
+// IngressIgnored reports whether the Ingress opted out with the ignore or ignore-ingress annotation
+func IngressIgnored(ingress *networkingv1.Ingress) bool {
+	return ingress.Annotations[IgnoreAnnotation] == fmt.Sprintf("%t", true) ||
+		ingress.Annotations[IgnoreIngressAnnotation] == fmt.Sprintf("%t", true)
+}
+
+// matchesIngressSelector reports whether the Ingress labels match --ingress-selector
+func (r *IngressReconciler) matchesIngressSelector(ingress *networkingv1.Ingress) bool {
+	return r.IngressSelector == nil || r.IngressSelector.Matches(labels.Set(ingress.Labels))
+}
+
+// namespaceOptedOut returns why the Namespace of the Ingress is not migrated: it doesn't match
+// --namespace-selector or carries the ignore annotation. Empty when it is migrated.
+func (r *IngressReconciler) namespaceOptedOut(ctx context.Context, ingress *networkingv1.Ingress) string {
+	namespace := &corev1.Namespace{}
+	if err := r.namespaceReader().Get(ctx, types.NamespacedName{Name: ingress.Namespace}, namespace); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.FromContext(ctx).Error(err, "unable to fetch Namespace for opt-out checks", "namespace", ingress.Namespace)
+		}
+		// Without the Namespace only the selector can tell, an empty one selects everything
+		if r.NamespaceSelector != nil && !r.NamespaceSelector.Empty() {
+			return "namespace-selector"
+		}
+		return ""
+	}
+	if namespace.Annotations[IgnoreAnnotation] == fmt.Sprintf("%t", true) {
+		return "namespace-ignored"
+	}
+	if r.NamespaceSelector != nil && !r.NamespaceSelector.Matches(labels.Set(namespace.Labels)) {
+		return "namespace-selector"
+	}
+	return ""
+}
+
+// namespaceReader reads Namespaces of the cluster the Ingresses live in
+func (r *IngressReconciler) namespaceReader() client.Reader {
+	if r.SourceCluster != nil {
+		return r.SourceCluster.Cluster.GetClient()
+	}
+	return r.Client
+}
+
+// watchNamespaces requeues the Ingresses of a Namespace whose labels or annotations changed, as that may
+// opt them in or out. Raw sources bypass the --watch-namespace event filter, which drops cluster-scoped objects.
+func (r *IngressReconciler) watchNamespaces(b *ctrlbuilder.Builder, localCache cache.Cache) *ctrlbuilder.Builder {
+	namespaceCache := localCache
+	if r.SourceCluster != nil {
+		namespaceCache = r.SourceCluster.Cluster.GetCache()
+	}
+	changed := predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			if r.WatchNamespace != "" && e.ObjectNew.GetName() != r.WatchNamespace {
+				return false
+			}
+			return !labels.Equals(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) ||
+				e.ObjectOld.GetAnnotations()[IgnoreAnnotation] != e.ObjectNew.GetAnnotations()[IgnoreAnnotation]
+		},
+	}
+	return b.WatchesRawSource(source.Kind[client.Object](namespaceCache, &corev1.Namespace{},
+		handler.EnqueueRequestsFromMapFunc(r.enqueueIngressesForNamespace), changed))
+}
+
+// enqueueIngressesForNamespace requeues every Ingress of the Namespace, bypassing the reconcile cache
+func (r *IngressReconciler) enqueueIngressesForNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	list := &networkingv1.IngressList{}
+	if err := r.List(ctx, list, client.InNamespace(obj.GetName())); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list Ingresses for Namespace change", "namespace", obj.GetName())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for i := range list.Items {
+		r.evictReconcileCache(ctx, &list.Items[i])
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
+			Namespace: list.Items[i].Namespace,
+			Name:      list.Items[i].Name,
+		}})
+	}
+	return requests
+}
@@ -0,0 +1,192 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// suppressSnippets stands in for SnippetsFilter handling when snippets are disabled or the data plane
+// provider has none. Whatever would have ended up in a SnippetsFilter is listed in the snippets-lost
+// annotation of the HTTPRoute instead, together with the extra lost entries. translated lists the
+// ingress-nginx annotations (without prefix) the data plane provider carried over by other means.
+func (r *IngressReconciler) suppressSnippets(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	httpRoute *gatewayv1.HTTPRoute,
+	extraLost []string,
+	translated []string,
+) {
+	logger := log.FromContext(ctx)
+
+	// An automatic SnippetsFilter from before snippets were disabled must not linger
+	filterName := r.automaticSnippetsFilterName(ingress)
+	if err := utils.DeleteManagedSnippetsFilter(ctx, r.tenantClient(), httpRoute.Namespace, filterName); err != nil {
+		logger.Error(err, "failed to remove automatic SnippetsFilter", "name", filterName, "namespace", httpRoute.Namespace)
+	}
+
+	lost := make([]string, 0)
+	for _, annotation := range r.lostSnippetAnnotations(ingress, httpRoute) {
+		if !isTranslatedAnnotation(annotation, translated) {
+			lost = append(lost, annotation)
+		}
+	}
+	lost = append(lost, extraLost...)
+	if len(lost) == 0 {
+		return
+	}
+
+	value := strings.Join(lost, ",")
+	if httpRoute.Annotations == nil {
+		httpRoute.Annotations = map[string]string{}
+	}
+	httpRoute.Annotations[SnippetsLostAnnotation] = value
+	logger.Info("Snippets are disabled or unsupported, Ingress behavior is not carried over",
+		"namespace", ingress.Namespace,
+		"name", ingress.Name,
+		"lost", value)
+	r.recordWarning(ingress, "SnippetsDisabled",
+		fmt.Sprintf("Snippets are disabled or unsupported by the data plane provider, HTTPRoute %s "+
+			"does not carry over: %s", httpRoute.Name, value))
+}
+
+// lostSnippetAnnotations returns the Ingress annotations that only a SnippetsFilter could express
+func (r *IngressReconciler) lostSnippetAnnotations(
+	ingress *networkingv1.Ingress,
+	httpRoute *gatewayv1.HTTPRoute,
+) []string {
+	annotations, origins := r.snippetAnnotations(ingress)
+	lost := utils.SnippetSourceAnnotations(annotations)
+	for i, annotation := range lost {
+		if origin, ok := origins[annotation]; ok {
+			lost[i] = origin
+		}
+	}
+	if _, ok := ingress.Annotations[HTTPRouteSnippetsFilterAnnotation]; ok {
+		lost = append(lost, HTTPRouteSnippetsFilterAnnotation)
+	}
+	if !translator.RewritesHost(httpRoute) {
+		if _, ok := utils.BuildUpstreamVhostSnippet(ingress.Annotations); ok {
+			for _, prefix := range []string{
+				translator.NginxIngressAnnotationPrefix,
+				translator.LegacyIngressAnnotationPrefix,
+			} {
+				if _, exists := ingress.Annotations[prefix+translator.NginxUpstreamVhostKey]; exists {
+					lost = append(lost, prefix+translator.NginxUpstreamVhostKey)
+				}
+			}
+		}
+	}
+	sort.Strings(lost)
+	return lost
+}
+
+// snippetAnnotations returns the annotations the automatic SnippetsFilter is built from: those of the
+// Ingress plus, for HAProxy Ingresses, the ingress-nginx equivalents of haproxy.org/ annotations. origins
+// maps every added annotation to the Ingress annotation it came from.
+func (r *IngressReconciler) snippetAnnotations(ingress *networkingv1.Ingress) (map[string]string, map[string]string) {
+	if !r.getTranslator().HAProxyDialect(ingress) {
+		return ingress.Annotations, nil
+	}
+	return translator.HAProxyNginxAnnotations(ingress.Annotations)
+}
+
+func isTranslatedAnnotation(annotation string, translated []string) bool {
+	for _, key := range translated {
+		if annotation == translator.NginxIngressAnnotationPrefix+key ||
+			annotation == translator.LegacyIngressAnnotationPrefix+key {
+			return true
+		}
+	}
+	return false
+}
+
+// syncSnippetsLostAnnotation copies the snippets-lost annotation of the generated HTTPRoutes onto the
+// source Ingress and clears it once nothing is lost anymore
+func (r *IngressReconciler) syncSnippetsLostAnnotation(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	httpRoutes []*gatewayv1.HTTPRoute,
+) {
+	lostSet := make(map[string]struct{})
+	for _, route := range httpRoutes {
+		value := route.Annotations[SnippetsLostAnnotation]
+		if value == "" {
+			continue
+		}
+		for _, entry := range strings.Split(value, ",") {
+			lostSet[entry] = struct{}{}
+		}
+	}
+	lost := make([]string, 0, len(lostSet))
+	for entry := range lostSet {
+		lost = append(lost, entry)
+	}
+	sort.Strings(lost)
+	desired := strings.Join(lost, ",")
+
+	if ingress.Annotations[SnippetsLostAnnotation] == desired {
+		return
+	}
+	patchBase := client.MergeFrom(ingress.DeepCopy())
+	if desired == "" {
+		delete(ingress.Annotations, SnippetsLostAnnotation)
+	} else {
+		if ingress.Annotations == nil {
+			ingress.Annotations = map[string]string{}
+		}
+		ingress.Annotations[SnippetsLostAnnotation] = desired
+	}
+	if err := r.Patch(ctx, ingress, patchBase); err != nil {
+		log.FromContext(ctx).Error(err, "failed to update snippets-lost annotation on Ingress")
+	}
+}
+
+// lossyPostProcessingBlocked holds back disabling or removing an Ingress whose snippet behavior
+// would be lost, unless lossy migrations are allowed
+func (r *IngressReconciler) lossyPostProcessingBlocked(
+	ingress *networkingv1.Ingress,
+	effectiveMode IngressPostProcessingMode,
+) bool {
+	if r.AllowLossy {
+		return false
+	}
+	if effectiveMode != IngressPostProcessingModeDisable && effectiveMode != IngressPostProcessingModeRemove {
+		return false
+	}
+	lost := ingress.Annotations[SnippetsLostAnnotation]
+	if lost == "" {
+		return false
+	}
+	r.recordWarning(ingress, "LossyMigrationBlocked",
+		fmt.Sprintf("Source Ingress is kept because snippets are unavailable and %s would be lost; "+
+			"use --allow-lossy to proceed anyway", lost))
+	metrics.IngressReconcileSkipsTotal.WithLabelValues("snippets-lost", ingress.Namespace, ingress.Name).Inc()
+	return true
+}
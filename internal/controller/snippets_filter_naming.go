@@ -0,0 +1,81 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// automaticSnippetsFilterName returns the name of the SnippetsFilter built from the annotations of ingress
+func (r *IngressReconciler) automaticSnippetsFilterName(ingress *networkingv1.Ingress) string {
+	return utils.AutomaticSnippetsFilterNameFor(r.SnippetsFilterNameTemplate, ingress, r.NameTemplate.Name(ingress))
+}
+
+// pruneRenamedSnippetsFilters moves an Ingress to the configured SnippetsFilter name: once an HTTPRoute
+// references the filter under the new name, the automatic filters it had under other names in that namespace
+// are deleted. Without --snippets-filter-name-template the name never changes and nothing needs to be listed.
+func (r *IngressReconciler) pruneRenamedSnippetsFilters(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	httpRoutes []*gatewayv1.HTTPRoute,
+) {
+	if r.SnippetsFilterNameTemplate == nil || r.DisableSnippets {
+		return
+	}
+	keep := r.automaticSnippetsFilterName(ingress)
+	legacyName := utils.AutomaticSnippetsFilterName(r.NameTemplate.Name(ingress))
+	namespaces := make(map[string]bool, 1)
+	for _, route := range httpRoutes {
+		if namespaces[route.Namespace] || !referencesSnippetsFilter(route, keep) {
+			continue
+		}
+		namespaces[route.Namespace] = true
+		pruned, err := utils.PruneAutomaticSnippetsFilters(ctx, r.tenantClient(), route.Namespace,
+			ingress.Namespace, ingress.Name, legacyName, keep)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "failed to remove renamed automatic SnippetsFilters",
+				"namespace", route.Namespace)
+		}
+		if len(pruned) > 0 {
+			r.recordNormal(ingress, "SnippetsFilterRenamed",
+				fmt.Sprintf("Moved the annotation snippets to SnippetsFilter %s/%s, deleted %s",
+					route.Namespace, keep, strings.Join(pruned, ", ")))
+		}
+	}
+}
+
+// referencesSnippetsFilter reports whether a rule of route uses the SnippetsFilter name
+func referencesSnippetsFilter(route *gatewayv1.HTTPRoute, name string) bool {
+	for _, rule := range route.Spec.Rules {
+		for _, filter := range rule.Filters {
+			ref := filter.ExtensionRef
+			if ref != nil && string(ref.Group) == utils.NginxGatewayGroup &&
+				string(ref.Kind) == utils.SnippetsFilterKind && string(ref.Name) == name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// OriginalSpecHashAnnotation holds the hash of the Ingress spec the derived resources were translated from
+// while the Ingress is disabled
+const OriginalSpecHashAnnotation = "ingress-doperator.fiction.si/original-spec-hash"
+
+// ingressSpecHash hashes the Ingress spec, leaving out the class that disabling changes
+func ingressSpecHash(ingress *networkingv1.Ingress) string {
+	spec := ingress.Spec.DeepCopy()
+	spec.IngressClassName = nil
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// changedWhileDisabled reports whether an Ingress disabled by us was edited after it was disabled
+func changedWhileDisabled(ingress *networkingv1.Ingress) bool {
+	if ingress.Annotations[IngressDisabledAnnotation] != IngressDisabledReasonNormal {
+		return false
+	}
+	hash, ok := ingress.Annotations[OriginalSpecHashAnnotation]
+	return ok && hash != ingressSpecHash(ingress)
+}
+
+// reconcileChangedWhileDisabled re-translates a disabled Ingress whose spec changed since it was
+// disabled and records the new spec hash
+func (r *IngressReconciler) reconcileChangedWhileDisabled(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	previous := ingress.Annotations[OriginalSpecHashAnnotation]
+	hash := ingressSpecHash(ingress)
+	logger.Info("Disabled Ingress changed since it was disabled, re-translating",
+		"namespace", ingress.Namespace,
+		"name", ingress.Name,
+		"previousHash", previous,
+		"hash", hash)
+
+	result, err := r.reconcileIngressToHTTPRoute(ctx, withOriginalSpec(ingress))
+	if err != nil || result.RequeueAfter != 0 {
+		return result, err
+	}
+
+	patchBase := client.MergeFrom(ingress.DeepCopy())
+	ingress.Annotations[OriginalSpecHashAnnotation] = hash
+	if err := r.Patch(ctx, ingress, patchBase); err != nil {
+		logger.Error(err, "failed to record spec hash on disabled Ingress")
+		return ctrl.Result{}, err
+	}
+	r.recordWarning(ingress, "ChangedWhileDisabled",
+		fmt.Sprintf("Ingress spec changed while disabled (hash %s, was %s); derived resources were re-translated",
+			hash, previous))
+	return result, nil
+}
@@ -0,0 +1,445 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/events"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// streamRouteKind knows how to build, list and update the route type of a layer 4 protocol
+type streamRouteKind struct {
+	kind      string
+	build     func(translator.StreamService, int32, types.NamespacedName, string, string) client.Object
+	newObject func() client.Object
+	list      func(context.Context, client.Client) ([]client.Object, error)
+	// syncSpec copies the spec of desired into existing and reports whether it differed
+	syncSpec func(existing, desired client.Object) bool
+	record   func(operation, namespace, name string)
+}
+
+var streamRouteKinds = map[gatewayv1.ProtocolType]streamRouteKind{
+	gatewayv1.TCPProtocolType: {
+		kind: "TCPRoute",
+		build: func(service translator.StreamService, port int32, cm types.NamespacedName, ns, name string) client.Object {
+			return translator.BuildTCPRoute(service, port, cm, ns, name)
+		},
+		newObject: func() client.Object { return &gatewayv1alpha2.TCPRoute{} },
+		list: func(ctx context.Context, c client.Client) ([]client.Object, error) {
+			routes := &gatewayv1alpha2.TCPRouteList{}
+			if err := c.List(ctx, routes); err != nil {
+				return nil, err
+			}
+			objects := make([]client.Object, 0, len(routes.Items))
+			for i := range routes.Items {
+				objects = append(objects, &routes.Items[i])
+			}
+			return objects, nil
+		},
+		syncSpec: func(existing, desired client.Object) bool {
+			current, wanted := existing.(*gatewayv1alpha2.TCPRoute), desired.(*gatewayv1alpha2.TCPRoute)
+			if equality.Semantic.DeepEqual(current.Spec, wanted.Spec) {
+				return false
+			}
+			current.Spec = wanted.Spec
+			return true
+		},
+		record: func(operation, namespace, name string) {
+			metrics.TCPRouteResourcesTotal.WithLabelValues(operation, namespace, name).Inc()
+		},
+	},
+	gatewayv1.UDPProtocolType: {
+		kind: "UDPRoute",
+		build: func(service translator.StreamService, port int32, cm types.NamespacedName, ns, name string) client.Object {
+			return translator.BuildUDPRoute(service, port, cm, ns, name)
+		},
+		newObject: func() client.Object { return &gatewayv1alpha2.UDPRoute{} },
+		list: func(ctx context.Context, c client.Client) ([]client.Object, error) {
+			routes := &gatewayv1alpha2.UDPRouteList{}
+			if err := c.List(ctx, routes); err != nil {
+				return nil, err
+			}
+			objects := make([]client.Object, 0, len(routes.Items))
+			for i := range routes.Items {
+				objects = append(objects, &routes.Items[i])
+			}
+			return objects, nil
+		},
+		syncSpec: func(existing, desired client.Object) bool {
+			current, wanted := existing.(*gatewayv1alpha2.UDPRoute), desired.(*gatewayv1alpha2.UDPRoute)
+			if equality.Semantic.DeepEqual(current.Spec, wanted.Spec) {
+				return false
+			}
+			current.Spec = wanted.Spec
+			return true
+		},
+		record: func(operation, namespace, name string) {
+			metrics.UDPRouteResourcesTotal.WithLabelValues(operation, namespace, name).Inc()
+		},
+	},
+}
+
+// StreamServicesReconciler migrates an ingress-nginx tcp-services or udp-services ConfigMap: every
+// entry becomes a TCP or UDP listener on the shared Gateway and a TCPRoute or UDPRoute in the
+// namespace of the backend Service
+type StreamServicesReconciler struct {
+	client.Client
+	// Protocol is TCP for tcp-services and UDP for udp-services
+	Protocol gatewayv1.ProtocolType
+	// ConfigMap is the services ConfigMap (--tcp-services-configmap, --udp-services-configmap)
+	ConfigMap types.NamespacedName
+	// Listeners provides the shared Gateway and the listener allowedRoutes policy
+	Listeners *HTTPRouteReconciler
+	Recorder  events.EventRecorder
+	// OwnedMetadataKeys are glob patterns of route annotation and label keys we own (--owned-metadata-keys)
+	OwnedMetadataKeys []string
+}
+
+// Reconcile brings routes and listeners in line with the services ConfigMap. A deleted ConfigMap
+// removes all of them.
+func (r *StreamServicesReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("configmap", r.ConfigMap.String(), "protocol", r.Protocol)
+	kind := streamRouteKinds[r.Protocol]
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, r.ConfigMap, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		configMap = nil
+	}
+
+	var services []translator.StreamService
+	if configMap != nil {
+		var invalid []string
+		services, invalid = translator.ParseStreamServices(configMap.Data)
+		if len(invalid) > 0 {
+			logger.Info("Ignoring invalid services entries", "entries", invalid)
+			r.recordWarning(configMap, "InvalidStreamService",
+				fmt.Sprintf("Ignoring invalid entries: %s", strings.Join(invalid, "; ")))
+		}
+	}
+
+	gateway := &gatewayv1.Gateway{}
+	gatewayNN := types.NamespacedName{Namespace: r.Listeners.GatewayNamespace, Name: r.Listeners.GatewayName}
+	if err := r.Get(ctx, gatewayNN, gateway); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		// The Gateway is created with the first HTTPRoute, its creation triggers another reconcile
+		logger.Info("Shared Gateway does not exist yet, waiting for it", "gateway", gatewayNN.String())
+		gateway = nil
+	}
+
+	desiredRoutes := make([]client.Object, 0, len(services))
+	listenerNamespaces := make(map[int32][]string, len(services))
+	var skipped []string
+	for _, service := range services {
+		if gateway != nil && portTakenByOtherProtocol(gateway, service.Port, r.Protocol) {
+			skipped = append(skipped, fmt.Sprintf("%d: port is used by another listener", service.Port))
+			continue
+		}
+		backendPort, err := r.resolveServicePort(ctx, service)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%d: %v", service.Port, err))
+			continue
+		}
+		if service.ProxyProtocol {
+			r.recordWarning(configMap, "ProxyProtocolIgnored",
+				fmt.Sprintf("Port %d asks for PROXY protocol, which %ss cannot express; "+
+					"configure it on the Gateway implementation instead", service.Port, kind.kind))
+		}
+		desiredRoutes = append(desiredRoutes, kind.build(service, backendPort, r.ConfigMap,
+			gatewayNN.Namespace, gatewayNN.Name))
+		listenerNamespaces[service.Port] = append(listenerNamespaces[service.Port], service.Namespace)
+	}
+	if len(skipped) > 0 {
+		logger.Info("Skipping services entries", "entries", skipped)
+		r.recordWarning(configMap, "StreamServiceSkipped",
+			fmt.Sprintf("Not migrated: %s", strings.Join(skipped, "; ")))
+	}
+
+	if err := r.applyStreamRoutes(ctx, kind, desiredRoutes); err != nil {
+		return ctrl.Result{}, err
+	}
+	if gateway == nil {
+		return ctrl.Result{}, nil
+	}
+	if err := r.Listeners.reconcileStreamListeners(ctx, gatewayNN, r.Protocol, listenerNamespaces); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// resolveServicePort returns the number of the Service port a services entry refers to
+func (r *StreamServicesReconciler) resolveServicePort(
+	ctx context.Context,
+	service translator.StreamService,
+) (int32, error) {
+	if port, err := strconv.ParseInt(service.ServicePort, 10, 32); err == nil {
+		return int32(port), nil
+	}
+	svc := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: service.Namespace, Name: service.Name}, svc); err != nil {
+		return 0, fmt.Errorf("cannot resolve port %q of Service %s/%s: %w",
+			service.ServicePort, service.Namespace, service.Name, err)
+	}
+	for _, port := range svc.Spec.Ports {
+		if port.Name == service.ServicePort {
+			return port.Port, nil
+		}
+	}
+	return 0, fmt.Errorf("service %s/%s has no port named %q", service.Namespace, service.Name, service.ServicePort)
+}
+
+// applyStreamRoutes creates or updates the desired routes and deletes the ones generated from the
+// ConfigMap that are no longer desired
+func (r *StreamServicesReconciler) applyStreamRoutes(
+	ctx context.Context,
+	kind streamRouteKind,
+	desiredRoutes []client.Object,
+) error {
+	logger := log.FromContext(ctx)
+
+	desired := make(map[types.NamespacedName]bool, len(desiredRoutes))
+	for _, route := range desiredRoutes {
+		desired[client.ObjectKeyFromObject(route)] = true
+	}
+
+	existingRoutes, err := kind.list(ctx, r.Client)
+	if err != nil {
+		return fmt.Errorf("failed to list %ss: %w", kind.kind, err)
+	}
+	for _, existing := range existingRoutes {
+		if !utils.IsManagedByUs(existing) ||
+			existing.GetAnnotations()[translator.ServicesConfigMapAnnotation] != r.ConfigMap.String() ||
+			desired[client.ObjectKeyFromObject(existing)] {
+			continue
+		}
+		logger.Info("Deleting obsolete "+kind.kind, "namespace", existing.GetNamespace(), "name", existing.GetName())
+		if err := r.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete obsolete %s %s/%s: %w",
+				kind.kind, existing.GetNamespace(), existing.GetName(), err)
+		}
+		kind.record("delete", existing.GetNamespace(), existing.GetName())
+	}
+
+	for _, route := range desiredRoutes {
+		if err := r.applyStreamRoute(ctx, kind, route); err != nil {
+			return fmt.Errorf("failed to apply %s %s/%s: %w", kind.kind, route.GetNamespace(), route.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// applyStreamRoute creates or updates a single route
+func (r *StreamServicesReconciler) applyStreamRoute(ctx context.Context, kind streamRouteKind, route client.Object) error {
+	logger := log.FromContext(ctx)
+
+	routeNN := client.ObjectKeyFromObject(route)
+	existing := kind.newObject()
+	canManage, err := utils.CanUpdateResource(ctx, r.Client, existing, routeNN)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		logger.Info("Skipping "+kind.kind+" synthesis - resource exists and is not managed by us",
+			"namespace", routeNN.Namespace, "name", routeNN.Name)
+		return nil
+	}
+
+	if err := r.Get(ctx, routeNN, existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		logger.Info("Creating "+kind.kind, "namespace", routeNN.Namespace, "name", routeNN.Name)
+		utils.RecordManagedKeys(route)
+		if err := r.Create(ctx, route); err != nil {
+			return err
+		}
+		kind.record("create", routeNN.Namespace, routeNN.Name)
+		return nil
+	}
+
+	specChanged := kind.syncSpec(existing, route)
+	metadataChanged := utils.MergeManagedMetadata(existing, route, r.OwnedMetadataKeys)
+	if !specChanged && !metadataChanged {
+		return nil
+	}
+	logger.Info("Updating "+kind.kind, "namespace", routeNN.Namespace, "name", routeNN.Name)
+	if err := r.Update(ctx, existing); err != nil {
+		return err
+	}
+	kind.record("update", routeNN.Namespace, routeNN.Name)
+	return nil
+}
+
+func (r *StreamServicesReconciler) recordWarning(configMap *corev1.ConfigMap, reason, message string) {
+	if r.Recorder == nil || configMap == nil {
+		return
+	}
+	r.Recorder.Eventf(configMap, nil, "Warning", reason, "Reconcile", message)
+}
+
+// portTakenByOtherProtocol reports whether a listener other than a TCP or UDP one already uses port.
+// TCP and UDP listeners may share a port, e.g. for DNS.
+func portTakenByOtherProtocol(gateway *gatewayv1.Gateway, port int32, protocol gatewayv1.ProtocolType) bool {
+	for _, listener := range gateway.Spec.Listeners {
+		if int32(listener.Port) == port && listener.Protocol != protocol && !translator.IsStreamListener(&listener) {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileStreamListeners makes the layer 4 listeners of protocol on the Gateway match desired, which
+// maps ports to the namespaces of their routes. Listeners of other protocols are left alone.
+func (r *HTTPRouteReconciler) reconcileStreamListeners(
+	ctx context.Context,
+	gatewayNN types.NamespacedName,
+	protocol gatewayv1.ProtocolType,
+	desired map[int32][]string,
+) error {
+	logger := log.FromContext(ctx)
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		gateway := &gatewayv1.Gateway{}
+		canManage, err := utils.CanUpdateResource(ctx, r.Client, gateway, gatewayNN)
+		if err != nil {
+			return err
+		}
+		if !canManage {
+			logger.V(1).Info("Cannot manage Gateway, skipping stream listeners", "gateway", gatewayNN)
+			return nil
+		}
+
+		updated := false
+		listeners := make([]gatewayv1.Listener, 0, len(gateway.Spec.Listeners))
+		present := make(map[int32]bool)
+		for _, listener := range gateway.Spec.Listeners {
+			if listener.Protocol != protocol {
+				listeners = append(listeners, listener)
+				continue
+			}
+			port := int32(listener.Port)
+			if _, ok := desired[port]; !ok || listener.Name != translator.StreamListenerName(protocol, port) {
+				logger.Info("Removing listener (no services reference it)", "listener", listener.Name)
+				updated = true
+				continue
+			}
+			present[port] = true
+			namespaces := sortedUnique(desired[port])
+			policy := r.ListenerAllowedRoutes.Resolve(gatewayNN.Name, string(listener.Name))
+			if policy.Apply(&listener, namespaces) ||
+				(policy.Mode == translator.AllowedRoutesNamespaces && r.updateListenerNamespaces(&listener, namespaces)) {
+				logger.Info("Updated listener allowedRoutes", "listener", listener.Name, "namespaces", namespaces)
+				updated = true
+			}
+			listeners = append(listeners, listener)
+		}
+
+		ports := make([]int32, 0, len(desired))
+		for port := range desired {
+			if !present[port] {
+				ports = append(ports, port)
+			}
+		}
+		sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+		for _, port := range ports {
+			listener := gatewayv1.Listener{
+				Name:     translator.StreamListenerName(protocol, port),
+				Port:     gatewayv1.PortNumber(port),
+				Protocol: protocol,
+			}
+			listener.AllowedRoutes = r.ListenerAllowedRoutes.Resolve(gatewayNN.Name, string(listener.Name)).
+				AllowedRoutes(sortedUnique(desired[port]))
+			listeners = append(listeners, listener)
+			logger.Info("Added new stream listener", "listener", listener.Name, "port", port)
+			updated = true
+		}
+
+		if !updated {
+			return nil
+		}
+		gateway.Spec.Listeners = listeners
+		utils.LogUpdateDiff(ctx, r.Client, gateway)
+		return r.Update(ctx, gateway)
+	})
+}
+
+// sortedUnique returns the sorted distinct values
+func sortedUnique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// SetupWithManager sets up the controller with the Manager. Routes generated from the ConfigMap and
+// the shared Gateway trigger a reconcile as well, so edits to either are reverted.
+func (r *StreamServicesReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	request := []reconcile.Request{{NamespacedName: r.ConfigMap}}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(string(r.Protocol))+"-services").
+		For(&corev1.ConfigMap{}, ctrlbuilder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetNamespace() == r.ConfigMap.Namespace && obj.GetName() == r.ConfigMap.Name
+		}))).
+		Watches(streamRouteKinds[r.Protocol].newObject(),
+			handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+				return request
+			}),
+			ctrlbuilder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetAnnotations()[translator.ServicesConfigMapAnnotation] == r.ConfigMap.String()
+			}))).
+		Watches(&gatewayv1.Gateway{},
+			handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+				return request
+			}),
+			ctrlbuilder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+				return obj.GetNamespace() == r.Listeners.GatewayNamespace && obj.GetName() == r.Listeners.GatewayName
+			}))).
+		Complete(r)
+}
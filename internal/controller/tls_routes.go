@@ -0,0 +1,170 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+const (
+	TLSRouteFinalizerName = "ingress-doperator.fiction.si/tlsroute-finalizer"
+)
+
+// buildTLSRoutes translates an ssl-passthrough Ingress into its TLSRoutes
+func (r *IngressReconciler) buildTLSRoutes(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	singleTrans *translator.Translator,
+) []*gatewayv1.TLSRoute {
+	tlsRoutes, ignored := singleTrans.TranslateToTLSRoutes(ingress)
+	if len(ignored) > 0 {
+		log.FromContext(ctx).Info("Ingress paths cannot be routed with ssl-passthrough, leaving them out of the TLSRoute",
+			"namespace", ingress.Namespace,
+			"name", ingress.Name,
+			"paths", ignored)
+		r.recordWarning(ingress, "TLSPassthroughPathIgnored",
+			fmt.Sprintf("Paths %s use another backend than their host's / path; with ssl-passthrough only the "+
+				"SNI hostname is routed and they were not translated", strings.Join(ignored, ", ")))
+	}
+	for _, tlsRoute := range tlsRoutes {
+		r.setRouteOwner(tlsRoute, ingress)
+		r.TLSRouteManager.ResolveNamedPorts(ctx, ingress, tlsRoute)
+	}
+	return tlsRoutes
+}
+
+// applyTLSRoutes applies the TLSRoutes of an Ingress, removing all of them when it has none
+func (r *IngressReconciler) applyTLSRoutes(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	tlsRoutes []*gatewayv1.TLSRoute,
+) error {
+	if r.TLSRouteManager == nil {
+		return nil
+	}
+	metricRecorder := r.resourceRecorder(ctx, ingress, "TLSRoute", metrics.TLSRouteResourcesTotal)
+	if err := r.TLSRouteManager.ApplyTLSRoutes(ctx, ingress, tlsRoutes, metricRecorder); err != nil {
+		log.FromContext(ctx).Error(err, "failed to apply TLSRoutes")
+		r.logErrorRateLimited(err, "apply-tlsroutes", "failed to apply TLSRoutes")
+		return err
+	}
+	return nil
+}
+
+// listTLSRouteListenerViews returns listener views of the managed TLSRoutes attached to a Gateway
+func (r *HTTPRouteReconciler) listTLSRouteListenerViews(
+	ctx context.Context,
+	gatewayName string,
+	excludeKey string,
+) ([]gatewayv1.HTTPRoute, error) {
+	if !r.TLSRoutes {
+		return nil, nil
+	}
+	allRoutes := &gatewayv1.TLSRouteList{}
+	if err := r.List(ctx, allRoutes); err != nil {
+		return nil, err
+	}
+
+	views := make([]gatewayv1.HTTPRoute, 0, len(allRoutes.Items))
+	for i := range allRoutes.Items {
+		view := translator.TLSRouteListenerView(&allRoutes.Items[i])
+		if !view.DeletionTimestamp.IsZero() || !(r.isManagedByUs(view) || isAdoptedRoute(view)) {
+			continue
+		}
+		if excludeKey != "" && fmt.Sprintf("%s/%s", view.Namespace, view.Name) == excludeKey {
+			continue
+		}
+		if r.getGatewayNameFromHTTPRoute(view) != gatewayName {
+			continue
+		}
+		views = append(views, *view)
+	}
+	return views, nil
+}
+
+// TLSRouteReconciler manages the TLS passthrough listeners of TLSRoutes the way HTTPRouteReconciler
+// does for HTTPRoutes, sharing its listener bookkeeping and debouncing
+type TLSRouteReconciler struct {
+	client.Client
+	Listeners *HTTPRouteReconciler
+}
+
+// Reconcile manages Gateway listeners based on TLSRoute changes
+func (r *TLSRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	tlsRoute := &gatewayv1.TLSRoute{}
+	if err := r.Get(ctx, req.NamespacedName, tlsRoute); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("TLSRoute not found, already deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "unable to fetch TLSRoute")
+		return ctrl.Result{}, err
+	}
+	if !utils.IsManagedByUs(tlsRoute) {
+		return ctrl.Result{}, nil
+	}
+
+	view := translator.TLSRouteListenerView(tlsRoute)
+	if !tlsRoute.DeletionTimestamp.IsZero() {
+		if utils.ContainsString(tlsRoute.Finalizers, TLSRouteFinalizerName) {
+			logger.Info("TLSRoute being deleted, cleaning up Gateway listeners", "namespace", req.Namespace, "name", req.Name)
+			if err := r.Listeners.handleHTTPRouteDelete(ctx, view); err != nil {
+				logger.Error(err, "failed to cleanup Gateway for deleted TLSRoute, removing finalizer anyway")
+			}
+			tlsRoute.Finalizers = utils.RemoveString(tlsRoute.Finalizers, TLSRouteFinalizerName)
+			if err := r.Update(ctx, tlsRoute); err != nil {
+				logger.Error(err, "failed to remove finalizer")
+				return ctrl.Result{}, err
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !utils.ContainsString(tlsRoute.Finalizers, TLSRouteFinalizerName) {
+		tlsRoute.Finalizers = append(tlsRoute.Finalizers, TLSRouteFinalizerName)
+		if err := r.Update(ctx, tlsRoute); err != nil {
+			logger.Error(err, "failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return r.Listeners.handleHTTPRouteCreateOrUpdate(ctx, view)
+}
+
+// SetupWithManager sets up the controller with the Manager. It must be called after the
+// HTTPRouteReconciler it shares the debouncer with is set up.
+func (r *TLSRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1.TLSRoute{}).
+		WithEventFilter(ManagedByIngressDoperatorPredicate()).
+		Complete(r)
+}
@@ -0,0 +1,90 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+	"github.com/fiksn/ingress-doperator/internal/utils"
+)
+
+// tlsSecretRequeue is how often an Ingress with an invalid TLS Secret is checked again, Secrets are not watched
+const tlsSecretRequeue = time.Minute
+
+// invalidTLSSecrets returns why the spec.tls Secrets of an Ingress cannot serve its HTTPS listeners. Entries
+// without secretName use the default certificate of the data plane and are not checked.
+func (r *IngressReconciler) invalidTLSSecrets(ctx context.Context, ingress *networkingv1.Ingress) ([]string, error) {
+	var reader client.Reader = r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	var problems []string
+	for _, entry := range ingress.Spec.TLS {
+		if entry.SecretName == "" {
+			continue
+		}
+		secret := &corev1.Secret{}
+		key := types.NamespacedName{Namespace: ingress.Namespace, Name: entry.SecretName}
+		if err := reader.Get(ctx, key, secret); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get TLS Secret %s: %w", key.String(), err)
+			}
+			problems = append(problems, fmt.Sprintf("secret %s does not exist", key.String()))
+			continue
+		}
+		if err := utils.ValidateTLSSecret(secret, entry.Hosts); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	return problems, nil
+}
+
+// tlsSecretsReady validates the TLS Secrets of an Ingress before listeners are synthesized for them. With an
+// invalid Secret the Ingress is skipped, so it keeps serving instead of being replaced by a broken listener.
+func (r *IngressReconciler) tlsSecretsReady(ctx context.Context, ingress *networkingv1.Ingress) (bool, error) {
+	// Passthrough listeners don't terminate TLS and attach-only mode doesn't synthesize listeners
+	if !r.ValidateTLSSecrets || r.AttachOnly || translator.IsSSLPassthrough(ingress.Annotations) {
+		return true, nil
+	}
+	problems, err := r.invalidTLSSecrets(ctx, ingress)
+	if err != nil {
+		return false, err
+	}
+	if len(problems) == 0 {
+		return true, nil
+	}
+	log.FromContext(ctx).Info("Skipping Ingress with invalid TLS Secrets",
+		"namespace", ingress.Namespace,
+		"name", ingress.Name,
+		"problems", problems)
+	r.recordWarning(ingress, "InvalidTLSSecret",
+		fmt.Sprintf("Ingress is not migrated until its TLS Secrets are valid: %s", strings.Join(problems, "; ")))
+	metrics.IngressReconcileSkipsTotal.WithLabelValues("invalid-tls-secret", ingress.Namespace, ingress.Name).Inc()
+	return false, nil
+}
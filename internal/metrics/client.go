@@ -0,0 +1,201 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+)
+
+// maxExemplarRunes is the limit Prometheus puts on the combined length of exemplar label names and values
+const maxExemplarRunes = 128
+
+// NewInstrumentedClient is a client.NewClientFunc whose client records every call in APIRequestDuration.
+// Reads of typed objects are served from the informer cache and show up as such.
+func NewInstrumentedClient(config *rest.Config, options client.Options) (client.Client, error) {
+	c, err := client.New(config, options)
+	if err != nil {
+		return nil, err
+	}
+	return InstrumentClient(c), nil
+}
+
+// InstrumentClient wraps c so every call is recorded in APIRequestDuration
+func InstrumentClient(c client.Client) client.Client {
+	return &instrumentedClient{Client: c}
+}
+
+type instrumentedClient struct {
+	client.Client
+}
+
+func (c *instrumentedClient) Get(
+	ctx context.Context,
+	key client.ObjectKey,
+	obj client.Object,
+	opts ...client.GetOption,
+) error {
+	defer observeAPIRequest(ctx, "get", c.kind(obj, ""), key.String(), time.Now())
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *instrumentedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	defer observeAPIRequest(ctx, "list", c.kind(list, ""), listOpts.Namespace, time.Now())
+	return c.Client.List(ctx, list, opts...)
+}
+
+func (c *instrumentedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	defer observeAPIRequest(ctx, "create", c.kind(obj, ""), objectKey(obj), time.Now())
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *instrumentedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	defer observeAPIRequest(ctx, "update", c.kind(obj, ""), objectKey(obj), time.Now())
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *instrumentedClient) Patch(
+	ctx context.Context,
+	obj client.Object,
+	patch client.Patch,
+	opts ...client.PatchOption,
+) error {
+	defer observeAPIRequest(ctx, "patch", c.kind(obj, ""), objectKey(obj), time.Now())
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *instrumentedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	defer observeAPIRequest(ctx, "delete", c.kind(obj, ""), objectKey(obj), time.Now())
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *instrumentedClient) DeleteAllOf(
+	ctx context.Context,
+	obj client.Object,
+	opts ...client.DeleteAllOfOption,
+) error {
+	defer observeAPIRequest(ctx, "deletecollection", c.kind(obj, ""), obj.GetNamespace(), time.Now())
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *instrumentedClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *instrumentedClient) SubResource(subResource string) client.SubResourceClient {
+	return &instrumentedSubResourceClient{
+		SubResourceClient: c.Client.SubResource(subResource),
+		parent:            c,
+		subResource:       subResource,
+	}
+}
+
+// kind returns the kind of obj for the metric labels, lists are reported under the kind of their items
+func (c *instrumentedClient) kind(obj runtime.Object, subResource string) string {
+	kind := "unknown"
+	if gvk, err := c.GroupVersionKindFor(obj); err == nil {
+		kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+	if subResource != "" {
+		kind += "/" + subResource
+	}
+	return kind
+}
+
+type instrumentedSubResourceClient struct {
+	client.SubResourceClient
+	parent      *instrumentedClient
+	subResource string
+}
+
+func (c *instrumentedSubResourceClient) Get(
+	ctx context.Context,
+	obj client.Object,
+	subResource client.Object,
+	opts ...client.SubResourceGetOption,
+) error {
+	defer observeAPIRequest(ctx, "get", c.parent.kind(obj, c.subResource), objectKey(obj), time.Now())
+	return c.SubResourceClient.Get(ctx, obj, subResource, opts...)
+}
+
+func (c *instrumentedSubResourceClient) Create(
+	ctx context.Context,
+	obj client.Object,
+	subResource client.Object,
+	opts ...client.SubResourceCreateOption,
+) error {
+	defer observeAPIRequest(ctx, "create", c.parent.kind(obj, c.subResource), objectKey(obj), time.Now())
+	return c.SubResourceClient.Create(ctx, obj, subResource, opts...)
+}
+
+func (c *instrumentedSubResourceClient) Update(
+	ctx context.Context,
+	obj client.Object,
+	opts ...client.SubResourceUpdateOption,
+) error {
+	defer observeAPIRequest(ctx, "update", c.parent.kind(obj, c.subResource), objectKey(obj), time.Now())
+	return c.SubResourceClient.Update(ctx, obj, opts...)
+}
+
+func (c *instrumentedSubResourceClient) Patch(
+	ctx context.Context,
+	obj client.Object,
+	patch client.Patch,
+	opts ...client.SubResourcePatchOption,
+) error {
+	defer observeAPIRequest(ctx, "patch", c.parent.kind(obj, c.subResource), objectKey(obj), time.Now())
+	return c.SubResourceClient.Patch(ctx, obj, patch, opts...)
+}
+
+func objectKey(obj client.Object) string {
+	return client.ObjectKeyFromObject(obj).String()
+}
+
+// observeAPIRequest records the time since start. The reconcile ID and the object are attached as an exemplar,
+// so a slow bucket leads straight to the reconcile logs (they carry the same reconcileID).
+func observeAPIRequest(ctx context.Context, verb, kind, object string, start time.Time) {
+	APIRequestDuration.WithLabelValues(verb, kind).ObserveWithExemplar(
+		time.Since(start).Seconds(), apiRequestExemplar(ctx, object))
+}
+
+func apiRequestExemplar(ctx context.Context, object string) prometheus.Labels {
+	labels := prometheus.Labels{}
+	budget := maxExemplarRunes
+	if reconcileID := string(controller.ReconcileIDFromContext(ctx)); reconcileID != "" {
+		labels["reconcile_id"] = reconcileID
+		budget -= len("reconcile_id") + len(reconcileID)
+	}
+	budget -= len("object")
+	if object != "" && budget > 0 {
+		runes := []rune(object)
+		if len(runes) > budget {
+			// Keep the end, the name tells more than the namespace
+			runes = runes[len(runes)-budget:]
+		}
+		labels["object"] = string(runes)
+	}
+	return labels
+}
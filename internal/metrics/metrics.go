@@ -17,54 +17,312 @@ limitations under the License.
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
-	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 var (
 	// GatewayResourcesTotal tracks the total number of Gateway resources created or updated
-	GatewayResourcesTotal = prometheus.NewCounterVec(
+	GatewayResourcesTotal = newCounterVec(
 		prometheus.CounterOpts{
-			Name: "ingress_operator_gateway_resources_total",
+			Name: "gateway_resources_total",
 			Help: "Total number of Gateway resources created or updated by the ingress operator",
 		},
 		[]string{"operation", "namespace", "name"},
 	)
 
 	// HTTPRouteResourcesTotal tracks the total number of HTTPRoute resources created or updated
-	HTTPRouteResourcesTotal = prometheus.NewCounterVec(
+	HTTPRouteResourcesTotal = newCounterVec(
 		prometheus.CounterOpts{
-			Name: "ingress_operator_httproute_resources_total",
+			Name: "httproute_resources_total",
 			Help: "Total number of HTTPRoute resources created or updated by the ingress operator",
 		},
 		[]string{"operation", "namespace", "name"},
 	)
 
+	// GRPCRouteResourcesTotal tracks the total number of GRPCRoute resources created, updated or deleted
+	GRPCRouteResourcesTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpcroute_resources_total",
+			Help: "Total number of GRPCRoute resources created, updated or deleted by the ingress operator",
+		},
+		[]string{"operation", "namespace", "name"},
+	)
+
+	// TLSRouteResourcesTotal tracks the total number of TLSRoute resources created, updated or deleted
+	TLSRouteResourcesTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "tlsroute_resources_total",
+			Help: "Total number of TLSRoute resources created, updated or deleted by the ingress operator",
+		},
+		[]string{"operation", "namespace", "name"},
+	)
+
+	// TCPRouteResourcesTotal tracks the total number of TCPRoute resources created, updated or deleted
+	TCPRouteResourcesTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "tcproute_resources_total",
+			Help: "Total number of TCPRoute resources created, updated or deleted by the ingress operator",
+		},
+		[]string{"operation", "namespace", "name"},
+	)
+
+	// UDPRouteResourcesTotal tracks the total number of UDPRoute resources created, updated or deleted
+	UDPRouteResourcesTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "udproute_resources_total",
+			Help: "Total number of UDPRoute resources created, updated or deleted by the ingress operator",
+		},
+		[]string{"operation", "namespace", "name"},
+	)
+
 	// ReferenceGrantResourcesTotal tracks the total number of ReferenceGrant resources created or updated
-	ReferenceGrantResourcesTotal = prometheus.NewCounterVec(
+	ReferenceGrantResourcesTotal = newCounterVec(
 		prometheus.CounterOpts{
-			Name: "ingress_operator_referencegrant_resources_total",
+			Name: "referencegrant_resources_total",
 			Help: "Total number of ReferenceGrant resources created or updated by the ingress operator",
 		},
 		[]string{"operation", "namespace", "name"},
 	)
 
+	// HostnameHandoffsTotal tracks hostnames released by their previous Ingress after a handoff, by result
+	// (completed: another Ingress took it over, expired: nobody did within the handoff window,
+	// acknowledged: its removal was acknowledged on the Gateway)
+	HostnameHandoffsTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "hostname_handoffs_total",
+			Help: "Total number of hostnames released after moving away from an Ingress, by result",
+		},
+		[]string{"result"},
+	)
+
+	// GatewayAddressConflictsTotal tracks pinned Gateway addresses skipped because another Gateway requests them
+	GatewayAddressConflictsTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "gateway_address_conflicts_total",
+			Help: "Total number of times a pinned Gateway address was skipped because another Gateway requests it",
+		},
+		[]string{"namespace", "name"},
+	)
+
 	// IngressReconcileSkipsTotal tracks the number of reconciles skipped due to cache/disabled/etc.
-	IngressReconcileSkipsTotal = prometheus.NewCounterVec(
+	IngressReconcileSkipsTotal = newCounterVec(
 		prometheus.CounterOpts{
-			Name: "ingress_operator_reconcile_skips_total",
+			Name: "reconcile_skips_total",
 			Help: "Total number of ingress reconciles skipped",
 		},
 		[]string{"reason", "namespace", "name"},
 	)
-)
 
-func init() {
-	// Register custom metrics with the global prometheus registry
-	metrics.Registry.MustRegister(
-		GatewayResourcesTotal,
-		HTTPRouteResourcesTotal,
-		ReferenceGrantResourcesTotal,
-		IngressReconcileSkipsTotal,
+	// ResourceConflictsTotal tracks generated resources skipped because an unmanaged object holds the name
+	ResourceConflictsTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "resource_conflicts_total",
+			Help: "Total number of times a generated resource was skipped because an unmanaged object has its name",
+		},
+		[]string{"kind", "namespace", "name"},
+	)
+
+	// ExternalDNSRewritesTotal tracks external-dns annotation rewrites on source Ingresses
+	ExternalDNSRewritesTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "externaldns_rewrites_total",
+			Help: "Total number of external-dns annotation rewrites on source Ingresses (disable or restore)",
+		},
+		[]string{"action"},
 	)
-}
+
+	// ExternalDNSIngresses reports how many Ingresses are in each external-dns state
+	ExternalDNSIngresses = newGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "externaldns_ingresses",
+			Help: "Number of Ingresses whose hostnames external-dns publishes (enabled) or ignores (disabled)",
+		},
+		[]string{"state"},
+	)
+
+	// MigrationPaused reports whether Ingress post-processing is paused due to an unhealthy GatewayClass
+	MigrationPaused = newGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "migration_paused",
+			Help: "Whether Ingress post-processing is paused because the target GatewayClass is missing or not accepted",
+		},
+		[]string{"gatewayclass"},
+	)
+
+	// HostnameServingStates reports the number of migrated hostnames per serving state
+	HostnameServingStates = newGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hostname_serving_states",
+			Help: "Number of hostnames served by the Ingress only, by both data planes or by the Gateway only",
+		},
+		[]string{"state"},
+	)
+
+	// HostnameRenamePlanState reports the state of each HostnameRenamePlan
+	HostnameRenamePlanState = newGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "hostname_rename_plan_state",
+			Help: "State of a HostnameRenamePlan (0 = pending, 1 = serving both, 2 = retaining old hostnames, " +
+				"3 = completed, -1 = rolled back)",
+		},
+		[]string{"plan"},
+	)
+
+	// PreviewsExpiredTotal tracks translate-only Ingresses whose derived resources were removed after their TTL
+	PreviewsExpiredTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "previews_expired_total",
+			Help: "Total number of translate-only previews whose derived resources were removed when their TTL expired",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// RolloutPercentage reports the share of eligible Ingresses the phased rollout migrates
+	RolloutPercentage = newGauge(
+		prometheus.GaugeOpts{
+			Name: "rollout_percentage",
+			Help: "Percentage of eligible Ingresses migrated by the phased rollout (100 = all)",
+		},
+	)
+
+	// IncompleteIntents reports destructive operations a previous run started but never finished
+	IncompleteIntents = newGauge(
+		prometheus.GaugeOpts{
+			Name: "incomplete_intents",
+			Help: "Number of destructive operations found unfinished in the intent log at startup and not resumed",
+		},
+	)
+
+	// NamespaceCircuitOpen reports whether the per-namespace circuit breaker is open
+	NamespaceCircuitOpen = newGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "namespace_circuit_open",
+			Help: "Whether reconciles for a namespace are backed off after repeated failures (1 = open)",
+		},
+		[]string{"namespace"},
+	)
+
+	// NamespaceCircuitTripsTotal tracks how often the per-namespace circuit breaker opened
+	NamespaceCircuitTripsTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "namespace_circuit_trips_total",
+			Help: "Total number of times the circuit breaker opened for a namespace",
+		},
+		[]string{"namespace"},
+	)
+
+	// HTTPRouteChunks reports how many HTTPRoutes the rules of an Ingress are split into
+	HTTPRouteChunks = newGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "httproute_chunks",
+			Help: "Number of HTTPRoutes the rules of an Ingress are split into to stay within the Gateway API limits",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// DriftRepairsTotal tracks derived resources the periodic resync found edited and re-rendered
+	DriftRepairsTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "drift_repairs_total",
+			Help: "Total number of Gateways and routes repaired by the drift resync, by kind and operation",
+		},
+		[]string{"kind", "operation", "namespace", "name"},
+	)
+
+	// LegacyIngressesDeniedTotal tracks new Ingresses the webhook denied in namespaces already migrated
+	LegacyIngressesDeniedTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "legacy_ingresses_denied_total",
+			Help: "Total number of new Ingresses denied because their namespace was migrated to Gateway API",
+		},
+		[]string{"namespace", "ingressclass"},
+	)
+
+	// AnnotationsNormalizedTotal tracks operator annotations the webhook rewrote or filled in on new Ingresses
+	AnnotationsNormalizedTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "annotations_normalized_total",
+			Help: "Total number of ingress-doperator annotations normalized or defaulted on new Ingresses",
+		},
+		[]string{"annotation"},
+	)
+
+	// ReadOnlyWritesTotal tracks writes --read-only dropped instead of sending them
+	ReadOnlyWritesTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "read_only_writes_total",
+			Help: "Total number of writes the operator would have made in read-only mode, by verb and kind",
+		},
+		[]string{"verb", "kind"},
+	)
+
+	// ReadOnlyPendingWrites reports how many writes the last reconcile of an Ingress would have made
+	ReadOnlyPendingWrites = newGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "read_only_pending_writes",
+			Help: "Writes the last reconcile of an Ingress would have made in read-only mode (0 = in sync)",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// UntranslatedFeatures reports how many annotations and spec fields of an Ingress are not carried over
+	UntranslatedFeatures = newGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "untranslated_features",
+			Help: "Annotations and spec fields of an Ingress its Gateway API resources do not carry over",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// NginxAnnotationUsage reports, as of the last annotation coverage report, how many Ingresses use
+	// each ingress-nginx annotation and how it is translated
+	NginxAnnotationUsage = newGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nginx_annotation_usage",
+			Help: "Ingresses using an ingress-nginx annotation, by how it is translated (native, snippet or dropped)",
+		},
+		[]string{"annotation", "translation"},
+	)
+
+	// GatewayAPIKindInstalled reports which Gateway API kinds the operator found on the API server
+	GatewayAPIKindInstalled = newGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "gateway_api_kind_installed",
+			Help: "Whether a Gateway API kind is served at the version the operator uses (1) or missing (0)",
+		},
+		[]string{"kind", "version"},
+	)
+
+	// GatewayCapacityRatio reports the estimated share of its listener and config size limits a Gateway uses
+	GatewayCapacityRatio = newGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "gateway_capacity_ratio",
+			Help: "Estimated listeners and nginx config size of a Gateway relative to the configured limits (1 = full)",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// NotificationsTotal tracks notifications sent to the notification webhook
+	NotificationsTotal = newCounterVec(
+		prometheus.CounterOpts{
+			Name: "notifications_total",
+			Help: "Total number of notifications by event type and result (sent, failed, dropped)",
+		},
+		[]string{"event", "result"},
+	)
+
+	// APIRequestDuration tracks the latency of API calls made through the manager client
+	APIRequestDuration = newHistogramVec(
+		prometheus.HistogramOpts{
+			Name:                            "api_request_duration_seconds",
+			Help:                            "Latency of API calls made by the ingress operator, by verb and kind",
+			Buckets:                         prometheus.ExponentialBuckets(0.001, 2, 15),
+			NativeHistogramBucketFactor:     1.1,
+			NativeHistogramMaxBucketNumber:  100,
+			NativeHistogramMinResetDuration: time.Hour,
+		},
+		[]string{"verb", "kind"},
+	)
+)
@@ -0,0 +1,292 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// DefaultNamespace prefixes the metric names, e.g. ingress_doperator_reconcile_skips_total
+	DefaultNamespace = "ingress_doperator"
+
+	// LegacyNamespace is the prefix metrics had before they moved to DefaultNamespace. They are
+	// registered under it as well until the deprecation window ends.
+	LegacyNamespace = "ingress_operator"
+)
+
+var namespaceRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// namespacedMetric creates the collectors of a metric, one per namespace
+type namespacedMetric interface {
+	register(namespaces []string) []prometheus.Collector
+}
+
+// collectors are the metrics Register creates collectors for
+var collectors []namespacedMetric
+
+// Register creates every metric under namespace and, with legacy, under LegacyNamespace too, and registers
+// them with the controller-runtime registry. Metrics are no-ops until then.
+func Register(namespace string, legacy bool) error {
+	if !namespaceRegex.MatchString(namespace) {
+		return fmt.Errorf("invalid metrics namespace %q", namespace)
+	}
+	namespaces := []string{namespace}
+	if legacy && namespace != LegacyNamespace {
+		namespaces = append(namespaces, LegacyNamespace)
+	}
+	for _, collector := range collectors {
+		for _, c := range collector.register(namespaces) {
+			if err := metrics.Registry.Register(c); err != nil {
+				return fmt.Errorf("failed to register metric: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// deprecatedHelp marks the help text of a metric registered under LegacyNamespace, namespaces[0] is the
+// namespace replacing it
+func deprecatedHelp(namespaces []string, namespace, name, help string) string {
+	if namespace == namespaces[0] {
+		return help
+	}
+	return fmt.Sprintf("%s (deprecated, use %s_%s)", help, namespaces[0], name)
+}
+
+// CounterVec is a counter registered under each metrics namespace
+type CounterVec struct {
+	opts   prometheus.CounterOpts
+	labels []string
+	vecs   []*prometheus.CounterVec
+}
+
+func newCounterVec(opts prometheus.CounterOpts, labels []string) *CounterVec {
+	v := &CounterVec{opts: opts, labels: labels}
+	collectors = append(collectors, v)
+	return v
+}
+
+func (v *CounterVec) register(namespaces []string) []prometheus.Collector {
+	v.vecs = nil
+	registered := make([]prometheus.Collector, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		opts := v.opts
+		opts.Namespace = namespace
+		opts.Help = deprecatedHelp(namespaces, namespace, opts.Name, opts.Help)
+		vec := prometheus.NewCounterVec(opts, v.labels)
+		v.vecs = append(v.vecs, vec)
+		registered = append(registered, vec)
+	}
+	return registered
+}
+
+// WithLabelValues returns the counter of the label values in every namespace
+func (v *CounterVec) WithLabelValues(lvs ...string) Counter {
+	all := make(counters, 0, len(v.vecs))
+	for _, vec := range v.vecs {
+		all = append(all, vec.WithLabelValues(lvs...))
+	}
+	return all
+}
+
+// Counter is a counter of one label set
+type Counter interface {
+	Inc()
+	Add(float64)
+}
+
+type counters []prometheus.Counter
+
+func (c counters) Inc() {
+	for _, counter := range c {
+		counter.Inc()
+	}
+}
+
+func (c counters) Add(value float64) {
+	for _, counter := range c {
+		counter.Add(value)
+	}
+}
+
+// GaugeVec is a gauge registered under each metrics namespace
+type GaugeVec struct {
+	opts   prometheus.GaugeOpts
+	labels []string
+	vecs   []*prometheus.GaugeVec
+}
+
+func newGaugeVec(opts prometheus.GaugeOpts, labels []string) *GaugeVec {
+	v := &GaugeVec{opts: opts, labels: labels}
+	collectors = append(collectors, v)
+	return v
+}
+
+func (v *GaugeVec) register(namespaces []string) []prometheus.Collector {
+	v.vecs = nil
+	registered := make([]prometheus.Collector, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		opts := v.opts
+		opts.Namespace = namespace
+		opts.Help = deprecatedHelp(namespaces, namespace, opts.Name, opts.Help)
+		vec := prometheus.NewGaugeVec(opts, v.labels)
+		v.vecs = append(v.vecs, vec)
+		registered = append(registered, vec)
+	}
+	return registered
+}
+
+// WithLabelValues returns the gauge of the label values in every namespace
+func (v *GaugeVec) WithLabelValues(lvs ...string) Gauge {
+	all := make(gauges, 0, len(v.vecs))
+	for _, vec := range v.vecs {
+		all = append(all, vec.WithLabelValues(lvs...))
+	}
+	return all
+}
+
+// DeleteLabelValues removes the gauge of the label values in every namespace
+func (v *GaugeVec) DeleteLabelValues(lvs ...string) {
+	for _, vec := range v.vecs {
+		vec.DeleteLabelValues(lvs...)
+	}
+}
+
+// Reset removes the gauges of all label values in every namespace
+func (v *GaugeVec) Reset() {
+	for _, vec := range v.vecs {
+		vec.Reset()
+	}
+}
+
+// Gauge is a gauge of one label set
+type Gauge interface {
+	Set(float64)
+	Inc()
+	Dec()
+}
+
+type gauges []prometheus.Gauge
+
+func (g gauges) Set(value float64) {
+	for _, gauge := range g {
+		gauge.Set(value)
+	}
+}
+
+func (g gauges) Inc() {
+	for _, gauge := range g {
+		gauge.Inc()
+	}
+}
+
+func (g gauges) Dec() {
+	for _, gauge := range g {
+		gauge.Dec()
+	}
+}
+
+// SingleGauge is a gauge without labels registered under each metrics namespace
+type SingleGauge struct {
+	gauges
+	opts prometheus.GaugeOpts
+}
+
+func newGauge(opts prometheus.GaugeOpts) *SingleGauge {
+	g := &SingleGauge{opts: opts}
+	collectors = append(collectors, g)
+	return g
+}
+
+func (g *SingleGauge) register(namespaces []string) []prometheus.Collector {
+	g.gauges = nil
+	registered := make([]prometheus.Collector, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		opts := g.opts
+		opts.Namespace = namespace
+		opts.Help = deprecatedHelp(namespaces, namespace, opts.Name, opts.Help)
+		gauge := prometheus.NewGauge(opts)
+		g.gauges = append(g.gauges, gauge)
+		registered = append(registered, gauge)
+	}
+	return registered
+}
+
+// HistogramVec is a histogram registered under each metrics namespace
+type HistogramVec struct {
+	opts   prometheus.HistogramOpts
+	labels []string
+	vecs   []*prometheus.HistogramVec
+}
+
+func newHistogramVec(opts prometheus.HistogramOpts, labels []string) *HistogramVec {
+	v := &HistogramVec{opts: opts, labels: labels}
+	collectors = append(collectors, v)
+	return v
+}
+
+func (v *HistogramVec) register(namespaces []string) []prometheus.Collector {
+	v.vecs = nil
+	registered := make([]prometheus.Collector, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		opts := v.opts
+		opts.Namespace = namespace
+		opts.Help = deprecatedHelp(namespaces, namespace, opts.Name, opts.Help)
+		vec := prometheus.NewHistogramVec(opts, v.labels)
+		v.vecs = append(v.vecs, vec)
+		registered = append(registered, vec)
+	}
+	return registered
+}
+
+// WithLabelValues returns the histogram of the label values in every namespace
+func (v *HistogramVec) WithLabelValues(lvs ...string) Observer {
+	all := make(observers, 0, len(v.vecs))
+	for _, vec := range v.vecs {
+		all = append(all, vec.WithLabelValues(lvs...))
+	}
+	return all
+}
+
+// Observer is a histogram of one label set, it keeps exemplars where the histogram supports them
+type Observer interface {
+	prometheus.Observer
+	prometheus.ExemplarObserver
+}
+
+type observers []prometheus.Observer
+
+func (o observers) Observe(value float64) {
+	for _, observer := range o {
+		observer.Observe(value)
+	}
+}
+
+func (o observers) ObserveWithExemplar(value float64, exemplar prometheus.Labels) {
+	for _, observer := range o {
+		if exemplarObserver, ok := observer.(prometheus.ExemplarObserver); ok {
+			exemplarObserver.ObserveWithExemplar(value, exemplar)
+		} else {
+			observer.Observe(value)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package probe builds the HTTP clients and DNS resolvers of outbound requests (the notifier, hostname and
+// DNS verification probes), so all of them honour the --probe-* resolvers, proxy and CA bundle
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds a single probe when Config.Timeout is not set
+const DefaultTimeout = 10 * time.Second
+
+// Config is the network environment probes run in
+type Config struct {
+	// Resolvers are DNS servers (host:port) queried in order instead of the system resolver
+	Resolvers []string
+	// Proxy is the HTTP(S) proxy URL, empty uses HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	Proxy string
+	// CABundle is a PEM file of CAs trusted in addition to the system pool
+	CABundle string
+	// InsecureSkipVerify disables verification of the certificates probes are served
+	InsecureSkipVerify bool
+	// Timeout bounds a single probe, 0 = DefaultTimeout
+	Timeout time.Duration
+	// FollowRedirects makes HTTPClient follow redirects like a plain http.Client. Probes leave it unset to see
+	// the response of the hostname itself, clients of other endpoints (the notifier) set it.
+	FollowRedirects bool
+}
+
+// ParseResolvers parses a comma-separated list of DNS servers (ip or ip:port, port 53 by default)
+func ParseResolvers(raw string) ([]string, error) {
+	var resolvers []string
+	for _, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		host, port, err := net.SplitHostPort(item)
+		if err != nil {
+			host, port = strings.Trim(item, "[]"), "53"
+		}
+		if net.ParseIP(host) == nil {
+			return nil, fmt.Errorf("invalid resolver %q: not an IP address", item)
+		}
+		resolvers = append(resolvers, net.JoinHostPort(host, port))
+	}
+	return resolvers, nil
+}
+
+// ParseProxy validates a proxy URL, empty means the proxy of the environment
+func ParseProxy(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid proxy %q: %w", raw, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" && parsed.Scheme != "socks5" {
+		return fmt.Errorf("invalid proxy %q: scheme must be http, https or socks5", raw)
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("invalid proxy %q: missing host", raw)
+	}
+	return nil
+}
+
+func (c Config) timeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return DefaultTimeout
+}
+
+// Resolver returns the resolver DNS probes use: the configured servers tried in order, or the system resolver
+func (c Config) Resolver() *net.Resolver {
+	if len(c.Resolvers) == 0 {
+		return net.DefaultResolver
+	}
+	resolvers := c.Resolvers
+	timeout := c.timeout()
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := &net.Dialer{Timeout: timeout}
+			var errs []error
+			for _, resolver := range resolvers {
+				conn, err := dialer.DialContext(ctx, network, resolver)
+				if err == nil {
+					return conn, nil
+				}
+				errs = append(errs, err)
+			}
+			return nil, errors.Join(errs...)
+		},
+	}
+}
+
+// TLSConfig returns the TLS configuration HTTPS probes use
+func (c Config) TLSConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// Probes may need to reach endpoints with self-signed certificates
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec
+	}
+	if c.CABundle == "" {
+		return config, nil
+	}
+	pem, err := os.ReadFile(c.CABundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle %s: %w", c.CABundle, err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("CA bundle %s contains no PEM certificates", c.CABundle)
+	}
+	config.RootCAs = pool
+	return config, nil
+}
+
+// HTTPClient returns the client HTTP probes use. It resolves names with Resolver, goes through the proxy
+// and trusts the CA bundle; redirects are only followed with FollowRedirects.
+func (c Config) HTTPClient() (*http.Client, error) {
+	tlsConfig, err := c.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	proxy := http.ProxyFromEnvironment
+	if c.Proxy != "" {
+		if err := ParseProxy(c.Proxy); err != nil {
+			return nil, err
+		}
+		proxyURL, _ := url.Parse(c.Proxy)
+		proxy = http.ProxyURL(proxyURL)
+	}
+	dialer := &net.Dialer{Timeout: c.timeout(), Resolver: c.Resolver()}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	transport.DialContext = dialer.DialContext
+	transport.TLSClientConfig = tlsConfig
+	httpClient := &http.Client{Transport: transport, Timeout: c.timeout()}
+	if !c.FollowRedirects {
+		httpClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	return httpClient, nil
+}
@@ -0,0 +1,259 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	ALBAnnotationPrefix = "alb.ingress.kubernetes.io/"
+
+	ALBListenPortsKey          = "listen-ports"
+	ALBSSLRedirectKey          = "ssl-redirect"
+	ALBGroupNameKey            = "group.name"
+	ALBHealthCheckPathKey      = "healthcheck-path"
+	ALBHealthCheckIntervalKey  = "healthcheck-interval-seconds"
+	ALBHealthCheckTimeoutKey   = "healthcheck-timeout-seconds"
+	ALBHealthyThresholdKey     = "healthy-threshold-count"
+	ALBUnhealthyThresholdKey   = "unhealthy-threshold-count"
+	ALBHealthCheckSuccessCodes = "success-codes"
+
+	// AWS Load Balancer Controller target group defaults
+	albDefaultHealthCheckPath     = "/"
+	albDefaultHealthCheckInterval = 15 * time.Second
+	albDefaultHealthCheckTimeout  = 5 * time.Second
+	albDefaultHealthyThreshold    = 5
+	albDefaultUnhealthyThreshold  = 2
+	albDefaultSuccessCode         = 200
+
+	// albSSLRedirectStatusCode is the HTTP_301 redirect action ssl-redirect creates
+	albSSLRedirectStatusCode = 301
+)
+
+// ALBListenPort is one entry of listen-ports, e.g. {"HTTPS": 443}
+type ALBListenPort struct {
+	Protocol string
+	Port     gatewayv1.PortNumber
+}
+
+// ALBHealthCheck is the target group health check of the healthcheck-* annotations
+type ALBHealthCheck struct {
+	Path               string
+	Interval           time.Duration
+	Timeout            time.Duration
+	HealthyThreshold   int
+	UnhealthyThreshold int
+	SuccessCodes       []int
+}
+
+// GetALBAnnotation returns the value of an alb.ingress.kubernetes.io/ annotation
+func GetALBAnnotation(annotations map[string]string, key string) (string, bool) {
+	value, ok := annotations[ALBAnnotationPrefix+key]
+	return strings.TrimSpace(value), ok
+}
+
+// ParseALBListenPorts parses listen-ports, a JSON list of protocol to port objects. It returns nil, nil
+// when the annotation is absent.
+func ParseALBListenPorts(annotations map[string]string) ([]ALBListenPort, error) {
+	value, ok := GetALBAnnotation(annotations, ALBListenPortsKey)
+	if !ok {
+		return nil, nil
+	}
+	var entries []map[string]int32
+	if err := json.Unmarshal([]byte(value), &entries); err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", ALBListenPortsKey, value, err)
+	}
+	var listenPorts []ALBListenPort
+	for _, entry := range entries {
+		for protocol, port := range entry {
+			protocol = strings.ToUpper(protocol)
+			if protocol != "HTTP" && protocol != "HTTPS" {
+				return nil, fmt.Errorf("invalid %s protocol %q, expected HTTP or HTTPS", ALBListenPortsKey, protocol)
+			}
+			if port < 1 || port > 65535 {
+				return nil, fmt.Errorf("invalid %s port %d", ALBListenPortsKey, port)
+			}
+			listenPorts = append(listenPorts, ALBListenPort{Protocol: protocol, Port: gatewayv1.PortNumber(port)})
+		}
+	}
+	return listenPorts, nil
+}
+
+// ALBGroupName returns the IngressGroup of group.name, false when it is absent
+func ALBGroupName(annotations map[string]string) (string, bool, error) {
+	group, ok := GetALBAnnotation(annotations, ALBGroupNameKey)
+	if !ok || group == "" {
+		return "", false, nil
+	}
+	if errs := validation.IsDNS1123Subdomain(group); len(errs) > 0 {
+		return "", false, fmt.Errorf("invalid %s %q: %s", ALBGroupNameKey, group, strings.Join(errs, ", "))
+	}
+	return group, true, nil
+}
+
+// ALBGroupGatewayName returns <gateway>-<group>, the Gateway shared by the Ingresses of an ALB IngressGroup
+func ALBGroupGatewayName(gatewayName, group string) string {
+	name := gatewayName + "-" + group
+	if len(name) > 63 {
+		name = strings.TrimRight(name[:63], "-.")
+	}
+	return name
+}
+
+// ParseALBHealthCheck translates the healthcheck-* annotations, AWS defaults fill in the missing ones.
+// It returns false when none of them is set.
+func ParseALBHealthCheck(annotations map[string]string) (ALBHealthCheck, bool, error) {
+	healthCheck := ALBHealthCheck{
+		Path:               albDefaultHealthCheckPath,
+		Interval:           albDefaultHealthCheckInterval,
+		Timeout:            albDefaultHealthCheckTimeout,
+		HealthyThreshold:   albDefaultHealthyThreshold,
+		UnhealthyThreshold: albDefaultUnhealthyThreshold,
+		SuccessCodes:       []int{albDefaultSuccessCode},
+	}
+	found := false
+	if value, ok := GetALBAnnotation(annotations, ALBHealthCheckPathKey); ok {
+		found = true
+		if !strings.HasPrefix(value, "/") {
+			return healthCheck, false, fmt.Errorf("invalid %s %q", ALBHealthCheckPathKey, value)
+		}
+		healthCheck.Path = value
+	}
+	for _, setting := range []struct {
+		key     string
+		seconds *time.Duration
+		count   *int
+	}{
+		{key: ALBHealthCheckIntervalKey, seconds: &healthCheck.Interval},
+		{key: ALBHealthCheckTimeoutKey, seconds: &healthCheck.Timeout},
+		{key: ALBHealthyThresholdKey, count: &healthCheck.HealthyThreshold},
+		{key: ALBUnhealthyThresholdKey, count: &healthCheck.UnhealthyThreshold},
+	} {
+		value, ok := GetALBAnnotation(annotations, setting.key)
+		if !ok {
+			continue
+		}
+		found = true
+		number, err := strconv.Atoi(value)
+		if err != nil || number <= 0 {
+			return healthCheck, false, fmt.Errorf("invalid %s %q", setting.key, value)
+		}
+		if setting.seconds != nil {
+			*setting.seconds = time.Duration(number) * time.Second
+		} else {
+			*setting.count = number
+		}
+	}
+	if value, ok := GetALBAnnotation(annotations, ALBHealthCheckSuccessCodes); ok {
+		found = true
+		codes, err := parseALBSuccessCodes(value)
+		if err != nil {
+			return healthCheck, false, err
+		}
+		healthCheck.SuccessCodes = codes
+	}
+	return healthCheck, found, nil
+}
+
+// parseALBSuccessCodes parses success-codes: HTTP codes separated by commas or as a range, e.g. 200-299
+func parseALBSuccessCodes(value string) ([]int, error) {
+	var codes []int
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		first, last, isRange := strings.Cut(item, "-")
+		if !isRange {
+			last = first
+		}
+		from, errFrom := strconv.Atoi(strings.TrimSpace(first))
+		to, errTo := strconv.Atoi(strings.TrimSpace(last))
+		if errFrom != nil || errTo != nil || from < 200 || to > 499 || from > to {
+			return nil, fmt.Errorf("invalid %s %q", ALBHealthCheckSuccessCodes, value)
+		}
+		for code := from; code <= to; code++ {
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
+}
+
+// albSSLRedirect reports whether ssl-redirect sends the HTTP listeners of an ALB Ingress to HTTPS
+func albSSLRedirect(ingress *networkingv1.Ingress) bool {
+	value, ok := GetALBAnnotation(ingress.Annotations, ALBSSLRedirectKey)
+	return ok && value != ""
+}
+
+// albServesPlainHTTP reports whether listen-ports opens an HTTP listener that ssl-redirect does not
+// redirect. Like Traefik, the AWS Load Balancer Controller does not redirect to HTTPS on its own.
+func albServesPlainHTTP(ingress *networkingv1.Ingress) bool {
+	if albSSLRedirect(ingress) {
+		return false
+	}
+	listenPorts, err := ParseALBListenPorts(ingress.Annotations)
+	if err != nil {
+		return false
+	}
+	for _, listenPort := range listenPorts {
+		if listenPort.Protocol == "HTTP" {
+			return true
+		}
+	}
+	return false
+}
+
+// albRedirectStatusCode returns the status code of the ssl-redirect redirect to HTTPS
+func albRedirectStatusCode(ingress *networkingv1.Ingress) (int, bool) {
+	if !albSSLRedirect(ingress) {
+		return 0, false
+	}
+	return albSSLRedirectStatusCode, true
+}
+
+// albListenPortParentRefs attaches the route of an Ingress with listen-ports to the listeners of a
+// pre-provisioned Gateway on those ports. With ssl-redirect the HTTP ports only redirect, so the
+// route stays off them.
+func (t *Translator) albListenPortParentRefs(ingress *networkingv1.Ingress) []gatewayv1.ParentReference {
+	if !t.Config.AttachOnly {
+		return nil
+	}
+	listenPorts, err := ParseALBListenPorts(ingress.Annotations)
+	if err != nil {
+		return nil
+	}
+	redirect := albSSLRedirect(ingress)
+	seen := make(map[gatewayv1.PortNumber]bool)
+	var ports []gatewayv1.PortNumber
+	for _, listenPort := range listenPorts {
+		if (redirect && listenPort.Protocol == "HTTP") || seen[listenPort.Port] {
+			continue
+		}
+		seen[listenPort.Port] = true
+		ports = append(ports, listenPort.Port)
+	}
+	if len(ports) == 0 {
+		return nil
+	}
+	return t.attachPortParentRefs(ports)
+}
@@ -0,0 +1,255 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// AllowedRoutesMode selects how a generated listener restricts route namespaces
+type AllowedRoutesMode string
+
+const (
+	// AllowedRoutesNamespaces allows exactly the namespaces of the Ingresses using the listener (default)
+	AllowedRoutesNamespaces AllowedRoutesMode = "namespaces"
+	// AllowedRoutesSame allows routes from the Gateway namespace only
+	AllowedRoutesSame AllowedRoutesMode = "same"
+	// AllowedRoutesAll allows routes from every namespace
+	AllowedRoutesAll AllowedRoutesMode = "all"
+	// AllowedRoutesSelector allows routes from namespaces matching a label selector
+	AllowedRoutesSelector AllowedRoutesMode = "selector"
+)
+
+// AllowedRoutesPolicy is the allowedRoutes strategy for listeners matching the patterns
+type AllowedRoutesPolicy struct {
+	// GatewayPattern is a glob matched against the Gateway (partition) name
+	GatewayPattern string
+	// ListenerPattern is a glob matched against the listener name (the hostname)
+	ListenerPattern string
+	Mode            AllowedRoutesMode
+	// Selector selects route namespaces in selector mode
+	Selector *metav1.LabelSelector
+}
+
+// AllowedRoutesPolicies are evaluated in order, the first matching policy wins
+type AllowedRoutesPolicies []AllowedRoutesPolicy
+
+// ParseAllowedRoutesPolicies parses comma-separated [gateway/listener=]policy entries where policy is
+// namespaces, same, all or selector:<requirements> (see ParseNamespaceSelector). Entries without a
+// target apply to every listener.
+func ParseAllowedRoutesPolicies(raw string) (AllowedRoutesPolicies, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	policies := make(AllowedRoutesPolicies, 0)
+	for _, entry := range strings.Split(raw, ",") {
+		trimmed := strings.TrimSpace(entry)
+		if trimmed == "" {
+			continue
+		}
+
+		policy := AllowedRoutesPolicy{GatewayPattern: "*", ListenerPattern: "*"}
+		value := trimmed
+		if target, rest, ok := strings.Cut(trimmed, "="); ok && !strings.HasPrefix(trimmed, string(AllowedRoutesSelector)+":") {
+			gatewayPattern, listenerPattern, ok := strings.Cut(target, "/")
+			if !ok || strings.TrimSpace(gatewayPattern) == "" || strings.TrimSpace(listenerPattern) == "" {
+				return nil, fmt.Errorf("invalid entry %q, expected gateway/listener=policy", trimmed)
+			}
+			policy.GatewayPattern = strings.TrimSpace(gatewayPattern)
+			policy.ListenerPattern = strings.TrimSpace(listenerPattern)
+			value = strings.TrimSpace(rest)
+		}
+		for _, pattern := range []string{policy.GatewayPattern, policy.ListenerPattern} {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q in entry %q: %w", pattern, trimmed, err)
+			}
+		}
+
+		mode, selector, _ := strings.Cut(value, ":")
+		policy.Mode = AllowedRoutesMode(mode)
+		switch policy.Mode {
+		case AllowedRoutesNamespaces, AllowedRoutesSame, AllowedRoutesAll:
+			if selector != "" {
+				return nil, fmt.Errorf("invalid entry %q, policy %s takes no label", trimmed, mode)
+			}
+		case AllowedRoutesSelector:
+			labelSelector, err := ParseNamespaceSelector(selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector in entry %q: %w", trimmed, err)
+			}
+			policy.Selector = labelSelector
+		default:
+			return nil, fmt.Errorf("invalid policy %q in entry %q (allowed: namespaces, same, all, selector:<requirements>)",
+				value, trimmed)
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// ParseNamespaceSelector parses &-separated label requirements: key (exists), !key (does not exist),
+// key=value, key!=value, key=v1|v2 (in) and key!=v1|v2 (not in). Commas separate policy entries,
+// hence the custom syntax instead of the kubectl one.
+func ParseNamespaceSelector(raw string) (*metav1.LabelSelector, error) {
+	selector := &metav1.LabelSelector{}
+	for _, requirement := range strings.Split(raw, "&") {
+		requirement = strings.TrimSpace(requirement)
+		if requirement == "" {
+			return nil, fmt.Errorf("empty label requirement in %q", raw)
+		}
+
+		key, values, hasValues := strings.Cut(requirement, "=")
+		negated := false
+		switch {
+		case !hasValues && strings.HasPrefix(key, "!"):
+			key = strings.TrimPrefix(key, "!")
+			negated = true
+		case hasValues && strings.HasSuffix(key, "!"):
+			key = strings.TrimSuffix(key, "!")
+			negated = true
+		}
+		key = strings.TrimSpace(key)
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+
+		if !hasValues {
+			operator := metav1.LabelSelectorOpExists
+			if negated {
+				operator = metav1.LabelSelectorOpDoesNotExist
+			}
+			selector.MatchExpressions = append(selector.MatchExpressions,
+				metav1.LabelSelectorRequirement{Key: key, Operator: operator})
+			continue
+		}
+
+		labelValues := strings.Split(values, "|")
+		for i, value := range labelValues {
+			labelValues[i] = strings.TrimSpace(value)
+			if errs := validation.IsValidLabelValue(labelValues[i]); len(errs) > 0 {
+				return nil, fmt.Errorf("invalid label value %q: %s", labelValues[i], strings.Join(errs, "; "))
+			}
+		}
+		if !negated && len(labelValues) == 1 {
+			if _, exists := selector.MatchLabels[key]; exists {
+				return nil, fmt.Errorf("duplicate label %q", key)
+			}
+			if selector.MatchLabels == nil {
+				selector.MatchLabels = make(map[string]string)
+			}
+			selector.MatchLabels[key] = labelValues[0]
+			continue
+		}
+		operator := metav1.LabelSelectorOpIn
+		if negated {
+			operator = metav1.LabelSelectorOpNotIn
+		}
+		selector.MatchExpressions = append(selector.MatchExpressions,
+			metav1.LabelSelectorRequirement{Key: key, Operator: operator, Values: labelValues})
+	}
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		return nil, err
+	}
+	return selector, nil
+}
+
+// Resolve returns the policy for a listener of the given Gateway, defaulting to namespaces
+func (p AllowedRoutesPolicies) Resolve(gatewayName, listenerName string) AllowedRoutesPolicy {
+	for _, policy := range p {
+		gatewayMatch, _ := filepath.Match(policy.GatewayPattern, gatewayName)
+		listenerMatch, _ := filepath.Match(policy.ListenerPattern, listenerName)
+		if gatewayMatch && listenerMatch {
+			return policy
+		}
+	}
+	return AllowedRoutesPolicy{GatewayPattern: "*", ListenerPattern: "*", Mode: AllowedRoutesNamespaces}
+}
+
+// AllowedRoutes builds the listener allowedRoutes for the policy; namespaces is only used
+// by the namespaces mode
+func (p AllowedRoutesPolicy) AllowedRoutes(namespaces []string) *gatewayv1.AllowedRoutes {
+	var from gatewayv1.FromNamespaces
+	var selector *metav1.LabelSelector
+	switch p.Mode {
+	case AllowedRoutesSame:
+		from = gatewayv1.NamespacesFromSame
+	case AllowedRoutesAll:
+		from = gatewayv1.NamespacesFromAll
+	case AllowedRoutesSelector:
+		from = gatewayv1.NamespacesFromSelector
+		selector = p.Selector.DeepCopy()
+	default:
+		from = gatewayv1.NamespacesFromSelector
+		selector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      labelSelectorNamespaceKey,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   append([]string{}, namespaces...),
+				},
+			},
+		}
+	}
+	return &gatewayv1.AllowedRoutes{
+		Namespaces: &gatewayv1.RouteNamespaces{From: &from, Selector: selector},
+	}
+}
+
+// Apply enforces the policy on a listener and reports whether it changed. In namespaces mode
+// listeners that already select namespaces by name are left to the incremental namespace updates.
+func (p AllowedRoutesPolicy) Apply(listener *gatewayv1.Listener, namespaces []string) bool {
+	if p.Mode == AllowedRoutesNamespaces || p.Mode == "" {
+		if HasNamespaceNameSelector(listener) {
+			return false
+		}
+		listener.AllowedRoutes = p.AllowedRoutes(namespaces)
+		return true
+	}
+
+	desired := p.AllowedRoutes(nil)
+	if reflect.DeepEqual(listener.AllowedRoutes, desired) {
+		return false
+	}
+	listener.AllowedRoutes = desired
+	return true
+}
+
+// HasNamespaceNameSelector reports whether the listener selects route namespaces by name
+func HasNamespaceNameSelector(listener *gatewayv1.Listener) bool {
+	if listener.AllowedRoutes == nil || listener.AllowedRoutes.Namespaces == nil ||
+		listener.AllowedRoutes.Namespaces.Selector == nil {
+		return false
+	}
+	selector := listener.AllowedRoutes.Namespaces.Selector
+	if _, ok := selector.MatchLabels[labelSelectorNamespaceKey]; ok {
+		return true
+	}
+	for _, expr := range selector.MatchExpressions {
+		if expr.Key == labelSelectorNamespaceKey && expr.Operator == metav1.LabelSelectorOpIn {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ParseAttachSectionNames parses a comma-separated list of listener names of a pre-provisioned Gateway
+func ParseAttachSectionNames(value string) ([]gatewayv1.SectionName, error) {
+	var sectionNames []gatewayv1.SectionName
+	for _, item := range strings.Split(value, ",") {
+		name := strings.TrimSpace(item)
+		if name == "" {
+			continue
+		}
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid attach section name %q: %s", name, strings.Join(errs, ", "))
+		}
+		sectionNames = append(sectionNames, gatewayv1.SectionName(name))
+	}
+	return sectionNames, nil
+}
+
+// attachParentRefs attaches a route to the configured listeners of a pre-provisioned Gateway, or to the
+// whole Gateway (every listener whose hostname matches) when no listener is configured
+func (t *Translator) attachParentRefs() []gatewayv1.ParentReference {
+	gatewayName := gatewayv1.ObjectName(t.Config.GatewayName)
+	gatewayNamespace := gatewayv1.Namespace(t.Config.GatewayNamespace)
+	if len(t.Config.AttachSectionNames) == 0 {
+		return []gatewayv1.ParentReference{{Name: gatewayName, Namespace: &gatewayNamespace}}
+	}
+	parentRefs := make([]gatewayv1.ParentReference, 0, len(t.Config.AttachSectionNames))
+	for _, sectionName := range t.Config.AttachSectionNames {
+		parentRefs = append(parentRefs, gatewayv1.ParentReference{
+			Name:        gatewayName,
+			Namespace:   &gatewayNamespace,
+			SectionName: &sectionName,
+		})
+	}
+	return parentRefs
+}
+
+// attachPortParentRefs attaches a route to the listeners of a pre-provisioned Gateway on ports
+func (t *Translator) attachPortParentRefs(ports []gatewayv1.PortNumber) []gatewayv1.ParentReference {
+	gatewayName := gatewayv1.ObjectName(t.Config.GatewayName)
+	gatewayNamespace := gatewayv1.Namespace(t.Config.GatewayNamespace)
+	parentRefs := make([]gatewayv1.ParentReference, 0, len(ports))
+	for _, port := range ports {
+		parentRefs = append(parentRefs, gatewayv1.ParentReference{
+			Name:      gatewayName,
+			Namespace: &gatewayNamespace,
+			Port:      &port,
+		})
+	}
+	return parentRefs
+}
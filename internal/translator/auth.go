@@ -0,0 +1,128 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	NginxAuthTypeKey            = "auth-type"
+	NginxAuthSecretKey          = "auth-secret"
+	NginxAuthSecretTypeKey      = "auth-secret-type"
+	NginxAuthURLKey             = "auth-url"
+	NginxAuthResponseHeadersKey = "auth-response-headers"
+
+	// AuthFileSecretType keeps an htpasswd file under the "auth" key, AuthMapSecretType one user per key
+	AuthFileSecretType = "auth-file"
+	AuthMapSecretType  = "auth-map"
+)
+
+// BasicAuth is ingress-nginx basic authentication against the htpasswd entries of a Secret
+type BasicAuth struct {
+	SecretNamespace string
+	SecretName      string
+	SecretType      string
+}
+
+// ParseBasicAuth translates auth-type: basic with auth-secret and auth-secret-type. auth-secret may be
+// <namespace>/<name>, a bare name refers to the Ingress namespace.
+func ParseBasicAuth(annotations map[string]string, namespace string) (BasicAuth, bool, error) {
+	authType, ok := GetNginxAnnotation(annotations, NginxAuthTypeKey)
+	if !ok || authType == "" {
+		return BasicAuth{}, false, nil
+	}
+	if !strings.EqualFold(authType, "basic") {
+		return BasicAuth{}, false, fmt.Errorf("unsupported %s %q (allowed: basic)", NginxAuthTypeKey, authType)
+	}
+	secret, ok := GetNginxAnnotation(annotations, NginxAuthSecretKey)
+	if !ok || secret == "" {
+		return BasicAuth{}, false, fmt.Errorf("%s: basic needs %s", NginxAuthTypeKey, NginxAuthSecretKey)
+	}
+	auth := BasicAuth{SecretNamespace: namespace, SecretName: secret, SecretType: AuthFileSecretType}
+	if secretNamespace, name, found := strings.Cut(secret, "/"); found {
+		auth.SecretNamespace, auth.SecretName = secretNamespace, name
+	}
+	if secretType, ok := GetNginxAnnotation(annotations, NginxAuthSecretTypeKey); ok && secretType != "" {
+		if secretType != AuthFileSecretType && secretType != AuthMapSecretType {
+			return BasicAuth{}, false, fmt.Errorf("unsupported %s %q (allowed: %s, %s)",
+				NginxAuthSecretTypeKey, secretType, AuthFileSecretType, AuthMapSecretType)
+		}
+		auth.SecretType = secretType
+	}
+	return auth, true, nil
+}
+
+// ExternalAuth is an ingress-nginx auth-url pointing at a Service of the cluster
+type ExternalAuth struct {
+	Namespace string
+	Service   string
+	Port      int32
+	Path      string
+	// ResponseHeaders are copied from the auth response to the upstream request
+	ResponseHeaders []string
+}
+
+// ParseExternalAuth translates auth-url and auth-response-headers. Only in-cluster URLs
+// (<service>, <service>.<namespace> or <service>.<namespace>.svc[.cluster.local]) can become a backend
+// reference, other hosts return an error.
+func ParseExternalAuth(annotations map[string]string, namespace string) (ExternalAuth, bool, error) {
+	raw, ok := GetNginxAnnotation(annotations, NginxAuthURLKey)
+	if !ok || raw == "" {
+		return ExternalAuth{}, false, nil
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Hostname() == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return ExternalAuth{}, false, fmt.Errorf("invalid %s %q", NginxAuthURLKey, raw)
+	}
+
+	labels := strings.Split(parsed.Hostname(), ".")
+	auth := ExternalAuth{Namespace: namespace, Service: labels[0], Path: parsed.Path}
+	switch {
+	case len(labels) == 1:
+	case len(labels) == 2,
+		len(labels) == 3 && labels[2] == "svc",
+		len(labels) == 5 && labels[2] == "svc" && labels[3] == "cluster" && labels[4] == "local":
+		auth.Namespace = labels[1]
+	default:
+		return ExternalAuth{}, false, fmt.Errorf("%s %q is not a Service of the cluster", NginxAuthURLKey, raw)
+	}
+
+	auth.Port = 80
+	if parsed.Scheme == "https" {
+		auth.Port = 443
+	}
+	if port := parsed.Port(); port != "" {
+		number, err := strconv.ParseInt(port, 10, 32)
+		if err != nil {
+			return ExternalAuth{}, false, fmt.Errorf("invalid port in %s %q", NginxAuthURLKey, raw)
+		}
+		auth.Port = int32(number)
+	}
+
+	if headers, ok := GetNginxAnnotation(annotations, NginxAuthResponseHeadersKey); ok {
+		for _, header := range strings.Split(headers, ",") {
+			if header = strings.TrimSpace(header); header != "" {
+				auth.ResponseHeaders = append(auth.ResponseHeaders, header)
+			}
+		}
+	}
+	return auth, true, nil
+}
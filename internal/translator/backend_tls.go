@@ -0,0 +1,80 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	NginxProxySSLSecretKey = "proxy-ssl-secret"
+	NginxProxySSLVerifyKey = "proxy-ssl-verify"
+	NginxProxySSLNameKey   = "proxy-ssl-name"
+)
+
+// ProxySSLConfig is the backend TLS configuration requested by the proxy-ssl-* annotations
+type ProxySSLConfig struct {
+	// SecretNamespace and SecretName reference the Secret holding tls.crt/tls.key (client
+	// certificate presented to the backend) and ca.crt (trusted backend CAs)
+	SecretNamespace string
+	SecretName      string
+	// Verify is proxy-ssl-verify: on
+	Verify bool
+	// ServerName is proxy-ssl-name, the name the backend certificate is verified against
+	ServerName string
+}
+
+// ParseProxySSL reads proxy-ssl-secret ([namespace/]name), proxy-ssl-verify and proxy-ssl-name.
+// It returns nil when no proxy-ssl-secret is set.
+func ParseProxySSL(annotations map[string]string, ingressNamespace string) (*ProxySSLConfig, error) {
+	ref, ok := GetNginxAnnotation(annotations, NginxProxySSLSecretKey)
+	ref = strings.TrimSpace(ref)
+	if !ok || ref == "" {
+		return nil, nil
+	}
+
+	config := &ProxySSLConfig{SecretNamespace: ingressNamespace, SecretName: ref}
+	if namespace, name, found := strings.Cut(ref, "/"); found {
+		config.SecretNamespace, config.SecretName = namespace, name
+	}
+	if len(validation.IsDNS1123Label(config.SecretNamespace)) > 0 ||
+		len(validation.IsDNS1123Subdomain(config.SecretName)) > 0 {
+		return nil, fmt.Errorf("invalid proxy-ssl-secret %q (expected namespace/name)", ref)
+	}
+
+	if verify, ok := GetNginxAnnotation(annotations, NginxProxySSLVerifyKey); ok {
+		config.Verify = strings.EqualFold(strings.TrimSpace(verify), "on")
+	}
+	if name, ok := GetNginxAnnotation(annotations, NginxProxySSLNameKey); ok {
+		name = strings.TrimSpace(name)
+		if name != "" && !isValidHostname(name) {
+			return nil, fmt.Errorf("invalid proxy-ssl-name %q", name)
+		}
+		config.ServerName = name
+	}
+	return config, nil
+}
+
+// ProxySSLOwnsVerification reports whether backend certificate verification is handled by a
+// BackendTLSPolicy, in which case proxy-ssl-verify and proxy-ssl-name must not become snippets
+func ProxySSLOwnsVerification(annotations map[string]string) bool {
+	ref, ok := GetNginxAnnotation(annotations, NginxProxySSLSecretKey)
+	return ok && strings.TrimSpace(ref) != ""
+}
@@ -0,0 +1,145 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	// DefaultBackendHTTPRouteSuffix is appended to the HTTPRoute name for the catch-all HTTPRoute
+	DefaultBackendHTTPRouteSuffix = "-default-backend"
+
+	nginxDefaultBackendKey = "default-backend"
+)
+
+// defaultBackendRef returns the backend for spec.defaultBackend or, if unset, the ingress-nginx
+// default-backend annotation (a Service in the Ingress namespace). Port 0 marks ports the
+// controller resolves from the Service.
+func defaultBackendRef(ingress *networkingv1.Ingress) *gatewayv1.HTTPBackendRef {
+	name := ""
+	port := int32(0)
+	if backend := ingress.Spec.DefaultBackend; backend != nil && backend.Service != nil {
+		name = backend.Service.Name
+		port = backend.Service.Port.Number
+	} else if value, ok := GetNginxAnnotation(ingress.Annotations, nginxDefaultBackendKey); ok {
+		name = value
+	}
+	if name == "" {
+		return nil
+	}
+	return &gatewayv1.HTTPBackendRef{
+		BackendRef: gatewayv1.BackendRef{
+			BackendObjectReference: gatewayv1.BackendObjectReference{
+				Name: gatewayv1.ObjectName(name),
+				Port: ptr.To(port),
+			},
+		},
+	}
+}
+
+// hasCatchAllPath reports whether a rule already sends every path of its host to a backend
+func hasCatchAllPath(rule networkingv1.IngressRule) bool {
+	if rule.HTTP == nil {
+		return false
+	}
+	for _, path := range rule.HTTP.Paths {
+		if path.Path != "/" && path.Path != "" {
+			continue
+		}
+		if path.PathType == nil || *path.PathType != networkingv1.PathTypeExact {
+			return true
+		}
+	}
+	return false
+}
+
+// TranslateDefaultBackendToHTTPRoute builds a catch-all HTTPRoute (PathPrefix "/") for
+// spec.defaultBackend or the default-backend annotation. It covers the Ingress hosts that do not
+// already route "/", and every listener of the Gateway when the Ingress has no host rules.
+// Route hostnames make the host-specific routes of other Ingresses take precedence.
+// It returns nil when the Ingress has no default backend or all hosts already route "/".
+func (t *Translator) TranslateDefaultBackendToHTTPRoute(ingress *networkingv1.Ingress) *gatewayv1.HTTPRoute {
+	backendRef := defaultBackendRef(ingress)
+	if backendRef == nil {
+		return nil
+	}
+
+	hostnames := make([]gatewayv1.Hostname, 0)
+	seen := make(map[string]struct{})
+	hasHosts := false
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host == "" {
+			continue
+		}
+		hasHosts = true
+		if hasCatchAllPath(rule) {
+			continue
+		}
+		hostname := t.TransformHostname(rule.Host)
+		if _, ok := seen[hostname]; ok {
+			continue
+		}
+		seen[hostname] = struct{}{}
+		hostnames = append(hostnames, gatewayv1.Hostname(hostname))
+	}
+	if hasHosts && len(hostnames) == 0 {
+		return nil
+	}
+
+	httpRoute := &gatewayv1.HTTPRoute{}
+	httpRoute.Name = t.ResourceName(ingress) + DefaultBackendHTTPRouteSuffix
+	httpRoute.Namespace = ingress.Namespace
+	httpRoute.Annotations = t.FilterAnnotations(ingress.Annotations, t.Config.HTTPRouteAnnotationFilters)
+	if httpRoute.Annotations == nil {
+		httpRoute.Annotations = make(map[string]string)
+	}
+	httpRoute.Annotations[ManagedByAnnotation] = ManagedByValue
+	httpRoute.Annotations[SourceAnnotation] = fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name)
+
+	httpRoute.Spec.Hostnames = hostnames
+	if len(hostnames) > 0 || t.Config.AttachOnly {
+		httpRoute.Spec.ParentRefs = t.buildParentRefs(hostnames)
+	} else {
+		// No host rules: attach to every listener of the Gateway
+		httpRoute.Spec.ParentRefs = []gatewayv1.ParentReference{
+			{
+				Name:      gatewayv1.ObjectName(t.Config.GatewayName),
+				Namespace: (*gatewayv1.Namespace)(&t.Config.GatewayNamespace),
+			},
+		}
+	}
+
+	httpRoute.Spec.Rules = []gatewayv1.HTTPRouteRule{
+		{
+			Matches: []gatewayv1.HTTPRouteMatch{
+				{
+					Path: &gatewayv1.HTTPPathMatch{
+						Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+						Value: ptr.To("/"),
+					},
+				},
+			},
+			BackendRefs: []gatewayv1.HTTPBackendRef{*backendRef},
+		},
+	}
+	return httpRoute
+}
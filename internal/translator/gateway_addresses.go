@@ -0,0 +1,152 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// PinnedAddressesAnnotation records the spec.addresses the operator pinned on a Gateway, so they can be
+// removed again once no rule matches the Gateway anymore
+const PinnedAddressesAnnotation = "ingress-doperator.fiction.si/pinned-addresses"
+
+// GatewayAddressRule pins spec.addresses on the Gateways whose name matches GatewayPattern
+type GatewayAddressRule struct {
+	// GatewayPattern is a glob matched against the Gateway (partition) name
+	GatewayPattern string
+	Addresses      []gatewayv1.GatewaySpecAddress
+}
+
+// GatewayAddressRules are evaluated in order, the first matching rule wins
+type GatewayAddressRules []GatewayAddressRule
+
+// ParseGatewayAddressRules parses comma-separated gateway=address|address entries. An address is
+// [type:]value, the type defaulting to IPAddress; Hostname, NamedAddress and domain-prefixed
+// implementation-specific types are accepted too. An address may only be requested by one entry.
+func ParseGatewayAddressRules(raw string) (GatewayAddressRules, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	rules := make(GatewayAddressRules, 0)
+	requestedBy := make(map[string]string)
+	for _, entry := range strings.Split(raw, ",") {
+		trimmed := strings.TrimSpace(entry)
+		if trimmed == "" {
+			continue
+		}
+		pattern, values, ok := strings.Cut(trimmed, "=")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || pattern == "" || strings.TrimSpace(values) == "" {
+			return nil, fmt.Errorf("invalid entry %q, expected gateway=address|address", trimmed)
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q in entry %q: %w", pattern, trimmed, err)
+		}
+
+		rule := GatewayAddressRule{GatewayPattern: pattern}
+		for _, value := range strings.Split(values, "|") {
+			address, err := parseGatewayAddress(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid address in entry %q: %w", trimmed, err)
+			}
+			key := GatewayAddressKey(address)
+			if other, exists := requestedBy[key]; exists {
+				return nil, fmt.Errorf("address %s is requested for both %s and %s", address.Value, other, pattern)
+			}
+			requestedBy[key] = pattern
+			rule.Addresses = append(rule.Addresses, address)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func parseGatewayAddress(value string) (gatewayv1.GatewaySpecAddress, error) {
+	addressType := gatewayv1.IPAddressType
+	if prefix, rest, ok := strings.Cut(value, ":"); ok && isGatewayAddressType(prefix) {
+		addressType = gatewayv1.AddressType(prefix)
+		value = rest
+	}
+	if value == "" {
+		return gatewayv1.GatewaySpecAddress{}, fmt.Errorf("empty %s address", addressType)
+	}
+	switch addressType {
+	case gatewayv1.IPAddressType:
+		if net.ParseIP(value) == nil {
+			return gatewayv1.GatewaySpecAddress{}, fmt.Errorf("%q is not an IP address", value)
+		}
+	case gatewayv1.HostnameAddressType:
+		if errs := validation.IsDNS1123Subdomain(value); len(errs) > 0 {
+			return gatewayv1.GatewaySpecAddress{}, fmt.Errorf("invalid hostname %q: %s", value, strings.Join(errs, "; "))
+		}
+	}
+	return gatewayv1.GatewaySpecAddress{Type: &addressType, Value: value}, nil
+}
+
+// isGatewayAddressType reports whether prefix names an address type rather than starting an IPv6 address
+func isGatewayAddressType(prefix string) bool {
+	switch gatewayv1.AddressType(prefix) {
+	case gatewayv1.IPAddressType, gatewayv1.HostnameAddressType, gatewayv1.NamedAddressType:
+		return true
+	}
+	return strings.Contains(prefix, "/")
+}
+
+// For returns the addresses pinned on the named Gateway, nil when no rule matches
+func (rules GatewayAddressRules) For(gatewayName string) []gatewayv1.GatewaySpecAddress {
+	for _, rule := range rules {
+		if matched, _ := filepath.Match(rule.GatewayPattern, gatewayName); matched {
+			addresses := make([]gatewayv1.GatewaySpecAddress, len(rule.Addresses))
+			copy(addresses, rule.Addresses)
+			return addresses
+		}
+	}
+	return nil
+}
+
+// GatewayAddressKey identifies an address independent of how its type and IP were spelled
+func GatewayAddressKey(address gatewayv1.GatewaySpecAddress) string {
+	addressType := gatewayv1.IPAddressType
+	if address.Type != nil {
+		addressType = *address.Type
+	}
+	value := address.Value
+	if addressType == gatewayv1.IPAddressType {
+		if ip := net.ParseIP(value); ip != nil {
+			value = ip.String()
+		}
+	}
+	return string(addressType) + ":" + value
+}
+
+// FormatGatewayAddresses renders addresses for PinnedAddressesAnnotation, sorted
+func FormatGatewayAddresses(addresses []gatewayv1.GatewaySpecAddress) string {
+	keys := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		keys = append(keys, GatewayAddressKey(address))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
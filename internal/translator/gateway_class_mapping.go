@@ -0,0 +1,88 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// GatewayClassRule maps ingress classes matching a glob pattern to a target GatewayClass.
+type GatewayClassRule struct {
+	Pattern      string
+	GatewayClass string
+}
+
+// ParseGatewayClassMapping parses rules of the form:
+// ingressClassPattern=gatewayClass,ingressClassPattern2=gatewayClass2
+func ParseGatewayClassMapping(raw string) ([]GatewayClassRule, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	entries := strings.Split(raw, ",")
+	rules := make([]GatewayClassRule, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid gateway-class-mapping entry %q (expected ingressClass=gatewayClass)", entry)
+		}
+		pattern := strings.TrimSpace(parts[0])
+		if pattern == "" {
+			return nil, fmt.Errorf("invalid gateway-class-mapping entry %q (empty ingress class)", entry)
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid gateway-class-mapping pattern %q: %w", pattern, err)
+		}
+		gatewayClass := strings.TrimSpace(parts[1])
+		if errs := validation.IsDNS1123Subdomain(gatewayClass); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid gateway-class-mapping GatewayClass %q: %s", gatewayClass, strings.Join(errs, ", "))
+		}
+		rules = append(rules, GatewayClassRule{Pattern: pattern, GatewayClass: gatewayClass})
+	}
+	return rules, nil
+}
+
+// ResolveGatewayClass returns the GatewayClass of the first rule matching ingressClass.
+func ResolveGatewayClass(rules []GatewayClassRule, ingressClass string) (string, bool) {
+	for _, rule := range rules {
+		if matched, _ := filepath.Match(rule.Pattern, ingressClass); matched {
+			return rule.GatewayClass, true
+		}
+	}
+	return "", false
+}
+
+// GatewayClasses returns the distinct target GatewayClasses of the rules.
+func GatewayClasses(rules []GatewayClassRule) []string {
+	classes := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		if !slices.Contains(classes, rule.GatewayClass) {
+			classes = append(classes, rule.GatewayClass)
+		}
+	}
+	return classes
+}
@@ -0,0 +1,183 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// PinnedInfrastructureAnnotationsAnnotation records the spec.infrastructure.annotations keys the operator set
+// from GatewayInfrastructureAnnotationRules, so they can be removed again once dropped from the configuration
+const PinnedInfrastructureAnnotationsAnnotation = "ingress-doperator.fiction.si/pinned-infrastructure-annotations"
+
+// GatewayInfrastructureAnnotationRule sets spec.infrastructure.annotations on the Gateways whose name
+// matches GatewayPattern
+type GatewayInfrastructureAnnotationRule struct {
+	// GatewayPattern is a glob matched against the Gateway (partition) name
+	GatewayPattern string
+	Annotations    map[string]string
+}
+
+// GatewayInfrastructureAnnotationRules are evaluated in order, the first matching rule wins
+type GatewayInfrastructureAnnotationRules []GatewayInfrastructureAnnotationRule
+
+// ParseGatewayInfrastructureAnnotationRules parses semicolon-separated gateway:key=value,key=value entries
+func ParseGatewayInfrastructureAnnotationRules(raw string) (GatewayInfrastructureAnnotationRules, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	rules := make(GatewayInfrastructureAnnotationRules, 0)
+	for _, entry := range strings.Split(raw, ";") {
+		trimmed := strings.TrimSpace(entry)
+		if trimmed == "" {
+			continue
+		}
+		pattern, pairs, ok := strings.Cut(trimmed, ":")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || pattern == "" || strings.TrimSpace(pairs) == "" {
+			return nil, fmt.Errorf("invalid entry %q, expected gateway:key=value,key=value", trimmed)
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q in entry %q: %w", pattern, trimmed, err)
+		}
+
+		annotations := make(map[string]string)
+		for _, pair := range strings.Split(pairs, ",") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			key, value, ok := strings.Cut(pair, "=")
+			key = strings.TrimSpace(key)
+			if !ok || key == "" {
+				return nil, fmt.Errorf("invalid pair %q in entry %q, expected key=value", pair, trimmed)
+			}
+			annotations[key] = strings.TrimSpace(value)
+		}
+		if len(annotations) == 0 {
+			return nil, fmt.Errorf("entry %q has no annotations", trimmed)
+		}
+		rules = append(rules, GatewayInfrastructureAnnotationRule{GatewayPattern: pattern, Annotations: annotations})
+	}
+	return rules, nil
+}
+
+// For returns the infrastructure annotations of the named Gateway, nil when no rule matches
+func (rules GatewayInfrastructureAnnotationRules) For(gatewayName string) map[string]string {
+	for _, rule := range rules {
+		if matched, _ := filepath.Match(rule.GatewayPattern, gatewayName); matched {
+			annotations := make(map[string]string, len(rule.Annotations))
+			for key, value := range rule.Annotations {
+				annotations[key] = value
+			}
+			return annotations
+		}
+	}
+	return nil
+}
+
+// SyncPinnedInfrastructureAnnotations sets the desired spec.infrastructure.annotations on gateway and removes
+// the ones pinned earlier that are no longer desired. Annotations set by hand or by other flags are kept.
+// Returns whether the Gateway changed.
+func SyncPinnedInfrastructureAnnotations(gateway *gatewayv1.Gateway, desired map[string]string) bool {
+	previous := gateway.Annotations[PinnedInfrastructureAnnotationsAnnotation]
+	if len(desired) == 0 && previous == "" {
+		return false
+	}
+
+	changed := false
+	if gateway.Spec.Infrastructure != nil {
+		for _, key := range strings.Split(previous, ",") {
+			if _, keep := desired[key]; keep || key == "" {
+				continue
+			}
+			annotationKey := gatewayv1.AnnotationKey(key)
+			if _, ok := gateway.Spec.Infrastructure.Annotations[annotationKey]; ok {
+				delete(gateway.Spec.Infrastructure.Annotations, annotationKey)
+				changed = true
+			}
+		}
+		if len(gateway.Spec.Infrastructure.Annotations) == 0 {
+			gateway.Spec.Infrastructure.Annotations = nil
+		}
+	}
+
+	if len(desired) > 0 {
+		if gateway.Spec.Infrastructure == nil {
+			gateway.Spec.Infrastructure = &gatewayv1.GatewayInfrastructure{}
+		}
+		if gateway.Spec.Infrastructure.Annotations == nil {
+			gateway.Spec.Infrastructure.Annotations = make(map[gatewayv1.AnnotationKey]gatewayv1.AnnotationValue,
+				len(desired))
+		}
+		for key, value := range desired {
+			annotationKey := gatewayv1.AnnotationKey(key)
+			if existing, ok := gateway.Spec.Infrastructure.Annotations[annotationKey]; ok &&
+				existing == gatewayv1.AnnotationValue(value) {
+				continue
+			}
+			gateway.Spec.Infrastructure.Annotations[annotationKey] = gatewayv1.AnnotationValue(value)
+			changed = true
+		}
+	}
+
+	pinned := formatAnnotationKeys(desired)
+	if pinned == previous {
+		return changed
+	}
+	if pinned == "" {
+		delete(gateway.Annotations, PinnedInfrastructureAnnotationsAnnotation)
+	} else {
+		if gateway.Annotations == nil {
+			gateway.Annotations = make(map[string]string)
+		}
+		gateway.Annotations[PinnedInfrastructureAnnotationsAnnotation] = pinned
+	}
+	return true
+}
+
+// pinnedInfrastructureAnnotations returns the infrastructure annotations of gateway listed in its
+// PinnedInfrastructureAnnotationsAnnotation
+func pinnedInfrastructureAnnotations(gateway *gatewayv1.Gateway) map[string]string {
+	pinned := gateway.Annotations[PinnedInfrastructureAnnotationsAnnotation]
+	if pinned == "" || gateway.Spec.Infrastructure == nil {
+		return nil
+	}
+	annotations := make(map[string]string)
+	for _, key := range strings.Split(pinned, ",") {
+		if value, ok := gateway.Spec.Infrastructure.Annotations[gatewayv1.AnnotationKey(key)]; ok {
+			annotations[key] = string(value)
+		}
+	}
+	return annotations
+}
+
+// formatAnnotationKeys renders the keys of annotations for PinnedInfrastructureAnnotationsAnnotation, sorted
+func formatAnnotationKeys(annotations map[string]string) string {
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
@@ -26,7 +26,11 @@ import (
 // MergeGatewaySpec merges the desired Gateway spec into the existing Gateway
 // Listeners are merged by hostname (unique by listener name)
 // Annotations are merged (desired overwrites existing on conflict, except for special cases)
+// Infrastructure annotations and labels are merged, desired overwrites existing on conflict
+// Pinned infrastructure annotations that desired no longer pins are removed
 func MergeGatewaySpec(existing, desired *gatewayv1.Gateway) {
+	SyncPinnedInfrastructureAnnotations(existing, pinnedInfrastructureAnnotations(desired))
+
 	// Merge annotations
 	if existing.Annotations == nil {
 		existing.Annotations = make(map[string]string)
@@ -52,7 +56,7 @@ func MergeGatewaySpec(existing, desired *gatewayv1.Gateway) {
 	// Update GatewayClassName
 	existing.Spec.GatewayClassName = desired.Spec.GatewayClassName
 
-	// Merge Infrastructure annotations
+	// Merge Infrastructure annotations and labels
 	if desired.Spec.Infrastructure != nil {
 		if existing.Spec.Infrastructure == nil {
 			existing.Spec.Infrastructure = &gatewayv1.GatewayInfrastructure{
@@ -65,6 +69,7 @@ func MergeGatewaySpec(existing, desired *gatewayv1.Gateway) {
 		for k, v := range desired.Spec.Infrastructure.Annotations {
 			existing.Spec.Infrastructure.Annotations[k] = v
 		}
+		MergeInfrastructureLabels(existing, desired.Spec.Infrastructure.Labels)
 	}
 
 	// Merge listeners - use listener name as unique key
@@ -0,0 +1,164 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	NginxBackendProtocolKey = "backend-protocol"
+
+	backendProtocolGRPC  = "GRPC"
+	backendProtocolGRPCS = "GRPCS"
+)
+
+// IsGRPCBackend reports whether the backend-protocol annotation marks the Ingress backends as gRPC
+func IsGRPCBackend(annotations map[string]string) bool {
+	value, ok := GetNginxAnnotation(annotations, NginxBackendProtocolKey)
+	return ok && (strings.EqualFold(value, backendProtocolGRPC) || strings.EqualFold(value, backendProtocolGRPCS))
+}
+
+// TranslateToGRPCRoute converts a gRPC Ingress to a GRPCRoute resource. Ingress paths become
+// method matchers: /pkg.Service matches the whole service, /pkg.Service/Method a single method
+// and / every request. Paths that are no gRPC method (more segments, regular expressions) are
+// returned so the caller can report them; they are left out of the GRPCRoute.
+func (t *Translator) TranslateToGRPCRoute(ingress *networkingv1.Ingress) (*gatewayv1.GRPCRoute, []string) {
+	grpcRoute := &gatewayv1.GRPCRoute{}
+	grpcRoute.Name = t.ResourceName(ingress)
+	grpcRoute.Namespace = ingress.Namespace
+
+	grpcRoute.Annotations = t.FilterAnnotations(ingress.Annotations, t.Config.HTTPRouteAnnotationFilters)
+	if grpcRoute.Annotations == nil {
+		grpcRoute.Annotations = make(map[string]string)
+	}
+	grpcRoute.Annotations[ManagedByAnnotation] = ManagedByValue
+	grpcRoute.Annotations[SourceAnnotation] = fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name)
+
+	hostnames := make([]gatewayv1.Hostname, 0)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" {
+			hostnames = append(hostnames, gatewayv1.Hostname(t.TransformHostname(rule.Host)))
+		}
+	}
+	grpcRoute.Spec.Hostnames = hostnames
+	grpcRoute.Spec.ParentRefs = t.buildParentRefs(hostnames)
+
+	var filters []gatewayv1.GRPCRouteFilter
+	requestHeaderFilter, responseHeaderFilter := buildHeaderModifierFilters(ingress.Annotations)
+	if requestHeaderFilter != nil {
+		filters = append(filters, gatewayv1.GRPCRouteFilter{
+			Type:                  gatewayv1.GRPCRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: requestHeaderFilter.RequestHeaderModifier,
+		})
+	}
+	if responseHeaderFilter != nil {
+		filters = append(filters, gatewayv1.GRPCRouteFilter{
+			Type:                   gatewayv1.GRPCRouteFilterResponseHeaderModifier,
+			ResponseHeaderModifier: responseHeaderFilter.ResponseHeaderModifier,
+		})
+	}
+
+	useRegex := nginxAnnotationEnabled(ingress.Annotations, nginxUseRegexKey)
+	var unsupported []string
+	var rules []gatewayv1.GRPCRouteRule
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+			if t.Config.ImplementationSpecificPaths.SkipsPath(path) {
+				continue
+			}
+			if t.Config.ImplementationSpecificPaths.RegexPath(useRegex, path) {
+				unsupported = append(unsupported, path.Path)
+				continue
+			}
+			method, ok := grpcMethodMatch(path.Path)
+			if !ok {
+				unsupported = append(unsupported, path.Path)
+				continue
+			}
+
+			backendRef := gatewayv1.GRPCBackendRef{
+				BackendRef: gatewayv1.BackendRef{
+					BackendObjectReference: gatewayv1.BackendObjectReference{
+						Name: gatewayv1.ObjectName(path.Backend.Service.Name),
+					},
+				},
+			}
+			// Named ports are stored as 0 and resolved by the controller
+			port := path.Backend.Service.Port.Number
+			backendRef.Port = &port
+
+			grpcRouteRule := gatewayv1.GRPCRouteRule{
+				BackendRefs: []gatewayv1.GRPCBackendRef{backendRef},
+			}
+			if method != nil {
+				grpcRouteRule.Matches = []gatewayv1.GRPCRouteMatch{{Method: method}}
+			}
+			for _, filter := range filters {
+				grpcRouteRule.Filters = append(grpcRouteRule.Filters, *filter.DeepCopy())
+			}
+			rules = append(rules, grpcRouteRule)
+		}
+	}
+	grpcRoute.Spec.Rules = rules
+
+	return grpcRoute, unsupported
+}
+
+// grpcMethodMatch derives a method matcher from an Ingress path. A nil matcher (with ok set)
+// matches every gRPC request.
+func grpcMethodMatch(path string) (*gatewayv1.GRPCMethodMatch, bool) {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return nil, true
+	}
+	parts := strings.Split(trimmed, "/")
+	if len(parts) > 2 || !strings.HasPrefix(path, "/") {
+		return nil, false
+	}
+	matchType := gatewayv1.GRPCMethodMatchExact
+	match := &gatewayv1.GRPCMethodMatch{
+		Type:    &matchType,
+		Service: &parts[0],
+	}
+	if len(parts) == 2 {
+		match.Method = &parts[1]
+	}
+	return match, true
+}
+
+// GRPCRouteListenerView returns an HTTPRoute carrying the metadata, hostnames and parent references
+// of a GRPCRoute, which is all Gateway listener management looks at
+func GRPCRouteListenerView(grpcRoute *gatewayv1.GRPCRoute) *gatewayv1.HTTPRoute {
+	view := &gatewayv1.HTTPRoute{}
+	grpcRoute.ObjectMeta.DeepCopyInto(&view.ObjectMeta)
+	view.Spec.Hostnames = append([]gatewayv1.Hostname(nil), grpcRoute.Spec.Hostnames...)
+	for i := range grpcRoute.Spec.ParentRefs {
+		view.Spec.ParentRefs = append(view.Spec.ParentRefs, *grpcRoute.Spec.ParentRefs[i].DeepCopy())
+	}
+	return view
+}
@@ -0,0 +1,72 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	// HandoffHostnamesAnnotation lists the hostnames a handoff HTTPRoute keeps serving after they left
+	// their Ingress, with the time the handoff window ends: host=RFC3339[,host=RFC3339...]
+	HandoffHostnamesAnnotation = "ingress-doperator.fiction.si/handoff-hostnames"
+
+	handoffRouteNameSuffix = "-handoff"
+)
+
+// HandoffRouteName returns the name of the handoff HTTPRoute of the Ingress HTTPRoutes named base
+func HandoffRouteName(base string) string {
+	return base + handoffRouteNameSuffix
+}
+
+// IsHandoffRoute reports whether an HTTPRoute only keeps hostnames served during a handoff
+func IsHandoffRoute(route *gatewayv1.HTTPRoute) bool {
+	return route.Annotations[HandoffHostnamesAnnotation] != ""
+}
+
+// ParseHandoffHostnames parses the handoff-hostnames annotation. Entries without a valid deadline
+// expire immediately.
+func ParseHandoffHostnames(value string) map[string]time.Time {
+	hostnames := make(map[string]time.Time)
+	for _, entry := range strings.Split(value, ",") {
+		host, deadline, _ := strings.Cut(strings.TrimSpace(entry), "=")
+		if host == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, deadline)
+		if err != nil {
+			parsed = time.Time{}
+		}
+		hostnames[host] = parsed
+	}
+	return hostnames
+}
+
+// FormatHandoffHostnames formats hostnames and their deadlines for the handoff-hostnames annotation
+func FormatHandoffHostnames(hostnames map[string]time.Time) string {
+	entries := make([]string, 0, len(hostnames))
+	for host, deadline := range hostnames {
+		entries = append(entries, fmt.Sprintf("%s=%s", host, deadline.UTC().Format(time.RFC3339)))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
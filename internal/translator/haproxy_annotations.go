@@ -0,0 +1,261 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	HAProxyAnnotationPrefix = "haproxy.org/"
+
+	HAProxyPathRewriteKey     = "path-rewrite"
+	HAProxySSLRedirectKey     = "ssl-redirect"
+	HAProxySSLRedirectCodeKey = "ssl-redirect-code"
+	HAProxyAllowListKey       = "allow-list"
+	HAProxyDenyListKey        = "deny-list"
+	HAProxyTimeoutConnectKey  = "timeout-connect"
+	HAProxyTimeoutServerKey   = "timeout-server"
+	haproxyLegacyWhitelistKey = "whitelist"
+	haproxyLegacyBlacklistKey = "blacklist"
+
+	nginxAllowlistSourceRangeKey = "allowlist-source-range"
+	nginxDenylistSourceRangeKey  = "denylist-source-range"
+
+	// HAProxy Kubernetes Ingress Controller defaults
+	haproxySSLRedirectCode = 302
+	haproxyDefaultConnect  = 5 * time.Second
+	haproxyDefaultServer   = 50 * time.Second
+
+	haproxyRegexMetaCharacters = `\.+*?()|[]{}^$`
+)
+
+var haproxyTimeUnits = map[string]time.Duration{
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+}
+
+// haproxyStripPrefixRegex matches path-rewrite regexes replacing a literal path prefix, e.g. ^/api/(.*)$
+var haproxyStripPrefixRegex = regexp.MustCompile(`^\^([^\\.+*?()|\[\]{}^$]*)\(\.\*\)\$?$`)
+
+// ParseHAProxyIngressClasses parses a comma-separated list of IngressClass glob patterns
+func ParseHAProxyIngressClasses(value string) ([]string, error) {
+	var patterns []string
+	for _, item := range strings.Split(value, ",") {
+		pattern := strings.TrimSpace(item)
+		if pattern == "" {
+			continue
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid haproxy ingress class pattern %q: %w", pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// GetHAProxyAnnotation returns the value of a haproxy.org/ annotation
+func GetHAProxyAnnotation(annotations map[string]string, key string) (string, bool) {
+	value, ok := annotations[HAProxyAnnotationPrefix+key]
+	return strings.TrimSpace(value), ok
+}
+
+// HAProxyDialect reports whether the haproxy.org/ annotations of the Ingress are translated, i.e. its
+// IngressClass matches one of the HAProxy class patterns
+func (t *Translator) HAProxyDialect(ingress *networkingv1.Ingress) bool {
+	ingressClass := t.GetIngressClass(ingress)
+	for _, pattern := range t.Config.HAProxyIngressClasses {
+		if matched, _ := filepath.Match(pattern, ingressClass); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// haproxyServesPlainHTTP reports whether ssl-redirect is off for an HAProxy Ingress
+func (t *Translator) haproxyServesPlainHTTP(ingress *networkingv1.Ingress) bool {
+	if !t.HAProxyDialect(ingress) {
+		return false
+	}
+	value, ok := GetHAProxyAnnotation(ingress.Annotations, HAProxySSLRedirectKey)
+	return ok && strings.EqualFold(value, "false")
+}
+
+// haproxyRedirectStatusCode returns the ssl-redirect-code of an HAProxy Ingress, 302 by default
+func (t *Translator) haproxyRedirectStatusCode(ingress *networkingv1.Ingress) (int, bool) {
+	if !t.HAProxyDialect(ingress) {
+		return 0, false
+	}
+	value, ok := GetHAProxyAnnotation(ingress.Annotations, HAProxySSLRedirectCodeKey)
+	if !ok {
+		return haproxySSLRedirectCode, true
+	}
+	// 303, 307 and 308 have no Gateway API RequestRedirect equivalent
+	if code, err := strconv.Atoi(value); err == nil && (code == 301 || code == 302) {
+		return code, true
+	}
+	return haproxySSLRedirectCode, true
+}
+
+// HAProxyNginxAnnotations returns the annotations of an HAProxy Ingress with the ingress-nginx equivalents
+// of allow-list and deny-list added (unless set explicitly), so they become SnippetsFilter directives.
+// origins maps every added annotation to the haproxy.org/ annotation it came from.
+func HAProxyNginxAnnotations(annotations map[string]string) (map[string]string, map[string]string) {
+	merged := make(map[string]string, len(annotations)+2)
+	for key, value := range annotations {
+		merged[key] = value
+	}
+	origins := make(map[string]string)
+	for _, mapping := range []struct {
+		nginxKey    string
+		haproxyKeys []string
+	}{
+		{nginxAllowlistSourceRangeKey, []string{HAProxyAllowListKey, haproxyLegacyWhitelistKey}},
+		{nginxDenylistSourceRangeKey, []string{HAProxyDenyListKey, haproxyLegacyBlacklistKey}},
+	} {
+		if _, ok := GetNginxAnnotation(annotations, mapping.nginxKey); ok {
+			continue
+		}
+		for _, haproxyKey := range mapping.haproxyKeys {
+			if value, ok := GetHAProxyAnnotation(annotations, haproxyKey); ok && value != "" {
+				merged[NginxIngressAnnotationPrefix+mapping.nginxKey] = value
+				origins[NginxIngressAnnotationPrefix+mapping.nginxKey] = HAProxyAnnotationPrefix + haproxyKey
+				break
+			}
+		}
+	}
+	return merged, origins
+}
+
+// BuildHAProxyTimeouts translates timeout-connect and timeout-server into HTTPRoute rule timeouts, a
+// backend request may take at most connect + server (HAProxy defaults fill in a missing value).
+// It returns nil, nil when neither annotation is set.
+func BuildHAProxyTimeouts(annotations map[string]string) (*gatewayv1.HTTPRouteTimeouts, error) {
+	total := time.Duration(0)
+	found := false
+	for _, timeout := range []struct {
+		key      string
+		fallback time.Duration
+	}{
+		{HAProxyTimeoutConnectKey, haproxyDefaultConnect},
+		{HAProxyTimeoutServerKey, haproxyDefaultServer},
+	} {
+		value, ok := GetHAProxyAnnotation(annotations, timeout.key)
+		if !ok || value == "" {
+			total += timeout.fallback
+			continue
+		}
+		found = true
+		parsed, err := parseHAProxyTime(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", timeout.key, err)
+		}
+		total += parsed
+	}
+	if !found {
+		return nil, nil
+	}
+	duration, ok := formatGatewayDuration(total)
+	if !ok {
+		return nil, fmt.Errorf("timeouts of %s exceed what Gateway API expresses", total)
+	}
+	return &gatewayv1.HTTPRouteTimeouts{Request: &duration, BackendRequest: &duration}, nil
+}
+
+// parseHAProxyTime parses an HAProxy time value; plain numbers are milliseconds
+func parseHAProxyTime(value string) (time.Duration, error) {
+	digits := strings.TrimRightFunc(value, func(r rune) bool { return r < '0' || r > '9' })
+	unit := time.Millisecond
+	if suffix := value[len(digits):]; suffix != "" {
+		var ok bool
+		if unit, ok = haproxyTimeUnits[suffix]; !ok {
+			return 0, fmt.Errorf("unknown time unit in %q", value)
+		}
+	}
+	number, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("invalid time %q", value)
+	}
+	return time.Duration(number) * unit, nil
+}
+
+// buildHAProxyPathRewriteFilter translates path-rewrite for one rule. A single argument replaces the
+// whole path; "<regex> <replacement>" is supported when the regex strips the literal prefix the rule
+// matches, e.g. "^/api/(.*)$ /\1" on a /api/ prefix rule. It returns an error when the rewrite cannot be
+// expressed with a URLRewrite filter.
+func buildHAProxyPathRewriteFilter(
+	value string,
+	path string,
+	pathType gatewayv1.PathMatchType,
+) (*gatewayv1.HTTPRouteFilter, error) {
+	fields := strings.Fields(value)
+	rewrite := func(modifier gatewayv1.HTTPPathModifier) *gatewayv1.HTTPRouteFilter {
+		return &gatewayv1.HTTPRouteFilter{
+			Type:       gatewayv1.HTTPRouteFilterURLRewrite,
+			URLRewrite: &gatewayv1.HTTPURLRewriteFilter{Path: &modifier},
+		}
+	}
+	switch len(fields) {
+	case 1:
+		if !strings.HasPrefix(fields[0], "/") || strings.ContainsAny(fields[0], `\$`) {
+			return nil, fmt.Errorf("path-rewrite %q is not a path", value)
+		}
+		return rewrite(gatewayv1.HTTPPathModifier{
+			Type:            gatewayv1.FullPathHTTPPathModifier,
+			ReplaceFullPath: ptr.To(fields[0]),
+		}), nil
+	case 2:
+		match := haproxyStripPrefixRegex.FindStringSubmatch(fields[0])
+		replacement, captures := strings.CutSuffix(fields[1], `\1`)
+		if match == nil || !captures || strings.ContainsAny(replacement, haproxyRegexMetaCharacters) {
+			return nil, fmt.Errorf("path-rewrite %q is not a prefix replacement", value)
+		}
+		if pathType != gatewayv1.PathMatchPathPrefix || strings.TrimSuffix(match[1], "/") != strings.TrimSuffix(path, "/") {
+			return nil, fmt.Errorf("path-rewrite %q does not match the rule path %q", value, path)
+		}
+		if replacement == "" {
+			replacement = "/"
+		}
+		return rewrite(gatewayv1.HTTPPathModifier{
+			Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+			ReplacePrefixMatch: ptr.To(replacement),
+		}), nil
+	default:
+		return nil, fmt.Errorf("path-rewrite %q needs one or two arguments", value)
+	}
+}
+
+// haproxyRulePathType returns the path match type of a translated rule, Exact when it has no path match
+func haproxyRulePathType(matches []gatewayv1.HTTPRouteMatch) gatewayv1.PathMatchType {
+	if len(matches) == 0 || matches[0].Path == nil || matches[0].Path.Type == nil {
+		return gatewayv1.PathMatchExact
+	}
+	return *matches[0].Path.Type
+}
@@ -0,0 +1,85 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"sort"
+	"strings"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	// HostnameRenamesAnnotation marks the rename HTTPRoute of an Ingress, which serves the hostnames of the
+	// HostnameRenamePlans next to the ones of its own HTTPRoutes, with the renames it was generated with:
+	// from=to[,from=to...], empty when it serves the hostnames before any rename
+	HostnameRenamesAnnotation = "ingress-doperator.fiction.si/hostname-renames"
+
+	renameRouteNameSuffix = "-rename"
+)
+
+// HostnameRename replaces the hostname suffix From with To after the hostname rewrite
+type HostnameRename struct {
+	From string
+	To   string
+}
+
+// RenameRouteName returns the name of the rename HTTPRoute of the Ingress HTTPRoutes named base
+func RenameRouteName(base string) string {
+	return base + renameRouteNameSuffix
+}
+
+// IsRenameRoute reports whether an HTTPRoute only serves the other hostnames of a hostname rename
+func IsRenameRoute(route *gatewayv1.HTTPRoute) bool {
+	_, ok := route.Annotations[HostnameRenamesAnnotation]
+	return ok
+}
+
+// ParseHostnameRenames parses the hostname-renames annotation, skipping malformed entries
+func ParseHostnameRenames(value string) []HostnameRename {
+	var renames []HostnameRename
+	for _, entry := range strings.Split(value, ",") {
+		from, to, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if ok && from != "" && to != "" {
+			renames = append(renames, HostnameRename{From: from, To: to})
+		}
+	}
+	return renames
+}
+
+// FormatHostnameRenames formats renames for the hostname-renames annotation
+func FormatHostnameRenames(renames []HostnameRename) string {
+	entries := make([]string, 0, len(renames))
+	for _, rename := range renames {
+		entries = append(entries, rename.From+"="+rename.To)
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
+// renameHostname replaces the suffix of the first rename matching hostname
+func (t *Translator) renameHostname(hostname string) string {
+	for _, rename := range t.Config.HostnameRenames {
+		if hostname == rename.From {
+			return rename.To
+		}
+		if prefix, ok := strings.CutSuffix(hostname, "."+rename.From); ok {
+			return prefix + "." + rename.To
+		}
+	}
+	return hostname
+}
@@ -0,0 +1,195 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	// PairedHTTPListenerAnnotation overrides --paired-http-listeners for one Ingress ("true" or "false")
+	PairedHTTPListenerAnnotation = "ingress-doperator.fiction.si/paired-http-listener"
+
+	// HTTPRedirectHTTPRouteSuffix is appended to the HTTPRoute name for the HTTP to HTTPS redirect HTTPRoute
+	HTTPRedirectHTTPRouteSuffix = "-http-redirect"
+
+	// httpListenerNamePrefix tells the plain HTTP listener of a hostname apart from its HTTPS listener
+	httpListenerNamePrefix = "http80."
+
+	// httpsRedirectStatusCode matches the ingress-nginx default http-redirect-code
+	httpsRedirectStatusCode = 308
+
+	nginxSSLRedirectKey = "ssl-redirect"
+)
+
+// HTTPListenerName returns the name of the paired port 80 listener for a listener hostname
+func HTTPListenerName(listenerHostname string) gatewayv1.SectionName {
+	return gatewayv1.SectionName(httpListenerNamePrefix + string(ListenerName(listenerHostname)))
+}
+
+// IsHTTPListenerName reports whether a section name refers to a paired HTTP listener
+func IsHTTPListenerName(name gatewayv1.SectionName) bool {
+	return strings.HasPrefix(string(name), httpListenerNamePrefix)
+}
+
+// PairedHTTPListeners reports whether the TLS hosts of the Ingress also get a port 80 listener
+func (t *Translator) PairedHTTPListeners(ingress *networkingv1.Ingress) bool {
+	if t.Config.AttachOnly {
+		// The pre-provisioned Gateway owns its listeners
+		return false
+	}
+	if value, ok := ingress.Annotations[PairedHTTPListenerAnnotation]; ok {
+		if enabled, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			return enabled
+		}
+	}
+	return t.Config.PairedHTTPListeners
+}
+
+// servesPlainHTTP reports whether ssl-redirect (or haproxy.org/ssl-redirect) is off or a Traefik router or
+// ALB listens on HTTP without redirecting, i.e. TLS hosts answer HTTP requests themselves instead of
+// redirecting them to HTTPS
+func (t *Translator) servesPlainHTTP(ingress *networkingv1.Ingress) bool {
+	if t.traefikServesPlainHTTP(ingress) || t.haproxyServesPlainHTTP(ingress) || albServesPlainHTTP(ingress) {
+		return true
+	}
+	value, ok := GetNginxAnnotation(ingress.Annotations, nginxSSLRedirectKey)
+	return ok && strings.EqualFold(value, "false")
+}
+
+// pairedHTTPHostnames returns the (rewritten) hostnames of the HTTPS listeners the Ingress TLS creates:
+// rule hosts with a TLS entry and, unless ignored, TLS-only hosts
+func (t *Translator) pairedHTTPHostnames(ingress *networkingv1.Ingress, includeTLSOnly bool) []gatewayv1.Hostname {
+	tlsHosts := make(map[string]bool)
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			tlsHosts[host] = true
+		}
+	}
+	seen := make(map[string]bool)
+	hostnames := make([]gatewayv1.Hostname, 0, len(tlsHosts))
+	add := func(host string) {
+		if host == "" || !tlsHosts[host] || seen[host] {
+			return
+		}
+		seen[host] = true
+		hostnames = append(hostnames, gatewayv1.Hostname(t.TransformHostname(host)))
+	}
+	for _, rule := range ingress.Spec.Rules {
+		add(rule.Host)
+	}
+	if includeTLSOnly && t.Config.TLSOnlyHosts.Serves() {
+		for _, host := range GetTLSOnlyHosts(ingress) {
+			add(host)
+		}
+	}
+	return hostnames
+}
+
+// buildHTTPParentRefs attaches a route to the paired HTTP listeners of hostnames
+func (t *Translator) buildHTTPParentRefs(hostnames []gatewayv1.Hostname) []gatewayv1.ParentReference {
+	parentRefs := make([]gatewayv1.ParentReference, 0, len(hostnames))
+	seen := make(map[gatewayv1.SectionName]bool, len(hostnames))
+	for _, hostname := range hostnames {
+		sectionName := HTTPListenerName(t.ListenerHostname(string(hostname)))
+		if seen[sectionName] {
+			continue
+		}
+		seen[sectionName] = true
+		parentRefs = append(parentRefs, gatewayv1.ParentReference{
+			Name:        gatewayv1.ObjectName(t.Config.GatewayName),
+			Namespace:   (*gatewayv1.Namespace)(&t.Config.GatewayNamespace),
+			SectionName: &sectionName,
+		})
+	}
+	return parentRefs
+}
+
+// plainHTTPParentRefs returns the paired HTTP listeners the main HTTPRoute attaches to: with ssl-redirect
+// off, TLS hosts serve their rules on port 80 as well
+func (t *Translator) plainHTTPParentRefs(ingress *networkingv1.Ingress) []gatewayv1.ParentReference {
+	if !t.PairedHTTPListeners(ingress) || !t.servesPlainHTTP(ingress) {
+		return nil
+	}
+	return t.buildHTTPParentRefs(t.pairedHTTPHostnames(ingress, false))
+}
+
+// TranslateHTTPRedirectToHTTPRoute builds an HTTPRoute on the paired HTTP listeners of the TLS hosts
+// that redirects every request to HTTPS, like ingress-nginx does with ssl-redirect (on by default).
+// It returns nil when paired HTTP listeners are off, ssl-redirect is off or the Ingress has no TLS hosts.
+func (t *Translator) TranslateHTTPRedirectToHTTPRoute(ingress *networkingv1.Ingress) *gatewayv1.HTTPRoute {
+	if !t.PairedHTTPListeners(ingress) || t.servesPlainHTTP(ingress) {
+		return nil
+	}
+	statusCode := httpsRedirectStatusCode
+	if code, ok := t.traefikRedirectStatusCode(ingress); ok {
+		statusCode = code
+	} else if code, ok := t.haproxyRedirectStatusCode(ingress); ok {
+		statusCode = code
+	} else if code, ok := albRedirectStatusCode(ingress); ok {
+		statusCode = code
+	}
+	hostnames := t.pairedHTTPHostnames(ingress, true)
+	if len(hostnames) == 0 {
+		return nil
+	}
+
+	httpRoute := &gatewayv1.HTTPRoute{}
+	httpRoute.Name = t.ResourceName(ingress) + HTTPRedirectHTTPRouteSuffix
+	httpRoute.Namespace = ingress.Namespace
+	httpRoute.Annotations = t.FilterAnnotations(ingress.Annotations, t.Config.HTTPRouteAnnotationFilters)
+	if httpRoute.Annotations == nil {
+		httpRoute.Annotations = make(map[string]string)
+	}
+	httpRoute.Annotations[ManagedByAnnotation] = ManagedByValue
+	httpRoute.Annotations[SourceAnnotation] = fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name)
+	httpRoute.Spec.Hostnames = hostnames
+	httpRoute.Spec.ParentRefs = t.buildHTTPParentRefs(hostnames)
+	httpRoute.Spec.Rules = []gatewayv1.HTTPRouteRule{
+		{
+			Filters: []gatewayv1.HTTPRouteFilter{
+				{
+					Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+					RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+						Scheme:     ptr.To("https"),
+						StatusCode: ptr.To(statusCode),
+					},
+				},
+			},
+		},
+	}
+	return httpRoute
+}
+
+// HTTPListenerNames returns the paired HTTP listeners the Ingress needs
+func (t *Translator) HTTPListenerNames(ingress *networkingv1.Ingress) []gatewayv1.SectionName {
+	if !t.PairedHTTPListeners(ingress) {
+		return nil
+	}
+	parentRefs := t.buildHTTPParentRefs(t.pairedHTTPHostnames(ingress, !t.servesPlainHTTP(ingress)))
+	names := make([]gatewayv1.SectionName, 0, len(parentRefs))
+	for _, parentRef := range parentRefs {
+		names = append(names, *parentRef.SectionName)
+	}
+	return names
+}
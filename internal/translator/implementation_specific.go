@@ -0,0 +1,92 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// ImplementationSpecificPolicy controls how paths of pathType ImplementationSpecific are translated, since
+// ingress-nginx installs interpret them differently
+type ImplementationSpecificPolicy string
+
+const (
+	// ImplementationSpecificAuto matches RegularExpression on use-regex Ingresses and PathPrefix otherwise
+	ImplementationSpecificAuto ImplementationSpecificPolicy = "auto"
+	// ImplementationSpecificPrefix always matches PathPrefix, even on use-regex Ingresses
+	ImplementationSpecificPrefix ImplementationSpecificPolicy = "treat-as-prefix"
+	// ImplementationSpecificRegex always matches RegularExpression, like ingress-nginx with use-regex
+	ImplementationSpecificRegex ImplementationSpecificPolicy = "treat-as-regex"
+	// ImplementationSpecificSkip leaves the paths out of the routes, the Ingress gets a warning
+	ImplementationSpecificSkip ImplementationSpecificPolicy = "skip-with-warning"
+)
+
+// ParseImplementationSpecificPolicy converts a flag value into an ImplementationSpecificPolicy
+func ParseImplementationSpecificPolicy(value string) (ImplementationSpecificPolicy, error) {
+	switch ImplementationSpecificPolicy(value) {
+	case ImplementationSpecificAuto, ImplementationSpecificPrefix, ImplementationSpecificRegex,
+		ImplementationSpecificSkip:
+		return ImplementationSpecificPolicy(value), nil
+	default:
+		return ImplementationSpecificAuto, fmt.Errorf("invalid implementation-specific-paths value %q "+
+			"(allowed: auto, treat-as-prefix, treat-as-regex, skip-with-warning)", value)
+	}
+}
+
+// isImplementationSpecific reports whether path is a non-empty path of pathType ImplementationSpecific
+func isImplementationSpecific(path networkingv1.HTTPIngressPath) bool {
+	return path.Path != "" && path.PathType != nil && *path.PathType == networkingv1.PathTypeImplementationSpecific
+}
+
+// RegexPath reports whether path becomes a RegularExpression match on an Ingress whose use-regex
+// annotation is useRegex (provided the GatewayClass supports them)
+func (p ImplementationSpecificPolicy) RegexPath(useRegex bool, path networkingv1.HTTPIngressPath) bool {
+	if !isImplementationSpecific(path) {
+		return false
+	}
+	switch p {
+	case ImplementationSpecificRegex:
+		return true
+	case ImplementationSpecificPrefix, ImplementationSpecificSkip:
+		return false
+	default:
+		return useRegex
+	}
+}
+
+// SkipsPath reports whether path is left out of the routes
+func (p ImplementationSpecificPolicy) SkipsPath(path networkingv1.HTTPIngressPath) bool {
+	return p == ImplementationSpecificSkip && isImplementationSpecific(path)
+}
+
+// SkippedPaths returns the host and path of every path of ingress the policy leaves out
+func (p ImplementationSpecificPolicy) SkippedPaths(ingress *networkingv1.Ingress) []string {
+	var skipped []string
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if p.SkipsPath(path) {
+				skipped = append(skipped, rule.Host+path.Path)
+			}
+		}
+	}
+	return skipped
+}
@@ -0,0 +1,92 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// InfrastructureLabels returns the Ingress labels whose key starts with one of the configured
+// infrastructure label prefixes, to be set on spec.infrastructure.labels of the Gateway
+func (t *Translator) InfrastructureLabels(ingress *networkingv1.Ingress) map[gatewayv1.LabelKey]gatewayv1.LabelValue {
+	if len(t.Config.InfrastructureLabelPrefixes) == 0 {
+		return nil
+	}
+	labels := make(map[gatewayv1.LabelKey]gatewayv1.LabelValue)
+	for key, value := range ingress.Labels {
+		for _, prefix := range t.Config.InfrastructureLabelPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				labels[gatewayv1.LabelKey(key)] = gatewayv1.LabelValue(value)
+				break
+			}
+		}
+	}
+	return labels
+}
+
+// sharedInfrastructureLabels merges the infrastructure labels of all Ingresses. Label values cannot be
+// joined like annotations, so on conflict the first Ingress by namespace/name wins.
+func (t *Translator) sharedInfrastructureLabels(
+	ingresses []networkingv1.Ingress,
+) map[gatewayv1.LabelKey]gatewayv1.LabelValue {
+	sorted := make([]*networkingv1.Ingress, 0, len(ingresses))
+	for i := range ingresses {
+		sorted = append(sorted, &ingresses[i])
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return fmt.Sprintf("%s/%s", sorted[i].Namespace, sorted[i].Name) <
+			fmt.Sprintf("%s/%s", sorted[j].Namespace, sorted[j].Name)
+	})
+
+	labels := make(map[gatewayv1.LabelKey]gatewayv1.LabelValue)
+	for _, ingress := range sorted {
+		for key, value := range t.InfrastructureLabels(ingress) {
+			if _, exists := labels[key]; !exists {
+				labels[key] = value
+			}
+		}
+	}
+	return labels
+}
+
+// MergeInfrastructureLabels sets labels on spec.infrastructure.labels of the Gateway and reports whether
+// anything changed. Labels not in labels are left alone since other Ingresses may have set them.
+func MergeInfrastructureLabels(gateway *gatewayv1.Gateway, labels map[gatewayv1.LabelKey]gatewayv1.LabelValue) bool {
+	if len(labels) == 0 {
+		return false
+	}
+	if gateway.Spec.Infrastructure == nil {
+		gateway.Spec.Infrastructure = &gatewayv1.GatewayInfrastructure{}
+	}
+	if gateway.Spec.Infrastructure.Labels == nil {
+		gateway.Spec.Infrastructure.Labels = make(map[gatewayv1.LabelKey]gatewayv1.LabelValue, len(labels))
+	}
+	changed := false
+	for key, value := range labels {
+		if existing, ok := gateway.Spec.Infrastructure.Labels[key]; ok && existing == value {
+			continue
+		}
+		gateway.Spec.Infrastructure.Labels[key] = value
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,81 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	NginxLoadBalanceKey = "load-balance"
+
+	// ConsistentHashLoadBalancingMethod matches ingress-nginx upstream-hash-by, which always
+	// configures "hash <key> consistent"
+	ConsistentHashLoadBalancingMethod = "hash consistent"
+
+	// RoundRobinLoadBalancingMethod is the ingress-nginx default. It has to be set explicitly, the
+	// UpstreamSettingsPolicy default is "random two least_conn".
+	RoundRobinLoadBalancingMethod = "round_robin"
+
+	// ewmaLoadBalancingMethod approximates ingress-nginx ewma: NGINX has no ewma balancer, power of two
+	// choices over the least connections is the closest latency-aware method
+	ewmaLoadBalancingMethod = "random two least_conn"
+)
+
+// UpstreamLoadBalancing is the load balancing an Ingress asks for through ingress-nginx annotations,
+// expressed as NGF UpstreamSettingsPolicy loadBalancingMethod and hashMethodKey
+type UpstreamLoadBalancing struct {
+	Method  string
+	HashKey string
+	// Annotation is the ingress-nginx annotation the method was translated from
+	Annotation string
+	// Approximated is set when NGINX cannot reproduce the ingress-nginx algorithm exactly
+	Approximated bool
+}
+
+// ParseUpstreamLoadBalancing translates upstream-hash-by and load-balance. upstream-hash-by takes
+// precedence like in ingress-nginx.
+func ParseUpstreamLoadBalancing(annotations map[string]string) (UpstreamLoadBalancing, bool, error) {
+	if hashKey, ok := GetNginxAnnotation(annotations, NginxUpstreamHashByKey); ok && hashKey != "" {
+		return UpstreamLoadBalancing{
+			Method:     ConsistentHashLoadBalancingMethod,
+			HashKey:    hashKey,
+			Annotation: NginxUpstreamHashByKey,
+		}, true, nil
+	}
+
+	value, ok := GetNginxAnnotation(annotations, NginxLoadBalanceKey)
+	if !ok || value == "" {
+		return UpstreamLoadBalancing{}, false, nil
+	}
+	switch strings.ToLower(value) {
+	case RoundRobinLoadBalancingMethod:
+		return UpstreamLoadBalancing{Method: RoundRobinLoadBalancingMethod, Annotation: NginxLoadBalanceKey}, true, nil
+	case "ip_hash":
+		return UpstreamLoadBalancing{Method: "ip_hash", Annotation: NginxLoadBalanceKey}, true, nil
+	case "ewma":
+		return UpstreamLoadBalancing{
+			Method:       ewmaLoadBalancingMethod,
+			Annotation:   NginxLoadBalanceKey,
+			Approximated: true,
+		}, true, nil
+	default:
+		return UpstreamLoadBalancing{}, false, fmt.Errorf("unsupported load-balance %q (allowed: round_robin, ewma, ip_hash)",
+			value)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import "testing"
+
+func TestParseUpstreamLoadBalancing(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        UpstreamLoadBalancing
+		wantOK      bool
+		wantErr     bool
+	}{
+		{name: "none", annotations: map[string]string{}},
+		{
+			name:        "round_robin is explicit",
+			annotations: map[string]string{NginxIngressAnnotationPrefix + NginxLoadBalanceKey: "round_robin"},
+			want:        UpstreamLoadBalancing{Method: "round_robin", Annotation: NginxLoadBalanceKey},
+			wantOK:      true,
+		},
+		{
+			name:        "ip_hash",
+			annotations: map[string]string{NginxIngressAnnotationPrefix + NginxLoadBalanceKey: "IP_HASH"},
+			want:        UpstreamLoadBalancing{Method: "ip_hash", Annotation: NginxLoadBalanceKey},
+			wantOK:      true,
+		},
+		{
+			name:        "ewma is approximated",
+			annotations: map[string]string{NginxIngressAnnotationPrefix + NginxLoadBalanceKey: "ewma"},
+			want: UpstreamLoadBalancing{
+				Method:       "random two least_conn",
+				Annotation:   NginxLoadBalanceKey,
+				Approximated: true,
+			},
+			wantOK: true,
+		},
+		{
+			name: "upstream-hash-by takes precedence",
+			annotations: map[string]string{
+				NginxIngressAnnotationPrefix + NginxLoadBalanceKey:    "round_robin",
+				NginxIngressAnnotationPrefix + NginxUpstreamHashByKey: "$remote_addr",
+			},
+			want: UpstreamLoadBalancing{
+				Method:     ConsistentHashLoadBalancingMethod,
+				HashKey:    "$remote_addr",
+				Annotation: NginxUpstreamHashByKey,
+			},
+			wantOK: true,
+		},
+		{
+			name:        "unsupported",
+			annotations: map[string]string{NginxIngressAnnotationPrefix + NginxLoadBalanceKey: "least_time"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := ParseUpstreamLoadBalancing(tt.annotations)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ok != tt.wantOK || got != tt.want {
+				t.Fatalf("expected %+v (ok=%v), got %+v (ok=%v)", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
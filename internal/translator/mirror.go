@@ -0,0 +1,163 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	NginxMirrorTargetKey = "nginx.ingress.kubernetes.io/mirror-target"
+	NginxMirrorHostKey   = "nginx.ingress.kubernetes.io/mirror-host"
+
+	// MirrorReferenceGrantPrefix prefixes ReferenceGrants that allow mirroring to Services in other namespaces
+	MirrorReferenceGrantPrefix = "ingress-doperator-mirror-"
+)
+
+// MirrorBackend is the in-cluster Service requests get mirrored to
+type MirrorBackend struct {
+	Namespace string
+	Name      string
+	Port      int32
+}
+
+// ResolveMirrorBackend resolves the mirror-target annotation to a Service. ingress-nginx mirrors to
+// arbitrary URLs, Gateway API only to backends, so the target host has to be a cluster Service name
+// (svc, svc.ns, svc.ns.svc or svc.ns.svc.cluster.local). When it is not, mirror-host is tried instead.
+// The request path of the target (usually $request_uri) is dropped, mirrored requests keep theirs.
+func ResolveMirrorBackend(ingress *networkingv1.Ingress) (*MirrorBackend, error) {
+	rawTarget := strings.TrimSpace(ingress.Annotations[NginxMirrorTargetKey])
+	if rawTarget == "" {
+		return nil, nil
+	}
+
+	// Cut nginx variables such as $request_uri, they can't be parsed as part of a URL
+	if idx := strings.Index(rawTarget, "$"); idx >= 0 {
+		rawTarget = rawTarget[:idx]
+	}
+	if !strings.Contains(rawTarget, "://") {
+		rawTarget = "http://" + rawTarget
+	}
+	target, err := url.Parse(rawTarget)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mirror-target %q: %w", ingress.Annotations[NginxMirrorTargetKey], err)
+	}
+
+	port := int32(80)
+	if target.Scheme == "https" {
+		port = 443
+	}
+	if rawPort := target.Port(); rawPort != "" {
+		parsed, err := strconv.ParseInt(rawPort, 10, 32)
+		if err != nil || parsed < 1 || parsed > 65535 {
+			return nil, fmt.Errorf("invalid mirror-target port %q", rawPort)
+		}
+		port = int32(parsed)
+	}
+
+	for _, host := range []string{target.Hostname(), strings.TrimSpace(ingress.Annotations[NginxMirrorHostKey])} {
+		if namespace, name, ok := serviceFromHost(host, ingress.Namespace); ok {
+			return &MirrorBackend{Namespace: namespace, Name: name, Port: port}, nil
+		}
+	}
+	return nil, fmt.Errorf("mirror-target %q does not point at a cluster Service", ingress.Annotations[NginxMirrorTargetKey])
+}
+
+// serviceFromHost extracts the Service namespace and name from a cluster DNS name
+func serviceFromHost(host, defaultNamespace string) (string, string, bool) {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" || net.ParseIP(host) != nil {
+		return "", "", false
+	}
+	host = strings.TrimSuffix(host, ".cluster.local")
+	host = strings.TrimSuffix(host, ".svc")
+
+	parts := strings.Split(host, ".")
+	namespace := defaultNamespace
+	switch len(parts) {
+	case 1:
+	case 2:
+		namespace = parts[1]
+	default:
+		return "", "", false
+	}
+	if len(validation.IsDNS1035Label(parts[0])) > 0 || len(validation.IsDNS1123Label(namespace)) > 0 {
+		return "", "", false
+	}
+	return namespace, parts[0], true
+}
+
+// buildMirrorFilter returns a RequestMirror filter for the mirror backend
+func buildMirrorFilter(routeNamespace string, backend *MirrorBackend) *gatewayv1.HTTPRouteFilter {
+	if backend == nil {
+		return nil
+	}
+	port := backend.Port
+	ref := gatewayv1.BackendObjectReference{
+		Name: gatewayv1.ObjectName(backend.Name),
+		Port: &port,
+	}
+	if backend.Namespace != routeNamespace {
+		namespace := gatewayv1.Namespace(backend.Namespace)
+		ref.Namespace = &namespace
+	}
+	return &gatewayv1.HTTPRouteFilter{
+		Type:          gatewayv1.HTTPRouteFilterRequestMirror,
+		RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{BackendRef: ref},
+	}
+}
+
+// MirrorReferenceGrantName returns the name of the ReferenceGrant that lets HTTPRoutes in
+// routeNamespace mirror to Services in another namespace
+func MirrorReferenceGrantName(routeNamespace string) string {
+	return MirrorReferenceGrantPrefix + routeNamespace
+}
+
+// CrossNamespaceMirrorTargets returns the Services, grouped by namespace, that HTTPRoutes
+// mirror to outside of their own namespace
+func CrossNamespaceMirrorTargets(httpRoutes []*gatewayv1.HTTPRoute) map[string][]string {
+	targets := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, httpRoute := range httpRoutes {
+		for _, rule := range httpRoute.Spec.Rules {
+			for _, filter := range rule.Filters {
+				if filter.Type != gatewayv1.HTTPRouteFilterRequestMirror || filter.RequestMirror == nil {
+					continue
+				}
+				ref := filter.RequestMirror.BackendRef
+				if ref.Namespace == nil || string(*ref.Namespace) == httpRoute.Namespace {
+					continue
+				}
+				key := string(*ref.Namespace) + "/" + string(ref.Name)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				targets[string(*ref.Namespace)] = append(targets[string(*ref.Namespace)], string(ref.Name))
+			}
+		}
+	}
+	return targets
+}
@@ -0,0 +1,231 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// maxTemplatedNameLength leaves room for the suffixes of derived names (-http-redirect, automatic-...-annotations)
+	maxTemplatedNameLength = 200
+	hostHashLength         = 8
+	// maxResourceNameLength is the limit of DNS subdomain names
+	maxResourceNameLength = 253
+)
+
+// NameTemplateData is what a name template can refer to
+type NameTemplateData struct {
+	IngressName  string
+	Namespace    string
+	IngressClass string
+	// HostHash is a short hash of the sorted Ingress hosts
+	HostHash string
+}
+
+// NameTemplate renders the base name of the Gateways, HTTPRoutes and SnippetsFilters generated for an
+// Ingress, e.g. "{{.IngressName}}-{{.HostHash}}". A nil NameTemplate uses the Ingress name.
+type NameTemplate struct {
+	raw  string
+	tmpl *template.Template
+}
+
+// ParseNameTemplate parses a Go template for generated resource names, empty means the Ingress name
+func ParseNameTemplate(raw string) (*NameTemplate, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid name template %q: %w", raw, err)
+	}
+	nameTemplate := &NameTemplate{raw: raw, tmpl: tmpl}
+	sample := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "sample", Namespace: "default"}}
+	if _, err := nameTemplate.render(sample); err != nil {
+		return nil, fmt.Errorf("invalid name template %q: %w", raw, err)
+	}
+	return nameTemplate, nil
+}
+
+// String returns the template source
+func (n *NameTemplate) String() string {
+	if n == nil {
+		return ""
+	}
+	return n.raw
+}
+
+// Name returns the base name for resources generated from ingress. Rendering errors were ruled out by
+// ParseNameTemplate, should one still happen (or the result be empty) the Ingress name is used.
+func (n *NameTemplate) Name(ingress *networkingv1.Ingress) string {
+	if n == nil {
+		return ingress.Name
+	}
+	name, err := n.render(ingress)
+	if err != nil || name == "" {
+		return ingress.Name
+	}
+	return name
+}
+
+func (n *NameTemplate) render(ingress *networkingv1.Ingress) (string, error) {
+	className := ""
+	if ingress.Spec.IngressClassName != nil {
+		className = *ingress.Spec.IngressClassName
+	}
+	data := NameTemplateData{
+		IngressName:  ingress.Name,
+		Namespace:    ingress.Namespace,
+		IngressClass: className,
+		HostHash:     ingressHostHash(ingress),
+	}
+	var out bytes.Buffer
+	if err := n.tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return sanitizeResourceName(out.String(), maxTemplatedNameLength), nil
+}
+
+// ingressHostHash hashes the sorted, unique hosts of an Ingress so the result does not depend on rule order
+func ingressHostHash(ingress *networkingv1.Ingress) string {
+	seen := make(map[string]bool)
+	hosts := make([]string, 0, len(ingress.Spec.Rules))
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" && !seen[rule.Host] {
+			seen[rule.Host] = true
+			hosts = append(hosts, rule.Host)
+		}
+	}
+	sort.Strings(hosts)
+	sum := sha256.Sum256([]byte(strings.Join(hosts, ",")))
+	return hex.EncodeToString(sum[:])[:hostHashLength]
+}
+
+// sanitizeResourceName turns a rendered template into a valid DNS subdomain name of at most maxLength
+func sanitizeResourceName(name string, maxLength int) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		default:
+			return '-'
+		}
+	}, strings.TrimSpace(name))
+	if len(name) > maxLength {
+		name = name[:maxLength]
+	}
+	return strings.Trim(name, "-.")
+}
+
+// SnippetsFilterNameData is what a SnippetsFilter name template can refer to
+type SnippetsFilterNameData struct {
+	NameTemplateData
+	// BaseName is the name the NameTemplate gives the other resources generated for the Ingress
+	BaseName string
+	// IngressHash is a short hash of namespace/name, unique per Ingress
+	IngressHash string
+}
+
+// SnippetsFilterNameTemplate renders the names of automatic SnippetsFilters, e.g.
+// "{{.Namespace}}-{{.IngressName}}-{{.IngressHash}}". A nil SnippetsFilterNameTemplate renders nothing,
+// the caller falls back to the default name.
+type SnippetsFilterNameTemplate struct {
+	raw  string
+	tmpl *template.Template
+}
+
+// ParseSnippetsFilterNameTemplate parses a Go template for automatic SnippetsFilter names, empty means the
+// default name
+func ParseSnippetsFilterNameTemplate(raw string) (*SnippetsFilterNameTemplate, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("snippets-filter-name").Option("missingkey=error").Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SnippetsFilter name template %q: %w", raw, err)
+	}
+	nameTemplate := &SnippetsFilterNameTemplate{raw: raw, tmpl: tmpl}
+	sample := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "sample", Namespace: "default"}}
+	name, err := nameTemplate.render(sample, sample.Name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SnippetsFilter name template %q: %w", raw, err)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("invalid SnippetsFilter name template %q: renders an empty name", raw)
+	}
+	return nameTemplate, nil
+}
+
+// String returns the template source
+func (n *SnippetsFilterNameTemplate) String() string {
+	if n == nil {
+		return ""
+	}
+	return n.raw
+}
+
+// Name returns the automatic SnippetsFilter name for ingress, whose other resources are named baseName.
+// It is empty for a nil template or when rendering fails.
+func (n *SnippetsFilterNameTemplate) Name(ingress *networkingv1.Ingress, baseName string) string {
+	if n == nil {
+		return ""
+	}
+	name, err := n.render(ingress, baseName)
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+func (n *SnippetsFilterNameTemplate) render(ingress *networkingv1.Ingress, baseName string) (string, error) {
+	className := ""
+	if ingress.Spec.IngressClassName != nil {
+		className = *ingress.Spec.IngressClassName
+	}
+	sum := sha256.Sum256([]byte(ingress.Namespace + "/" + ingress.Name))
+	data := SnippetsFilterNameData{
+		NameTemplateData: NameTemplateData{
+			IngressName:  ingress.Name,
+			Namespace:    ingress.Namespace,
+			IngressClass: className,
+			HostHash:     ingressHostHash(ingress),
+		},
+		BaseName:    baseName,
+		IngressHash: hex.EncodeToString(sum[:])[:hostHashLength],
+	}
+	var out bytes.Buffer
+	if err := n.tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return sanitizeResourceName(out.String(), maxResourceNameLength), nil
+}
+
+// ResourceName returns the base name of the resources generated for ingress
+func (t *Translator) ResourceName(ingress *networkingv1.Ingress) string {
+	return t.Config.NameTemplate.Name(ingress)
+}
@@ -0,0 +1,241 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	NginxIngressAnnotationPrefix  = "nginx.ingress.kubernetes.io/"
+	LegacyIngressAnnotationPrefix = "ingress.kubernetes.io/"
+
+	nginxUseRegexKey = "use-regex"
+	nginxAppRootKey  = "app-root"
+
+	nginxPermanentRedirectKey     = "permanent-redirect"
+	nginxPermanentRedirectCodeKey = "permanent-redirect-code"
+	nginxTemporalRedirectKey      = "temporal-redirect"
+
+	NginxProxySetHeadersKey = "proxy-set-headers"
+	NginxCustomHeadersKey   = "custom-headers"
+
+	NginxUpstreamHashByKey = "upstream-hash-by"
+	NginxUpstreamVhostKey  = "upstream-vhost"
+
+	NginxXForwardedPrefixKey = "x-forwarded-prefix"
+	NginxEnableAccessLogKey  = "enable-access-log"
+	NginxSSLCiphersKey       = "ssl-ciphers"
+	xForwardedPrefixHeader   = "X-Forwarded-Prefix"
+)
+
+// GetNginxAnnotation returns the value of an ingress-nginx annotation, accepting both the
+// nginx.ingress.kubernetes.io/ and the legacy ingress.kubernetes.io/ prefix
+func GetNginxAnnotation(annotations map[string]string, key string) (string, bool) {
+	if annotations == nil {
+		return "", false
+	}
+	if value, ok := annotations[NginxIngressAnnotationPrefix+key]; ok {
+		return strings.TrimSpace(value), true
+	}
+	if value, ok := annotations[LegacyIngressAnnotationPrefix+key]; ok {
+		return strings.TrimSpace(value), true
+	}
+	return "", false
+}
+
+func nginxAnnotationEnabled(annotations map[string]string, key string) bool {
+	value, ok := GetNginxAnnotation(annotations, key)
+	return ok && strings.EqualFold(value, "true")
+}
+
+// UsesRegexPaths reports whether the Ingress has at least one ImplementationSpecific path that policy
+// translates to a RegularExpression match
+func UsesRegexPaths(ingress *networkingv1.Ingress, policy ImplementationSpecificPolicy) bool {
+	if ingress == nil {
+		return false
+	}
+	useRegex := nginxAnnotationEnabled(ingress.Annotations, nginxUseRegexKey)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if policy.RegexPath(useRegex, path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// xForwardedPrefix returns the X-Forwarded-Prefix header requested by x-forwarded-prefix.
+// Values referencing nginx variables (e.g. regex captures) have no Gateway API equivalent.
+func xForwardedPrefix(annotations map[string]string) (gatewayv1.HTTPHeader, bool) {
+	value, ok := GetNginxAnnotation(annotations, NginxXForwardedPrefixKey)
+	value = strings.TrimSpace(value)
+	if !ok || value == "" || strings.Contains(value, "$") {
+		return gatewayv1.HTTPHeader{}, false
+	}
+	return gatewayv1.HTTPHeader{Name: xForwardedPrefixHeader, Value: value}, true
+}
+
+// buildAppRootRule translates app-root (of ingress-nginx or Traefik v1) into a rule redirecting "/" to the
+// application root
+func buildAppRootRule(annotations map[string]string) *gatewayv1.HTTPRouteRule {
+	appRoot, ok := GetNginxAnnotation(annotations, nginxAppRootKey)
+	if !ok {
+		appRoot, ok = GetTraefikAnnotation(annotations, TraefikAppRootKey)
+	}
+	if !ok || appRoot == "" || appRoot == "/" || !strings.HasPrefix(appRoot, "/") {
+		return nil
+	}
+
+	return &gatewayv1.HTTPRouteRule{
+		Matches: []gatewayv1.HTTPRouteMatch{
+			{
+				Path: &gatewayv1.HTTPPathMatch{
+					Type:  ptr.To(gatewayv1.PathMatchExact),
+					Value: ptr.To("/"),
+				},
+			},
+		},
+		Filters: []gatewayv1.HTTPRouteFilter{
+			{
+				Type: gatewayv1.HTTPRouteFilterRequestRedirect,
+				RequestRedirect: &gatewayv1.HTTPRequestRedirectFilter{
+					Path: &gatewayv1.HTTPPathModifier{
+						Type:            gatewayv1.FullPathHTTPPathModifier,
+						ReplaceFullPath: ptr.To(appRoot),
+					},
+					StatusCode: ptr.To(302),
+				},
+			},
+		},
+	}
+}
+
+// buildExternalRedirectFilter translates permanent-redirect (with permanent-redirect-code) and
+// temporal-redirect into a RequestRedirect filter pointing at the external URL
+func buildExternalRedirectFilter(annotations map[string]string) (*gatewayv1.HTTPRouteFilter, error) {
+	target, statusCode := "", 0
+	if value, ok := GetNginxAnnotation(annotations, nginxPermanentRedirectKey); ok && value != "" {
+		target, statusCode = value, 301
+		if rawCode, ok := GetNginxAnnotation(annotations, nginxPermanentRedirectCodeKey); ok && rawCode != "" {
+			code, err := strconv.Atoi(rawCode)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q: %w", nginxPermanentRedirectCodeKey, rawCode, err)
+			}
+			switch code {
+			case 301, 302, 303, 307, 308:
+				statusCode = code
+			default:
+				return nil, fmt.Errorf("unsupported %s %d (allowed: 301, 302, 303, 307, 308)",
+					nginxPermanentRedirectCodeKey, code)
+			}
+		}
+	} else if value, ok := GetNginxAnnotation(annotations, nginxTemporalRedirectKey); ok && value != "" {
+		target, statusCode = value, 302
+	}
+	if target == "" {
+		return nil, nil
+	}
+
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redirect URL %q: %w", target, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("invalid redirect URL %q: scheme must be http or https", target)
+	}
+	if !isValidHostname(parsed.Hostname()) {
+		return nil, fmt.Errorf("invalid redirect URL %q: hostname is not a valid DNS name", target)
+	}
+
+	path := parsed.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	redirect := &gatewayv1.HTTPRequestRedirectFilter{
+		Scheme:   ptr.To(parsed.Scheme),
+		Hostname: ptr.To(gatewayv1.PreciseHostname(parsed.Hostname())),
+		Path: &gatewayv1.HTTPPathModifier{
+			Type:            gatewayv1.FullPathHTTPPathModifier,
+			ReplaceFullPath: ptr.To(path),
+		},
+		StatusCode: ptr.To(statusCode),
+	}
+	if rawPort := parsed.Port(); rawPort != "" {
+		port, err := strconv.ParseInt(rawPort, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redirect URL %q: %w", target, err)
+		}
+		redirect.Port = ptr.To(gatewayv1.PortNumber(port))
+	}
+
+	return &gatewayv1.HTTPRouteFilter{
+		Type:            gatewayv1.HTTPRouteFilterRequestRedirect,
+		RequestRedirect: redirect,
+	}, nil
+}
+
+// UpstreamVhostHostname returns the upstream-vhost value when it can be expressed as a
+// URLRewrite hostname; values with ports or nginx variables need a snippet instead
+func UpstreamVhostHostname(annotations map[string]string) (string, bool) {
+	value, ok := GetNginxAnnotation(annotations, NginxUpstreamVhostKey)
+	if !ok || value == "" || !isValidHostname(value) {
+		return "", false
+	}
+	return value, true
+}
+
+// buildUpstreamVhostFilter rewrites the Host header sent to backends like upstream-vhost
+func buildUpstreamVhostFilter(annotations map[string]string) *gatewayv1.HTTPRouteFilter {
+	hostname, ok := UpstreamVhostHostname(annotations)
+	if !ok {
+		return nil
+	}
+	return &gatewayv1.HTTPRouteFilter{
+		Type: gatewayv1.HTTPRouteFilterURLRewrite,
+		URLRewrite: &gatewayv1.HTTPURLRewriteFilter{
+			Hostname: ptr.To(gatewayv1.PreciseHostname(hostname)),
+		},
+	}
+}
+
+// RewritesHost reports whether any rule of the HTTPRoute carries a URLRewrite hostname filter
+func RewritesHost(httpRoute *gatewayv1.HTTPRoute) bool {
+	if httpRoute == nil {
+		return false
+	}
+	for _, rule := range httpRoute.Spec.Rules {
+		for _, filter := range rule.Filters {
+			if filter.Type == gatewayv1.HTTPRouteFilterURLRewrite &&
+				filter.URLRewrite != nil && filter.URLRewrite.Hostname != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
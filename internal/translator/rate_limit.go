@@ -0,0 +1,81 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	NginxLimitRPSKey             = "limit-rps"
+	NginxLimitRPMKey             = "limit-rpm"
+	NginxLimitBurstMultiplierKey = "limit-burst-multiplier"
+
+	// ingress-nginx default for limit-burst-multiplier
+	defaultLimitBurstMultiplier = 5
+)
+
+// RateLimit is an ingress-nginx request rate limit as a token bucket: Requests tokens are added
+// every Interval, up to Burst
+type RateLimit struct {
+	Requests int
+	Interval time.Duration
+	Burst    int
+	// Annotation is the ingress-nginx annotation the limit was translated from
+	Annotation string
+}
+
+// ParseRateLimit translates limit-rps or limit-rpm with limit-burst-multiplier. limit-rps takes
+// precedence; ingress-nginx limits per client address, which data planes may only approximate.
+func ParseRateLimit(annotations map[string]string) (RateLimit, bool, error) {
+	limit := RateLimit{}
+	for _, candidate := range []struct {
+		key      string
+		interval time.Duration
+	}{
+		{NginxLimitRPSKey, time.Second},
+		{NginxLimitRPMKey, time.Minute},
+	} {
+		value, ok := GetNginxAnnotation(annotations, candidate.key)
+		if !ok || value == "" {
+			continue
+		}
+		requests, err := strconv.Atoi(value)
+		if err != nil || requests <= 0 {
+			return RateLimit{}, false, fmt.Errorf("invalid %s %q, expected a positive integer", candidate.key, value)
+		}
+		limit = RateLimit{Requests: requests, Interval: candidate.interval, Annotation: candidate.key}
+		break
+	}
+	if limit.Requests == 0 {
+		return RateLimit{}, false, nil
+	}
+
+	multiplier := defaultLimitBurstMultiplier
+	if value, ok := GetNginxAnnotation(annotations, NginxLimitBurstMultiplierKey); ok && value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			return RateLimit{}, false, fmt.Errorf("invalid %s %q, expected a positive integer",
+				NginxLimitBurstMultiplierKey, value)
+		}
+		multiplier = parsed
+	}
+	limit.Burst = limit.Requests * multiplier
+	return limit, true, nil
+}
@@ -0,0 +1,202 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+const (
+	// ServicesConfigMapAnnotation records the tcp-services/udp-services ConfigMap a route was generated from
+	ServicesConfigMapAnnotation = "ingress-doperator.fiction.si/services-configmap"
+
+	streamProxyProtocolFlag = "PROXY"
+)
+
+// StreamService is one entry of an ingress-nginx tcp-services or udp-services ConfigMap, e.g.
+// "9000": "default/example-go:8080" exposes port 8080 of Service default/example-go on port 9000
+type StreamService struct {
+	// Port is the port the Gateway listens on
+	Port int32
+	// Namespace and Name identify the backend Service
+	Namespace string
+	Name      string
+	// ServicePort is the Service port, a number or a port name
+	ServicePort string
+	// ProxyProtocol is set when PROXY protocol decoding or encoding was requested
+	ProxyProtocol bool
+}
+
+// ParseStreamServices parses the data of a tcp-services or udp-services ConfigMap. Entries that cannot
+// be parsed are returned as messages instead. Services are sorted by port.
+func ParseStreamServices(data map[string]string) ([]StreamService, []string) {
+	var services []StreamService
+	var invalid []string
+	for key, value := range data {
+		service, err := parseStreamService(key, value)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		services = append(services, service)
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Port < services[j].Port })
+	sort.Strings(invalid)
+	return services, invalid
+}
+
+func parseStreamService(key, value string) (StreamService, error) {
+	port, err := strconv.ParseInt(strings.TrimSpace(key), 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return StreamService{}, fmt.Errorf("invalid port %q", key)
+	}
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) < 2 || len(parts) > 4 {
+		return StreamService{}, fmt.Errorf("expected <namespace>/<service>:<port>[:PROXY][:PROXY], got %q", value)
+	}
+	namespace, name, ok := strings.Cut(parts[0], "/")
+	if !ok || namespace == "" || name == "" || parts[1] == "" {
+		return StreamService{}, fmt.Errorf("expected <namespace>/<service>:<port>[:PROXY][:PROXY], got %q", value)
+	}
+	service := StreamService{
+		Port:        int32(port),
+		Namespace:   namespace,
+		Name:        name,
+		ServicePort: parts[1],
+	}
+	for _, flag := range parts[2:] {
+		switch flag {
+		case streamProxyProtocolFlag:
+			service.ProxyProtocol = true
+		case "":
+		default:
+			return StreamService{}, fmt.Errorf("unknown flag %q", flag)
+		}
+	}
+	return service, nil
+}
+
+// StreamListenerName returns the name of the listener for a port of a layer 4 protocol, e.g. tcp-9000
+func StreamListenerName(protocol gatewayv1.ProtocolType, port int32) gatewayv1.SectionName {
+	return gatewayv1.SectionName(fmt.Sprintf("%s-%d", strings.ToLower(string(protocol)), port))
+}
+
+// IsStreamListener reports whether a listener serves a layer 4 protocol
+func IsStreamListener(listener *gatewayv1.Listener) bool {
+	return listener.Protocol == gatewayv1.TCPProtocolType || listener.Protocol == gatewayv1.UDPProtocolType
+}
+
+// StreamRouteName returns the name of the route generated for a port of a services ConfigMap
+func StreamRouteName(configMapName string, port int32) string {
+	return fmt.Sprintf("%s-%d", configMapName, port)
+}
+
+// BuildTCPRoute builds the TCPRoute forwarding the listener of a tcp-services entry to its Service
+func BuildTCPRoute(
+	service StreamService,
+	backendPort int32,
+	configMap types.NamespacedName,
+	gatewayNamespace string,
+	gatewayName string,
+) *gatewayv1alpha2.TCPRoute {
+	return &gatewayv1alpha2.TCPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayv1alpha2.GroupVersion.String(),
+			Kind:       "TCPRoute",
+		},
+		ObjectMeta: streamRouteMeta(service, configMap),
+		Spec: gatewayv1alpha2.TCPRouteSpec{
+			CommonRouteSpec: streamRouteParent(gatewayv1.TCPProtocolType, service, gatewayNamespace, gatewayName),
+			Rules: []gatewayv1alpha2.TCPRouteRule{
+				{BackendRefs: streamRouteBackend(service, backendPort)},
+			},
+		},
+	}
+}
+
+// BuildUDPRoute builds the UDPRoute forwarding the listener of a udp-services entry to its Service
+func BuildUDPRoute(
+	service StreamService,
+	backendPort int32,
+	configMap types.NamespacedName,
+	gatewayNamespace string,
+	gatewayName string,
+) *gatewayv1alpha2.UDPRoute {
+	return &gatewayv1alpha2.UDPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gatewayv1alpha2.GroupVersion.String(),
+			Kind:       "UDPRoute",
+		},
+		ObjectMeta: streamRouteMeta(service, configMap),
+		Spec: gatewayv1alpha2.UDPRouteSpec{
+			CommonRouteSpec: streamRouteParent(gatewayv1.UDPProtocolType, service, gatewayNamespace, gatewayName),
+			Rules: []gatewayv1alpha2.UDPRouteRule{
+				{BackendRefs: streamRouteBackend(service, backendPort)},
+			},
+		},
+	}
+}
+
+func streamRouteMeta(service StreamService, configMap types.NamespacedName) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      StreamRouteName(configMap.Name, service.Port),
+		Namespace: service.Namespace,
+		Annotations: map[string]string{
+			ManagedByAnnotation:         ManagedByValue,
+			ServicesConfigMapAnnotation: configMap.String(),
+		},
+	}
+}
+
+func streamRouteParent(
+	protocol gatewayv1.ProtocolType,
+	service StreamService,
+	gatewayNamespace string,
+	gatewayName string,
+) gatewayv1.CommonRouteSpec {
+	gatewayNS := gatewayv1.Namespace(gatewayNamespace)
+	sectionName := StreamListenerName(protocol, service.Port)
+	return gatewayv1.CommonRouteSpec{
+		ParentRefs: []gatewayv1.ParentReference{
+			{
+				Name:        gatewayv1.ObjectName(gatewayName),
+				Namespace:   &gatewayNS,
+				SectionName: &sectionName,
+			},
+		},
+	}
+}
+
+func streamRouteBackend(service StreamService, backendPort int32) []gatewayv1.BackendRef {
+	port := gatewayv1.PortNumber(backendPort)
+	return []gatewayv1.BackendRef{
+		{
+			BackendObjectReference: gatewayv1.BackendObjectReference{
+				Name: gatewayv1.ObjectName(service.Name),
+				Port: &port,
+			},
+		},
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+// TargetGatewayAnnotation names the shared Gateway an Ingress is meant for. The annotation normalizing
+// webhook fills it in on create; the operator warns when it no longer matches the Gateway of the class.
+const TargetGatewayAnnotation = "ingress-doperator.fiction.si/gateway"
+
+// SharedGatewayName returns the shared Gateway of an ingress class: the class name, or defaultGateway
+// (--gateway-name) for Ingresses without class
+func SharedGatewayName(defaultGateway, ingressClass string) string {
+	if ingressClass == "" || ingressClass == "default" {
+		return defaultGateway
+	}
+	return ingressClass
+}
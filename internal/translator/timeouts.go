@@ -0,0 +1,175 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	NginxProxyConnectTimeoutKey = "proxy-connect-timeout"
+	NginxProxySendTimeoutKey    = "proxy-send-timeout"
+	NginxProxyReadTimeoutKey    = "proxy-read-timeout"
+
+	// Gateway API durations allow at most 5 digits per unit
+	maxGatewayDurationUnitValue = 99999
+)
+
+// ingress-nginx defaults, used for timeouts that are not overridden by an annotation
+var defaultNginxProxyTimeouts = map[string]time.Duration{
+	NginxProxyConnectTimeoutKey: 5 * time.Second,
+	NginxProxySendTimeoutKey:    60 * time.Second,
+	NginxProxyReadTimeoutKey:    60 * time.Second,
+}
+
+var nginxTimeUnits = map[string]time.Duration{
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+}
+
+// ProxyTimeoutKeys returns the annotation suffixes handled by BuildProxyTimeouts
+func ProxyTimeoutKeys() []string {
+	return []string{NginxProxyConnectTimeoutKey, NginxProxySendTimeoutKey, NginxProxyReadTimeoutKey}
+}
+
+// BuildProxyTimeouts translates proxy-connect/send/read-timeout into HTTPRoute rule timeouts.
+// nginx applies them per operation, Gateway API only knows totals, so a backend request may take
+// at most connect + send + read (ingress-nginx defaults fill in missing values).
+// It returns nil, false when no timeout annotation is set and nil, true when a value cannot be
+// expressed as a Gateway API duration, in which case the SnippetsFilter keeps the nginx directives.
+func BuildProxyTimeouts(annotations map[string]string) (*gatewayv1.HTTPRouteTimeouts, bool) {
+	total := time.Duration(0)
+	found := false
+	for _, key := range ProxyTimeoutKeys() {
+		value, ok := GetNginxAnnotation(annotations, key)
+		if !ok || value == "" {
+			total += defaultNginxProxyTimeouts[key]
+			continue
+		}
+		found = true
+		parsed, err := parseNginxTime(value)
+		if err != nil || parsed <= 0 {
+			return nil, true
+		}
+		total += parsed
+	}
+	if !found {
+		return nil, false
+	}
+
+	duration, ok := formatGatewayDuration(total)
+	if !ok {
+		return nil, true
+	}
+	return &gatewayv1.HTTPRouteTimeouts{
+		Request:        &duration,
+		BackendRequest: &duration,
+	}, false
+}
+
+// ProxyTimeoutsNeedSnippet reports whether proxy timeout annotations must stay nginx directives
+func ProxyTimeoutsNeedSnippet(annotations map[string]string) bool {
+	_, fallback := BuildProxyTimeouts(annotations)
+	return fallback
+}
+
+// ProxyConnectTimeout returns the proxy-connect-timeout of the Ingress on its own, for data planes
+// that configure backend connect timeouts separately from HTTPRoute timeouts
+func ProxyConnectTimeout(annotations map[string]string) (time.Duration, bool) {
+	value, ok := GetNginxAnnotation(annotations, NginxProxyConnectTimeoutKey)
+	if !ok || value == "" {
+		return 0, false
+	}
+	parsed, err := parseNginxTime(value)
+	if err != nil || parsed <= 0 {
+		return 0, false
+	}
+	return parsed, true
+}
+
+// parseNginxTime parses an nginx time value; plain numbers are seconds as in ingress-nginx
+func parseNginxTime(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	total := time.Duration(0)
+	rest := value
+	for rest != "" {
+		digits := 0
+		for digits < len(rest) && rest[digits] >= '0' && rest[digits] <= '9' {
+			digits++
+		}
+		if digits == 0 {
+			return 0, fmt.Errorf("invalid nginx time %q", value)
+		}
+		number, err := strconv.ParseInt(rest[:digits], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid nginx time %q: %w", value, err)
+		}
+		rest = rest[digits:]
+
+		unitLen := 0
+		for unitLen < len(rest) && (rest[unitLen] < '0' || rest[unitLen] > '9') {
+			unitLen++
+		}
+		unit, ok := nginxTimeUnits[rest[:unitLen]]
+		if !ok {
+			return 0, fmt.Errorf("invalid nginx time unit in %q", value)
+		}
+		total += time.Duration(number) * unit
+		rest = rest[unitLen:]
+	}
+	return total, nil
+}
+
+// formatGatewayDuration renders d in the Gateway API duration format (e.g. "1h2m3s")
+func formatGatewayDuration(d time.Duration) (gatewayv1.Duration, bool) {
+	hours := int64(d / time.Hour)
+	if hours > maxGatewayDurationUnitValue {
+		return "", false
+	}
+	d -= time.Duration(hours) * time.Hour
+	minutes := int64(d / time.Minute)
+	d -= time.Duration(minutes) * time.Minute
+	seconds := int64(d / time.Second)
+	d -= time.Duration(seconds) * time.Second
+	millis := int64(d / time.Millisecond)
+
+	var b strings.Builder
+	for _, part := range []struct {
+		value int64
+		unit  string
+	}{{hours, "h"}, {minutes, "m"}, {seconds, "s"}, {millis, "ms"}} {
+		if part.value > 0 {
+			fmt.Fprintf(&b, "%d%s", part.value, part.unit)
+		}
+	}
+	if b.Len() == 0 {
+		return "", false
+	}
+	return gatewayv1.Duration(b.String()), true
+}
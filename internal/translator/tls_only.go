@@ -0,0 +1,150 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/utils/ptr"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// TLSOnlyHostsMode controls how spec.tls hosts without a matching rule are translated
+type TLSOnlyHostsMode string
+
+const (
+	// TLSOnlyHostsIgnore does not create listeners for TLS-only hosts
+	TLSOnlyHostsIgnore TLSOnlyHostsMode = "ignore"
+	// TLSOnlyHostsDefaultBackend routes TLS-only hosts to the default backend (404 if unset)
+	TLSOnlyHostsDefaultBackend TLSOnlyHostsMode = "default-backend"
+	// TLSOnlyHostsNotFound answers every request on TLS-only hosts with 404
+	TLSOnlyHostsNotFound TLSOnlyHostsMode = "not-found"
+
+	// TLSOnlyHTTPRouteSuffix is appended to the HTTPRoute name for the TLS-only HTTPRoute
+	TLSOnlyHTTPRouteSuffix = "-tls-only"
+
+	// tlsOnlyUnmatchedHeader is never sent by clients; a rule matching it leaves every request
+	// unmatched, which Gateway API implementations answer with 404
+	tlsOnlyUnmatchedHeader = "X-Ingress-Doperator-Tls-Only"
+)
+
+// ParseTLSOnlyHostsMode converts a flag value into a TLSOnlyHostsMode
+func ParseTLSOnlyHostsMode(value string) (TLSOnlyHostsMode, error) {
+	switch TLSOnlyHostsMode(value) {
+	case TLSOnlyHostsIgnore, TLSOnlyHostsDefaultBackend, TLSOnlyHostsNotFound:
+		return TLSOnlyHostsMode(value), nil
+	default:
+		return TLSOnlyHostsIgnore,
+			fmt.Errorf("invalid tls-only-hosts value %q (allowed: ignore, default-backend, not-found)", value)
+	}
+}
+
+// Serves reports whether TLS-only hosts get listeners and an HTTPRoute
+func (m TLSOnlyHostsMode) Serves() bool {
+	return m != "" && m != TLSOnlyHostsIgnore
+}
+
+// GetTLSOnlyHosts returns spec.tls hosts that do not appear in any Ingress rule
+func GetTLSOnlyHosts(ingress *networkingv1.Ingress) []string {
+	ruleHosts := make(map[string]struct{}, len(ingress.Spec.Rules))
+	for _, rule := range ingress.Spec.Rules {
+		ruleHosts[rule.Host] = struct{}{}
+	}
+
+	seen := make(map[string]struct{})
+	hosts := make([]string, 0)
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			if host == "" {
+				continue
+			}
+			if _, ok := ruleHosts[host]; ok {
+				continue
+			}
+			if _, ok := seen[host]; ok {
+				continue
+			}
+			seen[host] = struct{}{}
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// TranslateTLSOnlyHostsToHTTPRoute builds an HTTPRoute attaching listeners for TLS-only hosts.
+// It returns nil when the mode is ignore or the Ingress has no such hosts.
+func (t *Translator) TranslateTLSOnlyHostsToHTTPRoute(ingress *networkingv1.Ingress) *gatewayv1.HTTPRoute {
+	if !t.Config.TLSOnlyHosts.Serves() {
+		return nil
+	}
+	tlsOnlyHosts := GetTLSOnlyHosts(ingress)
+	if len(tlsOnlyHosts) == 0 {
+		return nil
+	}
+
+	httpRoute := &gatewayv1.HTTPRoute{}
+	httpRoute.Name = t.ResourceName(ingress) + TLSOnlyHTTPRouteSuffix
+	httpRoute.Namespace = ingress.Namespace
+	httpRoute.Annotations = t.FilterAnnotations(ingress.Annotations, t.Config.HTTPRouteAnnotationFilters)
+	if httpRoute.Annotations == nil {
+		httpRoute.Annotations = make(map[string]string)
+	}
+	httpRoute.Annotations[ManagedByAnnotation] = ManagedByValue
+	httpRoute.Annotations[SourceAnnotation] = fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name)
+
+	hostnames := make([]gatewayv1.Hostname, 0, len(tlsOnlyHosts))
+	for _, host := range tlsOnlyHosts {
+		hostnames = append(hostnames, gatewayv1.Hostname(t.TransformHostname(host)))
+	}
+	httpRoute.Spec.Hostnames = hostnames
+	httpRoute.Spec.ParentRefs = t.buildParentRefs(hostnames)
+
+	if backendRef := defaultBackendRef(ingress); t.Config.TLSOnlyHosts == TLSOnlyHostsDefaultBackend && backendRef != nil {
+		httpRoute.Spec.Rules = []gatewayv1.HTTPRouteRule{
+			{
+				Matches: []gatewayv1.HTTPRouteMatch{
+					{
+						Path: &gatewayv1.HTTPPathMatch{
+							Type:  ptr.To(gatewayv1.PathMatchPathPrefix),
+							Value: ptr.To("/"),
+						},
+					},
+				},
+				BackendRefs: []gatewayv1.HTTPBackendRef{*backendRef},
+			},
+		}
+		return httpRoute
+	}
+
+	httpRoute.Spec.Rules = []gatewayv1.HTTPRouteRule{
+		{
+			Matches: []gatewayv1.HTTPRouteMatch{
+				{
+					Headers: []gatewayv1.HTTPHeaderMatch{
+						{
+							Type:  ptr.To(gatewayv1.HeaderMatchExact),
+							Name:  tlsOnlyUnmatchedHeader,
+							Value: ManagedByValue,
+						},
+					},
+				},
+			},
+		},
+	}
+	return httpRoute
+}
@@ -0,0 +1,197 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	NginxSSLPassthroughKey = "ssl-passthrough"
+
+	// MaxTLSRouteHostnames is the Gateway API limit of hostnames per TLSRoute
+	MaxTLSRouteHostnames = 16
+
+	// passthroughListenerNamePrefix tells the TLS passthrough listener of a hostname apart from its HTTPS listener
+	passthroughListenerNamePrefix = "passthrough."
+)
+
+// IsSSLPassthrough reports whether the ssl-passthrough annotation asks for TLS to reach the backends untouched
+func IsSSLPassthrough(annotations map[string]string) bool {
+	return nginxAnnotationEnabled(annotations, NginxSSLPassthroughKey)
+}
+
+// PassthroughListenerName returns the name of the TLS passthrough listener for a listener hostname
+func PassthroughListenerName(listenerHostname string) gatewayv1.SectionName {
+	return gatewayv1.SectionName(passthroughListenerNamePrefix + string(ListenerName(listenerHostname)))
+}
+
+// IsPassthroughListenerName reports whether a section name refers to a TLS passthrough listener
+func IsPassthroughListenerName(name gatewayv1.SectionName) bool {
+	return strings.HasPrefix(string(name), passthroughListenerNamePrefix)
+}
+
+// TranslateToTLSRoutes converts an ssl-passthrough Ingress to TLSRoutes. The Gateway only sees the
+// SNI hostname, so every host is sent to the backend of its "/" path (or its first path, or the
+// default backend), like ingress-nginx does. Hosts sharing a backend share a TLSRoute. Paths that
+// cannot be honoured are returned so the caller can report them.
+func (t *Translator) TranslateToTLSRoutes(ingress *networkingv1.Ingress) ([]*gatewayv1.TLSRoute, []string) {
+	type hostGroup struct {
+		backend   gatewayv1.BackendRef
+		hostnames []gatewayv1.Hostname
+	}
+	var groups []*hostGroup
+	var ignored []string
+	seenHosts := make(map[string]bool)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host == "" || seenHosts[rule.Host] {
+			continue
+		}
+		seenHosts[rule.Host] = true
+		backend, paths := passthroughBackend(ingress, rule)
+		for _, path := range paths {
+			ignored = append(ignored, rule.Host+path)
+		}
+		if backend == nil {
+			continue
+		}
+		backendRef := gatewayv1.BackendRef{
+			BackendObjectReference: gatewayv1.BackendObjectReference{
+				Name: gatewayv1.ObjectName(backend.Name),
+			},
+		}
+		// Named ports are stored as 0 and resolved by the controller
+		port := backend.Port.Number
+		backendRef.Port = &port
+
+		hostname := gatewayv1.Hostname(t.TransformHostname(rule.Host))
+		var group *hostGroup
+		for _, existing := range groups {
+			if existing.backend.Name == backendRef.Name && *existing.backend.Port == port &&
+				len(existing.hostnames) < MaxTLSRouteHostnames {
+				group = existing
+				break
+			}
+		}
+		if group == nil {
+			group = &hostGroup{backend: backendRef}
+			groups = append(groups, group)
+		}
+		group.hostnames = append(group.hostnames, hostname)
+	}
+
+	routes := make([]*gatewayv1.TLSRoute, 0, len(groups))
+	for i, group := range groups {
+		tlsRoute := &gatewayv1.TLSRoute{}
+		tlsRoute.Name = t.ResourceName(ingress)
+		if i > 0 {
+			tlsRoute.Name = fmt.Sprintf("%s-%d", tlsRoute.Name, i+1)
+		}
+		tlsRoute.Namespace = ingress.Namespace
+
+		tlsRoute.Annotations = t.FilterAnnotations(ingress.Annotations, t.Config.HTTPRouteAnnotationFilters)
+		if tlsRoute.Annotations == nil {
+			tlsRoute.Annotations = make(map[string]string)
+		}
+		tlsRoute.Annotations[ManagedByAnnotation] = ManagedByValue
+		tlsRoute.Annotations[SourceAnnotation] = fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name)
+
+		tlsRoute.Spec.Hostnames = group.hostnames
+		tlsRoute.Spec.ParentRefs = t.buildPassthroughParentRefs(group.hostnames)
+		tlsRoute.Spec.Rules = []gatewayv1.TLSRouteRule{{
+			BackendRefs: []gatewayv1.BackendRef{group.backend},
+		}}
+		routes = append(routes, tlsRoute)
+	}
+	return routes, ignored
+}
+
+// passthroughBackend picks the Service a passthrough host is sent to and returns the paths whose
+// backend is not honoured because it differs from that Service
+func passthroughBackend(
+	ingress *networkingv1.Ingress,
+	rule networkingv1.IngressRule,
+) (*networkingv1.IngressServiceBackend, []string) {
+	var backend *networkingv1.IngressServiceBackend
+	if rule.HTTP != nil {
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service == nil {
+				continue
+			}
+			if path.Path == "/" || path.Path == "" {
+				backend = path.Backend.Service
+				break
+			}
+			if backend == nil {
+				backend = path.Backend.Service
+			}
+		}
+	}
+	if backend == nil && ingress.Spec.DefaultBackend != nil {
+		backend = ingress.Spec.DefaultBackend.Service
+	}
+	if backend == nil || rule.HTTP == nil {
+		return backend, nil
+	}
+
+	var ignored []string
+	for _, path := range rule.HTTP.Paths {
+		service := path.Backend.Service
+		if service == nil || service.Name != backend.Name || service.Port != backend.Port {
+			ignored = append(ignored, path.Path)
+		}
+	}
+	return backend, ignored
+}
+
+// buildPassthroughParentRefs references the TLS passthrough listener of each hostname
+func (t *Translator) buildPassthroughParentRefs(hostnames []gatewayv1.Hostname) []gatewayv1.ParentReference {
+	if t.Config.AttachOnly {
+		return t.attachParentRefs()
+	}
+	parentRefs := make([]gatewayv1.ParentReference, 0, len(hostnames))
+	seen := make(map[gatewayv1.SectionName]bool, len(hostnames))
+	for _, hostname := range hostnames {
+		sectionName := PassthroughListenerName(t.ListenerHostname(string(hostname)))
+		if seen[sectionName] {
+			continue
+		}
+		seen[sectionName] = true
+		parentRefs = append(parentRefs, gatewayv1.ParentReference{
+			Name:        gatewayv1.ObjectName(t.Config.GatewayName),
+			Namespace:   (*gatewayv1.Namespace)(&t.Config.GatewayNamespace),
+			SectionName: &sectionName,
+		})
+	}
+	return parentRefs
+}
+
+// TLSRouteListenerView returns an HTTPRoute carrying the metadata, hostnames and parent references
+// of a TLSRoute, which is all Gateway listener management looks at
+func TLSRouteListenerView(tlsRoute *gatewayv1.TLSRoute) *gatewayv1.HTTPRoute {
+	view := &gatewayv1.HTTPRoute{}
+	tlsRoute.ObjectMeta.DeepCopyInto(&view.ObjectMeta)
+	view.Spec.Hostnames = append([]gatewayv1.Hostname(nil), tlsRoute.Spec.Hostnames...)
+	for i := range tlsRoute.Spec.ParentRefs {
+		view.Spec.ParentRefs = append(view.Spec.ParentRefs, *tlsRoute.Spec.ParentRefs[i].DeepCopy())
+	}
+	return view
+}
@@ -0,0 +1,169 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	TraefikAnnotationPrefix = "traefik.ingress.kubernetes.io/"
+
+	TraefikEntryPointsKey = "router.entrypoints"
+	TraefikMiddlewaresKey = "router.middlewares"
+
+	// Traefik v1 Ingress provider annotations
+	TraefikRedirectEntryPointKey = "redirect-entry-point"
+	TraefikRedirectPermanentKey  = "redirect-permanent"
+	TraefikAppRootKey            = "app-root"
+
+	// DefaultTraefikEntryPoints are the entry points of the Traefik Helm chart
+	DefaultTraefikEntryPoints = "web=80,websecure=443"
+
+	// traefikCRDProvider is the provider suffix of Middlewares defined as Kubernetes custom resources
+	traefikCRDProvider = "@kubernetescrd"
+
+	httpPort  = 80
+	httpsPort = 443
+)
+
+// TraefikEntryPoints maps Traefik entry point names to the ports of the Gateway listeners serving them
+type TraefikEntryPoints map[string]gatewayv1.PortNumber
+
+// ParseTraefikEntryPoints parses a comma-separated list of name=port entries
+func ParseTraefikEntryPoints(value string) (TraefikEntryPoints, error) {
+	entryPoints := make(TraefikEntryPoints)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, portValue, ok := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid traefik entry point %q, expected name=port", item)
+		}
+		port, err := strconv.ParseInt(strings.TrimSpace(portValue), 10, 32)
+		if err != nil || port < 1 || port > 65535 {
+			return nil, fmt.Errorf("invalid port of traefik entry point %q", item)
+		}
+		entryPoints[name] = gatewayv1.PortNumber(port)
+	}
+	return entryPoints, nil
+}
+
+// GetTraefikAnnotation returns the value of a traefik.ingress.kubernetes.io/ annotation
+func GetTraefikAnnotation(annotations map[string]string, key string) (string, bool) {
+	value, ok := annotations[TraefikAnnotationPrefix+key]
+	return strings.TrimSpace(value), ok
+}
+
+// TraefikMiddlewares returns the Middlewares in the namespace of the Ingress that router.middlewares
+// references, in order, and the references that cannot become HTTPRoute filters: Middlewares of other
+// namespaces (ExtensionRefs are local) or of other Traefik providers.
+func TraefikMiddlewares(ingress *networkingv1.Ingress) (local []string, unsupported []string) {
+	value, ok := GetTraefikAnnotation(ingress.Annotations, TraefikMiddlewaresKey)
+	if !ok {
+		return nil, nil
+	}
+	prefix := ingress.Namespace + "-"
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		qualified, isCRD := strings.CutSuffix(item, traefikCRDProvider)
+		name, inNamespace := strings.CutPrefix(qualified, prefix)
+		if !isCRD || !inNamespace || name == "" {
+			unsupported = append(unsupported, item)
+			continue
+		}
+		local = append(local, name)
+	}
+	return local, unsupported
+}
+
+// traefikEntryPointPorts returns the ports of the entry points router.entrypoints restricts the Ingress
+// to, false when the annotation is absent or names no known entry point
+func (t *Translator) traefikEntryPointPorts(ingress *networkingv1.Ingress) ([]gatewayv1.PortNumber, bool) {
+	value, ok := GetTraefikAnnotation(ingress.Annotations, TraefikEntryPointsKey)
+	if !ok {
+		return nil, false
+	}
+	seen := make(map[gatewayv1.PortNumber]bool)
+	var ports []gatewayv1.PortNumber
+	for _, name := range strings.Split(value, ",") {
+		port, known := t.Config.TraefikEntryPoints[strings.TrimSpace(name)]
+		if !known || seen[port] {
+			continue
+		}
+		seen[port] = true
+		ports = append(ports, port)
+	}
+	return ports, len(ports) > 0
+}
+
+// traefikServesPlainHTTP reports whether router.entrypoints puts the Ingress on an HTTP entry point
+// without redirect-entry-point sending it to HTTPS. Unlike ingress-nginx, Traefik routers do not
+// redirect to HTTPS on their own.
+func (t *Translator) traefikServesPlainHTTP(ingress *networkingv1.Ingress) bool {
+	if _, ok := t.traefikRedirectStatusCode(ingress); ok {
+		return false
+	}
+	ports, _ := t.traefikEntryPointPorts(ingress)
+	for _, port := range ports {
+		if port == httpPort {
+			return true
+		}
+	}
+	return false
+}
+
+// traefikRedirectStatusCode returns the status code of a redirect-entry-point redirect to HTTPS:
+// 301 with redirect-permanent, 302 otherwise
+func (t *Translator) traefikRedirectStatusCode(ingress *networkingv1.Ingress) (int, bool) {
+	entryPoint, ok := GetTraefikAnnotation(ingress.Annotations, TraefikRedirectEntryPointKey)
+	if !ok {
+		return 0, false
+	}
+	if port, known := t.Config.TraefikEntryPoints[entryPoint]; (!known || port != httpsPort) && entryPoint != "https" {
+		return 0, false
+	}
+	if permanent, _ := GetTraefikAnnotation(ingress.Annotations, TraefikRedirectPermanentKey); strings.EqualFold(
+		permanent, "true") {
+		return 301, true
+	}
+	return 302, true
+}
+
+// traefikEntryPointParentRefs attaches the route of an Ingress restricted by router.entrypoints to the
+// listeners of a pre-provisioned Gateway on the entry point ports
+func (t *Translator) traefikEntryPointParentRefs(ingress *networkingv1.Ingress) []gatewayv1.ParentReference {
+	if !t.Config.AttachOnly {
+		return nil
+	}
+	ports, ok := t.traefikEntryPointPorts(ingress)
+	if !ok {
+		return nil
+	}
+	return t.attachPortParentRefs(ports)
+}
@@ -41,7 +41,9 @@ const (
 	ManagedByAnnotation       = "ingress-doperator.fiction.si/managed-by"
 	ManagedByValue            = "ingress-doperator"
 	SourceAnnotation          = "ingress-doperator.fiction.si/source"
+	SourceClusterAnnotation   = "ingress-doperator.fiction.si/source-cluster" // fan-in source of remote Ingresses
 	MismatchedCertAnnotation  = "ingress-doperator.fiction.si/certificate-mismatch"
+	RouteChunkAnnotation      = "ingress-doperator.fiction.si/route-chunk" // split HTTPRoutes of an Ingress, e.g. "2/3"
 	IngressClassAnnotation    = "kubernetes.io/ingress.class"
 	ReferenceGrantName        = "ingress-doperator-gateway-secrets"
 	MaxK8sNameLength          = 253 // Kubernetes resource name max length
@@ -57,19 +59,50 @@ const (
 
 // Config holds configuration for the translator
 type Config struct {
-	GatewayNamespace                 string
-	GatewayName                      string
-	GatewayClassName                 string
-	HostnameRewriteFrom              string
-	HostnameRewriteTo                string
+	GatewayNamespace    string
+	GatewayName         string
+	GatewayClassName    string
+	HostnameRewriteFrom string
+	HostnameRewriteTo   string
+	HostnamePrefix      string // extra first label of hostnames after rewriting (fan-in)
+	// HostnameRenames are the hostname renames in effect, applied after the rewrite and before the prefix
+	HostnameRenames                  []HostnameRename
 	DefaultGatewayAnnotations        map[string]string
 	GatewayInfrastructureAnnotations map[string]string
 	InfrastructureAnnotationsByClass []IngressClassAnnotationsRule
-	GatewayAnnotationFilters         []string
-	HTTPRouteAnnotationFilters       []string
-	UseIngress2Gateway               bool
-	Ingress2GatewayProvider          string
-	Ingress2GatewayIngressClass      string
+	// InfraAnnotationsByGateway sets spec.infrastructure.annotations per Gateway (partition)
+	InfraAnnotationsByGateway GatewayInfrastructureAnnotationRules
+	// AttachOnly attaches routes to a pre-provisioned Gateway instead of per-hostname listeners
+	AttachOnly bool
+	// AttachSectionNames are the listeners of the pre-provisioned Gateway routes attach to (empty = all)
+	AttachSectionNames []gatewayv1.SectionName
+	// NameTemplate names the HTTPRoutes (and Gateways per Ingress) generated for an Ingress, nil keeps the Ingress name
+	NameTemplate *NameTemplate
+	// InfrastructureLabelPrefixes selects Ingress labels (by key prefix) copied to spec.infrastructure.labels
+	InfrastructureLabelPrefixes []string
+	GatewayAnnotationFilters    []string
+	HTTPRouteAnnotationFilters  []string
+	UseIngress2Gateway          bool
+	Ingress2GatewayProvider     string
+	Ingress2GatewayIngressClass string
+	// RegexPathMatchSupported enables RegularExpression path matches for use-regex Ingresses
+	RegexPathMatchSupported bool
+	// ImplementationSpecificPaths controls the translation of ImplementationSpecific paths, "" = auto
+	ImplementationSpecificPaths ImplementationSpecificPolicy
+	// ListenerAllowedRoutes overrides the allowedRoutes of generated listeners per Gateway or listener
+	ListenerAllowedRoutes AllowedRoutesPolicies
+	// HostRewriteSupported enables URLRewrite hostname filters for upstream-vhost Ingresses
+	HostRewriteSupported bool
+	// TLSOnlyHosts controls listeners for spec.tls hosts that do not appear in any rule
+	TLSOnlyHosts TLSOnlyHostsMode
+	// WildcardListenerDomains consolidates <label>.<domain> hostnames into one *.<domain> listener
+	WildcardListenerDomains []string
+	// PairedHTTPListeners adds a port 80 listener next to every HTTPS listener created from Ingress TLS
+	PairedHTTPListeners bool
+	// HAProxyIngressClasses are the IngressClass globs whose haproxy.org/ annotations are translated
+	HAProxyIngressClasses []string
+	// TraefikEntryPoints maps the entry points of traefik.ingress.kubernetes.io/router.entrypoints to ports
+	TraefikEntryPoints TraefikEntryPoints
 }
 
 // Translator handles the conversion from Ingress to Gateway API resources
@@ -234,6 +267,8 @@ func (t *Translator) translateWithIngress2Gateway(
 			t.applyClassInfrastructureAnnotations(ingressClass, gateway.Spec.Infrastructure.Annotations)
 		}
 	}
+	SyncPinnedInfrastructureAnnotations(gateway, t.Config.InfraAnnotationsByGateway.For(gateway.Name))
+	MergeInfrastructureLabels(gateway, t.InfrastructureLabels(ingress))
 
 	if httpRoute.Annotations == nil {
 		httpRoute.Annotations = make(map[string]string)
@@ -311,8 +346,10 @@ func (t *Translator) TranslateMultipleToSharedGateway(
 	gateway := t.newSharedGateway(gatewayName)
 	t.applySharedGatewayAnnotations(gateway, ingresses)
 	t.applySharedGatewayInfrastructure(gateway, ingresses)
+	SyncPinnedInfrastructureAnnotations(gateway, t.Config.InfraAnnotationsByGateway.For(gatewayName))
+	MergeInfrastructureLabels(gateway, t.sharedInfrastructureLabels(ingresses))
 
-	listeners, certMismatches := t.buildSharedGatewayListeners(ingresses)
+	listeners, certMismatches := t.buildSharedGatewayListeners(ingresses, gatewayName)
 	if len(certMismatches) > 0 {
 		gateway.Annotations[MismatchedCertAnnotation] = strings.Join(certMismatches, "; ")
 	}
@@ -424,6 +461,7 @@ func (t *Translator) collectSharedHostnameInfo(
 
 func (t *Translator) buildSharedGatewayListeners(
 	ingresses []networkingv1.Ingress,
+	gatewayName string,
 ) ([]gatewayv1.Listener, []string) {
 	hostnameMap := t.collectSharedHostnameInfo(ingresses)
 	listeners := make([]gatewayv1.Listener, 0, len(hostnameMap))
@@ -467,6 +505,8 @@ func (t *Translator) buildSharedGatewayListeners(
 				},
 			},
 		}
+		t.Config.ListenerAllowedRoutes.Resolve(gatewayName, string(listener.Name)).
+			Apply(&listener, namespacesForHostname)
 
 		if info.tlsConfig != nil && info.tlsConfig.SecretName != "" {
 			secretName := info.tlsConfig.SecretName
@@ -569,6 +609,8 @@ func (t *Translator) TranslateToGateway(ingress *networkingv1.Ingress) *gatewayv
 			t.applyClassInfrastructureAnnotations(ingressClass, gateway.Spec.Infrastructure.Annotations)
 		}
 	}
+	SyncPinnedInfrastructureAnnotations(gateway, t.Config.InfraAnnotationsByGateway.For(gateway.Name))
+	MergeInfrastructureLabels(gateway, t.InfrastructureLabels(ingress))
 
 	// Collect hostnames with their TLS configurations
 	hostnameMap := make(map[string]*networkingv1.IngressTLS)
@@ -617,6 +659,8 @@ func (t *Translator) TranslateToGateway(ingress *networkingv1.Ingress) *gatewayv
 				},
 			},
 		}
+		t.Config.ListenerAllowedRoutes.Resolve(t.Config.GatewayName, string(listener.Name)).
+			Apply(&listener, []string{ingress.Namespace})
 
 		if tlsConfig != nil && tlsConfig.SecretName != "" {
 			secretName := tlsConfig.SecretName
@@ -671,7 +715,7 @@ func (t *Translator) TranslateToGateway(ingress *networkingv1.Ingress) *gatewayv
 func (t *Translator) TranslateToHTTPRoute(ingress *networkingv1.Ingress) *gatewayv1.HTTPRoute {
 	logger := log.Log.WithName("translator")
 	httpRoute := &gatewayv1.HTTPRoute{}
-	httpRoute.Name = ingress.Name
+	httpRoute.Name = t.ResourceName(ingress)
 	httpRoute.Namespace = ingress.Namespace
 
 	httpRoute.Annotations = t.FilterAnnotations(ingress.Annotations, t.Config.HTTPRouteAnnotationFilters)
@@ -692,25 +736,69 @@ func (t *Translator) TranslateToHTTPRoute(ingress *networkingv1.Ingress) *gatewa
 	httpRoute.Spec.Hostnames = hostnames
 
 	// Create parent refs to the Gateway
-	parentRefs := make([]gatewayv1.ParentReference, 0, len(hostnames))
-	for _, hostname := range hostnames {
-		sectionName := gatewayv1.SectionName(hostname)
-		parentRef := gatewayv1.ParentReference{
-			Name:        gatewayv1.ObjectName(t.Config.GatewayName),
-			Namespace:   (*gatewayv1.Namespace)(&t.Config.GatewayNamespace),
-			SectionName: &sectionName,
-		}
-		parentRefs = append(parentRefs, parentRef)
+	httpRoute.Spec.ParentRefs = t.buildParentRefs(hostnames)
+	if parentRefs := t.traefikEntryPointParentRefs(ingress); parentRefs != nil {
+		// Traefik entry points select the listeners of the pre-provisioned Gateway by port
+		httpRoute.Spec.ParentRefs = parentRefs
+	} else if parentRefs := t.albListenPortParentRefs(ingress); parentRefs != nil {
+		// So do ALB listen-ports
+		httpRoute.Spec.ParentRefs = parentRefs
 	}
-	httpRoute.Spec.ParentRefs = parentRefs
+	httpRoute.Spec.ParentRefs = append(httpRoute.Spec.ParentRefs, t.plainHTTPParentRefs(ingress)...)
 
 	requestHeaderFilter, responseHeaderFilter := buildHeaderModifierFilters(ingress.Annotations)
+	useRegex := nginxAnnotationEnabled(ingress.Annotations, nginxUseRegexKey)
+	redirectFilter, err := buildExternalRedirectFilter(ingress.Annotations)
+	if err != nil {
+		logger.Info("Ignoring redirect annotation",
+			"ingress", ingress.Name,
+			"namespace", ingress.Namespace,
+			"error", err.Error())
+	}
+	var hostRewriteFilter *gatewayv1.HTTPRouteFilter
+	if t.Config.HostRewriteSupported {
+		hostRewriteFilter = buildUpstreamVhostFilter(ingress.Annotations)
+	}
+	mirrorBackend, err := ResolveMirrorBackend(ingress)
+	if err != nil {
+		logger.Info("Ignoring mirror annotation",
+			"ingress", ingress.Name,
+			"namespace", ingress.Namespace,
+			"error", err.Error())
+	}
+	mirrorFilter := buildMirrorFilter(ingress.Namespace, mirrorBackend)
+	timeouts, timeoutFallback := BuildProxyTimeouts(ingress.Annotations)
+	if timeoutFallback {
+		logger.Info("Proxy timeouts exceed what Gateway API expresses, keeping them as nginx snippets",
+			"ingress", ingress.Name,
+			"namespace", ingress.Namespace)
+	}
+	haproxy := t.HAProxyDialect(ingress)
+	pathRewrite, _ := GetHAProxyAnnotation(ingress.Annotations, HAProxyPathRewriteKey)
+	if haproxy {
+		haproxyTimeouts, err := BuildHAProxyTimeouts(ingress.Annotations)
+		if err != nil {
+			logger.Info("Ignoring HAProxy timeout annotations",
+				"ingress", ingress.Name,
+				"namespace", ingress.Namespace,
+				"error", err.Error())
+		} else if haproxyTimeouts != nil {
+			timeouts = haproxyTimeouts
+		}
+	}
 
 	// Convert Ingress rules to HTTPRoute rules
 	var rules []gatewayv1.HTTPRouteRule
 	for _, rule := range ingress.Spec.Rules {
 		if rule.HTTP != nil {
 			for _, path := range rule.HTTP.Paths {
+				if t.Config.ImplementationSpecificPaths.SkipsPath(path) {
+					logger.Info("Skipping ImplementationSpecific path",
+						"ingress", ingress.Name,
+						"namespace", ingress.Namespace,
+						"path", path.Path)
+					continue
+				}
 				var backendRefs []gatewayv1.HTTPBackendRef
 
 				if path.Backend.Service != nil {
@@ -745,11 +833,24 @@ func (t *Translator) TranslateToHTTPRoute(ingress *networkingv1.Ingress) *gatewa
 						case networkingv1.PathTypeExact:
 							pathMatchType = gatewayv1.PathMatchExact
 						case networkingv1.PathTypeImplementationSpecific:
-							logger.Info("Converting PathType ImplementationSpecific to PathPrefix",
-								"ingress", ingress.Name,
-								"namespace", ingress.Namespace,
-								"path", path.Path)
-							pathMatchType = gatewayv1.PathMatchPathPrefix
+							regexPath := t.Config.ImplementationSpecificPaths.RegexPath(useRegex, path)
+							switch {
+							case regexPath && t.Config.RegexPathMatchSupported:
+								pathMatchType = gatewayv1.PathMatchRegularExpression
+							case regexPath:
+								logger.Info("Regex path matching not supported by GatewayClass, converting to PathPrefix",
+									"ingress", ingress.Name,
+									"namespace", ingress.Namespace,
+									"gatewayClass", t.Config.GatewayClassName,
+									"path", path.Path)
+								pathMatchType = gatewayv1.PathMatchPathPrefix
+							default:
+								logger.Info("Converting PathType ImplementationSpecific to PathPrefix",
+									"ingress", ingress.Name,
+									"namespace", ingress.Namespace,
+									"path", path.Path)
+								pathMatchType = gatewayv1.PathMatchPathPrefix
+							}
 						case networkingv1.PathTypePrefix:
 							pathMatchType = gatewayv1.PathMatchPathPrefix
 						}
@@ -763,25 +864,81 @@ func (t *Translator) TranslateToHTTPRoute(ingress *networkingv1.Ingress) *gatewa
 					matches = append(matches, match)
 				}
 
+				if redirectFilter != nil {
+					// Redirected paths never reach a backend, the rule consists solely of the redirect
+					rules = append(rules, gatewayv1.HTTPRouteRule{
+						Matches: matches,
+						Filters: []gatewayv1.HTTPRouteFilter{*redirectFilter},
+					})
+					continue
+				}
+
 				httpRouteRule := gatewayv1.HTTPRouteRule{
 					Matches:     matches,
 					BackendRefs: backendRefs,
 				}
+				if timeouts != nil {
+					httpRouteRule.Timeouts = timeouts.DeepCopy()
+				}
 				if requestHeaderFilter != nil {
 					httpRouteRule.Filters = append(httpRouteRule.Filters, *requestHeaderFilter)
 				}
 				if responseHeaderFilter != nil {
 					httpRouteRule.Filters = append(httpRouteRule.Filters, *responseHeaderFilter)
 				}
+				if hostRewriteFilter != nil {
+					httpRouteRule.Filters = append(httpRouteRule.Filters, *hostRewriteFilter.DeepCopy())
+				}
+				if mirrorFilter != nil {
+					httpRouteRule.Filters = append(httpRouteRule.Filters, *mirrorFilter.DeepCopy())
+				}
+				if haproxy && pathRewrite != "" {
+					if rewriteFilter, err := buildHAProxyPathRewriteFilter(
+						pathRewrite, path.Path, haproxyRulePathType(matches),
+					); err != nil {
+						logger.Info("Ignoring HAProxy path-rewrite for rule",
+							"ingress", ingress.Name,
+							"namespace", ingress.Namespace,
+							"error", err.Error())
+					} else {
+						httpRouteRule.Filters = append(httpRouteRule.Filters, *rewriteFilter)
+					}
+				}
 				rules = append(rules, httpRouteRule)
 			}
 		}
 	}
+	if appRootRule := buildAppRootRule(ingress.Annotations); appRootRule != nil {
+		rules = append(rules, *appRootRule)
+	}
 	httpRoute.Spec.Rules = rules
 
 	return httpRoute
 }
 
+// buildParentRefs attaches a route to the per-hostname (or consolidated wildcard) listeners of the Gateway
+func (t *Translator) buildParentRefs(hostnames []gatewayv1.Hostname) []gatewayv1.ParentReference {
+	if t.Config.AttachOnly {
+		return t.attachParentRefs()
+	}
+	parentRefs := make([]gatewayv1.ParentReference, 0, len(hostnames))
+	seen := make(map[gatewayv1.SectionName]bool, len(hostnames))
+	for _, hostname := range hostnames {
+		sectionName := ListenerName(t.ListenerHostname(string(hostname)))
+		if seen[sectionName] {
+			continue
+		}
+		seen[sectionName] = true
+		parentRef := gatewayv1.ParentReference{
+			Name:        gatewayv1.ObjectName(t.Config.GatewayName),
+			Namespace:   (*gatewayv1.Namespace)(&t.Config.GatewayNamespace),
+			SectionName: &sectionName,
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+	return parentRefs
+}
+
 func buildHeaderModifierFilters(annotations map[string]string) (*gatewayv1.HTTPRouteFilter, *gatewayv1.HTTPRouteFilter) {
 	if annotations == nil {
 		return nil, nil
@@ -793,6 +950,9 @@ func buildHeaderModifierFilters(annotations map[string]string) (*gatewayv1.HTTPR
 	responseAdd := parseHeaderNameValueList(annotations[ResponseHeaderAddAnnotation])
 	responseSet := parseHeaderNameValueList(annotations[ResponseHeaderSetAnnotation])
 	responseRemove := parseHeaderNameList(annotations[ResponseHeaderRemoveAnnotation])
+	if header, ok := xForwardedPrefix(annotations); ok && !hasHeader(requestSet, header.Name) && !hasHeader(requestAdd, header.Name) {
+		requestSet = append(requestSet, header)
+	}
 
 	var requestFilter *gatewayv1.HTTPRouteFilter
 	if len(requestAdd) > 0 || len(requestSet) > 0 || len(requestRemove) > 0 {
@@ -821,6 +981,81 @@ func buildHeaderModifierFilters(annotations map[string]string) (*gatewayv1.HTTPR
 	return requestFilter, responseFilter
 }
 
+// ApplyHeaderSets merges header values (e.g. resolved from proxy-set-headers / custom-headers
+// ConfigMaps) into the header modifier filters of all backend rules. Headers already set by
+// annotations take precedence.
+func ApplyHeaderSets(httpRoute *gatewayv1.HTTPRoute, requestSet, responseSet []gatewayv1.HTTPHeader) {
+	if httpRoute == nil || (len(requestSet) == 0 && len(responseSet) == 0) {
+		return
+	}
+	for i := range httpRoute.Spec.Rules {
+		rule := &httpRoute.Spec.Rules[i]
+		if len(rule.BackendRefs) == 0 {
+			continue
+		}
+		if len(requestSet) > 0 {
+			mergeHeaderSet(rule, gatewayv1.HTTPRouteFilterRequestHeaderModifier, requestSet)
+		}
+		if len(responseSet) > 0 {
+			mergeHeaderSet(rule, gatewayv1.HTTPRouteFilterResponseHeaderModifier, responseSet)
+		}
+	}
+}
+
+func mergeHeaderSet(rule *gatewayv1.HTTPRouteRule, filterType gatewayv1.HTTPRouteFilterType, headers []gatewayv1.HTTPHeader) {
+	index := -1
+	for i := range rule.Filters {
+		if rule.Filters[i].Type == filterType {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		rule.Filters = append(rule.Filters, gatewayv1.HTTPRouteFilter{Type: filterType})
+		index = len(rule.Filters) - 1
+	}
+
+	// Filters built from annotations are shared between rules, work on a copy
+	filter := &rule.Filters[index]
+	modifier := &gatewayv1.HTTPHeaderFilter{}
+	if filterType == gatewayv1.HTTPRouteFilterRequestHeaderModifier {
+		if filter.RequestHeaderModifier != nil {
+			modifier = filter.RequestHeaderModifier.DeepCopy()
+		}
+		filter.RequestHeaderModifier = modifier
+	} else {
+		if filter.ResponseHeaderModifier != nil {
+			modifier = filter.ResponseHeaderModifier.DeepCopy()
+		}
+		filter.ResponseHeaderModifier = modifier
+	}
+
+	existing := make(map[string]struct{}, len(modifier.Set)+len(modifier.Add))
+	for _, header := range modifier.Set {
+		existing[strings.ToLower(string(header.Name))] = struct{}{}
+	}
+	for _, header := range modifier.Add {
+		existing[strings.ToLower(string(header.Name))] = struct{}{}
+	}
+	for _, header := range headers {
+		name := strings.ToLower(string(header.Name))
+		if _, ok := existing[name]; ok {
+			continue
+		}
+		existing[name] = struct{}{}
+		modifier.Set = append(modifier.Set, header)
+	}
+}
+
+func hasHeader(headers []gatewayv1.HTTPHeader, name gatewayv1.HTTPHeaderName) bool {
+	for _, header := range headers {
+		if strings.EqualFold(string(header.Name), string(name)) {
+			return true
+		}
+	}
+	return false
+}
+
 func parseHeaderNameList(raw string) []string {
 	if strings.TrimSpace(raw) == "" {
 		return nil
@@ -883,9 +1118,24 @@ func isValidHostname(hostname string) bool {
 	return match
 }
 
-// TransformHostname applies hostname transformation rules
+// TransformHostname applies hostname transformation rules and renames followed by the hostname prefix
 // Supports multiple comma-separated from->to mappings
 func (t *Translator) TransformHostname(hostname string) string {
+	return prefixHostname(t.renameHostname(t.rewriteHostname(hostname)), t.Config.HostnamePrefix)
+}
+
+// prefixHostname adds prefix as the first label of hostname, after the * of wildcard hostnames
+func prefixHostname(hostname, prefix string) string {
+	if prefix == "" || hostname == "" {
+		return hostname
+	}
+	if rest, ok := strings.CutPrefix(hostname, "*."); ok {
+		return "*." + prefix + "." + rest
+	}
+	return prefix + "." + hostname
+}
+
+func (t *Translator) rewriteHostname(hostname string) string {
 	fromList := t.Config.HostnameRewriteFrom
 	toList := t.Config.HostnameRewriteTo
 
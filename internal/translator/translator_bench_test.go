@@ -0,0 +1,202 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Benchmarks of the Ingress shapes translation sees on every reconcile. Compare runs with
+// `make bench` and `make bench-gate`, see "Translator benchmarks" in the README.
+
+const (
+	benchManyHosts       = 50
+	benchManyPaths       = 200
+	benchSharedIngresses = 100
+)
+
+func benchTranslator() *Translator {
+	return New(Config{
+		GatewayNamespace:    "nginx-fabric",
+		GatewayName:         "ingress-doperator",
+		GatewayClassName:    "nginx",
+		HostnameRewriteFrom: "example.com",
+		HostnameRewriteTo:   "gw.example.com",
+		GatewayAnnotationFilters: []string{
+			"ingress.kubernetes.io", "nginx.ingress.kubernetes.io", "kubectl.kubernetes.io",
+			"kubernetes.io/ingress.class", "traefik.ingress.kubernetes.io", "ingress-doperator.fiction.si",
+			"external-dns.alpha.kubernetes.io",
+		},
+		HTTPRouteAnnotationFilters: []string{
+			"ingress.kubernetes.io", "nginx.ingress.kubernetes.io", "kubectl.kubernetes.io",
+			"kubernetes.io/ingress.class", "traefik.ingress.kubernetes.io", "ingress-doperator.fiction.si",
+		},
+	})
+}
+
+// benchIngress returns an Ingress with the given hosts, each serving paths paths. Every tenth host shares a
+// TLS Secret when tls is set
+func benchIngress(name string, hosts, paths int, tls bool) *networkingv1.Ingress {
+	className := "nginx"
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", Annotations: map[string]string{}},
+		Spec:       networkingv1.IngressSpec{IngressClassName: &className},
+	}
+	prefix := networkingv1.PathTypePrefix
+	exact := networkingv1.PathTypeExact
+	for h := range hosts {
+		host := fmt.Sprintf("%s-%d.example.com", name, h)
+		rule := networkingv1.IngressRule{
+			Host:             host,
+			IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{}},
+		}
+		for p := range paths {
+			pathType := &prefix
+			if p%3 == 2 {
+				pathType = &exact
+			}
+			rule.HTTP.Paths = append(rule.HTTP.Paths, networkingv1.HTTPIngressPath{
+				Path:     fmt.Sprintf("/api/v%d/items-%d", p%4, p),
+				PathType: pathType,
+				Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{
+					Name: fmt.Sprintf("svc-%d", p%8),
+					Port: networkingv1.ServiceBackendPort{Number: 8080},
+				}},
+			})
+		}
+		ingress.Spec.Rules = append(ingress.Spec.Rules, rule)
+		if !tls {
+			continue
+		}
+		secret := fmt.Sprintf("%s-tls-%d", name, h/10)
+		if h%10 == 0 {
+			ingress.Spec.TLS = append(ingress.Spec.TLS, networkingv1.IngressTLS{SecretName: secret})
+		}
+		last := &ingress.Spec.TLS[len(ingress.Spec.TLS)-1]
+		last.Hosts = append(last.Hosts, host)
+	}
+	return ingress
+}
+
+// heavyAnnotations sets the ingress-nginx annotations translation acts on plus unrelated ones the annotation
+// filters have to wade through
+func heavyAnnotations(ingress *networkingv1.Ingress) {
+	annotations := map[string]string{
+		NginxIngressAnnotationPrefix + nginxUseRegexKey:             "true",
+		NginxIngressAnnotationPrefix + nginxSSLRedirectKey:          "true",
+		NginxIngressAnnotationPrefix + NginxProxyConnectTimeoutKey:  "5",
+		NginxIngressAnnotationPrefix + NginxProxySendTimeoutKey:     "60",
+		NginxIngressAnnotationPrefix + NginxProxyReadTimeoutKey:     "60",
+		NginxIngressAnnotationPrefix + NginxLimitRPSKey:             "20",
+		NginxIngressAnnotationPrefix + NginxLimitBurstMultiplierKey: "3",
+		NginxIngressAnnotationPrefix + NginxLoadBalanceKey:          "ewma",
+		NginxIngressAnnotationPrefix + NginxXForwardedPrefixKey:     "/shop",
+		NginxIngressAnnotationPrefix + NginxEnableAccessLogKey:      "false",
+		NginxIngressAnnotationPrefix + "proxy-body-size":            "16m",
+		NginxIngressAnnotationPrefix + "enable-cors":                "true",
+		NginxIngressAnnotationPrefix + "cors-allow-origin":          "https://shop.example.com",
+		NginxIngressAnnotationPrefix + "configuration-snippet":      "more_set_headers \"X-Frame-Options: DENY\";",
+		"external-dns.alpha.kubernetes.io/hostname":                 "shop.example.com",
+		"kubectl.kubernetes.io/last-applied-configuration":          strings.Repeat("x", 4096),
+	}
+	var requestHeaders, responseHeaders []string
+	for i := range 10 {
+		requestHeaders = append(requestHeaders, fmt.Sprintf("X-Request-%d=value-%d", i, i))
+		responseHeaders = append(responseHeaders, fmt.Sprintf("X-Response-%d=value-%d", i, i))
+	}
+	annotations[RequestHeaderSetAnnotation] = strings.Join(requestHeaders, ",")
+	annotations[ResponseHeaderAddAnnotation] = strings.Join(responseHeaders, ",")
+	annotations[RequestHeaderRemoveAnnotation] = "X-Debug,X-Internal"
+	for i := range 40 {
+		annotations[fmt.Sprintf("team.example.com/label-%d", i)] = fmt.Sprintf("value-%d", i)
+	}
+	ingress.Annotations = annotations
+}
+
+// benchShapes are the representative Ingresses every benchmark runs against
+func benchShapes() []struct {
+	name    string
+	ingress *networkingv1.Ingress
+} {
+	heavy := benchIngress("heavy", 1, 10, true)
+	heavyAnnotations(heavy)
+	return []struct {
+		name    string
+		ingress *networkingv1.Ingress
+	}{
+		{"simple", benchIngress("simple", 1, 1, false)},
+		{"many-hosts", benchIngress("hosts", benchManyHosts, 2, true)},
+		{"many-paths", benchIngress("paths", 1, benchManyPaths, true)},
+		{"heavy-annotations", heavy},
+	}
+}
+
+func TestBenchShapesTranslate(t *testing.T) {
+	translator := benchTranslator()
+	for _, shape := range benchShapes() {
+		t.Run(shape.name, func(t *testing.T) {
+			gateway, httpRoute, err := translator.Translate(shape.ingress)
+			if err != nil {
+				t.Fatalf("Translate() error = %v", err)
+			}
+			if len(gateway.Spec.Listeners) == 0 {
+				t.Errorf("Gateway has no listeners")
+			}
+			if got, want := len(httpRoute.Spec.Hostnames), len(shape.ingress.Spec.Rules); got != want {
+				t.Errorf("HTTPRoute has %d hostnames, want %d", got, want)
+			}
+			matches := 0
+			for _, rule := range httpRoute.Spec.Rules {
+				matches += len(rule.Matches)
+			}
+			if paths := len(shape.ingress.Spec.Rules[0].HTTP.Paths); matches < paths {
+				t.Errorf("HTTPRoute has %d matches, want at least %d", matches, paths)
+			}
+		})
+	}
+}
+
+func BenchmarkTranslate(b *testing.B) {
+	translator := benchTranslator()
+	for _, shape := range benchShapes() {
+		b.Run(shape.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, _, err := translator.Translate(shape.ingress); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkTranslateMultipleToSharedGateway(b *testing.B) {
+	translator := benchTranslator()
+	ingresses := make([]networkingv1.Ingress, 0, benchSharedIngresses)
+	for i := range benchSharedIngresses {
+		ingresses = append(ingresses, *benchIngress(fmt.Sprintf("shared-%d", i), 3, 2, i%2 == 0))
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		translator.TranslateMultipleToSharedGateway(ingresses, "ingress-doperator-nginx")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package translator
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// wildcardListenerNamePrefix replaces "*." in listener names, which may not contain '*'
+const wildcardListenerNamePrefix = "wildcard."
+
+// ParseWildcardListenerDomains parses a comma-separated list of domains ("example.com" or "*.example.com")
+// whose hostnames share a wildcard certificate and get a single *.domain listener
+func ParseWildcardListenerDomains(value string) ([]string, error) {
+	var domains []string
+	for _, item := range strings.Split(value, ",") {
+		domain := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(item), "*."))
+		if domain == "" {
+			continue
+		}
+		if len(validation.IsDNS1123Subdomain(domain)) > 0 || !strings.Contains(domain, ".") {
+			return nil, fmt.Errorf("invalid wildcard listener domain %q", item)
+		}
+		domains = append(domains, domain)
+	}
+	return domains, nil
+}
+
+// WildcardListenerHostname returns *.<domain> when hostname is a direct subdomain of one of domains
+// (a wildcard certificate only covers a single label), otherwise hostname itself
+func WildcardListenerHostname(hostname string, domains []string) string {
+	if strings.HasPrefix(hostname, "*.") {
+		return hostname
+	}
+	label, parent, found := strings.Cut(hostname, ".")
+	if !found || label == "" {
+		return hostname
+	}
+	for _, domain := range domains {
+		if strings.EqualFold(parent, domain) {
+			return "*." + domain
+		}
+	}
+	return hostname
+}
+
+// ListenerName returns the listener (section) name for a listener hostname
+func ListenerName(listenerHostname string) gatewayv1.SectionName {
+	if domain, ok := strings.CutPrefix(listenerHostname, "*."); ok {
+		return gatewayv1.SectionName(wildcardListenerNamePrefix + domain)
+	}
+	return gatewayv1.SectionName(listenerHostname)
+}
+
+// ListenerHostname returns the hostname of the listener serving hostname
+func (t *Translator) ListenerHostname(hostname string) string {
+	return WildcardListenerHostname(hostname, t.Config.WildcardListenerDomains)
+}
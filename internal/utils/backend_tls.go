@@ -0,0 +1,407 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+const (
+	// BackendClientCertReferenceGrantName allows Gateways to use backend client certificates from other namespaces
+	BackendClientCertReferenceGrantName = "ingress-doperator-backend-client-certs"
+
+	backendCACertKey = "ca.crt"
+)
+
+// BackendTLSResult describes what EnsureBackendTLSForIngress configured
+type BackendTLSResult struct {
+	// ClientCertificate is set when the proxy-ssl-secret holds tls.crt/tls.key, which only
+	// the Gateway (spec.tls.backend.clientCertificateRef) can present
+	ClientCertificate *gatewayv1.SecretObjectReference
+	// WellKnownCA is true when the Secret has no ca.crt and the system CAs are used instead
+	WellKnownCA bool
+}
+
+// AutomaticBackendTLSPolicyName returns a stable name for the BackendTLSPolicy of an Ingress backend Service
+func AutomaticBackendTLSPolicyName(ingressName, serviceName string) string {
+	return trimK8sName(fmt.Sprintf("automatic-%s-%s-backend-tls", ingressName, serviceName))
+}
+
+// AutomaticBackendCAConfigMapName returns the ConfigMap holding the backend CA copied from proxy-ssl-secret
+func AutomaticBackendCAConfigMapName(ingressName string) string {
+	return trimK8sName(fmt.Sprintf("automatic-%s-backend-ca", ingressName))
+}
+
+func trimK8sName(name string) string {
+	if len(name) <= maxK8sNameLength {
+		return name
+	}
+	return strings.TrimRight(name[:maxK8sNameLength], "-.")
+}
+
+// EnsureBackendTLSForIngress translates proxy-ssl-* into one BackendTLSPolicy per backend Service.
+// The trusted CA (ca.crt of proxy-ssl-secret) is copied into a ConfigMap next to the policies,
+// because caCertificateRefs cannot cross namespaces. Secrets are read through reader so that they
+// are not cached.
+func EnsureBackendTLSForIngress(
+	ctx context.Context,
+	c client.Client,
+	reader client.Reader,
+	scheme *runtime.Scheme,
+	owner client.Object,
+	ingress *networkingv1.Ingress,
+	config *translator.ProxySSLConfig,
+) (BackendTLSResult, error) {
+	logger := log.FromContext(ctx)
+	result := BackendTLSResult{}
+
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Namespace: config.SecretNamespace, Name: config.SecretName}
+	if err := reader.Get(ctx, secretKey, secret); err != nil {
+		return result, fmt.Errorf("failed to get proxy-ssl-secret %s: %w", secretKey.String(), err)
+	}
+	if len(secret.Data[corev1.TLSCertKey]) > 0 && len(secret.Data[corev1.TLSPrivateKeyKey]) > 0 {
+		namespace := gatewayv1.Namespace(config.SecretNamespace)
+		result.ClientCertificate = &gatewayv1.SecretObjectReference{
+			Name:      gatewayv1.ObjectName(config.SecretName),
+			Namespace: &namespace,
+		}
+	}
+
+	annotations := map[string]string{
+		ManagedByAnnotation: ManagedByValue,
+		SourceAnnotation:    fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name),
+	}
+	setOwner := func(obj client.Object) error {
+		if scheme == nil || owner == nil {
+			return nil
+		}
+		return controllerutil.SetControllerReference(owner, obj, scheme)
+	}
+
+	var validation gatewayv1.BackendTLSPolicyValidation
+	caConfigMapName := AutomaticBackendCAConfigMapName(ingress.Name)
+	if caCert := secret.Data[backendCACertKey]; len(caCert) > 0 {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        caConfigMapName,
+				Namespace:   ingress.Namespace,
+				Annotations: annotations,
+			},
+			Data: map[string]string{backendCACertKey: string(caCert)},
+		}
+		if err := setOwner(cm); err != nil {
+			return result, err
+		}
+		if err := applyManagedConfigMap(ctx, c, cm); err != nil {
+			return result, err
+		}
+		validation.CACertificateRefs = []gatewayv1.LocalObjectReference{
+			{Group: "", Kind: "ConfigMap", Name: gatewayv1.ObjectName(caConfigMapName)},
+		}
+	} else {
+		result.WellKnownCA = true
+		validation.WellKnownCACertificates = ptr.To(gatewayv1.WellKnownCACertificatesSystem)
+		if err := deleteManagedConfigMap(ctx, c, ingress.Namespace, caConfigMapName); err != nil {
+			return result, err
+		}
+	}
+
+	desiredNames := make(map[string]struct{})
+	for _, service := range IngressBackendServiceNames(ingress) {
+		policyName := AutomaticBackendTLSPolicyName(ingress.Name, service)
+		desiredNames[policyName] = struct{}{}
+
+		hostname := config.ServerName
+		if hostname == "" {
+			hostname = fmt.Sprintf("%s.%s.svc", service, ingress.Namespace)
+		}
+		policyValidation := *validation.DeepCopy()
+		policyValidation.Hostname = gatewayv1.PreciseHostname(hostname)
+
+		desired := &gatewayv1.BackendTLSPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        policyName,
+				Namespace:   ingress.Namespace,
+				Annotations: annotations,
+			},
+			Spec: gatewayv1.BackendTLSPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					{
+						LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+							Group: "",
+							Kind:  "Service",
+							Name:  gatewayv1.ObjectName(service),
+						},
+					},
+				},
+				Validation: policyValidation,
+			},
+		}
+		if err := setOwner(desired); err != nil {
+			return result, err
+		}
+
+		existing := &gatewayv1.BackendTLSPolicy{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: ingress.Namespace, Name: policyName}, existing)
+		if apierrors.IsNotFound(err) {
+			logger.Info("Creating BackendTLSPolicy", "namespace", ingress.Namespace, "name", policyName)
+			if err := c.Create(ctx, desired); err != nil {
+				return result, fmt.Errorf("failed to create BackendTLSPolicy %s/%s: %w", ingress.Namespace, policyName, err)
+			}
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		if !IsManagedByUs(existing) {
+			logger.Info("BackendTLSPolicy exists but is not managed by us, skipping",
+				"namespace", ingress.Namespace,
+				"name", policyName)
+			continue
+		}
+		existing.Annotations = desired.Annotations
+		existing.OwnerReferences = desired.OwnerReferences
+		existing.Spec = desired.Spec
+		logger.V(1).Info("Updating BackendTLSPolicy", "namespace", ingress.Namespace, "name", policyName)
+		if err := c.Update(ctx, existing); err != nil {
+			return result, fmt.Errorf("failed to update BackendTLSPolicy %s/%s: %w", ingress.Namespace, policyName, err)
+		}
+	}
+
+	return result, deleteStaleBackendTLSPolicies(ctx, c, ingress, desiredNames)
+}
+
+// DeleteBackendTLSForIngress removes the BackendTLSPolicies and CA ConfigMap created for an Ingress
+func DeleteBackendTLSForIngress(ctx context.Context, c client.Client, ingress *networkingv1.Ingress) error {
+	if err := deleteStaleBackendTLSPolicies(ctx, c, ingress, nil); err != nil {
+		return err
+	}
+	return deleteManagedConfigMap(ctx, c, ingress.Namespace, AutomaticBackendCAConfigMapName(ingress.Name))
+}
+
+func deleteStaleBackendTLSPolicies(
+	ctx context.Context,
+	c client.Client,
+	ingress *networkingv1.Ingress,
+	keep map[string]struct{},
+) error {
+	var policies gatewayv1.BackendTLSPolicyList
+	if err := c.List(ctx, &policies, client.InNamespace(ingress.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			// BackendTLSPolicy CRD not installed, nothing to clean up
+			return nil
+		}
+		return fmt.Errorf("failed to list BackendTLSPolicies: %w", err)
+	}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if _, ok := keep[policy.Name]; ok {
+			continue
+		}
+		if !IsManagedByUsForIngress(policy, ingress.Namespace, ingress.Name) {
+			continue
+		}
+		log.FromContext(ctx).Info("Deleting BackendTLSPolicy", "namespace", policy.Namespace, "name", policy.Name)
+		if err := c.Delete(ctx, policy); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete BackendTLSPolicy %s/%s: %w", policy.Namespace, policy.Name, err)
+		}
+	}
+	return nil
+}
+
+func applyManagedConfigMap(ctx context.Context, c client.Client, desired *corev1.ConfigMap) error {
+	existing := &corev1.ConfigMap{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing)
+	if apierrors.IsNotFound(err) {
+		if err := c.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create ConfigMap %s/%s: %w", desired.Namespace, desired.Name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !IsManagedByUs(existing) {
+		return fmt.Errorf("ConfigMap %s/%s exists and is not managed by ingress-doperator", desired.Namespace, desired.Name)
+	}
+	existing.Annotations = desired.Annotations
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Data = desired.Data
+	if err := c.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update ConfigMap %s/%s: %w", desired.Namespace, desired.Name, err)
+	}
+	return nil
+}
+
+func deleteManagedConfigMap(ctx context.Context, c client.Client, namespace, name string) error {
+	existing := &corev1.ConfigMap{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, existing); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !IsManagedByUs(existing) {
+		return nil
+	}
+	if err := c.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete ConfigMap %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
+// SetGatewayBackendClientCertificate points spec.tls.backend.clientCertificateRef at ref. The
+// setting is Gateway-wide, so a Gateway already presenting another certificate is a conflict.
+func SetGatewayBackendClientCertificate(
+	gateway *gatewayv1.Gateway,
+	ref *gatewayv1.SecretObjectReference,
+) (changed bool, conflict bool) {
+	if ref == nil {
+		return false, false
+	}
+	if current := GatewayBackendClientCertificate(gateway); current != nil {
+		if sameSecretReference(current, ref, gateway.Namespace) {
+			return false, false
+		}
+		return false, true
+	}
+	if gateway.Spec.TLS == nil {
+		gateway.Spec.TLS = &gatewayv1.GatewayTLSConfig{}
+	}
+	if gateway.Spec.TLS.Backend == nil {
+		gateway.Spec.TLS.Backend = &gatewayv1.GatewayBackendTLS{}
+	}
+	gateway.Spec.TLS.Backend.ClientCertificateRef = ref.DeepCopy()
+	return true, false
+}
+
+// GatewayBackendClientCertificate returns the client certificate the Gateway presents to backends
+func GatewayBackendClientCertificate(gateway *gatewayv1.Gateway) *gatewayv1.SecretObjectReference {
+	if gateway == nil || gateway.Spec.TLS == nil || gateway.Spec.TLS.Backend == nil {
+		return nil
+	}
+	return gateway.Spec.TLS.Backend.ClientCertificateRef
+}
+
+func sameSecretReference(a, b *gatewayv1.SecretObjectReference, defaultNamespace string) bool {
+	namespace := func(ref *gatewayv1.SecretObjectReference) string {
+		if ref.Namespace == nil {
+			return defaultNamespace
+		}
+		return string(*ref.Namespace)
+	}
+	return a.Name == b.Name && namespace(a) == namespace(b)
+}
+
+// EnsureBackendClientCertReferenceGrant lets Gateways in gatewayNamespace use the client certificate
+// Secret from another namespace. Secret names and source Ingresses accumulate in the grant.
+func EnsureBackendClientCertReferenceGrant(
+	ctx context.Context,
+	c client.Client,
+	gatewayNamespace string,
+	ref *gatewayv1.SecretObjectReference,
+	ingress *networkingv1.Ingress,
+) error {
+	if ref == nil || ref.Namespace == nil || string(*ref.Namespace) == gatewayNamespace {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+	secretNamespace := string(*ref.Namespace)
+	source := fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name)
+
+	existing := &gatewayv1beta1.ReferenceGrant{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: secretNamespace, Name: BackendClientCertReferenceGrantName}, existing)
+	if apierrors.IsNotFound(err) {
+		name := ref.Name
+		grant := &gatewayv1beta1.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      BackendClientCertReferenceGrantName,
+				Namespace: secretNamespace,
+				Annotations: map[string]string{
+					ManagedByAnnotation: ManagedByValue,
+					SourceAnnotation:    source,
+				},
+			},
+			Spec: gatewayv1beta1.ReferenceGrantSpec{
+				From: []gatewayv1beta1.ReferenceGrantFrom{
+					{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: gatewayv1.Namespace(gatewayNamespace)},
+				},
+				To: []gatewayv1beta1.ReferenceGrantTo{{Group: "", Kind: "Secret", Name: &name}},
+			},
+		}
+		logger.Info("Creating backend client certificate ReferenceGrant",
+			"namespace", secretNamespace,
+			"name", BackendClientCertReferenceGrantName)
+		if err := c.Create(ctx, grant); err != nil {
+			return fmt.Errorf("failed to create ReferenceGrant: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !IsManagedByUs(existing) {
+		logger.Info("ReferenceGrant exists but is not managed by us (no managed-by annotation), skipping",
+			"namespace", secretNamespace, "name", BackendClientCertReferenceGrantName)
+		return nil
+	}
+
+	changed := false
+	sources := splitSources(existing.Annotations[SourceAnnotation])
+	if !ContainsString(sources, source) {
+		existing.Annotations[SourceAnnotation] = strings.Join(append(sources, source), ",")
+		changed = true
+	}
+	hasSecret := false
+	for _, to := range existing.Spec.To {
+		if to.Kind == "Secret" && to.Name != nil && *to.Name == ref.Name {
+			hasSecret = true
+			break
+		}
+	}
+	if !hasSecret {
+		name := ref.Name
+		existing.Spec.To = append(existing.Spec.To, gatewayv1beta1.ReferenceGrantTo{Group: "", Kind: "Secret", Name: &name})
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	if err := c.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update ReferenceGrant: %w", err)
+	}
+	return nil
+}
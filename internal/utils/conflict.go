@@ -0,0 +1,88 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	conflictAlternativeSuffix   = "-migrated"
+	conflictAlternativeAttempts = 5
+)
+
+// ResourceConflict is a generated resource whose name is taken by an object we do not manage
+type ResourceConflict struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// String renders the conflict as kind/namespace/name
+func (c ResourceConflict) String() string {
+	return fmt.Sprintf("%s/%s/%s", c.Kind, c.Namespace, c.Name)
+}
+
+// FindUnmanagedConflict returns a conflict when the object at namespacedName exists without
+// the managed-by annotation, i.e. when CanUpdateResource would skip it
+func FindUnmanagedConflict(
+	ctx context.Context,
+	c client.Client,
+	kind string,
+	obj client.Object,
+	namespacedName types.NamespacedName,
+) (*ResourceConflict, error) {
+	if err := c.Get(ctx, namespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if IsManagedByUs(obj) {
+		return nil, nil
+	}
+	return &ResourceConflict{Kind: kind, Namespace: namespacedName.Namespace, Name: namespacedName.Name}, nil
+}
+
+// ProposeAlternativeName returns the first free <name>-migrated[-N] next to the conflicting object.
+// obj is only used as a scratch object of the right type.
+func ProposeAlternativeName(
+	ctx context.Context,
+	c client.Client,
+	obj client.Object,
+	conflict ResourceConflict,
+) (string, error) {
+	for attempt := 1; attempt <= conflictAlternativeAttempts; attempt++ {
+		candidate := conflict.Name + conflictAlternativeSuffix
+		if attempt > 1 {
+			candidate = fmt.Sprintf("%s-%d", candidate, attempt)
+		}
+		err := c.Get(ctx, types.NamespacedName{Namespace: conflict.Namespace, Name: candidate}, obj)
+		if apierrors.IsNotFound(err) {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no free alternative name for %s", conflict.String())
+}
@@ -0,0 +1,190 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+// UntranslatedFeature is something an Ingress uses that its Gateway API resources do not carry over
+type UntranslatedFeature struct {
+	// Field is the annotation key or the path of the spec field
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// translatedNginxAnnotations are the ingress-nginx annotations (without prefix) translated into Gateway API
+// fields, policies or SnippetsFilter directives, besides the directives in nginxIngressDirectiveWhitelist
+var translatedNginxAnnotations = map[string]struct{}{
+	"app-root":                              {},
+	"use-regex":                             {},
+	"permanent-redirect":                    {},
+	"permanent-redirect-code":               {},
+	"temporal-redirect":                     {},
+	"default-backend":                       {},
+	"mirror-target":                         {},
+	"mirror-host":                           {},
+	translator.NginxProxySetHeadersKey:      {},
+	translator.NginxCustomHeadersKey:        {},
+	translator.NginxUpstreamHashByKey:       {},
+	translator.NginxUpstreamVhostKey:        {},
+	translator.NginxXForwardedPrefixKey:     {},
+	translator.NginxEnableAccessLogKey:      {},
+	translator.NginxAuthTypeKey:             {},
+	translator.NginxAuthSecretKey:           {},
+	translator.NginxAuthSecretTypeKey:       {},
+	translator.NginxAuthURLKey:              {},
+	translator.NginxAuthResponseHeadersKey:  {},
+	translator.NginxLimitRPSKey:             {},
+	translator.NginxLimitRPMKey:             {},
+	translator.NginxLimitBurstMultiplierKey: {},
+	translator.NginxLoadBalanceKey:          {},
+	translator.NginxProxySSLSecretKey:       {},
+	translator.NginxSSLPassthroughKey:       {},
+	translator.NginxBackendProtocolKey:      {},
+	sslRedirectKey:                          {},
+	forceSSLRedirectKey:                     {},
+	preserveTrailingSlashKey:                {},
+	proxyBodySizeKey:                        {},
+	clientMaxBodySizeKey:                    {},
+	proxyRedirectFromKey:                    {},
+	proxyRedirectToKey:                      {},
+	proxyBuffersNumberKey:                   {},
+	allowlistSourceRangeKey:                 {},
+	whitelistSourceRangeKey:                 {},
+	denylistSourceRangeKey:                  {},
+	blacklistSourceRangeKey:                 {},
+	customHTTPErrorsKey:                     {},
+	rewriteTargetKey:                        {},
+}
+
+// AnnotationTranslation is how an ingress-nginx annotation is carried over to Gateway API
+type AnnotationTranslation string
+
+const (
+	// AnnotationTranslationNative is a Gateway API field, filter or policy
+	AnnotationTranslationNative AnnotationTranslation = "native"
+	// AnnotationTranslationSnippet is a directive of the automatic SnippetsFilter
+	AnnotationTranslationSnippet AnnotationTranslation = "snippet"
+	// AnnotationTranslationDropped is not carried over at all
+	AnnotationTranslationDropped AnnotationTranslation = "dropped"
+)
+
+// snippetNginxAnnotations are the translatedNginxAnnotations that end up as SnippetsFilter directives
+var snippetNginxAnnotations = map[string]struct{}{
+	sslRedirectKey:           {},
+	forceSSLRedirectKey:      {},
+	preserveTrailingSlashKey: {},
+	proxyBodySizeKey:         {},
+	clientMaxBodySizeKey:     {},
+	proxyRedirectFromKey:     {},
+	proxyRedirectToKey:       {},
+	proxyBuffersNumberKey:    {},
+	allowlistSourceRangeKey:  {},
+	whitelistSourceRangeKey:  {},
+	denylistSourceRangeKey:   {},
+	blacklistSourceRangeKey:  {},
+	customHTTPErrorsKey:      {},
+	rewriteTargetKey:         {},
+}
+
+// NginxAnnotationTranslation returns how the ingress-nginx annotation with the given suffix (the key
+// without prefix) is translated, regardless of whether the data plane accepts SnippetsFilters
+func NginxAnnotationTranslation(suffix string) AnnotationTranslation {
+	if strings.HasSuffix(suffix, "-snippet") {
+		return AnnotationTranslationDropped
+	}
+	if _, ok := snippetNginxAnnotations[suffix]; ok || isWhitelistedNginxIngressDirective(suffix) {
+		return AnnotationTranslationSnippet
+	}
+	if _, ok := translatedNginxAnnotations[suffix]; ok {
+		return AnnotationTranslationNative
+	}
+	return AnnotationTranslationDropped
+}
+
+// UntranslatedIngressFeatures returns the ingress-nginx annotations and spec fields of an Ingress that its
+// Gateway API resources leave out, sorted by field. implementationSpecific is the translation policy of
+// ImplementationSpecific paths and regexPathMatchSupported tells whether regex paths become RegularExpression
+// matches.
+func UntranslatedIngressFeatures(
+	ingress *networkingv1.Ingress,
+	implementationSpecific translator.ImplementationSpecificPolicy,
+	regexPathMatchSupported bool,
+) []UntranslatedFeature {
+	var features []UntranslatedFeature
+	for key := range ingress.Annotations {
+		suffix, ok := strings.CutPrefix(key, nginxIngressAnnotationPrefix)
+		if !ok || suffix == "" {
+			continue
+		}
+		if strings.HasSuffix(suffix, "-snippet") {
+			features = append(features, UntranslatedFeature{Field: key,
+				Reason: "raw nginx snippets are not translated, reference a SnippetsFilter with " +
+					"ingress-doperator.fiction.si/httproute-snippets-filter instead"})
+			continue
+		}
+		if _, ok := translatedNginxAnnotations[suffix]; ok || isWhitelistedNginxIngressDirective(suffix) {
+			continue
+		}
+		features = append(features, UntranslatedFeature{Field: key,
+			Reason: "no Gateway API or SnippetsFilter equivalent"})
+	}
+
+	useRegex, _ := translator.GetNginxAnnotation(ingress.Annotations, useRegexKey)
+	for i, rule := range ingress.Spec.Rules {
+		if rule.Host == "" && rule.HTTP != nil {
+			features = append(features, UntranslatedFeature{Field: fmt.Sprintf("spec.rules[%d].host", i),
+				Reason: "rules without host are only served on the hostnames of the other rules"})
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for j, path := range rule.HTTP.Paths {
+			field := fmt.Sprintf("spec.rules[%d].http.paths[%d]", i, j)
+			if path.Backend.Resource != nil {
+				features = append(features, UntranslatedFeature{Field: field + ".backend.resource",
+					Reason: "resource backends are not supported, the rule has no backend"})
+			}
+			if path.PathType == nil || *path.PathType != networkingv1.PathTypeImplementationSpecific {
+				continue
+			}
+			switch {
+			case implementationSpecific.SkipsPath(path):
+				features = append(features, UntranslatedFeature{Field: field,
+					Reason: "ImplementationSpecific paths are skipped, the path is not routed"})
+			case !implementationSpecific.RegexPath(strings.EqualFold(useRegex, "true"), path):
+				features = append(features, UntranslatedFeature{Field: field + ".pathType",
+					Reason: "ImplementationSpecific is translated to PathPrefix"})
+			case !regexPathMatchSupported:
+				features = append(features, UntranslatedFeature{Field: field + ".pathType",
+					Reason: "the GatewayClass does not support RegularExpression matches, translated to PathPrefix"})
+			}
+		}
+	}
+
+	sort.Slice(features, func(i, j int) bool {
+		return features[i].Field < features[j].Field
+	})
+	return features
+}
@@ -0,0 +1,98 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+const defaultBackendKey = "default-backend"
+
+// CustomErrorBackend is the Service of the default-backend annotation that serves the custom-http-errors
+// responses of an Ingress
+type CustomErrorBackend struct {
+	Service string
+	Codes   []string
+}
+
+// CustomHTTPErrorBackend returns the error backend of an Ingress with both custom-http-errors and
+// default-backend. ingress-nginx proxies such errors to that Service instead of returning them.
+func CustomHTTPErrorBackend(annotations map[string]string) (CustomErrorBackend, bool) {
+	service, ok := translator.GetNginxAnnotation(annotations, defaultBackendKey)
+	if !ok || service == "" {
+		return CustomErrorBackend{}, false
+	}
+	value, ok := translator.GetNginxAnnotation(annotations, customHTTPErrorsKey)
+	if !ok {
+		return CustomErrorBackend{}, false
+	}
+	codes, _ := parseCustomHTTPErrors(value)
+	if len(codes) == 0 {
+		return CustomErrorBackend{}, false
+	}
+	return CustomErrorBackend{Service: service, Codes: codes}, true
+}
+
+// BuildCustomErrorPageSnippets renders custom-http-errors with a default-backend like ingress-nginx: the
+// locations of the Ingress intercept the listed status codes and hand them to a named location per code
+// that proxies to the error backend with the X-Code, X-Format, X-Original-URI, X-Namespace,
+// X-Ingress-Name and X-Request-ID headers. Named locations carry a hash of the Ingress so that several
+// Ingresses sharing a host can each use their own error backend.
+func BuildCustomErrorPageSnippets(
+	ingressNamespace, ingressName string,
+	backend CustomErrorBackend,
+	address string,
+	port int32,
+) []map[string]interface{} {
+	sum := sha256.Sum256([]byte(ingressNamespace + "/" + ingressName))
+	prefix := "@ingress_doperator_error_" + hex.EncodeToString(sum[:])[:10] + "_"
+
+	locationLines := []string{"proxy_intercept_errors on;"}
+	serverBlocks := make([]string, 0, len(backend.Codes))
+	for _, code := range backend.Codes {
+		locationLines = append(locationLines, fmt.Sprintf("error_page %s = %s%s;", code, prefix, code))
+		serverBlocks = append(serverBlocks, strings.Join([]string{
+			fmt.Sprintf("location %s%s {", prefix, code),
+			fmt.Sprintf("    proxy_set_header X-Code %s;", code),
+			"    proxy_set_header X-Format $http_accept;",
+			"    proxy_set_header X-Original-URI $request_uri;",
+			fmt.Sprintf("    proxy_set_header X-Namespace %s;", ingressNamespace),
+			fmt.Sprintf("    proxy_set_header X-Ingress-Name %s;", ingressName),
+			"    proxy_set_header X-Request-ID $request_id;",
+			"    proxy_set_header Host $host;",
+			fmt.Sprintf("    proxy_pass http://%s;", net.JoinHostPort(address, strconv.Itoa(int(port)))),
+			"}",
+		}, "\n"))
+	}
+	return []map[string]interface{}{
+		{
+			"context": serverSnippetContext,
+			"value":   strings.Join(serverBlocks, "\n"),
+		},
+		{
+			"context": "http.server.location",
+			"value":   strings.Join(locationLines, "\n"),
+		},
+	}
+}
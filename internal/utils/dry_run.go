@@ -0,0 +1,371 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+)
+
+const (
+	DryRunReportConfigMapName = "ingress-doperator-dry-run-report"
+
+	// dryRunReportMaxBytes keeps the report below the 1 MiB ConfigMap limit
+	dryRunReportMaxBytes = 900 * 1024
+)
+
+// DryRunAction is a write the operator would have made
+type DryRunAction struct {
+	Verb string `json:"verb"`
+	Kind string `json:"kind"`
+	// Object is the namespace/name (or name) of the object written
+	Object string `json:"object"`
+}
+
+func (a DryRunAction) String() string {
+	return fmt.Sprintf("%s %s %s", a.Verb, a.Kind, a.Object)
+}
+
+type dryRunRecorderKey struct{}
+
+// dryRunRecorder collects the writes of one reconcile
+type dryRunRecorder struct {
+	mu      sync.Mutex
+	actions []DryRunAction
+}
+
+// WithDryRunRecorder returns a context in which writes through a dry-run client are collected
+func WithDryRunRecorder(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunRecorderKey{}, &dryRunRecorder{})
+}
+
+// DryRunActions returns the writes collected in ctx, in order and without repetitions
+func DryRunActions(ctx context.Context) []DryRunAction {
+	recorder, ok := ctx.Value(dryRunRecorderKey{}).(*dryRunRecorder)
+	if !ok {
+		return nil
+	}
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	seen := make(map[DryRunAction]bool, len(recorder.actions))
+	actions := make([]DryRunAction, 0, len(recorder.actions))
+	for _, action := range recorder.actions {
+		if !seen[action] {
+			seen[action] = true
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// NewDryRunClient returns a client that sends every write with dryRun=All, so the API server validates
+// and admits it without persisting anything, and collects the writes in the context of the caller
+func NewDryRunClient(c client.Client) client.Client {
+	return &dryRunClient{Client: client.NewDryRunClient(c)}
+}
+
+// NewReadOnlyClient returns a client that drops every write without sending it, so it needs no write
+// permissions. Writes are logged, counted and collected in the context of the caller like with NewDryRunClient.
+func NewReadOnlyClient(c client.Client) client.Client {
+	return &dryRunClient{Client: c, readOnly: true}
+}
+
+type dryRunClient struct {
+	client.Client
+	readOnly bool
+}
+
+func (c *dryRunClient) record(ctx context.Context, verb string, obj runtime.Object, subResource string) {
+	action := DryRunAction{Verb: verb, Kind: "unknown"}
+	if gvk, err := c.GroupVersionKindFor(obj); err == nil {
+		action.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+	if subResource != "" {
+		action.Kind += "/" + subResource
+	}
+	if object, ok := obj.(client.Object); ok {
+		action.Object = client.ObjectKeyFromObject(object).String()
+		action.Object = strings.TrimPrefix(action.Object, "/")
+	}
+	if c.readOnly {
+		log.FromContext(ctx).Info("Read only, not sent", "verb", action.Verb, "kind", action.Kind,
+			"object", action.Object)
+		metrics.ReadOnlyWritesTotal.WithLabelValues(action.Verb, action.Kind).Inc()
+	} else {
+		log.FromContext(ctx).Info("Dry run, not persisted", "verb", action.Verb, "kind", action.Kind,
+			"object", action.Object)
+	}
+	if recorder, ok := ctx.Value(dryRunRecorderKey{}).(*dryRunRecorder); ok {
+		recorder.mu.Lock()
+		recorder.actions = append(recorder.actions, action)
+		recorder.mu.Unlock()
+	}
+}
+
+func (c *dryRunClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.record(ctx, "create", obj, "")
+	if c.readOnly {
+		return nil
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if isDryRunUpdate(opts) {
+		// Already a dry run, e.g. LogUpdateDiff, so not a write to report. Read-only clients may not
+		// have the permissions to send it.
+		if c.readOnly {
+			return nil
+		}
+		return c.Client.Update(ctx, obj, opts...)
+	}
+	c.record(ctx, "update", obj, "")
+	if c.readOnly {
+		return nil
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// isDryRunUpdate reports whether opts ask for a dry-run update
+func isDryRunUpdate(opts []client.UpdateOption) bool {
+	updateOpts := &client.UpdateOptions{}
+	updateOpts.ApplyOptions(opts)
+	return len(updateOpts.DryRun) > 0
+}
+
+func (c *dryRunClient) Patch(
+	ctx context.Context,
+	obj client.Object,
+	patch client.Patch,
+	opts ...client.PatchOption,
+) error {
+	c.record(ctx, "patch", obj, "")
+	if c.readOnly {
+		return nil
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *dryRunClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	c.record(ctx, "delete", obj, "")
+	if c.readOnly {
+		return nil
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	c.record(ctx, "deletecollection", obj, "")
+	if c.readOnly {
+		return nil
+	}
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+func (c *dryRunClient) Status() client.SubResourceWriter {
+	return c.SubResource("status")
+}
+
+func (c *dryRunClient) SubResource(subResource string) client.SubResourceClient {
+	return &dryRunSubResourceClient{
+		SubResourceClient: c.Client.SubResource(subResource),
+		parent:            c,
+		subResource:       subResource,
+	}
+}
+
+type dryRunSubResourceClient struct {
+	client.SubResourceClient
+	parent      *dryRunClient
+	subResource string
+}
+
+func (c *dryRunSubResourceClient) Create(
+	ctx context.Context,
+	obj client.Object,
+	subResource client.Object,
+	opts ...client.SubResourceCreateOption,
+) error {
+	c.parent.record(ctx, "create", obj, c.subResource)
+	if c.parent.readOnly {
+		return nil
+	}
+	return c.SubResourceClient.Create(ctx, obj, subResource, opts...)
+}
+
+func (c *dryRunSubResourceClient) Update(
+	ctx context.Context,
+	obj client.Object,
+	opts ...client.SubResourceUpdateOption,
+) error {
+	c.parent.record(ctx, "update", obj, c.subResource)
+	if c.parent.readOnly {
+		return nil
+	}
+	return c.SubResourceClient.Update(ctx, obj, opts...)
+}
+
+func (c *dryRunSubResourceClient) Patch(
+	ctx context.Context,
+	obj client.Object,
+	patch client.Patch,
+	opts ...client.SubResourcePatchOption,
+) error {
+	c.parent.record(ctx, "patch", obj, c.subResource)
+	if c.parent.readOnly {
+		return nil
+	}
+	return c.SubResourceClient.Patch(ctx, obj, patch, opts...)
+}
+
+// DryRunReportEntry is what the operator would do for one Ingress
+type DryRunReportEntry struct {
+	// UpdatedAt is when the actions last changed
+	UpdatedAt time.Time      `json:"updatedAt"`
+	Actions   []DryRunAction `json:"actions"`
+}
+
+// DryRunReport keeps the writes the operator would make, per Ingress, in a ConfigMap. It is written with
+// a client that persists, unlike everything else in dry-run mode.
+type DryRunReport struct {
+	client    client.Client
+	reader    client.Reader
+	namespace string
+	name      string
+
+	mu sync.Mutex
+}
+
+// NewDryRunReport creates a report in the namespace/name ConfigMap. Reads go through reader, which should
+// be uncached so that updates do not keep conflicting.
+func NewDryRunReport(c client.Client, reader client.Reader, namespace, name string) *DryRunReport {
+	return &DryRunReport{client: c, reader: reader, namespace: namespace, name: name}
+}
+
+// dryRunReportKey returns the ConfigMap key of an Ingress of cluster (empty for the local cluster),
+// ConfigMap keys cannot contain a slash
+func dryRunReportKey(cluster string, ingress types.NamespacedName) string {
+	key := ingress.Namespace + "." + ingress.Name
+	if cluster != "" {
+		key = cluster + "." + key
+	}
+	return key
+}
+
+// Record replaces the entry of an Ingress with the writes collected in ctx. An entry that does not fit
+// into the ConfigMap is logged and left out.
+func (r *DryRunReport) Record(ctx context.Context, cluster string, ingress types.NamespacedName) error {
+	if r == nil {
+		return nil
+	}
+	actions := DryRunActions(ctx)
+	raw, err := json.Marshal(DryRunReportEntry{UpdatedAt: time.Now().UTC(), Actions: actions})
+	if err != nil {
+		return err
+	}
+	key := dryRunReportKey(cluster, ingress)
+	return r.modify(ctx, func(data map[string]string) error {
+		var existing DryRunReportEntry
+		if err := json.Unmarshal([]byte(data[key]), &existing); err == nil && slices.Equal(existing.Actions, actions) {
+			return nil
+		}
+		size := len(key) + len(raw)
+		for other, value := range data {
+			if other != key {
+				size += len(other) + len(value)
+			}
+		}
+		if size > dryRunReportMaxBytes {
+			delete(data, key)
+			log.FromContext(ctx).Info("Dry run report is full, leaving out Ingress", "ingress", ingress.String())
+			return nil
+		}
+		data[key] = string(raw)
+		return nil
+	})
+}
+
+// Remove drops the entry of an Ingress that no longer exists
+func (r *DryRunReport) Remove(ctx context.Context, cluster string, ingress types.NamespacedName) error {
+	if r == nil {
+		return nil
+	}
+	key := dryRunReportKey(cluster, ingress)
+	return r.modify(ctx, func(data map[string]string) error {
+		delete(data, key)
+		return nil
+	})
+}
+
+// modify applies fn to the data of the report ConfigMap, creating it when missing
+func (r *DryRunReport) modify(ctx context.Context, fn func(data map[string]string) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		err := r.reader.Get(ctx, client.ObjectKey{Namespace: r.namespace, Name: r.name}, cm)
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: r.name, Namespace: r.namespace},
+				Data:       make(map[string]string),
+			}
+			if err := fn(cm.Data); err != nil {
+				return err
+			}
+			if len(cm.Data) == 0 {
+				return nil
+			}
+			err = r.client.Create(ctx, cm)
+			if apierrors.IsAlreadyExists(err) {
+				// Created concurrently, retry as an update
+				return apierrors.NewConflict(corev1.Resource("configmaps"), r.name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		before := maps.Clone(cm.Data)
+		if err := fn(cm.Data); err != nil {
+			return err
+		}
+		if maps.Equal(before, cm.Data) {
+			return nil
+		}
+		return r.client.Update(ctx, cm)
+	})
+}
@@ -0,0 +1,226 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+const (
+	EnvoyGatewayGroup              = "gateway.envoyproxy.io"
+	BackendTrafficPolicyKind       = "BackendTrafficPolicy"
+	ClientTrafficPolicyKind        = "ClientTrafficPolicy"
+	SecurityPolicyKind             = "SecurityPolicy"
+	BackendTrafficPolicyCRDName    = "backendtrafficpolicies.gateway.envoyproxy.io"
+	ClientTrafficPolicyCRDName     = "clienttrafficpolicies.gateway.envoyproxy.io"
+	SecurityPolicyCRDName          = "securitypolicies.gateway.envoyproxy.io"
+	envoyGatewayBasicAuthSecretKey = ".htpasswd"
+	ingressNginxBasicAuthSecretKey = "auth"
+)
+
+// EnvoyGatewayLoadBalancer translates upstream-hash-by and load-balance into a BackendTrafficPolicy
+// loadBalancer. Hash keys other than the client address, a header or a cookie have no equivalent.
+func EnvoyGatewayLoadBalancer(loadBalancing translator.UpstreamLoadBalancing) (map[string]interface{}, bool) {
+	switch {
+	case loadBalancing.HashKey != "":
+		hash, ok := envoyGatewayConsistentHash(loadBalancing.HashKey)
+		if !ok {
+			return nil, false
+		}
+		return map[string]interface{}{"type": "ConsistentHash", "consistentHash": hash}, true
+	case loadBalancing.Method == "ip_hash":
+		return map[string]interface{}{
+			"type":           "ConsistentHash",
+			"consistentHash": map[string]interface{}{"type": "SourceIP"},
+		}, true
+	case loadBalancing.Method == translator.RoundRobinLoadBalancingMethod:
+		// Envoy Gateway defaults to LeastRequest
+		return map[string]interface{}{"type": "RoundRobin"}, true
+	case loadBalancing.Approximated:
+		// ewma prefers the least loaded endpoint, which LeastRequest does too
+		return map[string]interface{}{"type": "LeastRequest"}, true
+	}
+	return nil, false
+}
+
+func envoyGatewayConsistentHash(hashKey string) (map[string]interface{}, bool) {
+	switch {
+	case hashKey == "$remote_addr" || hashKey == "$binary_remote_addr":
+		return map[string]interface{}{"type": "SourceIP"}, true
+	case strings.HasPrefix(hashKey, "$http_") && len(hashKey) > len("$http_"):
+		header := strings.ReplaceAll(strings.TrimPrefix(hashKey, "$http_"), "_", "-")
+		return map[string]interface{}{"type": "Header", "header": map[string]interface{}{"name": header}}, true
+	case strings.HasPrefix(hashKey, "$cookie_") && len(hashKey) > len("$cookie_"):
+		cookie := strings.TrimPrefix(hashKey, "$cookie_")
+		return map[string]interface{}{"type": "Cookie", "cookie": map[string]interface{}{"name": cookie}}, true
+	}
+	return nil, false
+}
+
+// EnvoyGatewayLocalRateLimit translates a rate limit into a BackendTrafficPolicy rateLimit. Envoy Gateway
+// counts per Gateway replica and has no burst, the bucket holds Requests tokens.
+func EnvoyGatewayLocalRateLimit(limit translator.RateLimit) map[string]interface{} {
+	unit := "Second"
+	if limit.Interval == time.Minute {
+		unit = "Minute"
+	}
+	return map[string]interface{}{
+		"type": "Local",
+		"local": map[string]interface{}{
+			"rules": []interface{}{
+				map[string]interface{}{
+					"limit": map[string]interface{}{"requests": int64(limit.Requests), "unit": unit},
+				},
+			},
+		},
+	}
+}
+
+// EnvoyGatewayActiveHealthCheck translates an ALB target group health check into a BackendTrafficPolicy
+// active HTTP health check
+func EnvoyGatewayActiveHealthCheck(healthCheck translator.ALBHealthCheck) map[string]interface{} {
+	expectedStatuses := make([]interface{}, 0, len(healthCheck.SuccessCodes))
+	for _, code := range healthCheck.SuccessCodes {
+		expectedStatuses = append(expectedStatuses, int64(code))
+	}
+	return map[string]interface{}{
+		"active": map[string]interface{}{
+			"type":               "HTTP",
+			"interval":           healthCheck.Interval.String(),
+			"timeout":            healthCheck.Timeout.String(),
+			"healthyThreshold":   int64(healthCheck.HealthyThreshold),
+			"unhealthyThreshold": int64(healthCheck.UnhealthyThreshold),
+			"http": map[string]interface{}{
+				"path":             healthCheck.Path,
+				"expectedStatuses": expectedStatuses,
+			},
+		},
+	}
+}
+
+// BuildEnvoyGatewayRoutePolicy returns an Envoy Gateway policy attached to the named HTTPRoutes
+func BuildEnvoyGatewayRoutePolicy(
+	name string,
+	routeNames []string,
+	spec map[string]interface{},
+) *unstructured.Unstructured {
+	targetRefs := make([]interface{}, 0, len(routeNames))
+	for _, routeName := range routeNames {
+		targetRefs = append(targetRefs, map[string]interface{}{
+			"group": gatewayv1.GroupName,
+			"kind":  "HTTPRoute",
+			"name":  routeName,
+		})
+	}
+	spec["targetRefs"] = targetRefs
+
+	object := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	object.SetName(name)
+	return object
+}
+
+// BuildClientTrafficPolicy returns a ClientTrafficPolicy attached to a Gateway
+func BuildClientTrafficPolicy(name, gatewayName string, spec map[string]interface{}) *unstructured.Unstructured {
+	spec["targetRefs"] = []interface{}{gatewayTargetRef(gatewayName)}
+	object := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	object.SetName(name)
+	return object
+}
+
+// BuildExternalAuth returns a SecurityPolicy extAuth calling the auth Service over HTTP
+func BuildExternalAuth(auth translator.ExternalAuth) map[string]interface{} {
+	http := map[string]interface{}{
+		"backendRefs": []interface{}{
+			map[string]interface{}{
+				"name":      auth.Service,
+				"namespace": auth.Namespace,
+				"port":      int64(auth.Port),
+			},
+		},
+	}
+	if auth.Path != "" && auth.Path != "/" {
+		http["path"] = auth.Path
+	}
+	if len(auth.ResponseHeaders) > 0 {
+		headers := make([]interface{}, 0, len(auth.ResponseHeaders))
+		for _, header := range auth.ResponseHeaders {
+			headers = append(headers, header)
+		}
+		http["headersToBackend"] = headers
+	}
+	return map[string]interface{}{"http": http}
+}
+
+// BuildBasicAuthSecret returns a Secret holding the htpasswd entries of an ingress-nginx auth-secret under
+// the .htpasswd key Envoy Gateway expects. It also returns the users whose hash is not {SHA}, which
+// Envoy Gateway rejects.
+func BuildBasicAuthSecret(
+	name string,
+	source *corev1.Secret,
+	secretType string,
+) (*unstructured.Unstructured, []string, error) {
+	lines := make([]string, 0)
+	if secretType == translator.AuthMapSecretType {
+		users := make([]string, 0, len(source.Data))
+		for user := range source.Data {
+			users = append(users, user)
+		}
+		sort.Strings(users)
+		for _, user := range users {
+			lines = append(lines, user+":"+strings.TrimSpace(string(source.Data[user])))
+		}
+	} else {
+		scanner := bufio.NewScanner(strings.NewReader(string(source.Data[ingressNginxBasicAuthSecretKey])))
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				lines = append(lines, line)
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return nil, nil, fmt.Errorf("auth-secret %s/%s has no htpasswd entries", source.Namespace, source.Name)
+	}
+
+	unsupported := make([]string, 0)
+	for _, line := range lines {
+		user, hash, _ := strings.Cut(line, ":")
+		if !strings.HasPrefix(hash, "{SHA}") {
+			unsupported = append(unsupported, user)
+		}
+	}
+
+	htpasswd := strings.Join(lines, "\n") + "\n"
+	object := &unstructured.Unstructured{Object: map[string]interface{}{
+		"type": string(corev1.SecretTypeOpaque),
+		"data": map[string]interface{}{
+			envoyGatewayBasicAuthSecretKey: base64.StdEncoding.EncodeToString([]byte(htpasswd)),
+		},
+	}}
+	object.SetName(name)
+	return object, unsupported, nil
+}
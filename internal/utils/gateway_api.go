@@ -0,0 +1,135 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+)
+
+// Gateway API kinds at the versions the operator reads and writes them
+var (
+	GatewayClassKind     = gatewayv1.SchemeGroupVersion.WithKind("GatewayClass")
+	GatewayKind          = gatewayv1.SchemeGroupVersion.WithKind("Gateway")
+	HTTPRouteKind        = gatewayv1.SchemeGroupVersion.WithKind("HTTPRoute")
+	GRPCRouteKind        = gatewayv1.SchemeGroupVersion.WithKind("GRPCRoute")
+	TLSRouteKind         = gatewayv1.SchemeGroupVersion.WithKind("TLSRoute")
+	BackendTLSPolicyKind = gatewayv1.SchemeGroupVersion.WithKind("BackendTLSPolicy")
+	ReferenceGrantKind   = gatewayv1beta1.SchemeGroupVersion.WithKind("ReferenceGrant")
+	TCPRouteKind         = gatewayv1alpha2.SchemeGroupVersion.WithKind("TCPRoute")
+	UDPRouteKind         = gatewayv1alpha2.SchemeGroupVersion.WithKind("UDPRoute")
+)
+
+// GatewayAPIKinds lists every Gateway API kind whose presence gates a feature
+var GatewayAPIKinds = []schema.GroupVersionKind{
+	GatewayClassKind, GatewayKind, HTTPRouteKind, GRPCRouteKind, TLSRouteKind, BackendTLSPolicyKind,
+	ReferenceGrantKind, TCPRouteKind, UDPRouteKind,
+}
+
+// GatewayAPICapabilities tracks which Gateway API kinds the API server serves, so features needing a
+// missing kind are skipped instead of failing. A nil GatewayAPICapabilities reports every kind installed.
+type GatewayAPICapabilities struct {
+	Discovery discovery.DiscoveryInterface
+
+	mu        sync.RWMutex
+	installed map[schema.GroupVersionKind]bool
+}
+
+// NewGatewayAPICapabilities detects the installed Gateway API kinds
+func NewGatewayAPICapabilities(d discovery.DiscoveryInterface) (*GatewayAPICapabilities, error) {
+	c := &GatewayAPICapabilities{Discovery: d}
+	if _, err := c.Refresh(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Refresh asks the API server again and returns the kinds that were installed or removed since the last
+// time
+func (c *GatewayAPICapabilities) Refresh() ([]schema.GroupVersionKind, error) {
+	installed := make(map[schema.GroupVersionKind]bool, len(GatewayAPIKinds))
+	served := make(map[schema.GroupVersion]map[string]bool)
+	for _, gvk := range GatewayAPIKinds {
+		gv := gvk.GroupVersion()
+		kinds, ok := served[gv]
+		if !ok {
+			list, err := c.Discovery.ServerResourcesForGroupVersion(gv.String())
+			if err != nil && !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to discover %s: %w", gv, err)
+			}
+			kinds = make(map[string]bool)
+			if list != nil {
+				for _, resource := range list.APIResources {
+					kinds[resource.Kind] = true
+				}
+			}
+			served[gv] = kinds
+		}
+		installed[gvk] = kinds[gvk.Kind]
+	}
+
+	c.mu.Lock()
+	var changed []schema.GroupVersionKind
+	for _, gvk := range GatewayAPIKinds {
+		if c.installed != nil && c.installed[gvk] != installed[gvk] {
+			changed = append(changed, gvk)
+		}
+	}
+	c.installed = installed
+	c.mu.Unlock()
+
+	for gvk, ok := range installed {
+		value := 0.0
+		if ok {
+			value = 1
+		}
+		metrics.GatewayAPIKindInstalled.WithLabelValues(gvk.Kind, gvk.Version).Set(value)
+	}
+	return changed, nil
+}
+
+// Installed reports whether the API server serves a kind
+func (c *GatewayAPICapabilities) Installed(gvk schema.GroupVersionKind) bool {
+	if c == nil {
+		return true
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.installed[gvk]
+}
+
+// Missing returns the kinds of the given ones the API server does not serve, as sorted Kind/version strings
+func (c *GatewayAPICapabilities) Missing(kinds ...schema.GroupVersionKind) []string {
+	var missing []string
+	for _, gvk := range kinds {
+		if !c.Installed(gvk) {
+			missing = append(missing, gvk.Kind+"/"+gvk.Version)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
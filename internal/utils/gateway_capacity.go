@@ -0,0 +1,106 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Rough sizes of what NGINX Gateway Fabric renders into nginx.conf, measured on typical routes. The
+// estimate only needs to be in the right order of magnitude to tell a Gateway that will be slow to reload.
+const (
+	estimatedServerBytes   = 640 // server block of one listener, TLS directives included
+	estimatedLocationBytes = 420 // location (and internal match location) of one path match per hostname
+	estimatedUpstreamBytes = 256 // upstream block of one backend Service port
+)
+
+// GatewayCapacity is the estimated size of the data plane configuration of a Gateway
+type GatewayCapacity struct {
+	Listeners   int
+	Routes      int
+	Locations   int
+	Upstreams   int
+	ConfigBytes int64
+}
+
+// GatewayCapacityLimits are the sizes a Gateway should stay below, zero values are not checked
+type GatewayCapacityLimits struct {
+	Listeners   int
+	ConfigBytes int64
+}
+
+// EstimateGatewayCapacity estimates the configuration NGF renders for a Gateway with listeners serving
+// routes. Every path match becomes a location in the server of every hostname of its route.
+func EstimateGatewayCapacity(listeners int, routes []*gatewayv1.HTTPRoute) GatewayCapacity {
+	capacity := GatewayCapacity{Listeners: listeners, Routes: len(routes)}
+	upstreams := make(map[string]bool)
+	for _, route := range routes {
+		hostnames := len(route.Spec.Hostnames)
+		if hostnames == 0 {
+			hostnames = 1
+		}
+		for _, rule := range route.Spec.Rules {
+			matches := len(rule.Matches)
+			if matches == 0 {
+				matches = 1
+			}
+			capacity.Locations += matches * hostnames
+			for _, backend := range rule.BackendRefs {
+				namespace := route.Namespace
+				if backend.Namespace != nil {
+					namespace = string(*backend.Namespace)
+				}
+				port := int32(0)
+				if backend.Port != nil {
+					port = int32(*backend.Port)
+				}
+				upstreams[fmt.Sprintf("%s/%s:%d", namespace, backend.Name, port)] = true
+			}
+		}
+	}
+	capacity.Upstreams = len(upstreams)
+	capacity.ConfigBytes = int64(capacity.Listeners)*estimatedServerBytes +
+		int64(capacity.Locations)*estimatedLocationBytes +
+		int64(capacity.Upstreams)*estimatedUpstreamBytes
+	return capacity
+}
+
+// Ratio returns the highest share of a limit the Gateway uses, above 1 when a limit is exceeded
+func (c GatewayCapacity) Ratio(limits GatewayCapacityLimits) float64 {
+	ratio := 0.0
+	if limits.Listeners > 0 {
+		ratio = max(ratio, float64(c.Listeners)/float64(limits.Listeners))
+	}
+	if limits.ConfigBytes > 0 {
+		ratio = max(ratio, float64(c.ConfigBytes)/float64(limits.ConfigBytes))
+	}
+	return ratio
+}
+
+// Exceeded describes the limits the Gateway exceeds, empty when it fits
+func (c GatewayCapacity) Exceeded(limits GatewayCapacityLimits) []string {
+	exceeded := make([]string, 0, 2)
+	if limits.Listeners > 0 && c.Listeners > limits.Listeners {
+		exceeded = append(exceeded, fmt.Sprintf("%d listeners (limit %d)", c.Listeners, limits.Listeners))
+	}
+	if limits.ConfigBytes > 0 && c.ConfigBytes > limits.ConfigBytes {
+		exceeded = append(exceeded, fmt.Sprintf("~%d bytes of config (limit %d)", c.ConfigBytes, limits.ConfigBytes))
+	}
+	return exceeded
+}
@@ -0,0 +1,116 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// RegexPathMatchFeature is the SupportedFeatures entry a GatewayClass may advertise
+// when it implements RegularExpression path matches
+const RegexPathMatchFeature gatewayv1.FeatureName = "HTTPRoutePathRegularExpression"
+
+// regexPathMatchControllers lists GatewayClass controllers known to implement
+// RegularExpression path matches (implementation-specific in Gateway API)
+var regexPathMatchControllers = map[gatewayv1.GatewayController]struct{}{
+	"gateway.nginx.org/nginx-gateway-controller":    {},
+	"gateway.envoyproxy.io/gatewayclass-controller": {},
+	"istio.io/gateway-controller":                   {},
+	"traefik.io/gateway-controller":                 {},
+}
+
+// HostRewriteFeature is the SupportedFeatures entry for URLRewrite hostname filters
+const HostRewriteFeature gatewayv1.FeatureName = "HTTPRouteHostRewrite"
+
+// hostRewriteControllers lists GatewayClass controllers known to implement URLRewrite
+// hostname rewrites even when they do not publish SupportedFeatures
+var hostRewriteControllers = map[gatewayv1.GatewayController]struct{}{
+	"gateway.nginx.org/nginx-gateway-controller":    {},
+	"gateway.envoyproxy.io/gatewayclass-controller": {},
+	"istio.io/gateway-controller":                   {},
+	"traefik.io/gateway-controller":                 {},
+}
+
+// BackendTLSPolicyFeature is the SupportedFeatures entry for BackendTLSPolicy
+const BackendTLSPolicyFeature gatewayv1.FeatureName = "BackendTLSPolicy"
+
+// backendTLSPolicyControllers lists GatewayClass controllers known to implement BackendTLSPolicy
+var backendTLSPolicyControllers = map[gatewayv1.GatewayController]struct{}{
+	"gateway.nginx.org/nginx-gateway-controller":    {},
+	"gateway.envoyproxy.io/gatewayclass-controller": {},
+	"istio.io/gateway-controller":                   {},
+}
+
+// BackendClientCertificateFeature is the SupportedFeatures entry for presenting a client
+// certificate to backends (Gateway spec.tls.backend.clientCertificateRef)
+const BackendClientCertificateFeature gatewayv1.FeatureName = "GatewayBackendClientCertificate"
+
+// backendClientCertificateControllers lists GatewayClass controllers known to present
+// spec.tls.backend client certificates
+var backendClientCertificateControllers = map[gatewayv1.GatewayController]struct{}{
+	"gateway.envoyproxy.io/gatewayclass-controller": {},
+	"istio.io/gateway-controller":                   {},
+}
+
+// GatewayClassSupportsRegexPathMatch checks whether the named GatewayClass is able to
+// handle HTTPRoute RegularExpression path matches
+func GatewayClassSupportsRegexPathMatch(ctx context.Context, reader client.Reader, name string) (bool, error) {
+	return gatewayClassSupportsFeature(ctx, reader, name, RegexPathMatchFeature, regexPathMatchControllers)
+}
+
+// GatewayClassSupportsHostRewrite checks whether the named GatewayClass is able to
+// rewrite the Host header through an HTTPRoute URLRewrite filter
+func GatewayClassSupportsHostRewrite(ctx context.Context, reader client.Reader, name string) (bool, error) {
+	return gatewayClassSupportsFeature(ctx, reader, name, HostRewriteFeature, hostRewriteControllers)
+}
+
+// GatewayClassSupportsBackendTLSPolicy checks whether the named GatewayClass implements BackendTLSPolicy
+func GatewayClassSupportsBackendTLSPolicy(ctx context.Context, reader client.Reader, name string) (bool, error) {
+	return gatewayClassSupportsFeature(ctx, reader, name, BackendTLSPolicyFeature, backendTLSPolicyControllers)
+}
+
+// GatewayClassSupportsBackendClientCertificate checks whether the named GatewayClass presents
+// client certificates to backends, which backend mTLS needs
+func GatewayClassSupportsBackendClientCertificate(ctx context.Context, reader client.Reader, name string) (bool, error) {
+	return gatewayClassSupportsFeature(ctx, reader, name, BackendClientCertificateFeature,
+		backendClientCertificateControllers)
+}
+
+func gatewayClassSupportsFeature(
+	ctx context.Context,
+	reader client.Reader,
+	name string,
+	feature gatewayv1.FeatureName,
+	knownControllers map[gatewayv1.GatewayController]struct{},
+) (bool, error) {
+	gatewayClass := &gatewayv1.GatewayClass{}
+	if err := reader.Get(ctx, client.ObjectKey{Name: name}, gatewayClass); err != nil {
+		return false, err
+	}
+
+	for _, supported := range gatewayClass.Status.SupportedFeatures {
+		if supported.Name == feature {
+			return true, nil
+		}
+	}
+
+	_, ok := knownControllers[gatewayClass.Spec.ControllerName]
+	return ok, nil
+}
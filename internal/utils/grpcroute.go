@@ -0,0 +1,204 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+const (
+	MaxGRPCRouteRules = 16 // Gateway API limit
+)
+
+// GRPCRouteManager handles GRPCRoute operations, the gRPC counterpart of HTTPRouteManager
+type GRPCRouteManager struct {
+	Client client.Client
+	// NameTemplate names the GRPCRoutes of an Ingress, nil means the Ingress name
+	NameTemplate *translator.NameTemplate
+	// SourceCluster is the fan-in source cluster of the Ingresses, empty for the local cluster
+	SourceCluster string
+	// OwnedMetadataKeys are glob patterns of annotation and label keys we own even when another party set
+	// them, all other foreign keys are kept on update
+	OwnedMetadataKeys []string
+}
+
+// GetGRPCRoutesForIngress returns the GRPCRoutes managed by us for the Ingress. A cluster without
+// the GRPCRoute CRD has none.
+func (m *GRPCRouteManager) GetGRPCRoutesForIngress(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+) ([]gatewayv1.GRPCRoute, error) {
+	routeList := &gatewayv1.GRPCRouteList{}
+	if err := m.Client.List(ctx, routeList, client.InNamespace(ingress.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	prefix := m.NameTemplate.Name(ingress)
+	var result []gatewayv1.GRPCRoute
+	for _, r := range routeList.Items {
+		if strings.HasPrefix(r.Name, prefix) && IsManagedByUsForIngress(&r, ingress.Namespace, ingress.Name) &&
+			r.Annotations[translator.SourceClusterAnnotation] == m.SourceCluster {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// SplitGRPCRouteIfNeeded splits a GRPCRoute into multiple routes if it exceeds the Gateway API limit
+func (m *GRPCRouteManager) SplitGRPCRouteIfNeeded(grpcRoute *gatewayv1.GRPCRoute) []*gatewayv1.GRPCRoute {
+	if len(grpcRoute.Spec.Rules) <= MaxGRPCRouteRules {
+		return []*gatewayv1.GRPCRoute{grpcRoute}
+	}
+
+	var result []*gatewayv1.GRPCRoute
+	rules := grpcRoute.Spec.Rules
+	for i, partNum := 0, 1; i < len(rules); i, partNum = i+MaxGRPCRouteRules, partNum+1 {
+		end := min(i+MaxGRPCRouteRules, len(rules))
+		part := grpcRoute.DeepCopy()
+		part.Spec.Rules = rules[i:end]
+		if partNum > 1 {
+			part.Name = fmt.Sprintf("%s-%d", grpcRoute.Name, partNum)
+		}
+		result = append(result, part)
+	}
+	return result
+}
+
+// ResolveNamedPorts resolves named ports in a GRPCRoute by looking up the actual Services
+func (m *GRPCRouteManager) ResolveNamedPorts(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	grpcRoute *gatewayv1.GRPCRoute,
+) {
+	ports := &HTTPRouteManager{Client: m.Client}
+	for i := range grpcRoute.Spec.Rules {
+		for j := range grpcRoute.Spec.Rules[i].BackendRefs {
+			ports.resolveBackendPort(ctx, ingress, &grpcRoute.Spec.Rules[i].BackendRefs[j].BackendRef)
+		}
+	}
+}
+
+// ApplyGRPCRoutes creates or updates the desired GRPCRoutes of the Ingress and deletes the ones
+// it no longer needs. Applying no routes removes every GRPCRoute of the Ingress.
+func (m *GRPCRouteManager) ApplyGRPCRoutes(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	desiredRoutes []*gatewayv1.GRPCRoute,
+	metricRecorder func(operation, namespace, name string),
+) error {
+	logger := log.FromContext(ctx)
+
+	existingRoutes, err := m.GetGRPCRoutesForIngress(ctx, ingress)
+	if err != nil {
+		return fmt.Errorf("failed to get existing GRPCRoutes: %w", err)
+	}
+
+	desired := make(map[string]bool, len(desiredRoutes))
+	for _, route := range desiredRoutes {
+		desired[route.Name] = true
+	}
+	for i := range existingRoutes {
+		existingRoute := &existingRoutes[i]
+		if desired[existingRoute.Name] {
+			continue
+		}
+		logger.Info("Deleting obsolete GRPCRoute", "namespace", existingRoute.Namespace, "name", existingRoute.Name)
+		if err := m.Client.Delete(ctx, existingRoute); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete obsolete GRPCRoute %s: %w", existingRoute.Name, err)
+		}
+		if metricRecorder != nil {
+			metricRecorder("delete", existingRoute.Namespace, existingRoute.Name)
+		}
+	}
+
+	for _, desiredRoute := range desiredRoutes {
+		if err := m.applyGRPCRoute(ctx, desiredRoute, metricRecorder); err != nil {
+			return fmt.Errorf("failed to apply GRPCRoute %s: %w", desiredRoute.Name, err)
+		}
+	}
+	return nil
+}
+
+// applyGRPCRoute creates or updates a single GRPCRoute
+func (m *GRPCRouteManager) applyGRPCRoute(
+	ctx context.Context,
+	grpcRoute *gatewayv1.GRPCRoute,
+	metricRecorder func(operation, namespace, name string),
+) error {
+	logger := log.FromContext(ctx)
+
+	grpcRouteNN := types.NamespacedName{Namespace: grpcRoute.Namespace, Name: grpcRoute.Name}
+	existingGRPCRoute := &gatewayv1.GRPCRoute{}
+	canManage, err := CanUpdateResource(ctx, m.Client, existingGRPCRoute, grpcRouteNN)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		logger.Info("Skipping GRPCRoute synthesis - resource exists and is not managed by us",
+			"namespace", grpcRouteNN.Namespace, "name", grpcRouteNN.Name)
+		return nil
+	}
+
+	if err := m.Client.Get(ctx, grpcRouteNN, existingGRPCRoute); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		logger.Info("Creating GRPCRoute", "namespace", grpcRoute.Namespace, "name", grpcRoute.Name)
+		RecordManagedKeys(grpcRoute)
+		if err := m.Client.Create(ctx, grpcRoute); err != nil {
+			return fmt.Errorf("failed to create GRPCRoute: %w", err)
+		}
+		if metricRecorder != nil {
+			metricRecorder("create", grpcRoute.Namespace, grpcRoute.Name)
+		}
+		return nil
+	}
+
+	metadataChanged := MergeManagedMetadata(existingGRPCRoute, grpcRoute, m.OwnedMetadataKeys)
+	if equality.Semantic.DeepEqual(existingGRPCRoute.Spec, grpcRoute.Spec) && !metadataChanged &&
+		!ownerReferencesChanged(existingGRPCRoute, grpcRoute) {
+		return nil
+	}
+	existingGRPCRoute.Spec = grpcRoute.Spec
+	if ownerReferencesChanged(existingGRPCRoute, grpcRoute) {
+		existingGRPCRoute.OwnerReferences = grpcRoute.OwnerReferences
+	}
+	logger.Info("Updating GRPCRoute", "namespace", existingGRPCRoute.Namespace, "name", existingGRPCRoute.Name)
+	if err := m.Client.Update(ctx, existingGRPCRoute); err != nil {
+		return fmt.Errorf("failed to update GRPCRoute: %w", err)
+	}
+	if metricRecorder != nil {
+		metricRecorder("update", existingGRPCRoute.Namespace, existingGRPCRoute.Name)
+	}
+	return nil
+}
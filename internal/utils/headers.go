@@ -0,0 +1,79 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+var headerNamePattern = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+\-.^_\x60|~]+$`)
+
+// ParseConfigMapReference parses an ingress-nginx style "namespace/name" (or "name") reference
+func ParseConfigMapReference(ref, defaultNamespace string) (types.NamespacedName, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return types.NamespacedName{}, fmt.Errorf("empty ConfigMap reference")
+	}
+	parts := strings.Split(ref, "/")
+	switch {
+	case len(parts) == 1:
+		return types.NamespacedName{Namespace: defaultNamespace, Name: parts[0]}, nil
+	case len(parts) == 2 && parts[0] != "" && parts[1] != "":
+		return types.NamespacedName{Namespace: parts[0], Name: parts[1]}, nil
+	default:
+		return types.NamespacedName{}, fmt.Errorf("invalid ConfigMap reference %q (expected namespace/name)", ref)
+	}
+}
+
+// GetConfigMapHeaders loads header name/value pairs from a ConfigMap, sorted by name.
+// Entries that are not valid HTTP header names are skipped.
+func GetConfigMapHeaders(
+	ctx context.Context,
+	reader client.Reader,
+	key types.NamespacedName,
+) ([]gatewayv1.HTTPHeader, error) {
+	cm := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, key, cm); err != nil {
+		return nil, fmt.Errorf("failed to get ConfigMap %s: %w", key.String(), err)
+	}
+
+	names := make([]string, 0, len(cm.Data))
+	for name := range cm.Data {
+		if headerNamePattern.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	headers := make([]gatewayv1.HTTPHeader, 0, len(names))
+	for _, name := range names {
+		headers = append(headers, gatewayv1.HTTPHeader{
+			Name:  gatewayv1.HTTPHeaderName(name),
+			Value: cm.Data[name],
+		})
+	}
+	return headers, nil
+}
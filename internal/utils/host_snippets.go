@@ -0,0 +1,126 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// SnippetsOrderAnnotation orders the server snippets of Ingresses sharing a host (lower first,
+	// default 0, ties broken by namespace/name)
+	SnippetsOrderAnnotation = "ingress-doperator.fiction.si/snippets-order"
+
+	// serverSnippetContext is the SnippetsFilter context that ends up in the (per host) server block
+	serverSnippetContext = "http.server"
+)
+
+// HostSnippetsContribution is the server snippet one Ingress contributes to the hosts it shares
+type HostSnippetsContribution struct {
+	Namespace string
+	Name      string
+	Order     int
+	Snippets  []map[string]interface{}
+}
+
+// ParseSnippetsOrder returns the snippets order of an Ingress, 0 when unset or invalid
+func ParseSnippetsOrder(annotations map[string]string) int {
+	order, err := strconv.Atoi(strings.TrimSpace(annotations[SnippetsOrderAnnotation]))
+	if err != nil {
+		return 0
+	}
+	return order
+}
+
+// HasServerSnippets reports whether snippets contain server block content
+func HasServerSnippets(snippets []map[string]interface{}) bool {
+	for _, snippet := range snippets {
+		if snippet["context"] == serverSnippetContext {
+			return true
+		}
+	}
+	return false
+}
+
+// WithoutServerSnippets returns snippets without server block content
+func WithoutServerSnippets(snippets []map[string]interface{}) []map[string]interface{} {
+	rest := make([]map[string]interface{}, 0, len(snippets))
+	for _, snippet := range snippets {
+		if snippet["context"] != serverSnippetContext {
+			rest = append(rest, snippet)
+		}
+	}
+	return rest
+}
+
+// SortHostSnippetsContributions orders contributions by order annotation, then namespace and name,
+// so the result does not depend on list or reconcile order
+func SortHostSnippetsContributions(contributions []HostSnippetsContribution) {
+	sort.SliceStable(contributions, func(i, j int) bool {
+		a, b := contributions[i], contributions[j]
+		if a.Order != b.Order {
+			return a.Order < b.Order
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
+
+// MergeServerSnippets merges the server snippets of all contributions into one snippet in a stable
+// order. Lines already emitted by an earlier contribution are dropped, since nginx rejects most
+// duplicate server directives.
+func MergeServerSnippets(contributions []HostSnippetsContribution) (map[string]interface{}, bool) {
+	sorted := append([]HostSnippetsContribution{}, contributions...)
+	SortHostSnippetsContributions(sorted)
+
+	seen := make(map[string]bool)
+	blocks := make([]string, 0, len(sorted))
+	for _, contribution := range sorted {
+		lines := make([]string, 0)
+		for _, snippet := range contribution.Snippets {
+			if snippet["context"] != serverSnippetContext {
+				continue
+			}
+			value, _ := snippet["value"].(string)
+			for _, line := range strings.Split(value, "\n") {
+				trimmed := strings.TrimSpace(line)
+				if trimmed == "" || seen[trimmed] {
+					continue
+				}
+				seen[trimmed] = true
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		header := fmt.Sprintf("# %s/%s", contribution.Namespace, contribution.Name)
+		blocks = append(blocks, header+"\n"+strings.Join(lines, "\n"))
+	}
+	if len(blocks) == 0 {
+		return nil, false
+	}
+	return map[string]interface{}{
+		"context": serverSnippetContext,
+		"value":   strings.Join(blocks, "\n"),
+	}, true
+}
@@ -0,0 +1,95 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func serverContribution(namespace, name string, order int, value string) HostSnippetsContribution {
+	return HostSnippetsContribution{
+		Namespace: namespace,
+		Name:      name,
+		Order:     order,
+		Snippets: []map[string]interface{}{
+			{"context": "http.server.location", "value": "proxy_buffering off;"},
+			{"context": serverSnippetContext, "value": value},
+		},
+	}
+}
+
+func TestMergeServerSnippetsStableAcrossOrder(t *testing.T) {
+	a := serverContribution("team-a", "web", 0, "add_header X-A a;\nadd_header X-Shared s;")
+	b := serverContribution("team-b", "api", 0, "add_header X-B b;\nadd_header X-Shared s;")
+	c := serverContribution("team-a", "admin", 0, "add_header X-C c;")
+
+	want := map[string]interface{}{
+		"context": serverSnippetContext,
+		"value": "# team-a/admin\nadd_header X-C c;\n" +
+			"# team-a/web\nadd_header X-A a;\nadd_header X-Shared s;\n" +
+			"# team-b/api\nadd_header X-B b;",
+	}
+	permutations := [][]HostSnippetsContribution{
+		{a, b, c}, {a, c, b}, {b, a, c}, {b, c, a}, {c, a, b}, {c, b, a},
+	}
+	for _, contributions := range permutations {
+		for range 3 {
+			got, ok := MergeServerSnippets(contributions)
+			if !ok {
+				t.Fatalf("expected merged snippet")
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("unexpected merge result:\n%v\nwant:\n%v", got["value"], want["value"])
+			}
+		}
+	}
+}
+
+func TestMergeServerSnippetsOrderAnnotation(t *testing.T) {
+	first := serverContribution("z", "last-by-name", -1, "add_header X-First 1;")
+	second := serverContribution("a", "first-by-name", 0, "add_header X-Second 2;")
+
+	got, ok := MergeServerSnippets([]HostSnippetsContribution{second, first})
+	if !ok {
+		t.Fatalf("expected merged snippet")
+	}
+	want := "# z/last-by-name\nadd_header X-First 1;\n# a/first-by-name\nadd_header X-Second 2;"
+	if got["value"] != want {
+		t.Fatalf("unexpected merge result:\n%v\nwant:\n%v", got["value"], want)
+	}
+}
+
+func TestMergeServerSnippetsWithoutServerContext(t *testing.T) {
+	contribution := HostSnippetsContribution{
+		Namespace: "default",
+		Name:      "web",
+		Snippets:  []map[string]interface{}{{"context": "http.server.location", "value": "proxy_buffering off;"}},
+	}
+	if _, ok := MergeServerSnippets([]HostSnippetsContribution{contribution}); ok {
+		t.Fatalf("expected no merged snippet")
+	}
+}
+
+func TestParseSnippetsOrder(t *testing.T) {
+	cases := map[string]int{"": 0, "5": 5, " -2 ": -2, "first": 0}
+	for value, want := range cases {
+		if got := ParseSnippetsOrder(map[string]string{SnippetsOrderAnnotation: value}); got != want {
+			t.Fatalf("ParseSnippetsOrder(%q) = %d, want %d", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+)
+
+const HostnameStatesConfigMapName = "ingress-doperator-hostname-states"
+
+// HostnameServingState tells which data plane serves a hostname
+type HostnameServingState string
+
+const (
+	// HostnameIngressOnly hostnames are still served by the Ingress controller alone
+	HostnameIngressOnly HostnameServingState = "ingress-only"
+	// HostnameDual hostnames are served by the Gateway while the source Ingress is still active
+	HostnameDual HostnameServingState = "dual"
+	// HostnameGatewayOnly hostnames are served by the Gateway after the source Ingress was handed over
+	HostnameGatewayOnly HostnameServingState = "gateway-only"
+)
+
+var hostnameServingStates = []HostnameServingState{HostnameIngressOnly, HostnameDual, HostnameGatewayOnly}
+
+// HostnameStateEntry is the serving state of one hostname
+type HostnameStateEntry struct {
+	Hostname string               `json:"hostname"`
+	State    HostnameServingState `json:"state"`
+	// Ingress is the namespace/name of the Ingress the hostname is migrated from
+	Ingress string `json:"ingress"`
+	// Cluster is the fan-in source cluster of the Ingress, empty for the local cluster
+	Cluster string `json:"cluster,omitempty"`
+	Gateway string `json:"gateway,omitempty"`
+	// Since is when the hostname entered its state
+	Since time.Time `json:"since"`
+}
+
+// HostnameStates keeps the serving state of every hostname the operator migrates in a ConfigMap,
+// one key per hostname. A nil HostnameStates records nothing.
+type HostnameStates struct {
+	client    client.Client
+	reader    client.Reader
+	namespace string
+	name      string
+
+	mu sync.Mutex
+}
+
+// NewHostnameStates creates the hostname states in the namespace/name ConfigMap. Reads go through
+// reader, which should be uncached so that updates do not keep conflicting.
+func NewHostnameStates(c client.Client, reader client.Reader, namespace, name string) *HostnameStates {
+	return &HostnameStates{client: c, reader: reader, namespace: namespace, name: name}
+}
+
+// hostnameStateKey returns the ConfigMap key of a hostname, ConfigMap keys cannot contain a '*'
+func hostnameStateKey(hostname string) string {
+	return strings.ReplaceAll(hostname, "*", "_")
+}
+
+// Set records the state of the hostnames of an Ingress of cluster (empty for the local cluster) and
+// drops the entries of hostnames the Ingress no longer has. Since only moves when the state changes and
+// an empty gateway keeps the recorded one. HostnameIngressOnly only adds hostnames without an entry of
+// the Ingress, whose routes keep serving them from an earlier reconcile.
+func (s *HostnameStates) Set(
+	ctx context.Context,
+	cluster string,
+	ingress types.NamespacedName,
+	gateway string,
+	hostnames []string,
+	state HostnameServingState,
+) error {
+	if s == nil {
+		return nil
+	}
+	now := time.Now().UTC()
+	return s.modify(ctx, func(data map[string]string) error {
+		keep := make(map[string]bool, len(hostnames))
+		for _, hostname := range hostnames {
+			key := hostnameStateKey(hostname)
+			keep[key] = true
+			entry := HostnameStateEntry{
+				Hostname: hostname,
+				State:    state,
+				Ingress:  ingress.String(),
+				Cluster:  cluster,
+				Gateway:  gateway,
+				Since:    now,
+			}
+			existing, ok := parseHostnameStateEntry(data[key])
+			sameIngress := ok && existing.Ingress == entry.Ingress && existing.Cluster == cluster
+			if sameIngress && state == HostnameIngressOnly {
+				continue
+			}
+			if sameIngress && gateway == "" {
+				entry.Gateway = existing.Gateway
+			}
+			if sameIngress && existing.State == state {
+				entry.Since = existing.Since
+			}
+			raw, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			data[key] = string(raw)
+		}
+		for key, raw := range data {
+			if entry, ok := parseHostnameStateEntry(raw); ok && !keep[key] &&
+				entry.Ingress == ingress.String() && entry.Cluster == cluster {
+				delete(data, key)
+			}
+		}
+		return nil
+	})
+}
+
+// Release drops the entries of a deleted Ingress. Hostnames it handed over to the Gateway stay
+// gateway-only unless all is set, because their routes outlive the Ingress.
+func (s *HostnameStates) Release(ctx context.Context, cluster string, ingress types.NamespacedName, all bool) error {
+	if s == nil {
+		return nil
+	}
+	return s.modify(ctx, func(data map[string]string) error {
+		for key, raw := range data {
+			entry, ok := parseHostnameStateEntry(raw)
+			if !ok || entry.Ingress != ingress.String() || entry.Cluster != cluster {
+				continue
+			}
+			if all || entry.State != HostnameGatewayOnly {
+				delete(data, key)
+			}
+		}
+		return nil
+	})
+}
+
+// modify applies fn to the data of the hostname states ConfigMap, creating it when missing
+func (s *HostnameStates) modify(ctx context.Context, fn func(data map[string]string) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		err := s.reader.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: s.name}, cm)
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: s.namespace},
+				Data:       make(map[string]string),
+			}
+			if err := fn(cm.Data); err != nil {
+				return err
+			}
+			if len(cm.Data) == 0 {
+				return nil
+			}
+			recordHostnameStateMetrics(cm.Data)
+			err = s.client.Create(ctx, cm)
+			if apierrors.IsAlreadyExists(err) {
+				// Created concurrently, retry as an update
+				return apierrors.NewConflict(corev1.Resource("configmaps"), s.name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		before := maps.Clone(cm.Data)
+		if err := fn(cm.Data); err != nil {
+			return err
+		}
+		recordHostnameStateMetrics(cm.Data)
+		if maps.Equal(before, cm.Data) {
+			return nil
+		}
+		return s.client.Update(ctx, cm)
+	})
+}
+
+// recordHostnameStateMetrics publishes the number of hostnames in each state
+func recordHostnameStateMetrics(data map[string]string) {
+	counts := make(map[HostnameServingState]int, len(hostnameServingStates))
+	for _, raw := range data {
+		if entry, ok := parseHostnameStateEntry(raw); ok {
+			counts[entry.State]++
+		}
+	}
+	for _, state := range hostnameServingStates {
+		metrics.HostnameServingStates.WithLabelValues(string(state)).Set(float64(counts[state]))
+	}
+}
+
+func parseHostnameStateEntry(raw string) (HostnameStateEntry, bool) {
+	var entry HostnameStateEntry
+	if raw == "" || json.Unmarshal([]byte(raw), &entry) != nil || entry.State == "" {
+		return HostnameStateEntry{}, false
+	}
+	return entry, true
+}
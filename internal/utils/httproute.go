@@ -23,11 +23,14 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
 )
 
 const (
@@ -37,6 +40,42 @@ const (
 // HTTPRouteManager handles HTTPRoute operations
 type HTTPRouteManager struct {
 	Client client.Client
+	// NameTemplate names the HTTPRoutes of an Ingress, nil means the Ingress name
+	NameTemplate *translator.NameTemplate
+	// SourceCluster is the fan-in source cluster of the Ingresses, empty for the local cluster
+	SourceCluster string
+	// OwnedMetadataKeys are glob patterns of annotation and label keys we own even when another party set
+	// them, all other foreign keys are kept on update
+	OwnedMetadataKeys []string
+}
+
+// GetHTTPRoutesForIngress returns the HTTPRoutes managed by us for the Ingress, looked up by the name
+// the HTTPRoutes were generated with
+func (m *HTTPRouteManager) GetHTTPRoutesForIngress(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+) ([]gatewayv1.HTTPRoute, error) {
+	routeList := &gatewayv1.HTTPRouteList{}
+	if err := m.Client.List(ctx, routeList, client.InNamespace(ingress.Namespace)); err != nil {
+		return nil, err
+	}
+
+	prefix := m.NameTemplate.Name(ingress)
+	var result []gatewayv1.HTTPRoute
+	for _, r := range routeList.Items {
+		if strings.HasPrefix(r.Name, prefix) && IsManagedByUsForIngress(&r, ingress.Namespace, ingress.Name) &&
+			r.Annotations[translator.SourceClusterAnnotation] == m.SourceCluster {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// ownerReferencesChanged reports whether desired carries ownerReferences that differ from those of existing.
+// Routes generated without ownerReferences keep the ones they have
+func ownerReferencesChanged(existing, desired client.Object) bool {
+	return len(desired.GetOwnerReferences()) > 0 &&
+		!equality.Semantic.DeepEqual(existing.GetOwnerReferences(), desired.GetOwnerReferences())
 }
 
 // GetHTTPRoutesWithPrefix returns all HTTPRoutes with a given name prefix
@@ -62,34 +101,39 @@ func (m *HTTPRouteManager) GetHTTPRoutesWithPrefix(
 	return result, nil
 }
 
-// SplitHTTPRouteIfNeeded splits an HTTPRoute into multiple routes if it exceeds the Gateway API limit
+// SplitHTTPRouteIfNeeded dedupes the filters of an HTTPRoute and splits it into multiple routes if it
+// exceeds the Gateway API limits on rules or matches
 func (m *HTTPRouteManager) SplitHTTPRouteIfNeeded(httpRoute *gatewayv1.HTTPRoute) []*gatewayv1.HTTPRoute {
-	// If the HTTPRoute has <= MaxHTTPRouteRules, no split needed
-	if len(httpRoute.Spec.Rules) <= MaxHTTPRouteRules {
+	DedupeHTTPRouteFilters(httpRoute)
+	parts := splitHTTPRouteRules(httpRoute.Spec.Rules)
+	// If the HTTPRoute fits, no split needed (rules with too many matches may still have been divided)
+	if len(parts) <= 1 {
+		if len(parts) == 1 {
+			httpRoute.Spec.Rules = parts[0]
+		}
 		return []*gatewayv1.HTTPRoute{httpRoute}
 	}
 
 	logger := log.Log.WithName("SplitHTTPRouteIfNeeded")
-	logger.Info("HTTPRoute exceeds max rules, splitting",
+	logger.Info("HTTPRoute exceeds max rules or matches, splitting",
 		"name", httpRoute.Name,
 		"namespace", httpRoute.Namespace,
 		"totalRules", len(httpRoute.Spec.Rules),
-		"maxRules", MaxHTTPRouteRules)
+		"maxRules", MaxHTTPRouteRules,
+		"maxMatches", MaxHTTPRouteMatches)
 
 	// Split into multiple HTTPRoutes
-	var result []*gatewayv1.HTTPRoute
-	rules := httpRoute.Spec.Rules
-	partNum := 1
-
-	for i := 0; i < len(rules); i += MaxHTTPRouteRules {
-		end := i + MaxHTTPRouteRules
-		if end > len(rules) {
-			end = len(rules)
-		}
+	result := make([]*gatewayv1.HTTPRoute, 0, len(parts))
+	for i, rules := range parts {
+		partNum := i + 1
 
 		// Create a copy of the HTTPRoute for this chunk
 		part := httpRoute.DeepCopy()
-		part.Spec.Rules = rules[i:end]
+		part.Spec.Rules = rules
+		if part.Annotations == nil {
+			part.Annotations = make(map[string]string)
+		}
+		part.Annotations[translator.RouteChunkAnnotation] = fmt.Sprintf("%d/%d", partNum, len(parts))
 
 		if partNum > 1 {
 			part.Name = fmt.Sprintf("%s-%d", httpRoute.Name, partNum)
@@ -102,15 +146,24 @@ func (m *HTTPRouteManager) SplitHTTPRouteIfNeeded(httpRoute *gatewayv1.HTTPRoute
 			"rulesInPart", len(part.Spec.Rules))
 
 		result = append(result, part)
-		partNum++
 	}
 
 	return result
 }
 
+// SplitHTTPRouteNames returns the names of the HTTPRoutes SplitHTTPRouteIfNeeded turns httpRoute into
+func SplitHTTPRouteNames(httpRoute *gatewayv1.HTTPRoute) []string {
+	names := []string{httpRoute.Name}
+	for partNum := 2; partNum <= len(splitHTTPRouteRules(httpRoute.Spec.Rules)); partNum++ {
+		names = append(names, fmt.Sprintf("%s-%d", httpRoute.Name, partNum))
+	}
+	return names
+}
+
 // ApplyHTTPRoutesAtomic handles applying HTTPRoutes with proper cleanup of obsolete split routes
 // If there's only one HTTPRoute before and after, it does an atomic update
-// If the count changed, it deletes all old HTTPRoutes first, then creates all new ones
+// If the count changed, it creates the new HTTPRoutes first, then updates the existing ones and deletes the
+// obsolete ones last, so a rule moving between split routes is served by one of them throughout
 func (m *HTTPRouteManager) ApplyHTTPRoutesAtomic(
 	ctx context.Context,
 	ingress *networkingv1.Ingress,
@@ -119,11 +172,17 @@ func (m *HTTPRouteManager) ApplyHTTPRoutesAtomic(
 ) error {
 	logger := log.FromContext(ctx)
 
-	// Get existing HTTPRoutes for this Ingress
-	existingRoutes, err := m.GetHTTPRoutesWithPrefix(ctx, ingress.Namespace, ingress.Name)
+	// Get existing HTTPRoutes for this Ingress, handoff and rename routes are managed separately
+	allRoutes, err := m.GetHTTPRoutesForIngress(ctx, ingress)
 	if err != nil {
 		return fmt.Errorf("failed to get existing HTTPRoutes: %w", err)
 	}
+	existingRoutes := make([]gatewayv1.HTTPRoute, 0, len(allRoutes))
+	for i := range allRoutes {
+		if !translator.IsHandoffRoute(&allRoutes[i]) && !translator.IsRenameRoute(&allRoutes[i]) {
+			existingRoutes = append(existingRoutes, allRoutes[i])
+		}
+	}
 
 	existingCount := len(existingRoutes)
 	desiredCount := len(desiredRoutes)
@@ -136,17 +195,42 @@ func (m *HTTPRouteManager) ApplyHTTPRoutesAtomic(
 	// Case 1: Single HTTPRoute before and after - atomic update
 	if existingCount == 1 && desiredCount == 1 {
 		logger.V(3).Info("Single HTTPRoute case - performing atomic update")
-		return m.applyHTTPRoute(ctx, desiredRoutes[0], metricRecorder)
+		return m.ApplyHTTPRoute(ctx, desiredRoutes[0], metricRecorder)
 	}
 
-	// Case 2: Count changed - delete all old, create all new
-	logger.Info("HTTPRoute count changed - performing atomic replacement",
-		"ingress", ingress.Name,
-		"from", existingCount,
-		"to", desiredCount)
+	// Case 2: Split HTTPRoutes - create new, update existing, delete obsolete
+	if existingCount != desiredCount {
+		logger.Info("HTTPRoute count changed - performing atomic replacement",
+			"ingress", ingress.Name,
+			"from", existingCount,
+			"to", desiredCount)
+	}
+
+	existingNames := make(map[string]bool, existingCount)
+	for _, existingRoute := range existingRoutes {
+		existingNames[existingRoute.Name] = true
+	}
+	desiredNames := make(map[string]bool, desiredCount)
+	for _, desiredRoute := range desiredRoutes {
+		desiredNames[desiredRoute.Name] = true
+	}
+	// First create the HTTPRoutes that do not exist yet, then update the existing ones
+	for _, exists := range []bool{false, true} {
+		for _, desiredRoute := range desiredRoutes {
+			if existingNames[desiredRoute.Name] != exists {
+				continue
+			}
+			if err := m.ApplyHTTPRoute(ctx, desiredRoute, metricRecorder); err != nil {
+				return fmt.Errorf("failed to apply HTTPRoute %s: %w", desiredRoute.Name, err)
+			}
+		}
+	}
 
-	// First, delete all existing HTTPRoutes that we manage for this ingress
+	// Then, delete the existing HTTPRoutes we manage for this ingress that are no longer desired
 	for _, existingRoute := range existingRoutes {
+		if desiredNames[existingRoute.Name] {
+			continue
+		}
 		if IsManagedByUsForIngress(&existingRoute, ingress.Namespace, ingress.Name) {
 			logger.Info("Deleting obsolete HTTPRoute",
 				"namespace", existingRoute.Namespace,
@@ -164,22 +248,15 @@ func (m *HTTPRouteManager) ApplyHTTPRoutesAtomic(
 		}
 	}
 
-	// Then, create all new HTTPRoutes
-	for _, desiredRoute := range desiredRoutes {
-		if err := m.applyHTTPRoute(ctx, desiredRoute, metricRecorder); err != nil {
-			return fmt.Errorf("failed to apply HTTPRoute %s: %w", desiredRoute.Name, err)
-		}
-	}
-
 	logger.Info("HTTPRoute atomic replacement completed successfully",
 		"ingress", ingress.Name,
-		"routesCreated", desiredCount)
+		"routes", desiredCount)
 
 	return nil
 }
 
-// applyHTTPRoute creates or updates a single HTTPRoute
-func (m *HTTPRouteManager) applyHTTPRoute(
+// ApplyHTTPRoute creates or updates a single HTTPRoute
+func (m *HTTPRouteManager) ApplyHTTPRoute(
 	ctx context.Context,
 	httpRoute *gatewayv1.HTTPRoute,
 	metricRecorder func(operation, namespace, name string),
@@ -209,6 +286,7 @@ func (m *HTTPRouteManager) applyHTTPRoute(
 		if apierrors.IsNotFound(err) {
 			// Create new HTTPRoute
 			logger.Info("Creating HTTPRoute", "namespace", httpRoute.Namespace, "name", httpRoute.Name)
+			RecordManagedKeys(httpRoute)
 			if err := m.Client.Create(ctx, httpRoute); err != nil {
 				return fmt.Errorf("failed to create HTTPRoute: %w", err)
 			}
@@ -220,10 +298,18 @@ func (m *HTTPRouteManager) applyHTTPRoute(
 		return err
 	}
 
-	// Update existing HTTPRoute
-	existingHTTPRoute.Annotations = httpRoute.Annotations
+	// Update existing HTTPRoute, unless nothing changed
+	metadataChanged := MergeManagedMetadata(existingHTTPRoute, httpRoute, m.OwnedMetadataKeys)
+	if equality.Semantic.DeepEqual(existingHTTPRoute.Spec, httpRoute.Spec) && !metadataChanged &&
+		!ownerReferencesChanged(existingHTTPRoute, httpRoute) {
+		return nil
+	}
 	existingHTTPRoute.Spec = httpRoute.Spec
+	if ownerReferencesChanged(existingHTTPRoute, httpRoute) {
+		existingHTTPRoute.OwnerReferences = httpRoute.OwnerReferences
+	}
 	logger.Info("Updating HTTPRoute", "namespace", existingHTTPRoute.Namespace, "name", existingHTTPRoute.Name)
+	LogUpdateDiff(ctx, m.Client, existingHTTPRoute)
 	if err := m.Client.Update(ctx, existingHTTPRoute); err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info("HTTPRoute disappeared during update, recreating",
@@ -252,51 +338,64 @@ func (m *HTTPRouteManager) ResolveNamedPorts(
 	ingress *networkingv1.Ingress,
 	httpRoute *gatewayv1.HTTPRoute,
 ) error {
-	logger := log.FromContext(ctx)
-
 	for i := range httpRoute.Spec.Rules {
 		for j := range httpRoute.Spec.Rules[i].BackendRefs {
-			backendRef := &httpRoute.Spec.Rules[i].BackendRefs[j]
-
-			// Check if port is 0 (indicates named port)
-			if backendRef.Port != nil && *backendRef.Port == 0 {
-				serviceName := string(backendRef.Name)
-
-				// Find the named port from the Ingress spec
-				portName := m.findPortNameInIngress(ingress, serviceName)
-				if portName == "" {
-					logger.Info("Could not find port name in Ingress for service, using fallback port 80",
-						"service", serviceName,
-						"namespace", ingress.Namespace)
-					fallbackPort := int32(80)
-					backendRef.Port = &fallbackPort
-					continue
-				}
+			m.resolveBackendPort(ctx, ingress, &httpRoute.Spec.Rules[i].BackendRefs[j].BackendRef)
+		}
+	}
 
-				// Look up the Service to resolve the named port
-				resolvedPort, err := m.resolveServicePort(ctx, ingress.Namespace, serviceName, portName)
-				if err != nil {
-					logger.Info("Could not resolve named port from Service, using fallback port 80",
-						"service", serviceName,
-						"portName", portName,
-						"namespace", ingress.Namespace,
-						"error", err.Error())
-					fallbackPort := int32(80)
-					backendRef.Port = &fallbackPort
-					continue
-				}
+	return nil
+}
 
-				logger.Info("Resolved named port from Service",
-					"service", serviceName,
-					"portName", portName,
-					"resolvedPort", resolvedPort,
-					"namespace", ingress.Namespace)
-				backendRef.Port = &resolvedPort
-			}
+// resolveBackendPort replaces the placeholder port 0 of a named Service port with the numeric port
+func (m *HTTPRouteManager) resolveBackendPort(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	backendRef *gatewayv1.BackendRef,
+) {
+	logger := log.FromContext(ctx)
+
+	// Check if port is 0 (indicates named port)
+	if backendRef.Port == nil || *backendRef.Port != 0 {
+		return
+	}
+	serviceName := string(backendRef.Name)
+
+	// Find the named port from the Ingress spec
+	portName := m.findPortNameInIngress(ingress, serviceName)
+	if portName == "" {
+		// Backends without a port (e.g. the default-backend annotation) use a single-port Service's port
+		if port, err := m.resolveSingleServicePort(ctx, ingress.Namespace, serviceName); err == nil {
+			backendRef.Port = &port
+			return
 		}
+		logger.Info("Could not find port name in Ingress for service, using fallback port 80",
+			"service", serviceName,
+			"namespace", ingress.Namespace)
+		fallbackPort := int32(80)
+		backendRef.Port = &fallbackPort
+		return
 	}
 
-	return nil
+	// Look up the Service to resolve the named port
+	resolvedPort, err := m.resolveServicePort(ctx, ingress.Namespace, serviceName, portName)
+	if err != nil {
+		logger.Info("Could not resolve named port from Service, using fallback port 80",
+			"service", serviceName,
+			"portName", portName,
+			"namespace", ingress.Namespace,
+			"error", err.Error())
+		fallbackPort := int32(80)
+		backendRef.Port = &fallbackPort
+		return
+	}
+
+	logger.Info("Resolved named port from Service",
+		"service", serviceName,
+		"portName", portName,
+		"resolvedPort", resolvedPort,
+		"namespace", ingress.Namespace)
+	backendRef.Port = &resolvedPort
 }
 
 // findPortNameInIngress finds the port name for a given service in the Ingress spec
@@ -310,9 +409,25 @@ func (m *HTTPRouteManager) findPortNameInIngress(ingress *networkingv1.Ingress,
 			}
 		}
 	}
+	if backend := ingress.Spec.DefaultBackend; backend != nil && backend.Service != nil &&
+		backend.Service.Name == serviceName {
+		return backend.Service.Port.Name
+	}
 	return ""
 }
 
+// resolveSingleServicePort returns the port of a Service that exposes exactly one port
+func (m *HTTPRouteManager) resolveSingleServicePort(ctx context.Context, namespace, serviceName string) (int32, error) {
+	var service corev1.Service
+	if err := m.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: serviceName}, &service); err != nil {
+		return 0, fmt.Errorf("failed to get Service: %w", err)
+	}
+	if len(service.Spec.Ports) != 1 {
+		return 0, fmt.Errorf("service %s has %d ports", serviceName, len(service.Spec.Ports))
+	}
+	return service.Spec.Ports[0].Port, nil
+}
+
 // resolveServicePort looks up a Service and resolves a named port to its numeric value
 func (m *HTTPRouteManager) resolveServicePort(ctx context.Context, namespace, serviceName, portName string) (int32, error) {
 	var service corev1.Service
@@ -0,0 +1,211 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	MaxHTTPRouteRuleMatches = 64  // Gateway API limit per rule
+	MaxHTTPRouteMatches     = 128 // Gateway API limit across the rules of a route
+	MaxHTTPRouteRuleFilters = 16  // Gateway API limit per rule
+
+	// maxHeaderModifications is the Gateway API limit on set, add and remove of a header modifier
+	maxHeaderModifications = 16
+)
+
+// DedupeHTTPRouteFilters drops filters a rule repeats, e.g. the same header modifier added from several
+// annotations, and merges the header modifiers of a rule into one (Gateway API allows one of each type)
+func DedupeHTTPRouteFilters(httpRoute *gatewayv1.HTTPRoute) {
+	for i := range httpRoute.Spec.Rules {
+		httpRoute.Spec.Rules[i].Filters = dedupeFilters(httpRoute.Spec.Rules[i].Filters)
+	}
+}
+
+func dedupeFilters(filters []gatewayv1.HTTPRouteFilter) []gatewayv1.HTTPRouteFilter {
+	if len(filters) < 2 {
+		return filters
+	}
+	result := make([]gatewayv1.HTTPRouteFilter, 0, len(filters))
+	headerModifiers := make(map[gatewayv1.HTTPRouteFilterType]int)
+	for _, filter := range filters {
+		switch filter.Type {
+		case gatewayv1.HTTPRouteFilterRequestHeaderModifier, gatewayv1.HTTPRouteFilterResponseHeaderModifier:
+			if index, ok := headerModifiers[filter.Type]; ok {
+				result[index] = mergeHeaderModifierFilters(result[index], filter)
+				continue
+			}
+			headerModifiers[filter.Type] = len(result)
+		default:
+			if containsFilter(result, filter) {
+				continue
+			}
+		}
+		result = append(result, *filter.DeepCopy())
+	}
+	return result
+}
+
+func containsFilter(filters []gatewayv1.HTTPRouteFilter, filter gatewayv1.HTTPRouteFilter) bool {
+	for _, existing := range filters {
+		if equality.Semantic.DeepEqual(existing, filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeHeaderModifierFilters merges the header modifier of next into base as if next ran after it: a header
+// set by both gets the value of next, additions and removals are combined
+func mergeHeaderModifierFilters(base, next gatewayv1.HTTPRouteFilter) gatewayv1.HTTPRouteFilter {
+	modifier := func(filter *gatewayv1.HTTPRouteFilter) **gatewayv1.HTTPHeaderFilter {
+		if filter.Type == gatewayv1.HTTPRouteFilterRequestHeaderModifier {
+			return &filter.RequestHeaderModifier
+		}
+		return &filter.ResponseHeaderModifier
+	}
+	merged := *base.DeepCopy()
+	into, from := modifier(&merged), *modifier(&next)
+	if from == nil {
+		return merged
+	}
+	if *into == nil {
+		*into = from.DeepCopy()
+		return merged
+	}
+	for _, header := range from.Set {
+		(*into).Set = setHeader((*into).Set, header)
+	}
+	for _, header := range from.Add {
+		if !containsHeader((*into).Add, header) {
+			(*into).Add = append((*into).Add, header)
+		}
+	}
+	for _, name := range from.Remove {
+		if !containsHeaderName((*into).Remove, name) {
+			(*into).Remove = append((*into).Remove, name)
+		}
+	}
+	return merged
+}
+
+func setHeader(headers []gatewayv1.HTTPHeader, header gatewayv1.HTTPHeader) []gatewayv1.HTTPHeader {
+	for i := range headers {
+		if strings.EqualFold(string(headers[i].Name), string(header.Name)) {
+			headers[i].Value = header.Value
+			return headers
+		}
+	}
+	return append(headers, header)
+}
+
+func containsHeader(headers []gatewayv1.HTTPHeader, header gatewayv1.HTTPHeader) bool {
+	for _, existing := range headers {
+		if strings.EqualFold(string(existing.Name), string(header.Name)) && existing.Value == header.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsHeaderName(names []string, name string) bool {
+	for _, existing := range names {
+		if strings.EqualFold(existing, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPRouteLimitViolations returns the Gateway API limits the rules of an HTTPRoute exceed that splitting
+// it cannot help with: filters per rule and headers per header modifier
+func HTTPRouteLimitViolations(httpRoute *gatewayv1.HTTPRoute) []string {
+	var violations []string
+	for i, rule := range httpRoute.Spec.Rules {
+		if len(rule.Filters) > MaxHTTPRouteRuleFilters {
+			violations = append(violations, fmt.Sprintf("rule %d of HTTPRoute %s has %d filters, at most %d are allowed",
+				i, httpRoute.Name, len(rule.Filters), MaxHTTPRouteRuleFilters))
+		}
+		for _, filter := range rule.Filters {
+			for _, modifier := range []*gatewayv1.HTTPHeaderFilter{
+				filter.RequestHeaderModifier, filter.ResponseHeaderModifier,
+			} {
+				if modifier == nil {
+					continue
+				}
+				if len(modifier.Set) > maxHeaderModifications || len(modifier.Add) > maxHeaderModifications ||
+					len(modifier.Remove) > maxHeaderModifications {
+					violations = append(violations, fmt.Sprintf(
+						"rule %d of HTTPRoute %s modifies more than %d headers in one %s filter",
+						i, httpRoute.Name, maxHeaderModifications, filter.Type))
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// splitHTTPRouteRules divides rules into the rules of the HTTPRoutes they need: a rule with more than
+// MaxHTTPRouteRuleMatches matches becomes several rules, and a route gets at most MaxHTTPRouteRules rules
+// with at most MaxHTTPRouteMatches matches between them
+func splitHTTPRouteRules(rules []gatewayv1.HTTPRouteRule) [][]gatewayv1.HTTPRouteRule {
+	var parts [][]gatewayv1.HTTPRouteRule
+	var part []gatewayv1.HTTPRouteRule
+	partMatches := 0
+	for _, rule := range splitHTTPRouteRuleMatches(rules) {
+		// A rule without matches is defaulted to one PathPrefix / match
+		matches := max(len(rule.Matches), 1)
+		if len(part) == MaxHTTPRouteRules || (len(part) > 0 && partMatches+matches > MaxHTTPRouteMatches) {
+			parts = append(parts, part)
+			part, partMatches = nil, 0
+		}
+		part = append(part, rule)
+		partMatches += matches
+	}
+	if len(part) > 0 {
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// splitHTTPRouteRuleMatches turns rules with more than MaxHTTPRouteRuleMatches matches into several rules
+// with the same filters and backends
+func splitHTTPRouteRuleMatches(rules []gatewayv1.HTTPRouteRule) []gatewayv1.HTTPRouteRule {
+	result := make([]gatewayv1.HTTPRouteRule, 0, len(rules))
+	for _, rule := range rules {
+		if len(rule.Matches) <= MaxHTTPRouteRuleMatches {
+			result = append(result, rule)
+			continue
+		}
+		for i := 0; i < len(rule.Matches); i += MaxHTTPRouteRuleMatches {
+			chunk := *rule.DeepCopy()
+			chunk.Matches = chunk.Matches[i:min(i+MaxHTTPRouteRuleMatches, len(rule.Matches))]
+			if chunk.Name != nil && i > 0 {
+				// Rule names are unique within a route
+				name := gatewayv1.SectionName(fmt.Sprintf("%s-%d", *chunk.Name, i/MaxHTTPRouteRuleMatches+1))
+				chunk.Name = &name
+			}
+			result = append(result, chunk)
+		}
+	}
+	return result
+}
@@ -0,0 +1,208 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+func routeWithRules(matchesPerRule ...int) *gatewayv1.HTTPRoute {
+	httpRoute := &gatewayv1.HTTPRoute{}
+	httpRoute.Name = "web"
+	for i, matches := range matchesPerRule {
+		rule := gatewayv1.HTTPRouteRule{}
+		for j := 0; j < matches; j++ {
+			path := fmt.Sprintf("/r%d/m%d", i, j)
+			rule.Matches = append(rule.Matches, gatewayv1.HTTPRouteMatch{
+				Path: &gatewayv1.HTTPPathMatch{Value: &path},
+			})
+		}
+		httpRoute.Spec.Rules = append(httpRoute.Spec.Rules, rule)
+	}
+	return httpRoute
+}
+
+func repeat(value, count int) []int {
+	values := make([]int, count)
+	for i := range values {
+		values[i] = value
+	}
+	return values
+}
+
+func routeNames(httpRoutes []*gatewayv1.HTTPRoute) []string {
+	names := make([]string, 0, len(httpRoutes))
+	for _, httpRoute := range httpRoutes {
+		names = append(names, httpRoute.Name)
+	}
+	return names
+}
+
+func TestSplitHTTPRouteAtLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		matchesPerRule []int
+		wantRules      []int
+	}{
+		{"16 rules fit", repeat(1, 16), []int{16}},
+		{"17 rules split", repeat(1, 17), []int{16, 1}},
+		{"128 matches fit", repeat(8, 16), []int{16}},
+		{"129 matches split", append(repeat(8, 15), 9), []int{15, 1}},
+		{"64 matches in a rule fit", []int{64}, []int{1}},
+		{"65 matches in a rule become two rules", []int{65}, []int{2}},
+		{"rules without matches count as one", repeat(0, 17), []int{16, 1}},
+	}
+	manager := &HTTPRouteManager{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpRoute := routeWithRules(tt.matchesPerRule...)
+			wantNames := SplitHTTPRouteNames(httpRoute)
+			parts := manager.SplitHTTPRouteIfNeeded(httpRoute)
+
+			gotRules := make([]int, 0, len(parts))
+			for _, part := range parts {
+				gotRules = append(gotRules, len(part.Spec.Rules))
+				matches := 0
+				for _, rule := range part.Spec.Rules {
+					if len(rule.Matches) > MaxHTTPRouteRuleMatches {
+						t.Fatalf("rule of %s has %d matches", part.Name, len(rule.Matches))
+					}
+					matches += len(rule.Matches)
+				}
+				if matches > MaxHTTPRouteMatches {
+					t.Fatalf("%s has %d matches", part.Name, matches)
+				}
+			}
+			if !reflect.DeepEqual(gotRules, tt.wantRules) {
+				t.Fatalf("rules per route = %v, want %v", gotRules, tt.wantRules)
+			}
+			if got := routeNames(parts); !reflect.DeepEqual(got, wantNames) {
+				t.Fatalf("route names = %v, SplitHTTPRouteNames = %v", got, wantNames)
+			}
+			if len(parts) > 1 && parts[1].Name != "web-2" {
+				t.Fatalf("second route is named %q, want web-2", parts[1].Name)
+			}
+		})
+	}
+}
+
+func TestSplitHTTPRouteOf300Paths(t *testing.T) {
+	manager := &HTTPRouteManager{}
+	parts := manager.SplitHTTPRouteIfNeeded(routeWithRules(repeat(1, 300)...))
+	if len(parts) != 19 {
+		t.Fatalf("300 rules split into %d routes, want 19", len(parts))
+	}
+
+	var paths []string
+	for i, part := range parts {
+		if len(part.Spec.Rules) > MaxHTTPRouteRules {
+			t.Fatalf("%s has %d rules", part.Name, len(part.Spec.Rules))
+		}
+		if violations := HTTPRouteLimitViolations(part); len(violations) > 0 {
+			t.Fatalf("%s violates limits: %v", part.Name, violations)
+		}
+		if got, want := part.Annotations[translator.RouteChunkAnnotation], fmt.Sprintf("%d/19", i+1); got != want {
+			t.Fatalf("%s is annotated as chunk %q, want %q", part.Name, got, want)
+		}
+		for _, rule := range part.Spec.Rules {
+			paths = append(paths, *rule.Matches[0].Path.Value)
+		}
+	}
+	if len(paths) != 300 || paths[0] != "/r0/m0" || paths[299] != "/r299/m0" {
+		t.Fatalf("rules lost their order: %d paths from %s to %s", len(paths), paths[0], paths[len(paths)-1])
+	}
+
+	again := manager.SplitHTTPRouteIfNeeded(routeWithRules(repeat(1, 300)...))
+	if !reflect.DeepEqual(parts, again) {
+		t.Fatal("splitting the same rules twice gave different routes")
+	}
+}
+
+func headerFilter(filterType gatewayv1.HTTPRouteFilterType, set ...string) gatewayv1.HTTPRouteFilter {
+	modifier := &gatewayv1.HTTPHeaderFilter{}
+	for i := 0; i+1 < len(set); i += 2 {
+		modifier.Set = append(modifier.Set, gatewayv1.HTTPHeader{
+			Name: gatewayv1.HTTPHeaderName(set[i]), Value: set[i+1],
+		})
+	}
+	filter := gatewayv1.HTTPRouteFilter{Type: filterType}
+	if filterType == gatewayv1.HTTPRouteFilterRequestHeaderModifier {
+		filter.RequestHeaderModifier = modifier
+	} else {
+		filter.ResponseHeaderModifier = modifier
+	}
+	return filter
+}
+
+func TestDedupeHTTPRouteFilters(t *testing.T) {
+	mirror := gatewayv1.HTTPRouteFilter{
+		Type:          gatewayv1.HTTPRouteFilterRequestMirror,
+		RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{BackendRef: gatewayv1.BackendObjectReference{Name: "shadow"}},
+	}
+	httpRoute := &gatewayv1.HTTPRoute{Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{{
+		Filters: []gatewayv1.HTTPRouteFilter{
+			headerFilter(gatewayv1.HTTPRouteFilterRequestHeaderModifier, "X-A", "a", "X-B", "b"),
+			mirror,
+			headerFilter(gatewayv1.HTTPRouteFilterResponseHeaderModifier, "X-C", "c"),
+			headerFilter(gatewayv1.HTTPRouteFilterRequestHeaderModifier, "x-b", "override", "X-D", "d"),
+			mirror,
+			headerFilter(gatewayv1.HTTPRouteFilterRequestHeaderModifier, "X-A", "a"),
+		},
+	}}}}
+
+	DedupeHTTPRouteFilters(httpRoute)
+
+	want := []gatewayv1.HTTPRouteFilter{
+		headerFilter(gatewayv1.HTTPRouteFilterRequestHeaderModifier, "X-A", "a", "X-B", "override", "X-D", "d"),
+		mirror,
+		headerFilter(gatewayv1.HTTPRouteFilterResponseHeaderModifier, "X-C", "c"),
+	}
+	if got := httpRoute.Spec.Rules[0].Filters; !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected filters:\n%+v\nwant:\n%+v", got, want)
+	}
+	if violations := HTTPRouteLimitViolations(httpRoute); len(violations) > 0 {
+		t.Fatalf("unexpected violations: %v", violations)
+	}
+}
+
+func TestHTTPRouteLimitViolationsAtFilterLimit(t *testing.T) {
+	filters := make([]gatewayv1.HTTPRouteFilter, 0, MaxHTTPRouteRuleFilters+1)
+	for i := 0; i <= MaxHTTPRouteRuleFilters; i++ {
+		filters = append(filters, gatewayv1.HTTPRouteFilter{
+			Type: gatewayv1.HTTPRouteFilterExtensionRef,
+			ExtensionRef: &gatewayv1.LocalObjectReference{
+				Kind: "SnippetsFilter", Name: gatewayv1.ObjectName(fmt.Sprintf("f%d", i)),
+			},
+		})
+	}
+	httpRoute := &gatewayv1.HTTPRoute{Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{{
+		Filters: filters[:MaxHTTPRouteRuleFilters],
+	}}}}
+	if violations := HTTPRouteLimitViolations(httpRoute); len(violations) > 0 {
+		t.Fatalf("%d filters should fit: %v", MaxHTTPRouteRuleFilters, violations)
+	}
+	httpRoute.Spec.Rules[0].Filters = filters
+	if violations := HTTPRouteLimitViolations(httpRoute); len(violations) != 1 {
+		t.Fatalf("expected one violation for %d filters, got %v", len(filters), violations)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+func managedRoute(name string, rules int) *gatewayv1.HTTPRoute {
+	httpRoute := routeWithRules(repeat(1, rules)...)
+	httpRoute.Name = name
+	httpRoute.Namespace = "default"
+	httpRoute.Annotations = map[string]string{
+		translator.ManagedByAnnotation: translator.ManagedByValue,
+		translator.SourceAnnotation:    "default/web",
+	}
+	return httpRoute
+}
+
+func TestApplyHTTPRoutesAtomicChunkLifecycle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := gatewayv1.Install(scheme); err != nil {
+		t.Fatal(err)
+	}
+	ingress := &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}}
+
+	tests := []struct {
+		name      string
+		existing  []string // nil when the desired routes exist already
+		rules     int
+		wantOps   []string
+		wantNames []string
+	}{
+		{"growing creates new chunks before updating", []string{"web"}, 40,
+			[]string{"create web-2", "create web-3", "update web"}, []string{"web", "web-2", "web-3"}},
+		{"shrinking deletes obsolete chunks last", []string{"web", "web-2", "web-3"}, 20,
+			[]string{"update web", "update web-2", "delete web-3"}, []string{"web", "web-2"}},
+		{"unchanged chunks are left alone", nil, 20, nil, []string{"web", "web-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &HTTPRouteManager{}
+			desired := manager.SplitHTTPRouteIfNeeded(managedRoute("web", tt.rules))
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			for _, name := range tt.existing {
+				builder = builder.WithObjects(managedRoute(name, 1))
+			}
+			if tt.existing == nil {
+				for _, part := range desired {
+					builder = builder.WithObjects(part.DeepCopy())
+				}
+			}
+			manager.Client = builder.Build()
+
+			var ops []string
+			record := func(operation, _, name string) { ops = append(ops, operation+" "+name) }
+			if err := manager.ApplyHTTPRoutesAtomic(context.Background(), ingress, desired, record); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(ops, tt.wantOps) {
+				t.Fatalf("operations = %v, want %v", ops, tt.wantOps)
+			}
+
+			routes := &gatewayv1.HTTPRouteList{}
+			if err := manager.Client.List(context.Background(), routes, client.InNamespace("default")); err != nil {
+				t.Fatal(err)
+			}
+			names := make([]string, 0, len(routes.Items))
+			for _, route := range routes.Items {
+				names = append(names, route.Name)
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Fatalf("routes = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ImpersonateNamespacePlaceholder is replaced with the namespace of the request in impersonation templates
+const ImpersonateNamespacePlaceholder = "{namespace}"
+
+// ValidateImpersonateTemplate checks that an impersonation template names a per-namespace identity
+func ValidateImpersonateTemplate(template string) error {
+	if !strings.Contains(template, ImpersonateNamespacePlaceholder) {
+		return fmt.Errorf("impersonation template %q must contain %s", template, ImpersonateNamespacePlaceholder)
+	}
+	return nil
+}
+
+// ImpersonatedUser returns the user a write into namespace is made as
+func ImpersonatedUser(template, namespace string) string {
+	return strings.ReplaceAll(template, ImpersonateNamespacePlaceholder, namespace)
+}
+
+// NewImpersonatingClient returns a client that creates, updates and deletes namespaced objects as the
+// user template expands to for their namespace, so the namespace RBAC of that identity applies.
+// Reads and cluster-scoped writes keep the identity of config.
+func NewImpersonatingClient(config *rest.Config, options client.Options, template string) (client.Client, error) {
+	if err := ValidateImpersonateTemplate(template); err != nil {
+		return nil, err
+	}
+	impersonating := rest.CopyConfig(config)
+	impersonating.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &namespaceImpersonator{delegate: rt, template: template}
+	})
+	// The HTTP client is derived from the config, a shared one would bypass the impersonation
+	options.HTTPClient = nil
+	return client.New(impersonating, options)
+}
+
+// namespaceImpersonator sets the Impersonate-User header on namespaced write requests
+type namespaceImpersonator struct {
+	delegate http.RoundTripper
+	template string
+}
+
+func (n *namespaceImpersonator) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return n.delegate.RoundTrip(req)
+	}
+	namespace := requestNamespace(req.URL.Path)
+	if namespace == "" {
+		return n.delegate.RoundTrip(req)
+	}
+	req = utilnet.CloneRequest(req)
+	req.Header.Set(transport.ImpersonateUserHeader, ImpersonatedUser(n.template, namespace))
+	return n.delegate.RoundTrip(req)
+}
+
+func (n *namespaceImpersonator) WrappedRoundTripper() http.RoundTripper {
+	return n.delegate
+}
+
+// requestNamespace returns the namespace of a request for a namespaced resource
+// (/api/v1/namespaces/<ns>/<resource>/... or /apis/<group>/<version>/namespaces/<ns>/<resource>/...),
+// empty for cluster-scoped resources including Namespaces themselves
+func requestNamespace(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	index := -1
+	switch {
+	case len(parts) > 0 && parts[0] == "api":
+		index = 2
+	case len(parts) > 0 && parts[0] == "apis":
+		index = 3
+	}
+	if index < 0 || len(parts) < index+3 || parts[index] != "namespaces" {
+		return ""
+	}
+	return parts[index+1]
+}
@@ -0,0 +1,199 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// InstalledGVK returns the GroupVersionKind of a custom resource kind, false when its CRD is missing
+func InstalledGVK(ctx context.Context, c client.Reader, group, kind, crdName string) (schema.GroupVersionKind, bool, error) {
+	version, ok, err := getCRDVersion(ctx, c, crdName)
+	if err != nil || !ok {
+		return schema.GroupVersionKind{}, false, err
+	}
+	return schema.GroupVersionKind{Group: group, Version: version, Kind: kind}, true, nil
+}
+
+// AutomaticResourceName returns a stable name for a data plane resource generated for an Ingress.
+// Resources in the Gateway namespace include the Ingress namespace to stay unique.
+func AutomaticResourceName(parts ...string) string {
+	base := "automatic-" + strings.Join(parts, "-")
+	if len(base) <= maxK8sNameLength {
+		return base
+	}
+	return strings.TrimRight(base[:maxK8sNameLength], "-")
+}
+
+// SyncIngressResources makes the resources of one kind generated for an Ingress in namespace match
+// desired: missing ones are created, managed ones updated, and managed ones of the Ingress that are not
+// desired anymore deleted. Objects not managed by us are left alone.
+func SyncIngressResources(
+	ctx context.Context,
+	c client.Client,
+	scheme *runtime.Scheme,
+	owner client.Object,
+	ingress *networkingv1.Ingress,
+	gvk schema.GroupVersionKind,
+	namespace string,
+	desired []*unstructured.Unstructured,
+) error {
+	wanted := make(map[string]bool, len(desired))
+	for _, object := range desired {
+		wanted[object.GetName()] = true
+		if err := ApplyIngressResource(ctx, c, scheme, owner, ingress, gvk, namespace, object); err != nil {
+			return err
+		}
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return err
+	}
+	for i := range list.Items {
+		object := &list.Items[i]
+		if wanted[object.GetName()] || !IsManagedByUsForIngress(object, ingress.Namespace, ingress.Name) {
+			continue
+		}
+		if err := deleteIngressResource(ctx, c, object); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyIngressResource creates or updates one resource generated for an Ingress. The owner is only set
+// when it lives in the same namespace.
+func ApplyIngressResource(
+	ctx context.Context,
+	c client.Client,
+	scheme *runtime.Scheme,
+	owner client.Object,
+	ingress *networkingv1.Ingress,
+	gvk schema.GroupVersionKind,
+	namespace string,
+	object *unstructured.Unstructured,
+) error {
+	logger := log.FromContext(ctx)
+	object.SetGroupVersionKind(gvk)
+	object.SetNamespace(namespace)
+	object.SetAnnotations(map[string]string{
+		ManagedByAnnotation: ManagedByValue,
+		SourceAnnotation:    fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name),
+	})
+	if scheme != nil && owner != nil && namespace == owner.GetNamespace() {
+		if err := controllerutil.SetControllerReference(owner, object, scheme); err != nil {
+			return err
+		}
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(gvk)
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: object.GetName()}, existing)
+	if apierrors.IsNotFound(err) {
+		logger.Info("Creating "+gvk.Kind, "namespace", namespace, "name", object.GetName())
+		if err := c.Create(ctx, object); err != nil {
+			return fmt.Errorf("failed to create %s %s/%s: %w", gvk.Kind, namespace, object.GetName(), err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !IsManagedByUs(existing) {
+		logger.Info(gvk.Kind+" exists but is not managed by us, skipping",
+			"namespace", namespace,
+			"name", object.GetName())
+		return nil
+	}
+	if equality.Semantic.DeepEqual(resourceContent(existing), resourceContent(object)) &&
+		equality.Semantic.DeepEqual(existing.GetAnnotations(), object.GetAnnotations()) {
+		return nil
+	}
+	existing.SetAnnotations(object.GetAnnotations())
+	existing.SetOwnerReferences(object.GetOwnerReferences())
+	for field := range resourceContent(existing) {
+		delete(existing.Object, field)
+	}
+	for field, value := range resourceContent(object) {
+		existing.Object[field] = value
+	}
+	logger.Info("Updating "+gvk.Kind, "namespace", namespace, "name", object.GetName())
+	if err := c.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update %s %s/%s: %w", gvk.Kind, namespace, object.GetName(), err)
+	}
+	return nil
+}
+
+// DeleteIngressResource deletes the named resource if it was generated for the Ingress
+func DeleteIngressResource(
+	ctx context.Context,
+	c client.Client,
+	ingress *networkingv1.Ingress,
+	gvk schema.GroupVersionKind,
+	namespace, name string,
+) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(gvk)
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, existing)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !IsManagedByUsForIngress(existing, ingress.Namespace, ingress.Name) {
+		return nil
+	}
+	return deleteIngressResource(ctx, c, existing)
+}
+
+func deleteIngressResource(ctx context.Context, c client.Client, object *unstructured.Unstructured) error {
+	log.FromContext(ctx).Info("Deleting "+object.GetKind(), "namespace", object.GetNamespace(), "name", object.GetName())
+	if err := c.Delete(ctx, object); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete %s %s/%s: %w", object.GetKind(), object.GetNamespace(), object.GetName(), err)
+	}
+	return nil
+}
+
+// resourceContent returns the generated fields of an object: spec for custom resources, data and type
+// for Secrets. The server owned status and metadata are left out.
+func resourceContent(object *unstructured.Unstructured) map[string]interface{} {
+	content := make(map[string]interface{}, len(object.Object))
+	for field, value := range object.Object {
+		switch field {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+		content[field] = value
+	}
+	return content
+}
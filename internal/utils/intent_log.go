@@ -0,0 +1,248 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	IntentLogConfigMapName = "ingress-doperator-intent-log"
+
+	// intentLogMaxFinished bounds the audit trail of finished intents kept in the ConfigMap
+	intentLogMaxFinished = 200
+)
+
+// IntentOperation is a destructive operation recorded in the intent log
+type IntentOperation string
+
+const (
+	IntentDeleteIngress      IntentOperation = "delete-ingress"
+	IntentDisableIngress     IntentOperation = "disable-ingress"
+	IntentDisableExternalDNS IntentOperation = "disable-external-dns"
+	IntentDeleteHTTPRoutes   IntentOperation = "delete-httproutes"
+)
+
+// Intent is one entry of the intent log
+type Intent struct {
+	ID        string          `json:"-"`
+	Operation IntentOperation `json:"operation"`
+	// Target is the namespace/name of the Ingress the operation is about
+	Target string `json:"target"`
+	// Cluster is the fan-in source cluster of the Ingress, empty for the local cluster
+	Cluster    string     `json:"cluster,omitempty"`
+	StartedAt  time.Time  `json:"startedAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	// Error is set when the operation failed; it is retried by the regular reconcile, not resumed
+	Error string `json:"error,omitempty"`
+}
+
+// Incomplete reports whether the operation was started but never finished, e.g. due to a crash
+func (i Intent) Incomplete() bool {
+	return i.FinishedAt == nil
+}
+
+// IntentLog is a write-ahead log of destructive operations kept in a ConfigMap: an entry is
+// appended before the operation and marked finished afterwards, so entries left incomplete
+// point at operations interrupted by a crash. Finished entries form an audit trail.
+// A nil IntentLog records nothing.
+type IntentLog struct {
+	client    client.Client
+	reader    client.Reader
+	namespace string
+	name      string
+
+	mu  sync.Mutex
+	seq uint64
+}
+
+// NewIntentLog creates an intent log in the namespace/name ConfigMap. Reads go through reader,
+// which should be uncached so that updates do not keep conflicting.
+func NewIntentLog(c client.Client, reader client.Reader, namespace, name string) *IntentLog {
+	return &IntentLog{client: c, reader: reader, namespace: namespace, name: name}
+}
+
+// Begin appends an incomplete intent and returns its ID
+func (l *IntentLog) Begin(
+	ctx context.Context,
+	operation IntentOperation,
+	target string,
+	cluster string,
+) (string, error) {
+	if l == nil {
+		return "", nil
+	}
+	l.mu.Lock()
+	l.seq++
+	now := time.Now().UTC()
+	intent := Intent{
+		ID:        fmt.Sprintf("%d-%d-%s", now.UnixNano(), l.seq, operation),
+		Operation: operation,
+		Target:    target,
+		Cluster:   cluster,
+		StartedAt: now,
+	}
+	l.mu.Unlock()
+
+	err := l.modify(ctx, func(data map[string]string) error {
+		raw, err := json.Marshal(intent)
+		if err != nil {
+			return err
+		}
+		data[intent.ID] = string(raw)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to record %s intent for %s: %w", operation, target, err)
+	}
+	return intent.ID, nil
+}
+
+// Finish marks an intent finished, recording opErr if the operation failed
+func (l *IntentLog) Finish(ctx context.Context, id string, opErr error) error {
+	if l == nil || id == "" {
+		return nil
+	}
+	return l.modify(ctx, func(data map[string]string) error {
+		raw, ok := data[id]
+		if !ok {
+			return nil
+		}
+		var intent Intent
+		if err := json.Unmarshal([]byte(raw), &intent); err != nil {
+			return err
+		}
+		now := time.Now().UTC()
+		intent.FinishedAt = &now
+		if opErr != nil {
+			intent.Error = opErr.Error()
+		}
+		updated, err := json.Marshal(intent)
+		if err != nil {
+			return err
+		}
+		data[id] = string(updated)
+		pruneFinishedIntents(data)
+		return nil
+	})
+}
+
+// Incomplete returns the intents that were started but never finished, oldest first
+func (l *IntentLog) Incomplete(ctx context.Context) ([]Intent, error) {
+	if l == nil {
+		return nil, nil
+	}
+	cm := &corev1.ConfigMap{}
+	if err := l.reader.Get(ctx, client.ObjectKey{Namespace: l.namespace, Name: l.name}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var intents []Intent
+	for id, raw := range cm.Data {
+		intent, ok := parseIntent(id, raw)
+		if ok && intent.Incomplete() {
+			intents = append(intents, intent)
+		}
+	}
+	sort.Slice(intents, func(i, j int) bool {
+		return intents[i].StartedAt.Before(intents[j].StartedAt)
+	})
+	return intents, nil
+}
+
+// modify applies fn to the data of the intent log ConfigMap, creating it when missing
+func (l *IntentLog) modify(ctx context.Context, fn func(data map[string]string) error) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// A recorded intent must not be lost to the reconcile context being cancelled
+	ctx = context.WithoutCancel(ctx)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		err := l.reader.Get(ctx, client.ObjectKey{Namespace: l.namespace, Name: l.name}, cm)
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: l.name, Namespace: l.namespace},
+				Data:       make(map[string]string),
+			}
+			if err := fn(cm.Data); err != nil {
+				return err
+			}
+			err = l.client.Create(ctx, cm)
+			if apierrors.IsAlreadyExists(err) {
+				// Created concurrently, retry as an update
+				return apierrors.NewConflict(corev1.Resource("configmaps"), l.name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		if err := fn(cm.Data); err != nil {
+			return err
+		}
+		return l.client.Update(ctx, cm)
+	})
+}
+
+// pruneFinishedIntents drops the oldest finished intents beyond intentLogMaxFinished
+func pruneFinishedIntents(data map[string]string) {
+	var finished []Intent
+	for id, raw := range data {
+		intent, ok := parseIntent(id, raw)
+		if !ok {
+			delete(data, id)
+			continue
+		}
+		if !intent.Incomplete() {
+			finished = append(finished, intent)
+		}
+	}
+	if len(finished) <= intentLogMaxFinished {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].FinishedAt.Before(*finished[j].FinishedAt)
+	})
+	for _, intent := range finished[:len(finished)-intentLogMaxFinished] {
+		delete(data, intent.ID)
+	}
+}
+
+func parseIntent(id, raw string) (Intent, bool) {
+	var intent Intent
+	if err := json.Unmarshal([]byte(raw), &intent); err != nil || intent.Operation == "" {
+		return Intent{}, false
+	}
+	intent.ID = id
+	return intent, true
+}
@@ -0,0 +1,252 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+const (
+	IstioNetworkingGroup   = "networking.istio.io"
+	IstioTelemetryGroup    = "telemetry.istio.io"
+	DestinationRuleKind    = "DestinationRule"
+	EnvoyFilterKind        = "EnvoyFilter"
+	TelemetryKind          = "Telemetry"
+	DestinationRuleCRDName = "destinationrules.networking.istio.io"
+	EnvoyFilterCRDName     = "envoyfilters.networking.istio.io"
+	TelemetryCRDName       = "telemetries.telemetry.istio.io"
+
+	localRateLimitFilter  = "envoy.filters.http.local_ratelimit"
+	localRateLimitTypeURL = "type.googleapis.com/envoy.extensions.filters.http.local_ratelimit.v3.LocalRateLimit"
+	typedStructTypeURL    = "type.googleapis.com/udpa.type.v1.TypedStruct"
+)
+
+// IstioLoadBalancer translates upstream-hash-by and load-balance into a DestinationRule loadBalancer.
+// Hash keys other than the client address, a header, a cookie or a query parameter have no equivalent.
+func IstioLoadBalancer(loadBalancing translator.UpstreamLoadBalancing) (map[string]interface{}, bool) {
+	switch {
+	case loadBalancing.HashKey != "":
+		hash, ok := istioConsistentHash(loadBalancing.HashKey)
+		if !ok {
+			return nil, false
+		}
+		return map[string]interface{}{"consistentHash": hash}, true
+	case loadBalancing.Method == "ip_hash":
+		return map[string]interface{}{"consistentHash": map[string]interface{}{"useSourceIp": true}}, true
+	case loadBalancing.Method == translator.RoundRobinLoadBalancingMethod:
+		// Istio defaults to LEAST_REQUEST
+		return map[string]interface{}{"simple": "ROUND_ROBIN"}, true
+	case loadBalancing.Approximated:
+		// ewma prefers the least loaded endpoint, which LEAST_REQUEST does too
+		return map[string]interface{}{"simple": "LEAST_REQUEST"}, true
+	}
+	return nil, false
+}
+
+func istioConsistentHash(hashKey string) (map[string]interface{}, bool) {
+	switch {
+	case hashKey == "$remote_addr" || hashKey == "$binary_remote_addr":
+		return map[string]interface{}{"useSourceIp": true}, true
+	case strings.HasPrefix(hashKey, "$http_") && len(hashKey) > len("$http_"):
+		header := strings.ReplaceAll(strings.TrimPrefix(hashKey, "$http_"), "_", "-")
+		return map[string]interface{}{"httpHeaderName": header}, true
+	case strings.HasPrefix(hashKey, "$cookie_") && len(hashKey) > len("$cookie_"):
+		return map[string]interface{}{
+			"httpCookie": map[string]interface{}{"name": strings.TrimPrefix(hashKey, "$cookie_"), "ttl": "0s"},
+		}, true
+	case strings.HasPrefix(hashKey, "$arg_") && len(hashKey) > len("$arg_"):
+		return map[string]interface{}{"httpQueryParameterName": strings.TrimPrefix(hashKey, "$arg_")}, true
+	}
+	return nil, false
+}
+
+// BuildDestinationRule returns a DestinationRule applying trafficPolicy to a backend Service
+func BuildDestinationRule(name, namespace, service string, trafficPolicy map[string]interface{}) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	object.SetName(name)
+	object.Object["spec"] = map[string]interface{}{
+		"host":          fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace),
+		"trafficPolicy": trafficPolicy,
+	}
+	return object
+}
+
+// LocalRateLimitFilterName returns the name of the EnvoyFilter adding the local rate limit filter to
+// the Gateway, which the per-Ingress EnvoyFilters configure per virtual host
+func LocalRateLimitFilterName(gatewayName string) string {
+	return AutomaticResourceName(gatewayName, "local-ratelimit")
+}
+
+// EnsureLocalRateLimitFilter adds the (unconfigured, so inactive) local rate limit filter to the HTTP
+// filter chain of the Gateway once. It is left in place when no Ingress is rate limited anymore.
+func EnsureLocalRateLimitFilter(
+	ctx context.Context,
+	c client.Client,
+	gvk schema.GroupVersionKind,
+	gateway types.NamespacedName,
+) error {
+	desired := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	desired.SetGroupVersionKind(gvk)
+	desired.SetName(LocalRateLimitFilterName(gateway.Name))
+	desired.SetNamespace(gateway.Namespace)
+	desired.SetAnnotations(map[string]string{ManagedByAnnotation: ManagedByValue})
+	desired.Object["spec"] = map[string]interface{}{
+		"targetRefs": []interface{}{gatewayTargetRef(gateway.Name)},
+		"configPatches": []interface{}{
+			map[string]interface{}{
+				"applyTo": "HTTP_FILTER",
+				"match": map[string]interface{}{
+					"context": "GATEWAY",
+					"listener": map[string]interface{}{
+						"filterChain": map[string]interface{}{
+							"filter": map[string]interface{}{
+								"name":      "envoy.filters.network.http_connection_manager",
+								"subFilter": map[string]interface{}{"name": "envoy.filters.http.router"},
+							},
+						},
+					},
+				},
+				"patch": map[string]interface{}{
+					"operation": "INSERT_BEFORE",
+					"value": map[string]interface{}{
+						"name":         localRateLimitFilter,
+						"typed_config": localRateLimitConfig(nil),
+					},
+				},
+			},
+		},
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(gvk)
+	err := c.Get(ctx, types.NamespacedName{Namespace: gateway.Namespace, Name: desired.GetName()}, existing)
+	if apierrors.IsNotFound(err) {
+		log.FromContext(ctx).Info("Creating EnvoyFilter", "namespace", gateway.Namespace, "name", desired.GetName())
+		return client.IgnoreAlreadyExists(c.Create(ctx, desired))
+	}
+	if err != nil || !IsManagedByUs(existing) || equality.Semantic.DeepEqual(existing.Object["spec"], desired.Object["spec"]) {
+		return err
+	}
+	existing.Object["spec"] = desired.Object["spec"]
+	return c.Update(ctx, existing)
+}
+
+func localRateLimitConfig(bucket map[string]interface{}) map[string]interface{} {
+	value := map[string]interface{}{"stat_prefix": "http_local_rate_limiter"}
+	if bucket != nil {
+		percent := map[string]interface{}{
+			"default_value": map[string]interface{}{"numerator": int64(100), "denominator": "HUNDRED"},
+		}
+		value["token_bucket"] = bucket
+		value["filter_enabled"] = mergeRuntimeKey(percent, "local_rate_limit_enabled")
+		value["filter_enforced"] = mergeRuntimeKey(percent, "local_rate_limit_enforced")
+	}
+	return map[string]interface{}{
+		"@type":    typedStructTypeURL,
+		"type_url": localRateLimitTypeURL,
+		"value":    value,
+	}
+}
+
+// BuildLocalRateLimitEnvoyFilter returns an EnvoyFilter enforcing limit on the virtual hosts of
+// hostnames on the Gateway. The token bucket is shared by all clients of a Gateway replica.
+func BuildLocalRateLimitEnvoyFilter(
+	name string,
+	gatewayName string,
+	hostnames []gatewayv1.Hostname,
+	limit translator.RateLimit,
+) *unstructured.Unstructured {
+	patches := make([]interface{}, 0, 2*len(hostnames))
+	bucket := map[string]interface{}{
+		"max_tokens":      int64(limit.Burst),
+		"tokens_per_fill": int64(limit.Requests),
+		"fill_interval":   limit.Interval.String(),
+	}
+	sorted := make([]string, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		sorted = append(sorted, string(hostname))
+	}
+	sort.Strings(sorted)
+	for _, hostname := range sorted {
+		for _, port := range []int{80, 443} {
+			patches = append(patches, map[string]interface{}{
+				"applyTo": "VIRTUAL_HOST",
+				"match": map[string]interface{}{
+					"context": "GATEWAY",
+					"routeConfiguration": map[string]interface{}{
+						"vhost": map[string]interface{}{"name": fmt.Sprintf("%s:%d", hostname, port)},
+					},
+				},
+				"patch": map[string]interface{}{
+					"operation": "MERGE",
+					"value": map[string]interface{}{
+						"typed_per_filter_config": map[string]interface{}{localRateLimitFilter: localRateLimitConfig(bucket)},
+					},
+				},
+			})
+		}
+	}
+
+	object := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	object.SetName(name)
+	object.Object["spec"] = map[string]interface{}{
+		"targetRefs":    []interface{}{gatewayTargetRef(gatewayName)},
+		"configPatches": patches,
+	}
+	return object
+}
+
+func mergeRuntimeKey(percent map[string]interface{}, key string) map[string]interface{} {
+	out := map[string]interface{}{"runtime_key": key}
+	for k, v := range percent {
+		out[k] = v
+	}
+	return out
+}
+
+// BuildAccessLogTelemetry returns a Telemetry turning off access logging on the Gateway
+func BuildAccessLogTelemetry(name, gatewayName string) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	object.SetName(name)
+	object.Object["spec"] = map[string]interface{}{
+		"targetRefs":    []interface{}{gatewayTargetRef(gatewayName)},
+		"accessLogging": []interface{}{map[string]interface{}{"disabled": true}},
+	}
+	return object
+}
+
+func gatewayTargetRef(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"group": gatewayv1.GroupName,
+		"kind":  "Gateway",
+		"name":  name,
+	}
+}
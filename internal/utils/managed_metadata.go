@@ -0,0 +1,141 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ManagedAnnotationKeysAnnotation lists the annotation keys outside our own prefix the operator wrote on a
+	// managed resource, so a key dropped from the source Ingress is removed on the next update
+	ManagedAnnotationKeysAnnotation = "ingress-doperator.fiction.si/managed-annotation-keys"
+	// ManagedLabelKeysAnnotation lists the label keys the operator wrote on a managed resource
+	ManagedLabelKeysAnnotation = "ingress-doperator.fiction.si/managed-label-keys"
+
+	operatorKeyPrefix = "ingress-doperator.fiction.si/"
+)
+
+// ValidateOwnedMetadataKeys checks the glob patterns of --owned-metadata-keys
+func ValidateOwnedMetadataKeys(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid owned metadata key pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// RecordManagedKeys notes the annotation and label keys of obj outside our own prefix, so a later
+// MergeManagedMetadata knows which keys it may remove. The record is written even when empty, since its
+// absence marks a resource last written before metadata was merged.
+func RecordManagedKeys(obj client.Object) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	setKeyList(annotations, ManagedAnnotationKeysAnnotation, annotations)
+	setKeyList(annotations, ManagedLabelKeysAnnotation, obj.GetLabels())
+	obj.SetAnnotations(annotations)
+}
+
+func setKeyList(annotations map[string]string, recordKey string, values map[string]string) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if !strings.HasPrefix(key, operatorKeyPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	annotations[recordKey] = strings.Join(keys, ",")
+}
+
+// MergeManagedMetadata merges the annotations and labels of desired into existing instead of replacing them,
+// so keys added by policy engines or other controllers survive our updates. A key of existing is dropped only
+// when the operator owns it: it carries our prefix, matches one of the owned glob patterns, or was written by
+// an earlier update and is no longer desired. It returns whether existing changed.
+func MergeManagedMetadata(existing, desired client.Object, owned []string) bool {
+	RecordManagedKeys(desired)
+	previous := existing.GetAnnotations()
+	writtenAnnotations := parseKeyList(previous[ManagedAnnotationKeysAnnotation])
+	if _, ok := previous[ManagedAnnotationKeysAnnotation]; !ok {
+		// Older versions replaced the annotations on every update, so all of them are ours
+		for key := range previous {
+			writtenAnnotations[key] = true
+		}
+	}
+	annotations := mergeKeys(previous, desired.GetAnnotations(), writtenAnnotations, owned)
+	labels := mergeKeys(existing.GetLabels(), desired.GetLabels(),
+		parseKeyList(previous[ManagedLabelKeysAnnotation]), owned)
+
+	// The key records alone do not warrant an update, resources written by older versions get them on
+	// their next real change
+	changed := !maps.Equal(withoutKeyRecords(annotations), withoutKeyRecords(previous)) ||
+		!maps.Equal(labels, existing.GetLabels())
+	if changed {
+		existing.SetAnnotations(annotations)
+		existing.SetLabels(labels)
+	}
+	return changed
+}
+
+func mergeKeys(current, desired map[string]string, written map[string]bool, owned []string) map[string]string {
+	merged := make(map[string]string, len(current)+len(desired))
+	for key, value := range current {
+		if !ownsKey(key, written, owned) {
+			merged[key] = value
+		}
+	}
+	maps.Copy(merged, desired)
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
+
+func ownsKey(key string, written map[string]bool, owned []string) bool {
+	if strings.HasPrefix(key, operatorKeyPrefix) || written[key] {
+		return true
+	}
+	for _, pattern := range owned {
+		if matched, _ := filepath.Match(pattern, key); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutKeyRecords(annotations map[string]string) map[string]string {
+	stripped := maps.Clone(annotations)
+	delete(stripped, ManagedAnnotationKeysAnnotation)
+	delete(stripped, ManagedLabelKeysAnnotation)
+	return stripped
+}
+
+func parseKeyList(value string) map[string]bool {
+	keys := map[string]bool{}
+	for _, key := range ParseCommaSeparatedList(value) {
+		keys[key] = true
+	}
+	return keys
+}
@@ -0,0 +1,195 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+// SyncMirrorReferenceGrants keeps the ReferenceGrants that allow HTTPRoutes in routeNamespace to
+// mirror requests to Services in other namespaces in line with the managed HTTPRoutes.
+// current are the HTTPRoutes just applied for ingressNamespace/ingressName; cached HTTPRoutes of
+// that Ingress are ignored so a deleted Ingress (current == nil) releases its grants.
+func SyncMirrorReferenceGrants(
+	ctx context.Context,
+	c client.Client,
+	routeNamespace string,
+	ingressNamespace string,
+	ingressName string,
+	current []*gatewayv1.HTTPRoute,
+) error {
+	logger := log.FromContext(ctx)
+	grantName := translator.MirrorReferenceGrantName(routeNamespace)
+
+	var routeList gatewayv1.HTTPRouteList
+	if err := c.List(ctx, &routeList, client.InNamespace(routeNamespace)); err != nil {
+		return fmt.Errorf("failed to list HTTPRoutes: %w", err)
+	}
+	routes := make([]*gatewayv1.HTTPRoute, 0, len(routeList.Items)+len(current))
+	for i := range routeList.Items {
+		route := &routeList.Items[i]
+		if !IsManagedByUs(route) || IsManagedByUsForIngress(route, ingressNamespace, ingressName) {
+			continue
+		}
+		routes = append(routes, route)
+	}
+	routes = append(routes, current...)
+
+	// Namespaces that need a grant, plus those that have one from earlier reconciles
+	targets := translator.CrossNamespaceMirrorTargets(routes)
+	namespaces := make(map[string]bool, len(targets))
+	for namespace := range targets {
+		namespaces[namespace] = true
+	}
+	var grantList gatewayv1beta1.ReferenceGrantList
+	if err := c.List(ctx, &grantList); err != nil {
+		return fmt.Errorf("failed to list ReferenceGrants: %w", err)
+	}
+	for i := range grantList.Items {
+		if grantList.Items[i].Name == grantName && IsManagedByUs(&grantList.Items[i]) {
+			namespaces[grantList.Items[i].Namespace] = true
+		}
+	}
+
+	for namespace := range namespaces {
+		desired := mirrorReferenceGrant(grantName, namespace, routeNamespace, targets[namespace], routes)
+		if err := applyMirrorReferenceGrant(ctx, c, desired); err != nil {
+			logger.Error(err, "failed to apply mirror ReferenceGrant", "namespace", namespace, "name", grantName)
+			return err
+		}
+	}
+	return nil
+}
+
+// mirrorReferenceGrant builds the desired grant, nil when no HTTPRoute mirrors into namespace
+func mirrorReferenceGrant(
+	grantName string,
+	namespace string,
+	routeNamespace string,
+	services []string,
+	routes []*gatewayv1.HTTPRoute,
+) *gatewayv1beta1.ReferenceGrant {
+	if len(services) == 0 {
+		return &gatewayv1beta1.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: grantName, Namespace: namespace},
+		}
+	}
+	sort.Strings(services)
+
+	sourceSet := make(map[string]bool)
+	for _, route := range routes {
+		source := route.Annotations[SourceAnnotation]
+		if source != "" && len(translator.CrossNamespaceMirrorTargets([]*gatewayv1.HTTPRoute{route})[namespace]) > 0 {
+			sourceSet[source] = true
+		}
+	}
+	sources := make([]string, 0, len(sourceSet))
+	for source := range sourceSet {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	to := make([]gatewayv1beta1.ReferenceGrantTo, 0, len(services))
+	for _, service := range services {
+		name := gatewayv1.ObjectName(service)
+		to = append(to, gatewayv1beta1.ReferenceGrantTo{
+			Group: "",
+			Kind:  "Service",
+			Name:  &name,
+		})
+	}
+
+	return &gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      grantName,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				ManagedByAnnotation: ManagedByValue,
+				SourceAnnotation:    strings.Join(sources, ","),
+			},
+		},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{
+				{
+					Group:     gatewayv1.GroupName,
+					Kind:      "HTTPRoute",
+					Namespace: gatewayv1.Namespace(routeNamespace),
+				},
+			},
+			To: to,
+		},
+	}
+}
+
+// applyMirrorReferenceGrant creates, updates or (when desired has no spec) deletes the grant
+func applyMirrorReferenceGrant(ctx context.Context, c client.Client, desired *gatewayv1beta1.ReferenceGrant) error {
+	logger := log.FromContext(ctx)
+
+	existing := &gatewayv1beta1.ReferenceGrant{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	if exists && !IsManagedByUs(existing) {
+		logger.Info("ReferenceGrant exists but is not managed by us (no managed-by annotation), skipping",
+			"namespace", desired.Namespace, "name", desired.Name)
+		return nil
+	}
+
+	if len(desired.Spec.To) == 0 {
+		if !exists {
+			return nil
+		}
+		logger.Info("Deleting mirror ReferenceGrant (no HTTPRoutes mirror into namespace)",
+			"namespace", desired.Namespace, "name", desired.Name)
+		if err := c.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ReferenceGrant: %w", err)
+		}
+		return nil
+	}
+
+	if !exists {
+		logger.Info("Creating mirror ReferenceGrant", "namespace", desired.Namespace, "name", desired.Name)
+		if err := c.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create ReferenceGrant: %w", err)
+		}
+		return nil
+	}
+
+	existing.Spec = desired.Spec
+	existing.Annotations = desired.Annotations
+	logger.V(1).Info("Updating mirror ReferenceGrant", "namespace", desired.Namespace, "name", desired.Name)
+	if err := c.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update ReferenceGrant: %w", err)
+	}
+	return nil
+}
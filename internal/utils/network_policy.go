@@ -0,0 +1,255 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// GatewayPeer selects the Gateway data plane pods that NetworkPolicies admit traffic from
+type GatewayPeer struct {
+	// Namespace is the namespace the Gateway data plane runs in
+	Namespace string
+	// PodSelector selects the data plane pods, nil selects every pod of Namespace
+	PodSelector *metav1.LabelSelector
+}
+
+// AutomaticNetworkPolicyName returns a stable name for the NetworkPolicy of an Ingress backend Service
+func AutomaticNetworkPolicyName(ingressName, serviceName string) string {
+	return trimK8sName(fmt.Sprintf("automatic-%s-%s-gateway", ingressName, serviceName))
+}
+
+// SyncGatewayNetworkPolicies creates one NetworkPolicy per backend Service of an Ingress that admits
+// traffic from the Gateway pods to the ports the Ingress uses, and deletes the NetworkPolicies of the
+// Ingress that are not needed anymore. Services without a pod selector are skipped.
+func SyncGatewayNetworkPolicies(
+	ctx context.Context,
+	c client.Client,
+	scheme *runtime.Scheme,
+	owner client.Object,
+	ingress *networkingv1.Ingress,
+	peer GatewayPeer,
+) error {
+	logger := log.FromContext(ctx)
+
+	annotations := map[string]string{
+		ManagedByAnnotation: ManagedByValue,
+		SourceAnnotation:    fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name),
+	}
+	podSelector := peer.PodSelector
+	if podSelector == nil {
+		podSelector = &metav1.LabelSelector{}
+	}
+
+	desiredNames := make(map[string]struct{})
+	backendPorts := ingressBackendPorts(ingress)
+	for _, serviceName := range IngressBackendServiceNames(ingress) {
+		service := &corev1.Service{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: ingress.Namespace, Name: serviceName}, service)
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("Backend Service not found, skipping NetworkPolicy",
+				"namespace", ingress.Namespace, "service", serviceName)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		ports := networkPolicyPorts(service, backendPorts[serviceName])
+		if len(service.Spec.Selector) == 0 || len(ports) == 0 {
+			continue
+		}
+
+		policyName := AutomaticNetworkPolicyName(ingress.Name, serviceName)
+		desiredNames[policyName] = struct{}{}
+		desired := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        policyName,
+				Namespace:   ingress.Namespace,
+				Annotations: annotations,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: service.Spec.Selector},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+				Ingress: []networkingv1.NetworkPolicyIngressRule{
+					{
+						From: []networkingv1.NetworkPolicyPeer{
+							{
+								NamespaceSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{corev1.LabelMetadataName: peer.Namespace},
+								},
+								PodSelector: podSelector,
+							},
+						},
+						Ports: ports,
+					},
+				},
+			},
+		}
+		if scheme != nil && owner != nil {
+			if err := controllerutil.SetControllerReference(owner, desired, scheme); err != nil {
+				return err
+			}
+		}
+		if err := applyNetworkPolicy(ctx, c, desired); err != nil {
+			return err
+		}
+	}
+
+	return deleteStaleNetworkPolicies(ctx, c, ingress, desiredNames)
+}
+
+// DeleteGatewayNetworkPoliciesForIngress removes the NetworkPolicies created for an Ingress
+func DeleteGatewayNetworkPoliciesForIngress(ctx context.Context, c client.Client, ingress *networkingv1.Ingress) error {
+	return deleteStaleNetworkPolicies(ctx, c, ingress, nil)
+}
+
+// ingressBackendPorts returns the ports an Ingress uses per backend Service
+func ingressBackendPorts(ingress *networkingv1.Ingress) map[string][]networkingv1.ServiceBackendPort {
+	ports := make(map[string][]networkingv1.ServiceBackendPort)
+	add := func(backend *networkingv1.IngressBackend) {
+		if backend == nil || backend.Service == nil || backend.Service.Name == "" {
+			return
+		}
+		ports[backend.Service.Name] = append(ports[backend.Service.Name], backend.Service.Port)
+	}
+
+	add(ingress.Spec.DefaultBackend)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			add(&rule.HTTP.Paths[i].Backend)
+		}
+	}
+	return ports
+}
+
+// networkPolicyPorts maps the Service ports used by an Ingress to the pod ports they target, sorted
+// and without duplicates
+func networkPolicyPorts(
+	service *corev1.Service,
+	backendPorts []networkingv1.ServiceBackendPort,
+) []networkingv1.NetworkPolicyPort {
+	seen := make(map[string]networkingv1.NetworkPolicyPort)
+	for _, backendPort := range backendPorts {
+		for _, servicePort := range service.Spec.Ports {
+			if backendPort.Name != "" && servicePort.Name != backendPort.Name ||
+				backendPort.Name == "" && servicePort.Port != backendPort.Number {
+				continue
+			}
+			target := servicePort.TargetPort
+			if target.Type == intstr.Int && target.IntVal == 0 || target.Type == intstr.String && target.StrVal == "" {
+				target = intstr.FromInt32(servicePort.Port)
+			}
+			protocol := servicePort.Protocol
+			if protocol == "" {
+				protocol = corev1.ProtocolTCP
+			}
+			seen[string(protocol)+"/"+target.String()] = networkingv1.NetworkPolicyPort{
+				Protocol: &protocol,
+				Port:     &target,
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	ports := make([]networkingv1.NetworkPolicyPort, 0, len(keys))
+	for _, key := range keys {
+		ports = append(ports, seen[key])
+	}
+	return ports
+}
+
+func applyNetworkPolicy(ctx context.Context, c client.Client, desired *networkingv1.NetworkPolicy) error {
+	logger := log.FromContext(ctx)
+	existing := &networkingv1.NetworkPolicy{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing)
+	if apierrors.IsNotFound(err) {
+		logger.Info("Creating NetworkPolicy", "namespace", desired.Namespace, "name", desired.Name)
+		if err := c.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create NetworkPolicy %s/%s: %w", desired.Namespace, desired.Name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !IsManagedByUs(existing) {
+		logger.Info("NetworkPolicy exists but is not managed by us, skipping",
+			"namespace", desired.Namespace,
+			"name", desired.Name)
+		return nil
+	}
+	if equality.Semantic.DeepEqual(existing.Spec, desired.Spec) &&
+		equality.Semantic.DeepEqual(existing.Annotations, desired.Annotations) &&
+		equality.Semantic.DeepEqual(existing.OwnerReferences, desired.OwnerReferences) {
+		return nil
+	}
+	existing.Annotations = desired.Annotations
+	existing.OwnerReferences = desired.OwnerReferences
+	existing.Spec = desired.Spec
+	logger.V(1).Info("Updating NetworkPolicy", "namespace", desired.Namespace, "name", desired.Name)
+	if err := c.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update NetworkPolicy %s/%s: %w", desired.Namespace, desired.Name, err)
+	}
+	return nil
+}
+
+func deleteStaleNetworkPolicies(
+	ctx context.Context,
+	c client.Client,
+	ingress *networkingv1.Ingress,
+	keep map[string]struct{},
+) error {
+	var policies networkingv1.NetworkPolicyList
+	if err := c.List(ctx, &policies, client.InNamespace(ingress.Namespace)); err != nil {
+		return fmt.Errorf("failed to list NetworkPolicies: %w", err)
+	}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if _, ok := keep[policy.Name]; ok {
+			continue
+		}
+		if !IsManagedByUsForIngress(policy, ingress.Namespace, ingress.Name) {
+			continue
+		}
+		log.FromContext(ctx).Info("Deleting NetworkPolicy", "namespace", policy.Namespace, "name", policy.Name)
+		if err := c.Delete(ctx, policy); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete NetworkPolicy %s/%s: %w", policy.Namespace, policy.Name, err)
+		}
+	}
+	return nil
+}
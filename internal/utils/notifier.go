@@ -0,0 +1,238 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+)
+
+// NotificationEvent is a milestone a notification is sent for
+type NotificationEvent string
+
+const (
+	// NotificationNamespaceMigrated is sent when the last Ingress of a namespace was handed over
+	NotificationNamespaceMigrated NotificationEvent = "namespace-migrated"
+	// NotificationCertMismatch is sent when a Gateway listener serves a hostname with another certificate
+	// than one of its Ingresses asks for
+	NotificationCertMismatch NotificationEvent = "cert-mismatch"
+	// NotificationIngressDeleted is sent when the operator deleted a source Ingress
+	NotificationIngressDeleted NotificationEvent = "ingress-deleted"
+
+	// DefaultNotificationTemplate is a payload Slack incoming webhooks accept
+	DefaultNotificationTemplate = `{"text": {{ json .Text }}}`
+
+	notificationQueueSize = 256
+	notificationAttempts  = 3
+	notificationTimeout   = 10 * time.Second
+)
+
+// NotificationEvents lists every event type in the order they are documented
+var NotificationEvents = []NotificationEvent{
+	NotificationNamespaceMigrated,
+	NotificationCertMismatch,
+	NotificationIngressDeleted,
+}
+
+// Notification is the data the payload template is rendered with
+type Notification struct {
+	Event NotificationEvent
+	// Cluster is the fan-in source the object belongs to, empty for the local cluster
+	Cluster   string
+	Namespace string
+	// Name is the Ingress or Gateway the notification is about, empty for namespace-migrated
+	Name    string
+	Message string
+	Time    time.Time
+}
+
+// Text is a one-line summary of the notification for chat messages
+func (n Notification) Text() string {
+	object := n.Namespace
+	if n.Name != "" {
+		object += "/" + n.Name
+	}
+	if n.Cluster != "" {
+		object = n.Cluster + ":" + object
+	}
+	return fmt.Sprintf("[ingress-doperator] %s %s: %s", n.Event, object, n.Message)
+}
+
+// ParseNotificationEvents parses a comma-separated list of event types
+func ParseNotificationEvents(value string) ([]NotificationEvent, error) {
+	var events []NotificationEvent
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		event := NotificationEvent(entry)
+		if !slices.Contains(NotificationEvents, event) {
+			return nil, fmt.Errorf("unknown notification event %q (allowed: namespace-migrated, cert-mismatch, "+
+				"ingress-deleted)", entry)
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Notifier posts a templated JSON payload to a webhook (e.g. a Slack incoming webhook) for selected events.
+// Notifications are sent in the background so reconciles never wait for the webhook. It runs as a manager
+// Runnable and, like the controllers, only on the leader.
+type Notifier struct {
+	url      string
+	events   []NotificationEvent
+	template *template.Template
+	client   *http.Client
+	queue    chan Notification
+}
+
+// NewNotifier creates a notifier posting to url for events, payloadTemplate is a text/template with a json
+// function rendering a Notification. httpClient sends the notifications, nil uses a plain client
+func NewNotifier(
+	url string,
+	events []NotificationEvent,
+	payloadTemplate string,
+	httpClient *http.Client,
+) (*Notifier, error) {
+	tmpl, err := template.New("notification").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(payloadTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification payload template: %w", err)
+	}
+	// Catch templates that can't produce JSON before the first milestone
+	if _, err := renderNotification(tmpl, Notification{Event: NotificationIngressDeleted, Time: time.Now()}); err != nil {
+		return nil, err
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: notificationTimeout}
+	}
+	return &Notifier{
+		url:      url,
+		events:   events,
+		template: tmpl,
+		client:   httpClient,
+		queue:    make(chan Notification, notificationQueueSize),
+	}, nil
+}
+
+// Notify queues a notification if its event type is enabled. It never blocks; when the webhook falls
+// behind, notifications are dropped and logged.
+func (n *Notifier) Notify(ctx context.Context, notification Notification) {
+	if n == nil || !slices.Contains(n.events, notification.Event) {
+		return
+	}
+	if notification.Time.IsZero() {
+		notification.Time = time.Now()
+	}
+	select {
+	case n.queue <- notification:
+	default:
+		log.FromContext(ctx).Info("Notification queue full, dropping notification",
+			"event", notification.Event, "namespace", notification.Namespace, "name", notification.Name)
+		metrics.NotificationsTotal.WithLabelValues(string(notification.Event), "dropped").Inc()
+	}
+}
+
+// NeedLeaderElection keeps followers from sending the notifications a second time
+func (n *Notifier) NeedLeaderElection() bool {
+	return true
+}
+
+// Start sends queued notifications until ctx is done
+func (n *Notifier) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("notifier")
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-n.queue:
+			result := "sent"
+			if err := n.send(ctx, notification); err != nil {
+				logger.Error(err, "failed to send notification",
+					"event", notification.Event, "namespace", notification.Namespace, "name", notification.Name)
+				result = "failed"
+			}
+			metrics.NotificationsTotal.WithLabelValues(string(notification.Event), result).Inc()
+		}
+	}
+}
+
+// send posts the notification, retrying server errors and unreachable webhooks
+func (n *Notifier) send(ctx context.Context, notification Notification) error {
+	payload, err := renderNotification(n.template, notification)
+	if err != nil {
+		return err
+	}
+	var lastErr error
+	for attempt := range notificationAttempts {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := n.client.Do(req)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		_ = resp.Body.Close()
+		switch {
+		case resp.StatusCode < 300:
+			return nil
+		case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+			lastErr = fmt.Errorf("notification webhook returned %s", resp.Status)
+		default:
+			return fmt.Errorf("notification webhook returned %s", resp.Status)
+		}
+	}
+	return lastErr
+}
+
+// renderNotification renders the payload and checks that it is JSON
+func renderNotification(tmpl *template.Template, notification Notification) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, notification); err != nil {
+		return nil, fmt.Errorf("failed to render notification payload: %w", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("notification payload template does not render JSON: %s", buf.String())
+	}
+	return buf.Bytes(), nil
+}
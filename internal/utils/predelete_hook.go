@@ -0,0 +1,191 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// PreDeleteHookAnnotation names a ConfigMap ([namespace/]name) holding a Job template that has to
+	// complete successfully before ingress-doperator deletes the Ingress
+	PreDeleteHookAnnotation = "ingress-doperator.fiction.si/pre-delete-hook"
+	// PreDeleteHookJobKey is the ConfigMap key holding the Job manifest
+	PreDeleteHookJobKey = "job.yaml"
+
+	preDeleteHookJobSuffix      = "-pre-delete"
+	preDeleteHookDefaultJobTTL  = int32(3600)
+	preDeleteHookPollInterval   = 2 * time.Second
+	preDeleteHookIngressNameEnv = "INGRESS_NAME"
+	preDeleteHookIngressNSEnv   = "INGRESS_NAMESPACE"
+)
+
+// ErrPreDeleteHookPending is returned while the pre-delete hook Job is still running
+var ErrPreDeleteHookPending = errors.New("pre-delete hook Job has not completed yet")
+
+// PreDeleteHookJobName returns the name of the hook Job for an Ingress
+func PreDeleteHookJobName(ingress *networkingv1.Ingress) string {
+	uid := string(ingress.UID)
+	if len(uid) > 8 {
+		uid = uid[:8]
+	}
+	suffix := preDeleteHookJobSuffix + "-" + uid
+	// Job names end up in Pod labels, which are limited to 63 characters
+	name := ingress.Name
+	if len(name)+len(suffix) > 63 {
+		name = strings.TrimRight(name[:63-len(suffix)], "-.")
+	}
+	return name + suffix
+}
+
+// RunPreDeleteHook creates the hook Job for the Ingress (once) and reports its outcome.
+// It returns nil when the Ingress has no hook or the Job succeeded, ErrPreDeleteHookPending while the
+// Job runs and an error when the Job failed or could not be created. Delete the failed Job to retry.
+func RunPreDeleteHook(ctx context.Context, c client.Client, ingress *networkingv1.Ingress) error {
+	ref, ok := ingress.Annotations[PreDeleteHookAnnotation]
+	if !ok || ref == "" {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+
+	jobName := PreDeleteHookJobName(ingress)
+	job := &batchv1.Job{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: ingress.Namespace, Name: jobName}, job)
+	if apierrors.IsNotFound(err) {
+		job, err = buildPreDeleteHookJob(ctx, c, ingress, ref, jobName)
+		if err != nil {
+			return err
+		}
+		logger.Info("Creating pre-delete hook Job", "namespace", job.Namespace, "name", job.Name, "template", ref)
+		if err := c.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create pre-delete hook Job: %w", err)
+		}
+		return ErrPreDeleteHookPending
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get pre-delete hook Job: %w", err)
+	}
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return nil
+		case batchv1.JobFailed:
+			return fmt.Errorf("pre-delete hook Job %s/%s failed: %s", job.Namespace, job.Name, condition.Message)
+		}
+	}
+	return ErrPreDeleteHookPending
+}
+
+// WaitForPreDeleteHook runs the pre-delete hook and polls until the Job finishes or timeout expires
+func WaitForPreDeleteHook(
+	ctx context.Context,
+	c client.Client,
+	ingress *networkingv1.Ingress,
+	timeout time.Duration,
+) error {
+	var hookErr error
+	err := wait.PollUntilContextTimeout(ctx, preDeleteHookPollInterval, timeout, true,
+		func(ctx context.Context) (bool, error) {
+			hookErr = RunPreDeleteHook(ctx, c, ingress)
+			if errors.Is(hookErr, ErrPreDeleteHookPending) {
+				return false, nil
+			}
+			return true, hookErr
+		})
+	if err != nil && errors.Is(hookErr, ErrPreDeleteHookPending) {
+		return fmt.Errorf("timed out after %s waiting for pre-delete hook Job %s/%s",
+			timeout, ingress.Namespace, PreDeleteHookJobName(ingress))
+	}
+	return err
+}
+
+func buildPreDeleteHookJob(
+	ctx context.Context,
+	c client.Client,
+	ingress *networkingv1.Ingress,
+	ref string,
+	jobName string,
+) (*batchv1.Job, error) {
+	key, err := ParseConfigMapReference(ref, ingress.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, key, cm); err != nil {
+		return nil, fmt.Errorf("failed to get pre-delete hook ConfigMap %s: %w", key.String(), err)
+	}
+	manifest, ok := cm.Data[PreDeleteHookJobKey]
+	if !ok {
+		return nil, fmt.Errorf("pre-delete hook ConfigMap %s has no %s key", key.String(), PreDeleteHookJobKey)
+	}
+
+	job := &batchv1.Job{}
+	if err := yaml.UnmarshalStrict([]byte(manifest), job); err != nil {
+		return nil, fmt.Errorf("invalid Job template in ConfigMap %s: %w", key.String(), err)
+	}
+	if job.Kind != "" && job.Kind != "Job" {
+		return nil, fmt.Errorf("template in ConfigMap %s is a %s, not a Job", key.String(), job.Kind)
+	}
+
+	// The template only contributes the spec and labels, identity is ours
+	job.ObjectMeta.Name = jobName
+	job.ObjectMeta.Namespace = ingress.Namespace
+	job.ObjectMeta.GenerateName = ""
+	job.ObjectMeta.ResourceVersion = ""
+	job.ObjectMeta.OwnerReferences = nil
+	job.Status = batchv1.JobStatus{}
+	if job.Annotations == nil {
+		job.Annotations = map[string]string{}
+	}
+	job.Annotations[ManagedByAnnotation] = ManagedByValue
+	job.Annotations[SourceAnnotation] = fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name)
+	if job.Spec.TTLSecondsAfterFinished == nil {
+		job.Spec.TTLSecondsAfterFinished = ptr.To(preDeleteHookDefaultJobTTL)
+	}
+	if job.Spec.Template.Spec.RestartPolicy == "" {
+		job.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	// Tell the hook which Ingress is going away
+	env := []corev1.EnvVar{
+		{Name: preDeleteHookIngressNameEnv, Value: ingress.Name},
+		{Name: preDeleteHookIngressNSEnv, Value: ingress.Namespace},
+	}
+	for i := range job.Spec.Template.Spec.Containers {
+		job.Spec.Template.Spec.Containers[i].Env = append(job.Spec.Template.Spec.Containers[i].Env, env...)
+	}
+	return job, nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PermissionRequirement is a set of verbs on a resource the operator needs for a feature
+type PermissionRequirement struct {
+	Group    string
+	Resource string
+	// Namespace is empty for cluster-scoped resources and for all namespaces
+	Namespace string
+	Verbs     []string
+	// Feature names what needs the permission, for the report
+	Feature string
+}
+
+// MissingPermission is a verb the operator is not allowed
+type MissingPermission struct {
+	Requirement PermissionRequirement
+	Verb        string
+}
+
+func (m MissingPermission) String() string {
+	resource := m.Requirement.Resource
+	if m.Requirement.Group != "" {
+		resource += "." + m.Requirement.Group
+	}
+	scope := "cluster-wide"
+	if m.Requirement.Namespace != "" {
+		scope = "in namespace " + m.Requirement.Namespace
+	}
+	return fmt.Sprintf("%s %s %s (%s)", m.Verb, resource, scope, m.Requirement.Feature)
+}
+
+// FormatMissingPermissions lists missing permissions one per line
+func FormatMissingPermissions(missing []MissingPermission) string {
+	lines := make([]string, 0, len(missing))
+	for _, permission := range missing {
+		lines = append(lines, "  - "+permission.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CheckPermissions asks the API server with a SelfSubjectAccessReview for every verb of the requirements and
+// returns the ones that are not allowed, in order
+func CheckPermissions(
+	ctx context.Context,
+	c client.Client,
+	requirements []PermissionRequirement,
+) ([]MissingPermission, error) {
+	var missing []MissingPermission
+	for _, requirement := range requirements {
+		for _, verb := range requirement.Verbs {
+			review := &authorizationv1.SelfSubjectAccessReview{
+				Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+					ResourceAttributes: &authorizationv1.ResourceAttributes{
+						Namespace: requirement.Namespace,
+						Verb:      verb,
+						Group:     requirement.Group,
+						Resource:  requirement.Resource,
+					},
+				},
+			}
+			if err := c.Create(ctx, review); err != nil {
+				return nil, fmt.Errorf("failed to review access to %s %s: %w", verb, requirement.Resource, err)
+			}
+			if !review.Status.Allowed {
+				missing = append(missing, MissingPermission{Requirement: requirement, Verb: verb})
+			}
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+)
+
+// NewReadOnlyEventRecorder returns an event recorder that logs events instead of creating them, for
+// --read-only where the operator may not be allowed to create Events
+func NewReadOnlyEventRecorder(logger logr.Logger) events.EventRecorder {
+	return &readOnlyEventRecorder{logger: logger}
+}
+
+type readOnlyEventRecorder struct {
+	logger logr.Logger
+}
+
+func (r *readOnlyEventRecorder) Eventf(
+	regarding runtime.Object,
+	_ runtime.Object,
+	eventtype, reason, action, note string,
+	args ...interface{},
+) {
+	object := ""
+	if accessor, err := meta.Accessor(regarding); err == nil {
+		object = accessor.GetNamespace() + "/" + accessor.GetName()
+	}
+	message := note
+	if len(args) > 0 {
+		message = fmt.Sprintf(note, args...)
+	}
+	r.logger.Info("Read only, event not recorded",
+		"object", object,
+		"type", eventtype,
+		"reason", reason,
+		"action", action,
+		"message", message)
+}
@@ -19,20 +19,28 @@ package utils
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
 	ReconcileCacheConfigMapBaseName = "ingress-doperator-reconcile-cache"
 	ReconcileCacheShardCount        = 16
 	ReconcileCacheTTL               = 24 * time.Hour
+
+	// ReconcileCacheEmptySinceAnnotation records when a shard ConfigMap became empty
+	ReconcileCacheEmptySinceAnnotation = "ingress-doperator.fiction.si/empty-since"
+
+	reconcileCachePruneInterval = 10 * time.Minute
 )
 
 type ReconcileCacheEntry struct {
@@ -40,6 +48,167 @@ type ReconcileCacheEntry struct {
 	UpdatedAtUnix   int64
 }
 
+// ReconcileCache remembers the ResourceVersion an Ingress was last successfully reconciled at
+type ReconcileCache interface {
+	// Get returns the entry for key unless it is missing or older than ReconcileCacheTTL
+	Get(ctx context.Context, key string) (ReconcileCacheEntry, bool)
+	// Record stores the entry for key
+	Record(ctx context.Context, key string, entry ReconcileCacheEntry)
+	// Evict removes the entry for key
+	Evict(ctx context.Context, key string)
+}
+
+// ConfigMapReconcileCache keeps entries in memory and optionally persists them to sharded ConfigMaps
+type ConfigMapReconcileCache struct {
+	client     client.Client
+	namespace  string
+	baseName   string
+	shards     int
+	persist    bool
+	maxEntries int
+	// emptyShardTTL is how long a shard ConfigMap may stay empty before it is deleted (0 = never)
+	emptyShardTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]ReconcileCacheEntry
+}
+
+// NewConfigMapReconcileCache creates a ConfigMap backed cache seeded with entries (may be nil).
+// Persistence is skipped when persist is false or namespace/baseName are empty; maxEntries <= 0
+// means unlimited. Shard ConfigMaps empty for longer than emptyShardTTL are deleted (0 = never).
+func NewConfigMapReconcileCache(
+	c client.Client,
+	namespace string,
+	baseName string,
+	shards int,
+	persist bool,
+	maxEntries int,
+	emptyShardTTL time.Duration,
+	entries map[string]ReconcileCacheEntry,
+) *ConfigMapReconcileCache {
+	if entries == nil {
+		entries = make(map[string]ReconcileCacheEntry)
+	}
+	return &ConfigMapReconcileCache{
+		client:     c,
+		namespace:  namespace,
+		baseName:   baseName,
+		shards:     shards,
+		persist:    persist && namespace != "" && baseName != "",
+		maxEntries: maxEntries,
+		entries:    entries,
+
+		emptyShardTTL: emptyShardTTL,
+	}
+}
+
+func (c *ConfigMapReconcileCache) Get(_ context.Context, key string) (ReconcileCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return ReconcileCacheEntry{}, false
+	}
+	if time.Since(time.Unix(entry.UpdatedAtUnix, 0)) > ReconcileCacheTTL {
+		delete(c.entries, key)
+		return ReconcileCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *ConfigMapReconcileCache) Record(ctx context.Context, key string, entry ReconcileCacheEntry) {
+	c.mu.Lock()
+	c.entries[key] = entry
+	if c.maxEntries > 0 && len(c.entries) > c.maxEntries {
+		c.evictOldestLocked()
+	}
+	snapshot := c.snapshotLocked()
+	c.mu.Unlock()
+
+	c.save(ctx, snapshot, "failed to persist reconcile cache")
+}
+
+func (c *ConfigMapReconcileCache) Evict(ctx context.Context, key string) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	snapshot := c.snapshotLocked()
+	c.mu.Unlock()
+
+	c.save(ctx, snapshot, "failed to persist reconcile cache eviction")
+}
+
+// Prune drops entries older than ReconcileCacheTTL and persists the result, which also deletes
+// shard ConfigMaps that stayed empty for longer than the configured period
+func (c *ConfigMapReconcileCache) Prune(ctx context.Context) {
+	c.mu.Lock()
+	cutoff := time.Now().Add(-ReconcileCacheTTL).Unix()
+	for key, entry := range c.entries {
+		if entry.UpdatedAtUnix < cutoff {
+			delete(c.entries, key)
+		}
+	}
+	snapshot := c.snapshotLocked()
+	c.mu.Unlock()
+
+	c.save(ctx, snapshot, "failed to persist pruned reconcile cache")
+}
+
+// Start prunes the cache periodically until ctx is done; it implements manager.Runnable so that
+// only the leader rewrites the shard ConfigMaps
+func (c *ConfigMapReconcileCache) Start(ctx context.Context) error {
+	if !c.persist {
+		return nil
+	}
+	ticker := time.NewTicker(reconcileCachePruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			c.Prune(ctx)
+		}
+	}
+}
+
+func (c *ConfigMapReconcileCache) snapshotLocked() map[string]ReconcileCacheEntry {
+	snapshot := make(map[string]ReconcileCacheEntry, len(c.entries))
+	for k, v := range c.entries {
+		snapshot[k] = v
+	}
+	return snapshot
+}
+
+func (c *ConfigMapReconcileCache) evictOldestLocked() {
+	type entry struct {
+		key string
+		ts  int64
+	}
+	entries := make([]entry, 0, len(c.entries))
+	for key, value := range c.entries {
+		entries = append(entries, entry{key: key, ts: value.UpdatedAtUnix})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ts < entries[j].ts
+	})
+	for len(c.entries) > c.maxEntries && len(entries) > 0 {
+		delete(c.entries, entries[0].key)
+		entries = entries[1:]
+	}
+}
+
+func (c *ConfigMapReconcileCache) save(ctx context.Context, snapshot map[string]ReconcileCacheEntry, message string) {
+	if !c.persist {
+		return
+	}
+	// Persisting must not be aborted by the reconcile context being cancelled
+	ctx = context.WithoutCancel(ctx)
+	err := SaveReconcileCacheSharded(ctx, c.client, c.namespace, c.baseName, c.shards, c.emptyShardTTL, snapshot)
+	if err != nil {
+		log.FromContext(ctx).Error(err, message)
+	}
+}
+
 func LoadReconcileCacheSharded(
 	ctx context.Context,
 	reader client.Reader,
@@ -84,6 +253,7 @@ func SaveReconcileCacheSharded(
 	namespace string,
 	baseName string,
 	shardCount int,
+	emptyShardTTL time.Duration,
 	data map[string]ReconcileCacheEntry,
 ) error {
 	if shardCount <= 0 {
@@ -109,6 +279,10 @@ func SaveReconcileCacheSharded(
 		err := c.Get(ctx, key, cm)
 		if err != nil {
 			if apierrors.IsNotFound(err) {
+				if len(shardData) == 0 {
+					// Nothing to keep, don't create empty shards
+					continue
+				}
 				newCM := &corev1.ConfigMap{
 					ObjectMeta: metav1.ObjectMeta{
 						Name:      name,
@@ -124,6 +298,13 @@ func SaveReconcileCacheSharded(
 			return err
 		}
 
+		if len(shardData) == 0 && emptyShardExpired(cm, emptyShardTTL) {
+			if err := c.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete empty reconcile cache configmap: %w", err)
+			}
+			continue
+		}
+		markEmptyShard(cm, len(shardData) == 0)
 		cm.Data = copyStringMap(shardData)
 		if err := c.Update(ctx, cm); err != nil {
 			return fmt.Errorf("failed to update reconcile cache configmap: %w", err)
@@ -132,6 +313,34 @@ func SaveReconcileCacheSharded(
 	return nil
 }
 
+// emptyShardExpired reports whether an empty shard ConfigMap was marked empty more than ttl ago
+func emptyShardExpired(cm *corev1.ConfigMap, ttl time.Duration) bool {
+	if ttl <= 0 {
+		return false
+	}
+	since, err := time.Parse(time.RFC3339, cm.Annotations[ReconcileCacheEmptySinceAnnotation])
+	if err != nil {
+		return false
+	}
+	return time.Since(since) >= ttl
+}
+
+// markEmptyShard sets ReconcileCacheEmptySinceAnnotation on shards that became empty and removes it
+// from shards holding entries again
+func markEmptyShard(cm *corev1.ConfigMap, empty bool) {
+	_, err := time.Parse(time.RFC3339, cm.Annotations[ReconcileCacheEmptySinceAnnotation])
+	marked := err == nil
+	switch {
+	case empty && !marked:
+		if cm.Annotations == nil {
+			cm.Annotations = make(map[string]string)
+		}
+		cm.Annotations[ReconcileCacheEmptySinceAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	case !empty:
+		delete(cm.Annotations, ReconcileCacheEmptySinceAnnotation)
+	}
+}
+
 func reconcileCacheShardForKey(key string, shardCount int) int {
 	if shardCount <= 1 {
 		return 0
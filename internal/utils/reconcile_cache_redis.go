@@ -0,0 +1,322 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// DefaultRedisReconcileCacheKeyPrefix namespaces reconcile cache keys in a shared Redis
+	DefaultRedisReconcileCacheKeyPrefix = "ingress-doperator:reconcile-cache:"
+
+	redisDefaultPort       = "6379"
+	redisOperationTimeout  = time.Second
+	redisDegradedRetryWait = 30 * time.Second
+	// redisMaxBulkLength is the largest string Redis itself accepts (proto-max-bulk-len)
+	redisMaxBulkLength = 512 << 20
+)
+
+// RedisReconcileCache stores reconcile cache entries in Redis so that every replica and shard
+// shares them. Entries expire after ReconcileCacheTTL. While Redis is unreachable the cache
+// degrades to the fallback (usually a ConfigMapReconcileCache) and retries Redis periodically.
+type RedisReconcileCache struct {
+	keyPrefix string
+	fallback  ReconcileCache
+	conn      *redisConn
+
+	mu            sync.Mutex
+	degradedUntil time.Time
+}
+
+// NewRedisReconcileCache creates a Redis backed cache for a redis:// or rediss:// URL
+// (redis://[user:password@]host[:port][/db]). The connection is established lazily.
+func NewRedisReconcileCache(rawURL, keyPrefix string, fallback ReconcileCache) (*RedisReconcileCache, error) {
+	conn, err := newRedisConn(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if keyPrefix == "" {
+		keyPrefix = DefaultRedisReconcileCacheKeyPrefix
+	}
+	return &RedisReconcileCache{
+		keyPrefix: keyPrefix,
+		fallback:  fallback,
+		conn:      conn,
+	}, nil
+}
+
+func (c *RedisReconcileCache) Get(ctx context.Context, key string) (ReconcileCacheEntry, bool) {
+	if c.degraded() {
+		return c.fallbackGet(ctx, key)
+	}
+	reply, err := c.conn.do(ctx, "GET", c.keyPrefix+key)
+	if err != nil {
+		c.degrade(ctx, err)
+		return c.fallbackGet(ctx, key)
+	}
+	raw, ok := reply.(string)
+	if !ok {
+		return ReconcileCacheEntry{}, false
+	}
+	entry, ok := parseReconcileCacheEntry(raw)
+	if !ok || time.Since(time.Unix(entry.UpdatedAtUnix, 0)) > ReconcileCacheTTL {
+		return ReconcileCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *RedisReconcileCache) Record(ctx context.Context, key string, entry ReconcileCacheEntry) {
+	if c.degraded() {
+		c.fallbackRecord(ctx, key, entry)
+		return
+	}
+	ttl := strconv.FormatInt(ReconcileCacheTTL.Milliseconds(), 10)
+	if _, err := c.conn.do(ctx, "SET", c.keyPrefix+key, formatReconcileCacheEntry(entry), "PX", ttl); err != nil {
+		c.degrade(ctx, err)
+		c.fallbackRecord(ctx, key, entry)
+	}
+}
+
+func (c *RedisReconcileCache) Evict(ctx context.Context, key string) {
+	// Always evict from the fallback too, it may hold entries recorded while degraded
+	if c.fallback != nil {
+		c.fallback.Evict(ctx, key)
+	}
+	if c.degraded() {
+		return
+	}
+	if _, err := c.conn.do(ctx, "DEL", c.keyPrefix+key); err != nil {
+		c.degrade(ctx, err)
+	}
+}
+
+func (c *RedisReconcileCache) degraded() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return time.Now().Before(c.degradedUntil)
+}
+
+func (c *RedisReconcileCache) degrade(ctx context.Context, err error) {
+	c.mu.Lock()
+	c.degradedUntil = time.Now().Add(redisDegradedRetryWait)
+	c.mu.Unlock()
+	log.FromContext(ctx).Error(err, "Redis reconcile cache unavailable, falling back to ConfigMaps",
+		"retryAfter", redisDegradedRetryWait)
+}
+
+func (c *RedisReconcileCache) fallbackGet(ctx context.Context, key string) (ReconcileCacheEntry, bool) {
+	if c.fallback == nil {
+		return ReconcileCacheEntry{}, false
+	}
+	return c.fallback.Get(ctx, key)
+}
+
+func (c *RedisReconcileCache) fallbackRecord(ctx context.Context, key string, entry ReconcileCacheEntry) {
+	if c.fallback != nil {
+		c.fallback.Record(ctx, key, entry)
+	}
+}
+
+// redisConn is a minimal RESP client holding a single connection, enough for GET/SET/DEL
+type redisConn struct {
+	address  string
+	useTLS   bool
+	username string
+	password string
+	db       int
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func newRedisConn(rawURL string) (*redisConn, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	rc := &redisConn{}
+	switch parsed.Scheme {
+	case "redis":
+	case "rediss":
+		rc.useTLS = true
+	default:
+		return nil, fmt.Errorf("invalid Redis URL scheme %q (expected redis or rediss)", parsed.Scheme)
+	}
+	if parsed.Hostname() == "" {
+		return nil, fmt.Errorf("redis URL has no host")
+	}
+	port := parsed.Port()
+	if port == "" {
+		port = redisDefaultPort
+	}
+	rc.address = net.JoinHostPort(parsed.Hostname(), port)
+	if parsed.User != nil {
+		rc.username = parsed.User.Username()
+		rc.password, _ = parsed.User.Password()
+	}
+	if db := strings.Trim(parsed.Path, "/"); db != "" {
+		rc.db, err = strconv.Atoi(db)
+		if err != nil || rc.db < 0 {
+			return nil, fmt.Errorf("invalid Redis database %q", db)
+		}
+	}
+	return rc, nil
+}
+
+// do sends a command and returns the reply: string, int64, nil (for a nil bulk string)
+func (rc *redisConn) do(ctx context.Context, args ...string) (interface{}, error) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.conn == nil {
+		if err := rc.connectLocked(ctx); err != nil {
+			return nil, err
+		}
+	}
+	reply, err := rc.roundTripLocked(ctx, args)
+	if err != nil {
+		var redisErr redisError
+		if !errors.As(err, &redisErr) {
+			// Connection state is unknown after I/O errors, reconnect next time
+			rc.closeLocked()
+		}
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (rc *redisConn) connectLocked(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: redisOperationTimeout}
+	var conn net.Conn
+	var err error
+	if rc.useTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{MinVersion: tls.VersionTLS12}}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", rc.address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", rc.address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to Redis at %s: %w", rc.address, err)
+	}
+	rc.conn = conn
+	rc.reader = bufio.NewReader(conn)
+
+	if rc.password != "" {
+		auth := []string{"AUTH", rc.password}
+		if rc.username != "" {
+			auth = []string{"AUTH", rc.username, rc.password}
+		}
+		if _, err := rc.roundTripLocked(ctx, auth); err != nil {
+			rc.closeLocked()
+			return fmt.Errorf("redis AUTH failed: %w", err)
+		}
+	}
+	if rc.db != 0 {
+		if _, err := rc.roundTripLocked(ctx, []string{"SELECT", strconv.Itoa(rc.db)}); err != nil {
+			rc.closeLocked()
+			return fmt.Errorf("redis SELECT %d failed: %w", rc.db, err)
+		}
+	}
+	return nil
+}
+
+func (rc *redisConn) closeLocked() {
+	if rc.conn != nil {
+		_ = rc.conn.Close()
+	}
+	rc.conn = nil
+	rc.reader = nil
+}
+
+func (rc *redisConn) roundTripLocked(ctx context.Context, args []string) (interface{}, error) {
+	deadline := time.Now().Add(redisOperationTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := rc.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(rc.conn, b.String()); err != nil {
+		return nil, err
+	}
+	return readRedisReply(rc.reader)
+}
+
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+func readRedisReply(reader *bufio.Reader) (interface{}, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("empty Redis reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < -1 || size > redisMaxBulkLength {
+			return nil, fmt.Errorf("invalid Redis bulk length %q", line[1:])
+		}
+		if size == -1 {
+			return nil, nil
+		}
+		buf := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, buf); err != nil {
+			return nil, err
+		}
+		if string(buf[size:]) != "\r\n" {
+			return nil, fmt.Errorf("redis bulk reply of length %d is not terminated by CRLF", size)
+		}
+		return string(buf[:size]), nil
+	default:
+		return nil, fmt.Errorf("unsupported Redis reply type %q", line[0])
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// RuleOriginsAnnotation lists on an HTTPRoute which Ingress path every rule was translated from, as JSON
+const RuleOriginsAnnotation = "ingress-doperator.fiction.si/rule-origins"
+
+// RuleOrigin is an Ingress path an HTTPRoute rule was translated from
+type RuleOrigin struct {
+	// Rule is the index of the rule in spec.rules of the HTTPRoute
+	Rule int `json:"rule"`
+	// IngressRule and IngressPath index spec.rules[].http.paths[] of the source Ingress
+	IngressRule int    `json:"ingressRule"`
+	IngressPath int    `json:"ingressPath"`
+	Host        string `json:"host,omitempty"`
+	Path        string `json:"path,omitempty"`
+	PathType    string `json:"pathType,omitempty"`
+}
+
+// SetRuleOrigins records in the rule-origins annotation of route which path of ingress each of its rules
+// comes from. A rule matches a path with the same value and a compatible type that routes to one of its
+// backends; rules the Ingress has no path for (redirects, default backends) are left out.
+func SetRuleOrigins(ingress *networkingv1.Ingress, route *gatewayv1.HTTPRoute) {
+	origins := ingressRuleOrigins(ingress, route)
+	if len(origins) == 0 {
+		delete(route.Annotations, RuleOriginsAnnotation)
+		return
+	}
+	data, err := json.Marshal(origins)
+	if err != nil {
+		return
+	}
+	if route.Annotations == nil {
+		route.Annotations = make(map[string]string)
+	}
+	route.Annotations[RuleOriginsAnnotation] = string(data)
+}
+
+// RuleOrigins returns the origins recorded on route, nil when it has none
+func RuleOrigins(route *gatewayv1.HTTPRoute) ([]RuleOrigin, error) {
+	raw, ok := route.Annotations[RuleOriginsAnnotation]
+	if !ok || raw == "" {
+		return nil, nil
+	}
+	var origins []RuleOrigin
+	if err := json.Unmarshal([]byte(raw), &origins); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation on HTTPRoute %s/%s: %w",
+			RuleOriginsAnnotation, route.Namespace, route.Name, err)
+	}
+	return origins, nil
+}
+
+// IngressPathsForRule returns the Ingress paths rule (an index into spec.rules) of route was translated
+// from, e.g. to find out which Ingress path a request answered with 404 was meant for
+func IngressPathsForRule(route *gatewayv1.HTTPRoute, rule int) ([]RuleOrigin, error) {
+	origins, err := RuleOrigins(route)
+	if err != nil {
+		return nil, err
+	}
+	var matching []RuleOrigin
+	for _, origin := range origins {
+		if origin.Rule == rule {
+			matching = append(matching, origin)
+		}
+	}
+	return matching, nil
+}
+
+func ingressRuleOrigins(ingress *networkingv1.Ingress, route *gatewayv1.HTTPRoute) []RuleOrigin {
+	var origins []RuleOrigin
+	for k, routeRule := range route.Spec.Rules {
+		for i, ingressRule := range ingress.Spec.Rules {
+			if ingressRule.HTTP == nil {
+				continue
+			}
+			for j, path := range ingressRule.HTTP.Paths {
+				if !ruleMatchesIngressPath(routeRule, path) {
+					continue
+				}
+				origin := RuleOrigin{
+					Rule:        k,
+					IngressRule: i,
+					IngressPath: j,
+					Host:        ingressRule.Host,
+					Path:        path.Path,
+				}
+				if path.PathType != nil {
+					origin.PathType = string(*path.PathType)
+				}
+				origins = append(origins, origin)
+			}
+		}
+	}
+	return origins
+}
+
+// ruleMatchesIngressPath reports whether rule can be the translation of path
+func ruleMatchesIngressPath(rule gatewayv1.HTTPRouteRule, path networkingv1.HTTPIngressPath) bool {
+	if path.Backend.Service != nil && len(rule.BackendRefs) > 0 {
+		found := false
+		for _, ref := range rule.BackendRefs {
+			if string(ref.Name) == path.Backend.Service.Name && (ref.Kind == nil || *ref.Kind == "Service") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if path.Path == "" {
+		return len(rule.Matches) == 0
+	}
+	for _, match := range rule.Matches {
+		if match.Path == nil || match.Path.Value == nil || *match.Path.Value != path.Path {
+			continue
+		}
+		exact := match.Path.Type != nil && *match.Path.Type == gatewayv1.PathMatchExact
+		if exact == (path.PathType != nil && *path.PathType == networkingv1.PathTypeExact) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ngfSnippetContexts maps the SnippetsFilter contexts NGF accepts to the nginx block they end up in
+var ngfSnippetContexts = map[string]string{
+	"main":                 "main",
+	"http":                 "http",
+	"http.server":          "server",
+	"http.server.location": "location",
+}
+
+// forbiddenSnippetDirectives are rejected by NGF or cannot work in its data plane, in any context
+var forbiddenSnippetDirectives = map[string]string{
+	"load_module":      "NGF does not load dynamic modules",
+	"include":          "files outside the generated configuration are not available",
+	"ssl_engine":       "hardware SSL engines are not available",
+	"lua_package_path": "the Lua module is not available",
+	"perl_modules":     "the Perl module is not available",
+	"perl_require":     "the Perl module is not available",
+}
+
+// snippetDirectiveContexts lists the nginx blocks a directive may appear in, for directives that are only
+// valid in some of them. Directives not listed are left to nginx to validate.
+var snippetDirectiveContexts = map[string][]string{
+	"user":                 {"main"},
+	"pid":                  {"main"},
+	"daemon":               {"main"},
+	"master_process":       {"main"},
+	"env":                  {"main"},
+	"worker_processes":     {"main"},
+	"worker_rlimit_nofile": {"main"},
+	"worker_cpu_affinity":  {"main"},
+	"worker_priority":      {"main"},
+	"working_directory":    {"main"},
+	"thread_pool":          {"main"},
+	"pcre_jit":             {"main"},
+	"lock_file":            {"main"},
+	"timer_resolution":     {"main"},
+	"events":               {"main"},
+	"http":                 {"main"},
+	"upstream":             {"http"},
+	"map":                  {"http"},
+	"geo":                  {"http"},
+	"split_clients":        {"http"},
+	"log_format":           {"http"},
+	"limit_req_zone":       {"http"},
+	"limit_conn_zone":      {"http"},
+	"proxy_cache_path":     {"http"},
+	"server":               {"http"},
+	"listen":               {"server"},
+	"server_name":          {"server"},
+	"location":             {"server", "location"},
+	"rewrite":              {"server", "location", "if"},
+	"return":               {"server", "location", "if"},
+	"set":                  {"server", "location", "if"},
+	"if":                   {"server", "location"},
+	"alias":                {"location"},
+	"internal":             {"location"},
+	"limit_except":         {"location"},
+	"proxy_pass":           {"location", "if", "limit_except"},
+}
+
+// SnippetRejection is a snippet statement dropped before it reached a SnippetsFilter
+type SnippetRejection struct {
+	Context   string
+	Directive string
+	Reason    string
+}
+
+func (r SnippetRejection) String() string {
+	if r.Directive == "" {
+		return fmt.Sprintf("%s snippet: %s", r.Context, r.Reason)
+	}
+	return fmt.Sprintf("%s in %s: %s", r.Directive, r.Context, r.Reason)
+}
+
+// SanitizeSnippets drops the snippet statements NGF would reject, so a single bad directive does not make
+// NGF reject the whole SnippetsFilter. Snippets in an unknown context or that do not parse are dropped as a
+// whole, otherwise only the offending top-level statements. Snippets without rejections are returned as is.
+func SanitizeSnippets(snippets []map[string]interface{}) ([]map[string]interface{}, []SnippetRejection) {
+	kept := make([]map[string]interface{}, 0, len(snippets))
+	var rejections []SnippetRejection
+	for _, snippet := range snippets {
+		context, _ := snippet["context"].(string)
+		value, _ := snippet["value"].(string)
+		block, ok := ngfSnippetContexts[context]
+		if !ok {
+			rejections = append(rejections, SnippetRejection{Context: context, Reason: "NGF does not accept this context"})
+			continue
+		}
+		statements, err := parseSnippetStatements(value)
+		if err != nil {
+			rejections = append(rejections, SnippetRejection{Context: context, Reason: err.Error()})
+			continue
+		}
+
+		valid := make([]string, 0, len(statements))
+		dropped := false
+		for _, statement := range statements {
+			if rejection, ok := validateSnippetStatement(statement, block); !ok {
+				rejection.Context = context
+				rejections = append(rejections, rejection)
+				dropped = true
+				continue
+			}
+			valid = append(valid, statement.text)
+		}
+		switch {
+		case !dropped:
+			kept = append(kept, snippet)
+		case len(valid) > 0:
+			kept = append(kept, map[string]interface{}{"context": context, "value": strings.Join(valid, "\n")})
+		}
+	}
+	return kept, rejections
+}
+
+// validateSnippetStatement checks a statement and the statements of the location, if and limit_except
+// blocks it opens against the block it appears in
+func validateSnippetStatement(statement snippetStatement, block string) (SnippetRejection, bool) {
+	if reason, forbidden := forbiddenSnippetDirectives[statement.name]; forbidden {
+		return SnippetRejection{Directive: statement.name, Reason: reason}, false
+	}
+	if blocks, restricted := snippetDirectiveContexts[statement.name]; restricted && !ContainsString(blocks, block) {
+		return SnippetRejection{
+			Directive: statement.name,
+			Reason:    fmt.Sprintf("only allowed in the %s block", strings.Join(blocks, " or ")),
+		}, false
+	}
+	inner := ""
+	switch statement.name {
+	case "location", "limit_except", "if":
+		inner = statement.name
+	}
+	if inner == "" || statement.body == "" {
+		return SnippetRejection{}, true
+	}
+	children, err := parseSnippetStatements(statement.body)
+	if err != nil {
+		return SnippetRejection{Directive: statement.name, Reason: err.Error()}, false
+	}
+	for _, child := range children {
+		if rejection, ok := validateSnippetStatement(child, inner); !ok {
+			rejection.Reason = fmt.Sprintf("inside %s: %s", statement.name, rejection.Reason)
+			return rejection, false
+		}
+	}
+	return SnippetRejection{}, true
+}
+
+// snippetStatement is a simple directive ending in ';' or a block directive with its body
+type snippetStatement struct {
+	name string
+	text string
+	body string
+}
+
+var errUnbalancedSnippet = errors.New("unbalanced braces or quotes")
+
+// parseSnippetStatements splits nginx configuration text into its top-level statements, honoring quotes
+// and comments
+func parseSnippetStatements(text string) ([]snippetStatement, error) {
+	statements := make([]snippetStatement, 0)
+	start, bodyStart, depth := -1, -1, 0
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		case c == '#':
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+			continue
+		case start < 0 && (c == ' ' || c == '\t' || c == '\r' || c == '\n'):
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '{':
+			if depth == 0 {
+				bodyStart = i + 1
+			}
+			depth++
+		case '}':
+			depth--
+			if depth < 0 {
+				return nil, errUnbalancedSnippet
+			}
+			if depth == 0 {
+				statements = append(statements, newSnippetStatement(text[start:i+1], text[bodyStart:i]))
+				start, bodyStart = -1, -1
+			}
+		case ';':
+			if depth == 0 {
+				statements = append(statements, newSnippetStatement(text[start:i+1], ""))
+				start = -1
+			}
+		}
+	}
+	if quote != 0 || depth != 0 {
+		return nil, errUnbalancedSnippet
+	}
+	if start >= 0 {
+		return nil, fmt.Errorf("statement %q does not end with ';'", strings.TrimSpace(text[start:]))
+	}
+	return statements, nil
+}
+
+func newSnippetStatement(text, body string) snippetStatement {
+	name := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\r' || r == '\n' || r == ';' || r == '{' || r == '('
+	})
+	statement := snippetStatement{text: text, body: body}
+	if len(name) > 0 {
+		statement.name = name[0]
+	}
+	return statement
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -34,6 +36,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
 )
 
 const (
@@ -43,11 +47,13 @@ const (
 	AuthenticationFilterKind        = "AuthenticationFilter"
 	RequestHeaderModifierFilterKind = "RequestHeaderModifierFilter"
 	RateLimitPolicyKind             = "RateLimitPolicy"
+	UpstreamSettingsPolicyKind      = "UpstreamSettingsPolicy"
 	SnippetsFilterCRDName           = "snippetsfilters.gateway.nginx.org"
 	SnippetsPolicyCRDName           = "snippetspolicies.gateway.nginx.org"
 	AuthenticationFilterCRDName     = "authenticationfilters.gateway.nginx.org"
 	RequestHeaderModifierCRDName    = "requestheadermodifierfilters.gateway.nginx.org"
 	RateLimitPolicyCRDName          = "ratelimitpolicies.gateway.nginx.org"
+	UpstreamSettingsPolicyCRDName   = "upstreamsettingspolicies.gateway.nginx.org"
 )
 
 const (
@@ -79,6 +85,7 @@ const (
 	customHTTPErrorsKey      = "custom-http-errors"
 	fromToWWWRedirectKey     = "from-to-www-redirect"
 	rewriteTargetKey         = "rewrite-target"
+	xForwardedPrefixKey      = "x-forwarded-prefix"
 	useRegexKey              = "use-regex"
 )
 
@@ -115,6 +122,15 @@ func isWhitelistedNginxIngressDirective(suffix string) bool {
 	return ok
 }
 
+func isProxyTimeoutKey(suffix string) bool {
+	for _, key := range translator.ProxyTimeoutKeys() {
+		if suffix == key {
+			return true
+		}
+	}
+	return false
+}
+
 func collectSnippetWarnings(annotations map[string]string) []string {
 	if annotations == nil {
 		return nil
@@ -451,7 +467,9 @@ type nginxIngressSnippetState struct {
 	whitelistSourceRanges []string
 	blacklistSourceRanges []string
 	customHTTPErrors      []string
+	customErrorsProxied   bool // custom-http-errors go to the default-backend, see BuildCustomErrorPageSnippets
 	rewriteTarget         string
+	xForwardedPrefix      string
 	useRegex              bool
 	warnings              []string
 }
@@ -460,6 +478,11 @@ func ingestNginxIngressAnnotations(annotations map[string]string, keys []ingress
 	state := nginxIngressSnippetState{
 		lines: make([]string, 0, len(keys)),
 	}
+	_, state.customErrorsProxied = CustomHTTPErrorBackend(annotations)
+	// Proxy timeouts become HTTPRoute rule timeouts unless Gateway API cannot express them
+	timeoutsAsSnippets := translator.ProxyTimeoutsNeedSnippet(annotations)
+	// With proxy-ssl-secret, BackendTLSPolicy owns verification; duplicate directives break nginx
+	proxySSLAsPolicy := translator.ProxySSLOwnsVerification(annotations)
 
 	for _, entry := range keys {
 		raw := annotations[entry.fullKey]
@@ -475,6 +498,13 @@ func ingestNginxIngressAnnotations(annotations map[string]string, keys []ingress
 			if !isWhitelistedNginxIngressDirective(entry.suffix) {
 				continue
 			}
+			if !timeoutsAsSnippets && isProxyTimeoutKey(entry.suffix) {
+				continue
+			}
+			if proxySSLAsPolicy && (entry.suffix == translator.NginxProxySSLVerifyKey ||
+				entry.suffix == translator.NginxProxySSLNameKey) {
+				continue
+			}
 			if !isSafeSnippetValue(&state, entry.fullKey, value) {
 				continue
 			}
@@ -557,6 +587,9 @@ func applyIngressAnnotationValue(state *nginxIngressSnippetState, suffix, value
 		}
 		return true
 	case customHTTPErrorsKey:
+		if state.customErrorsProxied {
+			return true
+		}
 		codes, warnings := parseCustomHTTPErrors(value)
 		if len(codes) > 0 {
 			state.customHTTPErrors = codes
@@ -576,6 +609,12 @@ func applyIngressAnnotationValue(state *nginxIngressSnippetState, suffix, value
 		}
 		state.rewriteTarget = value
 		return true
+	case xForwardedPrefixKey:
+		// Literal values become a RequestHeaderModifier filter, only variables need nginx
+		if strings.Contains(value, "$") && !strings.ContainsAny(value, "\"") && isSafeSnippetValue(state, suffix, value) {
+			state.xForwardedPrefix = value
+		}
+		return true
 	case useRegexKey:
 		if strings.EqualFold(value, "true") {
 			state.useRegex = true
@@ -630,6 +669,9 @@ func buildNginxDirectiveLines(state nginxIngressSnippetState) ([]string, []strin
 	if strings.TrimSpace(state.rewriteTarget) != "" && strings.Contains(state.rewriteTarget, "$") && !state.useRegex {
 		warnings = append(warnings, "rewrite-target contains capture references but use-regex is not enabled")
 	}
+	if hasCaptureReference(state.xForwardedPrefix) && !state.useRegex {
+		warnings = append(warnings, "x-forwarded-prefix contains capture references but use-regex is not enabled")
+	}
 
 	if state.clientMaxBodySize != "" {
 		lines = append(lines, fmt.Sprintf("client_max_body_size %s;", state.clientMaxBodySize))
@@ -661,6 +703,16 @@ func buildNginxDirectiveLines(state nginxIngressSnippetState) ([]string, []strin
 	return lines, warnings
 }
 
+// hasCaptureReference reports whether value refers to a regex capture such as $1.
+func hasCaptureReference(value string) bool {
+	for i := 0; i+1 < len(value); i++ {
+		if value[i] == '$' && value[i+1] >= '0' && value[i+1] <= '9' {
+			return true
+		}
+	}
+	return false
+}
+
 func buildNginxSnippetBlocks(lines []string, state nginxIngressSnippetState) []map[string]interface{} {
 	snippets := make([]map[string]interface{}, 0, 2)
 	if state.sslRedirectOff {
@@ -720,7 +772,14 @@ func buildNginxSnippetBlocks(lines []string, state nginxIngressSnippetState) []m
 	}
 
 	locationLines := make([]string, 0)
+	xForwardedPrefix := state.xForwardedPrefix
 	if strings.TrimSpace(state.rewriteTarget) != "" {
+		// The rewrite below replaces the location captures, so keep the ones x-forwarded-prefix refers to
+		if hasCaptureReference(xForwardedPrefix) {
+			locationLines = append(locationLines,
+				fmt.Sprintf("set $ingress_doperator_x_forwarded_prefix \"%s\";", xForwardedPrefix))
+			xForwardedPrefix = "$ingress_doperator_x_forwarded_prefix"
+		}
 		target := strings.TrimSpace(state.rewriteTarget)
 		pattern := "^"
 		if state.useRegex || strings.Contains(target, "$") {
@@ -728,6 +787,9 @@ func buildNginxSnippetBlocks(lines []string, state nginxIngressSnippetState) []m
 		}
 		locationLines = append(locationLines, fmt.Sprintf("rewrite %s %s break;", pattern, target))
 	}
+	if xForwardedPrefix != "" {
+		locationLines = append(locationLines, fmt.Sprintf("proxy_set_header X-Forwarded-Prefix \"%s\";", xForwardedPrefix))
+	}
 	for _, cidr := range uniqueStrings(state.blacklistSourceRanges) {
 		locationLines = append(locationLines, fmt.Sprintf("deny %s;", cidr))
 	}
@@ -747,6 +809,19 @@ func buildNginxSnippetBlocks(lines []string, state nginxIngressSnippetState) []m
 	return snippets
 }
 
+// BuildUpstreamVhostSnippet renders upstream-vhost as a location snippet for Gateways that
+// cannot rewrite the hostname through an HTTPRoute filter
+func BuildUpstreamVhostSnippet(annotations map[string]string) (map[string]interface{}, bool) {
+	value, ok := translator.GetNginxAnnotation(annotations, translator.NginxUpstreamVhostKey)
+	if !ok || value == "" || containsUnsafeSnippetChars(value) || strings.ContainsAny(value, " \t\"") {
+		return nil, false
+	}
+	return map[string]interface{}{
+		"context": "http.server.location",
+		"value":   fmt.Sprintf("proxy_set_header Host %s;", value),
+	}, true
+}
+
 func uniqueStrings(values []string) []string {
 	if len(values) == 0 {
 		return values
@@ -822,6 +897,40 @@ func NginxIngressSnippetWarningAnnotations(annotations map[string]string) []stri
 	return collectSnippetWarnings(annotations)
 }
 
+// SnippetSourceAnnotations returns the full annotation keys that contribute to the automatic SnippetsFilter.
+// A key contributes when leaving it out changes the generated snippets.
+func SnippetSourceAnnotations(annotations map[string]string) []string {
+	keys := make([]string, 0)
+	if _, ok := CustomHTTPErrorBackend(annotations); ok {
+		// The error page snippets come from custom-http-errors, default-backend only names the Service
+		for _, prefix := range []string{nginxIngressAnnotationPrefix, ingressAnnotationPrefix} {
+			if _, exists := annotations[prefix+customHTTPErrorsKey]; exists {
+				keys = append(keys, prefix+customHTTPErrorsKey)
+			}
+		}
+	}
+	snippets, _, ok := BuildNginxIngressSnippets(annotations)
+	if !ok {
+		return keys
+	}
+	for key := range annotations {
+		if !strings.HasPrefix(key, nginxIngressAnnotationPrefix) && !strings.HasPrefix(key, ingressAnnotationPrefix) {
+			continue
+		}
+		if slices.Contains(keys, key) || strings.HasSuffix(key, "/"+defaultBackendKey) {
+			continue
+		}
+		without := copyStringMap(annotations)
+		delete(without, key)
+		remaining, _, _ := BuildNginxIngressSnippets(without)
+		if !reflect.DeepEqual(snippets, remaining) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // AutomaticSnippetsFilterName returns a stable name for annotation-based SnippetsFilter resources.
 func AutomaticSnippetsFilterName(ingressName string) string {
 	base := fmt.Sprintf("automatic-%s-annotations", ingressName)
@@ -833,7 +942,8 @@ func AutomaticSnippetsFilterName(ingressName string) string {
 }
 
 // EnsureSnippetsFilterForIngress creates or updates a SnippetsFilter for the given Ingress.
-// Returns true if the resource exists and can be referenced safely.
+// Returns true if the resource exists and can be referenced safely. A filter of the same name that is not
+// managed for this Ingress is left alone and reported as a *SnippetsFilterCollisionError.
 func EnsureSnippetsFilterForIngress(
 	ctx context.Context,
 	c client.Client,
@@ -879,6 +989,7 @@ func EnsureSnippetsFilterForIngress(
 	}
 	annotations[ManagedByAnnotation] = ManagedByValue
 	annotations[SourceAnnotation] = fmt.Sprintf("%s/%s", ingressNamespace, ingressName)
+	annotations[AutomaticSnippetsFilterAnnotation] = "true"
 	desired.SetAnnotations(annotations)
 
 	desired.Object["spec"] = map[string]interface{}{
@@ -899,11 +1010,16 @@ func EnsureSnippetsFilterForIngress(
 		return false, err
 	}
 
-	if !IsManagedByUs(existing) {
-		logger.Info("SnippetsFilter exists but is not managed by us, skipping",
+	if !IsManagedByUsForIngress(existing, ingressNamespace, ingressName) {
+		collision := &SnippetsFilterCollisionError{Namespace: httpRoute.Namespace, Name: filterName}
+		if IsManagedByUs(existing) {
+			collision.Owner = existing.GetAnnotations()[SourceAnnotation]
+		}
+		logger.Info("SnippetsFilter name is taken, skipping",
 			"namespace", httpRoute.Namespace,
-			"name", filterName)
-		return false, nil
+			"name", filterName,
+			"owner", collision.Owner)
+		return false, collision
 	}
 
 	existing.SetAnnotations(desired.GetAnnotations())
@@ -916,6 +1032,30 @@ func EnsureSnippetsFilterForIngress(
 	return true, nil
 }
 
+// DeleteManagedSnippetsFilter removes a SnippetsFilter created by ingress-doperator.
+// Missing CRDs, missing filters and filters managed by someone else are left alone.
+func DeleteManagedSnippetsFilter(ctx context.Context, c client.Client, namespace, name string) error {
+	version, ok, err := getCRDVersion(ctx, c, SnippetsFilterCRDName)
+	if err != nil || !ok {
+		return err
+	}
+	filter := &unstructured.Unstructured{}
+	filter.SetGroupVersionKind(schema.GroupVersionKind{Group: NginxGatewayGroup, Version: version, Kind: SnippetsFilterKind})
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, filter); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !IsManagedByUs(filter) {
+		return nil
+	}
+	if err := c.Delete(ctx, filter); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete SnippetsFilter %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
+
 // EnsureSnippetsFilterCopyForHTTPRoute copies a SnippetsFilter from source namespace into the HTTPRoute namespace.
 // Returns true if the destination resource was created/updated and can be referenced safely.
 func EnsureSnippetsFilterCopyForHTTPRoute(
@@ -1284,6 +1424,8 @@ func extensionCRDNameForKind(kind string) (string, bool) {
 		return RequestHeaderModifierCRDName, true
 	case RateLimitPolicyKind:
 		return RateLimitPolicyCRDName, true
+	case UpstreamSettingsPolicyKind:
+		return UpstreamSettingsPolicyCRDName, true
 	default:
 		return "", false
 	}
@@ -0,0 +1,112 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+// AutomaticSnippetsFilterAnnotation marks the SnippetsFilters built from the annotations of an Ingress, so
+// they are found again after the naming scheme changed
+const AutomaticSnippetsFilterAnnotation = "ingress-doperator.fiction.si/automatic-snippets-filter"
+
+// AutomaticSnippetsFilterNameFor returns the name of the automatic SnippetsFilter of an Ingress whose other
+// generated resources are named baseName: rendered by nameTemplate or, when nil, AutomaticSnippetsFilterName
+func AutomaticSnippetsFilterNameFor(
+	nameTemplate *translator.SnippetsFilterNameTemplate,
+	ingress *networkingv1.Ingress,
+	baseName string,
+) string {
+	if name := nameTemplate.Name(ingress, baseName); name != "" {
+		return name
+	}
+	return AutomaticSnippetsFilterName(baseName)
+}
+
+// SnippetsFilterCollisionError is returned instead of overwriting a SnippetsFilter that holds the name of an
+// automatic SnippetsFilter but belongs to someone else
+type SnippetsFilterCollisionError struct {
+	Namespace string
+	Name      string
+	// Owner is the source of the filter when it is managed for another Ingress, empty when it is not managed
+	Owner string
+}
+
+func (e *SnippetsFilterCollisionError) Error() string {
+	if e.Owner == "" {
+		return fmt.Sprintf("SnippetsFilter %s/%s exists and is not managed by ingress-doperator", e.Namespace, e.Name)
+	}
+	return fmt.Sprintf("SnippetsFilter %s/%s is managed for Ingress %s", e.Namespace, e.Name, e.Owner)
+}
+
+// PruneAutomaticSnippetsFilters deletes the automatic SnippetsFilters of an Ingress in namespace other than
+// keep and returns their names. Filters created before they were marked with AutomaticSnippetsFilterAnnotation
+// are recognized by legacyName, the name AutomaticSnippetsFilterName gave them.
+func PruneAutomaticSnippetsFilters(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	ingressNamespace string,
+	ingressName string,
+	legacyName string,
+	keep string,
+) ([]string, error) {
+	version, ok, err := getCRDVersion(ctx, c, SnippetsFilterCRDName)
+	if err != nil || !ok {
+		return nil, err
+	}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   NginxGatewayGroup,
+		Version: version,
+		Kind:    SnippetsFilterKind + "List",
+	})
+	if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	var pruned []string
+	for i := range list.Items {
+		filter := &list.Items[i]
+		if filter.GetName() == keep || !IsManagedByUsForIngress(filter, ingressNamespace, ingressName) {
+			continue
+		}
+		if _, marked := filter.GetAnnotations()[AutomaticSnippetsFilterAnnotation]; !marked &&
+			filter.GetName() != legacyName {
+			continue
+		}
+		log.FromContext(ctx).Info("Deleting renamed automatic SnippetsFilter",
+			"namespace", namespace,
+			"name", filter.GetName(),
+			"replacement", keep)
+		if err := c.Delete(ctx, filter); err != nil && !apierrors.IsNotFound(err) {
+			return pruned, fmt.Errorf("failed to delete SnippetsFilter %s/%s: %w", namespace, filter.GetName(), err)
+		}
+		pruned = append(pruned, filter.GetName())
+	}
+	return pruned, nil
+}
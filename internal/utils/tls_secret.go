@@ -0,0 +1,49 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ValidateTLSSecret checks that a Secret can serve a Gateway listener for the hostnames: it is of type
+// kubernetes.io/tls, tls.crt and tls.key parse as a key pair and the certificate covers every hostname
+func ValidateTLSSecret(secret *corev1.Secret, hostnames []string) error {
+	if secret.Type != corev1.SecretTypeTLS {
+		return fmt.Errorf("secret %s/%s is of type %q, not %q",
+			secret.Namespace, secret.Name, secret.Type, corev1.SecretTypeTLS)
+	}
+	pair, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return fmt.Errorf("secret %s/%s does not hold a valid certificate and key: %w", secret.Namespace, secret.Name, err)
+	}
+	var uncovered []string
+	for _, hostname := range hostnames {
+		if err := pair.Leaf.VerifyHostname(hostname); err != nil {
+			uncovered = append(uncovered, hostname)
+		}
+	}
+	if len(uncovered) > 0 {
+		return fmt.Errorf("certificate of Secret %s/%s does not cover %s",
+			secret.Namespace, secret.Name, strings.Join(uncovered, ", "))
+	}
+	return nil
+}
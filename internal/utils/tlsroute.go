@@ -0,0 +1,180 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+// TLSRouteManager handles TLSRoute operations, the TLS passthrough counterpart of HTTPRouteManager
+type TLSRouteManager struct {
+	Client client.Client
+	// NameTemplate names the TLSRoutes of an Ingress, nil means the Ingress name
+	NameTemplate *translator.NameTemplate
+	// SourceCluster is the fan-in source cluster of the Ingresses, empty for the local cluster
+	SourceCluster string
+	// OwnedMetadataKeys are glob patterns of annotation and label keys we own even when another party set
+	// them, all other foreign keys are kept on update
+	OwnedMetadataKeys []string
+}
+
+// GetTLSRoutesForIngress returns the TLSRoutes managed by us for the Ingress. A cluster without
+// the TLSRoute CRD has none.
+func (m *TLSRouteManager) GetTLSRoutesForIngress(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+) ([]gatewayv1.TLSRoute, error) {
+	routeList := &gatewayv1.TLSRouteList{}
+	if err := m.Client.List(ctx, routeList, client.InNamespace(ingress.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	prefix := m.NameTemplate.Name(ingress)
+	var result []gatewayv1.TLSRoute
+	for _, r := range routeList.Items {
+		if strings.HasPrefix(r.Name, prefix) && IsManagedByUsForIngress(&r, ingress.Namespace, ingress.Name) &&
+			r.Annotations[translator.SourceClusterAnnotation] == m.SourceCluster {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// ResolveNamedPorts resolves named ports in a TLSRoute by looking up the actual Services
+func (m *TLSRouteManager) ResolveNamedPorts(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	tlsRoute *gatewayv1.TLSRoute,
+) {
+	ports := &HTTPRouteManager{Client: m.Client}
+	for i := range tlsRoute.Spec.Rules {
+		for j := range tlsRoute.Spec.Rules[i].BackendRefs {
+			ports.resolveBackendPort(ctx, ingress, &tlsRoute.Spec.Rules[i].BackendRefs[j])
+		}
+	}
+}
+
+// ApplyTLSRoutes creates or updates the desired TLSRoutes of the Ingress and deletes the ones
+// it no longer needs. Applying no routes removes every TLSRoute of the Ingress.
+func (m *TLSRouteManager) ApplyTLSRoutes(
+	ctx context.Context,
+	ingress *networkingv1.Ingress,
+	desiredRoutes []*gatewayv1.TLSRoute,
+	metricRecorder func(operation, namespace, name string),
+) error {
+	logger := log.FromContext(ctx)
+
+	existingRoutes, err := m.GetTLSRoutesForIngress(ctx, ingress)
+	if err != nil {
+		return fmt.Errorf("failed to get existing TLSRoutes: %w", err)
+	}
+
+	desired := make(map[string]bool, len(desiredRoutes))
+	for _, route := range desiredRoutes {
+		desired[route.Name] = true
+	}
+	for i := range existingRoutes {
+		existingRoute := &existingRoutes[i]
+		if desired[existingRoute.Name] {
+			continue
+		}
+		logger.Info("Deleting obsolete TLSRoute", "namespace", existingRoute.Namespace, "name", existingRoute.Name)
+		if err := m.Client.Delete(ctx, existingRoute); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete obsolete TLSRoute %s: %w", existingRoute.Name, err)
+		}
+		if metricRecorder != nil {
+			metricRecorder("delete", existingRoute.Namespace, existingRoute.Name)
+		}
+	}
+
+	for _, desiredRoute := range desiredRoutes {
+		if err := m.applyTLSRoute(ctx, desiredRoute, metricRecorder); err != nil {
+			return fmt.Errorf("failed to apply TLSRoute %s: %w", desiredRoute.Name, err)
+		}
+	}
+	return nil
+}
+
+// applyTLSRoute creates or updates a single TLSRoute
+func (m *TLSRouteManager) applyTLSRoute(
+	ctx context.Context,
+	tlsRoute *gatewayv1.TLSRoute,
+	metricRecorder func(operation, namespace, name string),
+) error {
+	logger := log.FromContext(ctx)
+
+	tlsRouteNN := types.NamespacedName{Namespace: tlsRoute.Namespace, Name: tlsRoute.Name}
+	existingTLSRoute := &gatewayv1.TLSRoute{}
+	canManage, err := CanUpdateResource(ctx, m.Client, existingTLSRoute, tlsRouteNN)
+	if err != nil {
+		return err
+	}
+	if !canManage {
+		logger.Info("Skipping TLSRoute synthesis - resource exists and is not managed by us",
+			"namespace", tlsRouteNN.Namespace, "name", tlsRouteNN.Name)
+		return nil
+	}
+
+	if err := m.Client.Get(ctx, tlsRouteNN, existingTLSRoute); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		logger.Info("Creating TLSRoute", "namespace", tlsRoute.Namespace, "name", tlsRoute.Name)
+		RecordManagedKeys(tlsRoute)
+		if err := m.Client.Create(ctx, tlsRoute); err != nil {
+			return fmt.Errorf("failed to create TLSRoute: %w", err)
+		}
+		if metricRecorder != nil {
+			metricRecorder("create", tlsRoute.Namespace, tlsRoute.Name)
+		}
+		return nil
+	}
+
+	metadataChanged := MergeManagedMetadata(existingTLSRoute, tlsRoute, m.OwnedMetadataKeys)
+	if equality.Semantic.DeepEqual(existingTLSRoute.Spec, tlsRoute.Spec) && !metadataChanged &&
+		!ownerReferencesChanged(existingTLSRoute, tlsRoute) {
+		return nil
+	}
+	existingTLSRoute.Spec = tlsRoute.Spec
+	if ownerReferencesChanged(existingTLSRoute, tlsRoute) {
+		existingTLSRoute.OwnerReferences = tlsRoute.OwnerReferences
+	}
+	logger.Info("Updating TLSRoute", "namespace", existingTLSRoute.Namespace, "name", existingTLSRoute.Name)
+	if err := m.Client.Update(ctx, existingTLSRoute); err != nil {
+		return fmt.Errorf("failed to update TLSRoute: %w", err)
+	}
+	if metricRecorder != nil {
+		metricRecorder("update", existingTLSRoute.Namespace, existingTLSRoute.Name)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// UpdateDiffVerbosity is the log verbosity from which updates of Gateways and routes are diffed before
+// they are applied, e.g. --zap-log-level=4
+const UpdateDiffVerbosity = 4
+
+// FieldChange is a field an update changes. Old is missing for added fields, New for removed ones.
+type FieldChange struct {
+	Path string      `json:"path"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// LogUpdateDiff logs the fields an update of obj is going to change, when logging at UpdateDiffVerbosity.
+// obj is sent as a server-side dry-run update, so defaults, admission webhooks and whatever else the API
+// server changes on write are part of the diff against the stored object. obj itself is left as is.
+func LogUpdateDiff(ctx context.Context, c client.Client, obj client.Object) {
+	logger := log.FromContext(ctx).V(UpdateDiffVerbosity)
+	if !logger.Enabled() {
+		return
+	}
+	gvk, err := c.GroupVersionKindFor(obj)
+	if err != nil {
+		return
+	}
+	logger = logger.WithValues("kind", gvk.Kind, "namespace", obj.GetNamespace(), "name", obj.GetName())
+
+	newObject, err := c.Scheme().New(gvk)
+	if err != nil {
+		return
+	}
+	current, ok := newObject.(client.Object)
+	if !ok {
+		return
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		logger.Info("Update diff unavailable, failed to read current object", "error", err.Error())
+		return
+	}
+	applied, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return
+	}
+	if err := c.Update(ctx, applied, client.DryRunAll); err != nil {
+		logger.Info("Update diff unavailable, dry-run update failed", "error", err.Error())
+		return
+	}
+
+	changes, err := DiffObjects(current, applied)
+	if err != nil {
+		logger.Info("Update diff unavailable", "error", err.Error())
+		return
+	}
+	logger.Info("Update diff", "changes", changes)
+}
+
+// DiffObjects returns the fields that differ between two objects of the same kind, sorted by path.
+// Status and the metadata the API server maintains are left out.
+func DiffObjects(before, after runtime.Object) ([]FieldChange, error) {
+	beforeFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(after)
+	if err != nil {
+		return nil, err
+	}
+	for _, fields := range []map[string]interface{}{beforeFields, afterFields} {
+		delete(fields, "status")
+		if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+			for _, key := range []string{"managedFields", "resourceVersion", "generation", "creationTimestamp"} {
+				delete(metadata, key)
+			}
+		}
+	}
+	changes := make([]FieldChange, 0)
+	diffFields("", beforeFields, afterFields, &changes)
+	return changes, nil
+}
+
+// diffFields appends the changes between before and after, found at path, to changes
+func diffFields(path string, before, after interface{}, changes *[]FieldChange) {
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	// A map that is added or removed as a whole is still reported key by key.
+	if (beforeIsMap && after == nil) || (afterIsMap && before == nil) {
+		beforeIsMap, afterIsMap = true, true
+	}
+	if beforeIsMap && afterIsMap {
+		keys := make([]string, 0, len(beforeMap)+len(afterMap))
+		for key := range beforeMap {
+			keys = append(keys, key)
+		}
+		for key := range afterMap {
+			if _, ok := beforeMap[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			diffFields(fieldPath(path, key), beforeMap[key], afterMap[key], changes)
+		}
+		return
+	}
+
+	beforeSlice, beforeIsSlice := before.([]interface{})
+	afterSlice, afterIsSlice := after.([]interface{})
+	if beforeIsSlice && afterIsSlice {
+		for i := 0; i < max(len(beforeSlice), len(afterSlice)); i++ {
+			var beforeItem, afterItem interface{}
+			if i < len(beforeSlice) {
+				beforeItem = beforeSlice[i]
+			}
+			if i < len(afterSlice) {
+				afterItem = afterSlice[i]
+			}
+			diffFields(fmt.Sprintf("%s[%d]", path, i), beforeItem, afterItem, changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*changes = append(*changes, FieldChange{Path: path, Old: before, New: after})
+	}
+}
+
+// fieldPath appends key to path, quoting keys such as annotation names that contain dots or slashes
+func fieldPath(path, key string) string {
+	if strings.ContainsAny(key, "./") {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
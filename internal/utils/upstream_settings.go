@@ -0,0 +1,195 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+// AutomaticUpstreamSettingsPolicyName returns a stable name for annotation-based UpstreamSettingsPolicy resources.
+func AutomaticUpstreamSettingsPolicyName(ingressName string) string {
+	base := fmt.Sprintf("automatic-%s-upstream", ingressName)
+	if len(base) <= maxK8sNameLength {
+		return base
+	}
+	trimmed := base[:maxK8sNameLength]
+	return strings.TrimRight(trimmed, "-")
+}
+
+// IngressBackendServiceNames returns the sorted, unique Service names referenced by an Ingress
+func IngressBackendServiceNames(ingress *networkingv1.Ingress) []string {
+	seen := make(map[string]struct{})
+	add := func(backend *networkingv1.IngressBackend) {
+		if backend == nil || backend.Service == nil || backend.Service.Name == "" {
+			return
+		}
+		seen[backend.Service.Name] = struct{}{}
+	}
+
+	add(ingress.Spec.DefaultBackend)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			add(&rule.HTTP.Paths[i].Backend)
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EnsureUpstreamSettingsPolicyForIngress creates or updates an NGF UpstreamSettingsPolicy that
+// sets the load balancing method for every backend Service of the Ingress.
+// Returns false without error when the UpstreamSettingsPolicy CRD is not installed.
+func EnsureUpstreamSettingsPolicyForIngress(
+	ctx context.Context,
+	c client.Client,
+	scheme *runtime.Scheme,
+	owner client.Object,
+	ingress *networkingv1.Ingress,
+	policyName string,
+	loadBalancing translator.UpstreamLoadBalancing,
+) (bool, error) {
+	logger := log.FromContext(ctx)
+	services := IngressBackendServiceNames(ingress)
+	if len(services) == 0 || loadBalancing.Method == "" {
+		return false, nil
+	}
+	crdName, ok := extensionCRDNameForKind(UpstreamSettingsPolicyKind)
+	if !ok {
+		return false, nil
+	}
+	version, ok, err := getCRDVersion(ctx, c, crdName)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	gvk := schema.GroupVersionKind{Group: NginxGatewayGroup, Version: version, Kind: UpstreamSettingsPolicyKind}
+	desired := &unstructured.Unstructured{}
+	desired.SetGroupVersionKind(gvk)
+	desired.SetName(policyName)
+	desired.SetNamespace(ingress.Namespace)
+
+	if scheme != nil && owner != nil {
+		if err := controllerutil.SetControllerReference(owner, desired, scheme); err != nil {
+			return false, err
+		}
+	}
+
+	desired.SetAnnotations(map[string]string{
+		ManagedByAnnotation: ManagedByValue,
+		SourceAnnotation:    fmt.Sprintf("%s/%s", ingress.Namespace, ingress.Name),
+	})
+
+	targetRefs := make([]interface{}, 0, len(services))
+	for _, service := range services {
+		targetRefs = append(targetRefs, map[string]interface{}{
+			"group": "",
+			"kind":  "Service",
+			"name":  service,
+		})
+	}
+	spec := map[string]interface{}{
+		"targetRefs":          targetRefs,
+		"loadBalancingMethod": loadBalancing.Method,
+	}
+	if loadBalancing.HashKey != "" {
+		spec["hashMethodKey"] = loadBalancing.HashKey
+	}
+	desired.Object["spec"] = spec
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(gvk)
+	err = c.Get(ctx, types.NamespacedName{Namespace: ingress.Namespace, Name: policyName}, existing)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Creating UpstreamSettingsPolicy", "namespace", ingress.Namespace, "name", policyName)
+			if err := c.Create(ctx, desired); err != nil {
+				return false, fmt.Errorf("failed to create UpstreamSettingsPolicy %s/%s: %w",
+					ingress.Namespace, policyName, err)
+			}
+			return true, nil
+		}
+		return false, err
+	}
+
+	if !IsManagedByUs(existing) {
+		logger.Info("UpstreamSettingsPolicy exists but is not managed by us, skipping",
+			"namespace", ingress.Namespace,
+			"name", policyName)
+		return false, nil
+	}
+
+	existing.SetAnnotations(desired.GetAnnotations())
+	existing.SetOwnerReferences(desired.GetOwnerReferences())
+	existing.Object["spec"] = desired.Object["spec"]
+	logger.Info("Updating UpstreamSettingsPolicy", "namespace", ingress.Namespace, "name", policyName)
+	if err := c.Update(ctx, existing); err != nil {
+		return false, fmt.Errorf("failed to update UpstreamSettingsPolicy %s/%s: %w", ingress.Namespace, policyName, err)
+	}
+	return true, nil
+}
+
+// DeleteUpstreamSettingsPolicyForIngress removes a previously generated UpstreamSettingsPolicy
+// once the Ingress no longer carries upstream-hash-by or load-balance
+func DeleteUpstreamSettingsPolicyForIngress(
+	ctx context.Context,
+	c client.Client,
+	namespace string,
+	policyName string,
+) error {
+	version, ok, err := getCRDVersion(ctx, c, UpstreamSettingsPolicyCRDName)
+	if err != nil || !ok {
+		return err
+	}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   NginxGatewayGroup,
+		Version: version,
+		Kind:    UpstreamSettingsPolicyKind,
+	})
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: policyName}, existing); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !IsManagedByUs(existing) {
+		return nil
+	}
+	log.FromContext(ctx).Info("Deleting UpstreamSettingsPolicy", "namespace", namespace, "name", policyName)
+	return client.IgnoreNotFound(c.Delete(ctx, existing))
+}
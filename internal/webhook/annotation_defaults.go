@@ -0,0 +1,153 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+	"github.com/fiksn/ingress-doperator/internal/translator"
+)
+
+const (
+	PausedAnnotation         = "ingress-doperator.fiction.si/paused"
+	operatorAnnotationPrefix = "ingress-doperator.fiction.si/"
+)
+
+// booleanAnnotations are the operator annotations compared against "true"
+var booleanAnnotations = []string{
+	IgnoreAnnotation,
+	IgnoreIngressAnnotation,
+	AllowIngressAnnotation,
+	PausedAnnotation,
+	translator.PairedHTTPListenerAnnotation,
+}
+
+//nolint:lll
+// +kubebuilder:webhook:path=/mutate-v1-ingress-defaults,mutating=true,failurePolicy=ignore,groups="networking.k8s.io",resources=ingresses,verbs=create,versions=v1,name=mingressdefaults.fiction.si,admissionReviewVersions=v1,sideEffects=None
+
+// AnnotationNormalizer defaults and normalizes the ingress-doperator annotations of new Ingresses so the
+// operator only ever sees canonical values: surrounding whitespace is trimmed, booleans become "true" or
+// "false" and the target Gateway is filled in
+type AnnotationNormalizer struct {
+	decoder admission.Decoder
+	// GatewayName is the shared Gateway of Ingresses without class, empty leaves the target Gateway unset
+	GatewayName       string
+	IngressClassEmpty string
+}
+
+// Handle mutates the annotations of a new Ingress
+func (n *AnnotationNormalizer) Handle(ctx context.Context, req admission.Request) admission.Response {
+	logger := log.FromContext(ctx)
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	ingress := &networkingv1.Ingress{}
+	if err := n.decoder.Decode(req, ingress); err != nil {
+		logger.Error(err, "failed to decode ingress")
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	changed, warnings := n.normalize(ingress)
+	if len(changed) == 0 {
+		return admission.Allowed("").WithWarnings(warnings...)
+	}
+	for _, key := range changed {
+		metrics.AnnotationsNormalizedTotal.WithLabelValues(key).Inc()
+	}
+	logger.Info("Normalized Ingress annotations",
+		"namespace", req.Namespace,
+		"name", ingress.Name,
+		"annotations", strings.Join(changed, ","))
+
+	marshaledIngress, err := json.Marshal(ingress)
+	if err != nil {
+		logger.Error(err, "failed to marshal normalized ingress")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaledIngress).WithWarnings(warnings...)
+}
+
+// normalize rewrites the operator annotations of ingress in place and returns the keys it changed and
+// warnings about values it could not make sense of
+func (n *AnnotationNormalizer) normalize(ingress *networkingv1.Ingress) ([]string, []string) {
+	var changed, warnings []string
+	for key, value := range ingress.Annotations {
+		if !strings.HasPrefix(key, operatorAnnotationPrefix) {
+			continue
+		}
+		normalized := strings.TrimSpace(value)
+		for _, boolean := range booleanAnnotations {
+			if key != boolean {
+				continue
+			}
+			if parsed, ok := parseBooleanAnnotation(normalized); ok {
+				normalized = fmt.Sprintf("%t", parsed)
+			} else {
+				warnings = append(warnings, fmt.Sprintf("annotation %s=%q is not a boolean, "+
+					"ingress-doperator treats it as false", key, value))
+			}
+		}
+		if normalized != value {
+			ingress.Annotations[key] = normalized
+			changed = append(changed, key)
+		}
+	}
+
+	if n.GatewayName != "" && ingress.Annotations[translator.TargetGatewayAnnotation] == "" {
+		gatewayName := translator.SharedGatewayName(n.GatewayName, ingressClassName(ingress, n.IngressClassEmpty))
+		if group, ok, _ := translator.ALBGroupName(ingress.Annotations); ok {
+			gatewayName = translator.ALBGroupGatewayName(gatewayName, group)
+		}
+		if ingress.Annotations == nil {
+			ingress.Annotations = make(map[string]string)
+		}
+		ingress.Annotations[translator.TargetGatewayAnnotation] = gatewayName
+		changed = append(changed, translator.TargetGatewayAnnotation)
+	}
+	sort.Strings(changed)
+	sort.Strings(warnings)
+	return changed, warnings
+}
+
+// parseBooleanAnnotation accepts the spellings people use for booleans in YAML and elsewhere
+func parseBooleanAnnotation(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "true", "t", "1", "yes", "y", "on":
+		return true, true
+	case "false", "f", "0", "no", "n", "off":
+		return false, true
+	}
+	return false, false
+}
+
+// InjectDecoder injects the decoder
+func (n *AnnotationNormalizer) InjectDecoder(d *admission.Decoder) error {
+	n.decoder = *d
+	return nil
+}
@@ -39,6 +39,7 @@ import (
 
 const (
 	IgnoreIngressAnnotation = "ingress-doperator.fiction.si/ignore-ingress"
+	IgnoreAnnotation        = "ingress-doperator.fiction.si/ignore"
 	AllowIngressAnnotation  = "ingress-doperator.fiction.si/allow-ingress"
 	WebhookAnnotation       = "ingress-doperator.fiction.si/webhook"
 )
@@ -77,7 +78,8 @@ func (m *IngressMutator) Handle(ctx context.Context, req admission.Request) admi
 	}
 
 	// Check if this Ingress should be ignored (skip all processing)
-	if ingress.Annotations != nil && ingress.Annotations[IgnoreIngressAnnotation] == fmt.Sprintf("%t", true) {
+	if ingress.Annotations[IgnoreIngressAnnotation] == fmt.Sprintf("%t", true) ||
+		ingress.Annotations[IgnoreAnnotation] == fmt.Sprintf("%t", true) {
 		logger.Info("Ingress has ignore annotation, skipping mutation")
 		return admission.Allowed("ignored")
 	}
@@ -513,6 +515,13 @@ func (m *IngressMutator) applyIngressAnnotationSnippetsFilter(
 	if !ok {
 		return
 	}
+	snippets, rejections := utils.SanitizeSnippets(snippets)
+	for _, rejection := range rejections {
+		warnings = append(warnings, "dropped from the automatic SnippetsFilter: "+rejection.String())
+	}
+	if len(snippets) == 0 {
+		return
+	}
 	for _, warning := range warnings {
 		logger.Info("nginx ingress annotation warning",
 			"warning", warning,
@@ -550,6 +559,9 @@ func (m *IngressMutator) finalizeIngressAnnotationSnippetsFilter(ctx context.Con
 	if !ok {
 		return
 	}
+	if snippets, _ = utils.SanitizeSnippets(snippets); len(snippets) == 0 {
+		return
+	}
 	owner := &gatewayv1.HTTPRoute{}
 	if err := m.Client.Get(ctx, client.ObjectKey{Namespace: ingress.Namespace, Name: ingress.Name}, owner); err != nil {
 		return
@@ -574,6 +586,12 @@ func (m *IngressMutator) finalizeIngressAnnotationSnippetsFilter(ctx context.Con
 
 // getIngressClass returns the ingress class from spec.ingressClassName or the legacy annotation
 func (m *IngressMutator) getIngressClass(ingress *networkingv1.Ingress) string {
+	return ingressClassName(ingress, m.IngressClassEmpty)
+}
+
+// ingressClassName returns the ingress class from spec.ingressClassName or the legacy annotation,
+// falling back to empty
+func ingressClassName(ingress *networkingv1.Ingress, empty string) string {
 	// First check spec.ingressClassName
 	if ingress.Spec.IngressClassName != nil && *ingress.Spec.IngressClassName != "" {
 		return *ingress.Spec.IngressClassName
@@ -586,10 +604,7 @@ func (m *IngressMutator) getIngressClass(ingress *networkingv1.Ingress) string {
 		}
 	}
 
-	if m.IngressClassEmpty != "" {
-		return m.IngressClassEmpty
-	}
-	return ""
+	return empty
 }
 
 // matchesIngressClassFilter checks if the Ingress class matches any configured filter pattern
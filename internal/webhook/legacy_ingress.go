@@ -0,0 +1,141 @@
+/*
+Copyright Gregor Pogacnik 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/fiksn/ingress-doperator/internal/metrics"
+)
+
+// Annotations the operator leaves on the Ingresses it migrated
+const (
+	DisabledAnnotation                 = "ingress-doperator.fiction.si/disabled"
+	RemovedAnnotation                  = "ingress-doperator.fiction.si/removed"
+	OriginalIngressClassAnnotation     = "ingress-doperator.fiction.si/original-ingress-class"
+	OriginalIngressClassNameAnnotation = "ingress-doperator.fiction.si/original-ingress-classname"
+)
+
+//nolint:lll
+// +kubebuilder:webhook:path=/validate-v1-ingress-legacy,mutating=false,failurePolicy=ignore,groups="networking.k8s.io",resources=ingresses,verbs=create,versions=v1,name=vlegacyingress.fiction.si,admissionReviewVersions=v1,sideEffects=None
+
+// LegacyIngressBlocker denies new Ingresses of a class in namespaces where the operator already migrated every
+// Ingress of that class, so the legacy surface stops growing once a namespace moved to Gateway API
+type LegacyIngressBlocker struct {
+	Client                    client.Reader
+	decoder                   admission.Decoder
+	IngressClassFilters       []string
+	IngressClassIgnoreFilters []string
+	IngressClassEmpty         string
+}
+
+// Handle validates the creation of an Ingress
+func (b *LegacyIngressBlocker) Handle(ctx context.Context, req admission.Request) admission.Response {
+	logger := log.FromContext(ctx)
+	if req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	ingress := &networkingv1.Ingress{}
+	if err := b.decoder.Decode(req, ingress); err != nil {
+		logger.Error(err, "failed to decode ingress")
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if ingress.Namespace == "" {
+		ingress.Namespace = req.Namespace
+	}
+	if ingress.Annotations[IgnoreIngressAnnotation] == fmt.Sprintf("%t", true) ||
+		ingress.Annotations[IgnoreAnnotation] == fmt.Sprintf("%t", true) ||
+		ingress.Annotations[AllowIngressAnnotation] == fmt.Sprintf("%t", true) {
+		return admission.Allowed("opted out")
+	}
+	class := ingressClassName(ingress, b.IngressClassEmpty)
+	if matchIngressClassPatterns(b.IngressClassIgnoreFilters, class, "ingress class ignore filter") ||
+		!matchIngressClassPatterns(b.IngressClassFilters, class, "ingress class filter") {
+		return admission.Allowed("ingress class not migrated")
+	}
+
+	migrated, err := b.namespaceMigrated(ctx, ingress.Namespace, class)
+	if err != nil {
+		logger.Error(err, "failed to check whether the namespace is migrated", "namespace", ingress.Namespace)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if !migrated {
+		return admission.Allowed("")
+	}
+	logger.Info("Denying new Ingress in a migrated namespace",
+		"namespace", ingress.Namespace,
+		"name", ingress.Name,
+		"ingressClass", class)
+	metrics.LegacyIngressesDeniedTotal.WithLabelValues(ingress.Namespace, class).Inc()
+	return admission.Denied(fmt.Sprintf("Ingresses of class %q in namespace %s were migrated to Gateway API, "+
+		"create an HTTPRoute instead (or annotate the Ingress with '%s=true')", class, ingress.Namespace,
+		AllowIngressAnnotation))
+}
+
+// namespaceMigrated reports whether the operator migrated Ingresses of class in namespace and none of that
+// class still serves traffic
+func (b *LegacyIngressBlocker) namespaceMigrated(ctx context.Context, namespace, class string) (bool, error) {
+	list := &networkingv1.IngressList{}
+	if err := b.Client.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return false, fmt.Errorf("failed to list Ingresses: %w", err)
+	}
+	migrated := false
+	for i := range list.Items {
+		existing := &list.Items[i]
+		if existing.Annotations[DisabledAnnotation] != "" || existing.Annotations[RemovedAnnotation] == "true" {
+			if originalIngressClass(existing, b.IngressClassEmpty) == class {
+				migrated = true
+			}
+			continue
+		}
+		if !existing.DeletionTimestamp.IsZero() ||
+			existing.Annotations[IgnoreIngressAnnotation] == fmt.Sprintf("%t", true) ||
+			existing.Annotations[IgnoreAnnotation] == fmt.Sprintf("%t", true) {
+			continue
+		}
+		if ingressClassName(existing, b.IngressClassEmpty) == class {
+			return false, nil
+		}
+	}
+	return migrated, nil
+}
+
+// originalIngressClass returns the class an Ingress had before the operator disabled it
+func originalIngressClass(ingress *networkingv1.Ingress, empty string) string {
+	if class := ingress.Annotations[OriginalIngressClassNameAnnotation]; class != "" {
+		return class
+	}
+	if class := ingress.Annotations[OriginalIngressClassAnnotation]; class != "" {
+		return class
+	}
+	return ingressClassName(ingress, empty)
+}
+
+// InjectDecoder injects the decoder
+func (b *LegacyIngressBlocker) InjectDecoder(d *admission.Decoder) error {
+	b.decoder = *d
+	return nil
+}